@@ -228,7 +228,7 @@ else
 fi
 psql_exec "GRANT ALL PRIVILEGES ON DATABASE ucp TO ucp;" || true
 
-# Helper: create the resources table and grant permissions in a given database
+# Helper: create the resources and queue_messages tables and grant permissions in a given database
 # Usage: init_db_tables <db_name> <db_user>
 init_db_tables() {
   local db="$1"
@@ -245,7 +245,21 @@ CREATE TABLE IF NOT EXISTS resources (
   resource_data jsonb NOT NULL
 );
 CREATE INDEX IF NOT EXISTS idx_resource_query ON resources (resource_type, root_scope);
+CREATE TABLE IF NOT EXISTS queue_messages (
+  id TEXT PRIMARY KEY NOT NULL,
+  queue_name TEXT NOT NULL,
+  dequeue_count INTEGER NOT NULL,
+  enqueue_at timestamp(6) with time zone NOT NULL,
+  expire_at timestamp(6) with time zone NOT NULL,
+  next_visible_at timestamp(6) with time zone NOT NULL,
+  content_type TEXT NOT NULL,
+  data bytea NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_queue_messages_visible ON queue_messages (queue_name, next_visible_at);
+ALTER TABLE queue_messages ADD COLUMN IF NOT EXISTS dead_letter_at timestamp(6) with time zone;
+ALTER TABLE queue_messages ADD COLUMN IF NOT EXISTS last_error TEXT;
 GRANT ALL PRIVILEGES ON TABLE resources TO ${db_user};
+GRANT ALL PRIVILEGES ON TABLE queue_messages TO ${db_user};
 GRANT ALL PRIVILEGES ON ALL SEQUENCES IN SCHEMA public TO ${db_user};
 "
 
@@ -76,15 +76,18 @@ var rootCmd = &cobra.Command{
 			services = append(services, &traceservice.Service{Options: &options.Config.TracerProvider})
 		}
 
-		builders, err := builders(options)
+		config, err := controllerconfig.New(options)
 		if err != nil {
 			return err
 		}
 
+		builders := builders(config)
+
 		services = append(
 			services,
 			server.NewAPIService(options, builders),
 			server.NewAsyncWorker(options, builders),
+			server.NewRecipeDriftService(options, config),
 		)
 
 		host := &hosting.Host{
@@ -107,12 +110,7 @@ func Execute() {
 	cobra.CheckErr(rootCmd.ExecuteContext(context.Background()))
 }
 
-func builders(options hostoptions.HostOptions) ([]builder.Builder, error) {
-	config, err := controllerconfig.New(options)
-	if err != nil {
-		return nil, err
-	}
-
+func builders(config *controllerconfig.RecipeControllerConfig) []builder.Builder {
 	return []builder.Builder{
 		corerp_setup.SetupNamespace(config).GenerateBuilder(),
 		// Eventually there will be only a single namespace Radius.Core for core resources.
@@ -124,5 +122,5 @@ func builders(options hostoptions.HostOptions) ([]builder.Builder, error) {
 		msgrp_setup.SetupNamespace(config).GenerateBuilder(),
 		dsrp_setup.SetupNamespace(config).GenerateBuilder(),
 		// Add resource provider builders...
-	}, nil
+	}
 }
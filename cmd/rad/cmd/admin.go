@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	RootCmd.AddCommand(adminCmd)
+	adminCmd.AddCommand(adminOperationsCmd)
+	adminCmd.AddCommand(adminKeysCmd)
+}
+
+func NewAdminCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "admin",
+		Short: "Manage the Radius control plane",
+		Long:  `Manage the Radius control plane. These commands are intended for operators of a Radius installation.`,
+	}
+}
+
+func NewAdminOperationsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "operations",
+		Short: "Manage dead-lettered async operations",
+		Long: `Manage the async operations moved to the dead-letter queue after they exceeded the maximum retry count.
+
+Dead-lettered operations can be listed, inspected, replayed or purged. The queue is selected with --queue:
+radius (default) for Applications.* and Radius.* resources, dynamic-rp for user-defined resource types,
+ucp for UCP resources and controller for the Radius controller.`,
+	}
+}
+
+func NewAdminKeysCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "keys",
+		Short: "Manage the keys used to encrypt sensitive fields",
+		Long:  `Manage the keys used to encrypt the sensitive fields of resources, which are stored in the radius-encryption-key Secret.`,
+	}
+}
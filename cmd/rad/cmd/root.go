@@ -31,9 +31,16 @@ import (
 	"github.com/radius-project/radius/pkg/cli/azure"
 	"github.com/radius-project/radius/pkg/cli/bicep"
 	"github.com/radius-project/radius/pkg/cli/clierrors"
+	admin_keys_rotate "github.com/radius-project/radius/pkg/cli/cmd/admin/keys/rotate"
+	admin_operations_list "github.com/radius-project/radius/pkg/cli/cmd/admin/operations/list"
+	admin_operations_purge "github.com/radius-project/radius/pkg/cli/cmd/admin/operations/purge"
+	admin_operations_replay "github.com/radius-project/radius/pkg/cli/cmd/admin/operations/replay"
+	admin_operations_show "github.com/radius-project/radius/pkg/cli/cmd/admin/operations/show"
 	app_delete "github.com/radius-project/radius/pkg/cli/cmd/app/delete"
 	app_delete_preview "github.com/radius-project/radius/pkg/cli/cmd/app/delete/preview"
 	app_graph "github.com/radius-project/radius/pkg/cli/cmd/app/graph"
+	app_graph_diff "github.com/radius-project/radius/pkg/cli/cmd/app/graph/diff"
+	app_graph_drift "github.com/radius-project/radius/pkg/cli/cmd/app/graph/drift"
 	app_graph_preview "github.com/radius-project/radius/pkg/cli/cmd/app/graph/preview"
 	app_list "github.com/radius-project/radius/pkg/cli/cmd/app/list"
 	app_list_preview "github.com/radius-project/radius/pkg/cli/cmd/app/list/preview"
@@ -62,6 +69,7 @@ import (
 	group "github.com/radius-project/radius/pkg/cli/cmd/group"
 	"github.com/radius-project/radius/pkg/cli/cmd/install"
 	install_kubernetes "github.com/radius-project/radius/pkg/cli/cmd/install/kubernetes"
+	migrate_database "github.com/radius-project/radius/pkg/cli/cmd/migrate/database"
 	"github.com/radius-project/radius/pkg/cli/cmd/radinit"
 	radinit_preview "github.com/radius-project/radius/pkg/cli/cmd/radinit/preview"
 	recipe_list "github.com/radius-project/radius/pkg/cli/cmd/recipe/list"
@@ -71,9 +79,12 @@ import (
 	recipe_pack_delete "github.com/radius-project/radius/pkg/cli/cmd/recipepack/delete"
 	recipe_pack_list "github.com/radius-project/radius/pkg/cli/cmd/recipepack/list"
 	recipe_pack_show "github.com/radius-project/radius/pkg/cli/cmd/recipepack/show"
+	resource_cancel "github.com/radius-project/radius/pkg/cli/cmd/resource/cancel"
 	resource_create "github.com/radius-project/radius/pkg/cli/cmd/resource/create"
 	resource_delete "github.com/radius-project/radius/pkg/cli/cmd/resource/delete"
+	resource_history "github.com/radius-project/radius/pkg/cli/cmd/resource/history"
 	resource_list "github.com/radius-project/radius/pkg/cli/cmd/resource/list"
+	resource_plan "github.com/radius-project/radius/pkg/cli/cmd/resource/plan"
 	resource_show "github.com/radius-project/radius/pkg/cli/cmd/resource/show"
 	resourceprovider_create "github.com/radius-project/radius/pkg/cli/cmd/resourceprovider/create"
 	resourceprovider_delete "github.com/radius-project/radius/pkg/cli/cmd/resourceprovider/delete"
@@ -88,6 +99,7 @@ import (
 	"github.com/radius-project/radius/pkg/cli/cmd/run"
 	cmd_shutdown "github.com/radius-project/radius/pkg/cli/cmd/shutdown"
 	cmd_startup "github.com/radius-project/radius/pkg/cli/cmd/startup"
+	state_list "github.com/radius-project/radius/pkg/cli/cmd/state/list"
 	"github.com/radius-project/radius/pkg/cli/cmd/uninstall"
 	uninstall_kubernetes "github.com/radius-project/radius/pkg/cli/cmd/uninstall/kubernetes"
 	"github.com/radius-project/radius/pkg/cli/cmd/upgrade"
@@ -145,6 +157,11 @@ var resourceProviderCmd = NewResourceProviderCommand()
 var resourceTypeCmd = NewResourceTypeCommand()
 var recipeCmd = NewRecipeCommand()
 var recipePackCmd = NewRecipePackCommand()
+var stateCmd = NewStateCommand()
+var adminCmd = NewAdminCommand()
+var adminOperationsCmd = NewAdminOperationsCommand()
+var adminKeysCmd = NewAdminKeysCommand()
+var migrateCmd = NewMigrateCommand()
 var envCmd = NewEnvironmentCommand()
 var workspaceCmd = NewWorkspaceCommand()
 
@@ -310,6 +327,15 @@ func initSubCommands() {
 	resourceDeleteCmd, _ := resource_delete.NewCommand(framework)
 	resourceCmd.AddCommand(resourceDeleteCmd)
 
+	resourceCancelCmd, _ := resource_cancel.NewCommand(framework)
+	resourceCmd.AddCommand(resourceCancelCmd)
+
+	resourceHistoryCmd, _ := resource_history.NewCommand(framework)
+	resourceCmd.AddCommand(resourceHistoryCmd)
+
+	resourcePlanCmd, _ := resource_plan.NewCommand(framework)
+	resourceCmd.AddCommand(resourcePlanCmd)
+
 	resourceProviderShowCmd, _ := resourceprovider_show.NewCommand(framework)
 	resourceProviderCmd.AddCommand(resourceProviderShowCmd)
 
@@ -372,6 +398,27 @@ func initSubCommands() {
 	shutdownCmd, _ := cmd_shutdown.NewCommand(framework)
 	RootCmd.AddCommand(shutdownCmd)
 
+	stateListCmd, _ := state_list.NewCommand(framework)
+	stateCmd.AddCommand(stateListCmd)
+
+	adminOperationsListCmd, _ := admin_operations_list.NewCommand(framework)
+	adminOperationsCmd.AddCommand(adminOperationsListCmd)
+
+	adminOperationsShowCmd, _ := admin_operations_show.NewCommand(framework)
+	adminOperationsCmd.AddCommand(adminOperationsShowCmd)
+
+	adminOperationsReplayCmd, _ := admin_operations_replay.NewCommand(framework)
+	adminOperationsCmd.AddCommand(adminOperationsReplayCmd)
+
+	adminOperationsPurgeCmd, _ := admin_operations_purge.NewCommand(framework)
+	adminOperationsCmd.AddCommand(adminOperationsPurgeCmd)
+
+	adminKeysRotateCmd, _ := admin_keys_rotate.NewCommand(framework)
+	adminKeysCmd.AddCommand(adminKeysRotateCmd)
+
+	migrateDatabaseCmd, _ := migrate_database.NewCommand(framework)
+	migrateCmd.AddCommand(migrateDatabaseCmd)
+
 	legacyEnvCreateCmd, _ := env_create.NewCommand(framework)
 	previewCreateCmd, _ := env_create_preview.NewCommand(framework)
 	wirePreviewSubcommandPreviewBase(previewCreateCmd, legacyEnvCreateCmd.RunE, "Use the Radius.Core preview implementation for environment create", "recipe-packs")
@@ -437,6 +484,10 @@ func initSubCommands() {
 	appGraphCmd, _ := app_graph.NewCommand(framework)
 	previewAppGraphCmd, _ := app_graph_preview.NewCommand(framework)
 	wirePreviewSubcommand(appGraphCmd, previewAppGraphCmd)
+	appGraphDiffCmd, _ := app_graph_diff.NewCommand(framework)
+	appGraphCmd.AddCommand(appGraphDiffCmd)
+	appGraphDriftCmd, _ := app_graph_drift.NewCommand(framework)
+	appGraphCmd.AddCommand(appGraphDriftCmd)
 	applicationCmd.AddCommand(appGraphCmd)
 
 	envSwitchCmd, _ := env_switch.NewCommand(framework)
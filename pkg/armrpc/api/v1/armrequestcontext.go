@@ -38,10 +38,22 @@ const (
 	APIVersionParameterName = "api-version"
 
 	// SkipTokenParameterName is the query string parameter for the skip token which is used for pagination purposes.
+	// The OData form "$skipToken" is also accepted.
 	SkipTokenParameterName = "skipToken"
 
 	// TopParameterName is an optional query parameter that defines the number of records requested by the client.
+	// The OData form "$top" is also accepted.
 	TopParameterName = "top"
+
+	// FilterParameterName is an optional query parameter that filters the resources returned by a list operation.
+	FilterParameterName = "$filter"
+
+	// OrderByParameterName is an optional query parameter that orders the resources returned by a list operation.
+	OrderByParameterName = "$orderby"
+
+	// WatchParameterName is an optional query parameter that asks a list operation to stream the changes to
+	// the listed resources instead of returning them.
+	WatchParameterName = "watch"
 )
 
 // The constants below define the default, max, and min values for the number of records to be returned by the server.
@@ -170,6 +182,10 @@ type ARMRequestContext struct {
 	SkipToken string
 	// Top is the maximum number of records to be returned by the server. The validation will be handled downstream.
 	Top int
+	// Filter is the OData filter expression of a list operation.
+	Filter string
+	// OrderBy is the OData order expression of a list operation.
+	OrderBy string
 
 	// HTTPMethod represents the original method.
 	HTTPMethod string
@@ -216,7 +232,7 @@ func FromARMRequest(r *http.Request, pathBase, location string) (*ARMRequestCont
 		}
 	}
 
-	queryItemCount, err := getQueryItemCount(r.URL.Query().Get(TopParameterName))
+	queryItemCount, err := getQueryItemCount(queryParameter(r.URL.Query(), TopParameterName))
 	if err != nil {
 		log.V(ucplog.LevelDebug).Info(fmt.Sprintf("Error parsing top query parameter: %v", r.URL.Query()))
 		return nil, err
@@ -246,8 +262,10 @@ func FromARMRequest(r *http.Request, pathBase, location string) (*ARMRequestCont
 		IfMatch:     r.Header.Get(IfMatch),
 		IfNoneMatch: r.Header.Get(IfNoneMatch),
 
-		SkipToken: r.URL.Query().Get(SkipTokenParameterName),
+		SkipToken: queryParameter(r.URL.Query(), SkipTokenParameterName),
 		Top:       queryItemCount,
+		Filter:    r.URL.Query().Get(FilterParameterName),
+		OrderBy:   r.URL.Query().Get(OrderByParameterName),
 
 		HTTPMethod:  r.Method,
 		OriginalURL: *r.URL,
@@ -271,6 +289,32 @@ func (rc ARMRequestContext) SystemData() *SystemData {
 	return systemDataProp
 }
 
+// Caller returns the identity of the client that made the request. It prefers the identity of the last
+// modifier in the system metadata, then the principal name, object ID and application ID of the client,
+// and falls back to the user agent when the request is not authenticated.
+func (rc ARMRequestContext) Caller() string {
+	if lastModifiedBy := rc.SystemData().LastModifiedBy; lastModifiedBy != "" {
+		return lastModifiedBy
+	}
+
+	for _, caller := range []string{rc.ClientPrincipalName, rc.ClientObjectID, rc.ClientApplicationID} {
+		if caller != "" {
+			return caller
+		}
+	}
+
+	return rc.UserAgent
+}
+
+// queryParameter returns the value of the query parameter name, or of its OData form prefixed by "$".
+func queryParameter(query url.Values, name string) string {
+	if query.Has(name) {
+		return query.Get(name)
+	}
+
+	return query.Get("$" + name)
+}
+
 // getQueryItemCount function returns the number of records requested.
 // The default value is defined above.
 // If there is a top query parameter, we use that instead of the default one.
@@ -176,6 +176,20 @@ func TestSystemData(t *testing.T) {
 	require.Equal(t, "User", sysData.LastModifiedByType)
 }
 
+func TestCaller(t *testing.T) {
+	req, err := getTestHTTPRequest(t, "./testdata/armrpcheaders.json")
+	require.NoError(t, err)
+	serviceCtx, err := FromARMRequest(req, "", LocationGlobal)
+	require.NoError(t, err)
+	require.Equal(t, "fake@hotmail.com", serviceCtx.Caller())
+
+	unauthenticated := ARMRequestContext{UserAgent: "rad/0.1"}
+	require.Equal(t, "rad/0.1", unauthenticated.Caller())
+
+	unauthenticated.ClientObjectID = "00000000-0000-0000-0000-000000000001"
+	require.Equal(t, "00000000-0000-0000-0000-000000000001", unauthenticated.Caller())
+}
+
 func TestFromContext(t *testing.T) {
 	t.Run("ARMRequestContext is injected", func(t *testing.T) {
 		req, err := getTestHTTPRequest(t, "./testdata/armrpcheaders.json")
@@ -54,6 +54,12 @@ var operationMethodToHTTPMethod = map[OperationMethod]string{
 	// ARM RPC specific operations.
 	OperationPutSubscriptions: http.MethodPut,
 
+	// The history of a resource.
+	OperationGetHistory: http.MethodGet,
+
+	// The plan of the recipe of a resource.
+	OperationRecipePlan: http.MethodPost,
+
 	// Non-idempotent lifecycle operations.
 	OperationGetImperative:    http.MethodPost,
 	OperationPutImperative:    http.MethodPost,
@@ -87,6 +93,15 @@ const (
 	OperationPutSubscriptions OperationMethod = "PUTSUBSCRIPTIONS"
 	OperationPost             OperationMethod = "POST"
 
+	// OperationCancel is the custom action to cancel an async operation, using POST on the operation status.
+	OperationCancel OperationMethod = "CANCEL"
+
+	// OperationGetHistory is used to get the operation history of a resource, using GET on {resourceId}/history.
+	OperationGetHistory OperationMethod = "GETHISTORY"
+
+	// OperationRecipePlan is used to plan the recipe of a resource without deploying it, using POST on {resourceId}/recipes/plan.
+	OperationRecipePlan OperationMethod = "RECIPEPLAN"
+
 	// Imperative operation methods for non-idempotent lifecycle operations.
 	// UCP extends the ARM resource lifecycle to support using POST for non-idempotent resource types.
 	//
@@ -22,6 +22,7 @@ import (
 
 	"github.com/radius-project/radius/pkg/components/database"
 	"github.com/radius-project/radius/pkg/corerp/backend/deployment"
+	"github.com/radius-project/radius/pkg/crypto/encryption"
 	"github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
 
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +44,10 @@ type Options struct {
 
 	// UcpClient is the UCP client factory.
 	UcpClient *v20231001preview.ClientFactory
+
+	// KeyProvider provides the keys used to encrypt and decrypt sensitive fields. When it is nil, controllers
+	// load the keys from the default Kubernetes Secret.
+	KeyProvider encryption.KeyProvider
 }
 
 // Validate validates that required fields are set on the options.
@@ -55,7 +60,7 @@ func (o Options) Validate() error {
 		err = errors.Join(err, errors.New(".ResourceType is required"))
 	}
 
-	// KubeClient and GetDeploymentProcessor are not used by the majority of the code, so they
+	// KubeClient, KeyProvider and GetDeploymentProcessor are not used by the majority of the code, so they
 	// are not validated here.
 
 	return err
@@ -95,6 +100,11 @@ func (b *BaseController) KubeClient() runtimeclient.Client {
 	return b.options.KubeClient
 }
 
+// KeyProvider gets the encryption key provider for this controller.
+func (b *BaseController) KeyProvider() encryption.KeyProvider {
+	return b.options.KeyProvider
+}
+
 // ResourceType gets the resource type for this controller.
 func (b *BaseController) ResourceType() string {
 	return b.options.ResourceType
@@ -53,6 +53,8 @@ type Request struct {
 	HomeTenantID string `json:"homeTenantID,omitempty"`
 	// ClientObjectID represents the client object id of caller.
 	ClientObjectID string `json:"clientObjectID,omitempty"`
+	// Caller represents the identity of the client that requested the operation.
+	Caller string `json:"caller,omitempty"`
 
 	// OperationTimeout represents the timeout duration of async operation.
 	OperationTimeout *time.Duration `json:"asyncOperationTimeout"`
@@ -28,6 +28,10 @@ type Result struct {
 	// Error represents the error when status is Cancelled or Failed.
 	Error *v1.ErrorDetails
 
+	// Output is the value returned by an operation that succeeded, like the plan of a recipe. It is saved in the
+	// operation status and returned by the operation result.
+	Output any
+
 	// state represents the provisioning status.
 	state *v1.ProvisioningState
 }
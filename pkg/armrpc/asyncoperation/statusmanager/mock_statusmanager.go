@@ -121,6 +121,44 @@ func (c *MockStatusManagerGetCall) DoAndReturn(f func(context.Context, resources
 	return c
 }
 
+// MarkDeadLettered mocks base method.
+func (m *MockStatusManager) MarkDeadLettered(ctx context.Context, id resources.ID, operationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDeadLettered", ctx, id, operationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDeadLettered indicates an expected call of MarkDeadLettered.
+func (mr *MockStatusManagerMockRecorder) MarkDeadLettered(ctx, id, operationID any) *MockStatusManagerMarkDeadLetteredCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDeadLettered", reflect.TypeOf((*MockStatusManager)(nil).MarkDeadLettered), ctx, id, operationID)
+	return &MockStatusManagerMarkDeadLetteredCall{Call: call}
+}
+
+// MockStatusManagerMarkDeadLetteredCall wrap *gomock.Call
+type MockStatusManagerMarkDeadLetteredCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStatusManagerMarkDeadLetteredCall) Return(arg0 error) *MockStatusManagerMarkDeadLetteredCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStatusManagerMarkDeadLetteredCall) Do(f func(context.Context, resources.ID, uuid.UUID) error) *MockStatusManagerMarkDeadLetteredCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStatusManagerMarkDeadLetteredCall) DoAndReturn(f func(context.Context, resources.ID, uuid.UUID) error) *MockStatusManagerMarkDeadLetteredCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // QueueAsyncOperation mocks base method.
 func (m *MockStatusManager) QueueAsyncOperation(ctx context.Context, sCtx *v1.ARMRequestContext, options QueueOperationOptions) error {
 	m.ctrl.T.Helper()
@@ -159,6 +197,120 @@ func (c *MockStatusManagerQueueAsyncOperationCall) DoAndReturn(f func(context.Co
 	return c
 }
 
+// RequestCancel mocks base method.
+func (m *MockStatusManager) RequestCancel(ctx context.Context, id resources.ID, operationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCancel", ctx, id, operationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestCancel indicates an expected call of RequestCancel.
+func (mr *MockStatusManagerMockRecorder) RequestCancel(ctx, id, operationID any) *MockStatusManagerRequestCancelCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCancel", reflect.TypeOf((*MockStatusManager)(nil).RequestCancel), ctx, id, operationID)
+	return &MockStatusManagerRequestCancelCall{Call: call}
+}
+
+// MockStatusManagerRequestCancelCall wrap *gomock.Call
+type MockStatusManagerRequestCancelCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStatusManagerRequestCancelCall) Return(arg0 error) *MockStatusManagerRequestCancelCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStatusManagerRequestCancelCall) Do(f func(context.Context, resources.ID, uuid.UUID) error) *MockStatusManagerRequestCancelCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStatusManagerRequestCancelCall) DoAndReturn(f func(context.Context, resources.ID, uuid.UUID) error) *MockStatusManagerRequestCancelCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Reset mocks base method.
+func (m *MockStatusManager) Reset(ctx context.Context, id resources.ID, operationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, id, operationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockStatusManagerMockRecorder) Reset(ctx, id, operationID any) *MockStatusManagerResetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockStatusManager)(nil).Reset), ctx, id, operationID)
+	return &MockStatusManagerResetCall{Call: call}
+}
+
+// MockStatusManagerResetCall wrap *gomock.Call
+type MockStatusManagerResetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStatusManagerResetCall) Return(arg0 error) *MockStatusManagerResetCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStatusManagerResetCall) Do(f func(context.Context, resources.ID, uuid.UUID) error) *MockStatusManagerResetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStatusManagerResetCall) DoAndReturn(f func(context.Context, resources.ID, uuid.UUID) error) *MockStatusManagerResetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetOutput mocks base method.
+func (m *MockStatusManager) SetOutput(ctx context.Context, id resources.ID, operationID uuid.UUID, output any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOutput", ctx, id, operationID, output)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOutput indicates an expected call of SetOutput.
+func (mr *MockStatusManagerMockRecorder) SetOutput(ctx, id, operationID, output any) *MockStatusManagerSetOutputCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOutput", reflect.TypeOf((*MockStatusManager)(nil).SetOutput), ctx, id, operationID, output)
+	return &MockStatusManagerSetOutputCall{Call: call}
+}
+
+// MockStatusManagerSetOutputCall wrap *gomock.Call
+type MockStatusManagerSetOutputCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStatusManagerSetOutputCall) Return(arg0 error) *MockStatusManagerSetOutputCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStatusManagerSetOutputCall) Do(f func(context.Context, resources.ID, uuid.UUID, any) error) *MockStatusManagerSetOutputCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStatusManagerSetOutputCall) DoAndReturn(f func(context.Context, resources.ID, uuid.UUID, any) error) *MockStatusManagerSetOutputCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Update mocks base method.
 func (m *MockStatusManager) Update(ctx context.Context, id resources.ID, operationID uuid.UUID, state v1.ProvisioningState, endTime *time.Time, opError *v1.ErrorDetails) error {
 	m.ctrl.T.Helper()
@@ -43,4 +43,16 @@ type Status struct {
 
 	// LastUpdatedTime represents the async operation last updated time.
 	LastUpdatedTime time.Time `json:"lastUpdatedTime"`
+
+	// CancelRequested is set when the user requested to cancel the operation. The worker processing
+	// the operation cancels the controller and completes the operation as Canceled.
+	CancelRequested bool `json:"cancelRequested,omitempty"`
+
+	// Output is the value returned by an operation that succeeded, like the plan of a recipe. It is returned by the
+	// operation result.
+	Output any `json:"output,omitempty"`
+
+	// DeadLettered is set when the request message of the failed operation was moved to the dead-letter
+	// queue. The worker runs the operation again when it receives the replayed message.
+	DeadLettered bool `json:"deadLettered,omitempty"`
 }
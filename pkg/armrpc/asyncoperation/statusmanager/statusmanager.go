@@ -34,6 +34,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrOperationCompleted is returned when the cancellation of an operation is requested after it completed.
+var ErrOperationCompleted = errors.New("operation has already completed")
+
 // statusManager includes the necessary functions to manage asynchronous operations.
 type statusManager struct {
 	databaseClient database.Client
@@ -41,6 +44,10 @@ type statusManager struct {
 	location       string
 }
 
+// maxSaveAttempts is the number of attempts to save a change of the operation status when it is
+// updated concurrently.
+const maxSaveAttempts = 3
+
 // QueueOperationOptions is the options type provided when queueing an async operation.
 type QueueOperationOptions struct {
 	// OperationTimeout specifies the timeout duration for the async operation.
@@ -61,6 +68,16 @@ type StatusManager interface {
 	Update(ctx context.Context, id resources.ID, operationID uuid.UUID, state v1.ProvisioningState, endTime *time.Time, opError *v1.ErrorDetails) error
 	// Delete deletes an async operation status.
 	Delete(ctx context.Context, id resources.ID, operationID uuid.UUID) error
+	// RequestCancel requests the cancellation of an async operation. It returns ErrOperationCompleted
+	// if the operation has already completed.
+	RequestCancel(ctx context.Context, id resources.ID, operationID uuid.UUID) error
+	// SetOutput saves the value returned by the operation in its status.
+	SetOutput(ctx context.Context, id resources.ID, operationID uuid.UUID, output any) error
+	// MarkDeadLettered records that the request message of the operation was moved to the dead-letter queue.
+	MarkDeadLettered(ctx context.Context, id resources.ID, operationID uuid.UUID) error
+	// Reset resets the status of a dead-lettered operation to Accepted so that its replayed request
+	// message is processed again.
+	Reset(ctx context.Context, id resources.ID, operationID uuid.UUID) error
 }
 
 // New creates statusManager instance.
@@ -179,6 +196,105 @@ func (aom *statusManager) Delete(ctx context.Context, id resources.ID, operation
 	return aom.databaseClient.Delete(ctx, aom.operationStatusResourceID(id, operationID))
 }
 
+// RequestCancel marks the operation status as cancel requested. The worker processing the operation
+// observes the flag, either when it dequeues the request message or while the operation is running,
+// and completes the operation as Canceled. It returns ErrOperationCompleted if the operation is already
+// in a terminal state.
+func (aom *statusManager) RequestCancel(ctx context.Context, id resources.ID, operationID uuid.UUID) error {
+	opID := aom.operationStatusResourceID(id, operationID)
+
+	// The worker updates the status concurrently, so retry when the status changed since it was read.
+	var err error
+	for range maxSaveAttempts {
+		var obj *database.Object
+		obj, err = aom.databaseClient.Get(ctx, opID)
+		if err != nil {
+			return err
+		}
+
+		s := &Status{}
+		if err := obj.As(s); err != nil {
+			return err
+		}
+
+		if s.Status.IsTerminal() {
+			return ErrOperationCompleted
+		}
+
+		if s.CancelRequested {
+			return nil
+		}
+
+		s.CancelRequested = true
+		s.LastUpdatedTime = time.Now().UTC()
+		obj.Data = s
+
+		err = aom.databaseClient.Save(ctx, obj, database.WithETag(obj.ETag))
+		if !errors.Is(err, &database.ErrConcurrency{}) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// SetOutput sets Output on the operation status.
+func (aom *statusManager) SetOutput(ctx context.Context, id resources.ID, operationID uuid.UUID, output any) error {
+	return aom.modify(ctx, id, operationID, func(s *Status) {
+		s.Output = output
+	})
+}
+
+// MarkDeadLettered sets DeadLettered on the operation status.
+func (aom *statusManager) MarkDeadLettered(ctx context.Context, id resources.ID, operationID uuid.UUID) error {
+	return aom.modify(ctx, id, operationID, func(s *Status) {
+		s.DeadLettered = true
+	})
+}
+
+// Reset sets the operation status back to Accepted and clears the result of the previous attempts.
+func (aom *statusManager) Reset(ctx context.Context, id resources.ID, operationID uuid.UUID) error {
+	return aom.modify(ctx, id, operationID, func(s *Status) {
+		s.Status = v1.ProvisioningStateAccepted
+		s.EndTime = nil
+		s.Error = nil
+		s.Output = nil
+		s.CancelRequested = false
+		s.DeadLettered = false
+	})
+}
+
+// modify applies fn to the operation status and saves it. It retries when the status changed since
+// it was read.
+func (aom *statusManager) modify(ctx context.Context, id resources.ID, operationID uuid.UUID, fn func(s *Status)) error {
+	opID := aom.operationStatusResourceID(id, operationID)
+
+	var err error
+	for range maxSaveAttempts {
+		var obj *database.Object
+		obj, err = aom.databaseClient.Get(ctx, opID)
+		if err != nil {
+			return err
+		}
+
+		s := &Status{}
+		if err := obj.As(s); err != nil {
+			return err
+		}
+
+		fn(s)
+		s.LastUpdatedTime = time.Now().UTC()
+		obj.Data = s
+
+		err = aom.databaseClient.Save(ctx, obj, database.WithETag(obj.ETag))
+		if !errors.Is(err, &database.ErrConcurrency{}) {
+			return err
+		}
+	}
+
+	return err
+}
+
 // queueRequestMessage function is to put the async operation message to the queue to be worked on.
 func (aom *statusManager) queueRequestMessage(ctx context.Context, sCtx *v1.ARMRequestContext, aos *Status, operationTimeout time.Duration) error {
 	msg := &ctrl.Request{
@@ -191,6 +307,7 @@ func (aom *statusManager) queueRequestMessage(ctx context.Context, sCtx *v1.ARMR
 		AcceptLanguage:   sCtx.AcceptLanguage,
 		HomeTenantID:     sCtx.HomeTenantID,
 		ClientObjectID:   sCtx.ClientObjectID,
+		Caller:           sCtx.Caller(),
 		OperationTimeout: &operationTimeout,
 	}
 
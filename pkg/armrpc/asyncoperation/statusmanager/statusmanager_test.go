@@ -26,6 +26,7 @@ import (
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
 	"github.com/radius-project/radius/pkg/armrpc/rpctest"
 	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
 	"github.com/radius-project/radius/pkg/components/queue"
 	"github.com/radius-project/radius/pkg/ucp/resources"
 	"github.com/stretchr/testify/require"
@@ -324,3 +325,158 @@ func TestUpdateAsyncOperationStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestCancel(t *testing.T) {
+	rid, err := resources.ParseResource(ucpEnvResourceID)
+	require.NoError(t, err)
+
+	cancelCases := []struct {
+		Desc            string
+		Status          v1.ProvisioningState
+		CancelRequested bool
+		Err             error
+	}{
+		{
+			Desc:            "running",
+			Status:          v1.ProvisioningStateUpdating,
+			CancelRequested: true,
+		},
+		{
+			Desc:            "queued",
+			Status:          v1.ProvisioningStateAccepted,
+			CancelRequested: true,
+		},
+		{
+			Desc:   "completed",
+			Status: v1.ProvisioningStateSucceeded,
+			Err:    ErrOperationCompleted,
+		},
+	}
+
+	for _, tt := range cancelCases {
+		t.Run(tt.Desc, func(t *testing.T) {
+			databaseClient := inmemory.NewClient()
+			manager := New(databaseClient, nil, "test-location")
+			operationID := uuid.New()
+
+			opStatusID := manager.(*statusManager).operationStatusResourceID(rid, operationID)
+			err := databaseClient.Save(t.Context(), &database.Object{
+				Metadata: database.Metadata{ID: opStatusID},
+				Data: &Status{
+					AsyncOperationStatus: v1.AsyncOperationStatus{ID: opStatusID, Name: operationID.String(), Status: tt.Status},
+					LinkedResourceID:     rid.String(),
+				},
+			})
+			require.NoError(t, err)
+
+			err = manager.RequestCancel(t.Context(), rid, operationID)
+			require.ErrorIs(t, err, tt.Err)
+
+			status, err := manager.Get(t.Context(), rid, operationID)
+			require.NoError(t, err)
+			require.Equal(t, tt.CancelRequested, status.CancelRequested)
+			require.Equal(t, tt.Status, status.Status)
+
+			// Requesting the cancellation again is a no-op.
+			err = manager.RequestCancel(t.Context(), rid, operationID)
+			require.ErrorIs(t, err, tt.Err)
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		manager := New(inmemory.NewClient(), nil, "test-location")
+		err := manager.RequestCancel(t.Context(), rid, uuid.New())
+		require.ErrorIs(t, err, &database.ErrNotFound{})
+	})
+}
+
+func TestMarkDeadLettered_Reset(t *testing.T) {
+	rid, err := resources.ParseResource(ucpEnvResourceID)
+	require.NoError(t, err)
+
+	databaseClient := inmemory.NewClient()
+	manager := New(databaseClient, nil, "test-location")
+	operationID := uuid.New()
+
+	endTime := time.Now().UTC()
+	opStatusID := manager.(*statusManager).operationStatusResourceID(rid, operationID)
+	err = databaseClient.Save(t.Context(), &database.Object{
+		Metadata: database.Metadata{ID: opStatusID},
+		Data: &Status{
+			AsyncOperationStatus: v1.AsyncOperationStatus{
+				ID:      opStatusID,
+				Name:    operationID.String(),
+				Status:  v1.ProvisioningStateFailed,
+				EndTime: &endTime,
+				Error:   &v1.ErrorDetails{Code: v1.CodeInternal, Message: "failed"},
+			},
+			LinkedResourceID: rid.String(),
+			CancelRequested:  true,
+		},
+	})
+	require.NoError(t, err)
+
+	err = manager.MarkDeadLettered(t.Context(), rid, operationID)
+	require.NoError(t, err)
+
+	status, err := manager.Get(t.Context(), rid, operationID)
+	require.NoError(t, err)
+	require.True(t, status.DeadLettered)
+	require.Equal(t, v1.ProvisioningStateFailed, status.Status)
+
+	err = manager.Reset(t.Context(), rid, operationID)
+	require.NoError(t, err)
+
+	status, err = manager.Get(t.Context(), rid, operationID)
+	require.NoError(t, err)
+	require.Equal(t, v1.ProvisioningStateAccepted, status.Status)
+	require.False(t, status.DeadLettered)
+	require.False(t, status.CancelRequested)
+	require.Nil(t, status.EndTime)
+	require.Nil(t, status.Error)
+
+	err = manager.Reset(t.Context(), rid, uuid.New())
+	require.ErrorIs(t, err, &database.ErrNotFound{})
+}
+
+func TestSetOutput(t *testing.T) {
+	rid, err := resources.ParseResource(ucpEnvResourceID)
+	require.NoError(t, err)
+
+	databaseClient := inmemory.NewClient()
+	manager := New(databaseClient, nil, "test-location")
+	operationID := uuid.New()
+
+	opStatusID := manager.(*statusManager).operationStatusResourceID(rid, operationID)
+	err = databaseClient.Save(t.Context(), &database.Object{
+		Metadata: database.Metadata{ID: opStatusID},
+		Data: &Status{
+			AsyncOperationStatus: v1.AsyncOperationStatus{
+				ID:     opStatusID,
+				Name:   operationID.String(),
+				Status: v1.ProvisioningStateUpdating,
+			},
+			LinkedResourceID: rid.String(),
+		},
+	})
+	require.NoError(t, err)
+
+	err = manager.SetOutput(t.Context(), rid, operationID, map[string]any{"changes": []any{}})
+	require.NoError(t, err)
+
+	status, err := manager.Get(t.Context(), rid, operationID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"changes": []any{}}, status.Output)
+	require.Equal(t, v1.ProvisioningStateUpdating, status.Status)
+
+	// The result of a previous attempt is cleared when the operation runs again.
+	err = manager.Reset(t.Context(), rid, operationID)
+	require.NoError(t, err)
+
+	status, err = manager.Get(t.Context(), rid, operationID)
+	require.NoError(t, err)
+	require.Nil(t, status.Output)
+
+	err = manager.SetOutput(t.Context(), rid, uuid.New(), nil)
+	require.ErrorIs(t, err, &database.ErrNotFound{})
+}
@@ -28,6 +28,7 @@ import (
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
 	ctrl "github.com/radius-project/radius/pkg/armrpc/asyncoperation/controller"
 	manager "github.com/radius-project/radius/pkg/armrpc/asyncoperation/statusmanager"
+	"github.com/radius-project/radius/pkg/armrpc/history"
 	"github.com/radius-project/radius/pkg/components/database"
 	"github.com/radius-project/radius/pkg/components/metrics"
 	"github.com/radius-project/radius/pkg/components/queue"
@@ -58,6 +59,9 @@ const (
 
 	// defaultDequeueInterval is the default duration for the dequeue interval.
 	defaultDequeueInterval = time.Duration(200) * time.Millisecond
+
+	// defaultCancellationPollInterval is the default duration between 2 checks for the cancellation of a running operation.
+	defaultCancellationPollInterval = time.Duration(5) * time.Second
 )
 
 // Options configures AsyncRequestProcessorWorker
@@ -79,6 +83,9 @@ type Options struct {
 
 	// DequeueIntervalDuration is the duration for the dequeue interval.
 	DequeueIntervalDuration time.Duration
+
+	// CancellationPollInterval is the duration between 2 checks for the cancellation of a running operation.
+	CancellationPollInterval time.Duration
 }
 
 // AsyncRequestProcessWorker is the worker to process async requests.
@@ -115,6 +122,9 @@ func New(
 	if options.DequeueIntervalDuration == time.Duration(0) {
 		options.DequeueIntervalDuration = defaultDequeueInterval
 	}
+	if options.CancellationPollInterval == time.Duration(0) {
+		options.CancellationPollInterval = defaultCancellationPollInterval
+	}
 
 	return &AsyncRequestProcessWorker{
 		options:      options,
@@ -186,24 +196,33 @@ func (w *AsyncRequestProcessWorker) Start(ctx context.Context) error {
 			}
 
 			if msgreq.DequeueCount > w.options.MaxOperationRetryCount {
-				// If the operation was already completed on a prior attempt (for example, the panic
-				// recovery recorded the real failure cause) but the message could not be finished then,
-				// just finish it now instead of overwriting the recorded status with a generic
-				// retry-count error.
-				if w.isOperationTerminal(reqCtx, op) {
-					if err := w.requestQueue.FinishMessage(reqCtx, msgreq); err != nil {
-						opLogger.Error(err, "failed to finish the message")
+				// If the operation was already completed on a prior attempt but the message could not be
+				// finished then, the recorded status must not be overwritten with a generic retry-count
+				// error. A succeeded or canceled operation is done, so its message is just finished now.
+				// A failed operation (for example, the panic recovery recorded the real failure cause) is
+				// moved to the dead-letter queue so that it can be replayed.
+				if status := w.terminalStatus(reqCtx, op); status != nil {
+					if status.Status != v1.ProvisioningStateFailed {
+						if err := w.requestQueue.FinishMessage(reqCtx, msgreq); err != nil {
+							opLogger.Error(err, "failed to finish the message")
+						}
+						return
 					}
+
+					lastError := string(status.Status)
+					if status.Error != nil {
+						lastError = status.Error.Message
+					}
+					w.deadLetterMessage(reqCtx, msgreq, op, lastError)
 					return
 				}
 
 				errMsg := fmt.Sprintf("exceeded max retry count to process async operation message: %d", msgreq.DequeueCount)
 				opLogger.Error(nil, errMsg)
-				failed := ctrl.NewFailedResult(v1.ErrorDetails{
+				w.deadLetterOperation(reqCtx, msgreq, v1.ErrorDetails{
 					Code:    v1.CodeInternal,
 					Message: errMsg,
-				})
-				w.completeOperation(reqCtx, msgreq, failed, asyncCtrl.DatabaseClient())
+				}, asyncCtrl.DatabaseClient())
 				return
 			}
 
@@ -221,6 +240,13 @@ func (w *AsyncRequestProcessWorker) Start(ctx context.Context) error {
 				return
 			}
 
+			// The operation was canceled while it was waiting in the queue, so it does not need to run.
+			if w.isCancelRequested(reqCtx, op) {
+				opLogger.Info("operation was canceled before it started")
+				w.completeOperation(reqCtx, msgreq, newUserCanceledResult(op), asyncCtrl.DatabaseClient())
+				return
+			}
+
 			if err = w.updateResourceAndOperationStatus(reqCtx, asyncCtrl.DatabaseClient(), op, v1.ProvisioningStateUpdating, nil); err != nil {
 				return
 			}
@@ -267,16 +293,15 @@ func (w *AsyncRequestProcessWorker) runOperation(ctx context.Context, message *q
 				// the real cause.
 				//
 				// To preserve the real cause, on the final attempt complete the operation as Failed using
-				// the panic details. Earlier attempts are still left unfinished so they are retried. We
+				// the panic details and move the message to the dead-letter queue. Earlier attempts are still left unfinished so they are retried. We
 				// only do this when the request context is still active (Err() == nil); if it was
 				// canceled or its deadline was exceeded (operation timeout or worker shutdown), those
 				// paths own completion and must not be overwritten.
 				if message.DequeueCount >= w.options.MaxOperationRetryCount && asyncReqCtx.Err() == nil {
-					failed := ctrl.NewFailedResult(v1.ErrorDetails{
+					w.deadLetterOperation(ctx, message, v1.ErrorDetails{
 						Code:    v1.CodeInternal,
 						Message: fmt.Sprintf("unexpected error while processing async operation: %v", err),
-					})
-					w.completeOperation(ctx, message, failed, asyncCtrl.DatabaseClient())
+					}, asyncCtrl.DatabaseClient())
 				}
 			}
 		}(opDone)
@@ -298,9 +323,10 @@ func (w *AsyncRequestProcessWorker) runOperation(ctx context.Context, message *q
 			logger.Info("Operation returned", "success", "false", "provisioningState", result.ProvisioningState(), "err", result.Error)
 		}
 
-		// There are two cases when asyncReqCtx is canceled.
+		// There are three cases when asyncReqCtx is canceled.
 		// 1. When the operation is timed out, w.completeOperation will be called in L186
-		// 2. When parent context is canceled or done, we need to requeue the operation to reprocess the request.
+		// 2. When the user canceled the operation, w.completeOperation is called by the cancellation poll.
+		// 3. When parent context is canceled or done, we need to requeue the operation to reprocess the request.
 		// Such cases should not call w.completeOperation.
 		if !errors.Is(asyncReqCtx.Err(), context.Canceled) {
 			w.completeOperation(ctx, message, result, asyncCtrl.DatabaseClient())
@@ -309,18 +335,23 @@ func (w *AsyncRequestProcessWorker) runOperation(ctx context.Context, message *q
 	}()
 
 	operationTimeoutAfter := time.After(asyncReq.Timeout())
-	messageExtendAfter := w.getMessageExtendDuration(message.NextVisibleAt)
+	messageExtendTimer := time.NewTimer(w.getMessageExtendDuration(message.NextVisibleAt))
+	defer messageExtendTimer.Stop()
+
+	// The cancellation can be requested from any frontend instance, so it is observed through the status.
+	cancellationPoll := time.NewTicker(w.options.CancellationPollInterval)
+	defer cancellationPoll.Stop()
 
 	for {
 		select {
-		case <-time.After(messageExtendAfter):
+		case <-messageExtendTimer.C:
 			if err := w.requestQueue.ExtendMessage(ctx, message); err != nil {
 				logger.Error(err, "fails to extend message lock")
 			} else {
 				logger.Info("Extended message lock duration.", "nextVisibleTime", message.NextVisibleAt.UTC().String())
 				metrics.DefaultAsyncOperationMetrics.RecordExtendedAsyncOperation(ctx, asyncReq)
 			}
-			messageExtendAfter = w.getMessageExtendDuration(message.NextVisibleAt)
+			messageExtendTimer.Reset(w.getMessageExtendDuration(message.NextVisibleAt))
 
 		case <-operationTimeoutAfter:
 			logger.Info("Cancelling async operation.")
@@ -332,6 +363,17 @@ func (w *AsyncRequestProcessWorker) runOperation(ctx context.Context, message *q
 			w.completeOperation(ctx, message, result, asyncCtrl.DatabaseClient())
 			return
 
+		case <-cancellationPoll.C:
+			if !w.isCancelRequested(ctx, asyncReq) {
+				continue
+			}
+
+			logger.Info("Cancelling async operation requested by the user.")
+
+			opCancel()
+			w.completeOperation(ctx, message, newUserCanceledResult(asyncReq), asyncCtrl.DatabaseClient())
+			return
+
 		case <-ctx.Done():
 			logger.Info("Stopping processing async operation. This operation will be reprocessed.")
 			return
@@ -363,6 +405,21 @@ func (w *AsyncRequestProcessWorker) completeOperation(ctx context.Context, messa
 		return
 	}
 
+	// The value returned by the operation is saved before the operation completes, so that it is available as
+	// soon as the status is terminal.
+	if result.Output != nil {
+		rID, err := resources.ParseResource(req.ResourceID)
+		if err != nil {
+			logger.Error(err, "failed to parse resource ID")
+			return
+		}
+
+		if err := w.sm.SetOutput(ctx, rID, req.OperationID, result.Output); err != nil {
+			logger.Error(err, "failed to save the result of the operation", "operationID", req.OperationID.String())
+			return
+		}
+	}
+
 	err := w.updateResourceAndOperationStatus(ctx, sc, req, result.ProvisioningState(), result.Error)
 	if err != nil {
 		logger.Error(err, "failed to update resource and/or operation status")
@@ -379,6 +436,63 @@ func (w *AsyncRequestProcessWorker) completeOperation(ctx context.Context, messa
 	metrics.DefaultAsyncOperationMetrics.RecordAsyncOperation(ctx, req, &result)
 }
 
+// deadLetterOperation completes the operation as Failed with opErr and moves the message to the
+// dead-letter queue, so that the poisoned message can be inspected and replayed.
+func (w *AsyncRequestProcessWorker) deadLetterOperation(ctx context.Context, message *queue.Message, opErr v1.ErrorDetails, sc database.Client) {
+	logger := ucplog.FromContextOrDiscard(ctx)
+	req := &ctrl.Request{}
+	if err := json.Unmarshal(message.Data, req); err != nil {
+		logger.Error(err, "failed to unmarshal queue message.")
+		return
+	}
+
+	result := ctrl.NewFailedResult(opErr)
+	err := w.updateResourceAndOperationStatus(ctx, sc, req, result.ProvisioningState(), result.Error)
+	if err != nil {
+		logger.Error(err, "failed to update resource and/or operation status")
+		return
+	}
+
+	w.deadLetterMessage(ctx, message, req, opErr.Message)
+	metrics.DefaultAsyncOperationMetrics.RecordAsyncOperation(ctx, req, &result)
+}
+
+// deadLetterMessage moves the message of the failed operation req to the dead-letter queue with
+// lastError. The message is finished instead if the queue has no dead-letter queue.
+func (w *AsyncRequestProcessWorker) deadLetterMessage(ctx context.Context, message *queue.Message, req *ctrl.Request, lastError string) {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	dlq, ok := w.requestQueue.(queue.DeadLetterClient)
+	if !ok {
+		if err := w.requestQueue.FinishMessage(ctx, message); err != nil {
+			logger.Error(err, "failed to finish the message")
+		}
+		return
+	}
+
+	rID, err := resources.ParseResource(req.ResourceID)
+	if err != nil {
+		logger.Error(err, "failed to parse resource ID")
+		return
+	}
+
+	// The message is moved before the status is marked, so that the status never claims a message is
+	// dead-lettered while it is still on the queue. If the move fails, the message is redelivered and
+	// dead-lettered again on the next attempt.
+	if err := dlq.DeadLetter(ctx, message, lastError); err != nil {
+		logger.Error(err, "failed to move the message to the dead-letter queue")
+		return
+	}
+	logger.Info("Moved the message to the dead-letter queue.", "messageID", message.ID)
+
+	// The mark lets the worker recognize the message when it is replayed. If it cannot be saved, the
+	// replayed message is ignored as a duplicate of the failed operation until it exceeds the retry
+	// count and is dead-lettered again.
+	if err := w.sm.MarkDeadLettered(ctx, rID, req.OperationID); err != nil {
+		logger.Error(err, "failed to mark the operation status as dead-lettered")
+	}
+}
+
 func (w *AsyncRequestProcessWorker) updateResourceAndOperationStatus(ctx context.Context, sc database.Client, req *ctrl.Request, state v1.ProvisioningState, opErr *v1.ErrorDetails) error {
 	logger := ucplog.FromContextOrDiscard(ctx)
 
@@ -388,12 +502,21 @@ func (w *AsyncRequestProcessWorker) updateResourceAndOperationStatus(ctx context
 		return err
 	}
 
-	err = updateResourceState(ctx, sc, rID.String(), state)
-	if errors.Is(err, &database.ErrNotFound{}) {
-		logger.Info("failed to update the provisioningState in resource because it no longer exists.")
-	} else if err != nil {
-		logger.Error(err, "failed to update the provisioningState in resource.")
-		return err
+	// Operations that don't change the resource, like recipe plans, neither update its provisioningState nor
+	// are recorded in its history.
+	changesResource := true
+	if opType, ok := v1.ParseOperationType(req.OperationType); ok {
+		changesResource = history.Tracked(opType.Method)
+	}
+
+	if changesResource {
+		err = updateResourceState(ctx, sc, rID.String(), state)
+		if errors.Is(err, &database.ErrNotFound{}) {
+			logger.Info("failed to update the provisioningState in resource because it no longer exists.")
+		} else if err != nil {
+			logger.Error(err, "failed to update the provisioningState in resource.")
+			return err
+		}
 	}
 
 	// Otherwise we update the operationStatus to the result.
@@ -404,14 +527,50 @@ func (w *AsyncRequestProcessWorker) updateResourceAndOperationStatus(ctx context
 		return err
 	}
 
+	if state.IsTerminal() && changesResource {
+		// Failing to record the history does not fail the operation, which has already completed.
+		err = history.New(sc).Record(ctx, &history.Entry{
+			ResourceID:        rID.String(),
+			OperationID:       req.OperationID.String(),
+			OperationType:     req.OperationType,
+			Caller:            req.Caller,
+			Timestamp:         now,
+			ProvisioningState: state,
+			Error:             opErr,
+		})
+		if err != nil {
+			logger.Error(err, "failed to record the operation in the resource history", "operationID", req.OperationID.String())
+		}
+	}
+
 	return nil
 }
 
-// isOperationTerminal reports whether the operation status has already reached a terminal
-// provisioning state (for example, it was completed as Failed by the panic recovery on the final
-// attempt). It is used to avoid overwriting an already-recorded terminal status with a generic
-// retry-count error. It returns false if the status cannot be read.
-func (w *AsyncRequestProcessWorker) isOperationTerminal(ctx context.Context, op *ctrl.Request) bool {
+// terminalStatus returns the operation status if it has already reached a terminal provisioning
+// state (for example, it was completed as Failed by the panic recovery on the final attempt). It is
+// used to avoid overwriting an already-recorded terminal status with a generic retry-count error.
+// It returns nil if the status is not terminal or cannot be read.
+func (w *AsyncRequestProcessWorker) terminalStatus(ctx context.Context, op *ctrl.Request) *manager.Status {
+	if w.sm == nil {
+		return nil
+	}
+
+	rID, err := resources.ParseResource(op.ResourceID)
+	if err != nil {
+		return nil
+	}
+
+	status, err := w.sm.Get(ctx, rID, op.OperationID)
+	if err != nil || !status.Status.IsTerminal() {
+		return nil
+	}
+
+	return status
+}
+
+// isCancelRequested reports whether the user requested to cancel the operation. It returns false if
+// the status cannot be read, so that the operation keeps running.
+func (w *AsyncRequestProcessWorker) isCancelRequested(ctx context.Context, op *ctrl.Request) bool {
 	if w.sm == nil {
 		return false
 	}
@@ -426,7 +585,14 @@ func (w *AsyncRequestProcessWorker) isOperationTerminal(ctx context.Context, op
 		return false
 	}
 
-	return status.Status.IsTerminal()
+	return status.CancelRequested && !status.Status.IsTerminal()
+}
+
+// newUserCanceledResult returns the result of an operation canceled by the user.
+func newUserCanceledResult(op *ctrl.Request) ctrl.Result {
+	result := ctrl.NewCanceledResult(fmt.Sprintf("Operation (%s) was canceled by the user.", op.OperationType))
+	result.Error.Target = op.ResourceID
+	return result
 }
 
 func (w *AsyncRequestProcessWorker) isDuplicated(ctx context.Context, resourceID string, operationID uuid.UUID) (bool, error) {
@@ -440,6 +606,12 @@ func (w *AsyncRequestProcessWorker) isDuplicated(ctx context.Context, resourceID
 		return false, err
 	}
 
+	// The message of a dead-lettered operation only comes back when it is replayed, so the operation
+	// is reset to run again instead of being considered as a duplicated operation.
+	if status.DeadLettered {
+		return false, w.sm.Reset(ctx, rID, operationID)
+	}
+
 	// 1. If the operation is in updating state and the last updated time is within the deduplication duration, we consider it as a duplicated operation.
 	// 2. If the operation is in terminal state, we consider it as a duplicated operation.
 	if (status.Status == v1.ProvisioningStateUpdating && !status.LastUpdatedTime.IsZero() &&
@@ -18,6 +18,7 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -205,13 +206,15 @@ func TestStart_MaxDequeueCount(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, id string, _ ...database.GetOptions) (*database.Object, error) {
 			return newTestResourceObject(), nil
 		}).AnyTimes()
-	tCtx.mockSC.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	// The resource is saved with the failed state, then the failure is recorded in the resource history.
+	tCtx.mockSC.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
 	// The operation is not yet terminal, so the max-retry path completes it with the generic message.
 	tCtx.mockSM.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(&manager.Status{
 			AsyncOperationStatus: v1.AsyncOperationStatus{Status: v1.ProvisioningStateUpdating},
 		}, nil).AnyTimes()
 	tCtx.mockSM.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(v1.ProvisioningStateFailed), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	tCtx.mockSM.EXPECT().MarkDeadLettered(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
 	expectedDequeueCount := 2
 
@@ -259,11 +262,17 @@ func TestStart_MaxDequeueCount(t *testing.T) {
 	<-done
 
 	require.Equal(t, expectedDequeueCount+2, testMessage.DequeueCount)
+
+	deadLetters := tCtx.internalQ.DeadLetters()
+	require.Len(t, deadLetters, 1, "the message must be moved to the dead-letter queue")
+	require.Equal(t, "exceeded max retry count to process async operation message: 4", deadLetters[0].LastError)
+	require.Equal(t, testMessage.Data, deadLetters[0].Data)
 }
 
 // TestStart_MaxDequeueCount_AlreadyTerminal verifies that when the retry count is exceeded but the
-// operation was already completed on a prior attempt (terminal status), the worker just finishes the
-// message and does NOT overwrite the recorded status with a generic "exceeded max retry count" error.
+// operation was already completed on a prior attempt (terminal status), the worker just moves the
+// message to the dead-letter queue and does NOT overwrite the recorded status with a generic
+// "exceeded max retry count" error.
 func TestStart_MaxDequeueCount_AlreadyTerminal(t *testing.T) {
 	tCtx, mctrl := newTestContext(t, 1*time.Minute)
 	defer mctrl.Finish()
@@ -275,10 +284,14 @@ func TestStart_MaxDequeueCount_AlreadyTerminal(t *testing.T) {
 	// The operation is already terminal (Failed) from a prior attempt.
 	tCtx.mockSM.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(&manager.Status{
-			AsyncOperationStatus: v1.AsyncOperationStatus{Status: v1.ProvisioningStateFailed},
+			AsyncOperationStatus: v1.AsyncOperationStatus{
+				Status: v1.ProvisioningStateFailed,
+				Error:  &v1.ErrorDetails{Code: v1.CodeInternal, Message: "recorded failure"},
+			},
 		}, nil).AnyTimes()
 	// No Update must happen: the recorded terminal status must not be overwritten.
 	tCtx.mockSM.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tCtx.mockSM.EXPECT().MarkDeadLettered(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
 	expectedDequeueCount := 2
 
@@ -317,7 +330,100 @@ func TestStart_MaxDequeueCount_AlreadyTerminal(t *testing.T) {
 	cancel()
 	<-done
 
-	require.Equal(t, 0, tCtx.internalQ.Len(), "message should be finished without overwriting the terminal status")
+	require.Equal(t, 0, tCtx.internalQ.Len(), "message should be dead-lettered without overwriting the terminal status")
+	deadLetters := tCtx.internalQ.DeadLetters()
+	require.Len(t, deadLetters, 1)
+	require.Equal(t, "recorded failure", deadLetters[0].LastError)
+}
+
+// TestStart_MaxDequeueCount_AlreadySucceeded verifies that when the retry count is exceeded but the
+// operation already succeeded on a prior attempt, the worker finishes the message instead of moving it
+// to the dead-letter queue, so that a successful operation is never replayed.
+func TestStart_MaxDequeueCount_AlreadySucceeded(t *testing.T) {
+	tCtx, mctrl := newTestContext(t, 1*time.Minute)
+	defer mctrl.Finish()
+
+	tCtx.mockSC.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, _ ...database.GetOptions) (*database.Object, error) {
+			return newTestResourceObject(), nil
+		}).AnyTimes()
+	// The operation succeeded on a prior attempt, but its message could not be finished.
+	tCtx.mockSM.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&manager.Status{
+			AsyncOperationStatus: v1.AsyncOperationStatus{Status: v1.ProvisioningStateSucceeded},
+		}, nil).AnyTimes()
+	tCtx.mockSM.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tCtx.mockSM.EXPECT().MarkDeadLettered(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	expectedDequeueCount := 2
+
+	registry := NewControllerRegistry()
+	worker := New(Options{MaxOperationRetryCount: expectedDequeueCount, DequeueIntervalDuration: defaultTestDequeueInterval}, tCtx.mockSM, tCtx.testQueue, registry)
+
+	called := &atomic.Bool{}
+	testCtrl := &testAsyncController{
+		BaseController: ctrl.NewBaseAsyncController(ctrl.Options{DatabaseClient: tCtx.mockSC}),
+		fn: func(ctx context.Context) (ctrl.Result, error) {
+			called.Store(true)
+			return ctrl.Result{}, nil
+		},
+	}
+
+	ctx, cancel := tCtx.cancellable(0)
+	err := registry.Register(
+		testResourceType, v1.OperationPut,
+		func(opts ctrl.Options) (ctrl.Controller, error) {
+			return testCtrl, nil
+		}, ctrl.Options{DatabaseClient: tCtx.mockSC})
+	require.NoError(t, err)
+
+	testMessage := genTestMessage(uuid.New(), ctrl.DefaultAsyncOperationTimeout)
+	err = tCtx.testQueue.Enqueue(ctx, testMessage)
+	require.NoError(t, err)
+	testMessage.DequeueCount = expectedDequeueCount + 1
+
+	done := make(chan struct{}, 1)
+	go func() {
+		err = worker.Start(ctx)
+		require.NoError(t, err)
+		close(done)
+	}()
+
+	tCtx.drainQueueOrAssert(t)
+
+	cancel()
+	<-done
+
+	require.Equal(t, 0, tCtx.internalQ.Len(), "message should be finished")
+	require.Empty(t, tCtx.internalQ.DeadLetters(), "a succeeded operation must not be dead-lettered")
+	require.False(t, called.Load())
+}
+
+// failingDeadLetterClient is a queue client whose dead-letter queue cannot be written.
+type failingDeadLetterClient struct {
+	*inmemory.Client
+}
+
+func (c *failingDeadLetterClient) DeadLetter(ctx context.Context, msg *queue.Message, lastError string) error {
+	return errors.New("dead-letter queue is unavailable")
+}
+
+// TestDeadLetterMessage_DeadLetterFails verifies that the operation status is not marked as
+// dead-lettered when the message cannot be moved to the dead-letter queue.
+func TestDeadLetterMessage_DeadLetterFails(t *testing.T) {
+	tCtx, mctrl := newTestContext(t, 1*time.Minute)
+	defer mctrl.Finish()
+
+	tCtx.mockSM.EXPECT().MarkDeadLettered(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	worker := New(Options{}, tCtx.mockSM, &failingDeadLetterClient{Client: tCtx.testQueue}, NewControllerRegistry())
+
+	testMessage := genTestMessage(uuid.New(), ctrl.DefaultAsyncOperationTimeout)
+	req := &ctrl.Request{}
+	require.NoError(t, json.Unmarshal(testMessage.Data, req))
+
+	worker.deadLetterMessage(tCtx.ctx, testMessage, req, "failed")
+	require.Empty(t, tCtx.internalQ.DeadLetters())
 }
 
 func TestStart_MaxConcurrency(t *testing.T) {
@@ -503,6 +609,44 @@ func TestRunOperation_Successfully(t *testing.T) {
 	require.Equal(t, 0, tCtx.internalQ.Len(), "message is finished")
 }
 
+func TestRunOperation_Output(t *testing.T) {
+	tCtx, mctrl := newTestContext(t, defaultTestLockTime)
+	defer mctrl.Finish()
+
+	// A recipe plan doesn't change the resource: neither its provisioningState nor its history are saved, so the
+	// database is not used.
+	output := map[string]any{"changes": []any{}}
+	setOutput := tCtx.mockSM.EXPECT().SetOutput(gomock.Any(), gomock.Any(), gomock.Any(), output).Return(nil)
+	tCtx.mockSM.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), v1.ProvisioningStateSucceeded, gomock.Any(), gomock.Any()).
+		Return(nil).
+		After(setOutput.Call)
+
+	timeout := ctrl.DefaultAsyncOperationTimeout
+	testMessage := queue.NewMessage(&ctrl.Request{
+		OperationID:      uuid.New(),
+		OperationType:    "APPLICATIONS.CORE/EXTENDERS|RECIPEPLAN",
+		ResourceID:       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/radius-test-rg/providers/Applications.Core/extenders/ext",
+		CorrelationID:    uuid.NewString(),
+		OperationTimeout: &timeout,
+	})
+	err := tCtx.testQueue.Enqueue(tCtx.ctx, testMessage)
+	require.NoError(t, err)
+	worker := New(Options{}, tCtx.mockSM, tCtx.testQueue, nil)
+
+	testCtrl := &testAsyncController{
+		BaseController: ctrl.NewBaseAsyncController(ctrl.Options{DatabaseClient: tCtx.mockSC}),
+		fn: func(ctx context.Context) (ctrl.Result, error) {
+			return ctrl.Result{Output: output}, nil
+		},
+	}
+
+	msg, err := tCtx.testQueue.Dequeue(tCtx.ctx, queue.QueueClientConfig{})
+	require.NoError(t, err)
+	worker.runOperation(t.Context(), msg, testCtrl)
+
+	require.Equal(t, 0, tCtx.internalQ.Len(), "message is finished")
+}
+
 func TestRunOperation_ExtendMessageLock(t *testing.T) {
 	tCtx, mctrl := newTestContext(t, defaultTestLockTime)
 	defer mctrl.Finish()
@@ -514,6 +658,8 @@ func TestRunOperation_ExtendMessageLock(t *testing.T) {
 		}).AnyTimes()
 	tCtx.mockSC.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	tCtx.mockSM.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	// The operation runs longer than the cancellation poll interval.
+	tCtx.mockSM.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(testOperationStatus, nil).AnyTimes()
 
 	testMessage := genTestMessage(uuid.New(), ctrl.DefaultAsyncOperationTimeout)
 	err := tCtx.testQueue.Enqueue(tCtx.ctx, testMessage)
@@ -549,6 +695,50 @@ func TestRunOperation_ExtendMessageLock(t *testing.T) {
 	require.Greater(t, msg.NextVisibleAt.UnixNano(), old.UnixNano(), "message lock is extended")
 }
 
+func TestRunOperation_UserCanceled(t *testing.T) {
+	tCtx, mctrl := newTestContext(t, defaultTestLockTime)
+	defer mctrl.Finish()
+
+	// set up mocks
+	tCtx.mockSC.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, _ ...database.GetOptions) (*database.Object, error) {
+			return newTestResourceObject(), nil
+		}).AnyTimes()
+	tCtx.mockSC.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	tCtx.mockSM.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(&manager.Status{
+		AsyncOperationStatus: v1.AsyncOperationStatus{Status: v1.ProvisioningStateUpdating},
+		CancelRequested:      true,
+	}, nil).AnyTimes()
+	tCtx.mockSM.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(v1.ProvisioningStateCanceled), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id resources.ID, operationID uuid.UUID, state v1.ProvisioningState, endTime *time.Time, opError *v1.ErrorDetails) error {
+			require.Contains(t, opError.Message, "was canceled by the user")
+			return nil
+		}).Times(1)
+
+	testMessage := genTestMessage(uuid.New(), ctrl.DefaultAsyncOperationTimeout)
+	err := tCtx.testQueue.Enqueue(tCtx.ctx, testMessage)
+	require.NoError(t, err)
+
+	worker := New(Options{CancellationPollInterval: 10 * time.Millisecond}, tCtx.mockSM, tCtx.testQueue, nil)
+
+	controllerCanceled := make(chan struct{})
+	testCtrl := &testAsyncController{
+		BaseController: ctrl.NewBaseAsyncController(ctrl.Options{DatabaseClient: tCtx.mockSC}),
+		fn: func(ctx context.Context) (ctrl.Result, error) {
+			<-ctx.Done()
+			close(controllerCanceled)
+			return ctrl.Result{}, ctx.Err()
+		},
+	}
+
+	msg, err := tCtx.testQueue.Dequeue(tCtx.ctx, queue.QueueClientConfig{})
+	require.NoError(t, err)
+	worker.runOperation(t.Context(), msg, testCtrl)
+
+	<-controllerCanceled
+	require.Equal(t, 0, tCtx.internalQ.Len(), "message is finished")
+}
+
 func TestRunOperation_CancelContext(t *testing.T) {
 	tCtx, _ := newTestContext(t, defaultTestLockTime)
 
@@ -685,7 +875,7 @@ func TestRunOperation_PanicController_Requeues(t *testing.T) {
 // TestRunOperation_PanicController_FinalAttempt verifies that when a controller panics on the final
 // retry, the operation is completed as Failed with the real panic reason (instead of being retried
 // to exhaustion and reported with a generic "exceeded max retry count" message), and the message is
-// finished.
+// moved to the dead-letter queue.
 func TestRunOperation_PanicController_FinalAttempt(t *testing.T) {
 	tCtx, mctrl := newTestContext(t, defaultTestLockTime)
 	defer mctrl.Finish()
@@ -707,6 +897,7 @@ func TestRunOperation_PanicController_FinalAttempt(t *testing.T) {
 			terminalErr = opErr
 			return nil
 		}).Times(1)
+	tCtx.mockSM.EXPECT().MarkDeadLettered(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
 	// MaxOperationRetryCount == 1 makes the single delivery (DequeueCount == 1) the final attempt.
 	worker := New(Options{MaxOperationRetryCount: 1}, tCtx.mockSM, tCtx.testQueue, nil)
@@ -732,8 +923,12 @@ func TestRunOperation_PanicController_FinalAttempt(t *testing.T) {
 		worker.runOperation(tCtx.ctx, msg, testCtrl)
 	})
 
-	require.Equal(t, 0, tCtx.internalQ.Len(), "ensure that the message is finished on the final attempt")
+	require.Equal(t, 0, tCtx.internalQ.Len(), "ensure that the message is dead-lettered on the final attempt")
 	require.NotNil(t, terminalErr, "the panic reason must be recorded as the terminal failure")
 	require.Equal(t, v1.CodeInternal, terminalErr.Code)
 	require.Contains(t, terminalErr.Message, "don't panic")
+
+	deadLetters := tCtx.internalQ.DeadLetters()
+	require.Len(t, deadLetters, 1)
+	require.Equal(t, terminalErr.Message, deadLetters[0].LastError)
 }
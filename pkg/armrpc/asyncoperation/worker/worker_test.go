@@ -207,4 +207,16 @@ func TestIsDuplicated(t *testing.T) {
 		require.NoError(t, err)
 		require.True(t, dup)
 	})
+
+	t.Run("replayed dead-letter status is reset and not duplicated", func(t *testing.T) {
+		sm.EXPECT().Get(gomock.Any(), rID, opID).Return(&manager.Status{
+			AsyncOperationStatus: v1.AsyncOperationStatus{Status: v1.ProvisioningStateFailed},
+			DeadLettered:         true,
+		}, nil)
+		sm.EXPECT().Reset(gomock.Any(), rID, opID).Return(nil)
+
+		dup, err := worker.isDuplicated(t.Context(), resourceID, opID)
+		require.NoError(t, err)
+		require.False(t, dup)
+	})
 }
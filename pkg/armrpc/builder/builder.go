@@ -73,6 +73,22 @@ func defaultHandlerOptions(
 		ControllerFactory: defaultoperation.NewGetOperationStatus,
 	})
 
+	handlers = append(handlers, server.HandlerOptions{
+		ParentRouter:      rootRouter,
+		Path:              fmt.Sprintf("%s/providers/%s/locations/{location}/operationstatuses", rootScopePath, namespace),
+		ResourceType:      statusType,
+		Method:            v1.OperationList,
+		ControllerFactory: defaultoperation.NewListOperationStatuses,
+	})
+
+	handlers = append(handlers, server.HandlerOptions{
+		ParentRouter:      rootRouter,
+		Path:              fmt.Sprintf("%s/providers/%s/locations/{location}/operationstatuses/{operationId}/%s", rootScopePath, namespace, defaultoperation.CancelOperationAction),
+		ResourceType:      statusType,
+		Method:            v1.OperationCancel,
+		ControllerFactory: defaultoperation.NewCancelOperation,
+	})
+
 	handlers = append(handlers, server.HandlerOptions{
 		ParentRouter:      rootRouter,
 		Path:              fmt.Sprintf("%s/providers/%s/locations/{location}/operationresults/{operationId}", rootScopePath, namespace),
@@ -146,13 +162,32 @@ func (b *Builder) ApplyAPIHandlers(ctx context.Context, r chi.Router, ctrlOpts a
 			routerMap[key] = server.NewSubrouter(r, route, middlewares...)
 		}
 
+		// The recipe plan action is registered on the root router because it is not part of the OpenAPI spec
+		// of the resource type.
+		parentRouter, path := routerMap[key], strings.ToLower(h.Path)
+		if h.Method == v1.OperationRecipePlan {
+			parentRouter, path = r, route+path
+		}
+
 		handlerOptions = append(handlerOptions, server.HandlerOptions{
-			ParentRouter:      routerMap[key],
-			Path:              strings.ToLower(h.Path),
+			ParentRouter:      parentRouter,
+			Path:              path,
 			ResourceType:      h.ResourceType,
 			Method:            h.Method,
 			ControllerFactory: h.APIController,
 		})
+
+		// Every resource that can be read also exposes its operation history. The history route is registered on
+		// the root router because it is not part of the OpenAPI spec of the resource type.
+		if h.Method == v1.OperationGet {
+			handlerOptions = append(handlerOptions, server.HandlerOptions{
+				ParentRouter:      r,
+				Path:              route + strings.ToLower(h.Path) + defaultoperation.HistoryPath,
+				ResourceType:      h.ResourceType,
+				Method:            v1.OperationGetHistory,
+				ControllerFactory: defaultoperation.NewGetResourceHistory,
+			})
+		}
 	}
 
 	for _, o := range handlerOptions {
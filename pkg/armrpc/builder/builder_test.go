@@ -47,6 +47,10 @@ var handlerTests = []rpctest.HandlerTestSpec{
 		OperationType: v1.OperationType{Type: "Applications.Compute/virtualMachines", Method: v1.OperationGet},
 		Path:          "/resourcegroups/testrg/providers/applications.compute/virtualmachines/vm0",
 		Method:        http.MethodGet,
+	}, {
+		OperationType: v1.OperationType{Type: "Applications.Compute/virtualMachines", Method: v1.OperationGetHistory},
+		Path:          "/resourcegroups/testrg/providers/applications.compute/virtualmachines/vm0/history",
+		Method:        http.MethodGet,
 	}, {
 		OperationType: v1.OperationType{Type: "Applications.Compute/virtualMachines", Method: v1.OperationPut},
 		Path:          "/resourcegroups/testrg/providers/applications.compute/virtualmachines/vm0",
@@ -67,6 +71,10 @@ var handlerTests = []rpctest.HandlerTestSpec{
 		OperationType: v1.OperationType{Type: "Applications.Compute/virtualMachines", Method: "ACTIONSTOP"},
 		Path:          "/resourcegroups/testrg/providers/applications.compute/virtualmachines/vm0/stop",
 		Method:        http.MethodPost,
+	}, {
+		OperationType: v1.OperationType{Type: "Applications.Compute/virtualMachines", Method: v1.OperationRecipePlan},
+		Path:          "/resourcegroups/testrg/providers/applications.compute/virtualmachines/vm0/recipes/plan",
+		Method:        http.MethodPost,
 	},
 	// applications.compute/containers
 	{
@@ -159,6 +167,14 @@ var defaultHandlerTests = []rpctest.HandlerTestSpec{
 		OperationType: v1.OperationType{Type: "Applications.Compute/operationStatuses", Method: v1.OperationGet},
 		Path:          "/providers/applications.compute/locations/global/operationstatuses/00000000-0000-0000-0000-000000000000",
 		Method:        http.MethodGet,
+	}, {
+		OperationType: v1.OperationType{Type: "Applications.Compute/operationStatuses", Method: v1.OperationList},
+		Path:          "/providers/applications.compute/locations/global/operationstatuses",
+		Method:        http.MethodGet,
+	}, {
+		OperationType: v1.OperationType{Type: "Applications.Compute/operationStatuses", Method: v1.OperationCancel},
+		Path:          "/providers/applications.compute/locations/global/operationstatuses/00000000-0000-0000-0000-000000000000/cancel",
+		Method:        http.MethodPost,
 	}, {
 		OperationType: v1.OperationType{Type: "Applications.Compute/operationResults", Method: v1.OperationGet},
 		Path:          "/providers/applications.compute/locations/global/operationresults/00000000-0000-0000-0000-000000000000",
@@ -93,6 +93,9 @@ func newTestNamespace(t *testing.T) *Namespace {
 				APIController: newTestController,
 			},
 		},
+		RecipePlan: Operation[rpctest.TestResourceDataModel]{
+			APIController: newTestController,
+		},
 	})
 
 	require.NotNil(t, vmResource)
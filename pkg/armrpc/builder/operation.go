@@ -26,6 +26,7 @@ import (
 	"github.com/radius-project/radius/pkg/armrpc/frontend/controller"
 	"github.com/radius-project/radius/pkg/armrpc/frontend/defaultoperation"
 	"github.com/radius-project/radius/pkg/armrpc/frontend/server"
+	"github.com/radius-project/radius/pkg/recipes"
 )
 
 const customActionPrefix = "ACTION"
@@ -96,6 +97,11 @@ type ResourceOption[P interface {
 
 	// Custom defines the custom actions.
 	Custom map[string]Operation[T]
+
+	// RecipePlan defines the operation for planning the recipe of a resource. It is registered only when
+	// APIController is set, since only the resource types that support recipes can be planned. The plan runs
+	// in AsyncJobController.
+	RecipePlan Operation[T]
 }
 
 // LinkResource links the resource node to the resource option.
@@ -127,6 +133,7 @@ func (r *ResourceOption[P, T]) BuildHandlerOutputs(opts BuildOptions) []*Operati
 		r.putOutput,
 		r.patchOutput,
 		r.deleteOutput,
+		r.recipePlanOutput,
 	}
 
 	hs := []*OperationRegistration{}
@@ -344,6 +351,21 @@ func (r *ResourceOption[P, T]) deleteOutput(opts BuildOptions) *OperationRegistr
 	return h
 }
 
+func (r *ResourceOption[P, T]) recipePlanOutput(opts BuildOptions) *OperationRegistration {
+	if r.RecipePlan.Disabled || r.RecipePlan.APIController == nil {
+		return nil
+	}
+
+	return &OperationRegistration{
+		ResourceType:        opts.ResourceType,
+		ResourceNamePattern: opts.ResourceNamePattern + "/" + opts.ParameterName,
+		Path:                "/" + recipes.PlanAction,
+		Method:              v1.OperationRecipePlan,
+		APIController:       r.RecipePlan.APIController,
+		AsyncController:     r.RecipePlan.AsyncJobController,
+	}
+}
+
 func (r *ResourceOption[P, T]) customActionOutputs(opts BuildOptions) []*OperationRegistration {
 	handlers := []*OperationRegistration{}
 
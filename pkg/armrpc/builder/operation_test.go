@@ -453,6 +453,41 @@ func TestResourceOption_DeleteOutput(t *testing.T) {
 	})
 }
 
+func TestResourceOption_RecipePlanOutput(t *testing.T) {
+	node := &ResourceNode{Name: "virtualMachines", Kind: TrackedResourceKind}
+
+	t.Run("no controller", func(t *testing.T) {
+		option := &ResourceOption[*rpctest.TestResourceDataModel, rpctest.TestResourceDataModel]{
+			linkedNode: node,
+		}
+		require.Nil(t, option.recipePlanOutput(testBuildOptionsWithName))
+	})
+
+	t.Run("custom controller", func(t *testing.T) {
+		option := &ResourceOption[*rpctest.TestResourceDataModel, rpctest.TestResourceDataModel]{
+			linkedNode: node,
+			RecipePlan: Operation[rpctest.TestResourceDataModel]{
+				APIController: func(opt controller.Options) (controller.Controller, error) {
+					return nil, errors.New("ok")
+				},
+				AsyncJobController: func(opts asyncctrl.Options) (asyncctrl.Controller, error) {
+					return nil, errors.New("async ok")
+				},
+			},
+		}
+		h := option.recipePlanOutput(testBuildOptionsWithName)
+		require.NotNil(t, h)
+		_, err := h.APIController(controller.Options{})
+		require.EqualError(t, err, "ok")
+		_, err = h.AsyncController(asyncctrl.Options{})
+		require.EqualError(t, err, "async ok")
+		require.Equal(t, v1.OperationRecipePlan, h.Method)
+		require.Equal(t, "Applications.Compute/virtualMachines", h.ResourceType)
+		require.Equal(t, "applications.compute/virtualmachines/{virtualMachineName}", h.ResourceNamePattern)
+		require.Equal(t, "/recipes/plan", h.Path)
+	})
+}
+
 func TestResourceOption_CustomActionOutput(t *testing.T) {
 	node := &ResourceNode{Name: "virtualMachines", Kind: TrackedResourceKind}
 	t.Run("valid custom action", func(t *testing.T) {
@@ -171,10 +171,7 @@ func (c *Operation[P, T]) PrepareAsyncOperation(ctx context.Context, newResource
 
 	options := sm.QueueOperationOptions{
 		OperationTimeout: asyncTimeout,
-		RetryAfter:       v1.DefaultRetryAfterDuration,
-	}
-	if c.resourceOptions.AsyncOperationRetryAfter != 0 {
-		options.RetryAfter = c.resourceOptions.AsyncOperationRetryAfter
+		RetryAfter:       c.AsyncOperationRetryAfter(),
 	}
 
 	if err := c.StatusManager().QueueAsyncOperation(ctx, serviceCtx, options); err != nil {
@@ -218,9 +215,7 @@ func (c *Operation[P, T]) ConstructAsyncResponse(ctx context.Context, method, et
 	}
 
 	response := rest.NewAsyncOperationResponse(versioned, serviceCtx.Location, respCode, serviceCtx.ResourceID, serviceCtx.OperationID, serviceCtx.APIVersion, "", "")
-	if c.resourceOptions.AsyncOperationRetryAfter != 0 {
-		response.RetryAfter = c.resourceOptions.AsyncOperationRetryAfter
-	}
+	response.RetryAfter = c.AsyncOperationRetryAfter()
 	return response, nil
 }
 
@@ -251,3 +246,11 @@ func (b *Operation[P, T]) AsyncOperationTimeout() time.Duration {
 	}
 	return b.resourceOptions.AsyncOperationTimeout
 }
+
+// AsyncOperationRetryAfter returns the value of the Retry-After header for the operation.
+func (b *Operation[P, T]) AsyncOperationRetryAfter() time.Duration {
+	if b.resourceOptions.AsyncOperationRetryAfter == 0 {
+		return v1.DefaultRetryAfterDuration
+	}
+	return b.resourceOptions.AsyncOperationRetryAfter
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/components/database"
+)
+
+// ListQueryOptions returns the filters and the options of the database query of a list operation, from the
+// $filter, $orderby, top and skipToken query parameters of the request.
+func ListQueryOptions(serviceCtx *v1.ARMRequestContext) ([]database.QueryFilter, []database.QueryOptions, error) {
+	filters, err := ParseFilter(serviceCtx.Filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	order, err := ParseOrderBy(serviceCtx.OrderBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	options := []database.QueryOptions{
+		database.WithPaginationToken(serviceCtx.SkipToken),
+		database.WithMaxQueryItemCount(serviceCtx.Top),
+		database.WithOrderBy(order),
+	}
+
+	return filters, options, nil
+}
+
+// ParseFilter parses the subset of the OData $filter syntax supported by list operations. The filter is a list of
+// conditions joined by 'and':
+//
+//	properties/application eq 'app'
+//	properties/status ne 'Failed'
+//	startswith(name, 'prod-')
+//	properties/environment in ('env1', 'env2')
+//	properties/recipe ne null
+//
+// Properties are compared as strings, and nested properties are separated by '/'.
+func ParseFilter(filter string) ([]database.QueryFilter, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid $filter: %w", err)
+	}
+
+	p := &filterParser{tokens: tokens}
+	filters := []database.QueryFilter{}
+	for {
+		f, err := p.condition()
+		if err != nil {
+			return nil, fmt.Errorf("invalid $filter: %w", err)
+		}
+
+		if err := f.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid $filter: property %q is not supported", f.Field)
+		}
+		filters = append(filters, f)
+
+		if p.done() {
+			return filters, nil
+		}
+
+		if !p.keyword("and") {
+			return nil, fmt.Errorf("invalid $filter: expected 'and' but found %q", p.peek().text)
+		}
+	}
+}
+
+// ParseOrderBy parses the OData $orderby syntax supported by list operations: a single property, optionally
+// followed by 'asc' or 'desc'.
+func ParseOrderBy(orderBy string) (database.QueryOrder, error) {
+	parts := strings.Fields(orderBy)
+	if len(parts) == 0 {
+		return database.QueryOrder{}, nil
+	}
+
+	order := database.QueryOrder{Field: strings.ReplaceAll(parts[0], "/", ".")}
+	if len(parts) > 2 {
+		return database.QueryOrder{}, fmt.Errorf("invalid $orderby: only one property is supported")
+	} else if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			order.Descending = true
+		default:
+			return database.QueryOrder{}, fmt.Errorf("invalid $orderby: expected 'asc' or 'desc' but found %q", parts[1])
+		}
+	}
+
+	if err := order.Validate(); err != nil {
+		return database.QueryOrder{}, fmt.Errorf("invalid $orderby: property %q is not supported", parts[0])
+	}
+
+	return order, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenName
+	tokenString
+	tokenPunctuation
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize splits filter into names (properties, operators and keywords), quoted strings and punctuation.
+func tokenize(filter string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(filter)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, token{kind: tokenPunctuation, text: string(r)})
+			i++
+		case r == '\'':
+			// Quotes are escaped by doubling them.
+			value := strings.Builder{}
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, errors.New("unterminated string")
+				}
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						value.WriteRune('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				value.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value.String()})
+		case isNameRune(r):
+			start := i
+			for i < len(runes) && isNameRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenName, text: string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+
+	return tokens, nil
+}
+
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$' || r == '/' || r == '.'
+}
+
+type filterParser struct {
+	tokens []token
+	next   int
+}
+
+func (p *filterParser) done() bool {
+	return p.next >= len(p.tokens)
+}
+
+func (p *filterParser) peek() token {
+	if p.done() {
+		return token{kind: tokenEnd, text: "end of filter"}
+	}
+	return p.tokens[p.next]
+}
+
+func (p *filterParser) take() token {
+	t := p.peek()
+	if !p.done() {
+		p.next++
+	}
+	return t
+}
+
+// keyword consumes the next token if it is the case-insensitive keyword.
+func (p *filterParser) keyword(keyword string) bool {
+	if t := p.peek(); t.kind == tokenName && strings.EqualFold(t.text, keyword) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) expect(kind tokenKind, text string, description string) (token, error) {
+	t := p.take()
+	if t.kind != kind || (text != "" && t.text != text) {
+		return token{}, fmt.Errorf("expected %s but found %q", description, t.text)
+	}
+	return t, nil
+}
+
+func (p *filterParser) field() (string, error) {
+	t, err := p.expect(tokenName, "", "a property")
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(t.text, "/", "."), nil
+}
+
+func (p *filterParser) condition() (database.QueryFilter, error) {
+	if p.keyword("startswith") {
+		if _, err := p.expect(tokenPunctuation, "(", "'('"); err != nil {
+			return database.QueryFilter{}, err
+		}
+		field, err := p.field()
+		if err != nil {
+			return database.QueryFilter{}, err
+		}
+		if _, err := p.expect(tokenPunctuation, ",", "','"); err != nil {
+			return database.QueryFilter{}, err
+		}
+		value, err := p.expect(tokenString, "", "a string")
+		if err != nil {
+			return database.QueryFilter{}, err
+		}
+		if _, err := p.expect(tokenPunctuation, ")", "')'"); err != nil {
+			return database.QueryFilter{}, err
+		}
+		return database.QueryFilter{Field: field, Operator: database.FilterOperatorPrefix, Value: value.text}, nil
+	}
+
+	field, err := p.field()
+	if err != nil {
+		return database.QueryFilter{}, err
+	}
+
+	switch {
+	case p.keyword("eq"):
+		value, err := p.expect(tokenString, "", "a string")
+		if err != nil {
+			return database.QueryFilter{}, err
+		}
+		return database.QueryFilter{Field: field, Operator: database.FilterOperatorEqual, Value: value.text}, nil
+
+	case p.keyword("ne"):
+		if p.keyword("null") {
+			return database.QueryFilter{Field: field, Operator: database.FilterOperatorExists}, nil
+		}
+		value, err := p.expect(tokenString, "", "a string or null")
+		if err != nil {
+			return database.QueryFilter{}, err
+		}
+		return database.QueryFilter{Field: field, Operator: database.FilterOperatorNotEqual, Value: value.text}, nil
+
+	case p.keyword("in"):
+		if _, err := p.expect(tokenPunctuation, "(", "'('"); err != nil {
+			return database.QueryFilter{}, err
+		}
+		values := []string{}
+		for {
+			value, err := p.expect(tokenString, "", "a string")
+			if err != nil {
+				return database.QueryFilter{}, err
+			}
+			values = append(values, value.text)
+
+			t, err := p.expect(tokenPunctuation, "", "',' or ')'")
+			if err != nil {
+				return database.QueryFilter{}, err
+			} else if t.text == ")" {
+				break
+			} else if t.text != "," {
+				return database.QueryFilter{}, fmt.Errorf("expected ',' or ')' but found %q", t.text)
+			}
+		}
+		return database.QueryFilter{Field: field, Operator: database.FilterOperatorIn, Values: values}, nil
+
+	default:
+		return database.QueryFilter{}, fmt.Errorf("expected 'eq', 'ne' or 'in' but found %q", p.peek().text)
+	}
+}
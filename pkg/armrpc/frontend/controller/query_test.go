@@ -0,0 +1,185 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		expected []database.QueryFilter
+		err      string
+	}{
+		{
+			name:     "empty",
+			filter:   "  ",
+			expected: nil,
+		},
+		{
+			name:     "equal",
+			filter:   "properties/application eq 'my-app'",
+			expected: []database.QueryFilter{{Field: "properties.application", Operator: database.FilterOperatorEqual, Value: "my-app"}},
+		},
+		{
+			name:     "not equal with escaped quote",
+			filter:   "name NE 'it''s'",
+			expected: []database.QueryFilter{{Field: "name", Operator: database.FilterOperatorNotEqual, Value: "it's"}},
+		},
+		{
+			name:     "not null",
+			filter:   "properties.recipe ne null",
+			expected: []database.QueryFilter{{Field: "properties.recipe", Operator: database.FilterOperatorExists}},
+		},
+		{
+			name:     "startswith",
+			filter:   "startswith(name, 'prod-')",
+			expected: []database.QueryFilter{{Field: "name", Operator: database.FilterOperatorPrefix, Value: "prod-"}},
+		},
+		{
+			name:   "in and",
+			filter: "properties/environment in ('env1', 'env2') and name eq 'web'",
+			expected: []database.QueryFilter{
+				{Field: "properties.environment", Operator: database.FilterOperatorIn, Values: []string{"env1", "env2"}},
+				{Field: "name", Operator: database.FilterOperatorEqual, Value: "web"},
+			},
+		},
+		{
+			name:   "unterminated string",
+			filter: "name eq 'web",
+			err:    "invalid $filter: unterminated string",
+		},
+		{
+			name:   "unsupported operator",
+			filter: "name gt 'web'",
+			err:    "invalid $filter: expected 'eq', 'ne' or 'in' but found \"gt\"",
+		},
+		{
+			name:   "or is not supported",
+			filter: "name eq 'web' or name eq 'api'",
+			err:    "invalid $filter: expected 'and' but found \"or\"",
+		},
+		{
+			name:   "missing value",
+			filter: "name eq",
+			err:    "invalid $filter: expected a string but found \"end of filter\"",
+		},
+		{
+			name:   "invalid property",
+			filter: "properties//application eq 'app'",
+			err:    "invalid $filter: property \"properties..application\" is not supported",
+		},
+		{
+			name:   "unexpected character",
+			filter: "name eq \"web\"",
+			err:    "invalid $filter: unexpected character '\"'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := ParseFilter(tt.filter)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, filters)
+		})
+	}
+}
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		orderBy  string
+		expected database.QueryOrder
+		err      string
+	}{
+		{
+			name:     "empty",
+			orderBy:  "",
+			expected: database.QueryOrder{},
+		},
+		{
+			name:     "ascending by default",
+			orderBy:  "properties/application",
+			expected: database.QueryOrder{Field: "properties.application"},
+		},
+		{
+			name:     "descending",
+			orderBy:  "name DESC",
+			expected: database.QueryOrder{Field: "name", Descending: true},
+		},
+		{
+			name:    "multiple properties",
+			orderBy: "name asc, type desc",
+			err:     "invalid $orderby: only one property is supported",
+		},
+		{
+			name:    "invalid direction",
+			orderBy: "name up",
+			err:     "invalid $orderby: expected 'asc' or 'desc' but found \"up\"",
+		},
+		{
+			name:    "invalid property",
+			orderBy: "name[0]",
+			err:     "invalid $orderby: property \"name[0]\" is not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := ParseOrderBy(tt.orderBy)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, order)
+		})
+	}
+}
+
+func TestListQueryOptions(t *testing.T) {
+	serviceCtx := &v1.ARMRequestContext{
+		Top:       5,
+		SkipToken: "token",
+		Filter:    "name eq 'web'",
+		OrderBy:   "name desc",
+	}
+
+	filters, options, err := ListQueryOptions(serviceCtx)
+	require.NoError(t, err)
+	require.Equal(t, []database.QueryFilter{{Field: "name", Operator: database.FilterOperatorEqual, Value: "web"}}, filters)
+
+	config := database.NewQueryConfig(options...)
+	require.Equal(t, 5, config.MaxQueryItemCount)
+	require.Equal(t, "token", config.PaginationToken)
+	require.Equal(t, database.QueryOrder{Field: "name", Descending: true}, config.OrderBy)
+
+	_, _, err = ListQueryOptions(&v1.ARMRequestContext{OrderBy: "name sideways"})
+	require.Error(t, err)
+}
@@ -144,5 +144,13 @@ func GetNextLinkURL(ctx context.Context, req *http.Request, paginationToken stri
 	qps.Add("skipToken", paginationToken)
 	qps.Add("top", strconv.Itoa(serviceCtx.Top))
 
+	// The pagination token is only valid with the same filter and order.
+	if serviceCtx.Filter != "" {
+		qps.Add(v1.FilterParameterName, serviceCtx.Filter)
+	}
+	if serviceCtx.OrderBy != "" {
+		qps.Add(v1.OrderByParameterName, serviceCtx.OrderBy)
+	}
+
 	return GetURLFromReqWithQueryParameters(req, qps).String()
 }
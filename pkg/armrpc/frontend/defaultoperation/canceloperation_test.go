@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultoperation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	manager "github.com/radius-project/radius/pkg/armrpc/asyncoperation/statusmanager"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	"github.com/radius-project/radius/pkg/armrpc/rpctest"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+func TestCancelOperationRun(t *testing.T) {
+	linkedID := resources.MustParse("/planes/radius/local/resourceGroups/test-rg/providers/Applications.Core/environments/env0")
+
+	tests := []struct {
+		name       string
+		status     v1.ProvisioningState
+		exists     bool
+		statusCode int
+		canceled   bool
+	}{
+		{name: "running operation", status: v1.ProvisioningStateUpdating, exists: true, statusCode: http.StatusAccepted, canceled: true},
+		{name: "queued operation", status: v1.ProvisioningStateAccepted, exists: true, statusCode: http.StatusAccepted, canceled: true},
+		{name: "completed operation", status: v1.ProvisioningStateSucceeded, exists: true, statusCode: http.StatusConflict},
+		{name: "non-existing operation", statusCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			databaseClient := inmemory.NewClient()
+			sm := manager.New(databaseClient, nil, "global")
+			operationID := uuid.New()
+			statusID := "/planes/radius/local/providers/Applications.Core/locations/global/operationStatuses/" + operationID.String()
+
+			if tt.exists {
+				err := databaseClient.Save(t.Context(), &database.Object{
+					Metadata: database.Metadata{ID: statusID},
+					Data: &manager.Status{
+						AsyncOperationStatus: v1.AsyncOperationStatus{ID: statusID, Name: operationID.String(), Status: tt.status},
+						LinkedResourceID:     linkedID.String(),
+					},
+				})
+				require.NoError(t, err)
+			}
+
+			w := httptest.NewRecorder()
+			req, err := rpctest.NewHTTPRequestWithContent(t.Context(), http.MethodPost, statusID+"/cancel", nil)
+			require.NoError(t, err)
+			ctx := rpctest.NewARMRequestContext(req)
+
+			ctl, err := NewCancelOperation(ctrl.Options{DatabaseClient: databaseClient, StatusManager: sm})
+			require.NoError(t, err)
+
+			resp, err := ctl.Run(ctx, w, req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Apply(ctx, w, req))
+			require.Equal(t, tt.statusCode, w.Result().StatusCode)
+
+			if tt.statusCode == http.StatusAccepted {
+				require.Contains(t, w.Header().Get("Location"), statusID)
+				require.NotContains(t, w.Header().Get("Location"), "/cancel")
+			}
+
+			if tt.exists {
+				status, err := sm.Get(t.Context(), linkedID, operationID)
+				require.NoError(t, err)
+				require.Equal(t, tt.canceled, status.CancelRequested)
+			}
+		})
+	}
+}
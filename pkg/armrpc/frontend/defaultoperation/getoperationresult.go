@@ -44,7 +44,8 @@ func NewGetOperationResult(opts ctrl.Options) (ctrl.Controller, error) {
 }
 
 // Run returns the response with necessary headers about the async operation - it checks if the operation is in a terminal state,
-// and if not, returns an AsyncOperationResultResponse with the Location and Retry-After headers set. If the operation is in a
+// and if not, returns an AsyncOperationResultResponse with the Location and Retry-After headers set. If the operation succeeded
+// and returned a value, like the plan of a recipe, it returns an OKResponse with the value. Otherwise, if the operation is in a
 // terminal state, it returns a NoContentResponse. If the operation is not found, it returns a NotFoundResponse. If an error occurs,
 // it returns a BadRequestResponse.
 // Spec: https://github.com/Azure/azure-resource-manager-rpc/blob/master/v1.0/async-api-reference.md#azure-asyncoperation-resource-format
@@ -75,6 +76,10 @@ func (e *GetOperationResult) Run(ctx context.Context, w http.ResponseWriter, req
 		return rest.NewAsyncOperationResultResponse(headers), nil
 	}
 
+	if os.Status == v1.ProvisioningStateSucceeded && os.Output != nil {
+		return rest.NewOKResponse(os.Output), nil
+	}
+
 	return rest.NewNoContentResponse(), nil
 }
 
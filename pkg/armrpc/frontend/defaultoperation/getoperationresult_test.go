@@ -154,4 +154,39 @@ func TestGetOperationResultRun(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("succeeded-state-with-output", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		databaseClient := database.NewMockClient(mctrl)
+
+		w := httptest.NewRecorder()
+		req, err := rpctest.NewHTTPRequestFromJSON(t.Context(), http.MethodGet, operationStatusTestHeaderFile, nil)
+		require.NoError(t, err)
+		ctx := rpctest.NewARMRequestContext(req)
+
+		status := *osDataModel
+		status.Status = v1.ProvisioningStateSucceeded
+		status.Output = map[string]any{"changes": []any{}}
+
+		databaseClient.
+			EXPECT().
+			Get(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id string, _ ...database.GetOptions) (*database.Object, error) {
+				return &database.Object{
+					Metadata: database.Metadata{ID: id},
+					Data:     &status,
+				}, nil
+			})
+
+		ctl, err := NewGetOperationResult(ctrl.Options{
+			DatabaseClient: databaseClient,
+		})
+
+		require.NoError(t, err)
+		resp, err := ctl.Run(ctx, w, req)
+		require.NoError(t, err)
+		_ = resp.Apply(ctx, w, req)
+		require.Equal(t, http.StatusOK, w.Result().StatusCode)
+		require.JSONEq(t, `{"changes": []}`, w.Body.String())
+	})
 }
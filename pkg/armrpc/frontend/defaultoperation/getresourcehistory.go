@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultoperation
+
+import (
+	"context"
+	"net/http"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	"github.com/radius-project/radius/pkg/armrpc/history"
+	"github.com/radius-project/radius/pkg/armrpc/rest"
+)
+
+// HistoryPath is the path of the operation history of a resource, relative to the resource.
+const HistoryPath = "/" + history.ResourceTypeSegment
+
+var _ ctrl.Controller = (*GetResourceHistory)(nil)
+
+// GetResourceHistory is the controller implementation to get the operation history of a resource.
+type GetResourceHistory struct {
+	ctrl.BaseController
+}
+
+// NewGetResourceHistory creates a new GetResourceHistory.
+func NewGetResourceHistory(opts ctrl.Options) (ctrl.Controller, error) {
+	return &GetResourceHistory{ctrl.NewBaseController(opts)}, nil
+}
+
+// Run returns the operation history of the resource in the order the operations completed. The history is
+// kept after the resource is deleted, so the response is empty rather than NotFound for unknown resources.
+func (e *GetResourceHistory) Run(ctx context.Context, w http.ResponseWriter, req *http.Request) (rest.Response, error) {
+	serviceCtx := v1.ARMRequestContextFromContext(ctx)
+
+	// The request URL is {resourceId}/history.
+	id := serviceCtx.ResourceID
+	if history.IsHistoryID(id) {
+		id = id.Truncate()
+	}
+	if !id.IsResource() {
+		return rest.NewBadRequestResponse("the request URL must be the history of a resource: {resourceId}" + HistoryPath), nil
+	}
+
+	entries, err := history.New(e.DatabaseClient()).List(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1.PaginatedList{Value: []any{}}
+	for _, entry := range entries {
+		list.Value = append(list.Value, entry)
+	}
+
+	return rest.NewOKResponse(list), nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultoperation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	"github.com/radius-project/radius/pkg/armrpc/history"
+	"github.com/radius-project/radius/pkg/armrpc/rpctest"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
+)
+
+func TestGetResourceHistoryRun(t *testing.T) {
+	const envID = "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Core/environments/env0"
+
+	databaseClient := inmemory.NewClient()
+	recorder := history.New(databaseClient)
+	for _, state := range []v1.ProvisioningState{v1.ProvisioningStateSucceeded, v1.ProvisioningStateFailed} {
+		err := recorder.Record(t.Context(), &history.Entry{ResourceID: envID, OperationType: "APPLICATIONS.CORE/ENVIRONMENTS|PUT", ProvisioningState: state})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		url      string
+		code     int
+		expected []v1.ProvisioningState
+	}{
+		{name: "history", url: envID + HistoryPath, code: http.StatusOK, expected: []v1.ProvisioningState{v1.ProvisioningStateSucceeded, v1.ProvisioningStateFailed}},
+		{name: "no history", url: envID + "-other" + HistoryPath, code: http.StatusOK, expected: []v1.ProvisioningState{}},
+		{name: "not a resource", url: "/planes/radius/local/resourceGroups/test-rg", code: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := rpctest.NewHTTPRequestWithContent(t.Context(), http.MethodGet, tt.url+"?api-version=2023-10-01-preview", nil)
+			require.NoError(t, err)
+			ctx := rpctest.NewARMRequestContext(req)
+
+			ctl, err := NewGetResourceHistory(ctrl.Options{DatabaseClient: databaseClient})
+			require.NoError(t, err)
+
+			resp, err := ctl.Run(ctx, w, req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Apply(ctx, w, req))
+			require.Equal(t, tt.code, w.Result().StatusCode)
+			if tt.code != http.StatusOK {
+				return
+			}
+
+			actual := struct {
+				Value []history.Entry `json:"value"`
+			}{}
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&actual))
+
+			states := []v1.ProvisioningState{}
+			for _, entry := range actual.Value {
+				states = append(states, entry.ProvisioningState)
+			}
+			require.Equal(t, tt.expected, states)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultoperation
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	manager "github.com/radius-project/radius/pkg/armrpc/asyncoperation/statusmanager"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	"github.com/radius-project/radius/pkg/armrpc/rest"
+	"github.com/radius-project/radius/pkg/components/database"
+)
+
+// ResourceIDQueryParam is the query parameter used to select the operations of a resource.
+const ResourceIDQueryParam = "resourceId"
+
+var _ ctrl.Controller = (*ListOperationStatuses)(nil)
+
+// ListOperationStatuses is the controller implementation to list the async operations in progress.
+type ListOperationStatuses struct {
+	ctrl.BaseController
+}
+
+// NewListOperationStatuses creates a new ListOperationStatuses.
+func NewListOperationStatuses(opts ctrl.Options) (ctrl.Controller, error) {
+	return &ListOperationStatuses{ctrl.NewBaseController(opts)}, nil
+}
+
+// Run returns the statuses of the asynchronous operations that have not completed yet. When the resourceId
+// query parameter is set, only the operations of that resource are returned. This is how clients find the
+// operation to cancel for a resource.
+func (e *ListOperationStatuses) Run(ctx context.Context, w http.ResponseWriter, req *http.Request) (rest.Response, error) {
+	serviceCtx := v1.ARMRequestContextFromContext(ctx)
+	resourceID := req.URL.Query().Get(ResourceIDQueryParam)
+
+	result, err := e.DatabaseClient().Query(ctx, database.Query{
+		RootScope:    serviceCtx.ResourceID.RootScope(),
+		ResourceType: serviceCtx.ResourceID.Type(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1.PaginatedList{Value: []any{}}
+	for _, item := range result.Items {
+		os := &manager.Status{}
+		if err := item.As(os); err != nil {
+			return nil, err
+		}
+
+		if os.Status.IsTerminal() {
+			continue
+		}
+
+		// Resource IDs are case-insensitive.
+		if resourceID != "" && !strings.EqualFold(os.LinkedResourceID, resourceID) {
+			continue
+		}
+
+		list.Value = append(list.Value, os.AsyncOperationStatus)
+	}
+
+	return rest.NewOKResponse(list), nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultoperation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	manager "github.com/radius-project/radius/pkg/armrpc/asyncoperation/statusmanager"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	"github.com/radius-project/radius/pkg/armrpc/rpctest"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
+)
+
+func TestListOperationStatusesRun(t *testing.T) {
+	const (
+		collectionID = "/planes/radius/local/providers/Applications.Core/locations/global/operationStatuses"
+		env0         = "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Core/environments/env0"
+		env1         = "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Core/environments/env1"
+	)
+
+	databaseClient := inmemory.NewClient()
+	save := func(linkedID string, state v1.ProvisioningState) string {
+		operationID := uuid.New().String()
+		statusID := "/planes/radius/local/providers/applications.core/locations/global/operationstatuses/" + operationID
+		err := databaseClient.Save(t.Context(), &database.Object{
+			Metadata: database.Metadata{ID: statusID},
+			Data: &manager.Status{
+				AsyncOperationStatus: v1.AsyncOperationStatus{ID: statusID, Name: operationID, Status: state},
+				LinkedResourceID:     linkedID,
+			},
+		})
+		require.NoError(t, err)
+		return statusID
+	}
+
+	running := save(env0, v1.ProvisioningStateUpdating)
+	save(env0, v1.ProvisioningStateSucceeded)
+	other := save(env1, v1.ProvisioningStateAccepted)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "all operations in progress", expected: []string{running, other}},
+		{name: "operations of a resource", query: "?resourceId=" + strings.ToLower(env0), expected: []string{running}},
+		{name: "no operations in progress", query: "?resourceId=" + env0 + "-other", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := rpctest.NewHTTPRequestWithContent(t.Context(), http.MethodGet, collectionID+tt.query, nil)
+			require.NoError(t, err)
+			ctx := rpctest.NewARMRequestContext(req)
+
+			ctl, err := NewListOperationStatuses(ctrl.Options{DatabaseClient: databaseClient})
+			require.NoError(t, err)
+
+			resp, err := ctl.Run(ctx, w, req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Apply(ctx, w, req))
+			require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+			actual := struct {
+				Value []v1.AsyncOperationStatus `json:"value"`
+			}{}
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&actual))
+
+			ids := []string{}
+			for _, os := range actual.Value {
+				ids = append(ids, os.ID)
+			}
+			require.ElementsMatch(t, tt.expected, ids)
+		})
+	}
+}
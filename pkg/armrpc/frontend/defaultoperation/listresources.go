@@ -18,6 +18,7 @@ package defaultoperation
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
@@ -43,19 +44,28 @@ func NewListResources[P interface {
 	return &ListResources[P, T]{ctrl.NewOperation[P](opts, ctrlOpts), ctrlOpts.ListRecursiveQuery}, nil
 }
 
-// Run queries the resource data store with a given type and scope and returns the paginated resource list. An internal error
-// is returned if the query fails.
+// Run queries the resource data store with a given type and scope and returns the paginated resource list. The list can be
+// filtered and ordered with the $filter and $orderby query parameters, and a bad request is returned if they or the skip
+// token are invalid. An internal error is returned if the query fails.
 func (e *ListResources[P, T]) Run(ctx context.Context, w http.ResponseWriter, req *http.Request) (rest.Response, error) {
 	serviceCtx := v1.ARMRequestContextFromContext(ctx)
 
+	filters, options, err := ctrl.ListQueryOptions(serviceCtx)
+	if err != nil {
+		return rest.NewBadRequestResponse(err.Error()), nil
+	}
+
 	query := database.Query{
 		RootScope:      serviceCtx.ResourceID.RootScope(),
 		ResourceType:   serviceCtx.ResourceID.Type(),
 		ScopeRecursive: e.listRecursiveQuery,
+		Filters:        filters,
 	}
 
-	result, err := e.DatabaseClient().Query(ctx, query, database.WithPaginationToken(serviceCtx.SkipToken), database.WithMaxQueryItemCount(serviceCtx.Top))
-	if err != nil {
+	result, err := e.DatabaseClient().Query(ctx, query, options...)
+	if errors.Is(err, &database.ErrInvalid{}) {
+		return rest.NewBadRequestResponse(err.Error()), nil
+	} else if err != nil {
 		return nil, err
 	}
 
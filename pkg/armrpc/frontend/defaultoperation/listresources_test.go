@@ -183,4 +183,89 @@ func TestListResourcesRun(t *testing.T) {
 			}
 		})
 	}
+	t.Run("list with filter and order", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, err := rpctest.NewHTTPRequestFromJSON(ctx, http.MethodGet, resourceTestHeaderFile, nil)
+		require.NoError(t, err)
+
+		q := req.URL.Query()
+		q.Add("$top", "5")
+		q.Add("$filter", "startswith(name, 'Resource')")
+		q.Add("$orderby", "name desc")
+		req.URL.RawQuery = q.Encode()
+
+		ctx := rpctest.NewARMRequestContext(req)
+		serviceCtx := v1.ARMRequestContextFromContext(ctx)
+
+		expectedQuery := database.Query{
+			RootScope:    serviceCtx.ResourceID.RootScope(),
+			ResourceType: serviceCtx.ResourceID.Type(),
+			Filters:      []database.QueryFilter{{Field: "name", Operator: database.FilterOperatorPrefix, Value: "Resource"}},
+		}
+
+		databaseClient.
+			EXPECT().
+			Query(gomock.Any(), expectedQuery, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, query database.Query, options ...database.QueryOptions) (*database.ObjectQueryResult, error) {
+				config := database.NewQueryConfig(options...)
+				require.Equal(t, 5, config.MaxQueryItemCount)
+				require.Equal(t, database.QueryOrder{Field: "name", Descending: true}, config.OrderBy)
+
+				return &database.ObjectQueryResult{
+					Items:           []database.Object{{Metadata: database.Metadata{ID: uuid.New().String()}, Data: testResourceDataModel}},
+					PaginationToken: "nextLink",
+				}, nil
+			})
+
+		ctl, err := NewListResources(ctrl.Options{DatabaseClient: databaseClient}, ctrl.ResourceOptions[testDataModel]{ResponseConverter: resourceToVersioned})
+		require.NoError(t, err)
+		resp, err := ctl.Run(ctx, w, req)
+		require.NoError(t, err)
+		_ = resp.Apply(ctx, w, req)
+		require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+		actualOutput := &testResourceList{}
+		_ = json.Unmarshal(w.Body.Bytes(), actualOutput)
+		require.Len(t, actualOutput.Value, 1)
+		require.NotNil(t, actualOutput.NextLink)
+		require.Contains(t, *actualOutput.NextLink, "%24filter=startswith%28name%2C+%27Resource%27%29")
+		require.Contains(t, *actualOutput.NextLink, "%24orderby=name+desc")
+	})
+
+	t.Run("list with invalid filter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, err := rpctest.NewHTTPRequestFromJSON(ctx, http.MethodGet, resourceTestHeaderFile, nil)
+		require.NoError(t, err)
+
+		q := req.URL.Query()
+		q.Add("$filter", "name gt 'Resource'")
+		req.URL.RawQuery = q.Encode()
+		ctx := rpctest.NewARMRequestContext(req)
+
+		ctl, err := NewListResources(ctrl.Options{DatabaseClient: databaseClient}, ctrl.ResourceOptions[testDataModel]{ResponseConverter: resourceToVersioned})
+		require.NoError(t, err)
+		resp, err := ctl.Run(ctx, w, req)
+		require.NoError(t, err)
+		_ = resp.Apply(ctx, w, req)
+		require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("list with invalid skip token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, err := rpctest.NewHTTPRequestFromJSON(ctx, http.MethodGet, resourceTestHeaderFile, nil)
+		require.NoError(t, err)
+		ctx := rpctest.NewARMRequestContext(req)
+
+		databaseClient.
+			EXPECT().
+			Query(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, &database.ErrInvalid{Message: "invalid argument. 'PaginationToken' is invalid."})
+
+		ctl, err := NewListResources(ctrl.Options{DatabaseClient: databaseClient}, ctrl.ResourceOptions[testDataModel]{ResponseConverter: resourceToVersioned})
+		require.NoError(t, err)
+		resp, err := ctl.Run(ctx, w, req)
+		require.NoError(t, err)
+		_ = resp.Apply(ctx, w, req)
+		require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
 }
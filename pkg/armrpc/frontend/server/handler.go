@@ -28,7 +28,9 @@ import (
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
 	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
 	"github.com/radius-project/radius/pkg/armrpc/frontend/defaultoperation"
+	"github.com/radius-project/radius/pkg/armrpc/history"
 	"github.com/radius-project/radius/pkg/armrpc/rest"
+	"github.com/radius-project/radius/pkg/components/database"
 	"github.com/radius-project/radius/pkg/ucp/ucplog"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel/attribute"
@@ -112,6 +114,8 @@ func HandlerForController(controller ctrl.Controller, operationType v1.Operation
 
 		// The response may be nil in some advanced cases like proxying to another server.
 		if response != nil {
+			recordHistory(ctx, controller, rpcCtx, response)
+
 			err = response.Apply(ctx, w, req)
 			if err != nil {
 				HandleError(ctx, w, req, err)
@@ -121,6 +125,39 @@ func HandlerForController(controller ctrl.Controller, operationType v1.Operation
 	}
 }
 
+// recordHistory records a synchronous operation that changed a resource in the history of the resource.
+// Asynchronous operations are recorded by the worker when they complete. Failing to record the history
+// does not fail the operation, which has already been processed.
+func recordHistory(ctx context.Context, controller ctrl.Controller, rpcCtx *v1.ARMRequestContext, response rest.Response) {
+	if !history.Tracked(rpcCtx.OperationType.Method) || !rpcCtx.ResourceID.IsResource() {
+		return
+	}
+
+	switch response.(type) {
+	case *rest.OKResponse, *rest.CreatedResponse:
+	default:
+		// Asynchronous operations, failures, and deletions of resources that do not exist.
+		return
+	}
+
+	// Controllers embedding ctrl.BaseController expose their database client.
+	dbc, ok := controller.(interface{ DatabaseClient() database.Client })
+	if !ok || dbc.DatabaseClient() == nil {
+		return
+	}
+
+	err := history.New(dbc.DatabaseClient()).Record(ctx, &history.Entry{
+		ResourceID:        rpcCtx.ResourceID.String(),
+		OperationID:       rpcCtx.OperationID.String(),
+		OperationType:     rpcCtx.OperationType.String(),
+		Caller:            rpcCtx.Caller(),
+		ProvisioningState: v1.ProvisioningStateSucceeded,
+	})
+	if err != nil {
+		ucplog.FromContextOrDiscard(ctx).Error(err, "failed to record the operation in the resource history", "resourceID", rpcCtx.ResourceID.String())
+	}
+}
+
 // CreateHandler creates an http.Handler for the given resource type and operation method.
 func CreateHandler(ctx context.Context, resourceType string, operationMethod v1.OperationMethod, opts ctrl.Options, factory ControllerFactoryFunc) (http.HandlerFunc, error) {
 	opts.ResourceType = resourceType
@@ -251,6 +288,28 @@ func ConfigureDefaultHandlers(
 		return err
 	}
 
+	err = RegisterHandler(ctx, HandlerOptions{
+		ParentRouter:      rootRouter,
+		Path:              fmt.Sprintf("%s/providers/%s/locations/{location}/operationstatuses", rootScopePath, providerNamespace),
+		ResourceType:      statusRT,
+		Method:            v1.OperationList,
+		ControllerFactory: defaultoperation.NewListOperationStatuses,
+	}, ctrlOpts)
+	if err != nil {
+		return err
+	}
+
+	err = RegisterHandler(ctx, HandlerOptions{
+		ParentRouter:      rootRouter,
+		Path:              opStatus + "/" + defaultoperation.CancelOperationAction,
+		ResourceType:      statusRT,
+		Method:            v1.OperationCancel,
+		ControllerFactory: defaultoperation.NewCancelOperation,
+	}, ctrlOpts)
+	if err != nil {
+		return err
+	}
+
 	opResult := fmt.Sprintf("%s/providers/%s/locations/{location}/operationresults/{operationId}", rootScopePath, providerNamespace)
 	err = RegisterHandler(ctx, HandlerOptions{
 		ParentRouter:      rootRouter,
@@ -27,13 +27,16 @@ import (
 	"testing"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
 	"github.com/radius-project/radius/pkg/armrpc/asyncoperation/statusmanager"
 	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	"github.com/radius-project/radius/pkg/armrpc/history"
 	"github.com/radius-project/radius/pkg/armrpc/rest"
 	"github.com/radius-project/radius/pkg/armrpc/rpctest"
 	"github.com/radius-project/radius/pkg/components/database/inmemory"
 	"github.com/radius-project/radius/pkg/middleware"
+	"github.com/radius-project/radius/pkg/ucp/resources"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -288,3 +291,62 @@ func Test_HandlerForController_OperationType(t *testing.T) {
 
 	require.Equal(t, expectedType.String(), rCtx.OperationType.String())
 }
+
+type testWriteController struct {
+	ctrl.BaseController
+	response rest.Response
+}
+
+func (e *testWriteController) Run(ctx context.Context, w http.ResponseWriter, req *http.Request) (rest.Response, error) {
+	return e.response, nil
+}
+
+func Test_HandlerForController_RecordsHistory(t *testing.T) {
+	resourceID := resources.MustParse("/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/environments/env")
+
+	tests := []struct {
+		name     string
+		method   v1.OperationMethod
+		response rest.Response
+		recorded bool
+	}{
+		{name: "sync put", method: v1.OperationPut, response: rest.NewOKResponse(nil), recorded: true},
+		{name: "sync action", method: "RESTART", response: rest.NewOKResponse(nil), recorded: true},
+		{name: "get", method: v1.OperationGet, response: rest.NewOKResponse(nil)},
+		{name: "read action", method: "LISTSECRETS", response: rest.NewOKResponse(nil)},
+		{name: "async put", method: v1.OperationPut, response: rest.NewAsyncOperationResponse(nil, v1.LocationGlobal, http.StatusCreated, resourceID, uuid.New(), "2023-10-01-preview", "", "")},
+		{name: "failed put", method: v1.OperationPut, response: rest.NewBadRequestResponse("invalid")},
+		{name: "delete of missing resource", method: v1.OperationDelete, response: rest.NewNoContentResponse()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			databaseClient := inmemory.NewClient()
+			controller := &testWriteController{
+				BaseController: ctrl.NewBaseController(ctrl.Options{DatabaseClient: databaseClient}),
+				response:       tc.response,
+			}
+			handler := HandlerForController(controller, v1.OperationType{Type: resourceID.Type(), Method: tc.method})
+
+			req, err := http.NewRequest(tc.method.HTTPMethod(), "", bytes.NewBuffer([]byte{}))
+			require.NoError(t, err)
+			rCtx := &v1.ARMRequestContext{ResourceID: resourceID, OperationID: uuid.New(), UserAgent: "rad"}
+			req = req.WithContext(v1.WithARMRequestContext(t.Context(), rCtx))
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			entries, err := history.New(databaseClient).List(t.Context(), resourceID)
+			require.NoError(t, err)
+			if !tc.recorded {
+				require.Empty(t, entries)
+				return
+			}
+
+			require.Len(t, entries, 1)
+			require.Equal(t, rCtx.OperationID.String(), entries[0].OperationID)
+			require.Equal(t, rCtx.OperationType.String(), entries[0].OperationType)
+			require.Equal(t, "rad", entries[0].Caller)
+			require.Equal(t, v1.ProvisioningStateSucceeded, entries[0].ProvisioningState)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package history records the operation history of resources.
+//
+// Every operation that changes a resource appends an immutable entry to the history of the resource:
+// the operation, the caller, the time, the resulting provisioning state and error, and a hash of the
+// resulting properties. Entries are stored through database.Client as child resources of the resource
+// ('{resourceId}/history/{entryName}'), so they work with every database provider and outlive the
+// deletion of the resource.
+package history
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+// ResourceTypeSegment is the child resource type of the history entries of a resource.
+const ResourceTypeSegment = "history"
+
+// Entry is an entry in the history of a resource.
+type Entry struct {
+	// ID is the id of the entry.
+	ID string `json:"id"`
+	// Name is the name of the entry. Names are ordered by the time the entry was recorded.
+	Name string `json:"name"`
+	// ResourceID is the id of the resource.
+	ResourceID string `json:"resourceId"`
+
+	// OperationID is the id of the operation.
+	OperationID string `json:"operationId"`
+	// OperationType is the type of the operation, for example APPLICATIONS.CORE/CONTAINERS|PUT.
+	OperationType string `json:"operationType"`
+	// Caller is the identity of the client that requested the operation.
+	Caller string `json:"caller,omitempty"`
+	// Timestamp is the time when the operation completed.
+	Timestamp time.Time `json:"timestamp"`
+
+	// ProvisioningState is the provisioning state resulting from the operation.
+	ProvisioningState v1.ProvisioningState `json:"provisioningState"`
+	// Error is the error of the operation if it failed.
+	Error *v1.ErrorDetails `json:"error,omitempty"`
+	// PropertiesHash is the hash of the properties of the resource after the operation. It is empty when
+	// the resource does not exist anymore. Two entries with different hashes mean that the properties
+	// of the resource changed between them.
+	PropertiesHash string `json:"propertiesHash,omitempty"`
+}
+
+// EntryList is the list of the entries in the history of a resource.
+type EntryList struct {
+	Value []*Entry `json:"value"`
+}
+
+//go:generate go tool mockgen -typed -destination=./mock_recorder.go -package=history -self_package github.com/radius-project/radius/pkg/armrpc/history github.com/radius-project/radius/pkg/armrpc/history Recorder
+
+// Recorder records and lists the history of resources.
+type Recorder interface {
+	// Record appends entry to the history of the resource of the entry. The id, name and properties hash of
+	// the entry are computed, and the timestamp defaults to the current time.
+	Record(ctx context.Context, entry *Entry) error
+	// List lists the history of a resource in the order the entries were recorded.
+	List(ctx context.Context, id resources.ID) ([]*Entry, error)
+}
+
+// New creates a Recorder that stores the history of resources with databaseClient.
+func New(databaseClient database.Client) Recorder {
+	return &recorder{databaseClient: databaseClient}
+}
+
+type recorder struct {
+	databaseClient database.Client
+}
+
+// Record appends entry to the history of the resource of the entry.
+func (r *recorder) Record(ctx context.Context, entry *Entry) error {
+	id, err := resources.ParseResource(entry.ResourceID)
+	if err != nil {
+		return err
+	}
+
+	// Entry names are version 7 UUIDs so that they are ordered by time.
+	name, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	entry.Name = name.String()
+	entry.ID = id.Append(resources.TypeSegment{Type: ResourceTypeSegment, Name: entry.Name}).String()
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+
+	entry.PropertiesHash = ""
+	obj, err := r.databaseClient.Get(ctx, id.String())
+	if err != nil && !errors.Is(err, &database.ErrNotFound{}) {
+		return err
+	} else if err == nil {
+		entry.PropertiesHash, err = PropertiesHash(obj.Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.databaseClient.Save(ctx, &database.Object{
+		Metadata: database.Metadata{ID: entry.ID},
+		Data:     entry,
+	})
+}
+
+// List lists the history of a resource in the order the entries were recorded.
+func (r *recorder) List(ctx context.Context, id resources.ID) ([]*Entry, error) {
+	query := database.Query{
+		RootScope:          id.RootScope(),
+		ResourceType:       id.Type() + resources.SegmentSeparator + ResourceTypeSegment,
+		RoutingScopePrefix: id.RoutingScope() + resources.SegmentSeparator + ResourceTypeSegment,
+	}
+
+	entries := []*Entry{}
+	options := []database.QueryOptions{}
+	for {
+		result, err := r.databaseClient.Query(ctx, query, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range result.Items {
+			entry := &Entry{}
+			if err := item.As(entry); err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+
+		if result.PaginationToken == "" {
+			break
+		}
+		options = []database.QueryOptions{database.WithPaginationToken(result.PaginationToken)}
+	}
+
+	slices.SortFunc(entries, func(a, b *Entry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return entries, nil
+}
+
+// IsHistoryID returns true if id is the id of the history of a resource, or of an entry in it.
+func IsHistoryID(id resources.ID) bool {
+	segments := id.TypeSegments()
+	return len(segments) > 1 && strings.EqualFold(segments[len(segments)-1].Type, ResourceTypeSegment)
+}
+
+// Tracked returns true if operations with method are recorded in the history of resources. These are the
+// operations that change resources: PUT, PATCH, DELETE and custom actions. Actions named get* or list*
+// only read resources, like listSecrets, and are not recorded. Neither are recipe plans. The asynchronous operations
+// that are not tracked don't update the provisioning state of their resource either.
+func Tracked(method v1.OperationMethod) bool {
+	switch method {
+	case v1.OperationPut, v1.OperationPatch, v1.OperationDelete, v1.OperationPutImperative, v1.OperationDeleteImperative:
+		return true
+	case v1.OperationPost, v1.OperationCancel, v1.OperationProxy, v1.OperationPutSubscriptions, v1.OperationGetImperative, v1.OperationRecipePlan:
+		return false
+	}
+
+	if method.HTTPMethod() != http.MethodPost {
+		return false
+	}
+
+	action := strings.ToUpper(string(method))
+	return !strings.HasPrefix(action, "GET") && !strings.HasPrefix(action, "LIST")
+}
+
+// PropertiesHash returns the hash of the properties of a resource stored as data. The provisioning state
+// and the status of the resource are excluded so that only the changes to its declared properties change
+// the hash.
+func PropertiesHash(data any) (string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal resource: %w", err)
+	}
+
+	resource := struct {
+		Properties map[string]any `json:"properties"`
+	}{}
+	if err := json.Unmarshal(b, &resource); err != nil {
+		return "", fmt.Errorf("failed to unmarshal resource: %w", err)
+	}
+
+	delete(resource.Properties, "provisioningState")
+	delete(resource.Properties, "status")
+
+	// encoding/json sorts map keys, so the same properties always produce the same hash.
+	b, err = json.Marshal(resource.Properties)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal properties: %w", err)
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+const (
+	containerID = "/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/containers/web"
+	otherID     = "/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/containers/web2"
+)
+
+func saveContainer(t *testing.T, client database.Client, image string) {
+	err := client.Save(t.Context(), &database.Object{
+		Metadata: database.Metadata{ID: containerID},
+		Data: map[string]any{
+			"id":                containerID,
+			"provisioningState": "Succeeded",
+			"properties": map[string]any{
+				"container": map[string]any{"image": image},
+				"status":    map[string]any{"outputResources": []any{}},
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestRecorder(t *testing.T) {
+	client := inmemory.NewClient()
+	recorder := New(client)
+	ctx := t.Context()
+
+	saveContainer(t, client, "web:1")
+	first := &Entry{ResourceID: containerID, OperationID: "op-1", OperationType: "APPLICATIONS.CORE/CONTAINERS|PUT", Caller: "rad", ProvisioningState: v1.ProvisioningStateSucceeded}
+	require.NoError(t, recorder.Record(ctx, first))
+	require.Equal(t, containerID+"/history/"+first.Name, first.ID)
+	require.NotEmpty(t, first.PropertiesHash)
+	require.False(t, first.Timestamp.IsZero())
+
+	saveContainer(t, client, "web:2")
+	failed := &Entry{
+		ResourceID:        containerID,
+		OperationID:       "op-2",
+		OperationType:     "APPLICATIONS.CORE/CONTAINERS|PUT",
+		ProvisioningState: v1.ProvisioningStateFailed,
+		Error:             &v1.ErrorDetails{Code: v1.CodeInternal, Message: "failed"},
+	}
+	require.NoError(t, recorder.Record(ctx, failed))
+	require.NotEqual(t, first.PropertiesHash, failed.PropertiesHash)
+
+	require.NoError(t, client.Delete(ctx, containerID))
+	deleted := &Entry{ResourceID: containerID, OperationID: "op-3", OperationType: "APPLICATIONS.CORE/CONTAINERS|DELETE", ProvisioningState: v1.ProvisioningStateSucceeded}
+	require.NoError(t, recorder.Record(ctx, deleted))
+	require.Empty(t, deleted.PropertiesHash)
+
+	// The history of another resource with the same prefix is not included.
+	require.NoError(t, recorder.Record(ctx, &Entry{ResourceID: otherID, OperationID: "op-4", OperationType: "APPLICATIONS.CORE/CONTAINERS|PUT"}))
+
+	entries, err := recorder.List(ctx, resources.MustParse(containerID))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, []string{"op-1", "op-2", "op-3"}, []string{entries[0].OperationID, entries[1].OperationID, entries[2].OperationID})
+	require.Equal(t, "rad", entries[0].Caller)
+	require.Equal(t, "failed", entries[1].Error.Message)
+	require.Equal(t, first.Timestamp.Truncate(time.Millisecond), entries[0].Timestamp.Truncate(time.Millisecond))
+
+	err = recorder.Record(ctx, &Entry{ResourceID: "/planes/radius/local/resourceGroups/test-group"})
+	require.Error(t, err)
+}
+
+func TestIsHistoryID(t *testing.T) {
+	require.True(t, IsHistoryID(resources.MustParse(containerID+"/history")))
+	require.True(t, IsHistoryID(resources.MustParse(containerID+"/history/entry")))
+	require.False(t, IsHistoryID(resources.MustParse(containerID)))
+	require.False(t, IsHistoryID(resources.MustParse("/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/history/entry")))
+}
+
+func TestTracked(t *testing.T) {
+	tests := []struct {
+		method  v1.OperationMethod
+		tracked bool
+	}{
+		{v1.OperationPut, true},
+		{v1.OperationPatch, true},
+		{v1.OperationDelete, true},
+		{v1.OperationPutImperative, true},
+		{"RUNRECIPE", true},
+		{v1.OperationGet, false},
+		{v1.OperationList, false},
+		{v1.OperationCancel, false},
+		{v1.OperationProxy, false},
+		{v1.OperationGetHistory, false},
+		{v1.OperationRecipePlan, false},
+		{"LISTSECRETS", false},
+		{"GETGRAPH", false},
+	}
+
+	for _, tc := range tests {
+		require.Equal(t, tc.tracked, Tracked(tc.method), string(tc.method))
+	}
+}
+
+func TestPropertiesHash(t *testing.T) {
+	hash := func(properties map[string]any) string {
+		h, err := PropertiesHash(map[string]any{"properties": properties})
+		require.NoError(t, err)
+		return h
+	}
+
+	base := hash(map[string]any{"image": "web:1", "ports": map[string]any{"a": 1, "b": 2}})
+	require.Equal(t, base, hash(map[string]any{"ports": map[string]any{"b": 2, "a": 1}, "image": "web:1", "provisioningState": "Updating", "status": map[string]any{"x": 1}}))
+	require.NotEqual(t, base, hash(map[string]any{"image": "web:2", "ports": map[string]any{"a": 1, "b": 2}}))
+}
@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/radius-project/radius/pkg/armrpc/history (interfaces: Recorder)
+//
+// Generated by this command:
+//
+//	mockgen -typed -destination=./mock_recorder.go -package=history -self_package github.com/radius-project/radius/pkg/armrpc/history github.com/radius-project/radius/pkg/armrpc/history Recorder
+//
+
+// Package history is a generated GoMock package.
+package history
+
+import (
+	context "context"
+	reflect "reflect"
+
+	resources "github.com/radius-project/radius/pkg/ucp/resources"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecorder is a mock of Recorder interface.
+type MockRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecorderMockRecorder
+	isgomock struct{}
+}
+
+// MockRecorderMockRecorder is the mock recorder for MockRecorder.
+type MockRecorderMockRecorder struct {
+	mock *MockRecorder
+}
+
+// NewMockRecorder creates a new mock instance.
+func NewMockRecorder(ctrl *gomock.Controller) *MockRecorder {
+	mock := &MockRecorder{ctrl: ctrl}
+	mock.recorder = &MockRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecorder) EXPECT() *MockRecorderMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockRecorder) List(ctx context.Context, id resources.ID) ([]*Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, id)
+	ret0, _ := ret[0].([]*Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRecorderMockRecorder) List(ctx, id any) *MockRecorderListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRecorder)(nil).List), ctx, id)
+	return &MockRecorderListCall{Call: call}
+}
+
+// MockRecorderListCall wrap *gomock.Call
+type MockRecorderListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRecorderListCall) Return(arg0 []*Entry, arg1 error) *MockRecorderListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRecorderListCall) Do(f func(context.Context, resources.ID) ([]*Entry, error)) *MockRecorderListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRecorderListCall) DoAndReturn(f func(context.Context, resources.ID) ([]*Entry, error)) *MockRecorderListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Record mocks base method.
+func (m *MockRecorder) Record(ctx context.Context, entry *Entry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRecorderMockRecorder) Record(ctx, entry any) *MockRecorderRecordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRecorder)(nil).Record), ctx, entry)
+	return &MockRecorderRecordCall{Call: call}
+}
+
+// MockRecorderRecordCall wrap *gomock.Call
+type MockRecorderRecordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRecorderRecordCall) Return(arg0 error) *MockRecorderRecordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRecorderRecordCall) Do(f func(context.Context, *Entry) error) *MockRecorderRecordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRecorderRecordCall) DoAndReturn(f func(context.Context, *Entry) error) *MockRecorderRecordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -46,6 +46,8 @@ type ProviderConfig struct {
 	Logging          ucplog.LoggingOptions                `yaml:"logging"`
 	Bicep            BicepOptions                         `yaml:"bicep,omitempty"`
 	Terraform        TerraformOptions                     `yaml:"terraform,omitempty"`
+	GraphDrift       GraphDriftOptions                    `yaml:"graphDrift,omitempty"`
+	RecipeDrift      RecipeDriftOptions                   `yaml:"recipeDrift,omitempty"`
 
 	// FeatureFlags includes the list of feature flags.
 	FeatureFlags []string `yaml:"featureFlags"`
@@ -102,3 +104,40 @@ type TerraformOptions struct {
 	// LogLevel is the log level for Terraform execution (ERROR, DEBUG, etc.).
 	LogLevel string `yaml:"logLevel,omitempty"`
 }
+
+// GraphDriftOptions configures the controller's periodic comparison of the modeled graph of
+// applications with their deployed graph.
+type GraphDriftOptions struct {
+	// Enabled turns on drift detection.
+	Enabled bool `yaml:"enabled"`
+
+	// Interval is the time between two checks, for example "10m". Defaults to 10 minutes.
+	Interval string `yaml:"interval,omitempty"`
+
+	// Applications lists the applications to check.
+	Applications []GraphDriftApplication `yaml:"applications,omitempty"`
+}
+
+// RecipeDriftOptions configures the periodic check of the resources deployed by recipes for drift. The
+// drift policy of each environment decides whether drift is only recorded or also remediated.
+type RecipeDriftOptions struct {
+	// Enabled turns on recipe drift detection.
+	Enabled bool `yaml:"enabled"`
+
+	// Interval is the time between two checks, for example "30m". Defaults to 30 minutes.
+	Interval string `yaml:"interval,omitempty"`
+}
+
+// GraphDriftApplication identifies an application checked for drift and where its modeled graph
+// is saved.
+type GraphDriftApplication struct {
+	// ID is the resource ID of the Radius.Core application.
+	ID string `yaml:"id"`
+
+	// Archive is the location of the radius-graph archive the modeled graph was saved to by
+	// 'rad app graph': an OCI repository, "s3://bucket/prefix" or "file:///path".
+	Archive string `yaml:"archive"`
+
+	// Branch is the branch whose modeled graph is compared. Defaults to "main".
+	Branch string `yaml:"branch,omitempty"`
+}
@@ -795,3 +795,69 @@ func (r *MethodNotAllowedResponse) Apply(ctx context.Context, w http.ResponseWri
 
 	return nil
 }
+
+// WatchEvent is a change to a resource streamed by a WatchResponse.
+type WatchEvent struct {
+	// Type is the type of the change: Created, Updated or Deleted.
+	Type string `json:"type"`
+
+	// ID is the id of the changed resource.
+	ID string `json:"id"`
+
+	// ETag is the ETag of the resource after the change, or the last ETag of a deleted resource.
+	ETag string `json:"etag,omitempty"`
+
+	// Object is the resource after the change. It is not set for deleted resources.
+	Object any `json:"object,omitempty"`
+}
+
+// WatchResponse represents an HTTP 200 that streams changes to resources as newline-delimited JSON.
+//
+// This is used when a list operation is called with the watch query parameter.
+type WatchResponse struct {
+	Events <-chan WatchEvent
+}
+
+// NewWatchResponse creates a WatchResponse that streams the events until the channel is closed or the
+// request is done.
+func NewWatchResponse(events <-chan WatchEvent) Response {
+	return &WatchResponse{Events: events}
+}
+
+// Apply writes the headers of the response and then writes and flushes each event as a line of JSON. The
+// response ends when the events channel is closed or ctx is done.
+func (r *WatchResponse) Apply(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+	logger.V(ucplog.LevelDebug).Info(fmt.Sprintf("responding with status code: %d", http.StatusOK), logging.LogHTTPStatusCode, http.StatusOK)
+
+	w.Header().Add("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	// Flush the headers so the client knows the watch has started.
+	rc := http.NewResponseController(w)
+	if err := rc.Flush(); err != nil {
+		return fmt.Errorf("error flushing the response: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-r.Events:
+			if !ok {
+				return nil
+			}
+
+			// The status code has already been sent, so a client that goes away ends the watch.
+			if err := encoder.Encode(event); err != nil {
+				logger.V(ucplog.LevelDebug).Info("stopped writing the watch", "error", err.Error())
+				return nil
+			}
+			if err := rc.Flush(); err != nil {
+				logger.V(ucplog.LevelDebug).Info("stopped writing the watch", "error", err.Error())
+				return nil
+			}
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package rest
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -146,6 +147,45 @@ func Test_OKResponse_WithBody(t *testing.T) {
 	require.Equal(t, payload, body)
 }
 
+func Test_WatchResponse(t *testing.T) {
+	events := make(chan WatchEvent, 2)
+	events <- WatchEvent{Type: "Created", ID: "/planes/radius/local/resourceGroups/rg/providers/Applications.Core/containers/c", ETag: "a", Object: map[string]any{"name": "c"}}
+	events <- WatchEvent{Type: "Deleted", ID: "/planes/radius/local/resourceGroups/rg/providers/Applications.Core/containers/c", ETag: "a"}
+	close(events)
+
+	response := NewWatchResponse(events)
+
+	req := httptest.NewRequest("GET", "http://example.com", nil)
+	w := httptest.NewRecorder()
+
+	err := response.Apply(t.Context(), w, req)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{"application/x-ndjson"}, w.Header()["Content-Type"])
+	require.True(t, w.Flushed)
+
+	expected := `{"type":"Created","id":"/planes/radius/local/resourceGroups/rg/providers/Applications.Core/containers/c","etag":"a","object":{"name":"c"}}
+{"type":"Deleted","id":"/planes/radius/local/resourceGroups/rg/providers/Applications.Core/containers/c","etag":"a"}
+`
+	require.Equal(t, expected, w.Body.String())
+}
+
+func Test_WatchResponse_Done(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	response := NewWatchResponse(make(chan WatchEvent))
+
+	req := httptest.NewRequest("GET", "http://example.com", nil)
+	w := httptest.NewRecorder()
+
+	err := response.Apply(ctx, w, req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Body.String())
+}
+
 func TestGetAsyncLocationPath(t *testing.T) {
 	operationID := uuid.New()
 
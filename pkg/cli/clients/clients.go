@@ -22,10 +22,13 @@ import (
 	"maps"
 	"os"
 
+	"github.com/radius-project/radius/pkg/armrpc/history"
 	"github.com/radius-project/radius/pkg/cli/clients_new/generated"
 	corerp "github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
 	radiuscore "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/recipes"
 	ucp_v20231001preview "github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
+	"github.com/radius-project/radius/pkg/ucp/frontend/controller/deadletters"
 	ucpresources "github.com/radius-project/radius/pkg/ucp/resources"
 )
 
@@ -115,6 +118,7 @@ type ApplicationStatus struct {
 	Name          string
 	ResourceCount int
 	Gateways      []GatewayStatus
+	Conditions    []ConditionStatus
 }
 
 type GatewayStatus struct {
@@ -122,6 +126,28 @@ type GatewayStatus struct {
 	Endpoint string
 }
 
+type ConditionStatus struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+}
+
+// ResourceChange is a change to a resource reported by WatchResourcesInResourceGroup.
+type ResourceChange struct {
+	// Type is the type of the change: Created, Updated or Deleted.
+	Type string `json:"type"`
+
+	// ID is the id of the entry that tracks the resource in the resource group.
+	ID string `json:"id"`
+
+	// ETag is the ETag of the entry after the change.
+	ETag string `json:"etag,omitempty"`
+
+	// Resource is the changed resource. It is not set for deleted resources.
+	Resource *ucp_v20231001preview.GenericResource `json:"object,omitempty"`
+}
+
 type EndpointOptions struct {
 	ResourceID ucpresources.ID
 }
@@ -182,6 +208,31 @@ type ApplicationsManagementClient interface {
 	// When force is true, the delete will proceed even if the resource is in a non-terminal provisioning state.
 	DeleteResource(ctx context.Context, resourceType string, resourceNameOrID string, force bool) (bool, error)
 
+	// CancelResourceOperations requests the cancellation of the operations in progress on a resource by its type
+	// and name (or id). It returns the ids of the operations whose cancellation was requested.
+	CancelResourceOperations(ctx context.Context, resourceType string, resourceNameOrID string) ([]string, error)
+
+	// GetResourceHistory retrieves the operation history of a resource by its type and name (or id), in the order
+	// the operations completed.
+	GetResourceHistory(ctx context.Context, resourceType string, resourceNameOrID string) ([]*history.Entry, error)
+
+	// PlanResourceRecipe plans the recipe of a resource by its type and name (or id), and returns the changes that
+	// deploying the recipe would make. Nothing is deployed.
+	PlanResourceRecipe(ctx context.Context, resourceType string, resourceNameOrID string) (*recipes.RecipePlan, error)
+
+	// ListDeadLetters lists the messages in the dead-letter queue of the queue queueName.
+	ListDeadLetters(ctx context.Context, queueName string) ([]*deadletters.DeadLetterMessage, error)
+
+	// GetDeadLetter gets a message, with its payload, in the dead-letter queue of the queue queueName.
+	GetDeadLetter(ctx context.Context, queueName string, id string) (*deadletters.DeadLetterMessage, error)
+
+	// ReplayDeadLetter moves a message from the dead-letter queue of the queue queueName back to the queue.
+	// It returns the replayed message.
+	ReplayDeadLetter(ctx context.Context, queueName string, id string) (*deadletters.DeadLetterMessage, error)
+
+	// PurgeDeadLetter deletes a message from the dead-letter queue of the queue queueName.
+	PurgeDeadLetter(ctx context.Context, queueName string, id string) error
+
 	// ListApplications lists all applications in the configured scope.
 	ListApplications(ctx context.Context) ([]corerp.ApplicationResource, error)
 
@@ -249,6 +300,10 @@ type ApplicationsManagementClient interface {
 	// ListResourcesInResourceGroup lists all resources in a specific resource group.
 	ListResourcesInResourceGroup(ctx context.Context, planeName string, resourceGroupName string) ([]generated.GenericResource, error)
 
+	// WatchResourcesInResourceGroup streams the changes to the resources in a specific resource group. The channel is
+	// closed when ctx is done or when the connection to the server is lost.
+	WatchResourcesInResourceGroup(ctx context.Context, planeName string, resourceGroupName string) (<-chan ResourceChange, error)
+
 	// ListResourcesInResourceGroupFiltered lists resources in a resource group, optionally filtered by environment and/or application.
 	ListResourcesInResourceGroupFiltered(ctx context.Context, planeName string, resourceGroupName string, environmentID string, applicationID string) ([]generated.GenericResource, error)
 
@@ -18,22 +18,31 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"golang.org/x/exp/maps"
 	"golang.org/x/sync/errgroup"
 
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/armrpc/history"
 	"github.com/radius-project/radius/pkg/azure/clientv2"
 	aztoken "github.com/radius-project/radius/pkg/azure/tokencredentials"
 	"github.com/radius-project/radius/pkg/cli/clients_new/generated"
 	corerpv20231001 "github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
 	corerpv20250801 "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/recipes"
 	ucpv20231001 "github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
+	"github.com/radius-project/radius/pkg/ucp/frontend/controller/deadletters"
 	"github.com/radius-project/radius/pkg/ucp/resources"
 	resources_radius "github.com/radius-project/radius/pkg/ucp/resources/radius"
 )
@@ -56,6 +65,13 @@ type UCPApplicationsManagementClient struct {
 
 var _ ApplicationsManagementClient = (*UCPApplicationsManagementClient)(nil)
 
+const (
+	// operationsModuleName and operationsModuleVersion identify the client sending operation status requests,
+	// which have no generated client.
+	operationsModuleName    = "github.com/radius-project/radius/pkg/cli/clients"
+	operationsModuleVersion = "v0.1.0"
+)
+
 // ListResourcesOfType lists all resources of a given type in the configured scope.
 func (amc *UCPApplicationsManagementClient) ListResourcesOfType(ctx context.Context, resourceType string) ([]generated.GenericResource, error) {
 	apiVersions, err := amc.getApiVersionsForResourceType(ctx, resourceType)
@@ -212,6 +228,238 @@ func (amc *UCPApplicationsManagementClient) DeleteResource(ctx context.Context,
 	return response.StatusCode != 204, nil
 }
 
+// CancelResourceOperations requests the cancellation of the operations in progress on a resource by its type
+// and name (or id). It returns the ids of the operations whose cancellation was requested.
+func (amc *UCPApplicationsManagementClient) CancelResourceOperations(ctx context.Context, resourceType string, resourceNameOrID string) ([]string, error) {
+	apiVersions, err := amc.getApiVersionsForResourceType(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceID, err := amc.fullyQualifyID(resourceNameOrID, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := resources.ParseResource(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := arm.NewClient(operationsModuleName, operationsModuleVersion, &aztoken.AnonymousCredential{}, amc.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if len(apiVersions) != 0 {
+		query.Set("api-version", apiVersions[0])
+	}
+
+	// Operation statuses are stored per provider namespace, so list the ones of the resource's namespace.
+	statusesURL := fmt.Sprintf("%s%s/providers/%s/locations/%s/operationStatuses", client.Endpoint(), id.PlaneScope(), id.ProviderNamespace(), v1.LocationGlobal)
+	listQuery := url.Values{"resourceId": []string{id.String()}}
+	if len(apiVersions) != 0 {
+		listQuery.Set("api-version", apiVersions[0])
+	}
+
+	list := struct {
+		Value []v1.AsyncOperationStatus `json:"value"`
+	}{}
+	if err := doOperationRequest(ctx, client, http.MethodGet, statusesURL+"?"+listQuery.Encode(), &list); err != nil {
+		return nil, err
+	}
+
+	canceled := []string{}
+	for _, status := range list.Value {
+		err := doOperationRequest(ctx, client, http.MethodPost, statusesURL+"/"+status.Name+"/cancel?"+query.Encode(), nil)
+		responseErr := &azcore.ResponseError{}
+		if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusConflict {
+			// The operation completed in the meantime.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		canceled = append(canceled, status.Name)
+	}
+
+	return canceled, nil
+}
+
+// GetResourceHistory retrieves the operation history of a resource by its type and name (or id), in the order
+// the operations completed.
+func (amc *UCPApplicationsManagementClient) GetResourceHistory(ctx context.Context, resourceType string, resourceNameOrID string) ([]*history.Entry, error) {
+	apiVersions, err := amc.getApiVersionsForResourceType(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceID, err := amc.fullyQualifyID(resourceNameOrID, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := arm.NewClient(operationsModuleName, operationsModuleVersion, &aztoken.AnonymousCredential{}, amc.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if len(apiVersions) != 0 {
+		query.Set("api-version", apiVersions[0])
+	}
+
+	list := &history.EntryList{}
+	historyURL := fmt.Sprintf("%s%s/%s?%s", client.Endpoint(), resourceID, history.ResourceTypeSegment, query.Encode())
+	if err := doOperationRequest(ctx, client, http.MethodGet, historyURL, list); err != nil {
+		return nil, err
+	}
+
+	return list.Value, nil
+}
+
+// PlanResourceRecipe plans the recipe of a resource by its type and name (or id), and returns the changes that
+// deploying the recipe would make. Nothing is deployed.
+func (amc *UCPApplicationsManagementClient) PlanResourceRecipe(ctx context.Context, resourceType string, resourceNameOrID string) (*recipes.RecipePlan, error) {
+	apiVersions, err := amc.getApiVersionsForResourceType(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceID, err := amc.fullyQualifyID(resourceNameOrID, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := arm.NewClient(operationsModuleName, operationsModuleVersion, &aztoken.AnonymousCredential{}, amc.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if len(apiVersions) != 0 {
+		query.Set("api-version", apiVersions[0])
+	}
+
+	planURL := fmt.Sprintf("%s%s/%s?%s", client.Endpoint(), resourceID, recipes.PlanAction, query.Encode())
+	req, err := runtime.NewRequest(ctx, http.MethodPost, planURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Pipeline().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusAccepted) {
+		return nil, runtime.NewResponseError(resp)
+	}
+
+	// The plan runs as an async operation. Its result is the plan once the operation succeeds.
+	poller, err := runtime.NewPoller[recipes.RecipePlan](resp, client.Pipeline(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// ListDeadLetters lists the messages in the dead-letter queue of the queue queueName.
+func (amc *UCPApplicationsManagementClient) ListDeadLetters(ctx context.Context, queueName string) ([]*deadletters.DeadLetterMessage, error) {
+	client, deadLettersURL, err := amc.deadLettersURL(queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &deadletters.DeadLetterMessageList{}
+	if err := doOperationRequest(ctx, client, http.MethodGet, deadLettersURL, list); err != nil {
+		return nil, err
+	}
+
+	return list.Value, nil
+}
+
+// GetDeadLetter gets a message, with its payload, in the dead-letter queue of the queue queueName.
+func (amc *UCPApplicationsManagementClient) GetDeadLetter(ctx context.Context, queueName string, id string) (*deadletters.DeadLetterMessage, error) {
+	client, deadLettersURL, err := amc.deadLettersURL(queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &deadletters.DeadLetterMessage{}
+	if err := doOperationRequest(ctx, client, http.MethodGet, deadLettersURL+"/"+url.PathEscape(id), msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// ReplayDeadLetter moves a message from the dead-letter queue of the queue queueName back to the queue.
+func (amc *UCPApplicationsManagementClient) ReplayDeadLetter(ctx context.Context, queueName string, id string) (*deadletters.DeadLetterMessage, error) {
+	client, deadLettersURL, err := amc.deadLettersURL(queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &deadletters.DeadLetterMessage{}
+	if err := doOperationRequest(ctx, client, http.MethodPost, deadLettersURL+"/"+url.PathEscape(id)+"/replay", msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// PurgeDeadLetter deletes a message from the dead-letter queue of the queue queueName.
+func (amc *UCPApplicationsManagementClient) PurgeDeadLetter(ctx context.Context, queueName string, id string) error {
+	client, deadLettersURL, err := amc.deadLettersURL(queueName)
+	if err != nil {
+		return err
+	}
+
+	return doOperationRequest(ctx, client, http.MethodDelete, deadLettersURL+"/"+url.PathEscape(id), nil)
+}
+
+// deadLettersURL returns the client and the URL of the UCP admin API for the dead-letter queue of queueName.
+func (amc *UCPApplicationsManagementClient) deadLettersURL(queueName string) (*arm.Client, string, error) {
+	client, err := arm.NewClient(operationsModuleName, operationsModuleVersion, &aztoken.AnonymousCredential{}, amc.ClientOptions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, fmt.Sprintf("%s/admin/queues/%s/deadletters", client.Endpoint(), url.PathEscape(queueName)), nil
+}
+
+// doOperationRequest sends a request for an operation status, a resource history or a dead-letter message and unmarshals the response
+// body into result, if result is not nil.
+func doOperationRequest(ctx context.Context, client *arm.Client, method string, rawURL string, result any) error {
+	req, err := runtime.NewRequest(ctx, method, rawURL)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Pipeline().Do(req)
+	if err != nil {
+		return err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusAccepted, http.StatusNoContent) {
+		return runtime.NewResponseError(resp)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return runtime.UnmarshalAsJSON(resp, result)
+}
+
 // ListApplications lists all applications in the configured scope.
 func (amc *UCPApplicationsManagementClient) ListApplications(ctx context.Context) ([]corerpv20231001.ApplicationResource, error) {
 	client, err := amc.createApplicationClient(amc.RootScope)
@@ -868,6 +1116,61 @@ func (amc *UCPApplicationsManagementClient) ListResourcesInResourceGroup(ctx con
 	return results, nil
 }
 
+// WatchResourcesInResourceGroup streams the changes to the resources in a specific resource group. The channel is
+// closed when ctx is done or when the connection to the server is lost.
+func (amc *UCPApplicationsManagementClient) WatchResourcesInResourceGroup(ctx context.Context, planeName string, resourceGroupName string) (<-chan ResourceChange, error) {
+	client, err := arm.NewClient(operationsModuleName, operationsModuleVersion, &aztoken.AnonymousCredential{}, amc.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("api-version", ucpv20231001.Version)
+	query.Set(v1.WatchParameterName, "true")
+
+	watchURL := fmt.Sprintf("%s/planes/radius/%s/resourceGroups/%s/resources?%s", client.Endpoint(), url.PathEscape(planeName), url.PathEscape(resourceGroupName), query.Encode())
+	req, err := runtime.NewRequest(ctx, http.MethodGet, watchURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// The response is streamed until ctx is done, so it can't be read by the pipeline.
+	runtime.SkipBodyDownload(req)
+
+	resp, err := client.Pipeline().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		defer resp.Body.Close()
+		return nil, runtime.NewResponseError(resp)
+	}
+
+	changes := make(chan ResourceChange)
+	go func() {
+		defer close(changes)
+		defer resp.Body.Close()
+
+		// Each change is a line of JSON.
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			change := ResourceChange{}
+			if err := decoder.Decode(&change); err != nil {
+				return
+			}
+
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
 // ListResourcesInResourceGroupFiltered lists resources in a resource group, optionally filtered by environment and/or application.
 func (amc *UCPApplicationsManagementClient) ListResourcesInResourceGroupFiltered(ctx context.Context, planeName string, resourceGroupName string, environmentID string, applicationID string) ([]generated.GenericResource, error) {
 	// First get all resources in the group
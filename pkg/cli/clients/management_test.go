@@ -34,6 +34,7 @@ import (
 	"github.com/radius-project/radius/pkg/cli/clients_new/generated"
 	corerp "github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
 	corerpv20250801 "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/recipes"
 	"github.com/radius-project/radius/pkg/to"
 	ucp "github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
 	"github.com/stretchr/testify/require"
@@ -868,6 +869,380 @@ func Test_DeleteResource_ForceQueryParameter(t *testing.T) {
 	}
 }
 
+func Test_CancelResourceOperations(t *testing.T) {
+	t.Parallel()
+
+	statuses := "/planes/radius/local/providers/Applications.Test/locations/global/operationStatuses"
+	resourceID := testScope + "/providers/Applications.Test/testResource/myresource"
+
+	var requests []string
+	transport := &mockTransport{
+		do: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			header := http.Header{}
+			header.Set("Content-Type", "application/json")
+
+			response := &http.Response{StatusCode: http.StatusAccepted, Header: header, Body: io.NopCloser(strings.NewReader("{}")), Request: req}
+			switch {
+			case req.Method == http.MethodGet:
+				if req.URL.Query().Get("resourceId") != resourceID || req.URL.Query().Get("api-version") != version {
+					response.StatusCode = http.StatusBadRequest
+					break
+				}
+				response.StatusCode = http.StatusOK
+				response.Body = io.NopCloser(strings.NewReader(`{"value": [{"name": "op-1", "status": "Updating"}, {"name": "op-2", "status": "Accepted"}]}`))
+			case strings.HasSuffix(req.URL.Path, "/op-2/cancel"):
+				// op-2 completed before it was canceled.
+				response.StatusCode = http.StatusConflict
+				response.Body = io.NopCloser(strings.NewReader(`{"error": {"code": "Conflict", "message": "Operation op-2 has already completed and cannot be canceled."}}`))
+			}
+			return response, nil
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	rpClient := NewMockresourceProviderClient(ctrl)
+	rpClient.EXPECT().
+		GetProviderSummary(gomock.Any(), "local", "Applications.Test", gomock.Any()).
+		Return(ucp.ResourceProvidersClientGetProviderSummaryResponse{
+			ResourceProviderSummary: ucp.ResourceProviderSummary{
+				Name: new("Applications.Test"),
+				ResourceTypes: map[string]*ucp.ResourceProviderSummaryResourceType{
+					"testResource": {
+						APIVersions: map[string]*ucp.ResourceTypeSummaryResultAPIVersion{
+							version: {},
+						},
+					},
+				},
+			},
+		}, nil)
+
+	client := &UCPApplicationsManagementClient{
+		RootScope: testScope,
+		ClientOptions: &arm.ClientOptions{
+			ClientOptions: policy.ClientOptions{
+				Transport: transport,
+			},
+		},
+		resourceProviderClientFactory: func() (resourceProviderClient, error) {
+			return rpClient, nil
+		},
+	}
+
+	canceled, err := client.CancelResourceOperations(t.Context(), "Applications.Test/testResource", "myresource")
+	require.NoError(t, err)
+	require.Equal(t, []string{"op-1"}, canceled)
+	require.Equal(t, []string{
+		http.MethodGet + " " + statuses,
+		http.MethodPost + " " + statuses + "/op-1/cancel",
+		http.MethodPost + " " + statuses + "/op-2/cancel",
+	}, requests)
+}
+
+func Test_GetResourceHistory(t *testing.T) {
+	t.Parallel()
+
+	resourceID := testScope + "/providers/Applications.Test/testResource/myresource"
+
+	var requests []string
+	transport := &mockTransport{
+		do: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			header := http.Header{}
+			header.Set("Content-Type", "application/json")
+
+			response := &http.Response{StatusCode: http.StatusOK, Header: header, Request: req}
+			if req.URL.Query().Get("api-version") != version {
+				response.StatusCode = http.StatusBadRequest
+				response.Body = io.NopCloser(strings.NewReader(`{"error": {"code": "BadRequest", "message": "bad api-version"}}`))
+				return response, nil
+			}
+
+			response.Body = io.NopCloser(strings.NewReader(`{"value": [
+				{"name": "entry-1", "operationType": "APPLICATIONS.TEST/TESTRESOURCE|PUT", "provisioningState": "Succeeded", "propertiesHash": "abc"},
+				{"name": "entry-2", "operationType": "APPLICATIONS.TEST/TESTRESOURCE|DELETE", "provisioningState": "Failed", "error": {"code": "Internal", "message": "failed"}}
+			]}`))
+			return response, nil
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	rpClient := NewMockresourceProviderClient(ctrl)
+	rpClient.EXPECT().
+		GetProviderSummary(gomock.Any(), "local", "Applications.Test", gomock.Any()).
+		Return(ucp.ResourceProvidersClientGetProviderSummaryResponse{
+			ResourceProviderSummary: ucp.ResourceProviderSummary{
+				Name: new("Applications.Test"),
+				ResourceTypes: map[string]*ucp.ResourceProviderSummaryResourceType{
+					"testResource": {
+						APIVersions: map[string]*ucp.ResourceTypeSummaryResultAPIVersion{
+							version: {},
+						},
+					},
+				},
+			},
+		}, nil)
+
+	client := &UCPApplicationsManagementClient{
+		RootScope: testScope,
+		ClientOptions: &arm.ClientOptions{
+			ClientOptions: policy.ClientOptions{
+				Transport: transport,
+			},
+		},
+		resourceProviderClientFactory: func() (resourceProviderClient, error) {
+			return rpClient, nil
+		},
+	}
+
+	entries, err := client.GetResourceHistory(t.Context(), "Applications.Test/testResource", "myresource")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "entry-1", entries[0].Name)
+	require.Equal(t, "abc", entries[0].PropertiesHash)
+	require.Equal(t, v1.ProvisioningStateFailed, entries[1].ProvisioningState)
+	require.Equal(t, "failed", entries[1].Error.Message)
+	require.Equal(t, []string{http.MethodGet + " " + resourceID + "/history"}, requests)
+}
+
+func Test_PlanResourceRecipe(t *testing.T) {
+	t.Parallel()
+
+	resourceID := testScope + "/providers/Applications.Test/testResource/myresource"
+	operationPath := "/planes/radius/local/providers/Applications.Test/locations/global"
+
+	// newTransport returns a transport that accepts the plan and returns status for the plan operation, and the
+	// requests that it received.
+	newTransport := func(status string) (*mockTransport, *[]string) {
+		requests := []string{}
+		return &mockTransport{
+			do: func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+				header := http.Header{}
+				header.Set("Content-Type", "application/json")
+
+				response := &http.Response{StatusCode: http.StatusOK, Header: header, Request: req}
+				if req.URL.Query().Get("api-version") != version {
+					response.StatusCode = http.StatusBadRequest
+					response.Body = io.NopCloser(strings.NewReader(`{"error": {"code": "BadRequest", "message": "bad api-version"}}`))
+					return response, nil
+				}
+
+				baseURL := req.URL.Scheme + "://" + req.URL.Host + operationPath
+				switch req.URL.Path {
+				case resourceID + "/recipes/plan":
+					response.StatusCode = http.StatusAccepted
+					header.Set("Azure-AsyncOperation", baseURL+"/operationStatuses/plan-operation?api-version="+version)
+					header.Set("Location", baseURL+"/operationResults/plan-operation?api-version="+version)
+					response.Body = io.NopCloser(strings.NewReader(`{}`))
+				case operationPath + "/operationStatuses/plan-operation":
+					response.Body = io.NopCloser(strings.NewReader(status))
+				case operationPath + "/operationResults/plan-operation":
+					response.Body = io.NopCloser(strings.NewReader(`{
+						"changes": [
+							{"id": "aws_s3_bucket.main", "type": "aws_s3_bucket", "action": "update", "attributes": [{"path": "tags", "before": {"a": "1"}, "after": {"a": "2"}}]}
+						],
+						"recipe": {"templateKind": "terraform", "templatePath": "git::https://example.com/recipe.git"}
+					}`))
+				default:
+					response.StatusCode = http.StatusNotFound
+					response.Body = io.NopCloser(strings.NewReader(`{"error": {"code": "NotFound", "message": "not found"}}`))
+				}
+				return response, nil
+			},
+		}, &requests
+	}
+
+	newClient := func(t *testing.T, transport *mockTransport) *UCPApplicationsManagementClient {
+		ctrl := gomock.NewController(t)
+		rpClient := NewMockresourceProviderClient(ctrl)
+		rpClient.EXPECT().
+			GetProviderSummary(gomock.Any(), "local", "Applications.Test", gomock.Any()).
+			Return(ucp.ResourceProvidersClientGetProviderSummaryResponse{
+				ResourceProviderSummary: ucp.ResourceProviderSummary{
+					Name: new("Applications.Test"),
+					ResourceTypes: map[string]*ucp.ResourceProviderSummaryResourceType{
+						"testResource": {
+							APIVersions: map[string]*ucp.ResourceTypeSummaryResultAPIVersion{
+								version: {},
+							},
+						},
+					},
+				},
+			}, nil)
+
+		return &UCPApplicationsManagementClient{
+			RootScope: testScope,
+			ClientOptions: &arm.ClientOptions{
+				ClientOptions: policy.ClientOptions{
+					Transport: transport,
+				},
+			},
+			resourceProviderClientFactory: func() (resourceProviderClient, error) {
+				return rpClient, nil
+			},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		transport, requests := newTransport(`{"status": "Succeeded"}`)
+		plan, err := newClient(t, transport).PlanResourceRecipe(t.Context(), "Applications.Test/testResource", "myresource")
+		require.NoError(t, err)
+		require.True(t, plan.HasChanges())
+		require.Equal(t, recipes.ResourceChange{
+			ID:     "aws_s3_bucket.main",
+			Type:   "aws_s3_bucket",
+			Action: recipes.ChangeActionUpdate,
+			Attributes: []recipes.AttributeChange{
+				{Path: "tags", Before: map[string]any{"a": "1"}, After: map[string]any{"a": "2"}},
+			},
+		}, plan.Changes[0])
+		require.Equal(t, "terraform", plan.Status.TemplateKind)
+		require.Equal(t, []string{
+			http.MethodPost + " " + resourceID + "/recipes/plan",
+			http.MethodGet + " " + operationPath + "/operationStatuses/plan-operation",
+			http.MethodGet + " " + operationPath + "/operationResults/plan-operation",
+		}, *requests)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+
+		transport, requests := newTransport(`{"status": "Failed", "error": {"code": "RecipePlanFailed", "message": "terraform plan failure"}}`)
+		plan, err := newClient(t, transport).PlanResourceRecipe(t.Context(), "Applications.Test/testResource", "myresource")
+		require.Nil(t, plan)
+
+		var responseErr *azcore.ResponseError
+		require.ErrorAs(t, err, &responseErr)
+		require.Equal(t, "RecipePlanFailed", responseErr.ErrorCode)
+		require.ErrorContains(t, err, "terraform plan failure")
+		require.Equal(t, []string{
+			http.MethodPost + " " + resourceID + "/recipes/plan",
+			http.MethodGet + " " + operationPath + "/operationStatuses/plan-operation",
+		}, *requests)
+	})
+}
+
+func Test_WatchResourcesInResourceGroup(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	transport := &mockTransport{
+		do: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path+"?"+req.URL.RawQuery)
+			header := http.Header{}
+			header.Set("Content-Type", "application/x-ndjson")
+
+			body := `{"type": "Created", "id": "/planes/radius/local/resourceGroups/test-group/providers/System.Resources/resources/a", "etag": "1", "object": {"id": "/planes/radius/local/resourceGroups/test-group/providers/Applications.Test/testResource/myresource", "name": "myresource", "type": "Applications.Test/testResource"}}
+{"type": "Deleted", "id": "/planes/radius/local/resourceGroups/test-group/providers/System.Resources/resources/a", "etag": "1"}
+`
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(body)), Request: req}, nil
+		},
+	}
+
+	client := &UCPApplicationsManagementClient{
+		RootScope: testScope,
+		ClientOptions: &arm.ClientOptions{
+			ClientOptions: policy.ClientOptions{
+				Transport: transport,
+			},
+		},
+	}
+
+	changes, err := client.WatchResourcesInResourceGroup(t.Context(), "local", "test-group")
+	require.NoError(t, err)
+
+	actual := []ResourceChange{}
+	for change := range changes {
+		actual = append(actual, change)
+	}
+
+	require.Equal(t, []ResourceChange{
+		{
+			Type: "Created",
+			ID:   "/planes/radius/local/resourceGroups/test-group/providers/System.Resources/resources/a",
+			ETag: "1",
+			Resource: &ucp.GenericResource{
+				ID:   new("/planes/radius/local/resourceGroups/test-group/providers/Applications.Test/testResource/myresource"),
+				Name: new("myresource"),
+				Type: new("Applications.Test/testResource"),
+			},
+		},
+		{
+			Type: "Deleted",
+			ID:   "/planes/radius/local/resourceGroups/test-group/providers/System.Resources/resources/a",
+			ETag: "1",
+		},
+	}, actual)
+	require.Equal(t, []string{http.MethodGet + " /planes/radius/local/resourceGroups/test-group/resources?api-version=2023-10-01-preview&watch=true"}, requests)
+}
+
+func Test_DeadLetters(t *testing.T) {
+	t.Parallel()
+
+	deadLetters := "/admin/queues/radius/deadletters"
+
+	var requests []string
+	transport := &mockTransport{
+		do: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			header := http.Header{}
+			header.Set("Content-Type", "application/json")
+
+			response := &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(`{"id": "msg-1", "lastError": "failed"}`)), Request: req}
+			switch {
+			case req.URL.Path == deadLetters:
+				response.Body = io.NopCloser(strings.NewReader(`{"value": [{"id": "msg-1", "lastError": "failed"}]}`))
+			case req.Method == http.MethodDelete:
+				response.StatusCode = http.StatusNoContent
+				response.Body = http.NoBody
+			case strings.HasSuffix(req.URL.Path, "/unknown"):
+				response.StatusCode = http.StatusNotFound
+				response.Body = io.NopCloser(strings.NewReader(`{"error": {"code": "NotFound", "message": "not found"}}`))
+			}
+			return response, nil
+		},
+	}
+
+	client := &UCPApplicationsManagementClient{
+		RootScope: testScope,
+		ClientOptions: &arm.ClientOptions{
+			ClientOptions: policy.ClientOptions{
+				Transport: transport,
+			},
+		},
+	}
+
+	list, err := client.ListDeadLetters(t.Context(), "radius")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "msg-1", list[0].ID)
+
+	msg, err := client.GetDeadLetter(t.Context(), "radius", "msg-1")
+	require.NoError(t, err)
+	require.Equal(t, "failed", msg.LastError)
+
+	_, err = client.GetDeadLetter(t.Context(), "radius", "unknown")
+	require.True(t, Is404Error(err))
+
+	msg, err = client.ReplayDeadLetter(t.Context(), "radius", "msg-1")
+	require.NoError(t, err)
+	require.Equal(t, "msg-1", msg.ID)
+
+	err = client.PurgeDeadLetter(t.Context(), "radius", "msg-1")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		http.MethodGet + " " + deadLetters,
+		http.MethodGet + " " + deadLetters + "/msg-1",
+		http.MethodGet + " " + deadLetters + "/unknown",
+		http.MethodPost + " " + deadLetters + "/msg-1/replay",
+		http.MethodDelete + " " + deadLetters + "/msg-1",
+	}, requests)
+}
+
 func Test_DeleteApplication_ForceQueryParameter(t *testing.T) {
 	t.Parallel()
 
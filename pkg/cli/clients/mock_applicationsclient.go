@@ -13,10 +13,13 @@ import (
 	context "context"
 	reflect "reflect"
 
+	history "github.com/radius-project/radius/pkg/armrpc/history"
 	generated "github.com/radius-project/radius/pkg/cli/clients_new/generated"
 	v20231001preview "github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
 	v20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	recipes "github.com/radius-project/radius/pkg/recipes"
 	v20231001preview0 "github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
+	deadletters "github.com/radius-project/radius/pkg/ucp/frontend/controller/deadletters"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -44,6 +47,45 @@ func (m *MockApplicationsManagementClient) EXPECT() *MockApplicationsManagementC
 	return m.recorder
 }
 
+// CancelResourceOperations mocks base method.
+func (m *MockApplicationsManagementClient) CancelResourceOperations(ctx context.Context, resourceType, resourceNameOrID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelResourceOperations", ctx, resourceType, resourceNameOrID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelResourceOperations indicates an expected call of CancelResourceOperations.
+func (mr *MockApplicationsManagementClientMockRecorder) CancelResourceOperations(ctx, resourceType, resourceNameOrID any) *MockApplicationsManagementClientCancelResourceOperationsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelResourceOperations", reflect.TypeOf((*MockApplicationsManagementClient)(nil).CancelResourceOperations), ctx, resourceType, resourceNameOrID)
+	return &MockApplicationsManagementClientCancelResourceOperationsCall{Call: call}
+}
+
+// MockApplicationsManagementClientCancelResourceOperationsCall wrap *gomock.Call
+type MockApplicationsManagementClientCancelResourceOperationsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockApplicationsManagementClientCancelResourceOperationsCall) Return(arg0 []string, arg1 error) *MockApplicationsManagementClientCancelResourceOperationsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockApplicationsManagementClientCancelResourceOperationsCall) Do(f func(context.Context, string, string) ([]string, error)) *MockApplicationsManagementClientCancelResourceOperationsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockApplicationsManagementClientCancelResourceOperationsCall) DoAndReturn(f func(context.Context, string, string) ([]string, error)) *MockApplicationsManagementClientCancelResourceOperationsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // CreateApplicationIfNotFound mocks base method.
 func (m *MockApplicationsManagementClient) CreateApplicationIfNotFound(ctx context.Context, applicationNameOrID string, resource *v20231001preview.ApplicationResource) error {
 	m.ctrl.T.Helper()
@@ -742,6 +784,45 @@ func (c *MockApplicationsManagementClientGetApplicationGraphCall) DoAndReturn(f
 	return c
 }
 
+// GetDeadLetter mocks base method.
+func (m *MockApplicationsManagementClient) GetDeadLetter(ctx context.Context, queueName, id string) (*deadletters.DeadLetterMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetter", ctx, queueName, id)
+	ret0, _ := ret[0].(*deadletters.DeadLetterMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetter indicates an expected call of GetDeadLetter.
+func (mr *MockApplicationsManagementClientMockRecorder) GetDeadLetter(ctx, queueName, id any) *MockApplicationsManagementClientGetDeadLetterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetter", reflect.TypeOf((*MockApplicationsManagementClient)(nil).GetDeadLetter), ctx, queueName, id)
+	return &MockApplicationsManagementClientGetDeadLetterCall{Call: call}
+}
+
+// MockApplicationsManagementClientGetDeadLetterCall wrap *gomock.Call
+type MockApplicationsManagementClientGetDeadLetterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockApplicationsManagementClientGetDeadLetterCall) Return(arg0 *deadletters.DeadLetterMessage, arg1 error) *MockApplicationsManagementClientGetDeadLetterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockApplicationsManagementClientGetDeadLetterCall) Do(f func(context.Context, string, string) (*deadletters.DeadLetterMessage, error)) *MockApplicationsManagementClientGetDeadLetterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockApplicationsManagementClientGetDeadLetterCall) DoAndReturn(f func(context.Context, string, string) (*deadletters.DeadLetterMessage, error)) *MockApplicationsManagementClientGetDeadLetterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetEnvironment mocks base method.
 func (m *MockApplicationsManagementClient) GetEnvironment(ctx context.Context, environmentNameOrID string) (v20231001preview.EnvironmentResource, error) {
 	m.ctrl.T.Helper()
@@ -937,6 +1018,45 @@ func (c *MockApplicationsManagementClientGetResourceGroupCall) DoAndReturn(f fun
 	return c
 }
 
+// GetResourceHistory mocks base method.
+func (m *MockApplicationsManagementClient) GetResourceHistory(ctx context.Context, resourceType, resourceNameOrID string) ([]*history.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceHistory", ctx, resourceType, resourceNameOrID)
+	ret0, _ := ret[0].([]*history.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceHistory indicates an expected call of GetResourceHistory.
+func (mr *MockApplicationsManagementClientMockRecorder) GetResourceHistory(ctx, resourceType, resourceNameOrID any) *MockApplicationsManagementClientGetResourceHistoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceHistory", reflect.TypeOf((*MockApplicationsManagementClient)(nil).GetResourceHistory), ctx, resourceType, resourceNameOrID)
+	return &MockApplicationsManagementClientGetResourceHistoryCall{Call: call}
+}
+
+// MockApplicationsManagementClientGetResourceHistoryCall wrap *gomock.Call
+type MockApplicationsManagementClientGetResourceHistoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockApplicationsManagementClientGetResourceHistoryCall) Return(arg0 []*history.Entry, arg1 error) *MockApplicationsManagementClientGetResourceHistoryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockApplicationsManagementClientGetResourceHistoryCall) Do(f func(context.Context, string, string) ([]*history.Entry, error)) *MockApplicationsManagementClientGetResourceHistoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockApplicationsManagementClientGetResourceHistoryCall) DoAndReturn(f func(context.Context, string, string) ([]*history.Entry, error)) *MockApplicationsManagementClientGetResourceHistoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetResourceProvider mocks base method.
 func (m *MockApplicationsManagementClient) GetResourceProvider(ctx context.Context, planeName, providerNamespace string) (v20231001preview0.ResourceProviderResource, error) {
 	m.ctrl.T.Helper()
@@ -1093,6 +1213,45 @@ func (c *MockApplicationsManagementClientListApplicationsCall) DoAndReturn(f fun
 	return c
 }
 
+// ListDeadLetters mocks base method.
+func (m *MockApplicationsManagementClient) ListDeadLetters(ctx context.Context, queueName string) ([]*deadletters.DeadLetterMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadLetters", ctx, queueName)
+	ret0, _ := ret[0].([]*deadletters.DeadLetterMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeadLetters indicates an expected call of ListDeadLetters.
+func (mr *MockApplicationsManagementClientMockRecorder) ListDeadLetters(ctx, queueName any) *MockApplicationsManagementClientListDeadLettersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadLetters", reflect.TypeOf((*MockApplicationsManagementClient)(nil).ListDeadLetters), ctx, queueName)
+	return &MockApplicationsManagementClientListDeadLettersCall{Call: call}
+}
+
+// MockApplicationsManagementClientListDeadLettersCall wrap *gomock.Call
+type MockApplicationsManagementClientListDeadLettersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockApplicationsManagementClientListDeadLettersCall) Return(arg0 []*deadletters.DeadLetterMessage, arg1 error) *MockApplicationsManagementClientListDeadLettersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockApplicationsManagementClientListDeadLettersCall) Do(f func(context.Context, string) ([]*deadletters.DeadLetterMessage, error)) *MockApplicationsManagementClientListDeadLettersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockApplicationsManagementClientListDeadLettersCall) DoAndReturn(f func(context.Context, string) ([]*deadletters.DeadLetterMessage, error)) *MockApplicationsManagementClientListDeadLettersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListEnvironments mocks base method.
 func (m *MockApplicationsManagementClient) ListEnvironments(ctx context.Context) ([]v20231001preview.EnvironmentResource, error) {
 	m.ctrl.T.Helper()
@@ -1755,3 +1914,158 @@ func (c *MockApplicationsManagementClientListResourcesOfTypeInResourceGroupFilte
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// PlanResourceRecipe mocks base method.
+func (m *MockApplicationsManagementClient) PlanResourceRecipe(ctx context.Context, resourceType, resourceNameOrID string) (*recipes.RecipePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanResourceRecipe", ctx, resourceType, resourceNameOrID)
+	ret0, _ := ret[0].(*recipes.RecipePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanResourceRecipe indicates an expected call of PlanResourceRecipe.
+func (mr *MockApplicationsManagementClientMockRecorder) PlanResourceRecipe(ctx, resourceType, resourceNameOrID any) *MockApplicationsManagementClientPlanResourceRecipeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanResourceRecipe", reflect.TypeOf((*MockApplicationsManagementClient)(nil).PlanResourceRecipe), ctx, resourceType, resourceNameOrID)
+	return &MockApplicationsManagementClientPlanResourceRecipeCall{Call: call}
+}
+
+// MockApplicationsManagementClientPlanResourceRecipeCall wrap *gomock.Call
+type MockApplicationsManagementClientPlanResourceRecipeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockApplicationsManagementClientPlanResourceRecipeCall) Return(arg0 *recipes.RecipePlan, arg1 error) *MockApplicationsManagementClientPlanResourceRecipeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockApplicationsManagementClientPlanResourceRecipeCall) Do(f func(context.Context, string, string) (*recipes.RecipePlan, error)) *MockApplicationsManagementClientPlanResourceRecipeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockApplicationsManagementClientPlanResourceRecipeCall) DoAndReturn(f func(context.Context, string, string) (*recipes.RecipePlan, error)) *MockApplicationsManagementClientPlanResourceRecipeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PurgeDeadLetter mocks base method.
+func (m *MockApplicationsManagementClient) PurgeDeadLetter(ctx context.Context, queueName, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeadLetter", ctx, queueName, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeDeadLetter indicates an expected call of PurgeDeadLetter.
+func (mr *MockApplicationsManagementClientMockRecorder) PurgeDeadLetter(ctx, queueName, id any) *MockApplicationsManagementClientPurgeDeadLetterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeadLetter", reflect.TypeOf((*MockApplicationsManagementClient)(nil).PurgeDeadLetter), ctx, queueName, id)
+	return &MockApplicationsManagementClientPurgeDeadLetterCall{Call: call}
+}
+
+// MockApplicationsManagementClientPurgeDeadLetterCall wrap *gomock.Call
+type MockApplicationsManagementClientPurgeDeadLetterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockApplicationsManagementClientPurgeDeadLetterCall) Return(arg0 error) *MockApplicationsManagementClientPurgeDeadLetterCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockApplicationsManagementClientPurgeDeadLetterCall) Do(f func(context.Context, string, string) error) *MockApplicationsManagementClientPurgeDeadLetterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockApplicationsManagementClientPurgeDeadLetterCall) DoAndReturn(f func(context.Context, string, string) error) *MockApplicationsManagementClientPurgeDeadLetterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ReplayDeadLetter mocks base method.
+func (m *MockApplicationsManagementClient) ReplayDeadLetter(ctx context.Context, queueName, id string) (*deadletters.DeadLetterMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDeadLetter", ctx, queueName, id)
+	ret0, _ := ret[0].(*deadletters.DeadLetterMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayDeadLetter indicates an expected call of ReplayDeadLetter.
+func (mr *MockApplicationsManagementClientMockRecorder) ReplayDeadLetter(ctx, queueName, id any) *MockApplicationsManagementClientReplayDeadLetterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDeadLetter", reflect.TypeOf((*MockApplicationsManagementClient)(nil).ReplayDeadLetter), ctx, queueName, id)
+	return &MockApplicationsManagementClientReplayDeadLetterCall{Call: call}
+}
+
+// MockApplicationsManagementClientReplayDeadLetterCall wrap *gomock.Call
+type MockApplicationsManagementClientReplayDeadLetterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockApplicationsManagementClientReplayDeadLetterCall) Return(arg0 *deadletters.DeadLetterMessage, arg1 error) *MockApplicationsManagementClientReplayDeadLetterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockApplicationsManagementClientReplayDeadLetterCall) Do(f func(context.Context, string, string) (*deadletters.DeadLetterMessage, error)) *MockApplicationsManagementClientReplayDeadLetterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockApplicationsManagementClientReplayDeadLetterCall) DoAndReturn(f func(context.Context, string, string) (*deadletters.DeadLetterMessage, error)) *MockApplicationsManagementClientReplayDeadLetterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// WatchResourcesInResourceGroup mocks base method.
+func (m *MockApplicationsManagementClient) WatchResourcesInResourceGroup(ctx context.Context, planeName, resourceGroupName string) (<-chan ResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchResourcesInResourceGroup", ctx, planeName, resourceGroupName)
+	ret0, _ := ret[0].(<-chan ResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchResourcesInResourceGroup indicates an expected call of WatchResourcesInResourceGroup.
+func (mr *MockApplicationsManagementClientMockRecorder) WatchResourcesInResourceGroup(ctx, planeName, resourceGroupName any) *MockApplicationsManagementClientWatchResourcesInResourceGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchResourcesInResourceGroup", reflect.TypeOf((*MockApplicationsManagementClient)(nil).WatchResourcesInResourceGroup), ctx, planeName, resourceGroupName)
+	return &MockApplicationsManagementClientWatchResourcesInResourceGroupCall{Call: call}
+}
+
+// MockApplicationsManagementClientWatchResourcesInResourceGroupCall wrap *gomock.Call
+type MockApplicationsManagementClientWatchResourcesInResourceGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockApplicationsManagementClientWatchResourcesInResourceGroupCall) Return(arg0 <-chan ResourceChange, arg1 error) *MockApplicationsManagementClientWatchResourcesInResourceGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockApplicationsManagementClientWatchResourcesInResourceGroupCall) Do(f func(context.Context, string, string) (<-chan ResourceChange, error)) *MockApplicationsManagementClientWatchResourcesInResourceGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockApplicationsManagementClientWatchResourcesInResourceGroupCall) DoAndReturn(f func(context.Context, string, string) (<-chan ResourceChange, error)) *MockApplicationsManagementClientWatchResourcesInResourceGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotate
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radius-project/radius/pkg/cli"
+	"github.com/radius-project/radius/pkg/cli/clierrors"
+	"github.com/radius-project/radius/pkg/cli/cmd/commonflags"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/kubernetes"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/prompt"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	"github.com/radius-project/radius/pkg/crypto/encryption"
+)
+
+const rotateConfirmation = "Are you sure you want to rotate the encryption key? The sensitive fields of every resource will be re-encrypted."
+
+// KeyRotationRequester requests the rotation of the encryption key.
+type KeyRotationRequester interface {
+	// RequestKeyRotation requests a key rotation and returns the key store before the rotation.
+	RequestKeyRotation(ctx context.Context, now time.Time) (*encryption.KeyRotation, error)
+}
+
+// NewCommand creates an instance of the command and runner for the `rad admin keys rotate` command.
+func NewCommand(factory framework.Factory) (*cobra.Command, framework.Runner) {
+	runner := NewRunner(factory)
+
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotate the key used to encrypt sensitive fields",
+		Long: `Rotates the key used to encrypt the sensitive fields of resources.
+
+The rotation is performed in the background by the dynamic-rp: it adds a new key version, re-encrypts the
+sensitive fields of every resource with it, and retires the previous versions once no resource references
+them. The progress is recorded in the 'radius.dev/key-rotation-status' annotation of the
+'radius-encryption-key' Secret.
+
+Keys can only be rotated when they are stored in the 'radius-encryption-key' Secret, which is the default.
+The command requires a workspace connected to a Kubernetes cluster.`,
+		Example: `
+# Rotate the encryption key
+rad admin keys rotate
+
+# Rotate the encryption key without a confirmation prompt
+rad admin keys rotate --yes`,
+		Args: cobra.ExactArgs(0),
+		RunE: framework.RunCommand(runner),
+	}
+
+	commonflags.AddWorkspaceFlag(cmd)
+	commonflags.AddConfirmationFlag(cmd)
+
+	return cmd, runner
+}
+
+// Runner is the runner implementation for the `rad admin keys rotate` command.
+type Runner struct {
+	ConfigHolder  *framework.ConfigHolder
+	Output        output.Interface
+	InputPrompter prompt.Interface
+	Workspace     *workspaces.Workspace
+	Confirm       bool
+
+	// newRequester creates the KeyRotationRequester for a Kubernetes context. It can be replaced in tests.
+	newRequester func(kubeContext string) (KeyRotationRequester, error)
+}
+
+// NewRunner creates a new instance of the `rad admin keys rotate` runner.
+func NewRunner(factory framework.Factory) *Runner {
+	return &Runner{
+		ConfigHolder:  factory.GetConfigHolder(),
+		Output:        factory.GetOutput(),
+		InputPrompter: factory.GetPrompter(),
+		newRequester: func(kubeContext string) (KeyRotationRequester, error) {
+			client, err := kubernetes.NewRuntimeClient(kubeContext, kubernetes.Scheme)
+			if err != nil {
+				return nil, err
+			}
+			return encryption.NewKubernetesKeyProvider(client, nil), nil
+		},
+	}
+}
+
+// Validate runs validation for the `rad admin keys rotate` command.
+func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
+	workspace, err := cli.RequireWorkspace(cmd, r.ConfigHolder.Config)
+	if err != nil {
+		return err
+	}
+	r.Workspace = workspace
+
+	if _, ok := workspace.KubernetesContext(); !ok {
+		return clierrors.Message("The 'rad admin keys rotate' command requires a workspace connected to a Kubernetes cluster. Workspace %q is not connected to a Kubernetes cluster.", workspace.Name)
+	}
+
+	r.Confirm, err = cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run runs the `rad admin keys rotate` command.
+func (r *Runner) Run(ctx context.Context) error {
+	if !r.Confirm {
+		confirmed, err := prompt.YesOrNoPrompt(rotateConfirmation, prompt.ConfirmNo, r.InputPrompter)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	kubeContext, ok := r.Workspace.KubernetesContext()
+	if !ok {
+		return clierrors.Message("Could not determine the Kubernetes context for workspace %q.", r.Workspace.Name)
+	}
+
+	requester, err := r.newRequester(kubeContext)
+	if err != nil {
+		return err
+	}
+
+	rotation, err := requester.RequestKeyRotation(ctx, time.Now())
+	if err != nil {
+		return clierrors.MessageWithCause(err, "Failed to request the rotation of the encryption key.")
+	}
+
+	if rotation.Status != nil && rotation.Status.State != encryption.KeyRotationStateCompleted {
+		r.Output.LogInfo("The rotation to key version %d is still in progress. It is superseded by the new rotation.", rotation.Status.Version)
+	}
+
+	r.Output.LogInfo("Requested the rotation of the encryption key. The current key version is %d.", rotation.KeyStore.CurrentVersion)
+	r.Output.LogInfo("The sensitive fields are re-encrypted in the background. The progress is recorded in the %q annotation of the %q Secret.",
+		encryption.KeyRotationStatusAnnotation, encryption.DefaultEncryptionKeySecretName)
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/scheme"
+
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/prompt"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	"github.com/radius-project/radius/pkg/crypto/encryption"
+	"github.com/radius-project/radius/test/k8sutil"
+	"github.com/radius-project/radius/test/radcli"
+)
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+	nonKubernetesConfig := radcli.LoadConfig(t, `
+workspaces:
+  default: github-workspace
+  items:
+    github-workspace:
+      connection:
+        kind: notkubernetes
+      environment: /planes/radius/local/resourceGroups/test/providers/Applications.Core/environments/test
+      scope: /planes/radius/local/resourceGroups/test
+`)
+
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "Valid: confirmed",
+			Input:         []string{"--yes"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				r := runner.(*Runner)
+				require.True(t, r.Confirm)
+			},
+		},
+		{
+			Name:          "Invalid: too many arguments",
+			Input:         []string{"now"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Invalid: not a Kubernetes workspace",
+			Input:         []string{},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: nonKubernetesConfig},
+		},
+	}
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func Test_Run(t *testing.T) {
+	keysJSON, err := json.Marshal(&encryption.KeyStore{
+		CurrentVersion: 3,
+		Keys:           map[string]encryption.KeyData{"3": {Key: "a2V5", Version: 3}},
+	})
+	require.NoError(t, err)
+
+	newRunner := func(t *testing.T, promptMock prompt.Interface, confirm bool) (*Runner, *encryption.KubernetesKeyProvider, *output.MockOutput) {
+		client := k8sutil.NewFakeKubeClient(scheme.Scheme)
+		require.NoError(t, client.Create(t.Context(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      encryption.DefaultEncryptionKeySecretName,
+				Namespace: encryption.RadiusNamespace,
+			},
+			Data: map[string][]byte{encryption.DefaultEncryptionKeySecretKey: keysJSON},
+		}))
+		store := encryption.NewKubernetesKeyProvider(client, nil)
+
+		outputSink := &output.MockOutput{}
+		return &Runner{
+			Output:        outputSink,
+			InputPrompter: promptMock,
+			Workspace: &workspaces.Workspace{
+				Name:       "test",
+				Connection: map[string]any{"kind": workspaces.KindKubernetes, "context": "k3d-test"},
+			},
+			Confirm: confirm,
+			newRequester: func(kubeContext string) (KeyRotationRequester, error) {
+				require.Equal(t, "k3d-test", kubeContext)
+				return store, nil
+			},
+		}, store, outputSink
+	}
+
+	t.Run("Confirmed", func(t *testing.T) {
+		runner, store, outputSink := newRunner(t, nil, true)
+		require.NoError(t, runner.Run(t.Context()))
+
+		rotation, err := store.LoadKeyRotation(t.Context())
+		require.NoError(t, err)
+		require.NotEmpty(t, rotation.RequestedAt)
+
+		expected := []any{
+			output.LogOutput{
+				Format: "Requested the rotation of the encryption key. The current key version is %d.",
+				Params: []any{3},
+			},
+			output.LogOutput{
+				Format: "The sensitive fields are re-encrypted in the background. The progress is recorded in the %q annotation of the %q Secret.",
+				Params: []any{encryption.KeyRotationStatusAnnotation, encryption.DefaultEncryptionKeySecretName},
+			},
+		}
+		require.Equal(t, expected, outputSink.Writes)
+	})
+
+	t.Run("Declined", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		promptMock := prompt.NewMockInterface(ctrl)
+		promptMock.EXPECT().
+			GetListInput([]string{prompt.ConfirmNo, prompt.ConfirmYes}, rotateConfirmation).
+			Return(prompt.ConfirmNo, nil).
+			Times(1)
+
+		runner, store, outputSink := newRunner(t, promptMock, false)
+		require.NoError(t, runner.Run(t.Context()))
+		require.Empty(t, outputSink.Writes)
+
+		rotation, err := store.LoadKeyRotation(t.Context())
+		require.NoError(t, err)
+		require.Empty(t, rotation.RequestedAt)
+	})
+}
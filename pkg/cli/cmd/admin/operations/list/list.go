@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radius-project/radius/pkg/cli"
+	"github.com/radius-project/radius/pkg/cli/cmd/admin/operations"
+	"github.com/radius-project/radius/pkg/cli/cmd/commonflags"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+)
+
+// NewCommand creates an instance of the command and runner for the `rad admin operations list` command.
+func NewCommand(factory framework.Factory) (*cobra.Command, framework.Runner) {
+	runner := NewRunner(factory)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the operations in the dead-letter queue",
+		Long: `Lists the operations in the dead-letter queue.
+
+An operation is moved to the dead-letter queue when it fails more than the maximum retry count, for
+example because the resource provider crashed while processing it. The resource is marked as Failed
+and the operation is kept with its last error so that it can be inspected, replayed or purged.`,
+		Example: `
+# List the dead-lettered operations on Radius resources
+rad admin operations list
+
+# List the dead-lettered operations of the dynamic resource provider in JSON format
+rad admin operations list --queue dynamic-rp --output json`,
+		Args: cobra.NoArgs,
+		RunE: framework.RunCommand(runner),
+	}
+
+	commonflags.AddWorkspaceFlag(cmd)
+	commonflags.AddOutputFlag(cmd)
+	operations.AddQueueFlag(cmd)
+
+	return cmd, runner
+}
+
+// Runner is the runner implementation for the `rad admin operations list` command.
+type Runner struct {
+	ConfigHolder      *framework.ConfigHolder
+	ConnectionFactory connections.Factory
+	Output            output.Interface
+	Workspace         *workspaces.Workspace
+	Queue             string
+	Format            string
+}
+
+// NewRunner creates a new instance of the `rad admin operations list` runner.
+func NewRunner(factory framework.Factory) *Runner {
+	return &Runner{
+		ConfigHolder:      factory.GetConfigHolder(),
+		ConnectionFactory: factory.GetConnectionFactory(),
+		Output:            factory.GetOutput(),
+	}
+}
+
+// Validate runs validation for the `rad admin operations list` command.
+func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
+	workspace, err := cli.RequireWorkspace(cmd, r.ConfigHolder.Config)
+	if err != nil {
+		return err
+	}
+	r.Workspace = workspace
+
+	r.Queue, err = operations.RequireQueue(cmd)
+	if err != nil {
+		return err
+	}
+
+	r.Format, err = cli.RequireOutput(cmd)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run runs the `rad admin operations list` command.
+func (r *Runner) Run(ctx context.Context) error {
+	client, err := r.ConnectionFactory.CreateApplicationsManagementClient(ctx, *r.Workspace)
+	if err != nil {
+		return err
+	}
+
+	messages, err := client.ListDeadLetters(ctx, r.Queue)
+	if err != nil {
+		return err
+	}
+
+	if len(messages) == 0 && r.Format != output.FormatJson {
+		r.Output.LogInfo("The dead-letter queue of %q is empty", r.Queue)
+		return nil
+	}
+
+	return r.Output.WriteFormatted(r.Format, messages, objectformats.GetDeadLetterTableFormat())
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	"github.com/radius-project/radius/pkg/ucp/frontend/controller/deadletters"
+	"github.com/radius-project/radius/test/radcli"
+)
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "Valid: default queue",
+			Input:         []string{},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				require.Equal(t, "radius", runner.(*Runner).Queue)
+			},
+		},
+		{
+			Name:          "Valid: queue and output",
+			Input:         []string{"--queue", "dynamic-rp", "-o", "json"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				r := runner.(*Runner)
+				require.Equal(t, "dynamic-rp", r.Queue)
+				require.Equal(t, output.FormatJson, r.Format)
+			},
+		},
+		{
+			Name:          "Invalid: empty queue",
+			Input:         []string{"--queue", ""},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Invalid: too many arguments",
+			Input:         []string{"foo"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+	}
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func Test_Run(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		messages := []*deadletters.DeadLetterMessage{{ID: "1", Queue: "radius", DequeueCount: 6, LastError: "failed"}}
+
+		client := clients.NewMockApplicationsManagementClient(ctrl)
+		client.EXPECT().ListDeadLetters(gomock.Any(), "radius").Return(messages, nil).Times(1)
+
+		outputSink := &output.MockOutput{}
+		runner := &Runner{
+			ConnectionFactory: &connections.MockFactory{ApplicationsManagementClient: client},
+			Output:            outputSink,
+			Workspace:         &workspaces.Workspace{},
+			Queue:             "radius",
+			Format:            output.FormatTable,
+		}
+		require.NoError(t, runner.Run(t.Context()))
+
+		expected := []any{
+			output.FormattedOutput{
+				Format:  output.FormatTable,
+				Obj:     messages,
+				Options: objectformats.GetDeadLetterTableFormat(),
+			},
+		}
+		require.Equal(t, expected, outputSink.Writes)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		client := clients.NewMockApplicationsManagementClient(ctrl)
+		client.EXPECT().ListDeadLetters(gomock.Any(), "ucp").Return([]*deadletters.DeadLetterMessage{}, nil).Times(1)
+
+		outputSink := &output.MockOutput{}
+		runner := &Runner{
+			ConnectionFactory: &connections.MockFactory{ApplicationsManagementClient: client},
+			Output:            outputSink,
+			Workspace:         &workspaces.Workspace{},
+			Queue:             "ucp",
+			Format:            output.FormatTable,
+		}
+		require.NoError(t, runner.Run(t.Context()))
+
+		expected := []any{
+			output.LogOutput{Format: "The dead-letter queue of %q is empty", Params: []any{"ucp"}},
+		}
+		require.Equal(t, expected, outputSink.Writes)
+	})
+}
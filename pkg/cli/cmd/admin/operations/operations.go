@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package operations contains the shared flags of the `rad admin operations` commands, which manage
+// the async operations moved to the dead-letter queue after they failed repeatedly.
+package operations
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/radius-project/radius/pkg/cli/clierrors"
+)
+
+// DefaultQueue is the queue of the Applications RP, which processes the operations on Radius resources.
+const DefaultQueue = "radius"
+
+// AddQueueFlag adds the flag to select the queue whose dead-letter queue is managed.
+func AddQueueFlag(cmd *cobra.Command) {
+	cmd.Flags().String("queue", DefaultQueue, "the queue of the operations, one of radius, dynamic-rp, ucp or controller")
+}
+
+// RequireQueue returns the value of the queue flag.
+func RequireQueue(cmd *cobra.Command) (string, error) {
+	queue, err := cmd.Flags().GetString("queue")
+	if err != nil {
+		return "", err
+	}
+
+	if queue == "" {
+		return "", clierrors.Message("The --queue flag cannot be empty.")
+	}
+
+	return queue, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package purge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radius-project/radius/pkg/cli"
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/clierrors"
+	"github.com/radius-project/radius/pkg/cli/cmd/admin/operations"
+	"github.com/radius-project/radius/pkg/cli/cmd/commonflags"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/prompt"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+)
+
+const purgeConfirmation = "Are you sure you want to purge operation '%v' from the dead-letter queue of '%v'? It cannot be replayed afterwards."
+
+// NewCommand creates an instance of the command and runner for the `rad admin operations purge` command.
+func NewCommand(factory framework.Factory) (*cobra.Command, framework.Runner) {
+	runner := NewRunner(factory)
+
+	cmd := &cobra.Command{
+		Use:   "purge [messageId]",
+		Short: "Purge an operation from the dead-letter queue",
+		Long: `Purges an operation from the dead-letter queue.
+
+The operation is deleted and cannot be replayed anymore. The resource stays in the Failed state until
+it is deployed or deleted again.`,
+		Example: `
+# Purge a dead-lettered operation without a confirmation prompt
+rad admin operations purge 2b8c1b5e-1a0e-4d8f-9f53-7bd3e8f5a6c1 --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: framework.RunCommand(runner),
+	}
+
+	commonflags.AddWorkspaceFlag(cmd)
+	commonflags.AddConfirmationFlag(cmd)
+	operations.AddQueueFlag(cmd)
+
+	return cmd, runner
+}
+
+// Runner is the runner implementation for the `rad admin operations purge` command.
+type Runner struct {
+	ConfigHolder      *framework.ConfigHolder
+	ConnectionFactory connections.Factory
+	Output            output.Interface
+	InputPrompter     prompt.Interface
+	Workspace         *workspaces.Workspace
+	Queue             string
+	MessageID         string
+	Confirm           bool
+}
+
+// NewRunner creates a new instance of the `rad admin operations purge` runner.
+func NewRunner(factory framework.Factory) *Runner {
+	return &Runner{
+		ConfigHolder:      factory.GetConfigHolder(),
+		ConnectionFactory: factory.GetConnectionFactory(),
+		Output:            factory.GetOutput(),
+		InputPrompter:     factory.GetPrompter(),
+	}
+}
+
+// Validate runs validation for the `rad admin operations purge` command.
+func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
+	workspace, err := cli.RequireWorkspace(cmd, r.ConfigHolder.Config)
+	if err != nil {
+		return err
+	}
+	r.Workspace = workspace
+	r.MessageID = args[0]
+
+	r.Queue, err = operations.RequireQueue(cmd)
+	if err != nil {
+		return err
+	}
+
+	r.Confirm, err = cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run runs the `rad admin operations purge` command.
+func (r *Runner) Run(ctx context.Context) error {
+	if !r.Confirm {
+		confirmed, err := prompt.YesOrNoPrompt(fmt.Sprintf(purgeConfirmation, r.MessageID, r.Queue), prompt.ConfirmNo, r.InputPrompter)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	client, err := r.ConnectionFactory.CreateApplicationsManagementClient(ctx, *r.Workspace)
+	if err != nil {
+		return err
+	}
+
+	err = client.PurgeDeadLetter(ctx, r.Queue, r.MessageID)
+	if clients.Is404Error(err) {
+		return clierrors.Message("The operation %q is not in the dead-letter queue of %q.", r.MessageID, r.Queue)
+	} else if err != nil {
+		return err
+	}
+
+	r.Output.LogInfo("Operation %s purged from the dead-letter queue of %q", r.MessageID, r.Queue)
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package purge
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/prompt"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	"github.com/radius-project/radius/test/radcli"
+)
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "Valid: confirmed",
+			Input:         []string{"1", "--yes"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				r := runner.(*Runner)
+				require.Equal(t, "1", r.MessageID)
+				require.True(t, r.Confirm)
+			},
+		},
+		{
+			Name:          "Invalid: missing message id",
+			Input:         []string{},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+	}
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func Test_Run(t *testing.T) {
+	t.Run("Confirmed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		promptMock := prompt.NewMockInterface(ctrl)
+		promptMock.EXPECT().
+			GetListInput([]string{prompt.ConfirmNo, prompt.ConfirmYes}, fmt.Sprintf(purgeConfirmation, "1", "radius")).
+			Return(prompt.ConfirmYes, nil).
+			Times(1)
+
+		client := clients.NewMockApplicationsManagementClient(ctrl)
+		client.EXPECT().PurgeDeadLetter(gomock.Any(), "radius", "1").Return(nil).Times(1)
+
+		outputSink := &output.MockOutput{}
+		runner := &Runner{
+			ConnectionFactory: &connections.MockFactory{ApplicationsManagementClient: client},
+			Output:            outputSink,
+			InputPrompter:     promptMock,
+			Workspace:         &workspaces.Workspace{},
+			Queue:             "radius",
+			MessageID:         "1",
+		}
+		require.NoError(t, runner.Run(t.Context()))
+
+		expected := []any{
+			output.LogOutput{
+				Format: "Operation %s purged from the dead-letter queue of %q",
+				Params: []any{"1", "radius"},
+			},
+		}
+		require.Equal(t, expected, outputSink.Writes)
+	})
+
+	t.Run("Declined", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		promptMock := prompt.NewMockInterface(ctrl)
+		promptMock.EXPECT().
+			GetListInput([]string{prompt.ConfirmNo, prompt.ConfirmYes}, fmt.Sprintf(purgeConfirmation, "1", "radius")).
+			Return(prompt.ConfirmNo, nil).
+			Times(1)
+
+		outputSink := &output.MockOutput{}
+		runner := &Runner{
+			ConnectionFactory: &connections.MockFactory{ApplicationsManagementClient: clients.NewMockApplicationsManagementClient(ctrl)},
+			Output:            outputSink,
+			InputPrompter:     promptMock,
+			Workspace:         &workspaces.Workspace{},
+			Queue:             "radius",
+			MessageID:         "1",
+		}
+		require.NoError(t, runner.Run(t.Context()))
+		require.Empty(t, outputSink.Writes)
+	})
+}
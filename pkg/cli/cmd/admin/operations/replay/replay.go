@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radius-project/radius/pkg/cli"
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/clierrors"
+	"github.com/radius-project/radius/pkg/cli/cmd/admin/operations"
+	"github.com/radius-project/radius/pkg/cli/cmd/commonflags"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+)
+
+// NewCommand creates an instance of the command and runner for the `rad admin operations replay` command.
+func NewCommand(factory framework.Factory) (*cobra.Command, framework.Runner) {
+	runner := NewRunner(factory)
+
+	cmd := &cobra.Command{
+		Use:   "replay [messageId]",
+		Short: "Replay an operation in the dead-letter queue",
+		Long: `Replays an operation in the dead-letter queue.
+
+The operation is moved back to its queue with its retry count reset, and runs again from the start
+against the current state of the resource. Its status goes back to Accepted until it is processed.`,
+		Example: `
+# Replay a dead-lettered operation
+rad admin operations replay 2b8c1b5e-1a0e-4d8f-9f53-7bd3e8f5a6c1`,
+		Args: cobra.ExactArgs(1),
+		RunE: framework.RunCommand(runner),
+	}
+
+	commonflags.AddWorkspaceFlag(cmd)
+	operations.AddQueueFlag(cmd)
+
+	return cmd, runner
+}
+
+// Runner is the runner implementation for the `rad admin operations replay` command.
+type Runner struct {
+	ConfigHolder      *framework.ConfigHolder
+	ConnectionFactory connections.Factory
+	Output            output.Interface
+	Workspace         *workspaces.Workspace
+	Queue             string
+	MessageID         string
+}
+
+// NewRunner creates a new instance of the `rad admin operations replay` runner.
+func NewRunner(factory framework.Factory) *Runner {
+	return &Runner{
+		ConfigHolder:      factory.GetConfigHolder(),
+		ConnectionFactory: factory.GetConnectionFactory(),
+		Output:            factory.GetOutput(),
+	}
+}
+
+// Validate runs validation for the `rad admin operations replay` command.
+func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
+	workspace, err := cli.RequireWorkspace(cmd, r.ConfigHolder.Config)
+	if err != nil {
+		return err
+	}
+	r.Workspace = workspace
+	r.MessageID = args[0]
+
+	r.Queue, err = operations.RequireQueue(cmd)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run runs the `rad admin operations replay` command.
+func (r *Runner) Run(ctx context.Context) error {
+	client, err := r.ConnectionFactory.CreateApplicationsManagementClient(ctx, *r.Workspace)
+	if err != nil {
+		return err
+	}
+
+	msg, err := client.ReplayDeadLetter(ctx, r.Queue, r.MessageID)
+	if clients.Is404Error(err) {
+		return clierrors.Message("The operation %q is not in the dead-letter queue of %q.", r.MessageID, r.Queue)
+	} else if err != nil {
+		return err
+	}
+
+	if msg.ResourceID == "" {
+		r.Output.LogInfo("Message %s was moved back to the %q queue", msg.ID, r.Queue)
+		return nil
+	}
+
+	r.Output.LogInfo("Operation %s on %s was moved back to the %q queue", msg.OperationType, msg.ResourceID, r.Queue)
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package show
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	"github.com/radius-project/radius/pkg/ucp/frontend/controller/deadletters"
+	"github.com/radius-project/radius/test/radcli"
+)
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "Valid: message id",
+			Input:         []string{"1", "--queue", "ucp"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				r := runner.(*Runner)
+				require.Equal(t, "1", r.MessageID)
+				require.Equal(t, "ucp", r.Queue)
+			},
+		},
+		{
+			Name:          "Invalid: missing message id",
+			Input:         []string{},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+	}
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func Test_Run(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		msg := &deadletters.DeadLetterMessage{ID: "1", Queue: "radius", Data: []byte(`{}`)}
+
+		client := clients.NewMockApplicationsManagementClient(ctrl)
+		client.EXPECT().GetDeadLetter(gomock.Any(), "radius", "1").Return(msg, nil).Times(1)
+
+		outputSink := &output.MockOutput{}
+		runner := &Runner{
+			ConnectionFactory: &connections.MockFactory{ApplicationsManagementClient: client},
+			Output:            outputSink,
+			Workspace:         &workspaces.Workspace{},
+			Queue:             "radius",
+			MessageID:         "1",
+			Format:            output.FormatJson,
+		}
+		require.NoError(t, runner.Run(t.Context()))
+
+		expected := []any{
+			output.FormattedOutput{
+				Format:  output.FormatJson,
+				Obj:     msg,
+				Options: objectformats.GetDeadLetterTableFormat(),
+			},
+		}
+		require.Equal(t, expected, outputSink.Writes)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		notFound := runtime.NewResponseError(&http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       http.NoBody,
+			Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "url"}},
+		})
+
+		client := clients.NewMockApplicationsManagementClient(ctrl)
+		client.EXPECT().GetDeadLetter(gomock.Any(), "radius", "1").Return(nil, notFound).Times(1)
+
+		runner := &Runner{
+			ConnectionFactory: &connections.MockFactory{ApplicationsManagementClient: client},
+			Output:            &output.MockOutput{},
+			Workspace:         &workspaces.Workspace{},
+			Queue:             "radius",
+			MessageID:         "1",
+		}
+		err := runner.Run(t.Context())
+		require.ErrorContains(t, err, `The operation "1" is not in the dead-letter queue of "radius".`)
+	})
+}
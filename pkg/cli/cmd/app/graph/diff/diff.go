@@ -0,0 +1,341 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diff implements the `rad app graph diff` command, which compares two application graphs.
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radius-project/radius/pkg/cli"
+	"github.com/radius-project/radius/pkg/cli/bicep"
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/clierrors"
+	"github.com/radius-project/radius/pkg/cli/cmd"
+	"github.com/radius-project/radius/pkg/cli/cmd/commonflags"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	cligraph "github.com/radius-project/radius/pkg/cli/graph"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	corerpv20250801 "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/graph/persistence"
+	gitstore "github.com/radius-project/radius/pkg/graph/persistence/git"
+)
+
+const (
+	// FormatMarkdown renders the diff as a Markdown summary for pull request comments. It is
+	// accepted by this command in addition to the formats of output.SupportedFormats.
+	FormatMarkdown = "markdown"
+
+	archivePrefix  = "archive:"
+	deployedPrefix = "deployed:"
+	jsonExtension  = ".json"
+	bicepExtension = ".bicep"
+)
+
+// sourceKind identifies where one side of the diff is read from.
+type sourceKind string
+
+const (
+	sourceFile     sourceKind = "file"
+	sourceBicep    sourceKind = "bicep"
+	sourceArchive  sourceKind = "archive"
+	sourceDeployed sourceKind = "deployed"
+)
+
+// GraphSource is one side of the diff.
+type GraphSource struct {
+	// Kind is where the graph is read from.
+	Kind sourceKind
+
+	// Value is the file path, archive branch or application name, depending on Kind.
+	Value string
+
+	// Label is the argument the user passed, used to name the side in the output.
+	Label string
+}
+
+// parseGraphSource parses a positional argument of the command.
+func parseGraphSource(arg string) (GraphSource, error) {
+	source := GraphSource{Label: arg}
+	switch {
+	case strings.HasPrefix(arg, archivePrefix):
+		source.Kind, source.Value = sourceArchive, strings.TrimPrefix(arg, archivePrefix)
+	case strings.HasPrefix(arg, deployedPrefix):
+		source.Kind, source.Value = sourceDeployed, strings.TrimPrefix(arg, deployedPrefix)
+	case strings.EqualFold(filepath.Ext(arg), jsonExtension):
+		source.Kind, source.Value = sourceFile, arg
+	case strings.EqualFold(filepath.Ext(arg), bicepExtension):
+		source.Kind, source.Value = sourceBicep, arg
+	default:
+		return GraphSource{}, clierrors.Message("Cannot compare %q. Specify an app-graph.json or app.bicep file, archive:<branch> or deployed:<application>.", arg)
+	}
+
+	if source.Value == "" {
+		return GraphSource{}, clierrors.Message("Cannot compare %q. A branch or application name is required after the prefix.", arg)
+	}
+	return source, nil
+}
+
+// NewCommand creates an instance of the `rad app graph diff` command and runner.
+func NewCommand(factory framework.Factory) (*cobra.Command, framework.Runner) {
+	runner := NewRunner(factory)
+
+	cmd := &cobra.Command{
+		Use:   "diff <before> <after>",
+		Short: "Compares two application graphs",
+		Long: `Compares two application graphs and reports the resources that were added, removed or
+modified, and the connections that were added or removed.
+
+Each side of the comparison is one of:
+  - a modeled graph file written by 'rad app graph <app.bicep>', such as ./app-graph.json
+  - an app.bicep file, compiled into its modeled graph
+  - archive:<branch>, the modeled graph saved for a branch in the radius-graph archive
+  - deployed:<application>, the live graph of a deployed application
+
+Resources are matched by type and name, so graphs from different resource groups can be compared.
+Resources are compared by their diff hash when both sides carry one, and otherwise by their
+authored properties.
+
+Use '--output markdown' to produce a summary suitable for a pull request comment.`,
+		Example: `
+# Compare the modeled graph of a pull request branch with main
+rad app graph diff archive:main archive:feature/cache
+
+# Compare a local modeled graph with the deployed application
+rad app graph diff deployed:my-app ./app-graph.json
+
+# Produce a Markdown summary for a pull request comment
+rad app graph diff archive:main ./app.bicep --output markdown`,
+		Args: cobra.ExactArgs(2),
+		RunE: framework.RunCommand(runner),
+	}
+
+	commonflags.AddWorkspaceFlag(cmd)
+	commonflags.AddResourceGroupFlag(cmd)
+	description := fmt.Sprintf("output format (supported formats are %s)", strings.Join(append(output.SupportedFormats(), FormatMarkdown), ", "))
+	cmd.Flags().StringP("output", "o", output.DefaultFormat, description)
+
+	return cmd, runner
+}
+
+// Runner is the runner implementation for the `rad app graph diff` command.
+type Runner struct {
+	ConfigHolder *framework.ConfigHolder
+	Output       output.Interface
+	Bicep        bicep.Interface
+
+	// GraphStore reads modeled graphs from the radius-graph archive.
+	GraphStore persistence.Store
+
+	// RadiusCoreClientFactory reads deployed graphs. Initialized on demand; tests may substitute
+	// a fake.
+	RadiusCoreClientFactory *corerpv20250801.ClientFactory
+
+	// Workspace is set only when a side is a deployed graph.
+	Workspace *workspaces.Workspace
+	Before    GraphSource
+	After     GraphSource
+	Format    string
+}
+
+// NewRunner creates a new instance of the `rad app graph diff` runner.
+func NewRunner(factory framework.Factory) *Runner {
+	return &Runner{
+		ConfigHolder: factory.GetConfigHolder(),
+		Output:       factory.GetOutput(),
+		Bicep:        factory.GetBicep(),
+		GraphStore:   factory.GetGraphStore(),
+	}
+}
+
+// Validate runs validation for the `rad app graph diff` command.
+func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
+	var err error
+	r.Before, err = parseGraphSource(args[0])
+	if err != nil {
+		return err
+	}
+	r.After, err = parseGraphSource(args[1])
+	if err != nil {
+		return err
+	}
+
+	format, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(strings.TrimSpace(format), FormatMarkdown) {
+		r.Format = FormatMarkdown
+	} else if r.Format, err = cli.RequireOutput(cmd); err != nil {
+		return err
+	}
+
+	if r.Before.Kind == sourceArchive || r.After.Kind == sourceArchive {
+		if r.GraphStore == nil {
+			return clierrors.Message("Modeled graph store is not configured.")
+		}
+	}
+
+	if r.Before.Kind == sourceDeployed || r.After.Kind == sourceDeployed {
+		r.Workspace, err = cli.RequireWorkspace(cmd, r.ConfigHolder.Config)
+		if err != nil {
+			return err
+		}
+		r.Workspace.Scope, err = cli.RequireScope(cmd, *r.Workspace)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run runs the `rad app graph diff` command.
+func (r *Runner) Run(ctx context.Context) error {
+	before, err := r.load(ctx, r.Before)
+	if err != nil {
+		return err
+	}
+	after, err := r.load(ctx, r.After)
+	if err != nil {
+		return err
+	}
+
+	diff, err := cligraph.DiffGraphs(before, after)
+	if err != nil {
+		return clierrors.MessageWithCause(err, "Failed to compare %q with %q.", r.Before.Label, r.After.Label)
+	}
+
+	switch r.Format {
+	case output.FormatJson:
+		return r.Output.WriteFormatted(r.Format, diff, output.FormatterOptions{})
+	case FormatMarkdown:
+		r.Output.LogInfo("%s", strings.TrimSuffix(diff.Markdown(r.Before.Label, r.After.Label), "\n"))
+		return nil
+	default:
+		return WriteDiff(r.Output, diff, r.Before.Label, r.After.Label)
+	}
+}
+
+// WriteDiff writes diff as a summary followed by tables of the changed resources and
+// connections. before and after name the two sides of the comparison.
+func WriteDiff(out output.Interface, diff *cligraph.GraphDiff, before string, after string) error {
+	if !diff.HasChanges() {
+		out.LogInfo("No changes between %s and %s. %d resources unchanged.", before, after, diff.Count(cligraph.ChangeUnchanged))
+		return nil
+	}
+
+	out.LogInfo("Comparing %s with %s: %d added, %d removed, %d modified, %d unchanged.",
+		before, after,
+		diff.Count(cligraph.ChangeAdded), diff.Count(cligraph.ChangeRemoved), diff.Count(cligraph.ChangeModified), diff.Count(cligraph.ChangeUnchanged))
+
+	if changes := diff.Changes(); len(changes) > 0 {
+		out.LogInfo("")
+		if err := out.WriteFormatted(output.FormatTable, changes, objectformats.GetGraphDiffResourceTableFormat()); err != nil {
+			return err
+		}
+	}
+
+	if len(diff.Connections) > 0 {
+		out.LogInfo("")
+		if err := out.WriteFormatted(output.FormatTable, diff.Connections, objectformats.GetGraphDiffConnectionTableFormat()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// load reads the graph of one side of the diff.
+func (r *Runner) load(ctx context.Context, source GraphSource) (*corerpv20250801.ApplicationGraphResponse, error) {
+	switch source.Kind {
+	case sourceFile:
+		return loadFile(source.Value)
+	case sourceBicep:
+		return r.loadBicep(ctx, source.Value)
+	case sourceArchive:
+		return r.loadArchive(ctx, source.Value)
+	case sourceDeployed:
+		return r.loadDeployed(ctx, source.Value)
+	default:
+		return nil, fmt.Errorf("unsupported graph source %q", source.Kind)
+	}
+}
+
+func loadFile(path string) (*corerpv20250801.ApplicationGraphResponse, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, clierrors.MessageWithCause(err, "Failed to read graph file %q.", path)
+	}
+
+	graph := &corerpv20250801.ApplicationGraphResponse{}
+	if err := json.Unmarshal(data, graph); err != nil {
+		return nil, clierrors.MessageWithCause(err, "File %q is not an application graph.", path)
+	}
+	return graph, nil
+}
+
+func (r *Runner) loadBicep(ctx context.Context, path string) (*corerpv20250801.ApplicationGraphResponse, error) {
+	displayPath := bicep.RedactTemplatePath(path)
+	template, err := r.Bicep.PrepareTemplate(ctx, path)
+	if err != nil {
+		return nil, clierrors.Message("Failed to compile %q: %v", displayPath, err)
+	}
+
+	graph, err := cligraph.BuildModeledGraph(template, false)
+	if err != nil {
+		return nil, clierrors.Message("Failed to build modeled graph: %v", err)
+	}
+	return graph, nil
+}
+
+func (r *Runner) loadArchive(ctx context.Context, branch string) (*corerpv20250801.ApplicationGraphResponse, error) {
+	graph, err := r.GraphStore.Load(ctx, cligraph.ModeledGraphKey(branch))
+	if errors.Is(err, persistence.ErrNotFound) {
+		return nil, clierrors.Message("No modeled graph is saved for branch %q in the %s archive.", branch, gitstore.DefaultGraphArchive)
+	} else if err != nil {
+		return nil, fmt.Errorf("load modeled graph for %s from %s archive: %w", branch, gitstore.DefaultGraphArchive, err)
+	}
+	return graph, nil
+}
+
+func (r *Runner) loadDeployed(ctx context.Context, applicationName string) (*corerpv20250801.ApplicationGraphResponse, error) {
+	if r.RadiusCoreClientFactory == nil {
+		factory, err := cmd.InitializeRadiusCoreClientFactory(ctx, r.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		r.RadiusCoreClientFactory = factory
+	}
+
+	response, err := r.RadiusCoreClientFactory.NewApplicationsClient().GetGraph(ctx, r.Workspace.Scope, applicationName, corerpv20250801.GetGraphRequest{}, &corerpv20250801.ApplicationsClientGetGraphOptions{})
+	if clients.Is404Error(err) {
+		return nil, clierrors.Message("Application %q does not exist or has been deleted.", applicationName)
+	} else if err != nil {
+		return nil, err
+	}
+	return &response.ApplicationGraphResponse, nil
+}
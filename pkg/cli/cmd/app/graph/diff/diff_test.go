@@ -0,0 +1,311 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/stretchr/testify/require"
+
+	"github.com/radius-project/radius/pkg/cli/framework"
+	cligraph "github.com/radius-project/radius/pkg/cli/graph"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/test_client_factory"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	corerpv20250801 "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/corerp/api/v20250801preview/fake"
+	"github.com/radius-project/radius/pkg/graph/persistence"
+	"github.com/radius-project/radius/pkg/graph/persistence/graphdb"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/radius-project/radius/test/radcli"
+)
+
+const (
+	webID   = "/planes/radius/local/resourceGroups/test-group/providers/Radius.Compute/containers/web"
+	cacheID = "/planes/radius/local/resourceGroups/test-group/providers/Radius.Data/redisCaches/cache"
+)
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "Valid: two graph files",
+			Input:         []string{"before.json", "after.json"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: radcli.LoadEmptyConfig(t)},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				r := runner.(*Runner)
+				require.Equal(t, GraphSource{Kind: sourceFile, Value: "before.json", Label: "before.json"}, r.Before)
+				require.Equal(t, output.FormatTable, r.Format)
+				require.Nil(t, r.Workspace, "a workspace is only needed for deployed graphs")
+			},
+		},
+		{
+			Name:          "Valid: bicep file and deployed graph with markdown output",
+			Input:         []string{"app.bicep", "deployed:my-app", "-o", "Markdown"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				r := runner.(*Runner)
+				require.Equal(t, sourceBicep, r.Before.Kind)
+				require.Equal(t, GraphSource{Kind: sourceDeployed, Value: "my-app", Label: "deployed:my-app"}, r.After)
+				require.Equal(t, FormatMarkdown, r.Format)
+				require.NotNil(t, r.Workspace)
+			},
+		},
+		{
+			Name:          "Invalid: one argument",
+			Input:         []string{"before.json"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Invalid: unrecognized graph source",
+			Input:         []string{"before.json", "main"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Invalid: empty archive branch",
+			Input:         []string{"archive:", "after.json"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Invalid: archive without a graph store",
+			Input:         []string{"archive:main", "after.json"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Invalid: unsupported output format",
+			Input:         []string{"before.json", "after.json", "-o", "yaml"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+	}
+
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func graphResource(id, resourceType, name string, properties map[string]any, connections ...*corerpv20250801.ApplicationGraphConnection) *corerpv20250801.ApplicationGraphResource {
+	if connections == nil {
+		connections = []*corerpv20250801.ApplicationGraphConnection{}
+	}
+	return &corerpv20250801.ApplicationGraphResource{
+		ID:              to.Ptr(id),
+		Name:            to.Ptr(name),
+		Type:            to.Ptr(resourceType),
+		Properties:      properties,
+		Connections:     connections,
+		OutputResources: []*corerpv20250801.ApplicationGraphOutputResource{},
+	}
+}
+
+// mainGraph is web -> cache.
+func mainGraph() *corerpv20250801.ApplicationGraphResponse {
+	return &corerpv20250801.ApplicationGraphResponse{
+		Resources: []*corerpv20250801.ApplicationGraphResource{
+			graphResource(webID, "Radius.Compute/containers", "web", map[string]any{"image": "web:1"},
+				&corerpv20250801.ApplicationGraphConnection{ID: to.Ptr(cacheID), Direction: to.Ptr(corerpv20250801.DirectionOutbound), Kind: to.Ptr(corerpv20250801.ConnectionKindConnection)}),
+			graphResource(cacheID, "Radius.Data/redisCaches", "cache", map[string]any{}),
+		},
+	}
+}
+
+// branchGraph drops the cache and changes the web image.
+func branchGraph() *corerpv20250801.ApplicationGraphResponse {
+	return &corerpv20250801.ApplicationGraphResponse{
+		Resources: []*corerpv20250801.ApplicationGraphResource{
+			graphResource(webID, "Radius.Compute/containers", "web", map[string]any{"image": "web:2"}),
+		},
+	}
+}
+
+func writeGraph(t *testing.T, graph *corerpv20250801.ApplicationGraphResponse) string {
+	t.Helper()
+	data, err := json.Marshal(graph)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "app-graph.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return path
+}
+
+func Test_Run_Table(t *testing.T) {
+	before := writeGraph(t, mainGraph())
+	after := writeGraph(t, branchGraph())
+
+	outputSink := &output.MockOutput{}
+	runner := &Runner{
+		Output: outputSink,
+		Before: GraphSource{Kind: sourceFile, Value: before, Label: "main"},
+		After:  GraphSource{Kind: sourceFile, Value: after, Label: "branch"},
+		Format: output.FormatTable,
+	}
+	require.NoError(t, runner.Run(t.Context()))
+
+	require.Equal(t, []any{
+		output.LogOutput{Format: "Comparing %s with %s: %d added, %d removed, %d modified, %d unchanged.", Params: []any{"main", "branch", 0, 1, 1, 0}},
+		output.LogOutput{Format: ""},
+		output.FormattedOutput{
+			Format: output.FormatTable,
+			Obj: []cligraph.ResourceChange{
+				{Change: cligraph.ChangeModified, ID: webID, Type: "Radius.Compute/containers", Name: "web", BeforeDiffHash: mustHash(t, "web:1"), AfterDiffHash: mustHash(t, "web:2")},
+				{Change: cligraph.ChangeRemoved, ID: cacheID, Type: "Radius.Data/redisCaches", Name: "cache"},
+			},
+			Options: objectformats.GetGraphDiffResourceTableFormat(),
+		},
+		output.LogOutput{Format: ""},
+		output.FormattedOutput{
+			Format: output.FormatTable,
+			Obj: []cligraph.ConnectionChange{
+				{Change: cligraph.ChangeRemoved, Source: "Radius.Compute/containers/web", Target: "Radius.Data/redisCaches/cache", Kind: corerpv20250801.ConnectionKindConnection},
+			},
+			Options: objectformats.GetGraphDiffConnectionTableFormat(),
+		},
+	}, outputSink.Writes)
+}
+
+func mustHash(t *testing.T, image string) string {
+	t.Helper()
+	hash, err := cligraph.ComputeDiffHash(map[string]any{"image": image})
+	require.NoError(t, err)
+	return hash
+}
+
+func Test_Run_NoChanges(t *testing.T) {
+	path := writeGraph(t, mainGraph())
+
+	outputSink := &output.MockOutput{}
+	runner := &Runner{
+		Output: outputSink,
+		Before: GraphSource{Kind: sourceFile, Value: path, Label: "a"},
+		After:  GraphSource{Kind: sourceFile, Value: path, Label: "b"},
+		Format: output.FormatTable,
+	}
+	require.NoError(t, runner.Run(t.Context()))
+
+	require.Equal(t, []any{
+		output.LogOutput{Format: "No changes between %s and %s. %d resources unchanged.", Params: []any{"a", "b", 2}},
+	}, outputSink.Writes)
+}
+
+func Test_Run_ArchiveMarkdown(t *testing.T) {
+	store := graphdb.NewStore(nil)
+	require.NoError(t, store.Save(t.Context(), cligraph.ModeledGraphKey("main"), mainGraph(), persistence.SaveOptions{}))
+	require.NoError(t, store.Save(t.Context(), cligraph.ModeledGraphKey("feature/cache"), branchGraph(), persistence.SaveOptions{}))
+
+	outputSink := &output.MockOutput{}
+	runner := &Runner{
+		Output:     outputSink,
+		GraphStore: store,
+		Before:     GraphSource{Kind: sourceArchive, Value: "main", Label: "archive:main"},
+		After:      GraphSource{Kind: sourceArchive, Value: "feature/cache", Label: "archive:feature/cache"},
+		Format:     FormatMarkdown,
+	}
+	require.NoError(t, runner.Run(t.Context()))
+
+	require.Len(t, outputSink.Writes, 1)
+	logOutput, ok := outputSink.Writes[0].(output.LogOutput)
+	require.True(t, ok)
+	require.Equal(t, "%s", logOutput.Format)
+	markdown := logOutput.Params[0].(string)
+	require.Contains(t, markdown, "### Application graph diff: `archive:main` → `archive:feature/cache`")
+	require.Contains(t, markdown, "| Removed | Radius.Data/redisCaches | cache |")
+	require.Contains(t, markdown, "| Removed | Radius.Compute/containers/web | Radius.Data/redisCaches/cache | Connection |")
+
+	runner.After = GraphSource{Kind: sourceArchive, Value: "missing", Label: "archive:missing"}
+	require.ErrorContains(t, runner.Run(t.Context()), `No modeled graph is saved for branch "missing"`)
+}
+
+func Test_Run_DeployedJSON(t *testing.T) {
+	workspace := &workspaces.Workspace{
+		Name:  "test-workspace",
+		Scope: "/planes/radius/local/resourceGroups/test-group",
+	}
+
+	graphServer := func() fake.ApplicationsServer {
+		srv := test_client_factory.WithApplicationsServerNoError()
+		srv.GetGraph = func(
+			ctx context.Context,
+			rootScope string,
+			applicationName string,
+			body corerpv20250801.GetGraphRequest,
+			options *corerpv20250801.ApplicationsClientGetGraphOptions,
+		) (resp azfake.Responder[corerpv20250801.ApplicationsClientGetGraphResponse], errResp azfake.ErrorResponder) {
+			if applicationName != "my-app" {
+				errResp.SetResponseError(http.StatusNotFound, "NotFound")
+				return
+			}
+			resp.SetResponse(http.StatusOK, corerpv20250801.ApplicationsClientGetGraphResponse{ApplicationGraphResponse: *mainGraph()}, nil)
+			return
+		}
+		return srv
+	}
+	factory, err := test_client_factory.NewRadiusCoreTestClientFactory(workspace.Scope, nil, nil, graphServer)
+	require.NoError(t, err)
+
+	outputSink := &output.MockOutput{}
+	runner := &Runner{
+		Output:                  outputSink,
+		RadiusCoreClientFactory: factory,
+		Workspace:               workspace,
+		Before:                  GraphSource{Kind: sourceDeployed, Value: "my-app", Label: "deployed:my-app"},
+		After:                   GraphSource{Kind: sourceFile, Value: writeGraph(t, mainGraph()), Label: "app-graph.json"},
+		Format:                  output.FormatJson,
+	}
+	require.NoError(t, runner.Run(t.Context()))
+
+	require.Len(t, outputSink.Writes, 1)
+	formatted, ok := outputSink.Writes[0].(output.FormattedOutput)
+	require.True(t, ok)
+	require.Equal(t, output.FormatJson, formatted.Format)
+	diff := formatted.Obj.(*cligraph.GraphDiff)
+	require.False(t, diff.HasChanges())
+	require.Equal(t, 2, diff.Count(cligraph.ChangeUnchanged))
+
+	runner.Before = GraphSource{Kind: sourceDeployed, Value: "other", Label: "deployed:other"}
+	require.ErrorContains(t, runner.Run(t.Context()), `Application "other" does not exist or has been deleted.`)
+}
+
+func Test_Run_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app-graph.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o644))
+
+	runner := &Runner{
+		Output: &output.MockOutput{},
+		Before: GraphSource{Kind: sourceFile, Value: path, Label: path},
+		After:  GraphSource{Kind: sourceFile, Value: filepath.Join(t.TempDir(), "missing.json"), Label: "missing.json"},
+		Format: output.FormatTable,
+	}
+	require.ErrorContains(t, runner.Run(t.Context()), "is not an application graph")
+
+	runner.Before = runner.After
+	require.ErrorContains(t, runner.Run(t.Context()), "Failed to read graph file")
+}
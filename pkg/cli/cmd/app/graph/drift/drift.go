@@ -0,0 +1,230 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift implements the `rad app graph drift` command, which compares the modeled graph of
+// an application with its deployed graph.
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radius-project/radius/pkg/cli"
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/clierrors"
+	"github.com/radius-project/radius/pkg/cli/cmd"
+	"github.com/radius-project/radius/pkg/cli/cmd/commonflags"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	cligraph "github.com/radius-project/radius/pkg/cli/graph"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	corerpv20250801 "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/graph/persistence"
+	gitstore "github.com/radius-project/radius/pkg/graph/persistence/git"
+)
+
+const defaultBranch = "main"
+
+// NewCommand creates an instance of the `rad app graph drift` command and runner.
+func NewCommand(factory framework.Factory) (*cobra.Command, framework.Runner) {
+	runner := NewRunner(factory)
+
+	cmd := &cobra.Command{
+		Use:   "drift [application]",
+		Short: "Detects drift between the modeled and the deployed graph of an application",
+		Long: `Detects drift between the modeled and the deployed graph of an application.
+
+The modeled graph is the graph saved for a branch in the radius-graph archive by
+'rad app graph <app.bicep>'. It is compared with the live graph of the deployed application and
+the command reports:
+  - modified resources, whose declared properties were changed out-of-band
+  - missing resources and connections, which are modeled but not deployed
+  - extra resources and connections, which are deployed but not modeled
+
+Only the properties declared in the modeled graph are compared, and template expressions are
+taken from the deployed graph, so computed values do not count as drift.
+
+Use --update-conditions to record the result as the GraphDrift condition of the application,
+which 'rad app status' displays. The Radius controller can run the same check periodically when
+graphDrift is enabled in its configuration.`,
+		Example: `
+# Compare the deployed application with the modeled graph saved for main
+rad app graph drift my-app
+
+# Compare with the modeled graph of another branch and record the result on the application
+rad app graph drift my-app --branch release/1.0 --update-conditions
+
+# Output the drift report in JSON format
+rad app graph drift my-app --output json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: framework.RunCommand(runner),
+	}
+
+	commonflags.AddWorkspaceFlag(cmd)
+	commonflags.AddResourceGroupFlag(cmd)
+	commonflags.AddApplicationNameFlag(cmd)
+	commonflags.AddOutputFlag(cmd)
+	cmd.Flags().String("branch", defaultBranch, "branch whose modeled graph is compared")
+	cmd.Flags().Bool("update-conditions", false, "record the result as the GraphDrift condition of the application")
+
+	return cmd, runner
+}
+
+// Runner is the runner implementation for the `rad app graph drift` command.
+type Runner struct {
+	ConfigHolder *framework.ConfigHolder
+	Output       output.Interface
+
+	// GraphStore reads modeled graphs from the radius-graph archive.
+	GraphStore persistence.Store
+
+	// RadiusCoreClientFactory reads deployed graphs and updates conditions. Initialized on
+	// demand; tests may substitute a fake.
+	RadiusCoreClientFactory *corerpv20250801.ClientFactory
+
+	Workspace        *workspaces.Workspace
+	ApplicationName  string
+	Branch           string
+	UpdateConditions bool
+	Format           string
+}
+
+// NewRunner creates a new instance of the `rad app graph drift` runner.
+func NewRunner(factory framework.Factory) *Runner {
+	return &Runner{
+		ConfigHolder: factory.GetConfigHolder(),
+		Output:       factory.GetOutput(),
+		GraphStore:   factory.GetGraphStore(),
+	}
+}
+
+// Validate runs validation for the `rad app graph drift` command.
+func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
+	workspace, err := cli.RequireWorkspace(cmd, r.ConfigHolder.Config)
+	if err != nil {
+		return err
+	}
+	r.Workspace = workspace
+
+	r.Workspace.Scope, err = cli.RequireScope(cmd, *r.Workspace)
+	if err != nil {
+		return err
+	}
+
+	r.ApplicationName, err = cli.RequireApplicationArgs(cmd, args, *workspace)
+	if err != nil {
+		return err
+	}
+
+	r.Branch, err = cmd.Flags().GetString("branch")
+	if err != nil {
+		return err
+	}
+	if r.Branch == "" {
+		return clierrors.Message("The --branch flag cannot be empty.")
+	}
+
+	r.UpdateConditions, err = cmd.Flags().GetBool("update-conditions")
+	if err != nil {
+		return err
+	}
+
+	r.Format, err = cli.RequireOutput(cmd)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run runs the `rad app graph drift` command.
+func (r *Runner) Run(ctx context.Context) error {
+	if r.GraphStore == nil {
+		return clierrors.Message("Modeled graph store is not configured.")
+	}
+
+	modeled, err := r.GraphStore.Load(ctx, cligraph.ModeledGraphKey(r.Branch))
+	if errors.Is(err, persistence.ErrNotFound) {
+		return clierrors.Message("No modeled graph is saved for branch %q in the %s archive. Run 'rad app graph <app.bicep>' on that branch first.", r.Branch, gitstore.DefaultGraphArchive)
+	} else if err != nil {
+		return fmt.Errorf("load modeled graph for %s from %s archive: %w", r.Branch, gitstore.DefaultGraphArchive, err)
+	}
+
+	if r.RadiusCoreClientFactory == nil {
+		factory, err := cmd.InitializeRadiusCoreClientFactory(ctx, r.Workspace)
+		if err != nil {
+			return err
+		}
+		r.RadiusCoreClientFactory = factory
+	}
+	applicationsClient := r.RadiusCoreClientFactory.NewApplicationsClient()
+
+	deployed, err := applicationsClient.GetGraph(ctx, r.Workspace.Scope, r.ApplicationName, corerpv20250801.GetGraphRequest{}, &corerpv20250801.ApplicationsClientGetGraphOptions{})
+	if clients.Is404Error(err) {
+		return clierrors.Message("Application %q does not exist or has been deleted.", r.ApplicationName)
+	} else if err != nil {
+		return err
+	}
+
+	report, err := cligraph.DetectDrift(modeled, &deployed.ApplicationGraphResponse)
+	if err != nil {
+		return clierrors.MessageWithCause(err, "Failed to compare the modeled graph for branch %q with application %q.", r.Branch, r.ApplicationName)
+	}
+
+	if r.UpdateConditions {
+		_, err = applicationsClient.UpdateConditions(ctx, r.Workspace.Scope, r.ApplicationName, corerpv20250801.UpdateConditionsRequest{
+			Conditions: []*corerpv20250801.ApplicationCondition{report.Condition()},
+		}, &corerpv20250801.ApplicationsClientUpdateConditionsOptions{})
+		if err != nil {
+			return clierrors.MessageWithCause(err, "Failed to update the conditions of application %q.", r.ApplicationName)
+		}
+	}
+
+	if r.Format == output.FormatJson {
+		return r.Output.WriteFormatted(r.Format, report, output.FormatterOptions{})
+	}
+
+	return WriteReport(r.Output, report)
+}
+
+// WriteReport writes report as a summary followed by tables of the drifted resources and
+// connections.
+func WriteReport(out output.Interface, report *cligraph.DriftReport) error {
+	out.LogInfo("%s", report.Summary())
+	if !report.HasDrift() {
+		return nil
+	}
+
+	if resources := report.Resources(); len(resources) > 0 {
+		out.LogInfo("")
+		if err := out.WriteFormatted(output.FormatTable, resources, objectformats.GetGraphDiffResourceTableFormat()); err != nil {
+			return err
+		}
+	}
+
+	if connections := report.Connections(); len(connections) > 0 {
+		out.LogInfo("")
+		if err := out.WriteFormatted(output.FormatTable, connections, objectformats.GetGraphDiffConnectionTableFormat()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
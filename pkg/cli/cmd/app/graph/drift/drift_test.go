@@ -0,0 +1,254 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/stretchr/testify/require"
+
+	"github.com/radius-project/radius/pkg/cli/framework"
+	cligraph "github.com/radius-project/radius/pkg/cli/graph"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/test_client_factory"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	corerpv20250801 "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/corerp/api/v20250801preview/fake"
+	"github.com/radius-project/radius/pkg/graph/persistence"
+	"github.com/radius-project/radius/pkg/graph/persistence/graphdb"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/radius-project/radius/test/radcli"
+)
+
+const (
+	webID   = "/planes/radius/local/resourceGroups/test-group/providers/Radius.Compute/containers/web"
+	cacheID = "/planes/radius/local/resourceGroups/test-group/providers/Radius.Data/redisCaches/cache"
+)
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "Valid: application with defaults",
+			Input:         []string{"my-app"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				r := runner.(*Runner)
+				require.Equal(t, "my-app", r.ApplicationName)
+				require.Equal(t, "main", r.Branch)
+				require.False(t, r.UpdateConditions)
+				require.Equal(t, output.FormatTable, r.Format)
+			},
+		},
+		{
+			Name:          "Valid: branch and update conditions",
+			Input:         []string{"my-app", "--branch", "release/1.0", "--update-conditions", "-o", "json"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				r := runner.(*Runner)
+				require.Equal(t, "release/1.0", r.Branch)
+				require.True(t, r.UpdateConditions)
+				require.Equal(t, output.FormatJson, r.Format)
+			},
+		},
+		{
+			Name:          "Invalid: empty branch",
+			Input:         []string{"my-app", "--branch", ""},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Invalid: too many arguments",
+			Input:         []string{"my-app", "other-app"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+	}
+
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func graphResource(id, resourceType, name string, properties map[string]any, connections ...*corerpv20250801.ApplicationGraphConnection) *corerpv20250801.ApplicationGraphResource {
+	if connections == nil {
+		connections = []*corerpv20250801.ApplicationGraphConnection{}
+	}
+	return &corerpv20250801.ApplicationGraphResource{
+		ID:              to.Ptr(id),
+		Name:            to.Ptr(name),
+		Type:            to.Ptr(resourceType),
+		Properties:      properties,
+		Connections:     connections,
+		OutputResources: []*corerpv20250801.ApplicationGraphOutputResource{},
+	}
+}
+
+// modeledGraph is web -> cache.
+func modeledGraph() *corerpv20250801.ApplicationGraphResponse {
+	return &corerpv20250801.ApplicationGraphResponse{
+		Resources: []*corerpv20250801.ApplicationGraphResource{
+			graphResource(webID, "Radius.Compute/containers", "web", map[string]any{"image": "web:1"},
+				&corerpv20250801.ApplicationGraphConnection{ID: to.Ptr(cacheID), Direction: to.Ptr(corerpv20250801.DirectionOutbound), Kind: to.Ptr(corerpv20250801.ConnectionKindConnection)}),
+			graphResource(cacheID, "Radius.Data/redisCaches", "cache", map[string]any{}),
+		},
+	}
+}
+
+// deployedGraph has the web image changed out-of-band and the cache deleted.
+func deployedGraph() *corerpv20250801.ApplicationGraphResponse {
+	return &corerpv20250801.ApplicationGraphResponse{
+		Resources: []*corerpv20250801.ApplicationGraphResource{
+			graphResource(webID, "Radius.Compute/containers", "web", map[string]any{"image": "web:2"}),
+		},
+	}
+}
+
+// newRunner returns a runner for my-app whose deployed graph is deployed. The conditions sent to
+// updateConditions are appended to recorded.
+func newRunner(t *testing.T, deployed *corerpv20250801.ApplicationGraphResponse, recorded *[]*corerpv20250801.ApplicationCondition) (*Runner, *output.MockOutput) {
+	workspace := &workspaces.Workspace{
+		Name:  "test-workspace",
+		Scope: "/planes/radius/local/resourceGroups/test-group",
+	}
+
+	server := func() fake.ApplicationsServer {
+		srv := test_client_factory.WithApplicationsServerNoError()
+		srv.GetGraph = func(
+			ctx context.Context,
+			rootScope string,
+			applicationName string,
+			body corerpv20250801.GetGraphRequest,
+			options *corerpv20250801.ApplicationsClientGetGraphOptions,
+		) (resp azfake.Responder[corerpv20250801.ApplicationsClientGetGraphResponse], errResp azfake.ErrorResponder) {
+			if applicationName != "my-app" {
+				errResp.SetResponseError(http.StatusNotFound, "NotFound")
+				return
+			}
+			resp.SetResponse(http.StatusOK, corerpv20250801.ApplicationsClientGetGraphResponse{ApplicationGraphResponse: *deployed}, nil)
+			return
+		}
+		srv.UpdateConditions = func(
+			ctx context.Context,
+			rootScope string,
+			applicationName string,
+			body corerpv20250801.UpdateConditionsRequest,
+			options *corerpv20250801.ApplicationsClientUpdateConditionsOptions,
+		) (resp azfake.Responder[corerpv20250801.ApplicationsClientUpdateConditionsResponse], errResp azfake.ErrorResponder) {
+			*recorded = append(*recorded, body.Conditions...)
+			resp.SetResponse(http.StatusOK, corerpv20250801.ApplicationsClientUpdateConditionsResponse{}, nil)
+			return
+		}
+		return srv
+	}
+	factory, err := test_client_factory.NewRadiusCoreTestClientFactory(workspace.Scope, nil, nil, server)
+	require.NoError(t, err)
+
+	store := graphdb.NewStore(nil)
+	require.NoError(t, store.Save(t.Context(), cligraph.ModeledGraphKey("main"), modeledGraph(), persistence.SaveOptions{}))
+
+	outputSink := &output.MockOutput{}
+	return &Runner{
+		Output:                  outputSink,
+		GraphStore:              store,
+		RadiusCoreClientFactory: factory,
+		Workspace:               workspace,
+		ApplicationName:         "my-app",
+		Branch:                  "main",
+		Format:                  output.FormatTable,
+	}, outputSink
+}
+
+func Test_Run_Drifted(t *testing.T) {
+	recorded := []*corerpv20250801.ApplicationCondition{}
+	runner, outputSink := newRunner(t, deployedGraph(), &recorded)
+	runner.UpdateConditions = true
+	require.NoError(t, runner.Run(t.Context()))
+
+	summary := "The deployed graph differs from the modeled graph: 1 resource modified, 1 resource missing, 1 connection missing."
+	require.Equal(t, []any{
+		output.LogOutput{Format: "%s", Params: []any{summary}},
+		output.LogOutput{Format: ""},
+		output.FormattedOutput{
+			Format: output.FormatTable,
+			Obj: []cligraph.ResourceChange{
+				{Change: cligraph.ChangeModified, ID: webID, Type: "Radius.Compute/containers", Name: "web", BeforeDiffHash: mustHash(t, "web:1"), AfterDiffHash: mustHash(t, "web:2")},
+				{Change: cligraph.ChangeMissing, ID: cacheID, Type: "Radius.Data/redisCaches", Name: "cache"},
+			},
+			Options: objectformats.GetGraphDiffResourceTableFormat(),
+		},
+		output.LogOutput{Format: ""},
+		output.FormattedOutput{
+			Format: output.FormatTable,
+			Obj: []cligraph.ConnectionChange{
+				{Change: cligraph.ChangeMissing, Source: "Radius.Compute/containers/web", Target: "Radius.Data/redisCaches/cache", Kind: corerpv20250801.ConnectionKindConnection},
+			},
+			Options: objectformats.GetGraphDiffConnectionTableFormat(),
+		},
+	}, outputSink.Writes)
+
+	require.Len(t, recorded, 1)
+	require.Equal(t, cligraph.DriftConditionType, *recorded[0].Type)
+	require.Equal(t, corerpv20250801.ConditionStatusTrue, *recorded[0].Status)
+	require.Equal(t, summary, *recorded[0].Message)
+}
+
+func mustHash(t *testing.T, image string) string {
+	t.Helper()
+	hash, err := cligraph.ComputeDiffHash(map[string]any{"image": image})
+	require.NoError(t, err)
+	return hash
+}
+
+func Test_Run_InSyncJSON(t *testing.T) {
+	recorded := []*corerpv20250801.ApplicationCondition{}
+	runner, outputSink := newRunner(t, modeledGraph(), &recorded)
+	runner.Format = output.FormatJson
+	require.NoError(t, runner.Run(t.Context()))
+
+	require.Len(t, outputSink.Writes, 1)
+	formatted, ok := outputSink.Writes[0].(output.FormattedOutput)
+	require.True(t, ok)
+	report := formatted.Obj.(*cligraph.DriftReport)
+	require.False(t, report.HasDrift())
+	require.Equal(t, 2, report.InSync)
+	require.Empty(t, recorded, "conditions are only updated with --update-conditions")
+}
+
+func Test_Run_Errors(t *testing.T) {
+	recorded := []*corerpv20250801.ApplicationCondition{}
+	runner, _ := newRunner(t, modeledGraph(), &recorded)
+
+	runner.Branch = "feature"
+	require.ErrorContains(t, runner.Run(t.Context()), `No modeled graph is saved for branch "feature"`)
+
+	runner.Branch = "main"
+	runner.ApplicationName = "other"
+	require.ErrorContains(t, runner.Run(t.Context()), `Application "other" does not exist or has been deleted.`)
+
+	runner.GraphStore = nil
+	require.ErrorContains(t, runner.Run(t.Context()), "Modeled graph store is not configured.")
+}
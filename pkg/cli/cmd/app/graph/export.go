@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radius-project/radius/pkg/cli"
+	cligraph "github.com/radius-project/radius/pkg/cli/graph"
+	"github.com/radius-project/radius/pkg/cli/output"
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+)
+
+// AddOutputFlag adds the output flag of the `rad app graph` commands, which accepts the graph
+// export formats in addition to the common output formats.
+func AddOutputFlag(cmd *cobra.Command) {
+	formats := append(output.SupportedFormats(), cligraph.ExportFormats()...)
+	description := fmt.Sprintf("output format (supported formats are %s)", strings.Join(formats, ", "))
+	cmd.Flags().StringP("output", "o", output.DefaultFormat, description)
+}
+
+// RequireOutput reads the output flag added by AddOutputFlag. Export formats are returned
+// lowercased; anything else is validated by cli.RequireOutput.
+func RequireOutput(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return "", err
+	}
+
+	format = strings.ToLower(strings.TrimSpace(format))
+	if cligraph.IsExportFormat(format) {
+		return format, nil
+	}
+	return cli.RequireOutput(cmd)
+}
+
+// WriteExport renders graph in the export format and writes it to out. name names the
+// application in the exported document.
+func WriteExport(out output.Interface, graph *corerpv20250801preview.ApplicationGraphResponse, format string, name string) error {
+	exported, err := cligraph.Export(graph, format, name)
+	if err != nil {
+		return err
+	}
+	out.LogInfo("%s", strings.TrimSuffix(exported, "\n"))
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/radius-project/radius/pkg/cli/bicep"
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	corerpv20231001preview "github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
+)
+
+func TestRequireOutput(t *testing.T) {
+	for input, expected := range map[string]string{
+		"":        output.FormatTable,
+		"json":    output.FormatJson,
+		" DOT ":   "dot",
+		"Mermaid": "mermaid",
+		"sbom":    "sbom",
+	} {
+		cmd := &cobra.Command{}
+		AddOutputFlag(cmd)
+		require.NoError(t, cmd.Flags().Set("output", input))
+
+		format, err := RequireOutput(cmd)
+		require.NoError(t, err, input)
+		require.Equal(t, expected, format, input)
+	}
+
+	cmd := &cobra.Command{}
+	AddOutputFlag(cmd)
+	require.Contains(t, cmd.Flags().Lookup("output").Usage, "json, table, dot, mermaid, sbom")
+	require.NoError(t, cmd.Flags().Set("output", "png"))
+	_, err := RequireOutput(cmd)
+	require.ErrorContains(t, err, `unsupported output format "png"`)
+}
+
+func Test_Run_DeployedExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graph := corerpv20231001preview.ApplicationGraphResponse{
+		Resources: []*corerpv20231001preview.ApplicationGraphResource{
+			{
+				ID:   new(containerResourceID),
+				Name: new(containerResourceName),
+				Type: new(containerResourceType),
+				Connections: []*corerpv20231001preview.ApplicationGraphConnection{
+					{ID: new(redisResourceID), Direction: &directionOutbound},
+				},
+			},
+		},
+	}
+
+	appManagementClient := clients.NewMockApplicationsManagementClient(ctrl)
+	appManagementClient.EXPECT().
+		GetApplicationGraph(gomock.Any(), "test-app").
+		Return(graph, nil).
+		Times(1)
+
+	outputSink := &output.MockOutput{}
+	runner := &Runner{
+		ConnectionFactory: &connections.MockFactory{ApplicationsManagementClient: appManagementClient},
+		Workspace:         &workspaces.Workspace{Name: "test", Scope: "/planes/radius/local/resourceGroups/test-group"},
+		Output:            outputSink,
+		Format:            "dot",
+		ApplicationName:   "test-app",
+	}
+	require.NoError(t, runner.Run(t.Context()))
+
+	require.Len(t, outputSink.Writes, 1)
+	logOutput, ok := outputSink.Writes[0].(output.LogOutput)
+	require.True(t, ok)
+	dot := logOutput.Params[0].(string)
+	require.Contains(t, dot, `digraph "test-app" {`)
+	// Stable-API connections carry no kind; they are exported as Connection (solid) edges.
+	require.Contains(t, dot, `"`+containerResourceID+`" -> "`+redisResourceID+`";`)
+}
+
+func TestRunner_RunModeled_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	withTempCwd(t)
+	t.Setenv("GITHUB_ACTIONS", "true")
+
+	bicepMock := bicep.NewMockInterface(ctrl)
+	bicepMock.EXPECT().
+		PrepareTemplate(gomock.Any(), sampleBicepPath).
+		Return(sampleTemplate(), nil).
+		Times(1)
+
+	outputSink := &output.MockOutput{}
+	runner := &Runner{
+		Bicep:         bicepMock,
+		Output:        outputSink,
+		BicepFilePath: sampleBicepPath,
+		Format:        "mermaid",
+	}
+	require.NoError(t, runner.Run(t.Context()))
+
+	// The export is printed; nothing is written locally or to the archive (GraphStore is nil).
+	require.Len(t, outputSink.Writes, 2)
+	logOutput, ok := outputSink.Writes[1].(output.LogOutput)
+	require.True(t, ok)
+	mermaid := logOutput.Params[0].(string)
+	require.Contains(t, mermaid, "title: app\n")
+	require.Contains(t, mermaid, "frontend<br/>Applications.Core/containers")
+
+	_, err := os.Stat(defaultModeledGraphFile)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -9,7 +9,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -33,7 +32,6 @@ import (
 const (
 	bicepExtension          = ".bicep"
 	defaultModeledGraphFile = "app-graph.json"
-	modeledGraphKeyName     = "app-graph"
 
 	// envGitHubActions is set to "true" by GitHub Actions for every step
 	// running inside a runner. When present, rad operates in repo-radius
@@ -66,21 +64,32 @@ the command compiles the template and writes the resulting modeled graph to
 If the command runs inside a GitHub Actions runner (GITHUB_ACTIONS=true), the
 modeled graph is saved to <source-branch>/app-graph.json in the radius-graph
 archive instead of the local filesystem. This is auto-detected; no flag
-is required.`,
+is required.
+
+With --output dot, mermaid or sbom, either form prints the graph as a Graphviz
+DOT digraph, a Mermaid flowchart or a CycloneDX-style bill of materials instead,
+and a modeled graph is not saved. Mermaid nodes are drawn with their resource
+type icons when the graph carries icon bytes (--include-icons).`,
 		Args: cobra.MaximumNArgs(1),
 		Example: `
 # Show graph for the deployed application named my-application.
 rad app graph -a my-application
 
 # Build the modeled graph for an app.bicep and write it to ./app-graph.json.
-rad app graph ./app.bicep`,
+rad app graph ./app.bicep
+
+# Render the modeled graph of an app.bicep as a Mermaid flowchart with icons.
+rad app graph ./app.bicep --include-icons -o mermaid
+
+# Export a bill of materials for the deployed application named my-application.
+rad app graph -a my-application -o sbom`,
 		RunE: framework.RunCommand(runner),
 	}
 
 	commonflags.AddWorkspaceFlag(cmd)
 	commonflags.AddResourceGroupFlag(cmd)
 	commonflags.AddApplicationNameFlag(cmd)
-	commonflags.AddOutputFlag(cmd)
+	AddOutputFlag(cmd)
 	cmd.Flags().Bool("include-icons", false, "When set with --preview (deployed) or with a bicep-file argument (modeled), embeds each referenced resource type icon's SVG bytes in the response's icons map.")
 
 	return cmd, runner
@@ -156,7 +165,7 @@ func sourceBranch() string {
 
 // Validate runs validation for the `rad app graph` command.
 func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
-	format, err := cli.RequireOutput(cmd)
+	format, err := RequireOutput(cmd)
 	if err != nil {
 		return err
 	}
@@ -230,8 +239,14 @@ func (r *Runner) runDeployed(ctx context.Context) error {
 		return err
 	}
 
-	switch r.Format {
-	case output.FormatJson:
+	switch {
+	case cligraph.IsExportFormat(r.Format):
+		graph, err := cligraph.FromApplicationsCoreGraph(applicationGraphResponse)
+		if err != nil {
+			return err
+		}
+		return WriteExport(r.Output, graph, r.Format, r.ApplicationName)
+	case r.Format == output.FormatJson:
 		return r.Output.WriteFormatted(r.Format, applicationGraphResponse, output.FormatterOptions{})
 	default:
 		graph := applicationGraphResponse.Resources
@@ -243,7 +258,8 @@ func (r *Runner) runDeployed(ctx context.Context) error {
 }
 
 // runModeled compiles the supplied Bicep file, builds the modeled
-// application graph, and persists the result. When running inside a
+// application graph, and persists the result, or prints it when an export
+// format was requested. When running inside a
 // GitHub Actions runner the graph is committed to the radius-graph archive
 // under <source-branch>/app-graph.json; otherwise it is written to
 // ./app-graph.json in the current working directory.
@@ -260,6 +276,10 @@ func (r *Runner) runModeled(ctx context.Context) error {
 		return clierrors.Message("Failed to build modeled graph: %v", err)
 	}
 
+	if cligraph.IsExportFormat(r.Format) {
+		name := strings.TrimSuffix(filepath.Base(r.BicepFilePath), filepath.Ext(r.BicepFilePath))
+		return WriteExport(r.Output, graph, r.Format, name)
+	}
 	if inRepoRadiusMode() {
 		return r.persistToArchive(ctx, graph)
 	}
@@ -303,8 +323,8 @@ func (r *Runner) persistToArchive(ctx context.Context, graph *corerpv20250801pre
 		return clierrors.Message("Modeled graph store is not configured.")
 	}
 
-	namespace := url.QueryEscape(branch)
-	key := persistence.Key{Namespace: namespace, Name: modeledGraphKeyName}
+	key := cligraph.ModeledGraphKey(branch)
+	namespace := key.Namespace
 	opts := persistence.SaveOptions{
 		Message: fmt.Sprintf("radius: update modeled graph for %s", branch),
 	}
@@ -312,6 +332,6 @@ func (r *Runner) persistToArchive(ctx context.Context, graph *corerpv20250801pre
 		return fmt.Errorf("save modeled graph to %s archive: %w", gitstore.DefaultGraphArchive, err)
 	}
 
-	r.Output.LogInfo("Parsed %d resources. Saved %s/%s.json to archive %s", len(graph.Resources), namespace, modeledGraphKeyName, gitstore.DefaultGraphArchive)
+	r.Output.LogInfo("Parsed %d resources. Saved %s/%s.json to archive %s", len(graph.Resources), namespace, cligraph.ModeledGraphKeyName, gitstore.DefaultGraphArchive)
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/radius-project/radius/pkg/cli/clients"
 	"github.com/radius-project/radius/pkg/cli/connections"
 	"github.com/radius-project/radius/pkg/cli/framework"
+	cligraph "github.com/radius-project/radius/pkg/cli/graph"
 	"github.com/radius-project/radius/pkg/cli/output"
 	"github.com/radius-project/radius/pkg/cli/workspaces"
 	corerpv20231001preview "github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
@@ -356,7 +357,7 @@ func TestRunner_RunModeled_OrphanBranchPersistence(t *testing.T) {
 		Times(1)
 
 	storeMock := persistence.NewMockStore(ctrl)
-	expectedKey := persistence.Key{Namespace: "feature%2Ffoo", Name: modeledGraphKeyName}
+	expectedKey := persistence.Key{Namespace: "feature%2Ffoo", Name: cligraph.ModeledGraphKeyName}
 	storeMock.EXPECT().
 		Save(gomock.Any(), expectedKey, gomock.Any(), gomock.Any()).
 		DoAndReturn(saveAssertion(t, "feature%2Ffoo", "frontend")).
@@ -430,7 +431,7 @@ func TestRunner_RunModeled_FallsBackToRefName(t *testing.T) {
 
 	storeMock := persistence.NewMockStore(ctrl)
 	storeMock.EXPECT().
-		Save(gomock.Any(), persistence.Key{Namespace: "main", Name: modeledGraphKeyName}, gomock.Any(), gomock.Any()).
+		Save(gomock.Any(), persistence.Key{Namespace: "main", Name: cligraph.ModeledGraphKeyName}, gomock.Any(), gomock.Any()).
 		Return(nil).
 		Times(1)
 
@@ -622,7 +623,7 @@ func saveAssertion(t *testing.T, wantBranch, wantResource string) func(ctx conte
 	t.Helper()
 	return func(_ context.Context, key persistence.Key, graph *corerpv20250801preview.ApplicationGraphResponse, opts persistence.SaveOptions) error {
 		require.Equal(t, wantBranch, key.Namespace)
-		require.Equal(t, modeledGraphKeyName, key.Name)
+		require.Equal(t, cligraph.ModeledGraphKeyName, key.Name)
 		require.NotNil(t, graph)
 		require.Len(t, graph.Resources, 1)
 		require.Equal(t, wantResource, *graph.Resources[0].Name)
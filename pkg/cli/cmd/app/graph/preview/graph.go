@@ -69,14 +69,17 @@ rad app graph my-application --preview
 rad app graph my-application --preview -o json --include-icons
 
 # Enrich the deployed graph with dependsOn edges from a local app.bicep
-rad app graph -a my-application --preview ./app.bicep`,
+rad app graph -a my-application --preview ./app.bicep
+
+# Render the deployed graph as a Mermaid flowchart with resource type icons
+rad app graph my-application --preview -o mermaid --include-icons`,
 		RunE: framework.RunCommand(runner),
 	}
 
 	commonflags.AddWorkspaceFlag(cmd)
 	commonflags.AddResourceGroupFlag(cmd)
 	commonflags.AddApplicationNameFlag(cmd)
-	commonflags.AddOutputFlag(cmd)
+	graph.AddOutputFlag(cmd)
 	cmd.Flags().Bool("include-icons", false, "When set, embeds each referenced resource type icon's SVG bytes in the response.")
 
 	return cmd, runner
@@ -148,7 +151,7 @@ func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	r.Format, err = cli.RequireOutput(cmd)
+	r.Format, err = graph.RequireOutput(cmd)
 	if err != nil {
 		return err
 	}
@@ -200,8 +203,10 @@ func (r *Runner) Run(ctx context.Context) error {
 		return err
 	}
 
-	switch r.Format {
-	case output.FormatJson:
+	switch {
+	case cligraph.IsExportFormat(r.Format):
+		return graph.WriteExport(r.Output, &graphResponse.ApplicationGraphResponse, r.Format, r.ApplicationName)
+	case r.Format == output.FormatJson:
 		return r.Output.WriteFormatted(r.Format, graphResponse.ApplicationGraphResponse, output.FormatterOptions{})
 	default:
 		d := display(graphResponse.Resources, r.ApplicationName)
@@ -33,6 +33,7 @@ import (
 	"github.com/radius-project/radius/pkg/cli/workspaces"
 	corerpv20250801 "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
 	"github.com/radius-project/radius/pkg/corerp/api/v20250801preview/fake"
+	"github.com/radius-project/radius/pkg/to"
 	"github.com/radius-project/radius/test/radcli"
 )
 
@@ -458,3 +459,63 @@ func Test_Run_EnrichedMode_CompileError_Wrapped(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "./bad.bicep")
 }
+
+func Test_Run_Export(t *testing.T) {
+	workspace := &workspaces.Workspace{
+		Name:  "test-workspace",
+		Scope: "/planes/radius/local/resourceGroups/test-group",
+	}
+
+	var observed corerpv20250801.GetGraphRequest
+	graphServer := func() fake.ApplicationsServer {
+		srv := test_client_factory.WithApplicationsServerNoError()
+		srv.GetGraph = func(
+			ctx context.Context,
+			rootScope string,
+			applicationName string,
+			body corerpv20250801.GetGraphRequest,
+			options *corerpv20250801.ApplicationsClientGetGraphOptions,
+		) (resp azfake.Responder[corerpv20250801.ApplicationsClientGetGraphResponse], errResp azfake.ErrorResponder) {
+			observed = body
+			resp.SetResponse(http.StatusOK, corerpv20250801.ApplicationsClientGetGraphResponse{
+				ApplicationGraphResponse: corerpv20250801.ApplicationGraphResponse{
+					Resources: []*corerpv20250801.ApplicationGraphResource{
+						{
+							ID:              to.Ptr("/planes/radius/local/resourceGroups/test-group/providers/Radius.Compute/containers/web"),
+							Name:            to.Ptr("web"),
+							Type:            to.Ptr("Radius.Compute/containers"),
+							IconHash:        to.Ptr("sha256:web"),
+							Connections:     []*corerpv20250801.ApplicationGraphConnection{},
+							OutputResources: []*corerpv20250801.ApplicationGraphOutputResource{},
+						},
+					},
+					Icons: map[string]*string{"sha256:web": to.Ptr("<svg/>")},
+				},
+			}, nil)
+			return
+		}
+		return srv
+	}
+
+	factory, err := test_client_factory.NewRadiusCoreTestClientFactory(workspace.Scope, nil, nil, graphServer)
+	require.NoError(t, err)
+
+	outputSink := &output.MockOutput{}
+	runner := &Runner{
+		RadiusCoreClientFactory: factory,
+		Workspace:               workspace,
+		ApplicationName:         "test-app",
+		Format:                  "mermaid",
+		IncludeIcons:            true,
+		Output:                  outputSink,
+	}
+	require.NoError(t, runner.Run(t.Context()))
+	require.True(t, to.Bool(observed.IncludeIcons))
+
+	require.Len(t, outputSink.Writes, 1)
+	logOutput, ok := outputSink.Writes[0].(output.LogOutput)
+	require.True(t, ok)
+	mermaid := logOutput.Params[0].(string)
+	require.Contains(t, mermaid, "title: test-app\n")
+	require.Contains(t, mermaid, `n0@{ img: "data:image/svg+xml;base64,PHN2Zy8+", label: "web<br/>Radius.Compute/containers"`)
+}
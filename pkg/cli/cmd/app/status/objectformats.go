@@ -50,3 +50,28 @@ func GatewayFormat() output.FormatterOptions {
 		},
 	}
 }
+
+// ConditionFormat returns a FormatterOptions object which contains a list of columns to be used for
+// formatting the output of a list of application conditions.
+func ConditionFormat() output.FormatterOptions {
+	return output.FormatterOptions{
+		Columns: []output.Column{
+			{
+				Heading:  "CONDITION",
+				JSONPath: "{ .Type }",
+			},
+			{
+				Heading:  "STATUS",
+				JSONPath: "{ .Status }",
+			},
+			{
+				Heading:  "REASON",
+				JSONPath: "{ .Reason }",
+			},
+			{
+				Heading:  "MESSAGE",
+				JSONPath: "{ .Message }",
+			},
+		},
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/radius-project/radius/pkg/cli/workspaces"
 	corerpv20250801 "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
 	"github.com/radius-project/radius/pkg/corerp/datamodel"
+	"github.com/radius-project/radius/pkg/to"
 	"github.com/radius-project/radius/pkg/ucp/resources"
 )
 
@@ -43,7 +44,7 @@ func NewCommand(factory framework.Factory) (*cobra.Command, framework.Runner) {
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show Radius Application status (preview)",
-		Long:  `Show Radius.Core application status using the preview API surface, including resource count and public endpoints.`,
+		Long:  `Show Radius.Core application status using the preview API surface, including resource count, public endpoints and conditions such as GraphDrift.`,
 		Args:  cobra.MaximumNArgs(1),
 		Example: `
 # Show status of specified application
@@ -145,6 +146,7 @@ func (r *Runner) Run(ctx context.Context) error {
 	applicationStatus := clients.ApplicationStatus{
 		Name:          *application.Name,
 		ResourceCount: len(resourceList),
+		Conditions:    conditionStatuses(application.Properties),
 	}
 
 	// Gather public endpoints from gateway resources.
@@ -187,5 +189,38 @@ func (r *Runner) Run(ctx context.Context) error {
 		}
 	}
 
+	if r.Format == output.FormatTable && len(applicationStatus.Conditions) > 0 {
+		r.Output.LogInfo("")
+		err = r.Output.WriteFormatted(r.Format, applicationStatus.Conditions, status.ConditionFormat())
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// conditionStatuses returns the conditions of an application, such as GraphDrift.
+func conditionStatuses(properties *corerpv20250801.ApplicationProperties) []clients.ConditionStatus {
+	if properties == nil {
+		return nil
+	}
+
+	conditions := []clients.ConditionStatus{}
+	for _, condition := range properties.Conditions {
+		if condition == nil {
+			continue
+		}
+		status := ""
+		if condition.Status != nil {
+			status = string(*condition.Status)
+		}
+		conditions = append(conditions, clients.ConditionStatus{
+			Type:    to.String(condition.Type),
+			Status:  status,
+			Reason:  to.String(condition.Reason),
+			Message: to.String(condition.Message),
+		})
+	}
+	return conditions
+}
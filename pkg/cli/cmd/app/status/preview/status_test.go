@@ -36,6 +36,7 @@ import (
 	"github.com/radius-project/radius/pkg/cli/workspaces"
 	corerpv20250801 "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
 	"github.com/radius-project/radius/pkg/corerp/api/v20250801preview/fake"
+	"github.com/radius-project/radius/pkg/to"
 	"github.com/radius-project/radius/pkg/ucp/resources"
 	"github.com/radius-project/radius/test/radcli"
 )
@@ -215,6 +216,78 @@ func Test_Run(t *testing.T) {
 		require.Equal(t, status.GatewayFormat(), gwFormatted.Options)
 	})
 
+	t.Run("Success: application with conditions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		conditionsServer := func() fake.ApplicationsServer {
+			srv := test_client_factory.WithApplicationsServerNoError()
+			srv.Get = func(
+				ctx context.Context,
+				rootScope string,
+				applicationName string,
+				options *corerpv20250801.ApplicationsClientGetOptions,
+			) (resp azfake.Responder[corerpv20250801.ApplicationsClientGetResponse], errResp azfake.ErrorResponder) {
+				resp.SetResponse(http.StatusOK, corerpv20250801.ApplicationsClientGetResponse{
+					ApplicationResource: corerpv20250801.ApplicationResource{
+						Name: new(applicationName),
+						Properties: &corerpv20250801.ApplicationProperties{
+							Conditions: []*corerpv20250801.ApplicationCondition{
+								{
+									Type:    new("GraphDrift"),
+									Status:  to.Ptr(corerpv20250801.ConditionStatusTrue),
+									Reason:  new("Drifted"),
+									Message: new("The deployed graph differs from the modeled graph: 1 resource modified."),
+								},
+							},
+						},
+					},
+				}, nil)
+				return
+			}
+			return srv
+		}
+		factory, err := test_client_factory.NewRadiusCoreTestClientFactory(workspace.Scope, nil, nil, conditionsServer)
+		require.NoError(t, err)
+
+		mockMgmt := clients.NewMockApplicationsManagementClient(ctrl)
+		mockMgmt.EXPECT().
+			ListResourcesInApplication(gomock.Any(), gomock.Any()).
+			Return([]generated.GenericResource{}, nil).
+			Times(1)
+
+		outputSink := &output.MockOutput{}
+		runner := &Runner{
+			RadiusCoreClientFactory: factory,
+			ConnectionFactory: &connections.MockFactory{
+				ApplicationsManagementClient: mockMgmt,
+				DiagnosticsClient:            clients.NewMockDiagnosticsClient(ctrl),
+			},
+			Workspace:       workspace,
+			ApplicationName: "test-app",
+			Format:          "table",
+			Output:          outputSink,
+		}
+
+		err = runner.Run(t.Context())
+		require.NoError(t, err)
+
+		// Should have: status table, blank line, condition table
+		require.Len(t, outputSink.Writes, 3)
+
+		expected := []clients.ConditionStatus{
+			{Type: "GraphDrift", Status: "True", Reason: "Drifted", Message: "The deployed graph differs from the modeled graph: 1 resource modified."},
+		}
+		formatted, ok := outputSink.Writes[0].(output.FormattedOutput)
+		require.True(t, ok)
+		require.Equal(t, expected, formatted.Obj.(clients.ApplicationStatus).Conditions)
+
+		conditionFormatted, ok := outputSink.Writes[2].(output.FormattedOutput)
+		require.True(t, ok)
+		require.Equal(t, status.ConditionFormat(), conditionFormatted.Options)
+		require.Equal(t, expected, conditionFormatted.Obj)
+	})
+
 	t.Run("Error: application not found (404)", func(t *testing.T) {
 		notFoundServer := func() fake.ApplicationsServer {
 			return fake.ApplicationsServer{
@@ -18,6 +18,7 @@ package status
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/radius-project/radius/pkg/cli"
 	"github.com/radius-project/radius/pkg/cli/clients"
@@ -28,6 +29,7 @@ import (
 	"github.com/radius-project/radius/pkg/cli/output"
 	"github.com/radius-project/radius/pkg/cli/workspaces"
 	"github.com/radius-project/radius/pkg/ucp/resources"
+	resources_radius "github.com/radius-project/radius/pkg/ucp/resources/radius"
 	"github.com/spf13/cobra"
 )
 
@@ -46,6 +48,9 @@ rad app status my-app
 
 # Show status of specified application in a specified resource group
 rad app status my-app --group my-group
+
+# Show status of specified application and print it again when it changes
+rad app status my-app --watch
 `,
 		RunE: framework.RunCommand(runner),
 	}
@@ -54,6 +59,7 @@ rad app status my-app --group my-group
 	commonflags.AddResourceGroupFlag(cmd)
 	commonflags.AddApplicationNameFlag(cmd)
 	commonflags.AddOutputFlag(cmd)
+	cmd.Flags().Bool("watch", false, "Watch the application and print its status again when its resources change")
 
 	return cmd, runner
 }
@@ -67,6 +73,7 @@ type Runner struct {
 
 	ApplicationName string
 	Format          string
+	Watch           bool
 }
 
 // NewRunner creates an instance of the runner for the `rad app status` command.
@@ -109,6 +116,11 @@ func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
 
 	r.Format = format
 
+	r.Watch, err = cmd.Flags().GetBool("watch")
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -116,23 +128,112 @@ func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
 //
 
 // Run() retrieves the application status and its associated gateways from the given workspace and returns it in the specified format.
-// It returns an error if the application is not found or if there is an error while retrieving the application status.
+// It returns an error if the application is not found or if there is an error while retrieving the application status. With --watch,
+// the status is printed again every time it changes, until the command is interrupted.
 func (r *Runner) Run(ctx context.Context) error {
 	client, err := r.ConnectionFactory.CreateApplicationsManagementClient(ctx, *r.Workspace)
 	if err != nil {
 		return err
 	}
 
+	var changes <-chan clients.ResourceChange
+	if r.Watch {
+		// Start watching before reading the status so that no change is missed.
+		changes, err = r.watch(ctx, client)
+		if err != nil {
+			return err
+		}
+	}
+
+	applicationStatus, err := r.getStatus(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	err = r.writeStatus(applicationStatus)
+	if err != nil {
+		return err
+	}
+
+	if !r.Watch {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-changes:
+			if !ok {
+				return clierrors.Message("The connection to Radius was lost while watching the application %q.", r.ApplicationName)
+			}
+		}
+
+		// Changes are often made together, read the status once for all of the changes already received.
+		drain(changes)
+
+		updated, err := r.getStatus(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		if reflect.DeepEqual(updated, applicationStatus) {
+			continue
+		}
+		applicationStatus = updated
+
+		// Print newline for readability
+		r.Output.LogInfo("")
+
+		err = r.writeStatus(applicationStatus)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// watch starts watching the resources of the resource group of the workspace. The resources of the application are
+// tracked in that resource group.
+func (r *Runner) watch(ctx context.Context, client clients.ApplicationsManagementClient) (<-chan clients.ResourceChange, error) {
+	scope, err := resources.ParseScope(r.Workspace.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	planeName := scope.FindScope(resources_radius.PlaneTypeRadius)
+	resourceGroupName := scope.FindScope(resources_radius.ScopeResourceGroups)
+	if planeName == "" || resourceGroupName == "" {
+		return nil, clierrors.Message("The scope %q is not a Radius resource group. Use --group to watch an application in a resource group.", r.Workspace.Scope)
+	}
+
+	return client.WatchResourcesInResourceGroup(ctx, planeName, resourceGroupName)
+}
+
+// drain discards the changes that are ready to be received without blocking.
+func drain(changes <-chan clients.ResourceChange) {
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (r *Runner) getStatus(ctx context.Context, client clients.ApplicationsManagementClient) (clients.ApplicationStatus, error) {
 	application, err := client.GetApplication(ctx, r.ApplicationName)
 	if clients.Is404Error(err) {
-		return clierrors.Message("The application %q was not found or has been deleted.", r.ApplicationName)
+		return clients.ApplicationStatus{}, clierrors.Message("The application %q was not found or has been deleted.", r.ApplicationName)
 	} else if err != nil {
-		return err
+		return clients.ApplicationStatus{}, err
 	}
 
 	resourceList, err := client.ListResourcesInApplication(ctx, r.ApplicationName)
 	if err != nil {
-		return err
+		return clients.ApplicationStatus{}, err
 	}
 
 	applicationStatus := clients.ApplicationStatus{
@@ -142,20 +243,20 @@ func (r *Runner) Run(ctx context.Context) error {
 
 	diagnosticsClient, err := r.ConnectionFactory.CreateDiagnosticsClient(ctx, *r.Workspace)
 	if err != nil {
-		return err
+		return clients.ApplicationStatus{}, err
 	}
 
 	for _, resource := range resourceList {
 		resourceID, err := resources.ParseResource(*resource.ID)
 		if err != nil {
-			return err
+			return clients.ApplicationStatus{}, err
 		}
 
 		publicEndpoint, err := diagnosticsClient.GetPublicEndpoint(ctx, clients.EndpointOptions{
 			ResourceID: resourceID,
 		})
 		if err != nil {
-			return err
+			return clients.ApplicationStatus{}, err
 		}
 
 		if publicEndpoint != nil {
@@ -166,7 +267,11 @@ func (r *Runner) Run(ctx context.Context) error {
 		}
 	}
 
-	err = r.Output.WriteFormatted(r.Format, applicationStatus, StatusFormat())
+	return applicationStatus, nil
+}
+
+func (r *Runner) writeStatus(applicationStatus clients.ApplicationStatus) error {
+	err := r.Output.WriteFormatted(r.Format, applicationStatus, StatusFormat())
 	if err != nil {
 		return err
 	}
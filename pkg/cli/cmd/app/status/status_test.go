@@ -17,6 +17,7 @@ limitations under the License.
 package status
 
 import (
+	"context"
 	"testing"
 
 	"github.com/radius-project/radius/pkg/cli/clients"
@@ -183,6 +184,106 @@ func Test_Run(t *testing.T) {
 		require.Equal(t, expected, outputSink.Writes)
 	})
 
+	t.Run("Success: Watch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		application := v20231001preview.ApplicationResource{
+			Name: new("test-app"),
+		}
+
+		container := generated.GenericResource{
+			Name: new("test-container"),
+			ID:   new("/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/containers/test-container"),
+		}
+
+		changes := make(chan clients.ResourceChange, 2)
+
+		appManagementClient := clients.NewMockApplicationsManagementClient(ctrl)
+		appManagementClient.EXPECT().
+			WatchResourcesInResourceGroup(gomock.Any(), "local", "test-group").
+			Return(changes, nil).
+			Times(1)
+		appManagementClient.EXPECT().
+			GetApplication(gomock.Any(), "test-app").
+			Return(application, nil).
+			Times(3)
+
+		// The first change does not change the status, so the status is printed again only after the second change.
+		changes <- clients.ResourceChange{Type: "Updated"}
+		gomock.InOrder(
+			appManagementClient.EXPECT().
+				ListResourcesInApplication(gomock.Any(), "test-app").
+				Return([]generated.GenericResource{}, nil).
+				Times(1),
+			appManagementClient.EXPECT().
+				ListResourcesInApplication(gomock.Any(), "test-app").
+				DoAndReturn(func(ctx context.Context, name string) ([]generated.GenericResource, error) {
+					changes <- clients.ResourceChange{Type: "Created"}
+					return []generated.GenericResource{}, nil
+				}).
+				Times(1),
+			appManagementClient.EXPECT().
+				ListResourcesInApplication(gomock.Any(), "test-app").
+				DoAndReturn(func(ctx context.Context, name string) ([]generated.GenericResource, error) {
+					close(changes)
+					return []generated.GenericResource{container}, nil
+				}).
+				Times(1),
+		)
+
+		diagnosticsClient := clients.NewMockDiagnosticsClient(ctrl)
+		diagnosticsClient.EXPECT().
+			GetPublicEndpoint(gomock.Any(), gomock.Any()).
+			Return(nil, nil).
+			Times(1)
+
+		workspace := &workspaces.Workspace{
+			Connection: map[string]any{
+				"kind":    "kubernetes",
+				"context": "kind-kind",
+			},
+			Name:  "kind-kind",
+			Scope: "/planes/radius/local/resourceGroups/test-group",
+		}
+		outputSink := &output.MockOutput{}
+		runner := &Runner{
+			ConnectionFactory: &connections.MockFactory{
+				ApplicationsManagementClient: appManagementClient,
+				DiagnosticsClient:            diagnosticsClient,
+			},
+			Workspace:       workspace,
+			Format:          "table",
+			Output:          outputSink,
+			ApplicationName: "test-app",
+			Watch:           true,
+		}
+
+		err := runner.Run(ctx)
+		require.Equal(t, clierrors.Message("The connection to Radius was lost while watching the application \"test-app\"."), err)
+
+		expected := []any{
+			output.FormattedOutput{
+				Format:  "table",
+				Obj:     clients.ApplicationStatus{Name: "test-app"},
+				Options: StatusFormat(),
+			},
+			output.LogOutput{
+				Format: "",
+			},
+			output.FormattedOutput{
+				Format:  "table",
+				Obj:     clients.ApplicationStatus{Name: "test-app", ResourceCount: 1},
+				Options: StatusFormat(),
+			},
+		}
+
+		require.Equal(t, expected, outputSink.Writes)
+	})
+
 	t.Run("Error: Application Not Found", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
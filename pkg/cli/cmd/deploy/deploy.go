@@ -44,6 +44,7 @@ import (
 	"github.com/radius-project/radius/pkg/cli/workspaces"
 	"github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
 	"github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/recipes/engine"
 	"github.com/radius-project/radius/pkg/to"
 	"github.com/radius-project/radius/pkg/ucp/resources"
 	"github.com/spf13/cobra"
@@ -91,6 +92,10 @@ When passing multiple parameters in a single file, use the format described here
 
 You can specify parameters using multiple sources. Parameters can be overridden based on the
 order they are provided. Parameters appearing later in the argument list will override those defined earlier.
+
+Pressing Ctrl-C during the deployment cancels the operations in progress on Radius resources, for example
+a recipe deployment that does not complete. Resources that were already deployed are not removed. Use
+'rad resource cancel' to cancel the operation of a single resource.
 `,
 		Example: `
 # deploy a Bicep template
@@ -129,6 +134,10 @@ rad deploy myapp.bicep --parameters @myfile.json
 
 # specify parameters from multiple sources
 rad deploy myapp.bicep --parameters @myfile.json --parameters version=latest
+
+
+# preview the changes a deployment would make without deploying anything
+rad deploy myapp.bicep --application myapp --what-if
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: framework.RunCommand(runner),
@@ -140,6 +149,7 @@ rad deploy myapp.bicep --parameters @myfile.json --parameters version=latest
 	commonflags.AddApplicationNameFlag(cmd)
 	commonflags.AddParameterFlag(cmd)
 	cmd.Flags().Bool("preview", false, "Deploy the application using the Radius.Core/applications resource type instead of Applications.Core/applications (can also be set via RADIUS_PREVIEW=true)")
+	cmd.Flags().Bool("what-if", false, "Show the planned graph of the deployment and how it differs from the deployed application, without deploying anything")
 
 	return cmd, runner
 }
@@ -168,6 +178,12 @@ type Runner struct {
 	// Preview indicates that the application should be deployed using the
 	// Radius.Core/applications resource type instead of Applications.Core/applications.
 	Preview bool
+	// WhatIf indicates that the deployment should be planned and compared with the deployed
+	// application instead of being deployed.
+	WhatIf bool
+	// RecipeEngine resolves recipes for a what-if deployment. Initialized on demand; tests may
+	// substitute a mock.
+	RecipeEngine engine.Engine
 }
 
 // NewRunner creates a new instance of the `rad deploy` runner.
@@ -260,6 +276,18 @@ func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
 		return clierrors.Message("The --preview flag requires an application. Use --application to specify the application name, or set a default application in your workspace.")
 	}
 
+	// The --what-if flag is only registered on the `rad deploy` command; `rad run` embeds this
+	// runner without it.
+	if cmd.Flags().Lookup("what-if") != nil {
+		r.WhatIf, err = cmd.Flags().GetBool("what-if")
+		if err != nil {
+			return err
+		}
+	}
+	if r.WhatIf && r.EnvironmentNameOrID == "" {
+		return clierrors.Message("The --what-if flag requires an existing environment. Use --environment to specify the environment name.")
+	}
+
 	if r.EnvironmentNameOrID != "" {
 		envResult, err := r.FetchEnvironment(cmd.Context(), r.EnvironmentNameOrID)
 		if err != nil {
@@ -313,6 +341,10 @@ func (r *Runner) Run(ctx context.Context) error {
 		return err
 	}
 
+	if r.WhatIf {
+		return r.runWhatIf(ctx, template)
+	}
+
 	// Create application if specified. This supports the case where the application resource
 	// is not specified in Bicep. Creating the application automatically helps us "bootstrap" in a new environment.
 	// Note: This only applies when the environment already exists. If the template is creating the environment,
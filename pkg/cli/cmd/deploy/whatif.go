@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/radius-project/radius/pkg/cli/bicep"
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/clierrors"
+	"github.com/radius-project/radius/pkg/cli/cmd"
+	appgraphdiff "github.com/radius-project/radius/pkg/cli/cmd/app/graph/diff"
+	cligraph "github.com/radius-project/radius/pkg/cli/graph"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/recipes/configloader"
+	"github.com/radius-project/radius/pkg/recipes/engine"
+	"github.com/radius-project/radius/pkg/sdk"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+// runWhatIf builds the planned graph of template and compares it with the deployed graph of the
+// application. Nothing is created, updated or deleted: the environment and the application are
+// only read, and recipes are resolved with simulated executions of the recipe engine.
+func (r *Runner) runWhatIf(ctx context.Context, template map[string]any) error {
+	displayPath := bicep.RedactTemplatePath(r.FilePath)
+	if r.ApplicationName == "" {
+		r.Output.LogInfo("Planning template '%v' for environment '%v' from workspace '%v'. Nothing will be deployed.",
+			displayPath, r.EnvironmentNameOrID, r.Workspace.Name)
+	} else {
+		r.Output.LogInfo("Planning template '%v' for application '%v' and environment '%v' from workspace '%v'. Nothing will be deployed.",
+			displayPath, r.ApplicationName, r.EnvironmentNameOrID, r.Workspace.Name)
+	}
+
+	deployed, applicationExists, err := r.loadDeployedGraph(ctx)
+	if err != nil {
+		return err
+	}
+
+	modeled, err := cligraph.BuildModeledGraph(template, false)
+	if err != nil {
+		return clierrors.Message("Failed to build modeled graph: %v", err)
+	}
+
+	if r.RecipeEngine == nil {
+		connection, err := r.Workspace.Connect(ctx)
+		if err != nil {
+			return err
+		}
+		r.RecipeEngine = engine.NewEngine(engine.Options{
+			ConfigurationLoader: configloader.NewEnvironmentLoader(sdk.NewClientOptions(connection)),
+		})
+	}
+
+	// The recipe engine loads the application when given its ID, so only pass the ID of an
+	// application that already exists.
+	applicationID := ""
+	if applicationExists {
+		applicationID = r.Providers.Radius.ApplicationID
+	}
+
+	planned, err := cligraph.BuildPlannedGraph(ctx, modeled, cligraph.PlanOptions{
+		Engine:        r.RecipeEngine,
+		EnvironmentID: r.Providers.Radius.EnvironmentID,
+		ApplicationID: applicationID,
+		Scope:         r.Workspace.Scope,
+	})
+	if err != nil {
+		return err
+	}
+
+	before := "deployed:" + r.ApplicationName
+	after := "planned:" + displayPath
+	diff, err := cligraph.DiffGraphs(deployed, planned.Graph)
+	if err != nil {
+		return clierrors.MessageWithCause(err, "Failed to compare %q with %q.", before, after)
+	}
+
+	if err := r.displayPlannedRecipes(planned); err != nil {
+		return err
+	}
+
+	r.Output.LogInfo("")
+	return appgraphdiff.WriteDiff(r.Output, diff, before, after)
+}
+
+// loadDeployedGraph returns the deployed graph of the application, and whether the application
+// exists. A deployment without an application, or to an application that does not exist yet,
+// is compared with an empty graph.
+func (r *Runner) loadDeployedGraph(ctx context.Context) (*v20250801preview.ApplicationGraphResponse, bool, error) {
+	empty := &v20250801preview.ApplicationGraphResponse{Resources: []*v20250801preview.ApplicationGraphResource{}}
+	if r.ApplicationName == "" {
+		return empty, false, nil
+	}
+
+	applicationID, err := resources.ParseResource(r.Providers.Radius.ApplicationID)
+	if err == nil && strings.EqualFold(applicationID.ProviderNamespace(), radiusCoreProviderName) {
+		if r.RadiusCoreClientFactory == nil {
+			clientFactory, err := cmd.InitializeRadiusCoreClientFactory(ctx, r.Workspace)
+			if err != nil {
+				return nil, false, err
+			}
+			r.RadiusCoreClientFactory = clientFactory
+		}
+
+		response, err := r.RadiusCoreClientFactory.NewApplicationsClient().GetGraph(ctx, r.Workspace.Scope, r.ApplicationName, v20250801preview.GetGraphRequest{}, &v20250801preview.ApplicationsClientGetGraphOptions{})
+		if clients.Is404Error(err) {
+			return empty, false, nil
+		} else if err != nil {
+			return nil, false, err
+		}
+		return &response.ApplicationGraphResponse, true, nil
+	}
+
+	client, err := r.ConnectionFactory.CreateApplicationsManagementClient(ctx, *r.Workspace)
+	if err != nil {
+		return nil, false, err
+	}
+
+	response, err := client.GetApplicationGraph(ctx, r.ApplicationName)
+	if clients.Is404Error(err) {
+		return empty, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	graph, err := cligraph.FromApplicationsCoreGraph(response)
+	if err != nil {
+		return nil, false, err
+	}
+	return graph, true, nil
+}
+
+// displayPlannedRecipes writes the recipes of the planned graph, followed by the reasons any of
+// them could not be resolved.
+func (r *Runner) displayPlannedRecipes(planned *cligraph.PlannedGraph) error {
+	if len(planned.Recipes) == 0 {
+		return nil
+	}
+
+	r.Output.LogInfo("")
+	r.Output.LogInfo("Recipes: %d resolved, %d failed, %d skipped.",
+		planned.Count(cligraph.PlanStatusResolved), planned.Count(cligraph.PlanStatusFailed), planned.Count(cligraph.PlanStatusSkipped))
+	r.Output.LogInfo("")
+	if err := r.Output.WriteFormatted(output.FormatTable, planned.Recipes, objectformats.GetPlannedRecipeTableFormat()); err != nil {
+		return err
+	}
+
+	failures := []string{}
+	for _, recipe := range planned.Recipes {
+		if recipe.Status == cligraph.PlanStatusFailed {
+			failures = append(failures, fmt.Sprintf("  - %s/%s: %s", recipe.ResourceType, recipe.ResourceName, recipe.Error))
+		}
+	}
+	if len(failures) > 0 {
+		r.Output.LogInfo("")
+		r.Output.LogInfo("The following recipes could not be resolved:\n\n%s", strings.Join(failures, "\n"))
+	}
+
+	return nil
+}
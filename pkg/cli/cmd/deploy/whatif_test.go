@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	cligraph "github.com/radius-project/radius/pkg/cli/graph"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	"github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/engine"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/radius-project/radius/test/radcli"
+)
+
+func Test_Run_WhatIf(t *testing.T) {
+	const (
+		scope         = "/planes/radius/local/resourceGroups/test-group"
+		applicationID = scope + "/providers/Applications.Core/applications/test-app"
+	)
+
+	template := map[string]any{
+		"resources": []any{
+			map[string]any{
+				"type":       "Applications.Core/containers",
+				"name":       "web",
+				"properties": map[string]any{"container": map[string]any{"image": "nginx"}},
+			},
+			map[string]any{
+				"type":       "Applications.Datastores/redisCaches",
+				"name":       "cache",
+				"properties": map[string]any{"recipe": map[string]any{"name": "azure"}},
+			},
+		},
+	}
+
+	t.Run("plans against the deployed application", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		appManagementClient := clients.NewMockApplicationsManagementClient(ctrl)
+		appManagementClient.EXPECT().
+			GetApplicationGraph(gomock.Any(), "test-app").
+			Return(v20231001preview.ApplicationGraphResponse{
+				Resources: []*v20231001preview.ApplicationGraphResource{
+					{
+						ID:         to.Ptr(scope + "/providers/Applications.Core/containers/web"),
+						Name:       to.Ptr("web"),
+						Type:       to.Ptr("Applications.Core/containers"),
+						Properties: map[string]any{"container": map[string]any{"image": "nginx"}},
+					},
+					{
+						ID:   to.Ptr(scope + "/providers/Applications.Core/containers/old"),
+						Name: to.Ptr("old"),
+						Type: to.Ptr("Applications.Core/containers"),
+					},
+				},
+			}, nil).
+			Times(1)
+
+		recipeEngine := engine.NewMockEngine(ctrl)
+		recipeEngine.EXPECT().
+			Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, opts engine.ExecuteOptions) (*recipes.RecipeOutput, error) {
+				require.True(t, opts.Simulated)
+				require.Equal(t, "azure", opts.Recipe.Name)
+				require.Equal(t, radcli.TestEnvironmentID, opts.Recipe.EnvironmentID)
+				require.Equal(t, applicationID, opts.Recipe.ApplicationID)
+				require.Equal(t, scope+"/providers/Applications.Datastores/redisCaches/cache", opts.Recipe.ResourceID)
+				return &recipes.RecipeOutput{
+					Status: &rpv1.RecipeStatus{TemplateKind: recipes.TemplateKindBicep, TemplatePath: "ghcr.io/acme/redis:1"},
+				}, nil
+			}).
+			Times(1)
+
+		// No deployment mock is configured: deploying, creating the application or setting up
+		// recipe packs would fail the test.
+		outputSink := &output.MockOutput{}
+		runner := &Runner{
+			ConnectionFactory:   &connections.MockFactory{ApplicationsManagementClient: appManagementClient},
+			Output:              outputSink,
+			RecipeEngine:        recipeEngine,
+			FilePath:            "app.bicep",
+			ApplicationName:     "test-app",
+			EnvironmentNameOrID: radcli.TestEnvironmentID,
+			Parameters:          map[string]map[string]any{},
+			Template:            template,
+			Workspace:           &workspaces.Workspace{Name: "test", Scope: scope},
+			Providers: &clients.Providers{
+				Radius: &clients.RadiusProvider{EnvironmentID: radcli.TestEnvironmentID, ApplicationID: applicationID},
+			},
+			WhatIf: true,
+		}
+		require.NoError(t, runner.Run(t.Context()))
+
+		require.Equal(t, output.LogOutput{
+			Format: "Planning template '%v' for application '%v' and environment '%v' from workspace '%v'. Nothing will be deployed.",
+			Params: []any{"app.bicep", "test-app", radcli.TestEnvironmentID, "test"},
+		}, outputSink.Writes[0])
+		require.Equal(t, output.LogOutput{
+			Format: "Recipes: %d resolved, %d failed, %d skipped.",
+			Params: []any{1, 0, 0},
+		}, outputSink.Writes[2])
+
+		recipesOutput, ok := outputSink.Writes[4].(output.FormattedOutput)
+		require.True(t, ok)
+		plannedRecipes := recipesOutput.Obj.([]cligraph.PlannedRecipe)
+		require.Len(t, plannedRecipes, 1)
+		require.Equal(t, "ghcr.io/acme/redis:1", plannedRecipes[0].TemplatePath)
+
+		require.Equal(t, output.LogOutput{
+			Format: "Comparing %s with %s: %d added, %d removed, %d modified, %d unchanged.",
+			Params: []any{"deployed:test-app", "planned:app.bicep", 1, 1, 0, 1},
+		}, outputSink.Writes[6])
+		changesOutput, ok := outputSink.Writes[8].(output.FormattedOutput)
+		require.True(t, ok)
+		changes := changesOutput.Obj.([]cligraph.ResourceChange)
+		require.Len(t, changes, 2)
+		require.Equal(t, cligraph.ChangeRemoved, changes[0].Change)
+		require.Equal(t, "old", changes[0].Name)
+		require.Equal(t, cligraph.ChangeAdded, changes[1].Change)
+		require.Equal(t, "cache", changes[1].Name)
+	})
+
+	t.Run("application that does not exist yet", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		appManagementClient := clients.NewMockApplicationsManagementClient(ctrl)
+		appManagementClient.EXPECT().
+			GetApplicationGraph(gomock.Any(), "test-app").
+			Return(v20231001preview.ApplicationGraphResponse{}, radcli.Create404Error()).
+			Times(1)
+
+		recipeEngine := engine.NewMockEngine(ctrl)
+		recipeEngine.EXPECT().
+			Execute(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, opts engine.ExecuteOptions) (*recipes.RecipeOutput, error) {
+				// The application is not passed to the recipe engine, which would fail to load it.
+				require.Empty(t, opts.Recipe.ApplicationID)
+				return nil, nil
+			}).
+			Times(1)
+
+		outputSink := &output.MockOutput{}
+		runner := &Runner{
+			ConnectionFactory:   &connections.MockFactory{ApplicationsManagementClient: appManagementClient},
+			Output:              outputSink,
+			RecipeEngine:        recipeEngine,
+			FilePath:            "app.bicep",
+			ApplicationName:     "test-app",
+			EnvironmentNameOrID: radcli.TestEnvironmentID,
+			Parameters:          map[string]map[string]any{},
+			Template:            template,
+			Workspace:           &workspaces.Workspace{Name: "test", Scope: scope},
+			Providers: &clients.Providers{
+				Radius: &clients.RadiusProvider{EnvironmentID: radcli.TestEnvironmentID, ApplicationID: applicationID},
+			},
+			WhatIf: true,
+		}
+		require.NoError(t, runner.Run(t.Context()))
+
+		require.Equal(t, output.LogOutput{
+			Format: "Recipes: %d resolved, %d failed, %d skipped.",
+			Params: []any{0, 0, 1},
+		}, outputSink.Writes[2])
+		require.Equal(t, output.LogOutput{
+			Format: "Comparing %s with %s: %d added, %d removed, %d modified, %d unchanged.",
+			Params: []any{"deployed:test-app", "planned:app.bicep", 2, 0, 0, 0},
+		}, outputSink.Writes[6])
+	})
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/radius-project/radius/pkg/cli/dbmigrate"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/pgbackup"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/databaseprovider"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
+	"github.com/radius-project/radius/test/radcli"
+	"github.com/stretchr/testify/require"
+)
+
+const postgresURL = "postgresql://radius@localhost:5432/{database}"
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+	nonKubernetesConfig := radcli.LoadConfig(t, `
+workspaces:
+  default: github-workspace
+  items:
+    github-workspace:
+      connection:
+        kind: notkubernetes
+      environment: /planes/radius/local/resourceGroups/test/providers/Applications.Core/environments/test
+      scope: /planes/radius/local/resourceGroups/test
+`)
+
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "apiserver to postgres is valid",
+			Input:         []string{"--from", "apiserver", "--to", "postgres", "--postgres-url", postgresURL},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: configWithWorkspace},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				r := runner.(*Runner)
+				require.Equal(t, databaseprovider.TypeAPIServer, r.From)
+				require.Equal(t, databaseprovider.TypePostgreSQL, r.To)
+				require.Equal(t, postgresURL, r.PostgresURL)
+				require.False(t, r.DryRun)
+			},
+		},
+		{
+			Name:          "postgresql to apiserver with dry run is valid",
+			Input:         []string{"--from", "postgresql", "--to", "apiserver", "--postgres-url", postgresURL, "--dry-run", "--overwrite"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: configWithWorkspace},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				r := runner.(*Runner)
+				require.True(t, r.DryRun)
+				require.True(t, r.Overwrite)
+			},
+		},
+		{
+			Name:          "same provider is invalid",
+			Input:         []string{"--from", "apiserver", "--to", "apiserver"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: configWithWorkspace},
+		},
+		{
+			Name:          "unknown provider is invalid",
+			Input:         []string{"--from", "apiserver", "--to", "cosmosdb"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: configWithWorkspace},
+		},
+		{
+			Name:          "postgres without placeholder is invalid",
+			Input:         []string{"--from", "apiserver", "--to", "postgres", "--postgres-url", "postgresql://radius@localhost:5432/ucp"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: configWithWorkspace},
+		},
+		{
+			Name:          "non-kubernetes workspace is invalid",
+			Input:         []string{"--from", "apiserver", "--to", "postgres", "--postgres-url", postgresURL},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: nonKubernetesConfig},
+		},
+		{
+			Name:          "positional args are invalid",
+			Input:         []string{"unexpected", "--from", "apiserver", "--to", "postgres", "--postgres-url", postgresURL},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: configWithWorkspace},
+		},
+	}
+
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func Test_Validate_PostgresURLFromEnvironment(t *testing.T) {
+	t.Setenv(pgbackup.DatabaseURLEnvVar, postgresURL)
+
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "postgres url defaults to the environment",
+			Input:         []string{"--from", "apiserver", "--to", "postgres"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: radcli.LoadConfigWithWorkspace(t)},
+			ValidateCallback: func(t *testing.T, runner framework.Runner) {
+				require.Equal(t, postgresURL, runner.(*Runner).PostgresURL)
+			},
+		},
+	}
+
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+// fakeScaler records scale operations in order.
+type fakeScaler struct {
+	order *[]string
+}
+
+func (s *fakeScaler) ScaleDown(ctx context.Context) (map[string]int32, error) {
+	*s.order = append(*s.order, "scaledown")
+	return map[string]int32{"ucp": 1}, nil
+}
+
+func (s *fakeScaler) ScaleUp(ctx context.Context, saved map[string]int32) error {
+	*s.order = append(*s.order, "scaleup")
+	return nil
+}
+
+func newTestRunner(t *testing.T, stores map[databaseprovider.DatabaseProviderType][]pgbackup.Store, order *[]string) (*Runner, *output.MockOutput) {
+	outputSink := &output.MockOutput{}
+	return &Runner{
+		Output: outputSink,
+		Workspace: &workspaces.Workspace{
+			Name:       "test",
+			Connection: map[string]any{"kind": workspaces.KindKubernetes, "context": "k3d-test"},
+		},
+		Format:      "table",
+		From:        databaseprovider.TypeAPIServer,
+		To:          databaseprovider.TypePostgreSQL,
+		PostgresURL: postgresURL,
+		openStores: func(ctx context.Context, provider databaseprovider.DatabaseProviderType, url, kubeContext, namespace string) ([]pgbackup.Store, error) {
+			require.Equal(t, "k3d-test", kubeContext)
+			*order = append(*order, "open "+string(provider))
+			return stores[provider], nil
+		},
+		newScaler: func(kubeContext, namespace string) (ControlPlaneScaler, error) {
+			return &fakeScaler{order: order}, nil
+		},
+	}, outputSink
+}
+
+func testStores(t *testing.T) map[databaseprovider.DatabaseProviderType][]pgbackup.Store {
+	source := inmemory.NewClient()
+	obj := &database.Object{Metadata: database.Metadata{ID: "/planes/radius/local/resourceGroups/default"}, Data: map[string]any{"name": "default"}}
+	require.NoError(t, source.Save(t.Context(), obj))
+
+	targets := []pgbackup.Store{}
+	for _, db := range pgbackup.Databases {
+		targets = append(targets, pgbackup.Store{Name: db, Client: inmemory.NewClient()})
+	}
+
+	return map[databaseprovider.DatabaseProviderType][]pgbackup.Store{
+		databaseprovider.TypeAPIServer:  {{Name: pgbackup.APIServerStoreName, Client: source}},
+		databaseprovider.TypePostgreSQL: targets,
+	}
+}
+
+func Test_Run(t *testing.T) {
+	stores := testStores(t)
+	order := []string{}
+	runner, outputSink := newTestRunner(t, stores, &order)
+
+	require.NoError(t, runner.Run(t.Context()))
+	require.Equal(t, []string{"open apiserver", "open postgresql", "scaledown", "scaleup"}, order)
+
+	_, err := stores[databaseprovider.TypePostgreSQL][0].Client.Get(t.Context(), "/planes/radius/local/resourceGroups/default")
+	require.NoError(t, err)
+
+	var formatted *output.FormattedOutput
+	for _, write := range outputSink.Writes {
+		if w, ok := write.(output.FormattedOutput); ok {
+			formatted = &w
+		}
+	}
+	require.NotNil(t, formatted)
+	require.Equal(t, objectformats.GetDatabaseMigrationTableFormat(), formatted.Options)
+	results := formatted.Obj.([]dbmigrate.StoreResult)
+	require.Len(t, results, 3)
+	require.Equal(t, 1, results[0].Count)
+}
+
+func Test_Run_DryRunDoesNotScaleOrWrite(t *testing.T) {
+	stores := testStores(t)
+	order := []string{}
+	runner, _ := newTestRunner(t, stores, &order)
+	runner.DryRun = true
+
+	require.NoError(t, runner.Run(t.Context()))
+	require.Equal(t, []string{"open apiserver", "open postgresql"}, order)
+
+	_, err := stores[databaseprovider.TypePostgreSQL][0].Client.Get(t.Context(), "/planes/radius/local/resourceGroups/default")
+	require.ErrorIs(t, err, &database.ErrNotFound{})
+}
+
+func Test_Run_FailureScalesBackUp(t *testing.T) {
+	stores := testStores(t)
+	existing := &database.Object{Metadata: database.Metadata{ID: "/planes/radius/local/resourceGroups/other"}, Data: map[string]any{}}
+	require.NoError(t, stores[databaseprovider.TypePostgreSQL][0].Client.Save(t.Context(), existing))
+
+	order := []string{}
+	runner, _ := newTestRunner(t, stores, &order)
+
+	err := runner.Run(t.Context())
+	require.ErrorContains(t, err, "already holds 1 objects")
+	require.Equal(t, []string{"open apiserver", "open postgresql", "scaledown", "scaleup"}, order)
+}
+
+func Test_Run_OpenFailureIsReturned(t *testing.T) {
+	order := []string{}
+	runner, _ := newTestRunner(t, nil, &order)
+	runner.openStores = func(ctx context.Context, provider databaseprovider.DatabaseProviderType, url, kubeContext, namespace string) ([]pgbackup.Store, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	err := runner.Run(t.Context())
+	require.ErrorContains(t, err, "failed to connect to the apiserver store")
+	require.ErrorContains(t, err, "connection refused")
+	require.Empty(t, order, "the control plane must not be scaled down when a store cannot be opened")
+}
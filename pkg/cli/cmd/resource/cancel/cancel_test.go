@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cancel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	"github.com/radius-project/radius/test/radcli"
+)
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "Valid Cancel Command",
+			Input:         []string{"Applications.Datastores/redisCaches", "cache"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Cancel Command with invalid resource type",
+			Input:         []string{"invalidResourceType", "cache"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Cancel Command with insufficient args",
+			Input:         []string{"Applications.Datastores/redisCaches"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+	}
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func Test_Run(t *testing.T) {
+	tests := []struct {
+		name     string
+		canceled []string
+		expected output.LogOutput
+	}{
+		{
+			name:     "operation in progress",
+			canceled: []string{"op-1"},
+			expected: output.LogOutput{
+				Format: "Cancellation of operation %s on %s/%s requested",
+				Params: []any{"op-1", "Applications.Datastores/redisCaches", "cache"},
+			},
+		},
+		{
+			name:     "no operation in progress",
+			canceled: []string{},
+			expected: output.LogOutput{
+				Format: "%s/%s has no operation in progress",
+				Params: []any{"Applications.Datastores/redisCaches", "cache"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			appManagementClient := clients.NewMockApplicationsManagementClient(ctrl)
+			appManagementClient.EXPECT().
+				CancelResourceOperations(gomock.Any(), "Applications.Datastores/redisCaches", "cache").
+				Return(tt.canceled, nil)
+
+			outputSink := &output.MockOutput{}
+			runner := &Runner{
+				ConnectionFactory:              &connections.MockFactory{ApplicationsManagementClient: appManagementClient},
+				Output:                         outputSink,
+				Workspace:                      &workspaces.Workspace{},
+				FullyQualifiedResourceTypeName: "Applications.Datastores/redisCaches",
+				ResourceName:                   "cache",
+			}
+
+			require.NoError(t, runner.Run(t.Context()))
+			require.Equal(t, []any{tt.expected}, outputSink.Writes)
+		})
+	}
+}
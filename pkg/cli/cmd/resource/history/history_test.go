@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/armrpc/history"
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	"github.com/radius-project/radius/test/radcli"
+)
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "Valid History Command",
+			Input:         []string{"Applications.Core/containers", "orders"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "History Command with invalid resource type",
+			Input:         []string{"invalidResourceType", "orders"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "History Command with insufficient args",
+			Input:         []string{"Applications.Core/containers"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+	}
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func Test_Run(t *testing.T) {
+	entries := []*history.Entry{
+		{
+			Name:              "entry-1",
+			OperationType:     "APPLICATIONS.CORE/CONTAINERS|PUT",
+			Caller:            "user@contoso.com",
+			Timestamp:         time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			ProvisioningState: v1.ProvisioningStateSucceeded,
+			PropertiesHash:    "abc",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		entries  []*history.Entry
+		expected any
+	}{
+		{
+			name:    "history",
+			format:  "table",
+			entries: entries,
+			expected: output.FormattedOutput{
+				Format:  "table",
+				Obj:     entries,
+				Options: objectformats.GetResourceHistoryTableFormat(),
+			},
+		},
+		{
+			name:    "no history",
+			format:  "table",
+			entries: []*history.Entry{},
+			expected: output.LogOutput{
+				Format: "%s/%s has no operation history",
+				Params: []any{"Applications.Core/containers", "orders"},
+			},
+		},
+		{
+			name:    "no history in JSON",
+			format:  "json",
+			entries: []*history.Entry{},
+			expected: output.FormattedOutput{
+				Format:  "json",
+				Obj:     []*history.Entry{},
+				Options: objectformats.GetResourceHistoryTableFormat(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			appManagementClient := clients.NewMockApplicationsManagementClient(ctrl)
+			appManagementClient.EXPECT().
+				GetResourceHistory(gomock.Any(), "Applications.Core/containers", "orders").
+				Return(tt.entries, nil)
+
+			outputSink := &output.MockOutput{}
+			runner := &Runner{
+				ConnectionFactory:              &connections.MockFactory{ApplicationsManagementClient: appManagementClient},
+				Output:                         outputSink,
+				Workspace:                      &workspaces.Workspace{},
+				FullyQualifiedResourceTypeName: "Applications.Core/containers",
+				ResourceName:                   "orders",
+				Format:                         tt.format,
+			}
+
+			require.NoError(t, runner.Run(t.Context()))
+			require.Equal(t, []any{tt.expected}, outputSink.Writes)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/connections"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/workspaces"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/test/radcli"
+)
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "Valid Plan Command",
+			Input:         []string{"Applications.Datastores/redisCaches", "cache"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Plan Command with invalid resource type",
+			Input:         []string{"invalidResourceType", "cache"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+		{
+			Name:          "Plan Command with insufficient args",
+			Input:         []string{"Applications.Datastores/redisCaches"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{Config: configWithWorkspace},
+		},
+	}
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func Test_Run(t *testing.T) {
+	plan := &recipes.RecipePlan{
+		Changes: []recipes.ResourceChange{
+			{
+				ID:     "aws_elasticache_cluster.cache",
+				Type:   "aws_elasticache_cluster",
+				Action: recipes.ChangeActionUpdate,
+				Attributes: []recipes.AttributeChange{
+					{Path: "num_cache_nodes", Before: float64(1), After: float64(2)},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		plan     *recipes.RecipePlan
+		expected any
+	}{
+		{
+			name:   "changes",
+			format: "table",
+			plan:   plan,
+			expected: output.FormattedOutput{
+				Format:  "table",
+				Obj:     plan.Changes,
+				Options: objectformats.GetRecipePlanTableFormat(),
+			},
+		},
+		{
+			name:   "changes in JSON",
+			format: "json",
+			plan:   plan,
+			expected: output.FormattedOutput{
+				Format:  "json",
+				Obj:     plan,
+				Options: objectformats.GetRecipePlanTableFormat(),
+			},
+		},
+		{
+			name:   "no changes",
+			format: "table",
+			plan:   &recipes.RecipePlan{Changes: []recipes.ResourceChange{}},
+			expected: output.LogOutput{
+				Format: "Deploying the recipe of %s/%s would make no changes",
+				Params: []any{"Applications.Datastores/redisCaches", "cache"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			appManagementClient := clients.NewMockApplicationsManagementClient(ctrl)
+			appManagementClient.EXPECT().
+				PlanResourceRecipe(gomock.Any(), "Applications.Datastores/redisCaches", "cache").
+				Return(tt.plan, nil)
+
+			outputSink := &output.MockOutput{}
+			runner := &Runner{
+				ConnectionFactory:              &connections.MockFactory{ApplicationsManagementClient: appManagementClient},
+				Output:                         outputSink,
+				Workspace:                      &workspaces.Workspace{},
+				FullyQualifiedResourceTypeName: "Applications.Datastores/redisCaches",
+				ResourceName:                   "cache",
+				Format:                         tt.format,
+			}
+
+			require.NoError(t, runner.Run(t.Context()))
+			require.Equal(t, []any{tt.expected}, outputSink.Writes)
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		appManagementClient := clients.NewMockApplicationsManagementClient(ctrl)
+		appManagementClient.EXPECT().
+			PlanResourceRecipe(gomock.Any(), "Applications.Datastores/redisCaches", "cache").
+			Return(nil, errors.New("the resource is not provisioned by a recipe"))
+
+		runner := &Runner{
+			ConnectionFactory:              &connections.MockFactory{ApplicationsManagementClient: appManagementClient},
+			Output:                         &output.MockOutput{},
+			Workspace:                      &workspaces.Workspace{},
+			FullyQualifiedResourceTypeName: "Applications.Datastores/redisCaches",
+			ResourceName:                   "cache",
+			Format:                         "table",
+		}
+
+		require.EqualError(t, runner.Run(t.Context()), "the resource is not provisioned by a recipe")
+	})
+}
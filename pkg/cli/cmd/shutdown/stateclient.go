@@ -26,7 +26,8 @@ import (
 // StateBackupClient backs up the durable Radius state for a Kubernetes context. It wraps the
 // pgbackup and tfstate packages so the command can be unit tested without a cluster.
 type StateBackupClient interface {
-	// BackupDatabases dumps the control-plane PostgreSQL databases into stateDir.
+	// BackupDatabases dumps the control-plane databases into stateDir, with pg_dump or as a native
+	// backup when one is configured.
 	BackupDatabases(ctx context.Context, kubeContext, namespace, stateDir string) error
 
 	// BackupTerraform exports the Terraform state Secrets into stateDir.
@@ -42,6 +43,13 @@ func NewStateBackupClient() StateBackupClient {
 }
 
 func (defaultStateBackupClient) BackupDatabases(ctx context.Context, kubeContext, namespace, stateDir string) error {
+	stores, err := pgbackup.NativeStores(ctx, kubeContext, namespace)
+	if err != nil {
+		return err
+	}
+	if stores != nil {
+		return pgbackup.BackupNative(ctx, stores, stateDir)
+	}
 	return pgbackup.Backup(ctx, kubeContext, namespace, stateDir)
 }
 
@@ -25,6 +25,7 @@ package startup
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -55,18 +56,25 @@ ready, restores the control-plane databases, and re-creates the Terraform recipe
 Run this after Radius is installed on a fresh cluster to resume from the state saved by
 'rad shutdown'.
 
+By default the latest snapshot is restored. Use --snapshot with an ID listed by
+'rad state list' to restore an earlier point in time.
+
 This command does not create the cluster or install Radius.`,
 		Example: `
 # Restore state for the current workspace
 rad startup
 
 # Restore state for a specific workspace
-rad startup --workspace my-workspace`,
+rad startup --workspace my-workspace
+
+# Restore an earlier snapshot listed by 'rad state list'
+rad startup --snapshot 3f1c2a9`,
 		Args: cobra.NoArgs,
 		RunE: framework.RunCommand(runner),
 	}
 
 	commonflags.AddWorkspaceFlag(cmd)
+	cmd.Flags().String("snapshot", "", "The ID of the state snapshot to restore (see 'rad state list'); defaults to the latest")
 
 	return cmd, runner
 }
@@ -78,6 +86,9 @@ type Runner struct {
 	Workspace    *workspaces.Workspace
 	StateClient  StateRestoreClient
 
+	// Snapshot is the ID of the snapshot to restore. Empty restores the latest state.
+	Snapshot string
+
 	// Archive is the durable state archive that state is restored from. Tests inject a mock.
 	Archive statearchive.Archive
 
@@ -109,6 +120,12 @@ func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
 	}
 
 	r.Workspace = workspace
+
+	r.Snapshot, err = cmd.Flags().GetString("snapshot")
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -124,9 +141,9 @@ func (r *Runner) Run(ctx context.Context) error {
 		return clierrors.Message("Could not determine the Kubernetes context for workspace %q.", r.Workspace.Name)
 	}
 
-	session, err := r.Archive.Open(ctx, pgbackup.StateArchiveName())
+	session, err := r.openArchive(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to open state archive: %w", err)
+		return err
 	}
 	defer session.Close(ctx)
 
@@ -178,3 +195,35 @@ func (r *Runner) Run(ctx context.Context) error {
 	r.Output.LogInfo("State restored successfully.")
 	return nil
 }
+
+// openArchive opens the latest state, or the snapshot selected with --snapshot.
+func (r *Runner) openArchive(ctx context.Context) (statearchive.Session, error) {
+	if r.Snapshot == "" {
+		session, err := r.Archive.Open(ctx, pgbackup.StateArchiveName())
+		if errors.Is(err, statearchive.ErrVerificationFailed) {
+			return nil, verificationFailed(err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open state archive: %w", err)
+		}
+		return session, nil
+	}
+
+	session, err := r.Archive.OpenRevision(ctx, pgbackup.StateArchiveName(), r.Snapshot)
+	if errors.Is(err, statearchive.ErrSnapshotNotFound) {
+		return nil, clierrors.Message("State snapshot %q was not found. Run 'rad state list' to see the available snapshots.", r.Snapshot)
+	}
+	if errors.Is(err, statearchive.ErrVerificationFailed) {
+		return nil, verificationFailed(err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state snapshot %q: %w", r.Snapshot, err)
+	}
+	r.Output.LogInfo("Restoring state snapshot %s", r.Snapshot)
+	return session, nil
+}
+
+// verificationFailed explains why a state archive that failed verification was not restored.
+func verificationFailed(err error) error {
+	return clierrors.Message("The state archive was not restored because it failed verification: %v. Check that %s points to the key store the state was saved with.", err, archivefactory.StateEncryptionKeysEnvVar)
+}
@@ -19,6 +19,7 @@ package startup
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/radius-project/radius/pkg/cli/framework"
@@ -26,6 +27,7 @@ import (
 	"github.com/radius-project/radius/pkg/cli/pgbackup"
 	"github.com/radius-project/radius/pkg/cli/workspaces"
 	"github.com/radius-project/radius/pkg/statearchive"
+	archivefactory "github.com/radius-project/radius/pkg/statearchive/factory"
 	"github.com/radius-project/radius/test/radcli"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -251,3 +253,84 @@ func Test_Run_ArchiveOpenFailureIsReturned(t *testing.T) {
 	require.ErrorContains(t, err, "not a git repo")
 	require.False(t, client.waited, "no restore should run when the archive cannot be opened")
 }
+
+func Test_Run_RestoresRequestedSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	session := statearchive.NewMockSession(ctrl)
+	session.EXPECT().Path().Return(t.TempDir()).AnyTimes()
+	session.EXPECT().Close(gomock.Any()).Times(1)
+
+	archive := statearchive.NewMockArchive(ctrl)
+	archive.EXPECT().OpenRevision(gomock.Any(), pgbackup.StateArchiveName(), "abc123").Return(session, nil).Times(1)
+
+	client := &fakeStateRestoreClient{}
+	scaler := &fakeScaler{order: &client.order}
+	r := &Runner{
+		Output:      &output.MockOutput{},
+		Workspace:   kubernetesWorkspace(),
+		StateClient: client,
+		Snapshot:    "abc123",
+		Archive:     archive,
+		newScaler: func(kubeContext, namespace string) (ControlPlaneScaler, error) {
+			return scaler, nil
+		},
+	}
+
+	err := r.Run(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []string{"scaledown", "wait", "db", "tf", "scaleup"}, client.order)
+}
+
+func Test_Run_MissingSnapshotIsReported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	archive := statearchive.NewMockArchive(ctrl)
+	archive.EXPECT().OpenRevision(gomock.Any(), pgbackup.StateArchiveName(), "missing").
+		Return(nil, fmt.Errorf("snapshot %q: %w", "missing", statearchive.ErrSnapshotNotFound)).Times(1)
+
+	client := &fakeStateRestoreClient{}
+	r := &Runner{
+		Output:      &output.MockOutput{},
+		Workspace:   kubernetesWorkspace(),
+		StateClient: client,
+		Snapshot:    "missing",
+		Archive:     archive,
+		newScaler: func(kubeContext, namespace string) (ControlPlaneScaler, error) {
+			t.Fatal("newScaler must not be called when the snapshot does not exist")
+			return nil, nil
+		},
+	}
+
+	err := r.Run(t.Context())
+	require.ErrorContains(t, err, "rad state list")
+	require.False(t, client.waited)
+}
+
+func Test_Run_VerificationFailureStopsBeforeRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	archive := statearchive.NewMockArchive(ctrl)
+	archive.EXPECT().Open(gomock.Any(), pgbackup.StateArchiveName()).
+		Return(nil, fmt.Errorf("%w: archive file %q was modified", statearchive.ErrVerificationFailed, "ucp.sql")).Times(1)
+
+	client := &fakeStateRestoreClient{}
+	r := &Runner{
+		Output:      &output.MockOutput{},
+		Workspace:   kubernetesWorkspace(),
+		StateClient: client,
+		Archive:     archive,
+		newScaler: func(kubeContext, namespace string) (ControlPlaneScaler, error) {
+			t.Fatal("newScaler must not be called when the archive fails verification")
+			return nil, nil
+		},
+	}
+
+	err := r.Run(t.Context())
+	require.ErrorContains(t, err, "failed verification")
+	require.ErrorContains(t, err, archivefactory.StateEncryptionKeysEnvVar)
+	require.False(t, client.waited)
+}
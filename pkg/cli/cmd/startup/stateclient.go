@@ -45,10 +45,10 @@ var newScalerForContext = func(kubeContext, namespace string) (ControlPlaneScale
 // StateRestoreClient restores the durable Radius state for a Kubernetes context. It wraps the
 // pgbackup and tfstate packages so the command can be unit tested without a cluster.
 type StateRestoreClient interface {
-	// WaitForDatabaseReady blocks until the control-plane PostgreSQL instance is ready.
+	// WaitForDatabaseReady blocks until the control-plane database is ready.
 	WaitForDatabaseReady(ctx context.Context, kubeContext, namespace string) error
 
-	// RestoreDatabases loads the control-plane PostgreSQL dumps from stateDir.
+	// RestoreDatabases loads the control-plane database backups from stateDir.
 	RestoreDatabases(ctx context.Context, kubeContext, namespace, stateDir string) error
 
 	// RestoreTerraform re-creates the Terraform state Secrets from stateDir.
@@ -64,10 +64,24 @@ func NewStateRestoreClient() StateRestoreClient {
 }
 
 func (defaultStateRestoreClient) WaitForDatabaseReady(ctx context.Context, kubeContext, namespace string) error {
+	stores, err := pgbackup.NativeStores(ctx, kubeContext, namespace)
+	if err != nil {
+		return err
+	}
+	if stores != nil {
+		return pgbackup.WaitForNativeReady(ctx, stores)
+	}
 	return pgbackup.WaitForReady(ctx, kubeContext, namespace)
 }
 
 func (defaultStateRestoreClient) RestoreDatabases(ctx context.Context, kubeContext, namespace, stateDir string) error {
+	stores, err := pgbackup.NativeStores(ctx, kubeContext, namespace)
+	if err != nil {
+		return err
+	}
+	if stores != nil {
+		return pgbackup.RestoreNative(ctx, stores, stateDir)
+	}
 	return pgbackup.Restore(ctx, kubeContext, namespace, stateDir)
 }
 
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package list implements the `rad state list` command, which lists the snapshots of the durable
+// Radius state archive written by `rad shutdown`.
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/radius-project/radius/pkg/cli"
+	"github.com/radius-project/radius/pkg/cli/cmd/commonflags"
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/pgbackup"
+	"github.com/radius-project/radius/pkg/statearchive"
+	archivefactory "github.com/radius-project/radius/pkg/statearchive/factory"
+)
+
+// NewCommand creates an instance of the `rad state list` command and runner.
+func NewCommand(factory framework.Factory) (*cobra.Command, framework.Runner) {
+	runner := NewRunner(factory)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List Radius state snapshots",
+		Long: `List the snapshots of the radius-state archive, newest first.
+
+Each 'rad shutdown' that changes the saved state creates a snapshot. Pass a snapshot ID to
+'rad startup --snapshot' to restore that point in time.
+
+The archive is selected with the same RADIUS_STATE_* environment variables as 'rad startup'
+and 'rad shutdown'.`,
+		Example: `
+# List state snapshots
+rad state list
+
+# List state snapshots as JSON
+rad state list --output json`,
+		Args: cobra.NoArgs,
+		RunE: framework.RunCommand(runner),
+	}
+
+	commonflags.AddOutputFlag(cmd)
+
+	return cmd, runner
+}
+
+// Runner is the runner implementation for the `rad state list` command.
+type Runner struct {
+	Output output.Interface
+	Format string
+
+	// Archive is the durable state archive whose history is listed. Tests inject a mock.
+	Archive statearchive.Archive
+}
+
+// NewRunner creates a new Runner for the `rad state list` command.
+func NewRunner(factory framework.Factory) *Runner {
+	return &Runner{
+		Output:  factory.GetOutput(),
+		Archive: archivefactory.NewStateArchive(os.Getenv(archivefactory.StateRegistryEnvVar)),
+	}
+}
+
+// Validate reads the output format.
+func (r *Runner) Validate(cmd *cobra.Command, args []string) error {
+	format, err := cli.RequireOutput(cmd)
+	if err != nil {
+		return err
+	}
+	r.Format = format
+	return nil
+}
+
+// Run lists the snapshots of the state archive.
+func (r *Runner) Run(ctx context.Context) error {
+	snapshots, err := r.Archive.History(ctx, pgbackup.StateArchiveName())
+	if err != nil {
+		return fmt.Errorf("failed to list state snapshots: %w", err)
+	}
+
+	return r.Output.WriteFormatted(r.Format, snapshots, objectformats.GetStateSnapshotTableFormat())
+}
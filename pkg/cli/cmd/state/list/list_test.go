@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/radius-project/radius/pkg/cli/framework"
+	"github.com/radius-project/radius/pkg/cli/objectformats"
+	"github.com/radius-project/radius/pkg/cli/output"
+	"github.com/radius-project/radius/pkg/cli/pgbackup"
+	"github.com/radius-project/radius/pkg/statearchive"
+	"github.com/radius-project/radius/test/radcli"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_CommandValidation(t *testing.T) {
+	radcli.SharedCommandValidation(t, NewCommand)
+}
+
+func Test_Validate(t *testing.T) {
+	configWithWorkspace := radcli.LoadConfigWithWorkspace(t)
+
+	testcases := []radcli.ValidateInput{
+		{
+			Name:          "list without args is valid",
+			Input:         []string{},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: configWithWorkspace},
+		},
+		{
+			Name:          "list with json output is valid",
+			Input:         []string{"--output", "json"},
+			ExpectedValid: true,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: configWithWorkspace},
+		},
+		{
+			Name:          "list does not accept positional args",
+			Input:         []string{"unexpected"},
+			ExpectedValid: false,
+			ConfigHolder:  framework.ConfigHolder{ConfigFilePath: "", Config: configWithWorkspace},
+		},
+	}
+
+	radcli.SharedValidateValidation(t, NewCommand, testcases)
+}
+
+func Test_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshots := []statearchive.Snapshot{
+		{ID: "b", Created: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Message: "radius: backup", Size: 2048},
+		{ID: "a", Created: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Message: "radius: backup", Size: 1024},
+	}
+	archive := statearchive.NewMockArchive(ctrl)
+	archive.EXPECT().History(gomock.Any(), pgbackup.StateArchiveName()).Return(snapshots, nil).Times(1)
+
+	outputSink := &output.MockOutput{}
+	runner := &Runner{
+		Output:  outputSink,
+		Format:  "table",
+		Archive: archive,
+	}
+
+	require.NoError(t, runner.Run(t.Context()))
+	require.Equal(t, []any{
+		output.FormattedOutput{
+			Format:  "table",
+			Obj:     snapshots,
+			Options: objectformats.GetStateSnapshotTableFormat(),
+		},
+	}, outputSink.Writes)
+}
+
+func Test_Run_HistoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	archive := statearchive.NewMockArchive(ctrl)
+	archive.EXPECT().History(gomock.Any(), pgbackup.StateArchiveName()).Return(nil, errors.New("registry unavailable")).Times(1)
+
+	runner := &Runner{
+		Output:  &output.MockOutput{},
+		Format:  "table",
+		Archive: archive,
+	}
+
+	err := runner.Run(t.Context())
+	require.ErrorContains(t, err, "registry unavailable")
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dbmigrate copies the Radius control-plane data between database providers, for example
+// from the Kubernetes API server store to PostgreSQL.
+//
+// Every object is streamed from the source stores to the target stores with its resource ID and
+// ETag unchanged, so clients holding an ETag keep working after the migration. The API server
+// provider keeps the data of every resource provider in one store, while the PostgreSQL provider
+// uses one database per component (see pgbackup.Databases); objects are routed to the database
+// of the component that owns them.
+//
+// After the copy, each target store is read back and its object count and checksum are compared
+// with the source. A dry run computes the same counts and checksums without writing.
+package dbmigrate
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/radius-project/radius/pkg/cli/pgbackup"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+// ErrVerificationFailed is returned when a target store does not match the source after a migration.
+var ErrVerificationFailed = errors.New("database migration verification failed")
+
+// applicationsRPNamespaces are the resource provider namespaces served by the applications RP. Every
+// other namespace outside of System.* is served by the dynamic RP.
+var applicationsRPNamespaces = []string{
+	"applications.core",
+	"applications.dapr",
+	"applications.datastores",
+	"applications.messaging",
+	"radius.core",
+}
+
+// maxReportedMismatches bounds the number of object IDs listed in a verification error.
+const maxReportedMismatches = 5
+
+// Options configures a migration.
+type Options struct {
+	// DryRun reads the source stores and reports what would be migrated without writing to the targets.
+	DryRun bool
+
+	// Overwrite allows migrating into target stores that already hold data. Objects in the targets
+	// that are not in the source are deleted. When false, a non-empty target store is an error.
+	Overwrite bool
+}
+
+// StoreResult summarizes the objects migrated into one target store.
+type StoreResult struct {
+	// Name is the name of the target store.
+	Name string
+
+	// Count is the number of objects migrated into the store.
+	Count int
+
+	// Checksum is a digest of the IDs, ETags and data of the objects migrated into the store.
+	Checksum string
+}
+
+// Result is the outcome of a migration.
+type Result struct {
+	// Stores has one entry per target store, in the order the targets were given.
+	Stores []StoreResult
+
+	// Stale is the number of objects deleted from the targets because they were not in the source,
+	// or that would be deleted in a dry run with Overwrite.
+	Stale int
+
+	// DryRun is true if nothing was written.
+	DryRun bool
+}
+
+// OwnerDatabase returns the name of the PostgreSQL database, one of pgbackup.Databases, that holds
+// the object with the given ID. Scopes and System.* resources belong to UCP, the namespaces served
+// by the applications RP belong to it, and everything else belongs to the dynamic RP.
+func OwnerDatabase(id string) (string, error) {
+	parsed, err := resources.Parse(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid object ID %q: %w", id, err)
+	}
+
+	namespace := strings.ToLower(parsed.ProviderNamespace())
+	switch {
+	case namespace == "" || strings.HasPrefix(namespace, "system."):
+		return "ucp", nil
+	case slices.Contains(applicationsRPNamespaces, namespace):
+		return "applications_rp", nil
+	default:
+		return "dynamic_rp", nil
+	}
+}
+
+// Migrate copies every object in the source stores into the target stores. When there is a single
+// target store, all objects are copied into it; otherwise each object is copied into the target
+// named by OwnerDatabase. The targets are then verified against the source.
+func Migrate(ctx context.Context, sources []pgbackup.Store, targets []pgbackup.Store, options Options) (*Result, error) {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	if len(sources) == 0 || len(targets) == 0 {
+		return nil, errors.New("at least one source and one target store are required")
+	}
+
+	importers := make([]database.Exporter, len(targets))
+	for i, target := range targets {
+		exporter, ok := target.Client.(database.Exporter)
+		if !ok {
+			return nil, fmt.Errorf("database client %T for %q does not support import", target.Client, target.Name)
+		}
+		importers[i] = exporter
+	}
+
+	// Read the current content of the targets first: it decides whether the migration may proceed
+	// and which objects are stale.
+	existing := make([]map[string]string, len(targets))
+	for i, target := range targets {
+		ids := map[string]string{}
+		err := database.ExportAll(ctx, target.Client, func(obj *database.Object) error {
+			ids[strings.ToLower(obj.ID)] = obj.ID
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read target database %q: %w", target.Name, err)
+		}
+		if len(ids) > 0 && !options.Overwrite {
+			return nil, fmt.Errorf("target database %q already holds %d objects", target.Name, len(ids))
+		}
+		existing[i] = ids
+	}
+
+	expected := make([]digestSet, len(targets))
+	for i := range expected {
+		expected[i] = digestSet{}
+	}
+
+	for _, source := range sources {
+		logger.Info("Migrating database", "database", source.Name, "dryRun", options.DryRun)
+
+		count := 0
+		err := database.ExportAll(ctx, source.Client, func(obj *database.Object) error {
+			index, err := route(obj.ID, targets)
+			if err != nil {
+				return err
+			}
+
+			key := strings.ToLower(obj.ID)
+			if _, ok := expected[index][key]; ok {
+				return fmt.Errorf("object %q is stored more than once in the source", obj.ID)
+			}
+			digest, err := objectDigest(obj)
+			if err != nil {
+				return err
+			}
+			expected[index][key] = digest
+
+			if !options.DryRun {
+				if err := importers[index].Import(ctx, obj); err != nil {
+					return fmt.Errorf("failed to import %q into %q: %w", obj.ID, targets[index].Name, err)
+				}
+			}
+			count++
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate database %q: %w", source.Name, err)
+		}
+
+		logger.Info("Database migrated", "database", source.Name, "objects", count)
+	}
+
+	result := &Result{DryRun: options.DryRun}
+	for i, target := range targets {
+		for key, id := range existing[i] {
+			if _, ok := expected[i][key]; ok {
+				continue
+			}
+			result.Stale++
+			if options.DryRun {
+				continue
+			}
+			if err := target.Client.Delete(ctx, id); err != nil && !errors.Is(err, &database.ErrNotFound{}) {
+				return nil, fmt.Errorf("failed to delete %q from %q: %w", id, target.Name, err)
+			}
+		}
+
+		result.Stores = append(result.Stores, StoreResult{Name: target.Name, Count: len(expected[i]), Checksum: expected[i].checksum()})
+	}
+
+	if options.DryRun {
+		return result, nil
+	}
+
+	for i, target := range targets {
+		if err := verify(ctx, target, expected[i]); err != nil {
+			return nil, err
+		}
+		logger.Info("Database verified", "database", target.Name, "objects", len(expected[i]), "checksum", result.Stores[i].Checksum)
+	}
+
+	return result, nil
+}
+
+// route returns the index of the target store for the object with the given ID.
+func route(id string, targets []pgbackup.Store) (int, error) {
+	if len(targets) == 1 {
+		return 0, nil
+	}
+
+	owner, err := OwnerDatabase(id)
+	if err != nil {
+		return 0, err
+	}
+	for i, target := range targets {
+		if target.Name == owner {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no target database %q for object %q", owner, id)
+}
+
+// verify reads target back and compares every object with the digests computed from the source.
+func verify(ctx context.Context, target pgbackup.Store, expected digestSet) error {
+	actual := digestSet{}
+	err := database.ExportAll(ctx, target.Client, func(obj *database.Object) error {
+		digest, err := objectDigest(obj)
+		if err != nil {
+			return err
+		}
+		actual[strings.ToLower(obj.ID)] = digest
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read target database %q: %w", target.Name, err)
+	}
+
+	if len(actual) == len(expected) && actual.checksum() == expected.checksum() {
+		return nil
+	}
+
+	mismatched := []string{}
+	for key, digest := range expected {
+		if actual[key] != digest {
+			mismatched = append(mismatched, key)
+		}
+	}
+	for key := range actual {
+		if _, ok := expected[key]; !ok {
+			mismatched = append(mismatched, key)
+		}
+	}
+	slices.Sort(mismatched)
+	if len(mismatched) > maxReportedMismatches {
+		mismatched = append(mismatched[:maxReportedMismatches], "...")
+	}
+
+	return fmt.Errorf("%w: database %q holds %d objects, expected %d; mismatched objects: %s",
+		ErrVerificationFailed, target.Name, len(actual), len(expected), strings.Join(mismatched, ", "))
+}
+
+// digestSet maps lowercased object IDs to the digest of the object.
+type digestSet map[string]string
+
+// checksum combines the digests of every object in ID order into a single hex-encoded SHA-256.
+func (s digestSet) checksum() string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		_, _ = hash.Write([]byte(key))
+		_, _ = hash.Write([]byte{0})
+		_, _ = hash.Write([]byte(s[key]))
+		_, _ = hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// objectDigest returns a hex-encoded SHA-256 of the ID, ETag and data of obj. The data is
+// re-encoded with sorted keys, so the digest does not depend on how a provider serializes it.
+func objectDigest(obj *database.Object) (string, error) {
+	data, err := json.Marshal(obj.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal object %q: %w", obj.ID, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("failed to read object %q: %w", obj.ID, err)
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal object %q: %w", obj.ID, err)
+	}
+
+	hash := sha256.New()
+	_, _ = hash.Write([]byte(obj.ID))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write([]byte(obj.ETag))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write(canonical)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbmigrate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/radius-project/radius/pkg/cli/pgbackup"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	groupID       = "/planes/radius/local/resourceGroups/default"
+	providerID    = "/planes/radius/local/providers/System.Resources/resourceProviders/Radius.Compute"
+	environmentID = "/planes/radius/local/resourceGroups/default/providers/Applications.Core/environments/env"
+	coreEnvID     = "/planes/radius/local/resourceGroups/default/providers/Radius.Core/environments/env"
+	containerID   = "/planes/radius/local/resourceGroups/default/providers/Radius.Compute/containers/web"
+	operationID   = "/planes/radius/local/providers/Applications.Core/locations/global/operationStatuses/abc"
+)
+
+func Test_OwnerDatabase(t *testing.T) {
+	tests := []struct {
+		id    string
+		owner string
+	}{
+		{id: "/planes/radius/local", owner: "ucp"},
+		{id: groupID, owner: "ucp"},
+		{id: providerID, owner: "ucp"},
+		{id: environmentID, owner: "applications_rp"},
+		{id: coreEnvID, owner: "applications_rp"},
+		{id: operationID, owner: "applications_rp"},
+		{id: containerID, owner: "dynamic_rp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			owner, err := OwnerDatabase(tt.id)
+			require.NoError(t, err)
+			require.Equal(t, tt.owner, owner)
+		})
+	}
+
+	_, err := OwnerDatabase("not-an-id")
+	require.ErrorContains(t, err, "invalid object ID")
+}
+
+func save(t *testing.T, client database.Client, id string) *database.Object {
+	t.Helper()
+	obj := &database.Object{Metadata: database.Metadata{ID: id}, Data: map[string]any{"id": id, "properties": map[string]any{"port": 8080}}}
+	require.NoError(t, client.Save(t.Context(), obj))
+	return obj
+}
+
+func postgresStores() []pgbackup.Store {
+	stores := []pgbackup.Store{}
+	for _, db := range pgbackup.Databases {
+		stores = append(stores, pgbackup.Store{Name: db, Client: inmemory.NewClient()})
+	}
+	return stores
+}
+
+func apiServerSource(t *testing.T) ([]pgbackup.Store, []*database.Object) {
+	client := inmemory.NewClient()
+	objects := []*database.Object{}
+	for _, id := range []string{groupID, providerID, environmentID, coreEnvID, containerID, operationID} {
+		objects = append(objects, save(t, client, id))
+	}
+	return []pgbackup.Store{{Name: pgbackup.APIServerStoreName, Client: client}}, objects
+}
+
+func Test_Migrate_SplitsIntoDatabases(t *testing.T) {
+	ctx := t.Context()
+	sources, objects := apiServerSource(t)
+	targets := postgresStores()
+
+	result, err := Migrate(ctx, sources, targets, Options{})
+	require.NoError(t, err)
+	require.False(t, result.DryRun)
+	require.Zero(t, result.Stale)
+	require.Len(t, result.Stores, 3)
+	require.Equal(t, 2, result.Stores[0].Count, "ucp")
+	require.Equal(t, 3, result.Stores[1].Count, "applications_rp")
+	require.Equal(t, 1, result.Stores[2].Count, "dynamic_rp")
+
+	byName := map[string]database.Client{}
+	for _, target := range targets {
+		byName[target.Name] = target.Client
+	}
+	for _, expected := range objects {
+		owner, err := OwnerDatabase(expected.ID)
+		require.NoError(t, err)
+
+		actual, err := byName[owner].Get(ctx, expected.ID)
+		require.NoError(t, err)
+		require.Equal(t, expected.ID, actual.ID)
+		require.Equal(t, expected.ETag, actual.ETag, "ETags are preserved")
+	}
+}
+
+func Test_Migrate_MergesIntoSingleStore(t *testing.T) {
+	ctx := t.Context()
+	sources := postgresStores()
+	save(t, sources[0].Client, groupID)
+	save(t, sources[1].Client, environmentID)
+	save(t, sources[2].Client, containerID)
+
+	target := inmemory.NewClient()
+	result, err := Migrate(ctx, sources, []pgbackup.Store{{Name: pgbackup.APIServerStoreName, Client: target}}, Options{})
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Stores[0].Count)
+
+	for _, id := range []string{groupID, environmentID, containerID} {
+		_, err := target.Get(ctx, id)
+		require.NoError(t, err)
+	}
+}
+
+func Test_Migrate_DryRunDoesNotWrite(t *testing.T) {
+	ctx := t.Context()
+	sources, _ := apiServerSource(t)
+	targets := postgresStores()
+
+	dryRun, err := Migrate(ctx, sources, targets, Options{DryRun: true})
+	require.NoError(t, err)
+	require.True(t, dryRun.DryRun)
+
+	for _, target := range targets {
+		result, err := target.Client.(database.Exporter).Export(ctx)
+		require.NoError(t, err)
+		require.Empty(t, result.Items, "dry run must not write to %q", target.Name)
+	}
+
+	result, err := Migrate(ctx, sources, targets, Options{})
+	require.NoError(t, err)
+	require.Equal(t, dryRun.Stores, result.Stores, "a dry run reports the same counts and checksums")
+}
+
+func Test_Migrate_RequiresEmptyTarget(t *testing.T) {
+	ctx := t.Context()
+	sources, _ := apiServerSource(t)
+	targets := postgresStores()
+	stale := save(t, targets[2].Client, "/planes/radius/local/resourceGroups/default/providers/Radius.Compute/containers/stale")
+
+	_, err := Migrate(ctx, sources, targets, Options{})
+	require.ErrorContains(t, err, `target database "dynamic_rp" already holds 1 objects`)
+
+	result, err := Migrate(ctx, sources, targets, Options{Overwrite: true, DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Stale)
+	_, err = targets[2].Client.Get(ctx, stale.ID)
+	require.NoError(t, err, "a dry run must not delete stale objects")
+
+	result, err = Migrate(ctx, sources, targets, Options{Overwrite: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Stale)
+	_, err = targets[2].Client.Get(ctx, stale.ID)
+	require.ErrorIs(t, err, &database.ErrNotFound{})
+}
+
+func Test_Migrate_RejectsDuplicateIDs(t *testing.T) {
+	sources := postgresStores()
+	save(t, sources[0].Client, environmentID)
+	save(t, sources[1].Client, environmentID)
+
+	_, err := Migrate(t.Context(), sources, []pgbackup.Store{{Name: pgbackup.APIServerStoreName, Client: inmemory.NewClient()}}, Options{})
+	require.ErrorContains(t, err, "stored more than once")
+}
+
+// lossyClient drops every write so the verification step has something to catch.
+type lossyClient struct {
+	*inmemory.Client
+}
+
+func (c *lossyClient) Import(ctx context.Context, obj *database.Object) error {
+	return nil
+}
+
+func Test_Migrate_VerificationFailure(t *testing.T) {
+	sources, _ := apiServerSource(t)
+	targets := []pgbackup.Store{{Name: pgbackup.APIServerStoreName, Client: &lossyClient{Client: inmemory.NewClient()}}}
+
+	_, err := Migrate(t.Context(), sources, targets, Options{})
+	require.ErrorIs(t, err, ErrVerificationFailed)
+	require.ErrorContains(t, err, "holds 0 objects, expected 6")
+}
+
+func Test_ObjectDigest(t *testing.T) {
+	raw, err := objectDigest(&database.Object{Metadata: database.Metadata{ID: groupID, ETag: "1"}, Data: json.RawMessage(`{"b":1,"a":2}`)})
+	require.NoError(t, err)
+
+	decoded, err := objectDigest(&database.Object{Metadata: database.Metadata{ID: groupID, ETag: "1"}, Data: map[string]any{"a": 2, "b": 1}})
+	require.NoError(t, err)
+	require.Equal(t, raw, decoded, "the digest does not depend on key order or representation")
+
+	otherETag, err := objectDigest(&database.Object{Metadata: database.Metadata{ID: groupID, ETag: "2"}, Data: map[string]any{"a": 2, "b": 1}})
+	require.NoError(t, err)
+	require.NotEqual(t, decoded, otherETag, "the ETag is part of the digest")
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/output"
+	ucpresources "github.com/radius-project/radius/pkg/ucp/resources"
+	resources_radius "github.com/radius-project/radius/pkg/ucp/resources/radius"
+)
+
+// progressTracker records the resources whose deployment started and has not completed yet.
+type progressTracker struct {
+	mu         sync.Mutex
+	inProgress map[string]ucpresources.ID
+}
+
+func newProgressTracker() *progressTracker {
+	return &progressTracker{inProgress: map[string]ucpresources.ID{}}
+}
+
+// Track forwards the progress updates from in to out and records them. out is closed when in is closed.
+func (t *progressTracker) Track(in <-chan clients.ResourceProgress, out chan<- clients.ResourceProgress) {
+	defer close(out)
+	for update := range in {
+		t.mu.Lock()
+		key := strings.ToLower(update.Resource.String())
+		if update.Status == clients.StatusStarted {
+			t.inProgress[key] = update.Resource
+		} else {
+			delete(t.inProgress, key)
+		}
+		t.mu.Unlock()
+
+		out <- update
+	}
+}
+
+// InProgress returns the Radius resources whose deployment is in progress, sorted by id. Resources of other
+// planes, such as Azure or AWS, are not returned since their operations cannot be canceled by Radius.
+func (t *progressTracker) InProgress() []ucpresources.ID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ids := []ucpresources.ID{}
+	for _, id := range t.inProgress {
+		if resources_radius.IsRadiusResource(id) {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b ucpresources.ID) int { return strings.Compare(a.String(), b.String()) })
+	return ids
+}
+
+// cancelInProgress requests the cancellation of the operations in progress on ids. Failures are reported
+// and do not stop the cancellation of the other resources.
+func cancelInProgress(ctx context.Context, client clients.ApplicationsManagementClient, ids []ucpresources.ID) {
+	for _, id := range ids {
+		canceled, err := client.CancelResourceOperations(ctx, id.Type(), id.String())
+		if err != nil {
+			output.LogInfo("    Failed to cancel %s: %v", output.FormatResourceForDisplay(id), err)
+		} else if len(canceled) > 0 {
+			output.LogInfo("    Canceled %s", output.FormatResourceForDisplay(id))
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/radius-project/radius/pkg/cli/clients"
+	ucpresources "github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+func Test_ProgressTracker(t *testing.T) {
+	container := ucpresources.MustParse("/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/containers/web")
+	cache := ucpresources.MustParse("/planes/radius/local/resourceGroups/test-group/providers/Applications.Datastores/redisCaches/cache")
+	database := ucpresources.MustParse("/planes/radius/local/resourceGroups/test-group/providers/Applications.Datastores/sqlDatabases/db")
+	storage := ucpresources.MustParse("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account")
+
+	updates := []clients.ResourceProgress{
+		{Resource: container, Status: clients.StatusStarted},
+		{Resource: cache, Status: clients.StatusStarted},
+		{Resource: database, Status: clients.StatusStarted},
+		{Resource: storage, Status: clients.StatusStarted},
+		{Resource: container, Status: clients.StatusCompleted},
+		{Resource: database, Status: clients.StatusFailed},
+	}
+
+	in := make(chan clients.ResourceProgress, len(updates))
+	out := make(chan clients.ResourceProgress, len(updates))
+	for _, update := range updates {
+		in <- update
+	}
+	close(in)
+
+	tracker := newProgressTracker()
+	tracker.Track(in, out)
+
+	forwarded := []clients.ResourceProgress{}
+	for update := range out {
+		forwarded = append(forwarded, update)
+	}
+	require.Equal(t, updates, forwarded)
+	require.Equal(t, []ucpresources.ID{cache}, tracker.InProgress(), "only Radius resources in progress can be canceled")
+}
+
+func Test_CancelInProgress(t *testing.T) {
+	cache := ucpresources.MustParse("/planes/radius/local/resourceGroups/test-group/providers/Applications.Datastores/redisCaches/cache")
+	database := ucpresources.MustParse("/planes/radius/local/resourceGroups/test-group/providers/Applications.Datastores/sqlDatabases/db")
+
+	ctrl := gomock.NewController(t)
+	client := clients.NewMockApplicationsManagementClient(ctrl)
+	client.EXPECT().
+		CancelResourceOperations(gomock.Any(), "Applications.Datastores/redisCaches", cache.String()).
+		Return(nil, errors.New("connection refused"))
+	client.EXPECT().
+		CancelResourceOperations(gomock.Any(), "Applications.Datastores/sqlDatabases", database.String()).
+		Return([]string{"op-1"}, nil)
+
+	// A failure does not stop the cancellation of the other resources.
+	cancelInProgress(t.Context(), client, []ucpresources.ID{cache, database})
+}
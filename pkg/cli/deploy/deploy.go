@@ -18,11 +18,15 @@ package deploy
 
 import (
 	"context"
+	"os"
+	"os/signal"
 	"sync"
 
 	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/cli/clierrors"
 	"github.com/radius-project/radius/pkg/cli/output"
 	"github.com/radius-project/radius/pkg/cli/output/progress"
+	ucpresources "github.com/radius-project/radius/pkg/ucp/resources"
 )
 
 // DeployWithProgress runs a deployment and displays progress to the user. This is intended to be used
@@ -40,15 +44,25 @@ func DeployWithProgress(ctx context.Context, options Options) (clients.Deploymen
 	step := output.BeginStep("%s", options.ProgressText)
 	output.LogInfo("")
 
+	// Ctrl-C stops waiting for the deployment, and the operations in progress are canceled below so
+	// they do not keep running in the background. A second Ctrl-C terminates the process.
+	deployCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
 	// Watch for progress while we're deploying.
 	progressChan := make(chan clients.ResourceProgress, 1)
-	listener := progress.NewListener(progressChan)
+	listenerChan := make(chan clients.ResourceProgress, 1)
+	tracker := newProgressTracker()
+	listener := progress.NewListener(listenerChan)
 	wg := &sync.WaitGroup{}
+	wg.Go(func() {
+		tracker.Track(progressChan, listenerChan)
+	})
 	wg.Go(func() {
 		listener.Run()
 	})
 
-	result, err := deploymentClient.Deploy(ctx, clients.DeploymentOptions{
+	result, err := deploymentClient.Deploy(deployCtx, clients.DeploymentOptions{
 		Template:     options.Template,
 		Parameters:   options.Parameters,
 		Providers:    options.Providers,
@@ -57,7 +71,10 @@ func DeployWithProgress(ctx context.Context, options Options) (clients.Deploymen
 
 	// Drain any UI progress updates before we process the results of the deployment.
 	wg.Wait()
-	if err != nil {
+	if err != nil && deployCtx.Err() != nil && ctx.Err() == nil {
+		stop()
+		return clients.DeploymentResult{}, cancelDeployment(ctx, options, tracker.InProgress())
+	} else if err != nil {
 		return clients.DeploymentResult{}, err
 	}
 
@@ -98,3 +115,19 @@ func DeployWithProgress(ctx context.Context, options Options) (clients.Deploymen
 
 	return result, nil
 }
+
+// cancelDeployment cancels the operations in progress after the deployment was interrupted by the user.
+func cancelDeployment(ctx context.Context, options Options, inProgress []ucpresources.ID) error {
+	output.LogInfo("")
+	if len(inProgress) > 0 {
+		output.LogInfo("Deployment interrupted, canceling the operations in progress:")
+
+		client, err := options.ConnectionFactory.CreateApplicationsManagementClient(ctx, options.Workspace)
+		if err != nil {
+			return err
+		}
+		cancelInProgress(ctx, client, inProgress)
+	}
+
+	return clierrors.Message("Deployment was canceled. Resources that were already deployed are not removed.")
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/graph/edges"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+// ChangeType classifies a resource or connection in a GraphDiff.
+type ChangeType string
+
+const (
+	// ChangeAdded marks an entry present only in the second graph.
+	ChangeAdded ChangeType = "Added"
+
+	// ChangeRemoved marks an entry present only in the first graph.
+	ChangeRemoved ChangeType = "Removed"
+
+	// ChangeModified marks a resource present in both graphs whose diff hash differs.
+	ChangeModified ChangeType = "Modified"
+
+	// ChangeUnchanged marks a resource present in both graphs with the same diff hash.
+	ChangeUnchanged ChangeType = "Unchanged"
+)
+
+// ResourceChange describes how one resource differs between two graphs.
+type ResourceChange struct {
+	Change ChangeType `json:"change"`
+
+	// ID is the resource ID from the second graph, or from the first graph for removed resources.
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	// BeforeDiffHash and AfterDiffHash are the hashes that were compared. They are empty on the
+	// side where the resource does not exist.
+	BeforeDiffHash string `json:"beforeDiffHash,omitempty"`
+	AfterDiffHash  string `json:"afterDiffHash,omitempty"`
+}
+
+// ConnectionChange describes an edge that exists in only one of two graphs. Source and Target
+// are "<type>/<name>" references, so the same edge matches across graphs deployed to different
+// scopes.
+type ConnectionChange struct {
+	Change ChangeType                            `json:"change"`
+	Source string                                `json:"source"`
+	Target string                                `json:"target"`
+	Kind   corerpv20250801preview.ConnectionKind `json:"kind"`
+}
+
+// GraphDiff is the result of comparing two application graphs.
+type GraphDiff struct {
+	// Resources lists every resource of either graph, including unchanged ones, sorted by type
+	// and name.
+	Resources []ResourceChange `json:"resources"`
+
+	// Connections lists the added and removed edges, sorted by source, target and kind.
+	Connections []ConnectionChange `json:"connections"`
+}
+
+// Count returns the number of resources with the given change type.
+func (d *GraphDiff) Count(change ChangeType) int {
+	count := 0
+	for _, r := range d.Resources {
+		if r.Change == change {
+			count++
+		}
+	}
+	return count
+}
+
+// Changes returns the resources that are not unchanged.
+func (d *GraphDiff) Changes() []ResourceChange {
+	result := []ResourceChange{}
+	for _, r := range d.Resources {
+		if r.Change != ChangeUnchanged {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// HasChanges reports whether any resource or connection differs.
+func (d *GraphDiff) HasChanges() bool {
+	return len(d.Connections) > 0 || len(d.Changes()) > 0
+}
+
+// DiffGraphs compares before with after and classifies every resource as added, removed,
+// modified or unchanged. Either graph may be nil, which is treated as empty.
+//
+// Resources are matched by type and name, compared case-insensitively, rather than by ID so
+// that a modeled graph (default scope) and a deployed graph (workspace scope) of the same
+// application line up. Two matched resources are compared by their diff hash when both carry
+// one. Otherwise, as is the case for deployed graphs, both sides are re-hashed from their
+// properties with ComputeDiffHash.
+//
+// Connection changes come from edges.ListEdges, so a connection declared as outbound on one
+// side and inbound on the other is the same edge.
+func DiffGraphs(before, after *corerpv20250801preview.ApplicationGraphResponse) (*GraphDiff, error) {
+	beforeResources, err := indexResources(before)
+	if err != nil {
+		return nil, err
+	}
+	afterResources, err := indexResources(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &GraphDiff{Resources: []ResourceChange{}, Connections: []ConnectionChange{}}
+	for key, a := range afterResources {
+		b, ok := beforeResources[key]
+		if !ok {
+			diff.Resources = append(diff.Resources, newResourceChange(ChangeAdded, a, nil))
+			continue
+		}
+
+		change, err := compareResources(b, a)
+		if err != nil {
+			return nil, err
+		}
+		diff.Resources = append(diff.Resources, newResourceChange(change, a, b))
+	}
+	for key, b := range beforeResources {
+		if _, ok := afterResources[key]; !ok {
+			diff.Resources = append(diff.Resources, newResourceChange(ChangeRemoved, nil, b))
+		}
+	}
+	sort.Slice(diff.Resources, func(i, j int) bool {
+		return referenceKey(diff.Resources[i].Type, diff.Resources[i].Name) < referenceKey(diff.Resources[j].Type, diff.Resources[j].Name)
+	})
+
+	beforeEdges := indexEdges(before)
+	afterEdges := indexEdges(after)
+	for key, edge := range afterEdges {
+		if _, ok := beforeEdges[key]; !ok {
+			diff.Connections = append(diff.Connections, newConnectionChange(ChangeAdded, edge))
+		}
+	}
+	for key, edge := range beforeEdges {
+		if _, ok := afterEdges[key]; !ok {
+			diff.Connections = append(diff.Connections, newConnectionChange(ChangeRemoved, edge))
+		}
+	}
+	sort.Slice(diff.Connections, func(i, j int) bool {
+		a, b := diff.Connections[i], diff.Connections[j]
+		if as, bs := strings.ToLower(a.Source), strings.ToLower(b.Source); as != bs {
+			return as < bs
+		}
+		if at, bt := strings.ToLower(a.Target), strings.ToLower(b.Target); at != bt {
+			return at < bt
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Change < b.Change
+	})
+
+	return diff, nil
+}
+
+// Markdown renders d as a Markdown summary suitable for a pull request comment. before and
+// after name the two compared graphs in the heading. Unchanged resources are counted but not
+// listed.
+func (d *GraphDiff) Markdown(before, after string) string {
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "### Application graph diff: `%s` → `%s`\n\n", before, after)
+
+	if !d.HasChanges() {
+		fmt.Fprintf(out, "No changes. %d resources unchanged.\n", d.Count(ChangeUnchanged))
+		return out.String()
+	}
+
+	fmt.Fprintf(out, "%d added, %d removed, %d modified, %d unchanged; %d connection changes.\n",
+		d.Count(ChangeAdded), d.Count(ChangeRemoved), d.Count(ChangeModified), d.Count(ChangeUnchanged), len(d.Connections))
+
+	if changes := d.Changes(); len(changes) > 0 {
+		out.WriteString("\n#### Resources\n\n")
+		out.WriteString("| Change | Type | Name |\n|---|---|---|\n")
+		for _, r := range changes {
+			fmt.Fprintf(out, "| %s | %s | %s |\n", r.Change, markdownCell(r.Type), markdownCell(r.Name))
+		}
+	}
+
+	if len(d.Connections) > 0 {
+		out.WriteString("\n#### Connections\n\n")
+		out.WriteString("| Change | Source | Target | Kind |\n|---|---|---|---|\n")
+		for _, c := range d.Connections {
+			fmt.Fprintf(out, "| %s | %s | %s | %s |\n", c.Change, markdownCell(c.Source), markdownCell(c.Target), c.Kind)
+		}
+	}
+
+	return out.String()
+}
+
+// markdownCell escapes the characters that would break a Markdown table cell.
+func markdownCell(value string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(value)
+}
+
+// indexResources maps the type/name key of every resource of graph to the resource.
+func indexResources(graph *corerpv20250801preview.ApplicationGraphResponse) (map[string]*corerpv20250801preview.ApplicationGraphResource, error) {
+	result := map[string]*corerpv20250801preview.ApplicationGraphResource{}
+	if graph == nil {
+		return result, nil
+	}
+
+	for _, r := range graph.Resources {
+		if r == nil {
+			continue
+		}
+		resourceType, name := resourceTypeAndName(r)
+		if resourceType == "" || name == "" {
+			return nil, fmt.Errorf("resource %q has no type or name", to.String(r.ID))
+		}
+		key := referenceKey(resourceType, name)
+		if _, ok := result[key]; ok {
+			return nil, fmt.Errorf("resource %s/%s appears more than once in the graph", resourceType, name)
+		}
+		result[key] = r
+	}
+	return result, nil
+}
+
+// indexEdges maps a scope-independent key of every edge of graph to the edge, with endpoints
+// rewritten as "<type>/<name>" references.
+func indexEdges(graph *corerpv20250801preview.ApplicationGraphResponse) map[string]edges.Edge {
+	result := map[string]edges.Edge{}
+	for _, edge := range edges.ListEdges(graph) {
+		edge.Source = reference(edge.Source)
+		edge.Target = reference(edge.Target)
+		result[strings.ToLower(edge.Source)+"|"+strings.ToLower(edge.Target)+"|"+string(edge.Kind)] = edge
+	}
+	return result
+}
+
+// compareResources classifies a resource present in both graphs.
+func compareResources(before, after *corerpv20250801preview.ApplicationGraphResource) (ChangeType, error) {
+	beforeHash, afterHash, err := comparableHashes(before, after)
+	if err != nil {
+		return "", err
+	}
+	if beforeHash == afterHash {
+		return ChangeUnchanged, nil
+	}
+	return ChangeModified, nil
+}
+
+// comparableHashes returns the diff hashes of two matched resources, re-computing both from
+// properties unless both sides carry a diff hash. Hashes built by BuildModeledGraph also cover
+// dependsOn, so mixing a stored hash with a re-computed one would always report a change.
+func comparableHashes(before, after *corerpv20250801preview.ApplicationGraphResource) (string, string, error) {
+	if before.DiffHash != nil && after.DiffHash != nil {
+		return *before.DiffHash, *after.DiffHash, nil
+	}
+
+	beforeHash, err := ComputeDiffHash(before.Properties)
+	if err != nil {
+		return "", "", err
+	}
+	afterHash, err := ComputeDiffHash(after.Properties)
+	if err != nil {
+		return "", "", err
+	}
+	return beforeHash, afterHash, nil
+}
+
+func newResourceChange(change ChangeType, after, before *corerpv20250801preview.ApplicationGraphResource) ResourceChange {
+	source := after
+	if source == nil {
+		source = before
+	}
+	resourceType, name := resourceTypeAndName(source)
+	result := ResourceChange{Change: change, ID: to.String(source.ID), Type: resourceType, Name: name}
+
+	if before != nil && after != nil {
+		// The error was already surfaced by compareResources.
+		result.BeforeDiffHash, result.AfterDiffHash, _ = comparableHashes(before, after)
+	} else if after != nil {
+		result.AfterDiffHash = to.String(after.DiffHash)
+	} else {
+		result.BeforeDiffHash = to.String(before.DiffHash)
+	}
+	return result
+}
+
+func newConnectionChange(change ChangeType, edge edges.Edge) ConnectionChange {
+	return ConnectionChange{Change: change, Source: edge.Source, Target: edge.Target, Kind: edge.Kind}
+}
+
+// resourceTypeAndName returns the type and name of r, falling back to its ID when either field
+// is missing.
+func resourceTypeAndName(r *corerpv20250801preview.ApplicationGraphResource) (string, string) {
+	resourceType, name := to.String(r.Type), to.String(r.Name)
+	if (resourceType == "" || name == "") && r.ID != nil {
+		if id, err := resources.Parse(*r.ID); err == nil {
+			if resourceType == "" {
+				resourceType = id.Type()
+			}
+			if name == "" {
+				name = id.Name()
+			}
+		}
+	}
+	return resourceType, name
+}
+
+// reference returns the "<type>/<name>" form of a resource ID, or the ID itself when it cannot
+// be parsed.
+func reference(resourceID string) string {
+	id, err := resources.Parse(resourceID)
+	if err != nil || id.Type() == "" || id.Name() == "" {
+		return resourceID
+	}
+	return id.Type() + "/" + id.Name()
+}
+
+func referenceKey(resourceType, name string) string {
+	return strings.ToLower(resourceType + "/" + name)
+}
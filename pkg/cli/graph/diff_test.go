@@ -0,0 +1,181 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"path"
+	"testing"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	modeledScope  = "/planes/radius/local/resourcegroups/default"
+	deployedScope = "/planes/radius/local/resourceGroups/prod"
+)
+
+func diffResource(scope, resourceType, name string, properties map[string]any, connections ...*corerpv20250801preview.ApplicationGraphConnection) *corerpv20250801preview.ApplicationGraphResource {
+	return &corerpv20250801preview.ApplicationGraphResource{
+		ID:          to.Ptr(path.Join(scope, "providers", resourceType, name)),
+		Name:        to.Ptr(name),
+		Type:        to.Ptr(resourceType),
+		Properties:  properties,
+		Connections: connections,
+	}
+}
+
+func diffConnection(target string, direction corerpv20250801preview.Direction, kind corerpv20250801preview.ConnectionKind) *corerpv20250801preview.ApplicationGraphConnection {
+	return &corerpv20250801preview.ApplicationGraphConnection{ID: to.Ptr(target), Direction: to.Ptr(direction), Kind: to.Ptr(kind)}
+}
+
+func TestDiffGraphs_AcrossScopes(t *testing.T) {
+	t.Parallel()
+
+	containers := "Radius.Compute/containers"
+	databases := "Radius.Data/postgreSqlDatabases"
+	caches := "Radius.Data/redisCaches"
+
+	before := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			diffResource(modeledScope, containers, "frontend", map[string]any{"image": "frontend:1"},
+				diffConnection(modeledScope+"/providers/"+databases+"/db", corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindConnection)),
+			diffResource(modeledScope, databases, "db", map[string]any{"size": "S"}),
+			diffResource(modeledScope, caches, "cache", map[string]any{}),
+		},
+	}
+
+	// The same application deployed to another scope: the frontend image changed, the cache is
+	// gone, a worker was added, and the frontend now reaches the database through the worker.
+	after := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			diffResource(deployedScope, containers, "frontend", map[string]any{"image": "frontend:2", "provisioningState": "Succeeded"},
+				diffConnection(deployedScope+"/providers/"+containers+"/worker", corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindConnection)),
+			diffResource(deployedScope, containers, "worker", map[string]any{}),
+			// Declared inbound on the database only.
+			diffResource(deployedScope, databases, "DB", map[string]any{"size": "S", "status": map[string]any{}},
+				diffConnection(deployedScope+"/providers/"+containers+"/worker", corerpv20250801preview.DirectionInbound, corerpv20250801preview.ConnectionKindDependency)),
+		},
+	}
+
+	diff, err := DiffGraphs(before, after)
+	require.NoError(t, err)
+
+	changes := map[string]ChangeType{}
+	for _, r := range diff.Resources {
+		changes[r.Type+"/"+r.Name] = r.Change
+	}
+	require.Equal(t, map[string]ChangeType{
+		containers + "/frontend": ChangeModified,
+		containers + "/worker":   ChangeAdded,
+		databases + "/DB":        ChangeUnchanged,
+		caches + "/cache":        ChangeRemoved,
+	}, changes)
+	require.Equal(t, deployedScope+"/providers/"+databases+"/DB", diff.Resources[2].ID, "matched resources report the ID from the second graph")
+
+	require.Equal(t, []ConnectionChange{
+		{Change: ChangeAdded, Source: containers + "/frontend", Target: containers + "/worker", Kind: corerpv20250801preview.ConnectionKindConnection},
+		{Change: ChangeRemoved, Source: containers + "/frontend", Target: databases + "/db", Kind: corerpv20250801preview.ConnectionKindConnection},
+		{Change: ChangeAdded, Source: containers + "/worker", Target: databases + "/DB", Kind: corerpv20250801preview.ConnectionKindDependency},
+	}, diff.Connections)
+
+	require.True(t, diff.HasChanges())
+	require.Equal(t, 1, diff.Count(ChangeUnchanged))
+	require.Len(t, diff.Changes(), 3)
+}
+
+func TestDiffGraphs_UsesDiffHashWhenBothSidesHaveOne(t *testing.T) {
+	t.Parallel()
+
+	before := diffResource(modeledScope, "Radius.Compute/containers", "frontend", map[string]any{"image": "a"})
+	after := diffResource(modeledScope, "Radius.Compute/containers", "frontend", map[string]any{"image": "a"})
+	before.DiffHash = to.Ptr("sha256:1")
+	after.DiffHash = to.Ptr("sha256:2")
+
+	diff, err := DiffGraphs(
+		&corerpv20250801preview.ApplicationGraphResponse{Resources: []*corerpv20250801preview.ApplicationGraphResource{before}},
+		&corerpv20250801preview.ApplicationGraphResponse{Resources: []*corerpv20250801preview.ApplicationGraphResource{after}},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []ResourceChange{{
+		Change:         ChangeModified,
+		ID:             modeledScope + "/providers/Radius.Compute/containers/frontend",
+		Type:           "Radius.Compute/containers",
+		Name:           "frontend",
+		BeforeDiffHash: "sha256:1",
+		AfterDiffHash:  "sha256:2",
+	}}, diff.Resources)
+
+	// A hash on one side only is ignored in favour of re-hashing both sides.
+	after.DiffHash = nil
+	diff, err = DiffGraphs(
+		&corerpv20250801preview.ApplicationGraphResponse{Resources: []*corerpv20250801preview.ApplicationGraphResource{before}},
+		&corerpv20250801preview.ApplicationGraphResponse{Resources: []*corerpv20250801preview.ApplicationGraphResource{after}},
+	)
+	require.NoError(t, err)
+	require.Equal(t, ChangeUnchanged, diff.Resources[0].Change)
+	require.Equal(t, diff.Resources[0].BeforeDiffHash, diff.Resources[0].AfterDiffHash)
+}
+
+func TestDiffGraphs_NilAndInvalidGraphs(t *testing.T) {
+	t.Parallel()
+
+	diff, err := DiffGraphs(nil, nil)
+	require.NoError(t, err)
+	require.False(t, diff.HasChanges())
+	require.Empty(t, diff.Resources)
+
+	duplicate := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			diffResource(modeledScope, "Radius.Compute/containers", "frontend", nil),
+			diffResource(deployedScope, "Radius.Compute/containers", "Frontend", nil),
+		},
+	}
+	_, err = DiffGraphs(duplicate, nil)
+	require.ErrorContains(t, err, "appears more than once")
+}
+
+func TestGraphDiff_Markdown(t *testing.T) {
+	t.Parallel()
+
+	diff := &GraphDiff{
+		Resources: []ResourceChange{
+			{Change: ChangeAdded, Type: "Radius.Compute/containers", Name: "worker"},
+			{Change: ChangeUnchanged, Type: "Radius.Data/postgreSqlDatabases", Name: "db"},
+		},
+		Connections: []ConnectionChange{
+			{Change: ChangeAdded, Source: "Radius.Compute/containers/worker", Target: "Radius.Data/postgreSqlDatabases/db", Kind: corerpv20250801preview.ConnectionKindConnection},
+		},
+	}
+
+	expected := "### Application graph diff: `main` → `feature|x`\n\n" +
+		"1 added, 0 removed, 0 modified, 1 unchanged; 1 connection changes.\n" +
+		"\n#### Resources\n\n" +
+		"| Change | Type | Name |\n|---|---|---|\n" +
+		"| Added | Radius.Compute/containers | worker |\n" +
+		"\n#### Connections\n\n" +
+		"| Change | Source | Target | Kind |\n|---|---|---|---|\n" +
+		"| Added | Radius.Compute/containers/worker | Radius.Data/postgreSqlDatabases/db | Connection |\n"
+	require.Equal(t, expected, diff.Markdown("main", "feature|x"))
+
+	unchanged := &GraphDiff{Resources: diff.Resources[1:]}
+	require.Equal(t, "### Application graph diff: `a` → `b`\n\nNo changes. 1 resources unchanged.\n", unchanged.Markdown("a", "b"))
+
+	escaped := &GraphDiff{Resources: []ResourceChange{{Change: ChangeRemoved, Type: "T", Name: "a|b"}}}
+	require.Contains(t, escaped.Markdown("a", "b"), "| Removed | T | a\\|b |")
+}
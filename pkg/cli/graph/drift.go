@@ -0,0 +1,268 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/to"
+)
+
+const (
+	// DriftConditionType is the type of the application condition that reports drift between the
+	// modeled and the deployed graph of an application.
+	DriftConditionType = "GraphDrift"
+
+	// DriftReasonInSync is the condition reason when the deployed graph matches the modeled graph.
+	DriftReasonInSync = "InSync"
+
+	// DriftReasonDrifted is the condition reason when the deployed graph differs from the modeled
+	// graph.
+	DriftReasonDrifted = "Drifted"
+
+	// DriftReasonModeledGraphNotFound is the condition reason when no modeled graph is saved to
+	// compare with.
+	DriftReasonModeledGraphNotFound = "ModeledGraphNotFound"
+
+	// DriftReasonCheckFailed is the condition reason when either graph could not be read or
+	// compared.
+	DriftReasonCheckFailed = "CheckFailed"
+
+	// ChangeMissing marks a modeled resource or connection that is not deployed.
+	ChangeMissing ChangeType = "Missing"
+
+	// ChangeExtra marks a deployed resource or connection that is not modeled.
+	ChangeExtra ChangeType = "Extra"
+)
+
+// DriftReport describes how the deployed graph of an application differs from its modeled graph.
+type DriftReport struct {
+	// Modified lists the resources present in both graphs whose declared properties have a
+	// different value in the deployed graph, for example because they were changed out-of-band.
+	Modified []ResourceChange `json:"modified"`
+
+	// Missing lists the modeled resources that are not deployed.
+	Missing []ResourceChange `json:"missing"`
+
+	// Extra lists the deployed resources that are not modeled.
+	Extra []ResourceChange `json:"extra"`
+
+	// MissingConnections lists the modeled connections that are not deployed.
+	MissingConnections []ConnectionChange `json:"missingConnections"`
+
+	// ExtraConnections lists the deployed connections that are not modeled.
+	ExtraConnections []ConnectionChange `json:"extraConnections"`
+
+	// InSync is the number of resources that match.
+	InSync int `json:"inSync"`
+}
+
+// DetectDrift compares the deployed graph of an application with its modeled graph. Neither
+// graph is modified.
+//
+// Resources are matched and compared with DiffGraphs, after two adjustments that keep the
+// comparison to what the modeled graph declares. Properties the modeled graph does not declare
+// are ignored on the deployed side, since the control plane adds computed values such as
+// defaults. Modeled values that are template expressions, such as "[reference('db').id]", only
+// resolve at deployment and are taken from the deployed side. Only Connection edges are
+// compared: Dependency edges are not part of the deployed graph.
+func DetectDrift(modeled, deployed *corerpv20250801preview.ApplicationGraphResponse) (*DriftReport, error) {
+	modeledClone, err := cloneGraph(modeled)
+	if err != nil {
+		return nil, err
+	}
+	deployedClone, err := cloneGraph(deployed)
+	if err != nil {
+		return nil, err
+	}
+	alignGraphs(modeledClone, deployedClone)
+
+	diff, err := DiffGraphs(modeledClone, deployedClone)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DriftReport{
+		Modified:           []ResourceChange{},
+		Missing:            []ResourceChange{},
+		Extra:              []ResourceChange{},
+		MissingConnections: []ConnectionChange{},
+		ExtraConnections:   []ConnectionChange{},
+	}
+	for _, r := range diff.Resources {
+		switch r.Change {
+		case ChangeModified:
+			report.Modified = append(report.Modified, r)
+		case ChangeRemoved:
+			r.Change = ChangeMissing
+			report.Missing = append(report.Missing, r)
+		case ChangeAdded:
+			r.Change = ChangeExtra
+			report.Extra = append(report.Extra, r)
+		default:
+			report.InSync++
+		}
+	}
+	for _, c := range diff.Connections {
+		if c.Kind != corerpv20250801preview.ConnectionKindConnection {
+			continue
+		}
+		if c.Change == ChangeRemoved {
+			c.Change = ChangeMissing
+			report.MissingConnections = append(report.MissingConnections, c)
+		} else {
+			c.Change = ChangeExtra
+			report.ExtraConnections = append(report.ExtraConnections, c)
+		}
+	}
+	return report, nil
+}
+
+// Resources returns the drifted resources: modified, then missing, then extra.
+func (r *DriftReport) Resources() []ResourceChange {
+	resources := append([]ResourceChange{}, r.Modified...)
+	resources = append(resources, r.Missing...)
+	return append(resources, r.Extra...)
+}
+
+// Connections returns the drifted connections: missing, then extra.
+func (r *DriftReport) Connections() []ConnectionChange {
+	connections := append([]ConnectionChange{}, r.MissingConnections...)
+	return append(connections, r.ExtraConnections...)
+}
+
+// HasDrift reports whether the deployed graph differs from the modeled graph.
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Modified)+len(r.Missing)+len(r.Extra)+len(r.MissingConnections)+len(r.ExtraConnections) > 0
+}
+
+// Summary returns a one-line description of the drift.
+func (r *DriftReport) Summary() string {
+	if !r.HasDrift() {
+		return fmt.Sprintf("The deployed graph matches the modeled graph. %d resources in sync.", r.InSync)
+	}
+
+	parts := []string{}
+	add := func(count int, singular, plural string) {
+		switch {
+		case count == 1:
+			parts = append(parts, "1 "+singular)
+		case count > 1:
+			parts = append(parts, fmt.Sprintf("%d %s", count, plural))
+		}
+	}
+	add(len(r.Modified), "resource modified", "resources modified")
+	add(len(r.Missing), "resource missing", "resources missing")
+	add(len(r.Extra), "extra resource", "extra resources")
+	add(len(r.MissingConnections), "connection missing", "connections missing")
+	add(len(r.ExtraConnections), "extra connection", "extra connections")
+	return "The deployed graph differs from the modeled graph: " + strings.Join(parts, ", ") + "."
+}
+
+// Condition returns the GraphDrift application condition for the report: True with reason
+// Drifted when the graphs differ, and False with reason InSync otherwise.
+func (r *DriftReport) Condition() *corerpv20250801preview.ApplicationCondition {
+	status, reason := corerpv20250801preview.ConditionStatusFalse, DriftReasonInSync
+	if r.HasDrift() {
+		status, reason = corerpv20250801preview.ConditionStatusTrue, DriftReasonDrifted
+	}
+	return &corerpv20250801preview.ApplicationCondition{
+		Type:    to.Ptr(DriftConditionType),
+		Status:  to.Ptr(status),
+		Reason:  to.Ptr(reason),
+		Message: to.Ptr(r.Summary()),
+	}
+}
+
+// DriftUnknownCondition returns the GraphDrift application condition for a check that could not
+// compare the graphs. reason is DriftReasonModeledGraphNotFound or DriftReasonCheckFailed.
+func DriftUnknownCondition(reason string, message string) *corerpv20250801preview.ApplicationCondition {
+	return &corerpv20250801preview.ApplicationCondition{
+		Type:    to.Ptr(DriftConditionType),
+		Status:  to.Ptr(corerpv20250801preview.ConditionStatusUnknown),
+		Reason:  to.Ptr(reason),
+		Message: to.Ptr(message),
+	}
+}
+
+// alignGraphs restricts the properties of every deployed resource to the properties declared by
+// the matching modeled resource, and replaces modeled template expressions with the deployed
+// value. Diff hashes are cleared so that DiffGraphs re-hashes both sides.
+func alignGraphs(modeled, deployed *corerpv20250801preview.ApplicationGraphResponse) {
+	deployedResources := map[string]*corerpv20250801preview.ApplicationGraphResource{}
+	for _, r := range deployed.Resources {
+		if r == nil {
+			continue
+		}
+		r.DiffHash = nil
+		resourceType, name := resourceTypeAndName(r)
+		deployedResources[referenceKey(resourceType, name)] = r
+	}
+
+	for _, m := range modeled.Resources {
+		if m == nil {
+			continue
+		}
+		m.DiffHash = nil
+		resourceType, name := resourceTypeAndName(m)
+		d, ok := deployedResources[referenceKey(resourceType, name)]
+		if !ok {
+			continue
+		}
+		modeledProperties, deployedProperties := alignValues(m.Properties, d.Properties)
+		m.Properties, _ = modeledProperties.(map[string]any)
+		d.Properties, _ = deployedProperties.(map[string]any)
+	}
+}
+
+// alignValues returns modeled and deployed with template expressions in modeled replaced by the
+// deployed value, and with the keys of deployed maps restricted to the keys of modeled maps.
+func alignValues(modeled any, deployed any) (any, any) {
+	if isTemplateExpression(modeled) {
+		return deployed, deployed
+	}
+
+	modeledMap, ok := modeled.(map[string]any)
+	if !ok {
+		return modeled, deployed
+	}
+	deployedMap, ok := deployed.(map[string]any)
+	if !ok {
+		return modeled, deployed
+	}
+
+	alignedModeled := make(map[string]any, len(modeledMap))
+	alignedDeployed := make(map[string]any, len(modeledMap))
+	for key, value := range modeledMap {
+		deployedValue, ok := deployedMap[key]
+		if !ok {
+			alignedModeled[key] = value
+			continue
+		}
+		alignedModeled[key], alignedDeployed[key] = alignValues(value, deployedValue)
+	}
+	return alignedModeled, alignedDeployed
+}
+
+// isTemplateExpression reports whether value is an ARM template expression string. Strings
+// starting with "[[" are escaped literals.
+func isTemplateExpression(value any) bool {
+	s, ok := value.(string)
+	return ok && strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") && !strings.HasPrefix(s, "[[")
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"testing"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDrift_InSync(t *testing.T) {
+	t.Parallel()
+
+	containers := "Radius.Compute/containers"
+	databases := "Radius.Data/postgreSqlDatabases"
+
+	modeled := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			diffResource(modeledScope, containers, "frontend",
+				map[string]any{"image": "frontend:1", "environment": "[parameters('environment')]"},
+				diffConnection(modeledScope+"/providers/"+databases+"/db", corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindConnection),
+				diffConnection(modeledScope+"/providers/"+databases+"/db", corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindDependency)),
+			diffResource(modeledScope, databases, "db", map[string]any{"size": "S"}),
+		},
+	}
+
+	// Expressions resolve on deployment and the control plane adds computed properties. Neither
+	// is drift, and neither is the Dependency edge the deployed graph does not carry.
+	deployed := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			diffResource(deployedScope, containers, "frontend",
+				map[string]any{"image": "frontend:1", "environment": deployedScope + "/providers/Radius.Core/environments/prod", "replicas": 1},
+				diffConnection(deployedScope+"/providers/"+databases+"/db", corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindConnection)),
+			diffResource(deployedScope, databases, "db", map[string]any{"size": "S", "host": "db.internal"}),
+		},
+	}
+
+	report, err := DetectDrift(modeled, deployed)
+	require.NoError(t, err)
+	require.False(t, report.HasDrift())
+	require.Equal(t, 2, report.InSync)
+	require.Equal(t, "The deployed graph matches the modeled graph. 2 resources in sync.", report.Summary())
+
+	condition := report.Condition()
+	require.Equal(t, DriftConditionType, *condition.Type)
+	require.Equal(t, corerpv20250801preview.ConditionStatusFalse, *condition.Status)
+	require.Equal(t, DriftReasonInSync, *condition.Reason)
+
+	// The inputs are not modified.
+	require.Equal(t, "[parameters('environment')]", modeled.Resources[0].Properties["environment"])
+	require.Contains(t, deployed.Resources[1].Properties, "host")
+}
+
+func TestDetectDrift_Drifted(t *testing.T) {
+	t.Parallel()
+
+	containers := "Radius.Compute/containers"
+	databases := "Radius.Data/postgreSqlDatabases"
+	caches := "Radius.Data/redisCaches"
+
+	modeled := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			diffResource(modeledScope, containers, "frontend",
+				map[string]any{"container": map[string]any{"image": "frontend:1"}},
+				diffConnection(modeledScope+"/providers/"+databases+"/db", corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindConnection)),
+			diffResource(modeledScope, databases, "db", map[string]any{}),
+			diffResource(modeledScope, caches, "cache", map[string]any{}),
+		},
+	}
+
+	// The frontend image was changed out-of-band, the cache was deleted, a worker was added and
+	// the frontend connects to the worker instead of the database.
+	deployed := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			diffResource(deployedScope, containers, "frontend",
+				map[string]any{"container": map[string]any{"image": "frontend:2", "ports": map[string]any{}}},
+				diffConnection(deployedScope+"/providers/"+containers+"/worker", corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindConnection)),
+			diffResource(deployedScope, containers, "worker", map[string]any{}),
+			diffResource(deployedScope, databases, "db", map[string]any{}),
+		},
+	}
+
+	report, err := DetectDrift(modeled, deployed)
+	require.NoError(t, err)
+	require.True(t, report.HasDrift())
+	require.Equal(t, 1, report.InSync)
+
+	require.Len(t, report.Modified, 1)
+	require.Equal(t, "frontend", report.Modified[0].Name)
+	require.Len(t, report.Missing, 1)
+	require.Equal(t, "cache", report.Missing[0].Name)
+	require.Len(t, report.Extra, 1)
+	require.Equal(t, "worker", report.Extra[0].Name)
+	require.Equal(t, []ChangeType{ChangeModified, ChangeMissing, ChangeExtra}, []ChangeType{report.Resources()[0].Change, report.Resources()[1].Change, report.Resources()[2].Change})
+	require.Equal(t, []ConnectionChange{{Change: ChangeMissing, Source: containers + "/frontend", Target: databases + "/db", Kind: corerpv20250801preview.ConnectionKindConnection}}, report.MissingConnections)
+	require.Equal(t, []ConnectionChange{{Change: ChangeExtra, Source: containers + "/frontend", Target: containers + "/worker", Kind: corerpv20250801preview.ConnectionKindConnection}}, report.ExtraConnections)
+
+	require.Equal(t, "The deployed graph differs from the modeled graph: 1 resource modified, 1 resource missing, 1 extra resource, 1 connection missing, 1 extra connection.", report.Summary())
+	condition := report.Condition()
+	require.Equal(t, corerpv20250801preview.ConditionStatusTrue, *condition.Status)
+	require.Equal(t, DriftReasonDrifted, *condition.Reason)
+	require.Equal(t, report.Summary(), *condition.Message)
+}
+
+func TestDriftUnknownCondition(t *testing.T) {
+	t.Parallel()
+
+	condition := DriftUnknownCondition(DriftReasonModeledGraphNotFound, "No modeled graph is saved for branch main.")
+	require.Equal(t, DriftConditionType, *condition.Type)
+	require.Equal(t, corerpv20250801preview.ConditionStatusUnknown, *condition.Status)
+	require.Equal(t, DriftReasonModeledGraphNotFound, *condition.Reason)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/graph/edges"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+const (
+	// ExportFormatDOT renders the graph as a Graphviz DOT digraph.
+	ExportFormatDOT = "dot"
+
+	// ExportFormatMermaid renders the graph as a Mermaid flowchart.
+	ExportFormatMermaid = "mermaid"
+
+	// ExportFormatSBOM renders the graph as a CycloneDX-style bill of materials in JSON.
+	ExportFormatSBOM = "sbom"
+)
+
+// ExportFormats returns the formats supported by Export.
+func ExportFormats() []string {
+	return []string{ExportFormatDOT, ExportFormatMermaid, ExportFormatSBOM}
+}
+
+// IsExportFormat reports whether format, compared case-insensitively, is one of ExportFormats.
+func IsExportFormat(format string) bool {
+	for _, f := range ExportFormats() {
+		if strings.EqualFold(f, format) {
+			return true
+		}
+	}
+	return false
+}
+
+// Export renders graph in the given export format. name names the application in the output:
+// the DOT graph ID, the Mermaid title and the SBOM root component.
+//
+// Icons are used where the format can carry them. Mermaid nodes whose iconHash has bytes in the
+// graph's icons map are drawn as image shapes with a base64 data URI, which browsers render as
+// an image rather than inline markup. The SBOM records each iconHash as a property. DOT can only
+// reference image files, so it carries no icons.
+func Export(graph *corerpv20250801preview.ApplicationGraphResponse, format string, name string) (string, error) {
+	if graph == nil {
+		graph = &corerpv20250801preview.ApplicationGraphResponse{}
+	}
+
+	switch strings.ToLower(format) {
+	case ExportFormatDOT:
+		return exportDOT(graph, name), nil
+	case ExportFormatMermaid:
+		return exportMermaid(graph, name), nil
+	case ExportFormatSBOM:
+		sbom := BuildSBOM(graph, name)
+		data, err := json.MarshalIndent(sbom, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshal bill of materials: %w", err)
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q, supported formats are: %s", format, strings.Join(ExportFormats(), ", "))
+	}
+}
+
+// exportNode is a vertex of an exported diagram. External nodes are edge endpoints that are not
+// resources of the graph.
+type exportNode struct {
+	ID       string
+	Name     string
+	Type     string
+	IconHash string
+	External bool
+}
+
+// exportNodesAndEdges returns the resources of graph sorted by type and name, followed by the
+// external edge endpoints sorted by ID, and the edges of graph. Edge endpoints are rewritten to
+// the ID of the matching node so lookups are exact.
+func exportNodesAndEdges(graph *corerpv20250801preview.ApplicationGraphResponse) ([]exportNode, []edges.Edge) {
+	nodes := []exportNode{}
+	byID := map[string]string{}
+	for _, r := range graph.Resources {
+		if r == nil || r.ID == nil {
+			continue
+		}
+		resourceType, name := resourceTypeAndName(r)
+		nodes = append(nodes, exportNode{ID: *r.ID, Name: name, Type: resourceType, IconHash: to.String(r.IconHash)})
+		byID[strings.ToLower(*r.ID)] = *r.ID
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return referenceKey(nodes[i].Type, nodes[i].Name) < referenceKey(nodes[j].Type, nodes[j].Name)
+	})
+
+	external := []exportNode{}
+	resolve := func(id string) string {
+		if canonical, ok := byID[strings.ToLower(id)]; ok {
+			return canonical
+		}
+		byID[strings.ToLower(id)] = id
+		node := exportNode{ID: id, Name: id, External: true}
+		if parsed, err := resources.Parse(id); err == nil && parsed.Name() != "" {
+			node.Type, node.Name = parsed.Type(), parsed.Name()
+		}
+		external = append(external, node)
+		return id
+	}
+
+	result := edges.ListEdges(graph)
+	for i := range result {
+		result[i].Source = resolve(result[i].Source)
+		result[i].Target = resolve(result[i].Target)
+	}
+	sort.Slice(external, func(i, j int) bool { return strings.ToLower(external[i].ID) < strings.ToLower(external[j].ID) })
+
+	return append(nodes, external...), result
+}
+
+// exportDOT renders graph as a Graphviz digraph. Connection edges are solid and Dependency edges
+// dashed; external endpoints are drawn dashed.
+func exportDOT(graph *corerpv20250801preview.ApplicationGraphResponse, name string) string {
+	nodes, graphEdges := exportNodesAndEdges(graph)
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "digraph %s {\n", dotQuote(name))
+	out.WriteString("  rankdir=LR;\n")
+	out.WriteString("  node [shape=box, style=rounded];\n")
+	for _, node := range nodes {
+		label := node.Name
+		if node.Type != "" {
+			label += "\n" + node.Type
+		}
+		if node.External {
+			fmt.Fprintf(out, "  %s [label=%s, style=\"rounded,dashed\"];\n", dotQuote(node.ID), dotQuote(label))
+		} else {
+			fmt.Fprintf(out, "  %s [label=%s];\n", dotQuote(node.ID), dotQuote(label))
+		}
+	}
+	for _, edge := range graphEdges {
+		if edge.Kind == corerpv20250801preview.ConnectionKindDependency {
+			fmt.Fprintf(out, "  %s -> %s [style=dashed];\n", dotQuote(edge.Source), dotQuote(edge.Target))
+		} else {
+			fmt.Fprintf(out, "  %s -> %s;\n", dotQuote(edge.Source), dotQuote(edge.Target))
+		}
+	}
+	out.WriteString("}\n")
+	return out.String()
+}
+
+// dotQuote returns value as a DOT double-quoted string.
+func dotQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+// exportMermaid renders graph as a Mermaid flowchart. Connection edges are solid arrows and
+// Dependency edges dotted arrows.
+func exportMermaid(graph *corerpv20250801preview.ApplicationGraphResponse, name string) string {
+	nodes, graphEdges := exportNodesAndEdges(graph)
+
+	out := &strings.Builder{}
+	if name != "" {
+		fmt.Fprintf(out, "---\ntitle: %s\n---\n", mermaidText(name))
+	}
+	out.WriteString("flowchart LR\n")
+
+	ids := map[string]string{}
+	for i, node := range nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[node.ID] = id
+
+		label := mermaidText(node.Name)
+		if node.Type != "" {
+			label += "<br/>" + mermaidText(node.Type)
+		}
+
+		icon := ""
+		if node.IconHash != "" {
+			if svg := to.String(graph.Icons[node.IconHash]); svg != "" {
+				icon = "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(svg))
+			}
+		}
+
+		switch {
+		case icon != "":
+			fmt.Fprintf(out, "  %s@{ img: \"%s\", label: \"%s\", pos: \"t\", h: 48, constraint: \"on\" }\n", id, icon, label)
+		case node.External:
+			fmt.Fprintf(out, "  %s([\"%s\"])\n", id, label)
+		default:
+			fmt.Fprintf(out, "  %s[\"%s\"]\n", id, label)
+		}
+	}
+	for _, edge := range graphEdges {
+		arrow := "-->"
+		if edge.Kind == corerpv20250801preview.ConnectionKindDependency {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(out, "  %s %s %s\n", ids[edge.Source], arrow, ids[edge.Target])
+	}
+	return out.String()
+}
+
+// mermaidText escapes value for use inside a double-quoted Mermaid label.
+func mermaidText(value string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ").Replace(value)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	exportWebID   = "/planes/radius/local/resourceGroups/test/providers/Radius.Compute/containers/web"
+	exportCacheID = "/planes/radius/local/resourceGroups/test/providers/Radius.Data/redisCaches/cache"
+	exportVaultID = "/planes/aws/aws/accounts/123/regions/us-east-1/providers/AWS.SecretsManager/Secret/vault"
+)
+
+// exportGraph is web -> cache (Connection), web -> vault (Dependency, external), where web has an
+// icon with bytes and cache an icon hash without bytes.
+func exportGraph() *corerpv20250801preview.ApplicationGraphResponse {
+	web := &corerpv20250801preview.ApplicationGraphResource{
+		ID:                to.Ptr(exportWebID),
+		Name:              to.Ptr("web"),
+		Type:              to.Ptr("Radius.Compute/containers"),
+		ProvisioningState: to.Ptr("Succeeded"),
+		IconHash:          to.Ptr("sha256:web"),
+		Properties: map[string]any{
+			"containers": map[string]any{
+				"app":     map[string]any{"image": "ghcr.io/acme/web:1.2"},
+				"sidecar": map[string]any{"image": "localhost:5000/proxy"},
+				"params":  map[string]any{"image": "[parameters('image')]"},
+			},
+		},
+		Connections: []*corerpv20250801preview.ApplicationGraphConnection{
+			{ID: to.Ptr(exportCacheID), Direction: to.Ptr(corerpv20250801preview.DirectionOutbound), Kind: to.Ptr(corerpv20250801preview.ConnectionKindConnection)},
+			{ID: to.Ptr(exportVaultID), Direction: to.Ptr(corerpv20250801preview.DirectionOutbound), Kind: to.Ptr(corerpv20250801preview.ConnectionKindDependency)},
+		},
+		OutputResources: []*corerpv20250801preview.ApplicationGraphOutputResource{
+			{ID: to.Ptr("/planes/kubernetes/local/namespaces/test/providers/apps/Deployment/web")},
+		},
+	}
+	cache := &corerpv20250801preview.ApplicationGraphResource{
+		ID:         to.Ptr(exportCacheID),
+		Name:       to.Ptr("cache"),
+		Type:       to.Ptr("Radius.Data/redisCaches"),
+		IconHash:   to.Ptr("sha256:cache"),
+		Properties: map[string]any{"recipe": map[string]any{"name": "azure"}},
+		Connections: []*corerpv20250801preview.ApplicationGraphConnection{
+			{ID: to.Ptr(exportWebID), Direction: to.Ptr(corerpv20250801preview.DirectionInbound), Kind: to.Ptr(corerpv20250801preview.ConnectionKindConnection)},
+		},
+	}
+
+	return &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{cache, web},
+		Icons:     map[string]*string{"sha256:web": to.Ptr("<svg/>")},
+	}
+}
+
+func TestExport_DOT(t *testing.T) {
+	t.Parallel()
+
+	out, err := Export(exportGraph(), "DOT", `my "app"`)
+	require.NoError(t, err)
+
+	expected := `digraph "my \"app\"" {
+  rankdir=LR;
+  node [shape=box, style=rounded];
+  "` + exportWebID + `" [label="web\nRadius.Compute/containers"];
+  "` + exportCacheID + `" [label="cache\nRadius.Data/redisCaches"];
+  "` + exportVaultID + `" [label="vault\nAWS.SecretsManager/Secret", style="rounded,dashed"];
+  "` + exportWebID + `" -> "` + exportVaultID + `" [style=dashed];
+  "` + exportWebID + `" -> "` + exportCacheID + `";
+}
+`
+	require.Equal(t, expected, out)
+}
+
+func TestExport_Mermaid(t *testing.T) {
+	t.Parallel()
+
+	out, err := Export(exportGraph(), ExportFormatMermaid, "my-app")
+	require.NoError(t, err)
+
+	icon := "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte("<svg/>"))
+	expected := "---\ntitle: my-app\n---\n" +
+		"flowchart LR\n" +
+		"  n0@{ img: \"" + icon + "\", label: \"web<br/>Radius.Compute/containers\", pos: \"t\", h: 48, constraint: \"on\" }\n" +
+		// The cache has an icon hash but no bytes in the icons map, so it is a plain node.
+		"  n1[\"cache<br/>Radius.Data/redisCaches\"]\n" +
+		"  n2([\"vault<br/>AWS.SecretsManager/Secret\"])\n" +
+		"  n0 -.-> n2\n" +
+		"  n0 --> n1\n"
+	require.Equal(t, expected, out)
+
+	escaped, err := Export(&corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			{ID: to.Ptr("/planes/radius/local/resourceGroups/test/providers/T/t/a"), Name: to.Ptr(`a"<b>`), Type: to.Ptr("T/t")},
+		},
+	}, ExportFormatMermaid, "")
+	require.NoError(t, err)
+	require.Equal(t, "flowchart LR\n  n0[\"a#quot;#lt;b#gt;<br/>T/t\"]\n", escaped)
+}
+
+func TestExport_SBOM(t *testing.T) {
+	t.Parallel()
+
+	out, err := Export(exportGraph(), ExportFormatSBOM, "my-app")
+	require.NoError(t, err)
+
+	sbom := &SBOM{}
+	require.NoError(t, json.Unmarshal([]byte(out), sbom))
+	require.Equal(t, &SBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.6",
+		Version:     1,
+		Metadata:    SBOMMetadata{Component: &SBOMComponent{BOMRef: "my-app", Type: "application", Name: "my-app"}},
+		Components: []SBOMComponent{
+			{
+				BOMRef: exportWebID, Type: "application", Name: "web",
+				Properties: []SBOMProperty{
+					{Name: "radius:resourceType", Value: "Radius.Compute/containers"},
+					{Name: "radius:provisioningState", Value: "Succeeded"},
+					{Name: "radius:iconHash", Value: "sha256:web"},
+					{Name: "radius:outputResource", Value: "/planes/kubernetes/local/namespaces/test/providers/apps/Deployment/web"},
+				},
+			},
+			{
+				BOMRef: exportCacheID, Type: "application", Name: "cache",
+				Properties: []SBOMProperty{
+					{Name: "radius:resourceType", Value: "Radius.Data/redisCaches"},
+					{Name: "radius:recipe", Value: "azure"},
+					{Name: "radius:iconHash", Value: "sha256:cache"},
+				},
+			},
+			{BOMRef: "image:ghcr.io/acme/web:1.2", Type: "container", Name: "ghcr.io/acme/web", Version: "1.2"},
+			{BOMRef: "image:localhost:5000/proxy", Type: "container", Name: "localhost:5000/proxy"},
+		},
+		Dependencies: []SBOMDependency{
+			{Ref: exportWebID, DependsOn: []string{exportVaultID, exportCacheID, "image:ghcr.io/acme/web:1.2", "image:localhost:5000/proxy"}},
+			{Ref: exportCacheID, DependsOn: []string{}},
+		},
+	}, sbom)
+}
+
+func TestExport_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := Export(nil, "png", "app")
+	require.ErrorContains(t, err, `unsupported export format "png"`)
+	require.True(t, IsExportFormat("Mermaid"))
+	require.False(t, IsExportFormat("json"))
+}
+
+func TestSplitImageReference(t *testing.T) {
+	t.Parallel()
+
+	for image, expected := range map[string][2]string{
+		"nginx":                         {"nginx", ""},
+		"nginx:1.25":                    {"nginx", "1.25"},
+		"registry:5000/team/app":        {"registry:5000/team/app", ""},
+		"registry:5000/team/app:v1":     {"registry:5000/team/app", "v1"},
+		"ghcr.io/acme/app@sha256:abc12": {"ghcr.io/acme/app", "sha256:abc12"},
+	} {
+		name, version := splitImageReference(image)
+		require.Equal(t, expected, [2]string{name, version}, image)
+	}
+}
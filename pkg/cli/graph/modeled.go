@@ -18,6 +18,7 @@ package graph
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"sort"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
 	"github.com/radius-project/radius/pkg/defaults"
 	"github.com/radius-project/radius/pkg/graph/edges"
+	"github.com/radius-project/radius/pkg/graph/persistence"
 	"github.com/radius-project/radius/pkg/to"
 )
 
@@ -849,3 +851,14 @@ func emptyGraph() *corerpv20250801preview.ApplicationGraphResponse {
 		Resources: []*corerpv20250801preview.ApplicationGraphResource{},
 	}
 }
+
+// ModeledGraphKeyName is the name under which the modeled graph of a branch is saved in the
+// radius-graph archive.
+const ModeledGraphKeyName = "app-graph"
+
+// ModeledGraphKey returns the key under which the modeled graph of branch is saved in the
+// radius-graph archive. The branch name is encoded with url.QueryEscape so that a branch such as
+// "feature/foo" is a single namespace segment and cannot collide with another branch.
+func ModeledGraphKey(branch string) persistence.Key {
+	return persistence.Key{Namespace: url.QueryEscape(branch), Name: ModeledGraphKeyName}
+}
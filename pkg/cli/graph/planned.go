@@ -0,0 +1,255 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/engine"
+	"github.com/radius-project/radius/pkg/to"
+)
+
+// PlanStatus is the outcome of resolving the recipe of a resource in a planned graph.
+type PlanStatus string
+
+const (
+	// PlanStatusResolved means the recipe was found and its parameters resolved.
+	PlanStatusResolved PlanStatus = "Resolved"
+
+	// PlanStatusSkipped means the environment is simulated, so recipes are not resolved.
+	PlanStatusSkipped PlanStatus = "Skipped"
+
+	// PlanStatusFailed means the recipe could not be resolved. Error says why.
+	PlanStatusFailed PlanStatus = "Failed"
+
+	// defaultRecipeName is the recipe used by resources that do not name one.
+	defaultRecipeName = "default"
+
+	// manualResourceProvisioning is the resourceProvisioning value of resources whose
+	// infrastructure is provided by the user rather than by a recipe.
+	manualResourceProvisioning = "manual"
+)
+
+// PlannedRecipe is the recipe a resource of a planned graph would be deployed with.
+type PlannedRecipe struct {
+	// ResourceID is the ID of the resource in the target scope.
+	ResourceID string `json:"resourceId"`
+
+	// ResourceType is the type of the resource.
+	ResourceType string `json:"resourceType"`
+
+	// ResourceName is the name of the resource.
+	ResourceName string `json:"resourceName"`
+
+	// Name is the name of the recipe.
+	Name string `json:"name"`
+
+	// Status is the outcome of resolving the recipe.
+	Status PlanStatus `json:"status"`
+
+	// TemplateKind, TemplatePath and TemplateVersion identify the recipe template.
+	TemplateKind    string `json:"templateKind,omitempty"`
+	TemplatePath    string `json:"templatePath,omitempty"`
+	TemplateVersion string `json:"templateVersion,omitempty"`
+
+	// Parameters are the parameters the recipe would be executed with, with environment and
+	// resource parameters merged and {{context.*}} expressions resolved. Sensitive values are
+	// nulled.
+	Parameters map[string]any `json:"parameters,omitempty"`
+
+	// Error is set when Status is Failed.
+	Error string `json:"error,omitempty"`
+}
+
+// PlannedGraph is the graph an application would have if a template were deployed to an
+// environment: the modeled graph placed in the target scope, plus the recipe each resource
+// would be deployed with.
+type PlannedGraph struct {
+	Graph   *corerpv20250801preview.ApplicationGraphResponse `json:"graph"`
+	Recipes []PlannedRecipe                                  `json:"recipes"`
+}
+
+// PlanOptions configures BuildPlannedGraph.
+type PlanOptions struct {
+	// Engine resolves recipes. Recipes are executed with ExecuteOptions.Simulated, so the
+	// engine does not need any drivers.
+	Engine engine.Engine
+
+	// EnvironmentID is the ID of the environment the template would be deployed to.
+	EnvironmentID string
+
+	// ApplicationID is the ID of the application, if any.
+	ApplicationID string
+
+	// Scope is the root scope the template would be deployed to, for example
+	// "/planes/radius/local/resourceGroups/my-group". Resource IDs of the modeled graph are
+	// moved from the modeled-graph default scope to Scope.
+	Scope string
+}
+
+// BuildPlannedGraph turns a modeled graph into a planned graph without deploying anything.
+// modeled is not modified.
+//
+// Every resource deployed by a recipe is given to the recipe engine as a simulated execution,
+// which loads the recipe from the environment and resolves its parameters. A resource is
+// deployed by a recipe when it selects one through properties.recipe, or when it is a Radius
+// resource type outside Applications.Core and Radius.Core, unless its resourceProvisioning is
+// manual. Connections are not passed to the engine, so context.resource.connections
+// expressions are left unresolved. A recipe that cannot be resolved is recorded as Failed
+// rather than failing the plan.
+func BuildPlannedGraph(ctx context.Context, modeled *corerpv20250801preview.ApplicationGraphResponse, options PlanOptions) (*PlannedGraph, error) {
+	graph, err := cloneGraph(modeled)
+	if err != nil {
+		return nil, err
+	}
+	rescopeGraph(graph, options.Scope)
+
+	planned := &PlannedGraph{Graph: graph, Recipes: []PlannedRecipe{}}
+	for _, r := range graph.Resources {
+		if r == nil || r.ID == nil {
+			continue
+		}
+		name, parameters, ok := recipeReference(r)
+		if !ok {
+			continue
+		}
+
+		resourceType, resourceName := resourceTypeAndName(r)
+		recipe := PlannedRecipe{ResourceID: *r.ID, ResourceType: resourceType, ResourceName: resourceName, Name: name}
+
+		output, err := options.Engine.Execute(ctx, engine.ExecuteOptions{
+			BaseOptions: engine.BaseOptions{
+				Recipe: recipes.ResourceMetadata{
+					Name:          name,
+					Parameters:    parameters,
+					EnvironmentID: options.EnvironmentID,
+					ApplicationID: options.ApplicationID,
+					ResourceID:    *r.ID,
+					Properties:    r.Properties,
+				},
+			},
+			Simulated: true,
+		})
+		switch {
+		case err != nil:
+			recipe.Status = PlanStatusFailed
+			recipe.Error = err.Error()
+		case output == nil:
+			recipe.Status = PlanStatusSkipped
+		default:
+			recipe.Status = PlanStatusResolved
+			recipe.Parameters = output.Parameters
+			redactSensitive(recipe.Parameters, nil)
+			if output.Status != nil {
+				recipe.TemplateKind = output.Status.TemplateKind
+				recipe.TemplatePath = output.Status.TemplatePath
+				recipe.TemplateVersion = output.Status.TemplateVersion
+			}
+		}
+		planned.Recipes = append(planned.Recipes, recipe)
+	}
+
+	sort.Slice(planned.Recipes, func(i, j int) bool {
+		return referenceKey(planned.Recipes[i].ResourceType, planned.Recipes[i].ResourceName) <
+			referenceKey(planned.Recipes[j].ResourceType, planned.Recipes[j].ResourceName)
+	})
+	return planned, nil
+}
+
+// Count returns the number of planned recipes with the given status.
+func (p *PlannedGraph) Count(status PlanStatus) int {
+	count := 0
+	for _, recipe := range p.Recipes {
+		if recipe.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// recipeReference returns the recipe name and parameters r is deployed with, and false when r
+// is not deployed by a recipe.
+func recipeReference(r *corerpv20250801preview.ApplicationGraphResource) (string, map[string]any, bool) {
+	if provisioning, _ := r.Properties["resourceProvisioning"].(string); strings.EqualFold(provisioning, manualResourceProvisioning) {
+		return "", nil, false
+	}
+
+	recipe, ok := r.Properties["recipe"].(map[string]any)
+	if !ok {
+		namespace, _, _ := strings.Cut(to.String(r.Type), "/")
+		switch {
+		case strings.EqualFold(namespace, "Applications.Core"), strings.EqualFold(namespace, "Radius.Core"):
+			return "", nil, false
+		case !strings.HasPrefix(strings.ToLower(namespace), "applications.") && !strings.HasPrefix(strings.ToLower(namespace), "radius."):
+			return "", nil, false
+		}
+	}
+
+	name, _ := recipe["name"].(string)
+	if name == "" {
+		name = defaultRecipeName
+	}
+	parameters, _ := recipe["parameters"].(map[string]any)
+	return name, parameters, true
+}
+
+// cloneGraph returns a deep copy of graph.
+func cloneGraph(graph *corerpv20250801preview.ApplicationGraphResponse) (*corerpv20250801preview.ApplicationGraphResponse, error) {
+	if graph == nil {
+		return emptyGraph(), nil
+	}
+	data, err := json.Marshal(graph)
+	if err != nil {
+		return nil, fmt.Errorf("marshal application graph: %w", err)
+	}
+	result := &corerpv20250801preview.ApplicationGraphResponse{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("unmarshal application graph: %w", err)
+	}
+	return result, nil
+}
+
+// rescopeGraph moves the resource and connection IDs of graph that are in the modeled-graph
+// default scope to scope. IDs in any other scope are left alone.
+func rescopeGraph(graph *corerpv20250801preview.ApplicationGraphResponse, scope string) {
+	if scope == "" {
+		return
+	}
+	defaultScope := fmt.Sprintf("/planes/radius/%s/resourcegroups/%s/", defaultPlane, defaultResourceGroup)
+	rescope := func(id *string) {
+		if id != nil && strings.HasPrefix(strings.ToLower(*id), defaultScope) {
+			*id = strings.TrimSuffix(scope, "/") + "/" + (*id)[len(defaultScope):]
+		}
+	}
+	for _, r := range graph.Resources {
+		if r == nil {
+			continue
+		}
+		rescope(r.ID)
+		for _, connection := range r.Connections {
+			if connection != nil {
+				rescope(connection.ID)
+			}
+		}
+	}
+}
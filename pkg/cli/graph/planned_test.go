@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/engine"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/to"
+)
+
+func TestBuildPlannedGraph(t *testing.T) {
+	const (
+		scope         = "/planes/radius/local/resourceGroups/prod"
+		environmentID = scope + "/providers/Applications.Core/environments/prod"
+		applicationID = scope + "/providers/Applications.Core/applications/app"
+	)
+
+	modeled := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			{
+				ID:   to.Ptr(buildResourceID("Applications.Core/containers", "web")),
+				Name: to.Ptr("web"),
+				Type: to.Ptr("Applications.Core/containers"),
+				Connections: []*corerpv20250801preview.ApplicationGraphConnection{
+					{ID: to.Ptr(buildResourceID("Applications.Datastores/redisCaches", "cache")), Direction: to.Ptr(corerpv20250801preview.DirectionOutbound)},
+				},
+			},
+			{
+				ID:   to.Ptr(buildResourceID("Applications.Datastores/redisCaches", "cache")),
+				Name: to.Ptr("cache"),
+				Type: to.Ptr("Applications.Datastores/redisCaches"),
+				Properties: map[string]any{
+					"recipe": map[string]any{"name": "azure", "parameters": map[string]any{"sku": "basic"}},
+				},
+			},
+			{
+				ID:   to.Ptr(buildResourceID("Radius.Data/mySqlDatabases", "db")),
+				Name: to.Ptr("db"),
+				Type: to.Ptr("Radius.Data/mySqlDatabases"),
+			},
+			{
+				ID:         to.Ptr(buildResourceID("Applications.Datastores/sqlDatabases", "legacy")),
+				Name:       to.Ptr("legacy"),
+				Type:       to.Ptr("Applications.Datastores/sqlDatabases"),
+				Properties: map[string]any{"resourceProvisioning": "manual"},
+			},
+			{
+				ID:   to.Ptr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/store"),
+				Name: to.Ptr("store"),
+				Type: to.Ptr("Microsoft.Storage/storageAccounts"),
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockEngine := engine.NewMockEngine(ctrl)
+	mockEngine.EXPECT().
+		Execute(gomock.Any(), engine.ExecuteOptions{
+			BaseOptions: engine.BaseOptions{Recipe: recipes.ResourceMetadata{
+				Name:          "azure",
+				Parameters:    map[string]any{"sku": "basic"},
+				EnvironmentID: environmentID,
+				ApplicationID: applicationID,
+				ResourceID:    scope + "/providers/Applications.Datastores/redisCaches/cache",
+				Properties:    map[string]any{"recipe": map[string]any{"name": "azure", "parameters": map[string]any{"sku": "basic"}}},
+			}},
+			Simulated: true,
+		}).
+		Return(&recipes.RecipeOutput{
+			Parameters: map[string]any{"sku": "basic", "password": "hunter2"},
+			Status:     &rpv1.RecipeStatus{TemplateKind: "bicep", TemplatePath: "ghcr.io/acme/redis:1"},
+		}, nil)
+	mockEngine.EXPECT().
+		Execute(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, opts engine.ExecuteOptions) (*recipes.RecipeOutput, error) {
+			require.Equal(t, defaultRecipeName, opts.Recipe.Name)
+			require.Equal(t, scope+"/providers/Radius.Data/mySqlDatabases/db", opts.Recipe.ResourceID)
+			return nil, errors.New("could not find recipe")
+		})
+
+	planned, err := BuildPlannedGraph(t.Context(), modeled, PlanOptions{
+		Engine:        mockEngine,
+		EnvironmentID: environmentID,
+		ApplicationID: applicationID,
+		Scope:         scope,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []PlannedRecipe{
+		{
+			ResourceID:   scope + "/providers/Applications.Datastores/redisCaches/cache",
+			ResourceType: "Applications.Datastores/redisCaches",
+			ResourceName: "cache",
+			Name:         "azure",
+			Status:       PlanStatusResolved,
+			TemplateKind: "bicep",
+			TemplatePath: "ghcr.io/acme/redis:1",
+			Parameters:   map[string]any{"sku": "basic", "password": nil},
+		},
+		{
+			ResourceID:   scope + "/providers/Radius.Data/mySqlDatabases/db",
+			ResourceType: "Radius.Data/mySqlDatabases",
+			ResourceName: "db",
+			Name:         defaultRecipeName,
+			Status:       PlanStatusFailed,
+			Error:        "could not find recipe",
+		},
+	}, planned.Recipes)
+	require.Equal(t, 1, planned.Count(PlanStatusResolved))
+	require.Equal(t, 1, planned.Count(PlanStatusFailed))
+
+	// IDs in the modeled-graph default scope are moved to the target scope; others are kept.
+	require.Equal(t, scope+"/providers/Applications.Core/containers/web", *planned.Graph.Resources[0].ID)
+	require.Equal(t, scope+"/providers/Applications.Datastores/redisCaches/cache", *planned.Graph.Resources[0].Connections[0].ID)
+	require.Equal(t, "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/store", *planned.Graph.Resources[4].ID)
+
+	// The modeled graph is not modified.
+	require.Equal(t, buildResourceID("Applications.Core/containers", "web"), *modeled.Resources[0].ID)
+}
+
+func TestBuildPlannedGraph_SimulatedEnvironment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockEngine := engine.NewMockEngine(ctrl)
+	mockEngine.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	planned, err := BuildPlannedGraph(t.Context(), &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			{ID: to.Ptr(buildResourceID("Radius.Data/redisCaches", "cache")), Name: to.Ptr("cache"), Type: to.Ptr("Radius.Data/redisCaches")},
+		},
+	}, PlanOptions{Engine: mockEngine})
+	require.NoError(t, err)
+	require.Len(t, planned.Recipes, 1)
+	require.Equal(t, PlanStatusSkipped, planned.Recipes[0].Status)
+	require.Equal(t, buildResourceID("Radius.Data/redisCaches", "cache"), planned.Recipes[0].ResourceID)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/graph/edges"
+	"github.com/radius-project/radius/pkg/to"
+)
+
+const (
+	sbomFormat      = "CycloneDX"
+	sbomSpecVersion = "1.6"
+
+	// Names of the Radius-specific properties recorded on SBOM components.
+	sbomPropertyResourceType      = "radius:resourceType"
+	sbomPropertyProvisioningState = "radius:provisioningState"
+	sbomPropertyRecipe            = "radius:recipe"
+	sbomPropertyIconHash          = "radius:iconHash"
+	sbomPropertyOutputResource    = "radius:outputResource"
+
+	// imageRefPrefix prefixes the bom-ref of image components so they cannot collide with the
+	// resource IDs used as the bom-ref of resource components.
+	imageRefPrefix = "image:"
+)
+
+// SBOM is a CycloneDX-style software bill of materials for an application graph. It follows the
+// shape of a CycloneDX 1.6 JSON document, but records only what the graph knows: the resources,
+// their types and recipes, the container images they run and the dependencies between them. It
+// omits serialNumber and timestamps so the same graph always produces the same document.
+type SBOM struct {
+	BOMFormat    string           `json:"bomFormat"`
+	SpecVersion  string           `json:"specVersion"`
+	Version      int              `json:"version"`
+	Metadata     SBOMMetadata     `json:"metadata"`
+	Components   []SBOMComponent  `json:"components"`
+	Dependencies []SBOMDependency `json:"dependencies"`
+}
+
+// SBOMMetadata describes the subject of an SBOM.
+type SBOMMetadata struct {
+	Component *SBOMComponent `json:"component,omitempty"`
+}
+
+// SBOMComponent is a resource or container image of the application.
+type SBOMComponent struct {
+	BOMRef     string         `json:"bom-ref"`
+	Type       string         `json:"type"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version,omitempty"`
+	Properties []SBOMProperty `json:"properties,omitempty"`
+}
+
+// SBOMProperty is a name/value pair attached to a component.
+type SBOMProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SBOMDependency lists the components that the component identified by Ref depends on.
+type SBOMDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// BuildSBOM builds the bill of materials of graph. name is the application name recorded as the
+// root component.
+//
+// Every resource becomes an "application" component whose bom-ref is its resource ID and whose
+// properties carry the resource type, provisioning state, recipe name, icon hash and output
+// resources. Every container image referenced by an "image" property becomes a "container"
+// component. Dependencies follow the graph's edges, plus one from each resource to the images
+// it runs. Image references that are unresolved ARM expressions are skipped.
+func BuildSBOM(graph *corerpv20250801preview.ApplicationGraphResponse, name string) *SBOM {
+	sbom := &SBOM{
+		BOMFormat:    sbomFormat,
+		SpecVersion:  sbomSpecVersion,
+		Version:      1,
+		Components:   []SBOMComponent{},
+		Dependencies: []SBOMDependency{},
+	}
+	if name != "" {
+		sbom.Metadata.Component = &SBOMComponent{BOMRef: name, Type: "application", Name: name}
+	}
+	if graph == nil {
+		return sbom
+	}
+
+	dependsOn := map[string][]string{}
+	images := map[string]SBOMComponent{}
+	resourceRefs := map[string]string{}
+	for _, r := range graph.Resources {
+		if r == nil || r.ID == nil {
+			continue
+		}
+		resourceRefs[strings.ToLower(*r.ID)] = *r.ID
+	}
+
+	for _, r := range graph.Resources {
+		if r == nil || r.ID == nil {
+			continue
+		}
+		resourceType, resourceName := resourceTypeAndName(r)
+		component := SBOMComponent{BOMRef: *r.ID, Type: "application", Name: resourceName}
+		component.Properties = append(component.Properties, SBOMProperty{Name: sbomPropertyResourceType, Value: resourceType})
+		if state := to.String(r.ProvisioningState); state != "" {
+			component.Properties = append(component.Properties, SBOMProperty{Name: sbomPropertyProvisioningState, Value: state})
+		}
+		if recipe := recipeName(r.Properties); recipe != "" {
+			component.Properties = append(component.Properties, SBOMProperty{Name: sbomPropertyRecipe, Value: recipe})
+		}
+		if hash := to.String(r.IconHash); hash != "" {
+			component.Properties = append(component.Properties, SBOMProperty{Name: sbomPropertyIconHash, Value: hash})
+		}
+		for _, output := range r.OutputResources {
+			if output != nil && output.ID != nil {
+				component.Properties = append(component.Properties, SBOMProperty{Name: sbomPropertyOutputResource, Value: *output.ID})
+			}
+		}
+		sbom.Components = append(sbom.Components, component)
+
+		dependsOn[*r.ID] = []string{}
+		for _, image := range collectImages(r.Properties) {
+			ref := imageRefPrefix + image
+			if _, ok := images[ref]; !ok {
+				imageName, version := splitImageReference(image)
+				images[ref] = SBOMComponent{BOMRef: ref, Type: "container", Name: imageName, Version: version}
+			}
+			dependsOn[*r.ID] = append(dependsOn[*r.ID], ref)
+		}
+	}
+
+	for _, edge := range edges.ListEdges(graph) {
+		source, ok := resourceRefs[strings.ToLower(edge.Source)]
+		if !ok {
+			continue
+		}
+		target := edge.Target
+		if canonical, ok := resourceRefs[strings.ToLower(target)]; ok {
+			target = canonical
+		}
+		dependsOn[source] = append(dependsOn[source], target)
+	}
+
+	sort.Slice(sbom.Components, func(i, j int) bool { return sbom.Components[i].BOMRef < sbom.Components[j].BOMRef })
+	imageRefs := make([]string, 0, len(images))
+	for ref := range images {
+		imageRefs = append(imageRefs, ref)
+	}
+	sort.Strings(imageRefs)
+	for _, ref := range imageRefs {
+		sbom.Components = append(sbom.Components, images[ref])
+	}
+
+	refs := make([]string, 0, len(dependsOn))
+	for ref := range dependsOn {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		sbom.Dependencies = append(sbom.Dependencies, SBOMDependency{Ref: ref, DependsOn: uniqueSorted(dependsOn[ref])})
+	}
+
+	return sbom
+}
+
+// recipeName returns properties.recipe.name, which portable resources use to select a recipe.
+func recipeName(properties map[string]any) string {
+	recipe, _ := properties["recipe"].(map[string]any)
+	name, _ := recipe["name"].(string)
+	return name
+}
+
+// collectImages returns the values of every "image" string property nested in properties, such
+// as properties.container.image on Applications.Core containers or
+// properties.containers.<name>.image on Radius.Compute containers.
+func collectImages(properties map[string]any) []string {
+	result := []string{}
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for key, item := range v {
+				if image, ok := item.(string); ok && key == "image" {
+					if image != "" && !strings.HasPrefix(image, "[") {
+						result = append(result, image)
+					}
+					continue
+				}
+				walk(item)
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(properties)
+	return uniqueSorted(result)
+}
+
+// splitImageReference splits a container image reference into its name and its tag or digest.
+func splitImageReference(image string) (string, string) {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[:i], image[i+1:]
+	}
+	// A colon after the last slash separates the tag; one before it belongs to a registry port.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, ""
+}
+
+func uniqueSorted(values []string) []string {
+	result := []string{}
+	seen := map[string]struct{}{}
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corerpv20231001preview "github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/to"
+)
+
+// FromApplicationsCoreGraph converts a graph returned by the Applications.Core API to the
+// Radius.Core preview shape the rest of this package consumes. The preview shape is a superset
+// of the stable one, so a JSON round trip carries every field across. The stable API only
+// reports connections, so every edge is given Kind: Connection.
+func FromApplicationsCoreGraph(graph corerpv20231001preview.ApplicationGraphResponse) (*corerpv20250801preview.ApplicationGraphResponse, error) {
+	data, err := json.Marshal(graph)
+	if err != nil {
+		return nil, fmt.Errorf("marshal application graph: %w", err)
+	}
+
+	result := &corerpv20250801preview.ApplicationGraphResponse{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("convert application graph: %w", err)
+	}
+	for _, resource := range result.Resources {
+		for _, connection := range resource.Connections {
+			if connection != nil && connection.Kind == nil {
+				connection.Kind = to.Ptr(corerpv20250801preview.ConnectionKindConnection)
+			}
+		}
+	}
+	return result, nil
+}
@@ -165,3 +165,203 @@ func GetProvidersForEnvironmentTableFormat() output.FormatterOptions {
 		},
 	}
 }
+
+// GetStateSnapshotTableFormat returns the fields to output from a state archive snapshot.
+func GetStateSnapshotTableFormat() output.FormatterOptions {
+	return output.FormatterOptions{
+		Columns: []output.Column{
+			{
+				Heading:  "SNAPSHOT",
+				JSONPath: "{ .ID }",
+			},
+			{
+				Heading:  "CREATED",
+				JSONPath: "{ .Created }",
+			},
+			{
+				Heading:  "SIZE",
+				JSONPath: "{ .Size }",
+			},
+			{
+				Heading:  "MESSAGE",
+				JSONPath: "{ .Message }",
+			},
+		},
+	}
+}
+
+// GetDatabaseMigrationTableFormat returns the fields to output from the result of a database migration.
+func GetDatabaseMigrationTableFormat() output.FormatterOptions {
+	return output.FormatterOptions{
+		Columns: []output.Column{
+			{
+				Heading:  "DATABASE",
+				JSONPath: "{ .Name }",
+			},
+			{
+				Heading:  "OBJECTS",
+				JSONPath: "{ .Count }",
+			},
+			{
+				Heading:  "CHECKSUM",
+				JSONPath: "{ .Checksum }",
+			},
+		},
+	}
+}
+
+// GetGraphDiffResourceTableFormat returns the fields to output from the resource changes of an application graph diff.
+func GetGraphDiffResourceTableFormat() output.FormatterOptions {
+	return output.FormatterOptions{
+		Columns: []output.Column{
+			{
+				Heading:  "CHANGE",
+				JSONPath: "{ .Change }",
+			},
+			{
+				Heading:  "TYPE",
+				JSONPath: "{ .Type }",
+			},
+			{
+				Heading:  "NAME",
+				JSONPath: "{ .Name }",
+			},
+		},
+	}
+}
+
+// GetGraphDiffConnectionTableFormat returns the fields to output from the connection changes of an application graph diff.
+func GetGraphDiffConnectionTableFormat() output.FormatterOptions {
+	return output.FormatterOptions{
+		Columns: []output.Column{
+			{
+				Heading:  "CHANGE",
+				JSONPath: "{ .Change }",
+			},
+			{
+				Heading:  "SOURCE",
+				JSONPath: "{ .Source }",
+			},
+			{
+				Heading:  "TARGET",
+				JSONPath: "{ .Target }",
+			},
+			{
+				Heading:  "KIND",
+				JSONPath: "{ .Kind }",
+			},
+		},
+	}
+}
+
+// GetPlannedRecipeTableFormat returns the fields to output from the recipes of a planned application graph.
+func GetPlannedRecipeTableFormat() output.FormatterOptions {
+	return output.FormatterOptions{
+		Columns: []output.Column{
+			{
+				Heading:  "RESOURCE",
+				JSONPath: "{ .ResourceName }",
+			},
+			{
+				Heading:  "TYPE",
+				JSONPath: "{ .ResourceType }",
+			},
+			{
+				Heading:  "RECIPE",
+				JSONPath: "{ .Name }",
+			},
+			{
+				Heading:  "STATUS",
+				JSONPath: "{ .Status }",
+			},
+			{
+				Heading:  "TEMPLATE",
+				JSONPath: "{ .TemplatePath }",
+			},
+		},
+	}
+}
+
+// GetDeadLetterTableFormat returns the fields to output from a message in a dead-letter queue.
+func GetDeadLetterTableFormat() output.FormatterOptions {
+	return output.FormatterOptions{
+		Columns: []output.Column{
+			{
+				Heading:  "ID",
+				JSONPath: "{ .ID }",
+			},
+			{
+				Heading:  "OPERATION",
+				JSONPath: "{ .OperationType }",
+			},
+			{
+				Heading:  "RESOURCE",
+				JSONPath: "{ .ResourceID }",
+			},
+			{
+				Heading:  "ATTEMPTS",
+				JSONPath: "{ .DequeueCount }",
+			},
+			{
+				Heading:  "DEAD-LETTERED AT",
+				JSONPath: "{ .DeadLetterAt }",
+			},
+			{
+				Heading:  "LAST ERROR",
+				JSONPath: "{ .LastError }",
+			},
+		},
+	}
+}
+
+// GetResourceHistoryTableFormat returns the fields to output from an entry in the operation history of a resource.
+func GetResourceHistoryTableFormat() output.FormatterOptions {
+	return output.FormatterOptions{
+		Columns: []output.Column{
+			{
+				Heading:  "TIMESTAMP",
+				JSONPath: "{ .Timestamp }",
+			},
+			{
+				Heading:  "OPERATION",
+				JSONPath: "{ .OperationType }",
+			},
+			{
+				Heading:  "STATE",
+				JSONPath: "{ .ProvisioningState }",
+			},
+			{
+				Heading:  "CALLER",
+				JSONPath: "{ .Caller }",
+			},
+			{
+				Heading:  "PROPERTIES HASH",
+				JSONPath: "{ .PropertiesHash }",
+			},
+			{
+				Heading:  "ERROR",
+				JSONPath: "{ .Error.Message }",
+			},
+		},
+	}
+}
+
+// GetRecipePlanTableFormat returns the fields to output from a change in the recipe plan of a resource.
+func GetRecipePlanTableFormat() output.FormatterOptions {
+	return output.FormatterOptions{
+		Columns: []output.Column{
+			{
+				Heading:  "ACTION",
+				JSONPath: "{ .Action }",
+			},
+			{
+				Heading:  "RESOURCE",
+				JSONPath: "{ .ID }",
+			},
+			{
+				Heading:  "TYPE",
+				JSONPath: "{ .Type }",
+			},
+		},
+	}
+}
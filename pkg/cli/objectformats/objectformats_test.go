@@ -19,10 +19,14 @@ package objectformats
 import (
 	"bytes"
 	"testing"
+	"time"
 
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/armrpc/history"
 	"github.com/radius-project/radius/pkg/cli/clients_new/generated"
 	"github.com/radius-project/radius/pkg/cli/output"
 	corerpv20231001preview "github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
+	"github.com/radius-project/radius/pkg/recipes"
 	"github.com/radius-project/radius/pkg/to"
 	"github.com/stretchr/testify/require"
 )
@@ -62,3 +66,55 @@ func Test_GetGenericResourceTableFormat(t *testing.T) {
 	expected := "RESOURCE  TYPE       GROUP       STATE\ntest      test-type  test-group  Updating\n"
 	require.Equal(t, expected, buffer.String())
 }
+
+func Test_GetResourceHistoryTableFormat(t *testing.T) {
+	obj := []*history.Entry{
+		{
+			OperationType:     "APPLICATIONS.CORE/CONTAINERS|PUT",
+			Caller:            "user",
+			Timestamp:         time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			ProvisioningState: v1.ProvisioningStateSucceeded,
+			PropertiesHash:    "abc",
+		},
+		{
+			OperationType:     "APPLICATIONS.CORE/CONTAINERS|DELETE",
+			Caller:            "user",
+			Timestamp:         time.Date(2026, 1, 2, 3, 5, 5, 0, time.UTC),
+			ProvisioningState: v1.ProvisioningStateFailed,
+			Error:             &v1.ErrorDetails{Code: v1.CodeInternal, Message: "deletion failed"},
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+	err := output.Write(output.FormatTable, obj, buffer, GetResourceHistoryTableFormat())
+	require.NoError(t, err)
+
+	expected := "TIMESTAMP               OPERATION                            STATE      CALLER    PROPERTIES HASH  ERROR\n" +
+		"\"2026-01-02T03:04:05Z\"  APPLICATIONS.CORE/CONTAINERS|PUT     Succeeded  user      abc              \n" +
+		"\"2026-01-02T03:05:05Z\"  APPLICATIONS.CORE/CONTAINERS|DELETE  Failed     user                       deletion failed\n"
+	require.Equal(t, expected, buffer.String())
+}
+
+func Test_GetRecipePlanTableFormat(t *testing.T) {
+	obj := []recipes.ResourceChange{
+		{
+			ID:     "aws_s3_bucket.main",
+			Type:   "aws_s3_bucket",
+			Action: recipes.ChangeActionCreate,
+		},
+		{
+			ID:     "kubernetes_deployment.redis",
+			Type:   "kubernetes_deployment",
+			Action: recipes.ChangeActionUpdate,
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+	err := output.Write(output.FormatTable, obj, buffer, GetRecipePlanTableFormat())
+	require.NoError(t, err)
+
+	expected := "ACTION    RESOURCE                     TYPE\n" +
+		"create    aws_s3_bucket.main           aws_s3_bucket\n" +
+		"update    kubernetes_deployment.redis  kubernetes_deployment\n"
+	require.Equal(t, expected, buffer.String())
+}
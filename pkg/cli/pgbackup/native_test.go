@@ -0,0 +1,203 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbackup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	environmentID = "/planes/radius/local/resourceGroups/default/providers/Applications.Core/environments/env"
+	groupID       = "/planes/radius/local/resourceGroups/default"
+)
+
+func saveObject(t *testing.T, client database.Client, id string, data any) database.Object {
+	t.Helper()
+	obj := database.Object{Metadata: database.Metadata{ID: id}, Data: data}
+	require.NoError(t, client.Save(t.Context(), &obj))
+	return obj
+}
+
+func Test_ExportImportStore_RoundTrip(t *testing.T) {
+	ctx := t.Context()
+	source := inmemory.NewClient()
+	environment := saveObject(t, source, environmentID, map[string]any{"name": "env", "properties": map[string]any{"count": float64(3)}})
+	group := saveObject(t, source, groupID, map[string]any{"name": "default"})
+
+	var buffer bytes.Buffer
+	count, err := ExportStore(ctx, "ucp", source, &buffer)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Len(t, lines, 3)
+	require.JSONEq(t, `{"format":"radius.database/v1","store":"ucp"}`, lines[0])
+
+	target := inmemory.NewClient()
+	stale := saveObject(t, target, groupID+"/providers/Applications.Core/applications/stale", map[string]any{"name": "stale"})
+
+	count, err = ImportStore(ctx, "ucp", target, &buffer)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	for _, expected := range []database.Object{environment, group} {
+		actual, err := target.Get(ctx, expected.ID)
+		require.NoError(t, err)
+		require.Equal(t, expected, *actual, "objects keep their ID, ETag and data")
+	}
+
+	_, err = target.Get(ctx, stale.ID)
+	require.ErrorIs(t, err, &database.ErrNotFound{}, "objects missing from the backup are deleted")
+}
+
+func Test_ImportStore_RejectsInvalidBackups(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "empty", input: "", wantErr: "backup file is empty"},
+		{name: "unknown format", input: `{"format":"pg_dump","store":"ucp"}`, wantErr: "not in the radius.database/v1 format"},
+		{name: "other store", input: `{"format":"radius.database/v1","store":"dynamic_rp"}`, wantErr: `belongs to database "dynamic_rp"`},
+		{name: "invalid record", input: "{\"format\":\"radius.database/v1\",\"store\":\"ucp\"}\nnot-json", wantErr: "invalid record on line 2"},
+		{name: "missing id", input: "{\"format\":\"radius.database/v1\",\"store\":\"ucp\"}\n{\"data\":{}}", wantErr: "id is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportStore(t.Context(), "ucp", inmemory.NewClient(), strings.NewReader(tt.input))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_ExportStore_RequiresExporter(t *testing.T) {
+	_, err := ExportStore(t.Context(), "ucp", database.NewMockClient(nil), &bytes.Buffer{})
+	require.ErrorContains(t, err, "does not support export")
+}
+
+func Test_BackupRestoreNative(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	writeDumps(t, dir, Databases...)
+
+	sources := []Store{}
+	for _, db := range Databases {
+		client := inmemory.NewClient()
+		saveObject(t, client, environmentID, map[string]any{"database": db})
+		sources = append(sources, Store{Name: db, Client: client})
+	}
+
+	require.NoError(t, BackupNative(ctx, sources, dir))
+	require.True(t, HasNativeBackup(dir, sources))
+	require.False(t, HasBackup(dir), "pg_dump files are replaced by the native backup")
+
+	targets := []Store{}
+	for _, db := range Databases {
+		targets = append(targets, Store{Name: db, Client: inmemory.NewClient()})
+	}
+	require.NoError(t, RestoreNative(ctx, targets, dir))
+
+	for i, target := range targets {
+		expected, err := sources[i].Client.Get(ctx, environmentID)
+		require.NoError(t, err)
+		actual, err := target.Client.Get(ctx, environmentID)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+}
+
+func Test_RestoreNative_SkipsWithoutBackup(t *testing.T) {
+	stores := []Store{{Name: "ucp", Client: inmemory.NewClient()}}
+	require.NoError(t, RestoreNative(t.Context(), stores, t.TempDir()))
+}
+
+func Test_RestoreNative_RejectsPgDumpBackup(t *testing.T) {
+	dir := t.TempDir()
+	writeDumps(t, dir, Databases...)
+
+	stores := []Store{{Name: "ucp", Client: inmemory.NewClient()}}
+	err := RestoreNative(t.Context(), stores, dir)
+	require.ErrorContains(t, err, "holds pg_dump backups")
+}
+
+func Test_HasNativeBackup(t *testing.T) {
+	dir := t.TempDir()
+	stores := []Store{{Name: "ucp"}, {Name: "dynamic_rp"}}
+	require.False(t, HasNativeBackup(dir, stores))
+	require.False(t, HasNativeBackup(dir, nil), "no stores is not a backup")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ucp.jsonl"), []byte("{}"), 0o644))
+	require.False(t, HasNativeBackup(dir, stores), "a partial set of files is not a backup")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dynamic_rp.jsonl"), []byte("{}"), 0o644))
+	require.True(t, HasNativeBackup(dir, stores))
+}
+
+func Test_NativeStores(t *testing.T) {
+	t.Run("unset", func(t *testing.T) {
+		t.Setenv(DatabaseProviderEnvVar, "")
+		stores, err := NativeStores(t.Context(), "kind-radius", DefaultNamespace)
+		require.NoError(t, err)
+		require.Nil(t, stores)
+	})
+
+	t.Run("postgresql", func(t *testing.T) {
+		t.Setenv(DatabaseProviderEnvVar, "postgresql")
+		t.Setenv(DatabaseURLEnvVar, "postgresql://radius@localhost:5432/{database}?sslmode=disable")
+		stores, err := NativeStores(t.Context(), "kind-radius", DefaultNamespace)
+		require.NoError(t, err)
+		require.Len(t, stores, len(Databases))
+		for i, db := range Databases {
+			require.Equal(t, db, stores[i].Name)
+		}
+	})
+
+	t.Run("postgresql without url", func(t *testing.T) {
+		t.Setenv(DatabaseProviderEnvVar, "postgresql")
+		t.Setenv(DatabaseURLEnvVar, "")
+		_, err := NativeStores(t.Context(), "kind-radius", DefaultNamespace)
+		require.ErrorContains(t, err, DatabaseURLEnvVar+" is required")
+	})
+
+	t.Run("postgresql without placeholder", func(t *testing.T) {
+		t.Setenv(DatabaseProviderEnvVar, "postgresql")
+		t.Setenv(DatabaseURLEnvVar, "postgresql://radius@localhost:5432/ucp")
+		_, err := NativeStores(t.Context(), "kind-radius", DefaultNamespace)
+		require.ErrorContains(t, err, "placeholder")
+	})
+
+	t.Run("inmemory", func(t *testing.T) {
+		t.Setenv(DatabaseProviderEnvVar, "inmemory")
+		_, err := NativeStores(t.Context(), "kind-radius", DefaultNamespace)
+		require.ErrorContains(t, err, "does not hold durable state")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Setenv(DatabaseProviderEnvVar, "cosmosdb")
+		_, err := NativeStores(t.Context(), "kind-radius", DefaultNamespace)
+		require.ErrorContains(t, err, "expected postgresql or apiserver")
+	})
+}
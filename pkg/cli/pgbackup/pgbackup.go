@@ -21,6 +21,11 @@ limitations under the License.
 // PostgreSQL instance. When the control plane runs on an ephemeral cluster, that data is lost on
 // teardown. This package dumps each database to a plain SQL file (via "kubectl exec ... pg_dump")
 // and restores them (via "kubectl exec ... psql") so state survives across runs.
+//
+// When DatabaseProviderEnvVar is set, a native backup is used instead. It reads and writes every
+// object through database.Client in a portable JSON-lines format, so it needs neither kubectl nor
+// PostgreSQL tools and also works for an external PostgreSQL instance or the Kubernetes API server
+// provider.
 package pgbackup
 
 import (
@@ -257,6 +257,23 @@ func WithApplicationsServerNoError() corerpfake.ApplicationsServer {
 			}, nil)
 			return
 		},
+		UpdateConditions: func(
+			ctx context.Context,
+			rootScope string,
+			applicationName string,
+			body v20250801preview.UpdateConditionsRequest,
+			options *v20250801preview.ApplicationsClientUpdateConditionsOptions,
+		) (resp azfake.Responder[v20250801preview.ApplicationsClientUpdateConditionsResponse], errResp azfake.ErrorResponder) {
+			resp.SetResponse(http.StatusOK, v20250801preview.ApplicationsClientUpdateConditionsResponse{
+				ApplicationResource: v20250801preview.ApplicationResource{
+					Name: to.Ptr(applicationName),
+					Properties: &v20250801preview.ApplicationProperties{
+						Conditions: body.Conditions,
+					},
+				},
+			}, nil)
+			return
+		},
 	}
 }
 
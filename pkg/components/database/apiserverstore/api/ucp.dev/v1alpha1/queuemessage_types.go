@@ -37,6 +37,11 @@ type QueueMessageSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:PreserveUnknownFields
 	Data *runtime.RawExtension `json:"data"`
+
+	// DeadLetterAt represents the time when the message was moved to the dead-letter queue.
+	DeadLetterAt *metav1.Time `json:"deadLetterAt,omitempty"`
+	// LastError represents the error of the last attempt to process a dead-letter message.
+	LastError string `json:"lastError,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
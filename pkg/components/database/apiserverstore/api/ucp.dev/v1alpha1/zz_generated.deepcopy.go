@@ -92,6 +92,10 @@ func (in *QueueMessageSpec) DeepCopyInto(out *QueueMessageSpec) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DeadLetterAt != nil {
+		in, out := &in.DeadLetterAt, &out.DeadLetterAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueMessageSpec.
@@ -88,7 +88,15 @@ func NewAPIServerClient(client runtimeclient.Client, namespace string) *APIServe
 }
 
 var _ database.Client = (*APIServerClient)(nil)
+var _ database.Exporter = (*APIServerClient)(nil)
 
+// APIServerClient is a database client that stores objects as Kubernetes custom resources.
+//
+// Queries are limited by what the API server can select: the scope and resource type of a query are pushed into
+// the List call as label selectors, but filters, RoutingScopePrefix, ordering and pagination are applied in memory.
+// Every page of a query lists all of the objects in the scope and resource type. Kubernetes continue tokens can't be
+// used to page, because objects are named by hash and can hold several entries, so their order is not the order
+// of the query. Use the PostgreSQL client for installations with many resources per scope.
 type APIServerClient struct {
 	client    runtimeclient.Client
 	namespace string
@@ -100,7 +108,8 @@ type APIServerClient struct {
 	waitChan <-chan struct{}
 }
 
-// Query searches for objects in the store that match the given query and returns them.
+// Query searches for objects in the store that match the given query and returns them. See APIServerClient for
+// the cost of queries.
 func (c *APIServerClient) Query(ctx context.Context, query database.Query, options ...database.QueryOptions) (*database.ObjectQueryResult, error) {
 	if ctx == nil {
 		return nil, &database.ErrInvalid{Message: "invalid argument. 'ctx' is required"}
@@ -121,7 +130,7 @@ func (c *APIServerClient) Query(ctx context.Context, query database.Query, optio
 		return nil, err
 	}
 
-	results := database.ObjectQueryResult{}
+	matches := []database.Object{}
 	for _, resource := range rs.Items {
 		for _, entry := range resource.Entries {
 			id, err := resources.Parse(entry.ID)
@@ -146,12 +155,12 @@ func (c *APIServerClient) Query(ctx context.Context, query database.Query, optio
 					continue
 				}
 
-				results.Items = append(results.Items, *converted)
+				matches = append(matches, *converted)
 			}
 		}
 	}
 
-	return &results, nil
+	return databaseutil.PageQuery(matches, database.NewQueryConfig(options...))
 }
 
 // Get retrieves an object from the store given its ID, or returns an error if the object does not exist or if an error occurs.
@@ -343,6 +352,84 @@ func (c *APIServerClient) Save(ctx context.Context, obj *database.Object, option
 	return err
 }
 
+// Export lists every Kubernetes object in the namespace and returns a page of the entries they hold.
+func (c *APIServerClient) Export(ctx context.Context, options ...database.QueryOptions) (*database.ObjectQueryResult, error) {
+	if ctx == nil {
+		return nil, &database.ErrInvalid{Message: "invalid argument. 'ctx' is required"}
+	}
+
+	rs := ucpv1alpha1.ResourceList{}
+	err := c.client.List(ctx, &rs, runtimeclient.InNamespace(c.namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []database.Object{}
+	for _, resource := range rs.Items {
+		for _, entry := range resource.Entries {
+			converted, err := readEntry(&entry)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, *converted)
+		}
+	}
+
+	return databaseutil.PageExport(objects, database.NewQueryConfig(options...))
+}
+
+// Import saves an object to the store, or replaces an existing object, keeping the ETag of obj.
+func (c *APIServerClient) Import(ctx context.Context, obj *database.Object) error {
+	if ctx == nil {
+		return &database.ErrInvalid{Message: "invalid argument. 'ctx' is required"}
+	}
+	if obj == nil {
+		return &database.ErrInvalid{Message: "invalid argument. 'obj' is required"}
+	}
+
+	id, err := resources.Parse(obj.ID)
+	if err != nil {
+		return &database.ErrInvalid{Message: "invalid argument. 'obj.ID' must be a valid resource id"}
+	}
+
+	return c.doWithRetry(func() (bool, error) {
+		name, resource, found, err := c.getResourceForSave(ctx, id)
+		if err != nil {
+			return false, err
+		}
+
+		resource.Name = name
+		resource.Namespace = c.namespace
+
+		converted, err := convert(obj)
+		if err != nil {
+			return false, err
+		}
+		if obj.ETag != "" {
+			converted.ETag = obj.ETag
+		}
+
+		index := findIndex(&resource, id)
+		if index == nil {
+			resource.Entries = append(resource.Entries, *converted)
+		} else {
+			resource.Entries[*index] = *converted
+		}
+
+		resource.Labels = assignLabels(&resource)
+
+		if found {
+			err = c.client.Update(ctx, &resource)
+		} else {
+			err = c.client.Create(ctx, &resource)
+		}
+		if err != nil && (apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)) {
+			return true, err // Retry this!
+		}
+		return false, err
+	})
+}
+
 func (c *APIServerClient) doWithRetry(action func() (bool, error)) error {
 	for range RetryCount {
 		retryable, err := action()
@@ -33,6 +33,7 @@ import (
 	ucpv1alpha1 "github.com/radius-project/radius/pkg/components/database/apiserverstore/api/ucp.dev/v1alpha1"
 	"github.com/radius-project/radius/pkg/ucp/resources"
 	"github.com/radius-project/radius/pkg/ucp/util/etag"
+	"github.com/radius-project/radius/test/k8sutil"
 	"github.com/radius-project/radius/test/ucp/kubeenv"
 	shared "github.com/radius-project/radius/test/ucp/storetest"
 )
@@ -130,6 +131,9 @@ func Test_APIServer_Client(t *testing.T) {
 
 	// The actual test logic lives in a shared package, we're just doing the setup here.
 	shared.RunTest(t, client, clear)
+	shared.RunQueryTest(t, client, clear)
+	shared.RunExportTest(t, client, clear)
+	shared.RunWatchTest(t, client, clear)
 
 	// The APIServer implementation is complex enough that we have some of our tests in addition
 	// to the standard suite.
@@ -697,6 +701,67 @@ func Test_APIServer_Client(t *testing.T) {
 	})
 }
 
+// Test_APIServer_Query runs the shared query tests against a fake Kubernetes client so they do not
+// require the Kubernetes test environment binaries.
+func Test_APIServer_Query(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ucpv1alpha1.AddToScheme(scheme))
+
+	ns := "radius-test"
+	rc := k8sutil.NewFakeKubeClient(scheme)
+	client := NewAPIServerClient(rc, ns)
+
+	clear := func(t *testing.T) {
+		err := rc.DeleteAllOf(t.Context(), &ucpv1alpha1.Resource{}, runtimeclient.InNamespace(ns))
+		require.NoError(t, err)
+	}
+
+	shared.RunQueryTest(t, client, clear)
+}
+
+// Test_APIServer_Export runs the shared export tests against a fake Kubernetes client so they do not
+// require the Kubernetes test environment binaries.
+func Test_APIServer_Export(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ucpv1alpha1.AddToScheme(scheme))
+
+	ns := "radius-test"
+	rc := k8sutil.NewFakeKubeClient(scheme)
+	client := NewAPIServerClient(rc, ns)
+
+	clear := func(t *testing.T) {
+		err := rc.DeleteAllOf(t.Context(), &ucpv1alpha1.Resource{}, runtimeclient.InNamespace(ns))
+		require.NoError(t, err)
+	}
+
+	shared.RunExportTest(t, client, clear)
+}
+
+// Test_APIServer_Watch runs the shared watch tests against a fake Kubernetes client so they do not
+// require the Kubernetes test environment binaries.
+func Test_APIServer_Watch(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ucpv1alpha1.AddToScheme(scheme))
+
+	ns := "radius-test"
+	rc := k8sutil.NewFakeKubeClient(scheme)
+	client := NewAPIServerClient(rc, ns)
+
+	clear := func(t *testing.T) {
+		err := rc.DeleteAllOf(t.Context(), &ucpv1alpha1.Resource{}, runtimeclient.InNamespace(ns))
+		require.NoError(t, err)
+	}
+
+	shared.RunWatchTest(t, client, clear)
+}
+
+func Test_APIServer_Watch_NotSupported(t *testing.T) {
+	client := NewAPIServerClient(struct{ runtimeclient.Client }{}, "radius-test")
+
+	_, err := client.Watch(t.Context(), database.Query{RootScope: shared.ResourceGroup1Scope, ResourceType: shared.ResourceType1})
+	require.ErrorContains(t, err, "does not support watch")
+}
+
 func Test_AssignLabels_Resource_NoConflicts(t *testing.T) {
 	resource := ucpv1alpha1.Resource{
 		Entries: []ucpv1alpha1.ResourceEntry{
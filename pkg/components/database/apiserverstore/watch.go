@@ -0,0 +1,208 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserverstore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/radius-project/radius/pkg/components/database"
+	ucpv1alpha1 "github.com/radius-project/radius/pkg/components/database/apiserverstore/api/ucp.dev/v1alpha1"
+	"github.com/radius-project/radius/pkg/components/database/databaseutil"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ database.Watcher = (*APIServerClient)(nil)
+
+// Watch implements database.Watcher using a Kubernetes watch of the objects selected by the labels of the
+// query. The Kubernetes client must implement runtimeclient.WithWatch.
+//
+// A Kubernetes object holds several resources when their names collide, so the changes to the resources
+// are computed by comparing the entries of each object with the entries seen before.
+func (c *APIServerClient) Watch(ctx context.Context, query database.Query) (<-chan database.WatchEvent, error) {
+	if ctx == nil {
+		return nil, &database.ErrInvalid{Message: "invalid argument. 'ctx' is required"}
+	}
+	err := query.Validate()
+	if err != nil {
+		return nil, &database.ErrInvalid{Message: fmt.Sprintf("invalid argument. Query is invalid: %s", err.Error())}
+	}
+
+	client, ok := c.client.(runtimeclient.WithWatch)
+	if !ok {
+		return nil, fmt.Errorf("the Kubernetes client %T does not support watch", c.client)
+	}
+
+	selector, err := createLabelSelector(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// List the objects first, so that the entries that exist when the watch starts are not reported as
+	// created. The watch starts at the resource version of the list so that no change is missed.
+	rs := ucpv1alpha1.ResourceList{}
+	err = client.List(ctx, &rs, runtimeclient.InNamespace(c.namespace), runtimeclient.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]map[string]database.Metadata{}
+	for i := range rs.Items {
+		_, known[rs.Items[i].Name], err = diffEntries(&rs.Items[i], nil, matchingEntries(ctx, &rs.Items[i], query), query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := client.Watch(ctx, &ucpv1alpha1.ResourceList{},
+		runtimeclient.InNamespace(c.namespace),
+		runtimeclient.MatchingLabelsSelector{Selector: selector},
+		&runtimeclient.ListOptions{Raw: &v1.ListOptions{ResourceVersion: rs.ResourceVersion}})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan database.WatchEvent)
+	go func() {
+		defer close(events)
+		defer w.Stop()
+
+		logger := ucplog.FromContextOrDiscard(ctx)
+		for {
+			var event watch.Event
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-w.ResultChan():
+				if !ok {
+					return
+				}
+				event = e
+			}
+
+			if event.Type == watch.Error {
+				logger.Info("the watch of the resources has failed", "status", event.Object)
+				return
+			}
+
+			resource, ok := event.Object.(*ucpv1alpha1.Resource)
+			if !ok {
+				// Bookmarks do not carry changes.
+				continue
+			}
+
+			current := map[string]database.Metadata{}
+			if event.Type != watch.Deleted {
+				current = matchingEntries(ctx, resource, query)
+			}
+
+			changes, matched, err := diffEntries(resource, known[resource.Name], current, query)
+			if err != nil {
+				logger.Error(err, "failed to read the changes of the resources", "name", resource.Name)
+				return
+			}
+
+			if event.Type == watch.Deleted {
+				delete(known, resource.Name)
+			} else {
+				known[resource.Name] = matched
+			}
+
+			for _, change := range changes {
+				select {
+				case events <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// matchingEntries returns the ids and ETags of the entries of resource that match query, keyed by their
+// lowercase ids. Filters are not applied.
+func matchingEntries(ctx context.Context, resource *ucpv1alpha1.Resource, query database.Query) map[string]database.Metadata {
+	entries := map[string]database.Metadata{}
+	for _, entry := range resource.Entries {
+		id, err := resources.Parse(entry.ID)
+		if err != nil {
+			// Ignore invalid IDs like queries do.
+			logger := ucplog.FromContextOrDiscard(ctx)
+			logger.Error(err, "found an invalid resource id as part of a watch", "name", resource.Name, "namespace", resource.Namespace)
+			continue
+		}
+
+		if databaseutil.IDMatchesQuery(id, query) {
+			entries[strings.ToLower(entry.ID)] = database.Metadata{ID: entry.ID, ETag: entry.ETag}
+		}
+	}
+
+	return entries
+}
+
+// diffEntries returns the events for the changes from the previous entries of resource to its current entries,
+// and the current entries that match the filters of query. previous only holds entries that matched the filters,
+// so that deletions are filtered like the other changes.
+func diffEntries(resource *ucpv1alpha1.Resource, previous map[string]database.Metadata, current map[string]database.Metadata, query database.Query) ([]database.WatchEvent, map[string]database.Metadata, error) {
+	events := []database.WatchEvent{}
+	matched := map[string]database.Metadata{}
+	for _, entry := range resource.Entries {
+		key := strings.ToLower(entry.ID)
+		metadata, ok := current[key]
+		if !ok {
+			continue
+		}
+
+		eventType := database.EventTypeCreated
+		if old, ok := previous[key]; ok && old.ETag == metadata.ETag {
+			matched[key] = metadata
+			continue
+		} else if ok {
+			eventType = database.EventTypeUpdated
+		}
+
+		obj, err := readEntry(&entry)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		match, err := obj.MatchesFilters(query.Filters)
+		if err != nil {
+			return nil, nil, err
+		} else if !match {
+			continue
+		}
+
+		matched[key] = metadata
+		events = append(events, database.WatchEvent{Type: eventType, Object: *obj})
+	}
+
+	for key, metadata := range previous {
+		if _, ok := current[key]; !ok {
+			events = append(events, database.WatchEvent{Type: database.EventTypeDeleted, Object: database.Object{Metadata: metadata}})
+		}
+	}
+
+	return events, matched, nil
+}
@@ -53,7 +53,9 @@ var fieldRegex = regexp.MustCompile(fmt.Sprintf(`^(%s)(\.%s)*$`, jsonPropertyPat
 type Client interface {
 	// Query executes a query against the data store and returns the results.
 	//
-	// Queries must provide a root scope and a resource type. Other fields are optional.
+	// Queries must provide a root scope and a resource type. Other fields are optional. Results are returned in
+	// pages of at most MaxQueryItemCount items, in the order specified by WithOrderBy. When more results are
+	// available, the result includes a PaginationToken to pass to WithPaginationToken to continue the query.
 	Query(ctx context.Context, query Query, options ...QueryOptions) (*ObjectQueryResult, error)
 
 	// Get retrieves a single resource from the data store by its resource id.
@@ -146,7 +148,7 @@ func (q Query) Validate() error {
 	}
 
 	for _, filter := range q.Filters {
-		err = errors.Join(filter.Validate())
+		err = errors.Join(err, filter.Validate())
 	}
 
 	return err
@@ -162,11 +164,33 @@ type QueryFilter struct {
 	//	- "properties.application"
 	Field string
 
-	// Value specifies the value to filter. The value must be a string and will be
-	// compared case-insentively with the property value.
+	// Operator specifies how the property value is compared. The zero value is FilterOperatorEqual.
+	Operator FilterOperator
+
+	// Value specifies the value to filter. The value must be a string and is compared with
+	// the property value, which must also be a string.
 	Value string
+
+	// Values specifies the values to filter with FilterOperatorIn.
+	Values []string
 }
 
+// FilterOperator is the comparison applied by a QueryFilter.
+type FilterOperator string
+
+const (
+	// FilterOperatorEqual matches string properties equal to Value.
+	FilterOperatorEqual FilterOperator = "eq"
+	// FilterOperatorNotEqual matches properties that are not a string equal to Value, including missing properties.
+	FilterOperatorNotEqual FilterOperator = "ne"
+	// FilterOperatorPrefix matches string properties that start with Value.
+	FilterOperatorPrefix FilterOperator = "prefix"
+	// FilterOperatorIn matches string properties equal to one of Values.
+	FilterOperatorIn FilterOperator = "in"
+	// FilterOperatorExists matches properties that exist and are not null. Value is ignored.
+	FilterOperatorExists FilterOperator = "exists"
+)
+
 // Validate validates the QueryFilter.
 func (f QueryFilter) Validate() error {
 	var err error
@@ -178,7 +202,39 @@ func (f QueryFilter) Validate() error {
 		err = errors.Join(err, &ErrInvalid{Message: fmt.Sprintf("Field is invalid in filter: %+v", f)})
 	}
 
+	switch f.Operator {
+	case "", FilterOperatorEqual, FilterOperatorNotEqual, FilterOperatorPrefix, FilterOperatorExists:
+	case FilterOperatorIn:
+		if len(f.Values) == 0 {
+			err = errors.Join(err, &ErrInvalid{Message: fmt.Sprintf("Values are required in filter: %+v", f)})
+		}
+	default:
+		err = errors.Join(err, &ErrInvalid{Message: fmt.Sprintf("Operator is invalid in filter: %+v", f)})
+	}
+
 	// Value can be blank. If it is blank, the filter will match the empty string in the target property.
 
 	return err
 }
+
+// QueryOrder specifies the order of the results of a query.
+type QueryOrder struct {
+	// Field specifies the property to order by, with the same syntax as QueryFilter.Field. Results with the same
+	// value are ordered by id. When Field is empty, the order is chosen by the data store but is stable.
+	//
+	// The value of the property is compared as a string. Numbers and booleans are compared by their JSON
+	// text, and results where the property is missing or holds an object come first.
+	Field string
+
+	// Descending reverses the order of the values of Field.
+	Descending bool
+}
+
+// Validate validates the QueryOrder.
+func (o QueryOrder) Validate() error {
+	if o.Field != "" && !fieldRegex.Match([]byte(o.Field)) {
+		return &ErrInvalid{Message: fmt.Sprintf("Field is invalid in order: %+v", o)}
+	}
+
+	return nil
+}
@@ -106,6 +106,26 @@ func TestQueryFilter_Validate(t *testing.T) {
 			filter:  QueryFilter{Field: "properties.application.some.other.thing", Value: "some value"},
 			wantErr: false,
 		},
+		{
+			name:    "Operator is invalid",
+			filter:  QueryFilter{Field: "properties.application", Operator: "like", Value: "some value"},
+			wantErr: true,
+		},
+		{
+			name:    "In without values",
+			filter:  QueryFilter{Field: "properties.application", Operator: FilterOperatorIn},
+			wantErr: true,
+		},
+		{
+			name:    "In with values",
+			filter:  QueryFilter{Field: "properties.application", Operator: FilterOperatorIn, Values: []string{"a", "b"}},
+			wantErr: false,
+		},
+		{
+			name:    "Exists without value",
+			filter:  QueryFilter{Field: "properties.application", Operator: FilterOperatorExists},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -119,3 +139,38 @@ func TestQueryFilter_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryOrder_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   QueryOrder
+		wantErr bool
+	}{
+		{
+			name:    "Field is empty",
+			order:   QueryOrder{},
+			wantErr: false,
+		},
+		{
+			name:    "Field is invalid",
+			order:   QueryOrder{Field: "invalid[field]"},
+			wantErr: true,
+		},
+		{
+			name:    "Field is valid",
+			order:   QueryOrder{Field: "properties.application", Descending: true},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.order.Validate()
+			if tt.wantErr {
+				require.Error(t, err, "expected an error but got none")
+			} else {
+				require.NoError(t, err, "expected no error but got one")
+			}
+		})
+	}
+}
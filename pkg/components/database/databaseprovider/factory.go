@@ -24,6 +24,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	store "github.com/radius-project/radius/pkg/components/database"
 	"github.com/radius-project/radius/pkg/components/database/apiserverstore"
@@ -72,7 +73,7 @@ func initAPIServerClient(ctx context.Context, opt Options) (store.Client, error)
 		Scheme: scheme,
 	}
 
-	rc, err := runtimeclient.New(cfg, options)
+	rc, err := runtimeclient.NewWithWatch(cfg, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize APIServer client: %w", err)
 	}
@@ -89,11 +90,11 @@ func initInMemoryClient(ctx context.Context, opt Options) (store.Client, error)
 // envVarPattern matches ${VAR_NAME} references for expansion against environment variables.
 var envVarPattern = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
 
-// expandEnvURL replaces ${VAR} references in the connection URL with the values of the
+// ExpandEnvURL replaces ${VAR} references in the connection URL with the values of the
 // corresponding environment variables. It returns an error if any referenced variable is unset,
 // so a missing or mistyped variable fails fast with a clear message instead of silently producing
 // a malformed connection string.
-func expandEnvURL(url string) (string, error) {
+func ExpandEnvURL(url string) (string, error) {
 	var missing []string
 	expanded := envVarPattern.ReplaceAllStringFunc(url, func(match string) string {
 		varName := envVarPattern.FindStringSubmatch(match)[1]
@@ -109,13 +110,23 @@ func expandEnvURL(url string) (string, error) {
 	return expanded, nil
 }
 
+// PostgreSQLURL returns the expanded connection URL of the PostgreSQL database configured by opt. It returns an
+// empty string when opt configures another provider.
+func PostgreSQLURL(opt Options) (string, error) {
+	if opt.Provider != TypePostgreSQL || opt.PostgreSQL.URL == "" {
+		return "", nil
+	}
+
+	return ExpandEnvURL(opt.PostgreSQL.URL)
+}
+
 // initPostgreSQLClient creates a new PostgreSQL store client.
 func initPostgreSQLClient(ctx context.Context, opt Options) (store.Client, error) {
 	if opt.PostgreSQL.URL == "" {
 		return nil, errors.New("failed to initialize PostgreSQL client: URL is required")
 	}
 
-	url, err := expandEnvURL(opt.PostgreSQL.URL)
+	url, err := ExpandEnvURL(opt.PostgreSQL.URL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize PostgreSQL client: %w", err)
 	}
@@ -125,5 +136,9 @@ func initPostgreSQLClient(ctx context.Context, opt Options) (store.Client, error
 		return nil, fmt.Errorf("failed to initialize PostgreSQL client: %w", err)
 	}
 
-	return postgres.NewPostgresClient(pool), nil
+	return postgres.NewPostgresClient(pool, postgres.Options{
+		Connect: func(ctx context.Context) (postgres.ListenerAPI, error) {
+			return pgx.Connect(ctx, url)
+		},
+	}), nil
 }
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package databaseutil
+
+import (
+	"encoding/base64"
+	"sort"
+	"strings"
+
+	"github.com/radius-project/radius/pkg/components/database"
+)
+
+// CreateExportToken creates the pagination token for database.Exporter.Export that continues
+// after the object stored under key.
+func CreateExportToken(key string) string {
+	return base64.StdEncoding.EncodeToString([]byte(key))
+}
+
+// ParseExportToken returns the storage key encoded in a pagination token created by CreateExportToken.
+func ParseExportToken(token string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return "", &database.ErrInvalid{Message: "invalid argument. 'PaginationToken' is invalid."}
+	}
+	return string(data), nil
+}
+
+// ExportKey is the storage key used to order objects for export. It is the lowercased object ID,
+// so the order is the same for every provider.
+func ExportKey(id string) string {
+	return strings.ToLower(id)
+}
+
+// PageExport sorts objects by ExportKey and returns the page selected by config. It is used by
+// providers that hold the full object list in memory.
+func PageExport(objects []database.Object, config database.DatabaseOptions) (*database.ObjectQueryResult, error) {
+	sort.Slice(objects, func(i, j int) bool { return ExportKey(objects[i].ID) < ExportKey(objects[j].ID) })
+
+	start := 0
+	if config.PaginationToken != "" {
+		after, err := ParseExportToken(config.PaginationToken)
+		if err != nil {
+			return nil, err
+		}
+		start = sort.Search(len(objects), func(i int) bool { return ExportKey(objects[i].ID) > after })
+	}
+
+	result := &database.ObjectQueryResult{Items: objects[start:]}
+	if config.MaxQueryItemCount > 0 && len(result.Items) > config.MaxQueryItemCount {
+		result.Items = result.Items[:config.MaxQueryItemCount]
+		result.PaginationToken = CreateExportToken(ExportKey(result.Items[len(result.Items)-1].ID))
+	}
+	return result, nil
+}
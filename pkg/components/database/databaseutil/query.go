@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package databaseutil
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/radius-project/radius/pkg/components/database"
+)
+
+// QueryToken is the position encoded in the pagination tokens of queries ordered by key. Queries continue
+// after the object with the Key and ID of the token.
+type QueryToken struct {
+	// Key is the sort key of the last object of the page. See database.Object.SortKey.
+	Key string `json:"k"`
+
+	// ID identifies the last object of the page and orders objects with the same key. Its format is chosen by
+	// the data store. PageQuery uses the lowercased id.
+	ID string `json:"id"`
+}
+
+// CreateQueryToken creates the pagination token that continues a query after token.
+func CreateQueryToken(token QueryToken) (string, error) {
+	b, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// ParseQueryToken returns the position encoded in a pagination token created by CreateQueryToken.
+func ParseQueryToken(token string) (*QueryToken, error) {
+	data, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return nil, &database.ErrInvalid{Message: "invalid argument. 'PaginationToken' is invalid."}
+	}
+
+	parsed := &QueryToken{}
+	if err := json.Unmarshal(data, parsed); err != nil {
+		return nil, &database.ErrInvalid{Message: "invalid argument. 'PaginationToken' is invalid."}
+	}
+
+	return parsed, nil
+}
+
+// PageQuery orders the results of a query as config.OrderBy requires and returns the page selected by config.
+// It is used by providers that hold the full list of results in memory. Results with the same sort key are
+// ordered by their lowercased id, so the order does not depend on the order of objects.
+func PageQuery(objects []database.Object, config database.DatabaseOptions) (*database.ObjectQueryResult, error) {
+	if err := config.OrderBy.Validate(); err != nil {
+		return nil, err
+	}
+
+	positions := make([]QueryToken, len(objects))
+	for i := range objects {
+		position := QueryToken{ID: strings.ToLower(objects[i].ID)}
+		if config.OrderBy.Field != "" {
+			key, err := objects[i].SortKey(config.OrderBy.Field)
+			if err != nil {
+				return nil, err
+			}
+			position.Key = key
+		}
+		positions[i] = position
+	}
+
+	compare := func(a QueryToken, b QueryToken) int {
+		c := strings.Compare(a.Key, b.Key)
+		if config.OrderBy.Descending {
+			c = -c
+		}
+		if c == 0 {
+			c = strings.Compare(a.ID, b.ID)
+		}
+		return c
+	}
+
+	indices := make([]int, len(objects))
+	for i := range indices {
+		indices[i] = i
+	}
+	slices.SortFunc(indices, func(a int, b int) int { return compare(positions[a], positions[b]) })
+
+	start := 0
+	if config.PaginationToken != "" {
+		after, err := ParseQueryToken(config.PaginationToken)
+		if err != nil {
+			return nil, err
+		}
+		start, _ = slices.BinarySearchFunc(indices, *after, func(i int, target QueryToken) int {
+			if compare(positions[i], target) <= 0 {
+				return -1
+			}
+			return 1
+		})
+	}
+
+	end := len(indices)
+	if config.MaxQueryItemCount > 0 && end-start > config.MaxQueryItemCount {
+		end = start + config.MaxQueryItemCount
+	}
+
+	result := &database.ObjectQueryResult{}
+	for _, i := range indices[start:end] {
+		result.Items = append(result.Items, objects[i])
+	}
+
+	if end < len(indices) {
+		token, err := CreateQueryToken(positions[indices[end-1]])
+		if err != nil {
+			return nil, err
+		}
+		result.PaginationToken = token
+	}
+
+	return result, nil
+}
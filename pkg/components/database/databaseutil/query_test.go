@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package databaseutil
+
+import (
+	"testing"
+
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QueryToken(t *testing.T) {
+	token, err := CreateQueryToken(QueryToken{Key: "cool", ID: "/planes/radius/local/"})
+	require.NoError(t, err)
+
+	parsed, err := ParseQueryToken(token)
+	require.NoError(t, err)
+	require.Equal(t, &QueryToken{Key: "cool", ID: "/planes/radius/local/"}, parsed)
+
+	_, err = ParseQueryToken("not-a-token")
+	require.ErrorIs(t, err, &database.ErrInvalid{})
+}
+
+func Test_PageQuery(t *testing.T) {
+	objects := []database.Object{
+		{Metadata: database.Metadata{ID: "/c"}, Data: map[string]any{"name": "b"}},
+		{Metadata: database.Metadata{ID: "/B"}, Data: map[string]any{"name": "a"}},
+		{Metadata: database.Metadata{ID: "/a"}, Data: map[string]any{"name": "b"}},
+	}
+
+	ids := func(result *database.ObjectQueryResult) []string {
+		ids := []string{}
+		for _, item := range result.Items {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	t.Run("ordered by id", func(t *testing.T) {
+		result, err := PageQuery(objects, database.NewQueryConfig())
+		require.NoError(t, err)
+		require.Equal(t, []string{"/a", "/B", "/c"}, ids(result))
+		require.Empty(t, result.PaginationToken)
+	})
+
+	t.Run("ordered by field", func(t *testing.T) {
+		order := database.WithOrderBy(database.QueryOrder{Field: "name", Descending: true})
+
+		result, err := PageQuery(objects, database.NewQueryConfig(order, database.WithMaxQueryItemCount(2)))
+		require.NoError(t, err)
+		require.Equal(t, []string{"/a", "/c"}, ids(result))
+		require.NotEmpty(t, result.PaginationToken)
+
+		result, err = PageQuery(objects, database.NewQueryConfig(order, database.WithMaxQueryItemCount(2), database.WithPaginationToken(result.PaginationToken)))
+		require.NoError(t, err)
+		require.Equal(t, []string{"/B"}, ids(result))
+		require.Empty(t, result.PaginationToken)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		result, err := PageQuery(nil, database.NewQueryConfig(database.WithMaxQueryItemCount(2)))
+		require.NoError(t, err)
+		require.Empty(t, result.Items)
+		require.Empty(t, result.PaginationToken)
+	})
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultExportPageSize is the page size used by ExportAll.
+const DefaultExportPageSize = 500
+
+// Exporter is implemented by Clients that can enumerate and restore every object they store,
+// independent of scope and resource type. It is used to back up a data store and to move data
+// between providers, so unlike Save it preserves the ETag of each object.
+//
+// Exporter is optional. Use a type assertion on a Client to check for support.
+type Exporter interface {
+	// Export returns a page of the objects in the data store, including scopes, ordered by ID.
+	// Use WithMaxQueryItemCount to bound the page size and WithPaginationToken with the
+	// PaginationToken of the previous page to continue. The PaginationToken is empty on the last page.
+	Export(ctx context.Context, options ...QueryOptions) (*ObjectQueryResult, error)
+
+	// Import stores obj exactly as given, including its ETag, creating the object or replacing
+	// an existing object with the same ID. When obj has no ETag, one is computed as Save would.
+	// Import does not perform optimistic concurrency checks.
+	Import(ctx context.Context, obj *Object) error
+}
+
+// ExportAll calls fn for every object in the data store, in the order returned by Export. It
+// returns an error if client does not implement Exporter.
+func ExportAll(ctx context.Context, client Client, fn func(obj *Object) error) error {
+	exporter, ok := client.(Exporter)
+	if !ok {
+		return fmt.Errorf("database client %T does not support export", client)
+	}
+
+	token := ""
+	for {
+		options := []QueryOptions{WithMaxQueryItemCount(DefaultExportPageSize)}
+		if token != "" {
+			options = append(options, WithPaginationToken(token))
+		}
+
+		result, err := exporter.Export(ctx, options...)
+		if err != nil {
+			return err
+		}
+
+		for i := range result.Items {
+			if err := fn(&result.Items[i]); err != nil {
+				return err
+			}
+		}
+
+		if result.PaginationToken == "" {
+			return nil
+		}
+		token = result.PaginationToken
+	}
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package database
 
 import (
+	"encoding/json"
 	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -28,6 +30,51 @@ func (o Object) MatchesFilters(filters []QueryFilter) (bool, error) {
 		return true, nil
 	}
 
+	data, err := o.filterData()
+	if err != nil {
+		return false, err
+	}
+
+	for _, filter := range filters {
+		value, ok := lookupField(data, filter.Field)
+		if !filter.matches(value, ok) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// SortKey returns the value of field used to order the object in queries. Strings are used as-is, numbers and
+// booleans are formatted as JSON. The key is empty when the field does not exist or holds another kind of value.
+func (o Object) SortKey(field string) (string, error) {
+	data, err := o.filterData()
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := lookupField(data, field)
+	if !ok {
+		return "", nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		b, err := json.Marshal(value.Interface())
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	default:
+		return "", nil
+	}
+}
+
+// filterData returns the data of the object as a map with string keys.
+func (o Object) filterData() (any, error) {
 	data := o.Data
 	if data == nil {
 		// Treat nil as "empty" data
@@ -36,45 +83,68 @@ func (o Object) MatchesFilters(filters []QueryFilter) (bool, error) {
 		reflect.TypeOf(o.Data).Key().Kind() != reflect.String {
 		// It's most likely for our use case that the data is a map[string]interface{}. However, if it's not
 		// then we need to convert This is basically just here for safety and completeness.
-		data = map[string]any{}
-		err := o.As(&data)
+		converted := map[string]any{}
+		err := o.As(&converted)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
+		data = converted
 	}
 
-	for _, filter := range filters {
-		value := reflect.ValueOf(data)
-		fields := strings.Split(filter.Field, ".")
-		for i, field := range fields {
-			value = value.MapIndex(reflect.ValueOf(field))
-			if !value.IsValid() {
-				// Field doesn't exist, no match
-				return false, nil
-			}
-
-			if i < len(fields)-1 {
-				// Need to go further into the nested fields
-				value = reflect.ValueOf(value.Interface())
-			}
-		}
-		comparator := reflect.ValueOf(filter.Value)
+	return data, nil
+}
 
-		if value.Type().Kind() == reflect.Interface {
+// lookupField returns the value of the '.' separated field path in data. It returns false if the field does not
+// exist or is null.
+func lookupField(data any, field string) (reflect.Value, bool) {
+	value := reflect.ValueOf(data)
+	for _, segment := range strings.Split(field, ".") {
+		if value.Kind() == reflect.Interface {
 			// Unwrap interface{}
-			value = reflect.ValueOf(value.Interface())
+			value = value.Elem()
 		}
 
-		if value.Type().Kind() != reflect.String {
-			// not a string, can't compare!
-			return false, nil
+		if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+			// Can't go further into a value that isn't an object.
+			return reflect.Value{}, false
 		}
 
-		if value.String() != comparator.String() {
-			// not the same value!
-			return false, nil
+		value = value.MapIndex(reflect.ValueOf(segment))
+		if !value.IsValid() {
+			// Field doesn't exist, no match
+			return reflect.Value{}, false
 		}
 	}
 
-	return true, nil
+	if value.Kind() == reflect.Interface {
+		// Unwrap interface{}
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		// The field is null.
+		return reflect.Value{}, false
+	}
+
+	return value, true
+}
+
+// matches returns true if the value of the field of the filter matches the filter. ok is false if the field does
+// not exist.
+func (f QueryFilter) matches(value reflect.Value, ok bool) bool {
+	if f.Operator == FilterOperatorExists {
+		return ok
+	}
+
+	isString := ok && value.Kind() == reflect.String
+	switch f.Operator {
+	case FilterOperatorNotEqual:
+		return !isString || value.String() != f.Value
+	case FilterOperatorPrefix:
+		return isString && strings.HasPrefix(value.String(), f.Value)
+	case FilterOperatorIn:
+		return isString && slices.Contains(f.Values, value.String())
+	default:
+		return isString && value.String() == f.Value
+	}
 }
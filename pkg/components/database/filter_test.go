@@ -119,6 +119,80 @@ func Test_MatchesFilters(t *testing.T) {
 			Filters:       []QueryFilter{{Field: "value", Value: "hot"}},
 			ExpectedMatch: false,
 		},
+		{
+			Description:   "nested_field_of_non_object",
+			Obj:           &Object{Data: map[string]any{"properties": "cool"}},
+			Filters:       []QueryFilter{{Field: "properties.value", Value: "cool"}},
+			ExpectedMatch: false,
+		},
+
+		// Operators
+		{
+			Description:   "not_equal_match",
+			Obj:           &Object{Data: map[string]any{"value": "cool"}},
+			Filters:       []QueryFilter{{Field: "value", Operator: FilterOperatorNotEqual, Value: "hot"}},
+			ExpectedMatch: true,
+		},
+		{
+			Description:   "not_equal_not_match",
+			Obj:           &Object{Data: map[string]any{"value": "cool"}},
+			Filters:       []QueryFilter{{Field: "value", Operator: FilterOperatorNotEqual, Value: "cool"}},
+			ExpectedMatch: false,
+		},
+		{
+			Description:   "not_equal_match_missing",
+			Obj:           &Object{Data: map[string]any{}},
+			Filters:       []QueryFilter{{Field: "value", Operator: FilterOperatorNotEqual, Value: "cool"}},
+			ExpectedMatch: true,
+		},
+		{
+			Description:   "not_equal_match_wrong_type",
+			Obj:           &Object{Data: map[string]any{"value": 3}},
+			Filters:       []QueryFilter{{Field: "value", Operator: FilterOperatorNotEqual, Value: "3"}},
+			ExpectedMatch: true,
+		},
+		{
+			Description:   "prefix_match",
+			Obj:           &Object{Data: map[string]any{"value": "cool-beans"}},
+			Filters:       []QueryFilter{{Field: "value", Operator: FilterOperatorPrefix, Value: "cool-"}},
+			ExpectedMatch: true,
+		},
+		{
+			Description:   "prefix_not_match",
+			Obj:           &Object{Data: map[string]any{"value": "cool-beans"}},
+			Filters:       []QueryFilter{{Field: "value", Operator: FilterOperatorPrefix, Value: "beans"}},
+			ExpectedMatch: false,
+		},
+		{
+			Description:   "in_match",
+			Obj:           &Object{Data: map[string]any{"value": "cool"}},
+			Filters:       []QueryFilter{{Field: "value", Operator: FilterOperatorIn, Values: []string{"hot", "cool"}}},
+			ExpectedMatch: true,
+		},
+		{
+			Description:   "in_not_match",
+			Obj:           &Object{Data: map[string]any{"value": "cool"}},
+			Filters:       []QueryFilter{{Field: "value", Operator: FilterOperatorIn, Values: []string{"hot", "warm"}}},
+			ExpectedMatch: false,
+		},
+		{
+			Description:   "exists_match",
+			Obj:           &Object{Data: map[string]any{"properties": map[string]any{"value": 3}}},
+			Filters:       []QueryFilter{{Field: "properties.value", Operator: FilterOperatorExists}},
+			ExpectedMatch: true,
+		},
+		{
+			Description:   "exists_not_match_null",
+			Obj:           &Object{Data: map[string]any{"properties": map[string]any{"value": nil}}},
+			Filters:       []QueryFilter{{Field: "properties.value", Operator: FilterOperatorExists}},
+			ExpectedMatch: false,
+		},
+		{
+			Description:   "exists_not_match_missing",
+			Obj:           &Object{Data: map[string]any{"properties": map[string]any{}}},
+			Filters:       []QueryFilter{{Field: "properties.value", Operator: FilterOperatorExists}},
+			ExpectedMatch: false,
+		},
 	}
 
 	for _, testcase := range cases {
@@ -129,3 +203,33 @@ func Test_MatchesFilters(t *testing.T) {
 		})
 	}
 }
+
+func Test_SortKey(t *testing.T) {
+	obj := &Object{Data: map[string]any{
+		"name": "cool",
+		"properties": map[string]any{
+			"size":    10,
+			"enabled": true,
+			"nested":  map[string]any{"value": "cool"},
+		},
+	}}
+
+	cases := []struct {
+		Field    string
+		Expected string
+	}{
+		{Field: "name", Expected: "cool"},
+		{Field: "properties.size", Expected: "10"},
+		{Field: "properties.enabled", Expected: "true"},
+		{Field: "properties.nested", Expected: ""},
+		{Field: "properties.missing", Expected: ""},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.Field, func(t *testing.T) {
+			key, err := obj.SortKey(testcase.Field)
+			require.NoError(t, err)
+			require.Equal(t, testcase.Expected, key)
+		})
+	}
+}
@@ -31,6 +31,8 @@ import (
 )
 
 var _ database.Client = (*Client)(nil)
+var _ database.Exporter = (*Client)(nil)
+var _ database.Watcher = (*Client)(nil)
 
 // Client is an in-memory implementation of database.Client.
 type Client struct {
@@ -44,6 +46,9 @@ type Client struct {
 	//
 	// The Query method will iterate over all entries in the map to find the matching ones.
 	resources map[string]entry
+
+	// watchers is the set of active watches. Changes are published to them while holding the mutex.
+	watchers map[*watcher]struct{}
 }
 
 // entry stores the commonly-used fields (extracted from the resource ID) for comparison in queries.
@@ -84,6 +89,7 @@ func NewClient() *Client {
 	return &Client{
 		mutex:     sync.Mutex{},
 		resources: map[string]entry{},
+		watchers:  map[*watcher]struct{}{},
 	}
 }
 
@@ -162,6 +168,8 @@ func (c *Client) Delete(ctx context.Context, id string, options ...database.Dele
 
 	delete(c.resources, strings.ToLower(converted.String()))
 
+	c.publish(database.EventTypeDeleted, parsed, &entry.obj)
+
 	return nil
 }
 
@@ -179,7 +187,7 @@ func (c *Client) Query(ctx context.Context, query database.Query, options ...dat
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	result := &database.ObjectQueryResult{}
+	matches := []database.Object{}
 	for _, entry := range c.resources {
 		// Check root scope.
 		if query.ScopeRecursive && !strings.HasPrefix(entry.rootScope, databaseutil.NormalizePart(query.RootScope)) {
@@ -211,13 +219,22 @@ func (c *Client) Query(ctx context.Context, query database.Query, options ...dat
 			continue
 		}
 
+		matches = append(matches, entry.obj)
+	}
+
+	result, err := databaseutil.PageQuery(matches, database.NewQueryConfig(options...))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result.Items {
 		// Make a defensive copy so users can't modify the data in the store.
-		copy, err := entry.obj.DeepCopy()
+		copy, err := result.Items[i].DeepCopy()
 		if err != nil {
 			return nil, err
 		}
 
-		result.Items = append(result.Items, *copy)
+		result.Items[i] = *copy
 	}
 
 	return result, nil
@@ -277,6 +294,87 @@ func (c *Client) Save(ctx context.Context, obj *database.Object, options ...data
 
 	c.resources[strings.ToLower(converted.String())] = entry
 
+	eventType := database.EventTypeUpdated
+	if !ok {
+		eventType = database.EventTypeCreated
+	}
+
+	c.publish(eventType, parsed, &entry.obj)
+
+	return nil
+}
+
+// Export implements database.Exporter.
+func (c *Client) Export(ctx context.Context, options ...database.QueryOptions) (*database.ObjectQueryResult, error) {
+	if ctx == nil {
+		return nil, &database.ErrInvalid{Message: "invalid argument. 'ctx' is required"}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	objects := make([]database.Object, 0, len(c.resources))
+	for _, entry := range c.resources {
+		// Make a defensive copy so users can't modify the data in the store.
+		copy, err := entry.obj.DeepCopy()
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, *copy)
+	}
+
+	return databaseutil.PageExport(objects, database.NewQueryConfig(options...))
+}
+
+// Import implements database.Exporter.
+func (c *Client) Import(ctx context.Context, obj *database.Object) error {
+	if ctx == nil {
+		return &database.ErrInvalid{Message: "invalid argument. 'ctx' is required"}
+	}
+	if obj == nil {
+		return &database.ErrInvalid{Message: "invalid argument. 'obj' is required"}
+	}
+
+	parsed, err := resources.Parse(obj.ID)
+	if err != nil {
+		return &database.ErrInvalid{Message: "invalid argument. 'obj.ID' must be a valid resource id"}
+	}
+
+	converted, err := databaseutil.ConvertScopeIDToResourceID(parsed)
+	if err != nil {
+		return err
+	}
+
+	// Make a defensive copy so users can't modify the data in the store.
+	copy, err := obj.DeepCopy()
+	if err != nil {
+		return err
+	}
+	if copy.ETag == "" {
+		raw, err := json.Marshal(obj.Data)
+		if err != nil {
+			return err
+		}
+		copy.ETag = etag.New(raw)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	eventType := database.EventTypeCreated
+	if _, ok := c.resources[strings.ToLower(converted.String())]; ok {
+		eventType = database.EventTypeUpdated
+	}
+
+	c.resources[strings.ToLower(converted.String())] = entry{
+		obj:          *copy,
+		rootScope:    databaseutil.NormalizePart(converted.RootScope()),
+		resourceType: databaseutil.NormalizePart(converted.Type()),
+		routingScope: databaseutil.NormalizePart(converted.RoutingScope()),
+	}
+
+	c.publish(eventType, parsed, copy)
+
 	return nil
 }
 
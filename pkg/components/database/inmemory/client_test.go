@@ -31,4 +31,7 @@ func Test_InMemoryClient(t *testing.T) {
 
 	// The actual test logic lives in a shared package, we're just doing the setup here.
 	shared.RunTest(t, client, clear)
+	shared.RunQueryTest(t, client, clear)
+	shared.RunExportTest(t, client, clear)
+	shared.RunWatchTest(t, client, clear)
 }
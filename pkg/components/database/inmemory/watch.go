@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/databaseutil"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+// Watch implements database.Watcher.
+func (c *Client) Watch(ctx context.Context, query database.Query) (<-chan database.WatchEvent, error) {
+	if ctx == nil {
+		return nil, &database.ErrInvalid{Message: "invalid argument. 'ctx' is required"}
+	}
+
+	err := query.Validate()
+	if err != nil {
+		return nil, &database.ErrInvalid{Message: fmt.Sprintf("invalid argument. Query is invalid: %s", err.Error())}
+	}
+
+	w := &watcher{query: query, signal: make(chan struct{}, 1)}
+
+	c.mutex.Lock()
+	c.watchers[w] = struct{}{}
+	c.mutex.Unlock()
+
+	events := make(chan database.WatchEvent)
+	go func() {
+		defer close(events)
+		defer func() {
+			c.mutex.Lock()
+			delete(c.watchers, w)
+			c.mutex.Unlock()
+		}()
+
+		w.run(ctx, events)
+	}()
+
+	return events, nil
+}
+
+// publish sends the change of obj to the watches that match it. The caller must hold the mutex, so
+// that every watch receives the changes in the order they were made.
+//
+// The change has already been made when publish is called, so objects that can't be compared with the
+// filters of a watch are skipped instead of failing the operation.
+func (c *Client) publish(eventType database.EventType, id resources.ID, obj *database.Object) {
+	for w := range c.watchers {
+		if !databaseutil.IDMatchesQuery(id, w.query) {
+			continue
+		}
+
+		// Deleted objects are compared with the filters using their last state.
+		match, err := obj.MatchesFilters(w.query.Filters)
+		if err != nil || !match {
+			continue
+		}
+
+		event := database.WatchEvent{Type: eventType, Object: database.Object{Metadata: obj.Metadata}}
+		if eventType != database.EventTypeDeleted {
+			// Make a defensive copy so watchers can't modify the data in the store.
+			copy, err := obj.DeepCopy()
+			if err != nil {
+				continue
+			}
+			event.Object = *copy
+		}
+
+		w.push(event)
+	}
+}
+
+// watcher is a watch of the changes matching query. Changes are queued without blocking the client,
+// and sent to the channel of the watch by run.
+type watcher struct {
+	query database.Query
+
+	// mutex is used to synchronize access to pending.
+	mutex   sync.Mutex
+	pending []database.WatchEvent
+
+	// signal is notified when pending is not empty.
+	signal chan struct{}
+}
+
+func (w *watcher) push(event database.WatchEvent) {
+	w.mutex.Lock()
+	w.pending = append(w.pending, event)
+	w.mutex.Unlock()
+
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher) run(ctx context.Context, events chan<- database.WatchEvent) {
+	for {
+		w.mutex.Lock()
+		pending := w.pending
+		w.pending = nil
+		w.mutex.Unlock()
+
+		for _, event := range pending {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-w.signal:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -70,6 +70,9 @@ type DatabaseOptions struct {
 	// MaxQueryItemCount represents max items in query result.
 	MaxQueryItemCount int
 
+	// OrderBy represents the order of the query results.
+	OrderBy QueryOrder
+
 	// ETag represents the entity tag for optimistic consistency control.
 	ETag ETag
 }
@@ -106,6 +109,16 @@ func WithMaxQueryItemCount(maxcnt int) QueryOptions {
 	}
 }
 
+// WithOrderBy sets the order of the results of Query(). Pagination tokens must be used with the same order.
+func WithOrderBy(order QueryOrder) QueryOptions {
+	return &queryOptions{
+		fn: func(cfg DatabaseOptions) DatabaseOptions {
+			cfg.OrderBy = order
+			return cfg
+		},
+	}
+}
+
 // MutatingOptions
 type mutatingOptions struct {
 	fn func(DatabaseOptions) DatabaseOptions
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -44,16 +45,25 @@ type PostgresAPI interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 }
 
+// Options configures a PostgresClient.
+type Options struct {
+	// Connect opens a connection that is not shared with other queries. It is used to LISTEN for the
+	// notifications of Watch. Watch is not supported when Connect is nil.
+	Connect func(ctx context.Context) (ListenerAPI, error)
+}
+
 // NewPostgresClient creates a new PostgresClient.
-func NewPostgresClient(api PostgresAPI) *PostgresClient {
-	return &PostgresClient{api: api}
+func NewPostgresClient(api PostgresAPI, options Options) *PostgresClient {
+	return &PostgresClient{api: api, options: options}
 }
 
 var _ database.Client = (*PostgresClient)(nil)
+var _ database.Exporter = (*PostgresClient)(nil)
 
 // PostgresClient is a database client that uses Postgres as the backend.
 type PostgresClient struct {
-	api PostgresAPI
+	api     PostgresAPI
+	options Options
 }
 
 // Delete implements database.Client.
@@ -87,20 +97,27 @@ func (p *PostgresClient) Delete(ctx context.Context, id string, options ...datab
 	// We need different SQL for the case where an etag is provided vs not provided.
 	//
 	// The key behavior difference is that if an etag is provided, should report failure differently.
+	//
+	// Both queries notify NotificationChannel of the deletion in the same statement. See Watch.
 	sql := `
 WITH deleted AS (
 	DELETE FROM resources
 	WHERE id = $1
-	RETURNING id
+	RETURNING original_id, etag
+),
+notified AS (
+	SELECT pg_notify($2, json_build_object('type', 'Deleted', 'id', original_id, 'etag', etag)::text)
+	FROM deleted
 )
 SELECT
 CASE
 	WHEN EXISTS (SELECT 1 FROM deleted) THEN 'Success'
 	WHEN EXISTS (SELECT 1 FROM resources WHERE id = $1) THEN 'ErrConcurrency'
 	ELSE 'ErrNotFound'
-END AS result;`
+END AS result
+FROM (SELECT count(*) FROM notified) AS notifications;`
 
-	args := []any{databaseutil.NormalizePart(converted.String())}
+	args := []any{databaseutil.NormalizePart(converted.String()), NotificationChannel}
 
 	if config.ETag != "" {
 		// NOTE: we want to report ErrConcurrency for all failure cases here. This is what the tests do.
@@ -108,16 +125,21 @@ END AS result;`
 WITH deleted AS (
 	DELETE FROM resources
 	WHERE id = $1 AND etag = $2
-	RETURNING id
+	RETURNING original_id, etag
+),
+notified AS (
+	SELECT pg_notify($3, json_build_object('type', 'Deleted', 'id', original_id, 'etag', etag)::text)
+	FROM deleted
 )
 SELECT
 CASE
 	WHEN EXISTS (SELECT 1 FROM deleted) THEN 'Success'
 	WHEN EXISTS (SELECT 1 FROM resources WHERE id = $1) THEN 'ErrConcurrency'
 	ELSE 'ErrConcurrency'
-END AS result;`
+END AS result
+FROM (SELECT count(*) FROM notified) AS notifications;`
 
-		args = []any{databaseutil.NormalizePart(converted.String()), etag}
+		args = []any{databaseutil.NormalizePart(converted.String()), etag, NotificationChannel}
 	}
 
 	result := ""
@@ -199,42 +221,13 @@ func (p *PostgresClient) Query(ctx context.Context, query database.Query, option
 		routingScopePrefixFilter = new(databaseutil.NormalizePart(query.RoutingScopePrefix))
 	}
 
-	var timestampFilter *string
-	if config.PaginationToken != "" {
-		ts, err := p.parsePaginationToken(config.PaginationToken)
-		if err != nil {
-			return nil, &database.ErrInvalid{Message: "invalid argument. 'query.PaginationToken' is invalid."}
-		}
-		timestampFilter = &ts
-	}
-
-	var limitFilter *int
-	if config.MaxQueryItemCount > 0 {
-		limitFilter = &config.MaxQueryItemCount
-	}
-
-	// For a scope query, we need to perform the same normalization as we do for other operations on scopes.
-	if query.IsScopeQuery {
-		var err error
-		query.ResourceType, err = databaseutil.ConvertScopeTypeToResourceType(query.ResourceType)
-		if err != nil {
-			return nil, err
-		}
+	if err := config.OrderBy.Validate(); err != nil {
+		return nil, err
 	}
 
 	// NOTE: building SQL by concatenating strings is hard to do safely and should be avoided.
 	// If you need to work on this code MAKE SURE you use SQL parameters
-	// for any user input.
-	sql := `
-SELECT original_id, etag, resource_data, created_at 
-FROM resources
-WHERE ((root_scope = $1) OR ($2 AND (root_scope LIKE $1 || '%'))) AND 
-	resource_type = $3 AND 
-	((routing_scope LIKE $4 || '%') OR $4 IS NULL) AND 
-	(created_at > $5::TIMESTAMP OR $5 IS NULL)
-ORDER BY created_at ASC
-LIMIT $6`
-
+	// for any user input. Only the placeholders and fixed fragments are concatenated here.
 	args := []any{
 		// If ScopeRecursive is false, the RootScope must match exactly.
 		// If ScopeRecursive is true, the RootScope must be a prefix of the stored RootScope.
@@ -242,9 +235,60 @@ LIMIT $6`
 		query.ScopeRecursive,
 		resourceType,
 		routingScopePrefixFilter, // RoutingScopePrefix is optional and always treated as as prefix.
-		timestampFilter,          // Optional for pagination.
-		limitFilter,              // NOTE: Postgres allows LIMIT to be set with a NULL value to mean no limit.
 	}
+	conditions := []string{
+		"((root_scope = $1) OR ($2 AND (root_scope LIKE $1 || '%')))",
+		"resource_type = $3",
+		"((routing_scope LIKE $4 || '%') OR $4 IS NULL)",
+	}
+
+	for _, filter := range query.Filters {
+		conditions = append(conditions, filterCondition(filter, &args))
+	}
+
+	// Without an order, results are ordered by creation time and the pagination token holds the timestamp of the
+	// last result. Otherwise the results are ordered by the key and the id, and the token holds both.
+	sortKey := "''"
+	orderBy := "created_at ASC"
+	if config.OrderBy.Field != "" {
+		path := addArg(&args, strings.Split(config.OrderBy.Field, "."))
+		sortKey = fmt.Sprintf(`(CASE WHEN jsonb_typeof(resource_data #> %[1]s::text[]) IN ('string', 'number', 'boolean') THEN resource_data #>> %[1]s::text[] ELSE '' END) COLLATE "C"`, path)
+
+		direction, comparison := "ASC", ">"
+		if config.OrderBy.Descending {
+			direction, comparison = "DESC", "<"
+		}
+		orderBy = fmt.Sprintf(`%s %s, id COLLATE "C" ASC`, sortKey, direction)
+
+		if config.PaginationToken != "" {
+			after, err := databaseutil.ParseQueryToken(config.PaginationToken)
+			if err != nil {
+				return nil, err
+			}
+
+			key, id := addArg(&args, after.Key), addArg(&args, after.ID)
+			conditions = append(conditions, fmt.Sprintf(`(%[1]s %[2]s %[3]s::text OR (%[1]s = %[3]s::text AND id COLLATE "C" > %[4]s::text))`, sortKey, comparison, key, id))
+		}
+	} else if config.PaginationToken != "" {
+		ts, err := p.parsePaginationToken(config.PaginationToken)
+		if err != nil {
+			return nil, &database.ErrInvalid{Message: "invalid argument. 'query.PaginationToken' is invalid."}
+		}
+		conditions = append(conditions, fmt.Sprintf("created_at > %s::TIMESTAMP", addArg(&args, ts)))
+	}
+
+	// Read one extra row so we know whether another page follows.
+	var limitFilter *int
+	if config.MaxQueryItemCount > 0 {
+		limitFilter = new(config.MaxQueryItemCount + 1)
+	}
+
+	sql := fmt.Sprintf(`
+SELECT original_id, etag, resource_data, created_at, id, %s
+FROM resources
+WHERE %s
+ORDER BY %s
+LIMIT %s`, sortKey, strings.Join(conditions, " AND\n\t"), orderBy, addArg(&args, limitFilter)) // NOTE: Postgres allows LIMIT to be set with a NULL value to mean no limit.
 
 	rows, err := p.api.Query(ctx, sql, args...)
 	if err != nil {
@@ -252,29 +296,23 @@ LIMIT $6`
 	}
 	defer rows.Close()
 
-	// Capture the last timestamp so we can use it for pagination.
-	var timestamp *time.Time
+	// Capture the position of each result so we can use the last one for pagination.
+	positions := []databaseutil.QueryToken{}
+	timestamps := []*time.Time{}
 
 	result := database.ObjectQueryResult{}
 	for rows.Next() {
 		obj := database.Object{}
-		err := rows.Scan(&obj.ID, &obj.ETag, &obj.Data, &timestamp)
-		if err != nil {
-			return nil, err
-		}
-
-		// We could improve this by moving the filter logic to the SQL query.
-		//
-		// The problem is that the current filter logic is not well documented or tested, and
-		// we want to stay compatible with the existing implementation for now.
-		match, err := obj.MatchesFilters(query.Filters)
+		position := databaseutil.QueryToken{}
+		var timestamp *time.Time
+		err := rows.Scan(&obj.ID, &obj.ETag, &obj.Data, &timestamp, &position.ID, &position.Key)
 		if err != nil {
 			return nil, err
-		} else if !match {
-			continue
 		}
 
 		result.Items = append(result.Items, obj)
+		positions = append(positions, position)
+		timestamps = append(timestamps, timestamp)
 	}
 
 	err = rows.Err()
@@ -282,23 +320,52 @@ LIMIT $6`
 		return nil, err
 	}
 
-	if len(result.Items) < config.MaxQueryItemCount && config.MaxQueryItemCount > 0 {
+	if config.MaxQueryItemCount == 0 || len(result.Items) <= config.MaxQueryItemCount {
 		// No more rows, so no need for pagination.
 		return &result, nil
 	}
 
-	if timestamp != nil {
-		// Will be empty if there were no rows.
-		token, err := p.createPaginationToken(*timestamp)
-		if err != nil {
-			return nil, err
-		}
-		result.PaginationToken = token
+	last := config.MaxQueryItemCount - 1
+	result.Items = result.Items[:config.MaxQueryItemCount]
+	if config.OrderBy.Field != "" {
+		result.PaginationToken, err = databaseutil.CreateQueryToken(positions[last])
+	} else if timestamps[last] != nil {
+		result.PaginationToken, err = p.createPaginationToken(*timestamps[last])
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	return &result, nil
 }
 
+// filterCondition returns the SQL condition for filter, adding its parameters to args. The property is read
+// from resource_data with a text[] path so the field name is never part of the SQL text.
+func filterCondition(filter database.QueryFilter, args *[]any) string {
+	path := addArg(args, strings.Split(filter.Field, "."))
+	value := fmt.Sprintf("resource_data #> %s::text[]", path)
+	text := fmt.Sprintf("resource_data #>> %s::text[]", path)
+
+	switch filter.Operator {
+	case database.FilterOperatorExists:
+		return fmt.Sprintf("COALESCE(jsonb_typeof(%s), 'null') <> 'null'", value)
+	case database.FilterOperatorNotEqual:
+		return fmt.Sprintf("(%s IS DISTINCT FROM to_jsonb(%s::text))", value, addArg(args, filter.Value))
+	case database.FilterOperatorPrefix:
+		return fmt.Sprintf("(jsonb_typeof(%s) = 'string' AND starts_with(%s, %s::text))", value, text, addArg(args, filter.Value))
+	case database.FilterOperatorIn:
+		return fmt.Sprintf("(jsonb_typeof(%s) = 'string' AND %s = ANY(%s::text[]))", value, text, addArg(args, filter.Values))
+	default:
+		return fmt.Sprintf("(%s = to_jsonb(%s::text))", value, addArg(args, filter.Value))
+	}
+}
+
+// addArg adds a parameter to args and returns its placeholder.
+func addArg(args *[]any, arg any) string {
+	*args = append(*args, arg)
+	return fmt.Sprintf("$%d", len(*args))
+}
+
 // Save implements database.Client.
 func (p *PostgresClient) Save(ctx context.Context, obj *database.Object, options ...database.SaveOptions) error {
 	if ctx == nil {
@@ -337,6 +404,8 @@ func (p *PostgresClient) Save(ctx context.Context, obj *database.Object, options
 	// We need different SQL for the case where an etag is provided vs not provided.
 	//
 	// The key behavior difference is that if an etag is provided, we should not perform inserts, only updates.
+	//
+	// Both queries notify NotificationChannel of the change in the same statement. See Watch.
 
 	// This is the more complex query that handles "upserts". It does not process etags.
 	sql := `
@@ -345,14 +414,19 @@ WITH updated AS (
 	VALUES ($1, $2, $3, $4, $5, $6, $7)
 	ON CONFLICT (id) 
 	DO UPDATE SET resource_data = $7, etag = $6
-	RETURNING id
+	RETURNING original_id, etag, (xmax = 0) AS inserted
+),
+notified AS (
+	SELECT pg_notify($8, json_build_object('type', CASE WHEN inserted THEN 'Created' ELSE 'Updated' END, 'id', original_id, 'etag', etag)::text)
+	FROM updated
 )
 SELECT
 CASE
 	WHEN EXISTS (SELECT 1 FROM updated) THEN 'Success'
 	WHEN EXISTS (SELECT 1 FROM resources WHERE id = $1) THEN 'ErrConcurrency'
 	ELSE 'ErrNotFound'
-END AS result;`
+END AS result
+FROM (SELECT count(*) FROM notified) AS notifications;`
 
 	args := []any{
 		databaseutil.NormalizePart(converted.String()),
@@ -362,6 +436,7 @@ END AS result;`
 		databaseutil.NormalizePart(converted.RoutingScope()),
 		obj.ETag,
 		obj.Data,
+		NotificationChannel,
 	}
 
 	if config.ETag != "" {
@@ -371,16 +446,21 @@ END AS result;`
 WITH updated AS (
 	UPDATE resources SET resource_data = $2, etag = $4
 	WHERE id = $1 AND etag = $3
-	RETURNING id
+	RETURNING original_id, etag
+),
+notified AS (
+	SELECT pg_notify($5, json_build_object('type', 'Updated', 'id', original_id, 'etag', etag)::text)
+	FROM updated
 )
 SELECT
 CASE
 	WHEN EXISTS (SELECT 1 FROM updated) THEN 'Success'
 	WHEN EXISTS (SELECT 1 FROM resources WHERE id = $1) THEN 'ErrConcurrency'
 	ELSE 'ErrConcurrency'
-END AS result;`
+END AS result
+FROM (SELECT count(*) FROM notified) AS notifications;`
 
-		args = []any{databaseutil.NormalizePart(converted.String()), obj.Data, config.ETag, obj.ETag}
+		args = []any{databaseutil.NormalizePart(converted.String()), obj.Data, config.ETag, obj.ETag, NotificationChannel}
 	}
 
 	result := ""
@@ -396,6 +476,125 @@ END AS result;`
 	return nil
 }
 
+// Export implements database.Exporter.
+func (p *PostgresClient) Export(ctx context.Context, options ...database.QueryOptions) (*database.ObjectQueryResult, error) {
+	if ctx == nil {
+		return nil, &database.ErrInvalid{Message: "invalid argument. 'ctx' is required"}
+	}
+
+	config := database.NewQueryConfig(options...)
+
+	var afterFilter *string
+	if config.PaginationToken != "" {
+		after, err := databaseutil.ParseExportToken(config.PaginationToken)
+		if err != nil {
+			return nil, err
+		}
+		afterFilter = &after
+	}
+
+	// Read one extra row so we know whether another page follows.
+	var limitFilter *int
+	if config.MaxQueryItemCount > 0 {
+		limitFilter = new(config.MaxQueryItemCount + 1)
+	}
+
+	sql := `
+SELECT original_id, etag, resource_data
+FROM resources
+WHERE (LOWER(original_id) > $1 OR $1 IS NULL)
+ORDER BY LOWER(original_id) ASC
+LIMIT $2`
+
+	rows, err := p.api.Query(ctx, sql, afterFilter, limitFilter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := database.ObjectQueryResult{}
+	for rows.Next() {
+		obj := database.Object{}
+		err := rows.Scan(&obj.ID, &obj.ETag, &obj.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Items = append(result.Items, obj)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.MaxQueryItemCount > 0 && len(result.Items) > config.MaxQueryItemCount {
+		result.Items = result.Items[:config.MaxQueryItemCount]
+		result.PaginationToken = databaseutil.CreateExportToken(databaseutil.ExportKey(result.Items[len(result.Items)-1].ID))
+	}
+
+	return &result, nil
+}
+
+// Import implements database.Exporter.
+func (p *PostgresClient) Import(ctx context.Context, obj *database.Object) error {
+	if ctx == nil {
+		return &database.ErrInvalid{Message: "invalid argument. 'ctx' is required"}
+	}
+	if obj == nil {
+		return &database.ErrInvalid{Message: "invalid argument. 'obj' is required"}
+	}
+
+	parsed, err := resources.Parse(obj.ID)
+	if err != nil {
+		return &database.ErrInvalid{Message: "invalid argument. 'obj.ID' must be a valid resource id"}
+	}
+	if parsed.IsEmpty() {
+		return &database.ErrInvalid{Message: "invalid argument. 'obj.ID' must not be empty"}
+	}
+	if parsed.IsResourceCollection() || parsed.IsScopeCollection() {
+		return &database.ErrInvalid{Message: "invalid argument. 'obj.ID' must refer to a named resource, not a collection"}
+	}
+
+	converted, err := databaseutil.ConvertScopeIDToResourceID(parsed)
+	if err != nil {
+		return err
+	}
+
+	objETag := obj.ETag
+	if objETag == "" {
+		raw, err := json.Marshal(obj.Data)
+		if err != nil {
+			return err
+		}
+		objETag = etag.New(raw)
+	}
+
+	sql := `
+WITH updated AS (
+	INSERT INTO resources (id, original_id, resource_type, root_scope, routing_scope, etag, resource_data)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (id)
+	DO UPDATE SET original_id = $2, resource_data = $7, etag = $6
+	RETURNING original_id, etag, (xmax = 0) AS inserted
+)
+SELECT pg_notify($8, json_build_object('type', CASE WHEN inserted THEN 'Created' ELSE 'Updated' END, 'id', original_id, 'etag', etag)::text)
+FROM updated`
+
+	_, err = p.api.Exec(
+		ctx,
+		sql,
+		databaseutil.NormalizePart(converted.String()),
+		obj.ID, // MUST NOT BE NORMALIZED. Preserve the original casing and format.
+		databaseutil.NormalizePart(converted.Type()),
+		databaseutil.NormalizePart(converted.RootScope()),
+		databaseutil.NormalizePart(converted.RoutingScope()),
+		objETag,
+		obj.Data,
+		NotificationChannel)
+	return err
+}
+
 // createPaginationToken converts a timestamp to a base64 encoded string.
 //
 // We use ISO8601/RFC3339 format which postgres understands and can be used for comparison.
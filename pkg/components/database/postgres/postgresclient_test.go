@@ -45,7 +45,11 @@ func Test_PostgresClient(t *testing.T) {
 	require.NoError(t, err)
 
 	logger := postgresLogger{t: t, pool: pool}
-	client := NewPostgresClient(&logger)
+	client := NewPostgresClient(&logger, Options{
+		Connect: func(ctx context.Context) (ListenerAPI, error) {
+			return pgx.Connect(ctx, url)
+		},
+	})
 
 	clear := func(t *testing.T) {
 		tag, err := pool.Exec(ctx, "DELETE FROM resources")
@@ -55,6 +59,9 @@ func Test_PostgresClient(t *testing.T) {
 
 	// The actual test logic lives in a shared package, we're just doing the setup here.
 	shared.RunTest(t, client, clear)
+	shared.RunQueryTest(t, client, clear)
+	shared.RunExportTest(t, client, clear)
+	shared.RunWatchTest(t, client, clear)
 }
 
 var _ PostgresAPI = (*postgresLogger)(nil)
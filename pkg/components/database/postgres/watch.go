@@ -0,0 +1,251 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/databaseutil"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+// NotificationChannel is the channel notified when a resource is saved or deleted. The payload is a
+// notification encoded as JSON.
+const NotificationChannel = "radius_resources"
+
+// watchQueryPageSize is the page size of the query of the existing objects when a watch with filters starts.
+const watchQueryPageSize = 1000
+
+// ListenerAPI defines the API surface from pgx that we use to receive notifications.
+//
+// Keep these definitions in sync with pgx.Conn.
+type ListenerAPI interface {
+	// Exec executes a query without returning any rows.
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	// WaitForNotification waits for a notification on a channel the connection listens to.
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	// Close closes the connection.
+	Close(ctx context.Context) error
+}
+
+// notification is the payload of the notifications sent on NotificationChannel.
+type notification struct {
+	Type database.EventType `json:"type"`
+	ID   string             `json:"id"`
+	ETag string             `json:"etag"`
+}
+
+var _ database.Watcher = (*PostgresClient)(nil)
+
+// Watch implements database.Watcher. Every write sends a NOTIFY in the same statement, so the changes are
+// delivered when they are committed. The data of created and updated objects is read when the notification
+// is received. If the object was written again in the meantime, the newer state is delivered with the event
+// and the event of the later write is skipped, so every event carries the data that matches its ETag and each
+// state is delivered once. LISTEN needs a connection that is not shared with other queries, so each watch uses
+// a dedicated connection opened with Options.Connect.
+//
+// The data of a deleted object is gone when the notification is received. When the query has filters, the watch
+// remembers which objects matched them, starting with a query of the existing objects, so that a Deleted event
+// is only delivered for an object whose last delivered state matched the filters.
+func (p *PostgresClient) Watch(ctx context.Context, query database.Query) (<-chan database.WatchEvent, error) {
+	if ctx == nil {
+		return nil, &database.ErrInvalid{Message: "invalid argument. 'ctx' is required"}
+	}
+
+	err := query.Validate()
+	if err != nil {
+		return nil, &database.ErrInvalid{Message: fmt.Sprintf("invalid argument. Query is invalid: %s", err.Error())}
+	}
+
+	if p.options.Connect == nil {
+		return nil, errors.New("the PostgreSQL client is not configured to watch resources")
+	}
+
+	conn, err := p.options.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Listen before returning so that the changes made after Watch returns are delivered.
+	if _, err := conn.Exec(ctx, "LISTEN "+NotificationChannel); err != nil {
+		_ = conn.Close(context.Background())
+		return nil, err
+	}
+
+	state := &watchState{latest: map[string]string{}}
+	if len(query.Filters) > 0 {
+		state.matching, err = p.matchingIDs(ctx, query)
+		if err != nil {
+			_ = conn.Close(context.Background())
+			return nil, err
+		}
+	}
+
+	events := make(chan database.WatchEvent)
+	go func() {
+		defer close(events)
+		defer func() {
+			// ctx may be done, but the connection still needs to be closed.
+			_ = conn.Close(context.Background())
+		}()
+
+		err := p.listen(ctx, conn, query, state, events)
+		if err != nil && ctx.Err() == nil {
+			logger := ucplog.FromContextOrDiscard(ctx)
+			logger.Error(err, "the watch of the resources has failed")
+		}
+	}()
+
+	return events, nil
+}
+
+// watchState is the state of a single watch. The keys are lowercased IDs.
+type watchState struct {
+	// latest holds the ETag of objects whose newer state was delivered ahead of the notification of the write
+	// that produced it. The notification is skipped when it arrives.
+	latest map[string]string
+
+	// matching holds the objects whose last delivered state matched the filters of the query. It is nil when the
+	// query has no filters.
+	matching map[string]bool
+}
+
+// matchingIDs returns the objects that match query when the watch starts.
+func (p *PostgresClient) matchingIDs(ctx context.Context, query database.Query) (map[string]bool, error) {
+	matching := map[string]bool{}
+	token := ""
+	for {
+		result, err := p.Query(ctx, query, database.WithPaginationToken(token), database.WithMaxQueryItemCount(watchQueryPageSize))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range result.Items {
+			matching[strings.ToLower(item.ID)] = true
+		}
+
+		if result.PaginationToken == "" {
+			return matching, nil
+		}
+
+		token = result.PaginationToken
+	}
+}
+
+func (p *PostgresClient) listen(ctx context.Context, conn ListenerAPI, query database.Query, state *watchState, events chan<- database.WatchEvent) error {
+	for {
+		received, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		n := notification{}
+		if err := json.Unmarshal([]byte(received.Payload), &n); err != nil {
+			return err
+		}
+
+		event, err := p.readEvent(ctx, n, query, state)
+		if err != nil {
+			return err
+		} else if event == nil {
+			continue
+		}
+
+		select {
+		case events <- *event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// readEvent returns the event for the notification n, or nil if the change does not match query or was
+// already delivered.
+func (p *PostgresClient) readEvent(ctx context.Context, n notification, query database.Query, state *watchState) (*database.WatchEvent, error) {
+	id, err := resources.Parse(n.ID)
+	if err != nil {
+		// Ignore invalid IDs like queries do.
+		return nil, nil
+	}
+
+	if !databaseutil.IDMatchesQuery(id, query) {
+		return nil, nil
+	}
+
+	key := strings.ToLower(n.ID)
+	if n.Type == database.EventTypeDeleted {
+		delete(state.latest, key)
+		if state.matching != nil {
+			if !state.matching[key] {
+				return nil, nil
+			}
+
+			delete(state.matching, key)
+		}
+
+		return &database.WatchEvent{Type: n.Type, Object: database.Object{Metadata: database.Metadata{ID: n.ID, ETag: n.ETag}}}, nil
+	}
+
+	if latest, ok := state.latest[key]; ok && latest == n.ETag {
+		// The state of this write was delivered with the event of an earlier write.
+		delete(state.latest, key)
+		return nil, nil
+	}
+
+	obj, err := p.Get(ctx, n.ID)
+	if errors.Is(err, &database.ErrNotFound{}) {
+		// The object was deleted since, the Deleted event follows.
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if latest, ok := state.latest[key]; ok && latest == obj.ETag {
+		// This state was already delivered, its notification follows.
+		return nil, nil
+	} else if obj.ETag != n.ETag {
+		// The object was written again since the notification was sent. Deliver the newer state now and skip
+		// the notification of the later write.
+		state.latest[key] = obj.ETag
+	}
+
+	match, err := obj.MatchesFilters(query.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.matching != nil {
+		if match {
+			state.matching[key] = true
+		} else {
+			delete(state.matching, key)
+		}
+	}
+
+	if !match {
+		return nil, nil
+	}
+
+	return &database.WatchEvent{Type: n.Type, Object: *obj}, nil
+}
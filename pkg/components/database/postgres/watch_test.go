@@ -0,0 +1,295 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/databaseutil"
+	"github.com/stretchr/testify/require"
+)
+
+var _ ListenerAPI = (*pgx.Conn)(nil)
+var _ ListenerAPI = (*fakeListener)(nil)
+
+// fakeListener delivers notifications until it is empty and then fails with err.
+type fakeListener struct {
+	notifications chan *pgconn.Notification
+	err           error
+	listened      []string
+	closed        chan struct{}
+}
+
+// Exec implements ListenerAPI.
+func (f *fakeListener) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.listened = append(f.listened, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+// WaitForNotification implements ListenerAPI.
+func (f *fakeListener) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case n, ok := <-f.notifications:
+		if !ok {
+			return nil, f.err
+		}
+		return n, nil
+	}
+}
+
+// Close implements ListenerAPI.
+func (f *fakeListener) Close(ctx context.Context) error {
+	close(f.closed)
+	return nil
+}
+
+func Test_PostgresClient_Watch_Fake(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	listener := &fakeListener{notifications: make(chan *pgconn.Notification), err: errors.New("connection lost"), closed: make(chan struct{})}
+	client := NewPostgresClient(nil, Options{
+		Connect: func(ctx context.Context) (ListenerAPI, error) {
+			return listener, nil
+		},
+	})
+
+	events, err := client.Watch(ctx, database.Query{RootScope: "/planes/radius/local/resourceGroups/group1", ResourceType: "System.Resources/resourceType1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"LISTEN " + NotificationChannel}, listener.listened)
+
+	// Deleted events don't read the data of the object, changes to other resources are ignored.
+	listener.notifications <- &pgconn.Notification{Channel: NotificationChannel, Payload: `{"type":"Deleted","id":"/planes/radius/local/resourceGroups/group2/providers/System.Resources/resourceType1/resource1","etag":"a"}`}
+	listener.notifications <- &pgconn.Notification{Channel: NotificationChannel, Payload: `{"type":"Deleted","id":"/planes/radius/local/resourceGroups/group1/providers/System.Resources/resourceType1/resource1","etag":"b"}`}
+
+	event := <-events
+	require.Equal(t, database.WatchEvent{
+		Type: database.EventTypeDeleted,
+		Object: database.Object{
+			Metadata: database.Metadata{ID: "/planes/radius/local/resourceGroups/group1/providers/System.Resources/resourceType1/resource1", ETag: "b"},
+		},
+	}, event)
+
+	// The watch ends when the connection fails.
+	close(listener.notifications)
+
+	_, ok := <-events
+	require.False(t, ok)
+	<-listener.closed
+}
+
+func Test_PostgresClient_Watch_NotConfigured(t *testing.T) {
+	client := NewPostgresClient(nil, Options{})
+
+	_, err := client.Watch(t.Context(), database.Query{RootScope: "/planes/radius/local", ResourceType: "System.Resources/resourceType1"})
+	require.EqualError(t, err, "the PostgreSQL client is not configured to watch resources")
+}
+
+var _ PostgresAPI = (*fakeStore)(nil)
+
+// fakeStore serves the objects in current to Get, and the objects in matching to Query.
+type fakeStore struct {
+	current  map[string]database.Object
+	matching []database.Object
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{current: map[string]database.Object{}}
+}
+
+func (f *fakeStore) set(id string, etag string, data map[string]any) {
+	f.current[databaseutil.NormalizePart(id)] = database.Object{Metadata: database.Metadata{ID: id, ETag: etag}, Data: data}
+}
+
+// Exec implements PostgresAPI.
+func (f *fakeStore) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errors.New("not implemented")
+}
+
+// Query implements PostgresAPI.
+func (f *fakeStore) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return &fakeRows{items: f.matching, index: -1}, nil
+}
+
+// QueryRow implements PostgresAPI.
+func (f *fakeStore) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	obj, ok := f.current[args[0].(string)]
+	if !ok {
+		return &fakeRows{err: pgx.ErrNoRows}
+	}
+
+	return &fakeRows{items: []database.Object{obj}}
+}
+
+// fakeRows returns items as the rows of a query. Only the methods used by PostgresClient are implemented.
+type fakeRows struct {
+	pgx.Rows
+	items []database.Object
+	index int
+	err   error
+}
+
+// Next implements pgx.Rows.
+func (r *fakeRows) Next() bool {
+	r.index++
+	return r.index < len(r.items)
+}
+
+// Scan implements pgx.Rows and pgx.Row.
+func (r *fakeRows) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	obj := r.items[r.index]
+	*(dest[0].(*string)) = obj.ID
+	*(dest[1].(*string)) = obj.ETag
+	*(dest[2].(*any)) = obj.Data
+	if len(dest) > 3 {
+		*(dest[3].(**time.Time)) = nil
+		*(dest[4].(*string)) = databaseutil.NormalizePart(obj.ID)
+		*(dest[5].(*string)) = ""
+	}
+	return nil
+}
+
+// Err implements pgx.Rows.
+func (r *fakeRows) Err() error {
+	return nil
+}
+
+// Close implements pgx.Rows.
+func (r *fakeRows) Close() {
+}
+
+func startFakeWatch(t *testing.T, store *fakeStore, query database.Query) (*fakeListener, <-chan database.WatchEvent) {
+	listener := &fakeListener{notifications: make(chan *pgconn.Notification, 10), err: errors.New("connection lost"), closed: make(chan struct{})}
+	client := NewPostgresClient(store, Options{
+		Connect: func(ctx context.Context) (ListenerAPI, error) {
+			return listener, nil
+		},
+	})
+
+	events, err := client.Watch(t.Context(), query)
+	require.NoError(t, err)
+
+	return listener, events
+}
+
+func notify(listener *fakeListener, eventType database.EventType, id string, etag string) {
+	listener.notifications <- &pgconn.Notification{
+		Channel: NotificationChannel,
+		Payload: `{"type":"` + string(eventType) + `","id":"` + id + `","etag":"` + etag + `"}`,
+	}
+}
+
+func Test_PostgresClient_Watch_SupersededChanges(t *testing.T) {
+	id := "/planes/radius/local/resourceGroups/group1/providers/System.Resources/resourceType1/resource1"
+	store := newFakeStore()
+	listener, events := startFakeWatch(t, store, database.Query{RootScope: "/planes/radius/local/resourceGroups/group1", ResourceType: "System.Resources/resourceType1"})
+
+	// The object was created and updated before the notification of the creation is read.
+	store.set(id, "b", map[string]any{"value": "2"})
+	notify(listener, database.EventTypeCreated, id, "a")
+	notify(listener, database.EventTypeUpdated, id, "b")
+
+	// The creation is delivered with the newer state, and the update that produced it is skipped.
+	event := <-events
+	require.Equal(t, database.EventTypeCreated, event.Type)
+	require.Equal(t, "b", event.Object.ETag)
+	require.Equal(t, map[string]any{"value": "2"}, event.Object.Data)
+
+	// The next update is delivered as usual, with the data of its ETag.
+	store.set(id, "c", map[string]any{"value": "3"})
+	notify(listener, database.EventTypeUpdated, id, "c")
+
+	event = <-events
+	require.Equal(t, database.EventTypeUpdated, event.Type)
+	require.Equal(t, "c", event.Object.ETag)
+	require.Equal(t, map[string]any{"value": "3"}, event.Object.Data)
+
+	// Two updates read after a third one are delivered once.
+	store.set(id, "f", map[string]any{"value": "6"})
+	notify(listener, database.EventTypeUpdated, id, "d")
+	notify(listener, database.EventTypeUpdated, id, "e")
+	notify(listener, database.EventTypeUpdated, id, "f")
+	notify(listener, database.EventTypeDeleted, id, "f")
+
+	event = <-events
+	require.Equal(t, database.EventTypeUpdated, event.Type)
+	require.Equal(t, "f", event.Object.ETag)
+
+	event = <-events
+	require.Equal(t, database.EventTypeDeleted, event.Type)
+	require.Equal(t, id, event.Object.ID)
+}
+
+func Test_PostgresClient_Watch_FiltersDeleted(t *testing.T) {
+	scope := "/planes/radius/local/resourceGroups/group1/providers/System.Resources/resourceType1/"
+	store := newFakeStore()
+
+	// existing matches the filters when the watch starts.
+	store.matching = []database.Object{{Metadata: database.Metadata{ID: scope + "existing", ETag: "a"}, Data: map[string]any{"env": "prod"}}}
+	listener, events := startFakeWatch(t, store, database.Query{
+		RootScope:    "/planes/radius/local/resourceGroups/group1",
+		ResourceType: "System.Resources/resourceType1",
+		Filters:      []database.QueryFilter{{Field: "env", Value: "prod"}},
+	})
+
+	// Deletions of objects that never matched the filters are skipped.
+	store.set(scope+"dev", "a", map[string]any{"env": "dev"})
+	notify(listener, database.EventTypeCreated, scope+"dev", "a")
+	notify(listener, database.EventTypeDeleted, scope+"dev", "a")
+	notify(listener, database.EventTypeDeleted, scope+"unknown", "a")
+
+	notify(listener, database.EventTypeDeleted, scope+"existing", "a")
+	event := <-events
+	require.Equal(t, database.WatchEvent{Type: database.EventTypeDeleted, Object: database.Object{Metadata: database.Metadata{ID: scope + "existing", ETag: "a"}}}, event)
+
+	// An object that stops matching the filters is not reported as deleted.
+	store.set(scope+"prod", "a", map[string]any{"env": "prod"})
+	notify(listener, database.EventTypeCreated, scope+"prod", "a")
+
+	event = <-events
+	require.Equal(t, database.EventTypeCreated, event.Type)
+	require.Equal(t, scope+"prod", event.Object.ID)
+
+	store.set(scope+"prod", "b", map[string]any{"env": "dev"})
+	notify(listener, database.EventTypeUpdated, scope+"prod", "b")
+	notify(listener, database.EventTypeDeleted, scope+"prod", "b")
+
+	// A matching object deleted after the filtered changes above shows that they were skipped.
+	store.set(scope+"last", "a", map[string]any{"env": "prod"})
+	notify(listener, database.EventTypeCreated, scope+"last", "a")
+	notify(listener, database.EventTypeDeleted, scope+"last", "a")
+
+	event = <-events
+	require.Equal(t, database.EventTypeCreated, event.Type)
+	require.Equal(t, scope+"last", event.Object.ID)
+
+	event = <-events
+	require.Equal(t, database.EventTypeDeleted, event.Type)
+	require.Equal(t, scope+"last", event.Object.ID)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// EventType is the type of a change to an object in the data store.
+type EventType string
+
+const (
+	// EventTypeCreated is the type of the event sent when an object is created.
+	EventTypeCreated EventType = "Created"
+	// EventTypeUpdated is the type of the event sent when an object is updated.
+	EventTypeUpdated EventType = "Updated"
+	// EventTypeDeleted is the type of the event sent when an object is deleted.
+	EventTypeDeleted EventType = "Deleted"
+)
+
+// WatchEvent is a change to an object in the data store.
+type WatchEvent struct {
+	// Type is the type of the change.
+	Type EventType
+
+	// Object is the object after the change. For Deleted events, Object has the ID and the last
+	// ETag of the deleted object but no data.
+	Object Object
+}
+
+// Watcher is implemented by Clients that can stream the changes to the objects they store instead
+// of being polled with Query.
+//
+// Watcher is optional. Use a type assertion on a Client, or the Watch function, to check for support.
+type Watcher interface {
+	// Watch streams the changes to the objects matching query that happen after Watch returns.
+	// The query is validated like in Query. Filters are applied to the data of created and updated
+	// objects, and to the last state of deleted objects: a Deleted event is sent only for an object
+	// whose ID matches the query and whose state before the deletion matched the filters. Like the
+	// changes of objects that don't match the filters, the deletion of such an object is not sent.
+	//
+	// The channel is closed when ctx is done, or when the watch cannot continue, for example if the
+	// connection to the data store is lost. Events may have been missed in that case: callers
+	// should Query again to resynchronize before watching again.
+	Watch(ctx context.Context, query Query) (<-chan WatchEvent, error)
+}
+
+// Watch calls Watch on client. It returns an error if client does not implement Watcher.
+func Watch(ctx context.Context, client Client, query Query) (<-chan WatchEvent, error) {
+	watcher, ok := client.(Watcher)
+	if !ok {
+		return nil, fmt.Errorf("database client %T does not support watch", client)
+	}
+
+	return watcher.Watch(ctx, query)
+}
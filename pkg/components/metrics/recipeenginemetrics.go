@@ -31,6 +31,9 @@ const (
 	// recipeEngineOperationDuration is the metric name for the recipe engine operation duration.
 	recipeEngineOperationDuration = "recipe.operation.duration"
 
+	// recipeDuration is the metric name for the end-to-end recipe duration, recorded per driver and outcome.
+	recipeDuration = "recipe.duration"
+
 	// recipeDownloadDuration is the metric name for the recipe download duration.
 	recipeDownloadDuration = "recipe.download.duration"
 
@@ -52,6 +55,9 @@ const (
 	// RecipeEngineOperationDelete represents the Delete operation of the Recipe Engine.
 	RecipeEngineOperationDelete = "delete"
 
+	// RecipeEngineOperationPlan represents the Plan operation of the Recipe Engine.
+	RecipeEngineOperationPlan = "plan"
+
 	// RecipeEngineOperationDownloadRecipe represents the Download Recipe operation of the Recipe Engine.
 	RecipeEngineOperationDownloadRecipe = "download.recipe"
 
@@ -81,11 +87,21 @@ func (m *recipeEngineMetrics) Init() error {
 		return err
 	}
 
+	m.valueRecorders[recipeDuration], err = meter.Float64Histogram(recipeDuration)
+	if err != nil {
+		return err
+	}
+
 	m.valueRecorders[recipeDownloadDuration], err = meter.Float64Histogram(recipeDownloadDuration)
 	if err != nil {
 		return err
 	}
 
+	m.valueRecorders[recipeGCDuration], err = meter.Float64Histogram(recipeGCDuration)
+	if err != nil {
+		return err
+	}
+
 	m.valueRecorders[terraformInstallationDuration], err = meter.Float64Histogram(terraformInstallationDuration)
 	if err != nil {
 		return err
@@ -96,6 +112,11 @@ func (m *recipeEngineMetrics) Init() error {
 		return err
 	}
 
+	m.valueRecorders[terraformInstallVerificationDuration], err = meter.Float64Histogram(terraformInstallVerificationDuration)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -108,6 +129,15 @@ func (m *recipeEngineMetrics) RecordRecipeOperationDuration(ctx context.Context,
 	}
 }
 
+// RecordRecipeDuration records the end-to-end duration of a recipe with the given attributes. The attributes are
+// expected to be created by NewRecipeDurationAttributes so that the histogram is broken down by driver and outcome only.
+func (m *recipeEngineMetrics) RecordRecipeDuration(ctx context.Context, startTime time.Time, attrs []attribute.KeyValue) {
+	if m.valueRecorders[recipeDuration] != nil {
+		elapsedTime := float64(time.Since(startTime)) / float64(time.Millisecond)
+		m.valueRecorders[recipeDuration].Record(ctx, elapsedTime, metric.WithAttributes(attrs...))
+	}
+}
+
 // RecordRecipeDownloadDuration records the recipe download duration with the given attributes.
 func (m *recipeEngineMetrics) RecordRecipeDownloadDuration(ctx context.Context, startTime time.Time, attrs []attribute.KeyValue) {
 	if m.valueRecorders[recipeDownloadDuration] != nil {
@@ -178,3 +208,24 @@ func NewRecipeAttributes(operationType, recipeName string, definition *recipes.E
 
 	return attrs
 }
+
+// NewRecipeDurationAttributes generates the attributes for the recipe duration metric: the operation type, the recipe
+// driver and the outcome of the operation, which is either SuccessfulOperationState or FailedOperationState. The driver
+// is "unknown" when the recipe failed before its definition was loaded.
+func NewRecipeDurationAttributes(operationType string, definition *recipes.EnvironmentDefinition, failed bool) []attribute.KeyValue {
+	driver := "unknown"
+	if definition != nil && definition.Driver != "" {
+		driver = strings.ToLower(definition.Driver)
+	}
+
+	state := SuccessfulOperationState
+	if failed {
+		state = FailedOperationState
+	}
+
+	return []attribute.KeyValue{
+		operationTypeAttrKey.String(strings.ToLower(operationType)),
+		recipeDriverAttrKey.String(driver),
+		OperationStateAttrKey.String(state),
+	}
+}
@@ -49,6 +49,9 @@ limitations under the License.
 // and checks if its dequeue count matches the dequeue count of Message Client A currently have. We are using DequeueCount as a
 // revision number of message here. If it is mismatched, it means that Client B already leased the message. In this case,
 // ExtendMessage returns ErrDequeuedMessage to prevent Client A from extending lock.
+//
+// Dead-letter messages are kept as QueueMessage CRs with the `ucp.dev/deadletter` label. Dequeue skips the messages
+// with this label, and replaying a message removes the label and resets its DequeueCount.
 
 package apiserver
 
@@ -62,6 +65,7 @@ import (
 
 	v1alpha1 "github.com/radius-project/radius/pkg/components/database/apiserverstore/api/ucp.dev/v1alpha1"
 	"github.com/radius-project/radius/pkg/components/queue"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -75,12 +79,15 @@ const (
 	LabelQueueName = "ucp.dev/queuename"
 	// LabelNextVisibleAt is the label representing the time when message is visible in the queue or requeued.
 	LabelNextVisibleAt = "ucp.dev/nextvisibleat"
+	// LabelDeadLetter is the label representing the message in the dead-letter queue.
+	LabelDeadLetter = "ucp.dev/deadletter"
 
 	defaultMessageLockDuration = time.Duration(5) * time.Minute
 	defaultExpiryDuration      = time.Duration(10) * time.Hour
 )
 
 var _ queue.Client = (*Client)(nil)
+var _ queue.DeadLetterClient = (*Client)(nil)
 
 // Client is the queue client used for dev and test purpose.
 type Client struct {
@@ -208,7 +215,13 @@ func newMessageLabelSelector(now time.Time, name string) (labels.Selector, error
 		return nil, err
 	}
 
-	return selector.Add(*nameLabel), nil
+	// The messages in the dead-letter queue are never dequeued.
+	deadLetterLabel, err := labels.NewRequirement(LabelDeadLetter, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return selector.Add(*nameLabel, *deadLetterLabel), nil
 }
 
 // getQueueMessage fetches the first item which is the message in the current queue. We can
@@ -355,3 +368,122 @@ func (c *Client) ExtendMessage(ctx context.Context, msg *queue.Message) error {
 	copyMessage(msg, result)
 	return nil
 }
+
+// DeadLetter implements queue.DeadLetterClient.
+func (c *Client) DeadLetter(ctx context.Context, msg *queue.Message, lastError string) error {
+	if msg == nil {
+		return queue.ErrEmptyMessage
+	}
+
+	result := &v1alpha1.QueueMessage{}
+	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		getErr := c.client.Get(ctx, runtimeclient.ObjectKey{Namespace: c.opts.Namespace, Name: msg.ID}, result)
+		if apierrors.IsNotFound(getErr) {
+			return queue.ErrInvalidMessage
+		} else if getErr != nil {
+			return getErr
+		}
+
+		// Another client leased the message after the lock of msg expired.
+		if result.Spec.DequeueCount != msg.DequeueCount {
+			return queue.ErrDequeuedMessage
+		}
+
+		result.Labels[LabelDeadLetter] = "true"
+		result.Spec.DeadLetterAt = &metav1.Time{Time: time.Now().UTC()}
+		result.Spec.LastError = lastError
+		return c.client.Update(ctx, result)
+	})
+
+	return retryErr
+}
+
+// ListDeadLetters implements queue.DeadLetterClient.
+func (c *Client) ListDeadLetters(ctx context.Context) ([]*queue.DeadLetterMessage, error) {
+	ql := &v1alpha1.QueueMessageList{}
+	err := c.client.List(
+		ctx, ql,
+		runtimeclient.InNamespace(c.opts.Namespace),
+		runtimeclient.MatchingLabels{LabelQueueName: c.opts.Name},
+		runtimeclient.HasLabels{LabelDeadLetter})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*queue.DeadLetterMessage, 0, len(ql.Items))
+	for i := range ql.Items {
+		result = append(result, copyDeadLetter(&ql.Items[i]))
+	}
+	return result, nil
+}
+
+// GetDeadLetter implements queue.DeadLetterClient.
+func (c *Client) GetDeadLetter(ctx context.Context, id string) (*queue.DeadLetterMessage, error) {
+	result, err := c.getDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return copyDeadLetter(result), nil
+}
+
+// ReplayDeadLetter implements queue.DeadLetterClient.
+func (c *Client) ReplayDeadLetter(ctx context.Context, id string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		result, err := c.getDeadLetter(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		delete(result.Labels, LabelDeadLetter)
+		result.Labels[LabelNextVisibleAt] = int64toa(now.UnixNano())
+		result.Spec.DequeueCount = 0
+		result.Spec.ExpireAt = metav1.Time{Time: now.Add(c.opts.ExpiryDuration).UTC()}
+		result.Spec.DeadLetterAt = nil
+		result.Spec.LastError = ""
+		return c.client.Update(ctx, result)
+	})
+}
+
+// PurgeDeadLetter implements queue.DeadLetterClient.
+func (c *Client) PurgeDeadLetter(ctx context.Context, id string) error {
+	result, err := c.getDeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = c.client.Delete(ctx, result, &runtimeclient.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &result.UID},
+	})
+	if apierrors.IsNotFound(err) {
+		return queue.ErrDeadLetterNotFound
+	}
+	return err
+}
+
+// getDeadLetter gets the message id of this queue if it is in the dead-letter queue.
+func (c *Client) getDeadLetter(ctx context.Context, id string) (*v1alpha1.QueueMessage, error) {
+	result := &v1alpha1.QueueMessage{}
+	err := c.client.Get(ctx, runtimeclient.ObjectKey{Namespace: c.opts.Namespace, Name: id}, result)
+	if apierrors.IsNotFound(err) {
+		return nil, queue.ErrDeadLetterNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if _, ok := result.Labels[LabelDeadLetter]; !ok || result.Labels[LabelQueueName] != c.opts.Name {
+		return nil, queue.ErrDeadLetterNotFound
+	}
+
+	return result, nil
+}
+
+func copyDeadLetter(queueMessage *v1alpha1.QueueMessage) *queue.DeadLetterMessage {
+	msg := &queue.DeadLetterMessage{LastError: queueMessage.Spec.LastError}
+	copyMessage(&msg.Message, queueMessage)
+	if queueMessage.Spec.DeadLetterAt != nil {
+		msg.DeadLetterAt = queueMessage.Spec.DeadLetterAt.Time
+	}
+	return msg
+}
@@ -120,6 +120,7 @@ func TestClient(t *testing.T) {
 	}
 
 	sharedtest.RunTest(t, cli, clear)
+	sharedtest.RunDeadLetterTest(t, cli, clear)
 
 	t.Run("ExtendMessage is failed when machine's clock is skewed", func(t *testing.T) {
 		clear(t)
@@ -144,3 +145,36 @@ func TestClient(t *testing.T) {
 		require.ErrorIs(t, err, queue.ErrDequeuedMessage)
 	})
 }
+
+func TestCopyDeadLetter(t *testing.T) {
+	now := time.Now().UTC()
+	queueM := &v1alpha1.QueueMessage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "applications.core.10101010",
+			Labels: map[string]string{
+				LabelNextVisibleAt: int64toa(now.UnixNano()),
+				LabelQueueName:     "applications.core",
+				LabelDeadLetter:    "true",
+			},
+		},
+		Spec: v1alpha1.QueueMessageSpec{
+			DequeueCount: 3,
+			ContentType:  queue.JSONContentType,
+			Data:         &runtime.RawExtension{Raw: []byte("{}")},
+			DeadLetterAt: &metav1.Time{Time: now},
+			LastError:    "failed",
+		},
+	}
+
+	msg := copyDeadLetter(queueM)
+	require.Equal(t, queueM.Name, msg.ID)
+	require.Equal(t, 3, msg.DequeueCount)
+	require.Equal(t, now, msg.DeadLetterAt)
+	require.Equal(t, "failed", msg.LastError)
+}
+
+func TestNewMessageLabelSelector(t *testing.T) {
+	selector, err := newMessageLabelSelector(time.Unix(0, 100), "applications.core")
+	require.NoError(t, err)
+	require.Equal(t, "!ucp.dev/deadletter,ucp.dev/nextvisibleat<100,ucp.dev/queuename=applications.core", selector.String())
+}
@@ -39,6 +39,12 @@ var (
 
 	// ErrEmptyMessage represents nil or empty Message.
 	ErrEmptyMessage = errors.New("message must not be nil or message is empty")
+
+	// ErrDeadLetterNotFound represents the error when the message is not in the dead-letter queue.
+	ErrDeadLetterNotFound = errors.New("message is not in the dead-letter queue")
+
+	// ErrQueueNotFound represents the error when the queue is not one of the configured queues.
+	ErrQueueNotFound = errors.New("queue is not configured")
 )
 
 //go:generate go tool mockgen -typed -destination=./mock_client.go -package=queue -self_package github.com/radius-project/radius/pkg/components/queue github.com/radius-project/radius/pkg/components/queue Client
@@ -58,11 +64,58 @@ type Client interface {
 	ExtendMessage(ctx context.Context, msg *Message) error
 }
 
+// Notifier is implemented by the queue clients which push a wakeup when a message is enqueued, so
+// that dequeuers do not need to poll an empty queue.
+type Notifier interface {
+	// Notifications returns a channel which receives a value when messages may be available. It
+	// returns nil if the client cannot push wakeups. The channel is valid until ctx is done.
+	Notifications(ctx context.Context) <-chan struct{}
+
+	// NextVisible returns the time until the earliest message that is not visible, because it is
+	// leased or was dequeued and not finished, becomes visible again. It returns false if there is no
+	// such message. No wakeup is pushed when the lease of a message expires, so dequeuers use it to
+	// retry the message without waiting for the poll interval.
+	NextVisible(ctx context.Context) (time.Duration, bool, error)
+}
+
+// DeadLetterClient is implemented by the queue clients which keep the messages whose processing failed
+// repeatedly in a dead-letter queue, so that they can be inspected and replayed instead of being dropped.
+// Dead-letter messages are never dequeued and do not expire.
+type DeadLetterClient interface {
+	// DeadLetter moves a dequeued message to the dead-letter queue with the error of its last attempt.
+	DeadLetter(ctx context.Context, msg *Message, lastError string) error
+
+	// ListDeadLetters lists the messages in the dead-letter queue.
+	ListDeadLetters(ctx context.Context) ([]*DeadLetterMessage, error)
+
+	// GetDeadLetter gets a message in the dead-letter queue. It returns ErrDeadLetterNotFound if the
+	// message is not in the dead-letter queue.
+	GetDeadLetter(ctx context.Context, id string) (*DeadLetterMessage, error)
+
+	// ReplayDeadLetter moves a message from the dead-letter queue back to the queue, with its dequeue
+	// count reset. It returns ErrDeadLetterNotFound if the message is not in the dead-letter queue.
+	ReplayDeadLetter(ctx context.Context, id string) error
+
+	// PurgeDeadLetter deletes a message from the dead-letter queue. It returns ErrDeadLetterNotFound if
+	// the message is not in the dead-letter queue.
+	PurgeDeadLetter(ctx context.Context, id string) error
+}
+
 // StartDequeuer starts a dequeuer to consume the message from the queue and return the output channel.
+//
+// If cli implements Notifier, the dequeuer waits for a wakeup instead of polling when the queue is
+// empty, or until the lease of a dequeued message expires, whichever comes first. It still polls
+// every NotifierPollIntervalDuration in case a wakeup is lost.
 func StartDequeuer(ctx context.Context, cli Client, opts ...DequeueOptions) (<-chan *Message, error) {
 	log := ucplog.FromContextOrDiscard(ctx)
 	out := make(chan *Message, 1)
 
+	notifier, _ := cli.(Notifier)
+	var notifications <-chan struct{}
+	if notifier != nil {
+		notifications = notifier.Notifications(ctx)
+	}
+
 	go func() {
 		for {
 			var queueconfig QueueClientConfig
@@ -76,11 +129,24 @@ func StartDequeuer(ctx context.Context, cli Client, opts ...DequeueOptions) (<-c
 				log.Error(err, "fails to dequeue the message")
 			}
 
+			interval := queueconfig.DequeueIntervalDuration
+			if notifications != nil && errors.Is(err, ErrMessageNotFound) {
+				interval = max(interval, queueconfig.notifierPollInterval())
+
+				next, ok, err := notifier.NextVisible(ctx)
+				if err != nil {
+					log.Error(err, "fails to get the next visible message")
+				} else if ok {
+					interval = min(interval, max(next, queueconfig.DequeueIntervalDuration))
+				}
+			}
+
 			select {
 			case <-ctx.Done():
 				close(out)
 				return
-			case <-time.After(queueconfig.DequeueIntervalDuration):
+			case <-notifications:
+			case <-time.After(interval):
 			}
 
 		}
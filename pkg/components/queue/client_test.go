@@ -79,3 +79,85 @@ func TestStartDequeuer(t *testing.T) {
 
 	require.Equal(t, 1, recvCnt)
 }
+
+type notifyingClient struct {
+	*MockClient
+	notifications chan struct{}
+	nextVisible   time.Duration
+}
+
+func (c *notifyingClient) Notifications(ctx context.Context) <-chan struct{} {
+	return c.notifications
+}
+
+func (c *notifyingClient) NextVisible(ctx context.Context) (time.Duration, bool, error) {
+	return c.nextVisible, c.nextVisible != 0, nil
+}
+
+func TestStartDequeuer_Notifier(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	cli := &notifyingClient{MockClient: NewMockClient(mctrl), notifications: make(chan struct{}, 1)}
+
+	emptyCh := make(chan struct{})
+	firstCall := cli.EXPECT().Dequeue(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, cfg QueueClientConfig) (*Message, error) {
+			close(emptyCh)
+			return nil, ErrMessageNotFound
+		})
+	cli.EXPECT().Dequeue(gomock.Any(), gomock.Any()).Return(&Message{Metadata: Metadata{ID: "testID"}}, nil).After(firstCall.Call)
+	cli.EXPECT().Dequeue(gomock.Any(), gomock.Any()).Return(nil, ErrMessageNotFound).AnyTimes()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	// The poll interval on an empty queue is long, so only the wakeup can trigger the next dequeue.
+	msgCh, err := StartDequeuer(ctx, cli, WithDequeueInterval(defaultTestDequeueInterval), WithNotifierPollInterval(time.Hour))
+	require.NoError(t, err)
+
+	<-emptyCh
+	cli.notifications <- struct{}{}
+
+	select {
+	case msg := <-msgCh:
+		require.Equal(t, "testID", msg.ID)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "the wakeup did not trigger a dequeue")
+	}
+}
+
+func TestStartDequeuer_Notifier_LeaseExpired(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	// The lease of a dequeued message expires soon, and no wakeup is pushed when it does.
+	cli := &notifyingClient{MockClient: NewMockClient(mctrl), notifications: make(chan struct{}), nextVisible: 50 * time.Millisecond}
+
+	firstCall := cli.EXPECT().Dequeue(gomock.Any(), gomock.Any()).Return(nil, ErrMessageNotFound)
+	cli.EXPECT().Dequeue(gomock.Any(), gomock.Any()).Return(&Message{Metadata: Metadata{ID: "testID", DequeueCount: 2}}, nil).After(firstCall.Call)
+	cli.EXPECT().Dequeue(gomock.Any(), gomock.Any()).Return(nil, ErrMessageNotFound).AnyTimes()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	// The poll interval on an empty queue is long, so only the lease expiry can trigger the next dequeue.
+	msgCh, err := StartDequeuer(ctx, cli, WithDequeueInterval(defaultTestDequeueInterval), WithNotifierPollInterval(time.Hour))
+	require.NoError(t, err)
+
+	select {
+	case msg := <-msgCh:
+		require.Equal(t, "testID", msg.ID)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "the lease expiry did not trigger a dequeue")
+	}
+}
+
+func TestNewDequeueConfig(t *testing.T) {
+	cfg := NewDequeueConfig(WithDequeueInterval(time.Second))
+	require.Equal(t, time.Second, cfg.DequeueIntervalDuration)
+	require.Equal(t, DefaultNotifierPollInterval, cfg.notifierPollInterval())
+
+	cfg = NewDequeueConfig(WithNotifierPollInterval(time.Minute))
+	require.Equal(t, time.Minute, cfg.notifierPollInterval())
+}
@@ -25,6 +25,7 @@ import (
 
 var namedQueue = &sync.Map{}
 var _ queue.Client = (*Client)(nil)
+var _ queue.DeadLetterClient = (*Client)(nil)
 
 // Client is the queue client used for dev and test purpose.
 type Client struct {
@@ -89,3 +90,32 @@ func (c *Client) ExtendMessage(ctx context.Context, msg *queue.Message) error {
 	}
 	return err
 }
+
+// DeadLetter moves the message to the dead-letter queue.
+func (c *Client) DeadLetter(ctx context.Context, msg *queue.Message, lastError string) error {
+	if msg == nil {
+		return queue.ErrEmptyMessage
+	}
+
+	return c.queue.DeadLetter(msg, lastError)
+}
+
+// ListDeadLetters lists the messages in the dead-letter queue.
+func (c *Client) ListDeadLetters(ctx context.Context) ([]*queue.DeadLetterMessage, error) {
+	return c.queue.DeadLetters(), nil
+}
+
+// GetDeadLetter gets a message in the dead-letter queue.
+func (c *Client) GetDeadLetter(ctx context.Context, id string) (*queue.DeadLetterMessage, error) {
+	return c.queue.GetDeadLetter(id)
+}
+
+// ReplayDeadLetter moves a message from the dead-letter queue back to the queue.
+func (c *Client) ReplayDeadLetter(ctx context.Context, id string) error {
+	return c.queue.Replay(id)
+}
+
+// PurgeDeadLetter deletes a message from the dead-letter queue.
+func (c *Client) PurgeDeadLetter(ctx context.Context, id string) error {
+	return c.queue.Purge(id)
+}
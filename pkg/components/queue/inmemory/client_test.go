@@ -52,3 +52,14 @@ func TestClient(t *testing.T) {
 
 	sharedtest.RunTest(t, cli, clean)
 }
+
+func TestClient_DeadLetter(t *testing.T) {
+	inmem := NewInMemQueue(sharedtest.TestMessageLockTime)
+	cli := New(inmem)
+
+	clean := func(t *testing.T) {
+		inmem.DeleteAll()
+	}
+
+	sharedtest.RunDeadLetterTest(t, cli, clean)
+}
@@ -18,6 +18,7 @@ package inmemory
 
 import (
 	"container/list"
+	"slices"
 	"sync"
 	"time"
 
@@ -44,6 +45,9 @@ type InmemQueue struct {
 	vMu sync.Mutex
 
 	lockDuration time.Duration
+
+	// deadLetters is the dead-letter queue, in the order the messages were dead-lettered. It is guarded by vMu.
+	deadLetters []*queue.DeadLetterMessage
 }
 
 func NewInMemQueue(lockDuration time.Duration) *InmemQueue {
@@ -63,6 +67,7 @@ func (q *InmemQueue) DeleteAll() {
 	q.vMu.Lock()
 	defer q.vMu.Unlock()
 	_ = q.v.Init()
+	q.deadLetters = nil
 }
 
 func (q *InmemQueue) Enqueue(msg *queue.Message) {
@@ -116,6 +121,100 @@ func (q *InmemQueue) Complete(msg *queue.Message) error {
 	return nil
 }
 
+// DeadLetter moves msg from the queue to the dead-letter queue.
+func (q *InmemQueue) DeadLetter(msg *queue.Message, lastError string) error {
+	var found *queue.Message
+	q.elementRange(func(e *list.Element, elem *element) bool {
+		if elem.val.ID == msg.ID {
+			found = elem.val
+			q.v.Remove(e)
+			return true
+		}
+		return false
+	})
+
+	if found == nil {
+		return queue.ErrInvalidMessage
+	}
+
+	q.vMu.Lock()
+	defer q.vMu.Unlock()
+	q.deadLetters = append(q.deadLetters, &queue.DeadLetterMessage{
+		Message:      *found,
+		LastError:    lastError,
+		DeadLetterAt: time.Now().UTC(),
+	})
+
+	return nil
+}
+
+// DeadLetters returns copies of the messages in the dead-letter queue.
+func (q *InmemQueue) DeadLetters() []*queue.DeadLetterMessage {
+	q.vMu.Lock()
+	defer q.vMu.Unlock()
+
+	result := make([]*queue.DeadLetterMessage, 0, len(q.deadLetters))
+	for _, msg := range q.deadLetters {
+		copied := *msg
+		result = append(result, &copied)
+	}
+	return result
+}
+
+// GetDeadLetter returns a copy of the message id in the dead-letter queue.
+func (q *InmemQueue) GetDeadLetter(id string) (*queue.DeadLetterMessage, error) {
+	q.vMu.Lock()
+	defer q.vMu.Unlock()
+
+	i := q.deadLetterIndex(id)
+	if i < 0 {
+		return nil, queue.ErrDeadLetterNotFound
+	}
+
+	copied := *q.deadLetters[i]
+	return &copied, nil
+}
+
+// Replay moves the message id from the dead-letter queue back to the queue.
+func (q *InmemQueue) Replay(id string) error {
+	q.vMu.Lock()
+	defer q.vMu.Unlock()
+
+	i := q.deadLetterIndex(id)
+	if i < 0 {
+		return queue.ErrDeadLetterNotFound
+	}
+
+	msg := q.deadLetters[i].Message
+	q.deadLetters = slices.Delete(q.deadLetters, i, i+1)
+
+	msg.DequeueCount = 0
+	msg.NextVisibleAt = time.Time{}
+	msg.ExpireAt = time.Now().UTC().Add(messageExpireDuration)
+	q.v.PushBack(&element{val: &msg, visible: true})
+
+	return nil
+}
+
+// Purge deletes the message id from the dead-letter queue.
+func (q *InmemQueue) Purge(id string) error {
+	q.vMu.Lock()
+	defer q.vMu.Unlock()
+
+	i := q.deadLetterIndex(id)
+	if i < 0 {
+		return queue.ErrDeadLetterNotFound
+	}
+
+	q.deadLetters = slices.Delete(q.deadLetters, i, i+1)
+	return nil
+}
+
+// deadLetterIndex returns the index of the message id in the dead-letter queue, or -1. The caller must hold vMu.
+func (q *InmemQueue) deadLetterIndex(id string) int {
+	return slices.IndexFunc(q.deadLetters, func(msg *queue.DeadLetterMessage) bool { return msg.ID == id })
+}
+
 func (q *InmemQueue) Extend(msg *queue.Message) error {
 	found := false
 	now := time.Now()
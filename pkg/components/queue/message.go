@@ -48,6 +48,17 @@ type Metadata struct {
 	NextVisibleAt time.Time
 }
 
+// DeadLetterMessage represents a message moved to the dead-letter queue because its processing failed
+// repeatedly. DequeueCount is the number of attempts to process the message.
+type DeadLetterMessage struct {
+	Message
+
+	// LastError is the error of the last attempt to process the message.
+	LastError string
+	// DeadLetterAt represents the time when the message was moved to the dead-letter queue.
+	DeadLetterAt time.Time
+}
+
 // NewMessage creates Message.
 func NewMessage(data any) *Message {
 	msg := &Message{
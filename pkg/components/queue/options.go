@@ -37,10 +37,25 @@ type (
 	}
 )
 
+// DefaultNotifierPollInterval is the time between 2 dequeue attempts on an empty queue whose client
+// implements Notifier, when NotifierPollIntervalDuration is not set.
+const DefaultNotifierPollInterval = time.Duration(30) * time.Second
+
 // QueueClientConfig is a configuration for queue client APIs.
 type QueueClientConfig struct {
 	// DequeueIntervalDuration is the time duration between 2 successive dequeue attempts on the queue
 	DequeueIntervalDuration time.Duration
+
+	// NotifierPollIntervalDuration is the time duration between 2 dequeue attempts on an empty queue
+	// when the queue client pushes wakeups. Defaults to DefaultNotifierPollInterval.
+	NotifierPollIntervalDuration time.Duration
+}
+
+func (cfg QueueClientConfig) notifierPollInterval() time.Duration {
+	if cfg.NotifierPollIntervalDuration == 0 {
+		return DefaultNotifierPollInterval
+	}
+	return cfg.NotifierPollIntervalDuration
 }
 
 type dequeueOptions struct {
@@ -62,6 +77,17 @@ func WithDequeueInterval(t time.Duration) DequeueOptions {
 	}
 }
 
+// WithNotifierPollInterval sets the dequeueing interval on an empty queue when the queue client
+// pushes wakeups.
+func WithNotifierPollInterval(t time.Duration) DequeueOptions {
+	return &dequeueOptions{
+		fn: func(cfg QueueClientConfig) QueueClientConfig {
+			cfg.NotifierPollIntervalDuration = t
+			return cfg
+		},
+	}
+}
+
 func (q dequeueOptions) private() {}
 
 // NewDequeueConfig returns new queue config for StartDequeuer().
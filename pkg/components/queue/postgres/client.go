@@ -0,0 +1,469 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package postgres is a PostgreSQL based queue implementation. Messages are rows of the
+// queue_messages table, which is created with the resources table (see deploy/init-db/db.sql.txt).
+// Databases created before the table existed are upgraded by Migrate when the queue provider
+// creates the client.
+//
+// We need four operations for the queue:
+//
+//  1. Enqueue: Inserts a row and sends a NOTIFY on NotificationChannel with the queue name as payload.
+//  2. Dequeue: Leases the first visible row. The row is selected with FOR UPDATE SKIP LOCKED, so
+//     concurrent clients never wait on each other or lease the same message. Leasing increments
+//     dequeue_count and moves next_visible_at to the end of the lock.
+//  3. FinishMessage: Deletes the leased row.
+//  4. ExtendMessage: Moves next_visible_at of the leased row to postpone the re-queue operation.
+//
+// All timestamps are computed by the database with now(), so clients on different nodes do not
+// depend on their clocks being in sync. dequeue_count is used as the revision of a lease:
+// FinishMessage and ExtendMessage fail with queue.ErrDequeuedMessage when the message was leased
+// again by another client after its lock expired.
+//
+// Dead-letter messages are kept in the table with dead_letter_at set. They are skipped by Dequeue and
+// are not deleted when they expire. Replaying a message clears dead_letter_at and resets dequeue_count.
+//
+// Dequeuers started with queue.StartDequeuer are woken up by the NOTIFY instead of polling, since
+// Client implements queue.Notifier when Options.Connect is set. LISTEN needs a connection that is
+// not shared with other queries, so the wakeups use a dedicated connection. A lease that expires is
+// not notified, so the dequeuers also wake up at the earliest next_visible_at of the queue.
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/radius-project/radius/pkg/components/queue"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+const (
+	// NotificationChannel is the channel notified when a message is enqueued. The payload is the
+	// queue name.
+	NotificationChannel = "radius_queue"
+
+	defaultMessageLockDuration = time.Duration(5) * time.Minute
+	defaultExpiryDuration      = time.Duration(10) * time.Hour
+	listenRetryInterval        = time.Duration(5) * time.Second
+)
+
+// PostgresAPI defines the API surface from pgx that we use. This is used to allow for easier testing.
+//
+// Keep these definitions in sync with pgxpool.Pool and pgx.Conn.
+type PostgresAPI interface {
+	// Exec executes a query without returning any rows.
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	// Query executes a query that returns rows.
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	// QueryRow executes a query that is expected to return at most one row.
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// ListenerAPI defines the API surface from pgx that we use to receive notifications.
+//
+// Keep these definitions in sync with pgx.Conn.
+type ListenerAPI interface {
+	// Exec executes a query without returning any rows.
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	// WaitForNotification waits for a notification on a channel the connection listens to.
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	// Close closes the connection.
+	Close(ctx context.Context) error
+}
+
+// Options is the options to create the PostgreSQL queue client.
+type Options struct {
+	// Name represents the name of queue.
+	Name string
+
+	// MessageLockDuration represents the duration of message lock.
+	MessageLockDuration time.Duration
+	// ExpiryDuration represents the duration of the expiry.
+	ExpiryDuration time.Duration
+
+	// Connect opens the dedicated connection used to listen for enqueued messages. If nil, the
+	// client does not push wakeups and dequeuers poll the queue.
+	Connect func(ctx context.Context) (ListenerAPI, error)
+}
+
+var _ queue.Client = (*Client)(nil)
+var _ queue.Notifier = (*Client)(nil)
+var _ queue.DeadLetterClient = (*Client)(nil)
+
+// Client is the queue client backed by PostgreSQL.
+type Client struct {
+	api  PostgresAPI
+	opts Options
+}
+
+// New creates the queue backed by PostgreSQL. name is unique name for each service which will consume the queue.
+func New(api PostgresAPI, options Options) (*Client, error) {
+	if options.Name == "" {
+		return nil, errors.New("Name is required")
+	}
+
+	if options.MessageLockDuration == time.Duration(0) {
+		options.MessageLockDuration = defaultMessageLockDuration
+	}
+
+	if options.ExpiryDuration == time.Duration(0) {
+		options.ExpiryDuration = defaultExpiryDuration
+	}
+
+	return &Client{api: api, opts: options}, nil
+}
+
+// Enqueue implements queue.Client.
+func (c *Client) Enqueue(ctx context.Context, msg *queue.Message, options ...queue.EnqueueOptions) error {
+	if msg == nil || len(msg.Data) == 0 {
+		return queue.ErrEmptyMessage
+	}
+
+	if msg.ContentType != queue.JSONContentType {
+		return queue.ErrUnsupportedContentType
+	}
+
+	// The NOTIFY is sent in the same statement, so it is delivered when the row is committed.
+	sql := `
+WITH inserted AS (
+	INSERT INTO queue_messages (id, queue_name, dequeue_count, enqueue_at, expire_at, next_visible_at, content_type, data)
+	VALUES ($1, $2, 0, now(), now() + make_interval(secs => $3), now(), $4, $5)
+	RETURNING queue_name
+)
+SELECT pg_notify($6, queue_name) FROM inserted;`
+
+	_, err := c.api.Exec(ctx, sql, uuid.New().String(), c.opts.Name, c.opts.ExpiryDuration.Seconds(), msg.ContentType, msg.Data, NotificationChannel)
+	return err
+}
+
+// Dequeue implements queue.Client.
+func (c *Client) Dequeue(ctx context.Context, cfg queue.QueueClientConfig) (*queue.Message, error) {
+	sql := `
+UPDATE queue_messages
+SET dequeue_count = dequeue_count + 1, next_visible_at = now() + make_interval(secs => $2)
+WHERE id = (
+	SELECT id FROM queue_messages
+	WHERE queue_name = $1 AND next_visible_at <= now() AND expire_at > now() AND dead_letter_at IS NULL
+	ORDER BY next_visible_at, enqueue_at
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING id, dequeue_count, enqueue_at, expire_at, next_visible_at, content_type, data;`
+
+	msg := &queue.Message{}
+	err := c.api.QueryRow(ctx, sql, c.opts.Name, c.opts.MessageLockDuration.Seconds()).Scan(
+		&msg.ID, &msg.DequeueCount, &msg.EnqueueAt, &msg.ExpireAt, &msg.NextVisibleAt, &msg.ContentType, &msg.Data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The queue is idle, so this is a good time to drop the messages nobody finished in time.
+		if err := c.deleteExpired(ctx); err != nil {
+			return nil, err
+		}
+		return nil, queue.ErrMessageNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func (c *Client) deleteExpired(ctx context.Context) error {
+	_, err := c.api.Exec(ctx, "DELETE FROM queue_messages WHERE queue_name = $1 AND expire_at <= now() AND dead_letter_at IS NULL;", c.opts.Name)
+	return err
+}
+
+// FinishMessage implements queue.Client.
+func (c *Client) FinishMessage(ctx context.Context, msg *queue.Message) error {
+	if msg == nil {
+		return queue.ErrEmptyMessage
+	}
+
+	sql := `
+WITH deleted AS (
+	DELETE FROM queue_messages
+	WHERE id = $1 AND queue_name = $2 AND dequeue_count = $3
+	RETURNING id
+)
+SELECT
+CASE
+	WHEN EXISTS (SELECT 1 FROM deleted) THEN 'Success'
+	WHEN EXISTS (SELECT 1 FROM queue_messages WHERE id = $1 AND queue_name = $2) THEN 'ErrDequeuedMessage'
+	ELSE 'ErrInvalidMessage'
+END AS result;`
+
+	result := ""
+	err := c.api.QueryRow(ctx, sql, msg.ID, c.opts.Name, msg.DequeueCount).Scan(&result)
+	if err != nil {
+		return err
+	}
+
+	return resultError(result)
+}
+
+// ExtendMessage implements queue.Client.
+func (c *Client) ExtendMessage(ctx context.Context, msg *queue.Message) error {
+	if msg == nil {
+		return queue.ErrEmptyMessage
+	}
+
+	// A message whose lock expired may already be leased by another client, which is detected by
+	// the dequeue count. A message whose lock expired and which was not leased again cannot be
+	// extended either: it is visible in the queue.
+	sql := `
+WITH current AS (
+	SELECT id, dequeue_count, next_visible_at FROM queue_messages
+	WHERE id = $1 AND queue_name = $2
+	FOR UPDATE
+), updated AS (
+	UPDATE queue_messages m
+	SET next_visible_at = now() + make_interval(secs => $4)
+	FROM current
+	WHERE m.id = current.id AND current.dequeue_count = $3 AND current.next_visible_at >= now()
+	RETURNING m.next_visible_at
+)
+SELECT
+CASE
+	WHEN EXISTS (SELECT 1 FROM updated) THEN 'Success'
+	WHEN EXISTS (SELECT 1 FROM current WHERE dequeue_count <> $3) THEN 'ErrDequeuedMessage'
+	ELSE 'ErrInvalidMessage'
+END AS result,
+(SELECT next_visible_at FROM updated) AS next_visible_at;`
+
+	result := ""
+	var nextVisibleAt *time.Time
+	err := c.api.QueryRow(ctx, sql, msg.ID, c.opts.Name, msg.DequeueCount, c.opts.MessageLockDuration.Seconds()).Scan(&result, &nextVisibleAt)
+	if err != nil {
+		return err
+	}
+
+	if err := resultError(result); err != nil {
+		return err
+	}
+
+	if nextVisibleAt != nil {
+		msg.NextVisibleAt = *nextVisibleAt
+	}
+	return nil
+}
+
+func resultError(result string) error {
+	switch result {
+	case "Success":
+		return nil
+	case "ErrDequeuedMessage":
+		return queue.ErrDequeuedMessage
+	default:
+		return queue.ErrInvalidMessage
+	}
+}
+
+// DeadLetter implements queue.DeadLetterClient.
+func (c *Client) DeadLetter(ctx context.Context, msg *queue.Message, lastError string) error {
+	if msg == nil {
+		return queue.ErrEmptyMessage
+	}
+
+	sql := `
+WITH updated AS (
+	UPDATE queue_messages
+	SET dead_letter_at = now(), last_error = $4
+	WHERE id = $1 AND queue_name = $2 AND dequeue_count = $3 AND dead_letter_at IS NULL
+	RETURNING id
+)
+SELECT
+CASE
+	WHEN EXISTS (SELECT 1 FROM updated) THEN 'Success'
+	WHEN EXISTS (SELECT 1 FROM queue_messages WHERE id = $1 AND queue_name = $2 AND dead_letter_at IS NULL) THEN 'ErrDequeuedMessage'
+	ELSE 'ErrInvalidMessage'
+END AS result;`
+
+	result := ""
+	err := c.api.QueryRow(ctx, sql, msg.ID, c.opts.Name, msg.DequeueCount, lastError).Scan(&result)
+	if err != nil {
+		return err
+	}
+
+	return resultError(result)
+}
+
+const deadLetterColumns = "id, dequeue_count, enqueue_at, expire_at, next_visible_at, content_type, data, dead_letter_at, COALESCE(last_error, '')"
+
+// ListDeadLetters implements queue.DeadLetterClient.
+func (c *Client) ListDeadLetters(ctx context.Context) ([]*queue.DeadLetterMessage, error) {
+	sql := "SELECT " + deadLetterColumns + " FROM queue_messages WHERE queue_name = $1 AND dead_letter_at IS NOT NULL ORDER BY dead_letter_at;"
+	rows, err := c.api.Query(ctx, sql, c.opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []*queue.DeadLetterMessage{}
+	for rows.Next() {
+		msg, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, msg)
+	}
+
+	return result, rows.Err()
+}
+
+// GetDeadLetter implements queue.DeadLetterClient.
+func (c *Client) GetDeadLetter(ctx context.Context, id string) (*queue.DeadLetterMessage, error) {
+	sql := "SELECT " + deadLetterColumns + " FROM queue_messages WHERE id = $1 AND queue_name = $2 AND dead_letter_at IS NOT NULL;"
+	msg, err := scanDeadLetter(c.api.QueryRow(ctx, sql, id, c.opts.Name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, queue.ErrDeadLetterNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func scanDeadLetter(row pgx.Row) (*queue.DeadLetterMessage, error) {
+	msg := &queue.DeadLetterMessage{}
+	err := row.Scan(&msg.ID, &msg.DequeueCount, &msg.EnqueueAt, &msg.ExpireAt, &msg.NextVisibleAt, &msg.ContentType, &msg.Data, &msg.DeadLetterAt, &msg.LastError)
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// ReplayDeadLetter implements queue.DeadLetterClient. The replayed message is announced like an
+// enqueued message.
+func (c *Client) ReplayDeadLetter(ctx context.Context, id string) error {
+	sql := `
+WITH replayed AS (
+	UPDATE queue_messages
+	SET dequeue_count = 0, next_visible_at = now(), expire_at = now() + make_interval(secs => $3), dead_letter_at = NULL, last_error = NULL
+	WHERE id = $1 AND queue_name = $2 AND dead_letter_at IS NOT NULL
+	RETURNING queue_name
+)
+SELECT pg_notify($4, queue_name) FROM replayed;`
+
+	tag, err := c.api.Exec(ctx, sql, id, c.opts.Name, c.opts.ExpiryDuration.Seconds(), NotificationChannel)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return queue.ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+// PurgeDeadLetter implements queue.DeadLetterClient.
+func (c *Client) PurgeDeadLetter(ctx context.Context, id string) error {
+	tag, err := c.api.Exec(ctx, "DELETE FROM queue_messages WHERE id = $1 AND queue_name = $2 AND dead_letter_at IS NOT NULL;", id, c.opts.Name)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return queue.ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+// Notifications implements queue.Notifier. It listens on NotificationChannel with a dedicated
+// connection until ctx is done, and reconnects when the connection fails. The channel also
+// receives a value after each (re)connection, since messages enqueued while the client was not
+// listening were not announced.
+func (c *Client) Notifications(ctx context.Context) <-chan struct{} {
+	if c.opts.Connect == nil {
+		return nil
+	}
+
+	notifications := make(chan struct{}, 1)
+	go func() {
+		logger := ucplog.FromContextOrDiscard(ctx)
+		for {
+			err := c.listen(ctx, notifications)
+			if ctx.Err() != nil {
+				return
+			}
+
+			logger.Error(err, "failed to listen for queue messages, retrying", "queue", c.opts.Name)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(listenRetryInterval):
+			}
+		}
+	}()
+
+	return notifications
+}
+
+func (c *Client) listen(ctx context.Context, notifications chan<- struct{}) error {
+	conn, err := c.opts.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// ctx may be done, but the connection still needs to be closed.
+		_ = conn.Close(context.Background())
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+NotificationChannel); err != nil {
+		return err
+	}
+	notify(notifications)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		if notification.Payload == c.opts.Name {
+			notify(notifications)
+		}
+	}
+}
+
+// NextVisible implements queue.Notifier. It returns the time until the earliest next_visible_at of the
+// messages of the queue that are not visible yet, computed by the database like the leases.
+func (c *Client) NextVisible(ctx context.Context) (time.Duration, bool, error) {
+	sql := `
+SELECT EXTRACT(EPOCH FROM MIN(next_visible_at) - now())::float8 FROM queue_messages
+WHERE queue_name = $1 AND next_visible_at > now() AND expire_at > now() AND dead_letter_at IS NULL;`
+
+	var seconds *float64
+	if err := c.api.QueryRow(ctx, sql, c.opts.Name).Scan(&seconds); err != nil {
+		return 0, false, err
+	}
+
+	if seconds == nil {
+		return 0, false, nil
+	}
+
+	return time.Duration(*seconds * float64(time.Second)), true, nil
+}
+
+// notify sends a wakeup without blocking. A pending wakeup is enough since the dequeuer dequeues
+// until the queue is empty.
+func notify(notifications chan<- struct{}) {
+	select {
+	case notifications <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+
+	"github.com/radius-project/radius/pkg/components/queue"
+	sharedtest "github.com/radius-project/radius/test/ucp/queuetest"
+)
+
+func TestClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	// You can get the right value for this by running the command: make db-init
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set.")
+		return
+	}
+
+	pool, err := pgxpool.New(ctx, url)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	cli, err := New(pool, Options{Name: "test-queue", MessageLockDuration: sharedtest.TestMessageLockTime})
+	require.NoError(t, err)
+
+	clear := func(t *testing.T) {
+		_, err := pool.Exec(ctx, "DELETE FROM queue_messages WHERE queue_name = $1", "test-queue")
+		require.NoError(t, err)
+	}
+
+	// The actual test logic lives in a shared package, we're just doing the setup here.
+	sharedtest.RunTest(t, cli, clear)
+	sharedtest.RunDeadLetterTest(t, cli, clear)
+}
+
+func TestClient_Notifications(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set.")
+		return
+	}
+
+	pool, err := pgxpool.New(ctx, url)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	cli, err := New(pool, Options{
+		Name: "test-notify",
+		Connect: func(ctx context.Context) (ListenerAPI, error) {
+			return pgx.Connect(ctx, url)
+		},
+	})
+	require.NoError(t, err)
+
+	notifications := cli.Notifications(ctx)
+	<-notifications // listening
+
+	require.NoError(t, cli.Enqueue(ctx, queue.NewMessage("hello")))
+	select {
+	case <-notifications:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "no notification for the enqueued message")
+	}
+
+	msg, err := cli.Dequeue(ctx, queue.QueueClientConfig{})
+	require.NoError(t, err)
+	require.NoError(t, cli.FinishMessage(ctx, msg))
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(nil, Options{})
+	require.ErrorContains(t, err, "Name is required")
+
+	cli, err := New(nil, Options{Name: "test"})
+	require.NoError(t, err)
+	require.Equal(t, defaultMessageLockDuration, cli.opts.MessageLockDuration)
+	require.Equal(t, defaultExpiryDuration, cli.opts.ExpiryDuration)
+	require.Nil(t, cli.Notifications(t.Context()), "no notifications without Connect")
+}
+
+var _ PostgresAPI = (*fakeAPI)(nil)
+
+// fakeAPI returns row for every QueryRow and tag for every Exec, and records the statements passed to Exec.
+type fakeAPI struct {
+	row  pgx.Row
+	tag  pgconn.CommandTag
+	exec []string
+}
+
+// Exec implements PostgresAPI.
+func (f *fakeAPI) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.exec = append(f.exec, sql)
+	return f.tag, nil
+}
+
+// Query implements PostgresAPI.
+func (f *fakeAPI) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+// QueryRow implements PostgresAPI.
+func (f *fakeAPI) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return f.row
+}
+
+// resultRow is a row with a result column and an optional timestamp column.
+type resultRow struct {
+	result string
+	at     *time.Time
+	err    error
+}
+
+// Scan implements pgx.Row.
+func (r *resultRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*(dest[0].(*string)) = r.result
+	if len(dest) > 1 {
+		*(dest[1].(**time.Time)) = r.at
+	}
+	return nil
+}
+
+func TestClient_Results(t *testing.T) {
+	next := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		result string
+		err    error
+	}{
+		{name: "success", result: "Success"},
+		{name: "dequeued by another client", result: "ErrDequeuedMessage", err: queue.ErrDequeuedMessage},
+		{name: "invalid message", result: "ErrInvalidMessage", err: queue.ErrInvalidMessage},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cli, err := New(&fakeAPI{row: &resultRow{result: tc.result, at: &next}}, Options{Name: "test"})
+			require.NoError(t, err)
+
+			err = cli.FinishMessage(t.Context(), &queue.Message{Metadata: queue.Metadata{ID: "1", DequeueCount: 1}})
+			require.ErrorIs(t, err, tc.err)
+
+			msg := &queue.Message{Metadata: queue.Metadata{ID: "1", DequeueCount: 1}}
+			err = cli.ExtendMessage(t.Context(), msg)
+			require.ErrorIs(t, err, tc.err)
+			if tc.err == nil {
+				require.Equal(t, next, msg.NextVisibleAt)
+			}
+		})
+	}
+}
+
+func TestClient_DeadLetter_Results(t *testing.T) {
+	for _, result := range []string{"Success", "ErrDequeuedMessage", "ErrInvalidMessage"} {
+		t.Run(result, func(t *testing.T) {
+			cli, err := New(&fakeAPI{row: &resultRow{result: result}}, Options{Name: "test"})
+			require.NoError(t, err)
+
+			err = cli.DeadLetter(t.Context(), &queue.Message{Metadata: queue.Metadata{ID: "1", DequeueCount: 1}}, "failed")
+			require.ErrorIs(t, err, resultError(result))
+		})
+	}
+}
+
+func TestClient_DeadLetter_NotFound(t *testing.T) {
+	api := &fakeAPI{row: &resultRow{err: pgx.ErrNoRows}, tag: pgconn.NewCommandTag("SELECT 0")}
+	cli, err := New(api, Options{Name: "test"})
+	require.NoError(t, err)
+
+	_, err = cli.GetDeadLetter(t.Context(), "1")
+	require.ErrorIs(t, err, queue.ErrDeadLetterNotFound)
+	err = cli.ReplayDeadLetter(t.Context(), "1")
+	require.ErrorIs(t, err, queue.ErrDeadLetterNotFound)
+
+	api.tag = pgconn.NewCommandTag("DELETE 0")
+	err = cli.PurgeDeadLetter(t.Context(), "1")
+	require.ErrorIs(t, err, queue.ErrDeadLetterNotFound)
+
+	api.tag = pgconn.NewCommandTag("DELETE 1")
+	err = cli.PurgeDeadLetter(t.Context(), "1")
+	require.NoError(t, err)
+}
+
+func TestClient_Dequeue_Empty(t *testing.T) {
+	api := &fakeAPI{row: &resultRow{err: pgx.ErrNoRows}}
+	cli, err := New(api, Options{Name: "test"})
+	require.NoError(t, err)
+
+	_, err = cli.Dequeue(t.Context(), queue.QueueClientConfig{})
+	require.ErrorIs(t, err, queue.ErrMessageNotFound)
+	require.Len(t, api.exec, 1, "expired messages are deleted when the queue is empty")
+}
+
+// secondsRow is a row with a nullable number of seconds.
+type secondsRow struct {
+	seconds *float64
+}
+
+// Scan implements pgx.Row.
+func (r *secondsRow) Scan(dest ...any) error {
+	*(dest[0].(**float64)) = r.seconds
+	return nil
+}
+
+func TestClient_NextVisible(t *testing.T) {
+	cli, err := New(&fakeAPI{row: &secondsRow{}}, Options{Name: "test"})
+	require.NoError(t, err)
+
+	_, ok, err := cli.NextVisible(t.Context())
+	require.NoError(t, err)
+	require.False(t, ok, "no message is leased")
+
+	seconds := 1.5
+	cli, err = New(&fakeAPI{row: &secondsRow{seconds: &seconds}}, Options{Name: "test"})
+	require.NoError(t, err)
+
+	next, ok, err := cli.NextVisible(t.Context())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1500*time.Millisecond, next)
+}
+
+func TestClient_Enqueue_Invalid(t *testing.T) {
+	cli, err := New(&fakeAPI{}, Options{Name: "test"})
+	require.NoError(t, err)
+
+	err = cli.Enqueue(t.Context(), &queue.Message{Data: []byte("test"), ContentType: "text/plain"})
+	require.ErrorIs(t, err, queue.ErrUnsupportedContentType)
+}
+
+var _ ListenerAPI = (*fakeListener)(nil)
+
+// fakeListener delivers notifications until it is empty and then fails with err.
+type fakeListener struct {
+	notifications chan *pgconn.Notification
+	err           error
+	closed        chan struct{}
+}
+
+// Exec implements ListenerAPI.
+func (f *fakeListener) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+// WaitForNotification implements ListenerAPI.
+func (f *fakeListener) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case n, ok := <-f.notifications:
+		if !ok {
+			return nil, f.err
+		}
+		return n, nil
+	}
+}
+
+// Close implements ListenerAPI.
+func (f *fakeListener) Close(ctx context.Context) error {
+	close(f.closed)
+	return nil
+}
+
+func TestClient_Notifications_Fake(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	listener := &fakeListener{notifications: make(chan *pgconn.Notification), closed: make(chan struct{})}
+	connects := 0
+	cli, err := New(nil, Options{
+		Name: "test",
+		Connect: func(ctx context.Context) (ListenerAPI, error) {
+			connects++
+			if connects > 1 {
+				return nil, errors.New("connection refused")
+			}
+			return listener, nil
+		},
+	})
+	require.NoError(t, err)
+
+	notifications := cli.Notifications(ctx)
+	<-notifications // listening
+
+	// Notifications for other queues are ignored.
+	listener.notifications <- &pgconn.Notification{Channel: NotificationChannel, Payload: "other"}
+	select {
+	case <-notifications:
+		require.Fail(t, "unexpected notification for another queue")
+	default:
+	}
+
+	listener.notifications <- &pgconn.Notification{Channel: NotificationChannel, Payload: "test"}
+	select {
+	case <-notifications:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "no notification")
+	}
+
+	listener.err = errors.New("connection lost")
+	close(listener.notifications)
+	select {
+	case <-listener.closed:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "connection is not closed")
+	}
+}
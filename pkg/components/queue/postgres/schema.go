@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// migrationLockID is the key of the advisory lock held while migrating the schema, so that the clients starting
+// at the same time don't race to create the same objects.
+const migrationLockID = 7262617469757351
+
+// migrations are the statements that create the queue_messages table or upgrade it to the current release. They
+// must be idempotent because they run every time a client is created. Keep them in sync with
+// deploy/init-db/db.sql.txt.
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS queue_messages (
+		id TEXT PRIMARY KEY NOT NULL,
+		queue_name TEXT NOT NULL,
+		dequeue_count INTEGER NOT NULL,
+		enqueue_at TIMESTAMP (6) WITH TIME ZONE NOT NULL,
+		expire_at TIMESTAMP (6) WITH TIME ZONE NOT NULL,
+		next_visible_at TIMESTAMP (6) WITH TIME ZONE NOT NULL,
+		content_type TEXT NOT NULL,
+		data BYTEA NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_queue_messages_visible ON queue_messages (queue_name, next_visible_at)`,
+
+	// Dead-letter queue.
+	`ALTER TABLE queue_messages ADD COLUMN IF NOT EXISTS dead_letter_at TIMESTAMP (6) WITH TIME ZONE`,
+	`ALTER TABLE queue_messages ADD COLUMN IF NOT EXISTS last_error TEXT`,
+}
+
+// Migrate creates the queue_messages table and its index, or upgrades them to the current release. The init
+// scripts of the database only run on a new volume, so a database created by an older release is upgraded here.
+func Migrate(ctx context.Context, api PostgresAPI) error {
+	// The statements are sent as a single query without arguments, so PostgreSQL runs them in one implicit
+	// transaction, which releases the lock when it ends.
+	statements := append([]string{fmt.Sprintf("SELECT pg_advisory_xact_lock(%d)", migrationLockID)}, migrations...)
+	if _, err := api.Exec(ctx, strings.Join(statements, ";\n")); err != nil {
+		return fmt.Errorf("failed to migrate the queue_messages table: %w", err)
+	}
+
+	return nil
+}
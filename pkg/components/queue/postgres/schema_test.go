@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+
+	sharedtest "github.com/radius-project/radius/test/ucp/queuetest"
+)
+
+// migrationSchema is the schema used to test the migrations without touching the tables of the other tests.
+const migrationSchema = "queue_migration_test"
+
+// newMigrationPool creates a pool whose connections use an empty migrationSchema. init is run in the schema
+// before the pool is returned, to create the tables of an older release.
+func newMigrationPool(t *testing.T, url string, init string) *pgxpool.Pool {
+	ctx := t.Context()
+
+	admin, err := pgx.Connect(ctx, url)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = admin.Close(context.Background()) })
+
+	_, err = admin.Exec(ctx, "DROP SCHEMA IF EXISTS "+migrationSchema+" CASCADE; CREATE SCHEMA "+migrationSchema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = admin.Exec(context.Background(), "DROP SCHEMA IF EXISTS "+migrationSchema+" CASCADE")
+	})
+
+	config, err := pgxpool.ParseConfig(url)
+	require.NoError(t, err)
+	config.ConnConfig.RuntimeParams["search_path"] = migrationSchema
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	if init != "" {
+		_, err = pool.Exec(ctx, init)
+		require.NoError(t, err)
+	}
+
+	return pool
+}
+
+func TestMigrate_Database(t *testing.T) {
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set.")
+		return
+	}
+
+	tests := []struct {
+		name string
+		init string
+	}{
+		{name: "new database"},
+		{name: "existing database", init: strings.Join(migrations, ";\n")},
+		// The table created before the dead-letter queue was added.
+		{name: "database without dead-letter columns", init: strings.Join(migrations[:2], ";\n")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			pool := newMigrationPool(t, url, tc.init)
+
+			// Migrating twice is a no-op.
+			require.NoError(t, Migrate(ctx, pool))
+			require.NoError(t, Migrate(ctx, pool))
+
+			cli, err := New(pool, Options{Name: "test-migrate", MessageLockDuration: sharedtest.TestMessageLockTime})
+			require.NoError(t, err)
+
+			clear := func(t *testing.T) {
+				_, err := pool.Exec(ctx, "DELETE FROM queue_messages")
+				require.NoError(t, err)
+			}
+
+			sharedtest.RunTest(t, cli, clear)
+			sharedtest.RunDeadLetterTest(t, cli, clear)
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	api := &fakeAPI{}
+	require.NoError(t, Migrate(t.Context(), api))
+
+	// The statements are sent at once, so that they run in one transaction with the lock.
+	require.Len(t, api.exec, 1)
+	require.True(t, strings.HasPrefix(api.exec[0], "SELECT pg_advisory_xact_lock("))
+	for _, migration := range migrations {
+		require.Contains(t, api.exec[0], migration)
+		require.Contains(t, migration, "IF NOT EXISTS", "migrations must be idempotent")
+	}
+}
+
+// failingAPI fails every statement.
+type failingAPI struct {
+	fakeAPI
+}
+
+// Exec implements PostgresAPI.
+func (f *failingAPI) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errors.New("permission denied")
+}
+
+func TestMigrate_Error(t *testing.T) {
+	err := Migrate(t.Context(), &failingAPI{})
+	require.ErrorContains(t, err, "failed to migrate the queue_messages table: permission denied")
+}
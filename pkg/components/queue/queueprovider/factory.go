@@ -21,10 +21,14 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	ucpv1alpha1 "github.com/radius-project/radius/pkg/components/database/apiserverstore/api/ucp.dev/v1alpha1"
+	"github.com/radius-project/radius/pkg/components/database/databaseprovider"
 	"github.com/radius-project/radius/pkg/components/queue"
 	"github.com/radius-project/radius/pkg/components/queue/apiserver"
 	qinmem "github.com/radius-project/radius/pkg/components/queue/inmemory"
+	qpostgres "github.com/radius-project/radius/pkg/components/queue/postgres"
 	"github.com/radius-project/radius/pkg/kubeutil"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
@@ -34,8 +38,9 @@ import (
 type factoryFunc func(context.Context, QueueProviderOptions) (queue.Client, error)
 
 var clientFactory = map[QueueProviderType]factoryFunc{
-	TypeInmemory:  initInMemory,
-	TypeAPIServer: initAPIServer,
+	TypeInmemory:   initInMemory,
+	TypeAPIServer:  initAPIServer,
+	TypePostgreSQL: initPostgreSQL,
 }
 
 func initInMemory(ctx context.Context, opt QueueProviderOptions) (queue.Client, error) {
@@ -79,3 +84,32 @@ func initAPIServer(ctx context.Context, opt QueueProviderOptions) (queue.Client,
 		Namespace: opt.APIServer.Namespace,
 	})
 }
+
+func initPostgreSQL(ctx context.Context, opt QueueProviderOptions) (queue.Client, error) {
+	if opt.PostgreSQL.URL == "" {
+		return nil, errors.New("failed to initialize PostgreSQL queue client: URL is required")
+	}
+
+	url, err := databaseprovider.ExpandEnvURL(opt.PostgreSQL.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize PostgreSQL queue client: %w", err)
+	}
+
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize PostgreSQL queue client: %w", err)
+	}
+
+	if err := qpostgres.Migrate(ctx, pool); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to initialize PostgreSQL queue client: %w", err)
+	}
+
+	return qpostgres.New(pool, qpostgres.Options{
+		Name: opt.Name,
+		// LISTEN needs a dedicated connection, pooled connections are shared between queries.
+		Connect: func(ctx context.Context) (qpostgres.ListenerAPI, error) {
+			return pgx.Connect(ctx, url)
+		},
+	})
+}
@@ -24,11 +24,18 @@ type QueueProviderOptions struct {
 	// Name represents the unique name of queue.
 	Name string `yaml:"name"`
 
+	// ManagedQueues is the list of the names of the other queues, using the same provider, which the
+	// service manages, for example through the dead-letter admin API. (Optional)
+	ManagedQueues []string `yaml:"managedQueues,omitempty"`
+
 	// InMemory represents inmemory queue client options. (Optional)
 	InMemory *InMemoryQueueOptions `yaml:"inMemoryQueue,omitempty"`
 
 	// APIServer configures options for the Kubernetes APIServer store. (Optional)
 	APIServer APIServerOptions `yaml:"apiserver,omitempty"`
+
+	// PostgreSQL configures options for the PostgreSQL queue. (Optional)
+	PostgreSQL PostgreSQLOptions `yaml:"postgresql,omitempty"`
 }
 
 // InMemoryQueueOptions represents the inmemory queue options.
@@ -44,3 +51,18 @@ type APIServerOptions struct {
 	// Namespace configures the Kubernetes namespace used for data-storage. The namespace must already exist.
 	Namespace string `yaml:"namespace"`
 }
+
+// PostgreSQLOptions represents options for the PostgreSQL queue. Messages are stored in the
+// queue_messages table of the database.
+type PostgreSQLOptions struct {
+	// URL is the connection information for the PostgreSQL database in URL format.
+	//
+	// The URL should be formatted according to:
+	// https://www.postgresql.org/docs/current/libpq-connect.html#LIBPQ-CONNSTRING-URIS
+	//
+	// The URL can contain secrets like passwords so it must be treated as sensitive.
+	//
+	// In place of the actual URL, you can substitute an environment variable by using the format:
+	// 	${ENV_VAR_NAME}
+	URL string `yaml:"url"`
+}
@@ -19,6 +19,7 @@ package queueprovider
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 
 	"github.com/radius-project/radius/pkg/components/queue"
@@ -34,6 +35,9 @@ type QueueProvider struct {
 
 	queueClient queue.Client
 	once        sync.Once
+
+	namedClients   map[string]queue.Client
+	namedClientsMu sync.Mutex
 }
 
 // New creates new QueueProvider instance.
@@ -60,6 +64,58 @@ func (p *QueueProvider) GetClient(ctx context.Context) (queue.Client, error) {
 	return p.queueClient, err
 }
 
+// GetNamedClient gets the client of the queue name, which uses the same provider options as the client
+// returned by GetClient. It is used to manage the queues consumed by other services. Only the queue of the
+// provider and the queues in ManagedQueues are accepted; it returns queue.ErrQueueNotFound for any other
+// name. The clients of the managed queues are created together the first time one of them is requested.
+func (p *QueueProvider) GetNamedClient(ctx context.Context, name string) (queue.Client, error) {
+	if name == p.options.Name {
+		return p.GetClient(ctx)
+	}
+
+	if !slices.Contains(p.options.ManagedQueues, name) {
+		return nil, queue.ErrQueueNotFound
+	}
+
+	p.namedClientsMu.Lock()
+	defer p.namedClientsMu.Unlock()
+
+	if err := p.initNamedClients(ctx); err != nil {
+		return nil, err
+	}
+
+	return p.namedClients[name], nil
+}
+
+// initNamedClients creates the clients of the managed queues which were not created yet. The caller must
+// hold namedClientsMu.
+func (p *QueueProvider) initNamedClients(ctx context.Context) error {
+	fn, ok := clientFactory[p.options.Provider]
+	if !ok {
+		return ErrUnsupportedQueueProvider
+	}
+
+	if p.namedClients == nil {
+		p.namedClients = map[string]queue.Client{}
+	}
+
+	for _, name := range p.options.ManagedQueues {
+		if _, ok := p.namedClients[name]; ok {
+			continue
+		}
+
+		options := p.options
+		options.Name = name
+		client, err := fn(ctx, options)
+		if err != nil {
+			return err
+		}
+		p.namedClients[name] = client
+	}
+
+	return nil
+}
+
 // SetClient sets the queue client for the QueueProvider. This should be used by tests that need to mock the queue client.
 func (p *QueueProvider) SetClient(client queue.Client) {
 	p.queueClient = client
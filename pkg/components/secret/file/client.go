@@ -0,0 +1,219 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/radius-project/radius/pkg/components/secret"
+	"github.com/radius-project/radius/pkg/crypto/encryption"
+	"github.com/radius-project/radius/pkg/kubernetes"
+)
+
+const (
+	// DefaultKeyFileName is the name of the key file in the secrets directory when no key file is configured.
+	// It starts with a '.' so that it can't collide with a secret name.
+	DefaultKeyFileName = ".key"
+
+	// secretFileExtension is the extension of the files holding the secrets.
+	secretFileExtension = ".json"
+)
+
+var _ secret.Client = (*Client)(nil)
+
+// Client implements secret storage with encrypted files. It is suitable for standalone and development
+// installations that don't run on Kubernetes.
+//
+// Each secret is stored in the file '<name>.json' of a directory, encrypted with AES-256-GCM. The secret name
+// is used as associated data, so a file can't be renamed to another secret. The key is read from a key file
+// holding a base64-encoded 32-byte key, which is generated when it doesn't exist.
+type Client struct {
+	dir     string
+	keyFile string
+
+	lock      sync.Mutex
+	encryptor *encryption.Encryptor
+}
+
+// NewClient creates a new Client that stores the secrets in dir. keyFile is the path of the key file, and
+// defaults to the DefaultKeyFileName file of dir if it is empty. Store the key file outside of dir to keep the
+// key and the secrets apart.
+func NewClient(dir string, keyFile string) (*Client, error) {
+	if dir == "" {
+		return nil, errors.New("the secrets directory is required")
+	}
+	if keyFile == "" {
+		keyFile = filepath.Join(dir, DefaultKeyFileName)
+	}
+
+	return &Client{dir: dir, keyFile: keyFile}, nil
+}
+
+// Save encrypts the secret and writes it to its file, replacing the previous value.
+func (c *Client) Save(ctx context.Context, name string, value []byte) error {
+	if name == "" {
+		return &secret.ErrInvalid{Message: "invalid argument. 'name' is required"}
+	}
+
+	if value == nil {
+		return &secret.ErrInvalid{Message: "invalid argument. 'value' is required"}
+	}
+
+	if valid, _ := kubernetes.IsValidObjectName(name); !valid {
+		return &secret.ErrInvalid{Message: "invalid name: " + name}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	encryptor, err := c.getEncryptor()
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := encryptor.Encrypt(value, []byte(name))
+	if err != nil {
+		return err
+	}
+
+	return writeFile(c.path(name), encrypted)
+}
+
+// Delete deletes the file of the secret, returning ErrNotFound if it does not exist.
+func (c *Client) Delete(ctx context.Context, name string) error {
+	if name == "" {
+		return &secret.ErrInvalid{Message: "invalid argument. 'name' is required"}
+	}
+
+	if valid, _ := kubernetes.IsValidObjectName(name); !valid {
+		return &secret.ErrInvalid{Message: "invalid name: " + name}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	err := os.Remove(c.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return &secret.ErrNotFound{}
+	}
+
+	return err
+}
+
+// Get reads and decrypts the file of the secret, returning ErrNotFound if it does not exist.
+func (c *Client) Get(ctx context.Context, name string) ([]byte, error) {
+	if name == "" {
+		return nil, &secret.ErrInvalid{Message: "invalid argument. 'name' is required"}
+	}
+
+	if valid, _ := kubernetes.IsValidObjectName(name); !valid {
+		return nil, &secret.ErrInvalid{Message: "invalid name: " + name}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	data, err := os.ReadFile(c.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, &secret.ErrNotFound{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	encryptor, err := c.getEncryptor()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := encryptor.Decrypt(data, []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret %q: %w", name, err)
+	}
+
+	return value, nil
+}
+
+// path returns the path of the file of the secret name.
+func (c *Client) path(name string) string {
+	return filepath.Join(c.dir, name+secretFileExtension)
+}
+
+// getEncryptor returns the encryptor of the key file, generating the key when the file doesn't exist. The
+// lock must be held.
+func (c *Client) getEncryptor() (*encryption.Encryptor, error) {
+	if c.encryptor != nil {
+		return c.encryptor, nil
+	}
+
+	data, err := os.ReadFile(c.keyFile)
+	if errors.Is(err, os.ErrNotExist) {
+		key, err := encryption.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		data = []byte(base64.StdEncoding.EncodeToString(key))
+		if err := writeFile(c.keyFile, data); err != nil {
+			return nil, fmt.Errorf("failed to write the secret key file: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read the secret key file: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the secret key file: %w", err)
+	}
+
+	c.encryptor, err = encryption.NewEncryptor(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.encryptor, nil
+}
+
+// writeFile atomically writes data to a file that only the owner can read, creating its directory if needed.
+func writeFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/radius-project/radius/pkg/components/secret"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	secretName = "test-secret-name"
+)
+
+func Test_NewClient(t *testing.T) {
+	_, err := NewClient("", "")
+	require.EqualError(t, err, "the secrets directory is required")
+
+	client, err := NewClient("/var/lib/radius/secrets", "")
+	require.NoError(t, err)
+	require.Equal(t, "/var/lib/radius/secrets/.key", client.keyFile)
+}
+
+func Test_Client(t *testing.T) {
+	ctx := t.Context()
+	dir := filepath.Join(t.TempDir(), "secrets")
+
+	client, err := NewClient(dir, "")
+	require.NoError(t, err)
+
+	_, err = client.Get(ctx, secretName)
+	require.ErrorIs(t, err, &secret.ErrNotFound{})
+
+	require.NoError(t, client.Save(ctx, secretName, []byte(`{"clientSecret":"a"}`)))
+	require.NoError(t, client.Save(ctx, secretName, []byte(`{"clientSecret":"b"}`)))
+
+	// The file is encrypted and only readable by the owner.
+	data, err := os.ReadFile(filepath.Join(dir, secretName+".json"))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "clientSecret")
+
+	info, err := os.Stat(filepath.Join(dir, secretName+".json"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	value, err := client.Get(ctx, secretName)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"clientSecret":"b"}`), value)
+
+	// A new client reads the secrets with the generated key.
+	client, err = NewClient(dir, "")
+	require.NoError(t, err)
+	value, err = client.Get(ctx, secretName)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"clientSecret":"b"}`), value)
+
+	require.NoError(t, client.Delete(ctx, secretName))
+
+	_, err = client.Get(ctx, secretName)
+	require.ErrorIs(t, err, &secret.ErrNotFound{})
+
+	err = client.Delete(ctx, secretName)
+	require.ErrorIs(t, err, &secret.ErrNotFound{})
+}
+
+func Test_Client_KeyFile(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	keyFile := filepath.Join(t.TempDir(), "key")
+
+	client, err := NewClient(dir, keyFile)
+	require.NoError(t, err)
+	require.NoError(t, client.Save(ctx, secretName, []byte("value")))
+	require.FileExists(t, keyFile)
+	require.NoFileExists(t, filepath.Join(dir, DefaultKeyFileName))
+
+	t.Run("wrong key", func(t *testing.T) {
+		other, err := NewClient(dir, filepath.Join(t.TempDir(), "other"))
+		require.NoError(t, err)
+
+		_, err = other.Get(ctx, secretName)
+		require.ErrorContains(t, err, "failed to decrypt secret")
+	})
+
+	t.Run("renamed secret", func(t *testing.T) {
+		require.NoError(t, os.Rename(filepath.Join(dir, secretName+".json"), filepath.Join(dir, "other.json")))
+
+		_, err := client.Get(ctx, "other")
+		require.ErrorContains(t, err, "failed to decrypt secret")
+	})
+
+	t.Run("invalid key file", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(invalid, []byte("not a key"), 0600))
+
+		client, err := NewClient(dir, invalid)
+		require.NoError(t, err)
+
+		err = client.Save(ctx, secretName, []byte("value"))
+		require.ErrorContains(t, err, "failed to decode the secret key file")
+	})
+}
+
+func Test_Client_InvalidArguments(t *testing.T) {
+	ctx := t.Context()
+
+	client, err := NewClient(t.TempDir(), "")
+	require.NoError(t, err)
+
+	err = client.Save(ctx, "../other", []byte("value"))
+	require.ErrorIs(t, err, &secret.ErrInvalid{})
+
+	err = client.Save(ctx, secretName, nil)
+	require.Equal(t, &secret.ErrInvalid{Message: "invalid argument. 'value' is required"}, err)
+
+	_, err = client.Get(ctx, "")
+	require.Equal(t, &secret.ErrInvalid{Message: "invalid argument. 'name' is required"}, err)
+
+	err = client.Delete(ctx, "Invalid_Name")
+	require.ErrorIs(t, err, &secret.ErrInvalid{})
+}
@@ -18,10 +18,13 @@ package secretprovider
 
 import (
 	"context"
+	"os"
 
 	"github.com/radius-project/radius/pkg/components/secret"
+	"github.com/radius-project/radius/pkg/components/secret/file"
 	"github.com/radius-project/radius/pkg/components/secret/inmemory"
 	kubernetes_client "github.com/radius-project/radius/pkg/components/secret/kubernetes"
+	"github.com/radius-project/radius/pkg/components/secret/vault"
 	"github.com/radius-project/radius/pkg/kubeutil"
 	"k8s.io/kubectl/pkg/scheme"
 	controller_runtime "sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,8 +35,13 @@ type secretFactoryFunc func(context.Context, SecretProviderOptions) (secret.Clie
 var secretClientFactory = map[SecretProviderType]secretFactoryFunc{
 	TypeKubernetesSecret: initKubernetesSecretClient,
 	TypeInMemorySecret:   initInMemorySecretClient,
+	TypeVaultSecret:      initVaultSecretClient,
+	TypeFileSecret:       initFileSecretClient,
 }
 
+// VaultTokenEnvVar is the environment variable holding the Vault token when no token file is configured.
+const VaultTokenEnvVar = "VAULT_TOKEN" //nolint:gosec // This is an environment variable name, not credentials
+
 func initKubernetesSecretClient(ctx context.Context, opt SecretProviderOptions) (secret.Client, error) {
 	s := scheme.Scheme
 	cfg, err := kubeutil.NewClientConfig(&kubeutil.ConfigOptions{
@@ -55,3 +63,29 @@ func initKubernetesSecretClient(ctx context.Context, opt SecretProviderOptions)
 func initInMemorySecretClient(ctx context.Context, opt SecretProviderOptions) (secret.Client, error) {
 	return &inmemory.Client{}, nil
 }
+
+func initVaultSecretClient(ctx context.Context, opt SecretProviderOptions) (secret.Client, error) {
+	options := vault.Options{
+		Address:    opt.Vault.Address,
+		Namespace:  opt.Vault.Namespace,
+		MountPath:  opt.Vault.MountPath,
+		PathPrefix: opt.Vault.PathPrefix,
+		TokenFile:  opt.Vault.TokenFile,
+	}
+	if options.TokenFile == "" {
+		options.Token = os.Getenv(VaultTokenEnvVar)
+	}
+	client, err := vault.NewClient(options)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func initFileSecretClient(ctx context.Context, opt SecretProviderOptions) (secret.Client, error) {
+	client, err := file.NewClient(opt.File.Path, opt.File.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
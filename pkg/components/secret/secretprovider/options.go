@@ -23,4 +23,39 @@ type SecretProviderOptions struct {
 
 	// InMemory configures options for the in-memory secret store.
 	InMemory struct{} `yaml:"inmemory,omitempty"`
+
+	// Vault configures options for the HashiCorp Vault secret store.
+	Vault VaultOptions `yaml:"vault,omitempty"`
+
+	// File configures options for the encrypted file secret store.
+	File FileOptions `yaml:"file,omitempty"`
+}
+
+// VaultOptions represents options for the HashiCorp Vault secret store, which uses the KV version 2 secrets engine.
+type VaultOptions struct {
+	// Address is the address of the Vault server.
+	Address string `yaml:"address"`
+
+	// Namespace is the optional Vault Enterprise namespace.
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// MountPath is the mount path of the KV version 2 secrets engine. Defaults to "secret" if not specified.
+	MountPath string `yaml:"mountPath,omitempty"`
+
+	// PathPrefix is the path under which the secrets are stored. Defaults to "radius" if not specified.
+	PathPrefix string `yaml:"pathPrefix,omitempty"`
+
+	// TokenFile is the path of a file holding the Vault token. When it is not specified, the token is read
+	// from the VAULT_TOKEN environment variable.
+	TokenFile string `yaml:"tokenFile,omitempty"`
+}
+
+// FileOptions represents options for the encrypted file secret store.
+type FileOptions struct {
+	// Path is the directory holding the secret files.
+	Path string `yaml:"path"`
+
+	// KeyFile is the path of the file holding the base64-encoded encryption key. The key is generated if the
+	// file doesn't exist. Defaults to the ".key" file of Path if not specified.
+	KeyFile string `yaml:"keyFile,omitempty"`
 }
@@ -19,6 +19,8 @@ package secretprovider
 import (
 	"testing"
 
+	"github.com/radius-project/radius/pkg/components/secret/file"
+	"github.com/radius-project/radius/pkg/components/secret/vault"
 	"github.com/stretchr/testify/require"
 )
 
@@ -30,3 +32,35 @@ func TestGetClient_InvalidType(t *testing.T) {
 	require.Equal(t, err, ErrUnsupportedSecretProvider)
 	require.Nil(t, client)
 }
+
+func TestGetClient_Vault(t *testing.T) {
+	t.Setenv(VaultTokenEnvVar, "test-token")
+
+	secretProvider := NewSecretProvider(SecretProviderOptions{
+		Provider: TypeVaultSecret,
+		Vault:    VaultOptions{Address: "http://localhost:8200"},
+	})
+	client, err := secretProvider.GetClient(t.Context())
+	require.NoError(t, err)
+	require.IsType(t, &vault.Client{}, client)
+
+	secretProvider = NewSecretProvider(SecretProviderOptions{Provider: TypeVaultSecret})
+	client, err = secretProvider.GetClient(t.Context())
+	require.EqualError(t, err, "the Vault address is required")
+	require.Nil(t, client)
+}
+
+func TestGetClient_File(t *testing.T) {
+	secretProvider := NewSecretProvider(SecretProviderOptions{
+		Provider: TypeFileSecret,
+		File:     FileOptions{Path: t.TempDir()},
+	})
+	client, err := secretProvider.GetClient(t.Context())
+	require.NoError(t, err)
+	require.IsType(t, &file.Client{}, client)
+
+	require.NoError(t, client.Save(t.Context(), "test-secret", []byte("value")))
+	value, err := client.Get(t.Context(), "test-secret")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+}
@@ -25,4 +25,10 @@ const (
 
 	// TypeInMemorySecret represents the in-memory secret provider.
 	TypeInMemorySecret SecretProviderType = "inmemory"
+
+	// TypeVaultSecret represents the HashiCorp Vault KV version 2 secret provider.
+	TypeVaultSecret SecretProviderType = "vault"
+
+	// TypeFileSecret represents the encrypted file secret provider.
+	TypeFileSecret SecretProviderType = "file"
 )
@@ -0,0 +1,265 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/radius-project/radius/pkg/components/secret"
+	"github.com/radius-project/radius/pkg/kubernetes"
+)
+
+const (
+	// DefaultMountPath is the default mount path of the KV version 2 secrets engine.
+	DefaultMountPath = "secret"
+
+	// DefaultPathPrefix is the default path under which the secrets are stored in the secrets engine.
+	DefaultPathPrefix = "radius"
+
+	// tokenHeader is the header holding the Vault token.
+	tokenHeader = "X-Vault-Token" //nolint:gosec // This is a header name, not credentials
+
+	// namespaceHeader is the header holding the Vault Enterprise namespace.
+	namespaceHeader = "X-Vault-Namespace"
+
+	// maxResponseSize limits the size of the responses read from Vault.
+	maxResponseSize = 4 << 20
+)
+
+var _ secret.Client = (*Client)(nil)
+
+// Options contains options for creating a Client.
+type Options struct {
+	// Address is the address of the Vault server, for example "https://vault.vault-system:8200".
+	Address string
+
+	// Token is the Vault token used to authenticate requests.
+	Token string
+
+	// TokenFile is the path of a file holding the Vault token, for example a token written by the Vault agent.
+	// The file is read on every request so that renewed tokens are picked up. It takes precedence over Token.
+	TokenFile string
+
+	// Namespace is the optional Vault Enterprise namespace.
+	Namespace string
+
+	// MountPath is the mount path of the KV version 2 secrets engine. Defaults to DefaultMountPath if not specified.
+	MountPath string
+
+	// PathPrefix is the path under which the secrets are stored. Defaults to DefaultPathPrefix if not specified.
+	PathPrefix string
+
+	// HTTPClient is the client used to call Vault. Defaults to http.DefaultClient if not specified.
+	HTTPClient *http.Client
+}
+
+// Client implements secret storage with the KV version 2 secrets engine of HashiCorp Vault. Any server
+// implementing the same HTTP API can be used.
+//
+// Each secret is stored at '<mountPath>/data/<pathPrefix>/<name>' with the value base64-encoded in the
+// 'value' field. Deleting a secret deletes all of its versions and its metadata.
+type Client struct {
+	options Options
+}
+
+// NewClient creates a new Client with the given options.
+func NewClient(opts Options) (*Client, error) {
+	if opts.Address == "" {
+		return nil, errors.New("the Vault address is required")
+	}
+	if opts.MountPath == "" {
+		opts.MountPath = DefaultMountPath
+	}
+	if opts.PathPrefix == "" {
+		opts.PathPrefix = DefaultPathPrefix
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	return &Client{options: opts}, nil
+}
+
+// kvData is the data of a secret in the KV secrets engine.
+type kvData struct {
+	Value string `json:"value"`
+}
+
+// kvRequest is the request body of the KV data endpoint.
+type kvRequest struct {
+	Data kvData `json:"data"`
+}
+
+// kvResponse is the response body of the KV data endpoint.
+type kvResponse struct {
+	Data struct {
+		Data *kvData `json:"data"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// Save saves the secret as the new version of the secret in Vault.
+func (c *Client) Save(ctx context.Context, name string, value []byte) error {
+	if name == "" {
+		return &secret.ErrInvalid{Message: "invalid argument. 'name' is required"}
+	}
+
+	if value == nil {
+		return &secret.ErrInvalid{Message: "invalid argument. 'value' is required"}
+	}
+
+	if valid, _ := kubernetes.IsValidObjectName(name); !valid {
+		return &secret.ErrInvalid{Message: "invalid name: " + name}
+	}
+
+	body := kvRequest{Data: kvData{Value: base64.StdEncoding.EncodeToString(value)}}
+	_, err := c.call(ctx, http.MethodPost, "data", name, body, nil)
+	return err
+}
+
+// Delete deletes all versions and the metadata of the secret, returning ErrNotFound if the secret does not exist.
+func (c *Client) Delete(ctx context.Context, name string) error {
+	if name == "" {
+		return &secret.ErrInvalid{Message: "invalid argument. 'name' is required"}
+	}
+
+	if valid, _ := kubernetes.IsValidObjectName(name); !valid {
+		return &secret.ErrInvalid{Message: "invalid name: " + name}
+	}
+
+	// Vault doesn't report whether the deleted secret existed, so check it first.
+	found, err := c.call(ctx, http.MethodGet, "metadata", name, nil, nil)
+	if err != nil {
+		return err
+	} else if !found {
+		return &secret.ErrNotFound{}
+	}
+
+	_, err = c.call(ctx, http.MethodDelete, "metadata", name, nil, nil)
+	return err
+}
+
+// Get returns the latest version of the secret, or ErrNotFound if the secret does not exist or its latest
+// version was deleted.
+func (c *Client) Get(ctx context.Context, name string) ([]byte, error) {
+	if name == "" {
+		return nil, &secret.ErrInvalid{Message: "invalid argument. 'name' is required"}
+	}
+
+	if valid, _ := kubernetes.IsValidObjectName(name); !valid {
+		return nil, &secret.ErrInvalid{Message: "invalid name: " + name}
+	}
+
+	response := &kvResponse{}
+	found, err := c.call(ctx, http.MethodGet, "data", name, nil, response)
+	if err != nil {
+		return nil, err
+	} else if !found || response.Data.Data == nil {
+		return nil, &secret.ErrNotFound{}
+	}
+
+	value, err := base64.StdEncoding.DecodeString(response.Data.Data.Value)
+	if err != nil {
+		return nil, fmt.Errorf("vault secret %q has an invalid value: %w", name, err)
+	}
+
+	return value, nil
+}
+
+// token returns the Vault token from TokenFile or Token.
+func (c *Client) token() (string, error) {
+	if c.options.TokenFile == "" {
+		return c.options.Token, nil
+	}
+
+	token, err := os.ReadFile(c.options.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the Vault token file: %w", err)
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
+
+// call sends a request to the data or metadata endpoint of the secret name and decodes the response into
+// response if it is not nil. It returns false if Vault responds with 404 Not Found.
+func (c *Client) call(ctx context.Context, method string, endpoint string, name string, body any, response *kvResponse) (bool, error) {
+	u, err := url.JoinPath(c.options.Address, "v1", c.options.MountPath, endpoint, c.options.PathPrefix, name)
+	if err != nil {
+		return false, fmt.Errorf("invalid Vault address %q: %w", c.options.Address, err)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return false, err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return false, err
+	}
+	token, err := c.token()
+	if err != nil {
+		return false, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(tokenHeader, token)
+	if c.options.Namespace != "" {
+		req.Header.Set(namespaceHeader, c.options.Namespace)
+	}
+
+	resp, err := c.options.HTTPClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("vault %s %s failed: %w", method, endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// The errors reported by Vault never contain the secret value.
+		errorResponse := &kvResponse{}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(errorResponse)
+		return false, fmt.Errorf("vault %s %s failed with status %d: %s", method, endpoint, resp.StatusCode, strings.Join(errorResponse.Errors, "; "))
+	}
+
+	if response != nil {
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(response); err != nil {
+			return false, fmt.Errorf("vault %s %s returned an invalid response: %w", method, endpoint, err)
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/radius-project/radius/pkg/components/secret"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	secretName = "test-secret-name"
+	testToken  = "test-token"
+)
+
+// newFakeKVServer starts a stand-in for the KV version 2 secrets engine of a Vault dev server, mounted at
+// "secret". It returns the server and the stored values by path.
+func newFakeKVServer(t *testing.T) (*httptest.Server, map[string][]string) {
+	var lock sync.Mutex
+	secrets := map[string][]string{}
+
+	writeError := func(w http.ResponseWriter, status int, message string) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{message}})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if r.Header.Get(tokenHeader) != testToken {
+			writeError(w, http.StatusForbidden, "permission denied")
+			return
+		}
+
+		var endpoint, path string
+		if p, ok := strings.CutPrefix(r.URL.Path, "/v1/secret/data/"); ok {
+			endpoint, path = "data", p
+		} else if p, ok := strings.CutPrefix(r.URL.Path, "/v1/secret/metadata/"); ok {
+			endpoint, path = "metadata", p
+		} else {
+			writeError(w, http.StatusNotFound, "no handler for route")
+			return
+		}
+
+		versions, found := secrets[path]
+		switch {
+		case endpoint == "data" && r.Method == http.MethodPost:
+			body := kvRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			secrets[path] = append(versions, body.Data.Value)
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"version": len(secrets[path])}})
+
+		case endpoint == "data" && r.Method == http.MethodGet:
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"data":     map[string]any{"value": versions[len(versions)-1]},
+					"metadata": map[string]any{"version": len(versions)},
+				},
+			})
+
+		case endpoint == "metadata" && r.Method == http.MethodGet:
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"current_version": len(versions)}})
+
+		case endpoint == "metadata" && r.Method == http.MethodDelete:
+			delete(secrets, path)
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "unsupported operation")
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, secrets
+}
+
+func Test_NewClient(t *testing.T) {
+	_, err := NewClient(Options{})
+	require.EqualError(t, err, "the Vault address is required")
+
+	client, err := NewClient(Options{Address: "http://localhost:8200"})
+	require.NoError(t, err)
+	require.Equal(t, DefaultMountPath, client.options.MountPath)
+	require.Equal(t, DefaultPathPrefix, client.options.PathPrefix)
+}
+
+func Test_Client(t *testing.T) {
+	ctx := t.Context()
+	server, secrets := newFakeKVServer(t)
+
+	client, err := NewClient(Options{Address: server.URL, Token: testToken})
+	require.NoError(t, err)
+
+	_, err = client.Get(ctx, secretName)
+	require.ErrorIs(t, err, &secret.ErrNotFound{})
+
+	require.NoError(t, client.Save(ctx, secretName, []byte(`{"clientSecret":"a"}`)))
+	require.NoError(t, client.Save(ctx, secretName, []byte(`{"clientSecret":"b"}`)))
+	require.Len(t, secrets["radius/"+secretName], 2)
+
+	value, err := client.Get(ctx, secretName)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"clientSecret":"b"}`), value)
+
+	require.NoError(t, client.Delete(ctx, secretName))
+	require.Empty(t, secrets)
+
+	_, err = client.Get(ctx, secretName)
+	require.ErrorIs(t, err, &secret.ErrNotFound{})
+
+	err = client.Delete(ctx, secretName)
+	require.ErrorIs(t, err, &secret.ErrNotFound{})
+}
+
+func Test_Client_Options(t *testing.T) {
+	ctx := t.Context()
+	server, secrets := newFakeKVServer(t)
+
+	t.Run("token file and path prefix", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte(testToken+"\n"), 0600))
+
+		client, err := NewClient(Options{Address: server.URL, Token: "ignored", TokenFile: tokenFile, PathPrefix: "radius/ucp"})
+		require.NoError(t, err)
+
+		require.NoError(t, client.Save(ctx, secretName, []byte("value")))
+		require.Contains(t, secrets, "radius/ucp/"+secretName)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		client, err := NewClient(Options{Address: server.URL, Token: "wrong-token"})
+		require.NoError(t, err)
+
+		_, err = client.Get(ctx, secretName)
+		require.EqualError(t, err, "vault GET data failed with status 403: permission denied")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		client, err := NewClient(Options{Address: server.URL, Token: testToken})
+		require.NoError(t, err)
+
+		err = client.Save(ctx, "../other", []byte("value"))
+		require.ErrorIs(t, err, &secret.ErrInvalid{})
+
+		err = client.Save(ctx, secretName, nil)
+		require.Equal(t, &secret.ErrInvalid{Message: "invalid argument. 'value' is required"}, err)
+
+		_, err = client.Get(ctx, "")
+		require.Equal(t, &secret.ErrInvalid{Message: "invalid argument. 'name' is required"}, err)
+	})
+}
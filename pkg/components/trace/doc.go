@@ -26,6 +26,8 @@ In addition, trace package introduces the below helpers:
 * StartCustomSpan(ctx, spanName, tracerName, attr, spanKind) starts a new span with the given names and attributes.
 * StartProducerSpan(ctx, spanName, tracerName) starts a new Producer span with the given names.
 * StartConsumerSpan(ctx, spanName, tracerName) starts a new Consumer span with the given names.
+* StartRecipeSpan(ctx, spanName, recipe, definition) starts a new span for a phase of recipe execution with the recipe attributes.
+* EndSpan(span, err) sets the status of the span from err and ends it.
 
 
 # Examples
@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// RecipeNameAttrKey is the span attribute key for the recipe name.
+	RecipeNameAttrKey = attribute.Key("recipe.name")
+
+	// RecipeDriverAttrKey is the span attribute key for the recipe driver.
+	RecipeDriverAttrKey = attribute.Key("recipe.driver")
+
+	// RecipeTemplatePathAttrKey is the span attribute key for the recipe template path.
+	RecipeTemplatePathAttrKey = attribute.Key("recipe.template_path")
+
+	// ResourceIDAttrKey is the span attribute key for the ID of the resource the recipe is deployed for.
+	ResourceIDAttrKey = attribute.Key(ucplog.LogFieldResourceID)
+)
+
+// RecipeAttributes returns the span attributes describing a recipe: its name, driver, template path and the ID of the
+// resource it is deployed for. Attributes with empty values are omitted.
+func RecipeAttributes(recipe *recipes.ResourceMetadata, definition *recipes.EnvironmentDefinition) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{}
+
+	name := ""
+	if recipe != nil {
+		name = recipe.Name
+		if recipe.ResourceID != "" {
+			attrs = append(attrs, ResourceIDAttrKey.String(recipe.ResourceID))
+		}
+	}
+
+	if definition != nil {
+		if name == "" {
+			name = definition.Name
+		}
+		if definition.Driver != "" {
+			attrs = append(attrs, RecipeDriverAttrKey.String(definition.Driver))
+		}
+		if definition.TemplatePath != "" {
+			attrs = append(attrs, RecipeTemplatePathAttrKey.String(definition.TemplatePath))
+		}
+	}
+
+	if name != "" {
+		attrs = append(attrs, RecipeNameAttrKey.String(name))
+	}
+
+	return attrs
+}
+
+// StartRecipeSpan starts an internal span on the backend tracer for a phase of recipe execution, with the attributes
+// returned by RecipeAttributes. The span is parented by the span in ctx, which for recipes deployed by the async worker
+// is the span of the queued operation, so the recipe phases are part of the trace of the originating request.
+func StartRecipeSpan(ctx context.Context, spanName string, recipe *recipes.ResourceMetadata, definition *recipes.EnvironmentDefinition) (context.Context, trace.Span) {
+	return StartCustomSpan(ctx, spanName, BackendTracerName, RecipeAttributes(recipe, definition), trace.WithSpanKind(trace.SpanKindInternal))
+}
+
+// EndSpan sets the status of the span from err, records err as an exception event if it is not nil, and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	} else {
+		span.SetStatus(otelcodes.Ok, "")
+	}
+
+	span.End()
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRecipeAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		recipe     *recipes.ResourceMetadata
+		definition *recipes.EnvironmentDefinition
+		expected   []attribute.KeyValue
+	}{
+		{
+			name:     "nil",
+			expected: []attribute.KeyValue{},
+		},
+		{
+			name: "recipe and definition",
+			recipe: &recipes.ResourceMetadata{
+				Name:       "default",
+				ResourceID: "/planes/radius/local/resourceGroups/test/providers/Applications.Datastores/redisCaches/redis",
+			},
+			definition: &recipes.EnvironmentDefinition{
+				Name:         "default",
+				Driver:       recipes.TemplateKindTerraform,
+				TemplatePath: "Azure/redis/azurerm",
+			},
+			expected: []attribute.KeyValue{
+				ResourceIDAttrKey.String("/planes/radius/local/resourceGroups/test/providers/Applications.Datastores/redisCaches/redis"),
+				RecipeDriverAttrKey.String("terraform"),
+				RecipeTemplatePathAttrKey.String("Azure/redis/azurerm"),
+				RecipeNameAttrKey.String("default"),
+			},
+		},
+		{
+			name: "name from definition",
+			definition: &recipes.EnvironmentDefinition{
+				Name:   "mongo",
+				Driver: recipes.TemplateKindBicep,
+			},
+			expected: []attribute.KeyValue{
+				RecipeDriverAttrKey.String("bicep"),
+				RecipeNameAttrKey.String("mongo"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, RecipeAttributes(tt.recipe, tt.definition))
+		})
+	}
+}
+
+func TestStartRecipeSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	recipe := &recipes.ResourceMetadata{Name: "default"}
+	definition := &recipes.EnvironmentDefinition{Driver: recipes.TemplateKindBicep, TemplatePath: "ghcr.io/radius-project/recipes/redis:latest"}
+
+	ctx, parent := StartRecipeSpan(t.Context(), "recipeengine.Execute", recipe, nil)
+	_, child := StartRecipeSpan(ctx, "bicepdriver.Execute", recipe, definition)
+	EndSpan(child, errors.New("deployment failed"))
+	EndSpan(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	childSpan, parentSpan := spans[0], spans[1]
+	require.Equal(t, "bicepdriver.Execute", childSpan.Name())
+	require.Equal(t, parentSpan.SpanContext().TraceID(), childSpan.SpanContext().TraceID())
+	require.Equal(t, parentSpan.SpanContext().SpanID(), childSpan.Parent().SpanID())
+	require.Contains(t, childSpan.Attributes(), RecipeDriverAttrKey.String("bicep"))
+	require.Contains(t, childSpan.Attributes(), RecipeNameAttrKey.String("default"))
+
+	require.Equal(t, otelcodes.Error, childSpan.Status().Code)
+	require.Equal(t, "deployment failed", childSpan.Status().Description)
+	require.Len(t, childSpan.Events(), 1)
+
+	require.Equal(t, otelcodes.Ok, parentSpan.Status().Code)
+}
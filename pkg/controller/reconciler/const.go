@@ -22,6 +22,9 @@ const (
 	// PollingDelay is the amount of time to wait between polling for the status of a resource.
 	PollingDelay time.Duration = 5 * time.Second
 
+	// WatchResyncDelay is the amount of time to wait between checks of an operation whose resource is watched.
+	WatchResyncDelay time.Duration = 1 * time.Minute
+
 	// DeleteRetryDelay is the default RequeueAfter used when a DeploymentResource
 	// delete operation returns a transient error. Using a fixed bound instead of
 	// returning the error avoids controller-runtime's exponential rate-limiter,
@@ -65,6 +65,13 @@ type DeploymentReconciler struct {
 
 	// DelayInterval is the amount of time to wait between operations.
 	DelayInterval time.Duration
+
+	// ResyncInterval is the amount of time to wait between checks of an operation whose resource is watched.
+	// Changes to the resource trigger a reconcile immediately, so this is only a safety net.
+	ResyncInterval time.Duration
+
+	// watcher triggers a reconcile when a resource with an operation in progress changes.
+	watcher *resourceWatcher
 }
 
 // Reconcile is the main reconciliation loop for the Deployment resource.
@@ -87,7 +94,8 @@ func (r *DeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// Our algorithm is as follows:
 	//
 	// 1. Check if we have an "operation" in progress. If so, check it's status.
-	//   a. If the operation is still in progress, then queue another reconcile (polling).
+	//   a. If the operation is still in progress, then wait for the resource to change (watching), or
+	//      queue another reconcile (polling) if the resource can't be watched.
 	//   b. If the operation completed successfully then update the status and continue processing (happy-path).
 	//   c. If the operation failed then update the status and continue processing (retry).
 	// 2. If the deployment is being deleted then process deletion.
@@ -168,10 +176,11 @@ func (r *DeploymentReconciler) reconcileOperation(ctx context.Context, deploymen
 		}
 
 		if !poller.Done() {
-			return ctrl.Result{Requeue: true, RequeueAfter: r.requeueDelay()}, nil
+			return ctrl.Result{Requeue: true, RequeueAfter: r.operationDelay(ctx, deployment, annotations)}, nil
 		}
 
 		// If we get here, the operation is complete.
+		r.untrackOperation(deployment, annotations)
 		_, err = poller.Result(ctx)
 		if err != nil {
 			// Operation failed, reset state and retry.
@@ -207,10 +216,11 @@ func (r *DeploymentReconciler) reconcileOperation(ctx context.Context, deploymen
 		}
 
 		if !poller.Done() {
-			return ctrl.Result{Requeue: true, RequeueAfter: r.requeueDelay()}, nil
+			return ctrl.Result{Requeue: true, RequeueAfter: r.operationDelay(ctx, deployment, annotations)}, nil
 		}
 
 		// If we get here, the operation is complete.
+		r.untrackOperation(deployment, annotations)
 		_, err = poller.Result(ctx)
 		if err != nil {
 			// Operation failed, reset state and retry.
@@ -325,7 +335,7 @@ func (r *DeploymentReconciler) reconcileUpdate(ctx context.Context, deployment *
 			return ctrl.Result{}, err
 		}
 
-		return ctrl.Result{Requeue: true, RequeueAfter: r.requeueDelay()}, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: r.operationDelay(ctx, deployment, annotations)}, nil
 	} else if deletePoller != nil {
 		// We've successfully started an operation. Update the status and requeue.
 		token, err := deletePoller.ResumeToken()
@@ -340,7 +350,7 @@ func (r *DeploymentReconciler) reconcileUpdate(ctx context.Context, deployment *
 			return ctrl.Result{}, err
 		}
 
-		return ctrl.Result{Requeue: true, RequeueAfter: r.requeueDelay()}, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: r.operationDelay(ctx, deployment, annotations)}, nil
 	}
 
 	// If we get here then it means we can process the result of the operation.
@@ -382,7 +392,7 @@ func (r *DeploymentReconciler) reconcileDelete(ctx context.Context, deployment *
 			return ctrl.Result{}, err
 		}
 
-		return ctrl.Result{Requeue: true, RequeueAfter: r.requeueDelay()}, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: r.operationDelay(ctx, deployment, annotations)}, nil
 	}
 
 	logger.Info("Resource is deleted.")
@@ -695,6 +705,40 @@ func (r *DeploymentReconciler) requeueDelay() time.Duration {
 	return delay
 }
 
+// operationDelay returns the amount of time to wait before checking the operation in progress again. If the
+// resource of the operation can be watched then the Deployment is reconciled as soon as the resource changes,
+// otherwise we fall back to polling.
+func (r *DeploymentReconciler) operationDelay(ctx context.Context, deployment *appsv1.Deployment, annotations *deploymentAnnotations) time.Duration {
+	if r.watcher == nil || !r.watcher.Track(ctx, operationResourceID(deployment, annotations), client.ObjectKeyFromObject(deployment)) {
+		return r.requeueDelay()
+	}
+
+	delay := r.ResyncInterval
+	if delay == 0 {
+		delay = WatchResyncDelay
+	}
+
+	return delay
+}
+
+// untrackOperation stops watching the resource of a completed operation.
+func (r *DeploymentReconciler) untrackOperation(deployment *appsv1.Deployment, annotations *deploymentAnnotations) {
+	if r.watcher != nil {
+		r.watcher.Untrack(operationResourceID(deployment, annotations))
+	}
+}
+
+// operationResourceID returns the ID of the resource targeted by the operation in progress.
+func operationResourceID(deployment *appsv1.Deployment, annotations *deploymentAnnotations) string {
+	// A DELETE operation targets the existing container, which could be in a different scope if the
+	// environment or application changed.
+	if annotations.Status.Operation.OperationKind == radappiov1alpha3.OperationKindDelete && annotations.Status.Container != "" {
+		return annotations.Status.Container
+	}
+
+	return annotations.Status.Scope + "/providers/Applications.Core/containers/" + deployment.Name
+}
+
 const indexField = "spec.recipe-reference"
 
 func (r *DeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -717,9 +761,15 @@ func (r *DeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	r.watcher = newResourceWatcher(r.Radius)
+	if err := mgr.Add(r.watcher); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&appsv1.Deployment{}).
 		Watches(&radappiov1alpha3.Recipe{}, handler.EnqueueRequestsFromMapFunc(r.findDeploymentsForRecipe), builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		WatchesRawSource(r.watcher.Source()).
 		Owns(&corev1.Secret{}).
 		Complete(r)
 }
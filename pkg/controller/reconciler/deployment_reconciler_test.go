@@ -49,6 +49,10 @@ const (
 )
 
 func SetupDeploymentTest(t *testing.T) (*mockRadiusClient, client.Client) {
+	return setupDeploymentTestWithIntervals(t, deploymentTestControllerDelayInterval, 0)
+}
+
+func setupDeploymentTestWithIntervals(t *testing.T, delayInterval time.Duration, resyncInterval time.Duration) (*mockRadiusClient, client.Client) {
 	SkipWithoutEnvironment(t)
 
 	// Shut down the manager when the test exits.
@@ -70,11 +74,12 @@ func SetupDeploymentTest(t *testing.T) (*mockRadiusClient, client.Client) {
 	radius := NewMockRadiusClient()
 	//nolint:staticcheck // SA1019: GetEventRecorderFor is deprecated but migration to new events API requires significant refactoring
 	err = (&DeploymentReconciler{
-		Client:        mgr.GetClient(),
-		Scheme:        mgr.GetScheme(),
-		EventRecorder: mgr.GetEventRecorderFor("deployment-controller"),
-		Radius:        radius,
-		DelayInterval: deploymentTestControllerDelayInterval,
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		EventRecorder:  mgr.GetEventRecorderFor("deployment-controller"),
+		Radius:         radius,
+		DelayInterval:  delayInterval,
+		ResyncInterval: resyncInterval,
 	}).SetupWithManager(mgr)
 	require.NoError(t, err)
 
@@ -244,6 +249,47 @@ func Test_DeploymentReconciler_RadiusEnabled_ThenDeploymentDeleted(t *testing.T)
 	waitForDeploymentDeleted(t, client, name)
 }
 
+func Test_DeploymentReconciler_OperationCompletion_DrivenByWatch(t *testing.T) {
+	ctx := t.Context()
+
+	// Neither polling nor resync will happen during the test, so progress depends on watch events.
+	radius, client := setupDeploymentTestWithIntervals(t, time.Hour, time.Hour)
+
+	name := types.NamespacedName{Namespace: "deployment-watch", Name: "test-deployment-watch"}
+	err := client.Create(ctx, &corev1.Namespace{ObjectMeta: ctrl.ObjectMeta{Name: name.Namespace}})
+	require.NoError(t, err)
+
+	createEnvironment(radius, "default", "default")
+
+	deployment := makeDeployment(name)
+	deployment.Annotations[AnnotationRadiusEnabled] = "true"
+	err = client.Create(ctx, deployment)
+	require.NoError(t, err)
+
+	annotations := waitForStateUpdating(t, client, name)
+
+	// Completing the operation sends a change of the container to the watch of the resource group.
+	radius.CompleteOperation(annotations.Status.Operation.ResumeToken, nil)
+	annotations = waitForStateReady(t, client, name)
+	require.Equal(t, "/planes/radius/local/resourceGroups/default/providers/Applications.Core/containers/"+name.Name, annotations.Status.Container)
+
+	err = client.Delete(ctx, deployment)
+	require.NoError(t, err)
+
+	annotations = waitForStateDeleting(t, client, name)
+
+	// Losing the watch reconciles the Deployment, which starts watching again.
+	radius.CloseWatches()
+	require.Eventually(t, func() bool {
+		radius.lock.Lock()
+		defer radius.lock.Unlock()
+		return len(radius.watches) == 1
+	}, deploymentTestWaitDuration, deploymentTestWaitInterval, "watch was not restarted")
+
+	radius.CompleteOperation(annotations.Status.Operation.ResumeToken, nil)
+	waitForDeploymentDeleted(t, client, name)
+}
+
 func Test_DeploymentReconciler_ChangeEnvironmentAndApplication(t *testing.T) {
 	ctx := t.Context()
 	radius, client := SetupDeploymentTest(t)
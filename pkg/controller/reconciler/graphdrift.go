@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/radius-project/radius/pkg/armrpc/hostoptions"
+	cligraph "github.com/radius-project/radius/pkg/cli/graph"
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/graph/persistence"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+const (
+	// DefaultGraphDriftInterval is the time between two drift checks when none is configured.
+	DefaultGraphDriftInterval = 10 * time.Minute
+
+	// defaultGraphDriftBranch is the branch whose modeled graph is compared when none is configured.
+	defaultGraphDriftBranch = "main"
+)
+
+// GraphDriftDetector periodically compares the modeled graph of applications, as saved by
+// 'rad app graph' for a branch, with their deployed graph. The result is recorded as the
+// GraphDrift condition of each application.
+type GraphDriftDetector struct {
+	// Applications reads deployed graphs and records conditions.
+	Applications *corerpv20250801preview.ApplicationsClient
+
+	// NewGraphStore returns the store of the radius-graph archive at the given location.
+	NewGraphStore func(archive string) (persistence.Store, error)
+
+	// Targets are the applications to check.
+	Targets []hostoptions.GraphDriftApplication
+
+	// Interval is the time between two checks.
+	Interval time.Duration
+}
+
+// NewGraphDriftDetector creates a GraphDriftDetector from the controller configuration.
+func NewGraphDriftDetector(options hostoptions.GraphDriftOptions, applications *corerpv20250801preview.ApplicationsClient, newGraphStore func(archive string) (persistence.Store, error)) (*GraphDriftDetector, error) {
+	interval := DefaultGraphDriftInterval
+	if options.Interval != "" {
+		var err error
+		interval, err = time.ParseDuration(options.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid graphDrift.interval %q: %w", options.Interval, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("invalid graphDrift.interval %q: must be positive", options.Interval)
+		}
+	}
+
+	for i, target := range options.Applications {
+		if _, err := resources.ParseResource(target.ID); err != nil {
+			return nil, fmt.Errorf("invalid graphDrift.applications[%d].id %q: %w", i, target.ID, err)
+		}
+		if target.Archive == "" {
+			return nil, fmt.Errorf("graphDrift.applications[%d].archive is required", i)
+		}
+	}
+
+	return &GraphDriftDetector{
+		Applications:  applications,
+		NewGraphStore: newGraphStore,
+		Targets:       options.Applications,
+		Interval:      interval,
+	}, nil
+}
+
+// SetupWithManager registers the detector to run with the controller manager.
+func (d *GraphDriftDetector) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(d)
+}
+
+// Start checks every target immediately and then once per Interval, until ctx is done. It
+// implements manager.Runnable.
+func (d *GraphDriftDetector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	for {
+		d.CheckAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll checks every target. A failure is logged and does not stop the other checks.
+func (d *GraphDriftDetector) CheckAll(ctx context.Context) {
+	logger := ucplog.FromContextOrDiscard(ctx)
+	for _, target := range d.Targets {
+		if err := d.Check(ctx, target); err != nil {
+			logger.Error(err, "Failed to check application graph drift", "application", target.ID)
+		}
+	}
+}
+
+// Check compares the modeled and the deployed graph of target and records the result as the
+// GraphDrift condition of the application. When the graphs cannot be compared, the condition is
+// Unknown.
+func (d *GraphDriftDetector) Check(ctx context.Context, target hostoptions.GraphDriftApplication) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	id, err := resources.ParseResource(target.ID)
+	if err != nil {
+		return err
+	}
+	branch := target.Branch
+	if branch == "" {
+		branch = defaultGraphDriftBranch
+	}
+
+	condition := d.compare(ctx, id, target.Archive, branch)
+	logger.Info("Checked application graph drift", "application", target.ID, "branch", branch,
+		"status", *condition.Status, "reason", *condition.Reason)
+
+	_, err = d.Applications.UpdateConditions(ctx, id.RootScope(), id.Name(), corerpv20250801preview.UpdateConditionsRequest{
+		Conditions: []*corerpv20250801preview.ApplicationCondition{condition},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update conditions: %w", err)
+	}
+	return nil
+}
+
+// compare returns the GraphDrift condition of the application.
+func (d *GraphDriftDetector) compare(ctx context.Context, id resources.ID, archive string, branch string) *corerpv20250801preview.ApplicationCondition {
+	store, err := d.NewGraphStore(archive)
+	if err != nil {
+		return cligraph.DriftUnknownCondition(cligraph.DriftReasonCheckFailed, fmt.Sprintf("Failed to open graph archive %q: %v", archive, err))
+	}
+
+	modeled, err := store.Load(ctx, cligraph.ModeledGraphKey(branch))
+	if errors.Is(err, persistence.ErrNotFound) {
+		return cligraph.DriftUnknownCondition(cligraph.DriftReasonModeledGraphNotFound, fmt.Sprintf("No modeled graph is saved for branch %q.", branch))
+	} else if err != nil {
+		return cligraph.DriftUnknownCondition(cligraph.DriftReasonCheckFailed, fmt.Sprintf("Failed to load the modeled graph for branch %q: %v", branch, err))
+	}
+
+	deployed, err := d.Applications.GetGraph(ctx, id.RootScope(), id.Name(), corerpv20250801preview.GetGraphRequest{}, nil)
+	if err != nil {
+		return cligraph.DriftUnknownCondition(cligraph.DriftReasonCheckFailed, fmt.Sprintf("Failed to get the deployed graph: %v", err))
+	}
+
+	report, err := cligraph.DetectDrift(modeled, &deployed.ApplicationGraphResponse)
+	if err != nil {
+		return cligraph.DriftUnknownCondition(cligraph.DriftReasonCheckFailed, fmt.Sprintf("Failed to compare the graphs: %v", err))
+	}
+	return report.Condition()
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	armpolicy "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/policy"
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/radius-project/radius/pkg/armrpc/hostoptions"
+	cligraph "github.com/radius-project/radius/pkg/cli/graph"
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	corerpfake "github.com/radius-project/radius/pkg/corerp/api/v20250801preview/fake"
+	"github.com/radius-project/radius/pkg/graph/persistence"
+	"github.com/radius-project/radius/pkg/graph/persistence/graphdb"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/stretchr/testify/require"
+)
+
+const graphDriftAppID = "/planes/radius/local/resourcegroups/default/providers/Radius.Core/applications/myapp"
+
+func graphDriftResource(name string, image string) *corerpv20250801preview.ApplicationGraphResource {
+	return &corerpv20250801preview.ApplicationGraphResource{
+		ID:                to.Ptr("/planes/radius/local/resourcegroups/default/providers/Radius.Compute/containers/" + name),
+		Name:              to.Ptr(name),
+		Type:              to.Ptr("Radius.Compute/containers"),
+		ProvisioningState: to.Ptr("Succeeded"),
+		Properties:        map[string]any{"image": image},
+		Connections:       []*corerpv20250801preview.ApplicationGraphConnection{},
+	}
+}
+
+// newGraphDriftApplicationsClient returns an applications client that serves deployed for every
+// application and records the conditions it receives.
+func newGraphDriftApplicationsClient(t *testing.T, deployed *corerpv20250801preview.ApplicationGraphResponse, recorded *[]*corerpv20250801preview.ApplicationCondition) *corerpv20250801preview.ApplicationsClient {
+	server := corerpfake.ApplicationsServer{
+		GetGraph: func(ctx context.Context, rootScope string, applicationName string, body corerpv20250801preview.GetGraphRequest, options *corerpv20250801preview.ApplicationsClientGetGraphOptions) (resp azfake.Responder[corerpv20250801preview.ApplicationsClientGetGraphResponse], errResp azfake.ErrorResponder) {
+			resp.SetResponse(http.StatusOK, corerpv20250801preview.ApplicationsClientGetGraphResponse{ApplicationGraphResponse: *deployed}, nil)
+			return
+		},
+		UpdateConditions: func(ctx context.Context, rootScope string, applicationName string, body corerpv20250801preview.UpdateConditionsRequest, options *corerpv20250801preview.ApplicationsClientUpdateConditionsOptions) (resp azfake.Responder[corerpv20250801preview.ApplicationsClientUpdateConditionsResponse], errResp azfake.ErrorResponder) {
+			if applicationName != "myapp" {
+				errResp.SetResponseError(http.StatusNotFound, "NotFound")
+				return
+			}
+			*recorded = append(*recorded, body.Conditions...)
+			resp.SetResponse(http.StatusOK, corerpv20250801preview.ApplicationsClientUpdateConditionsResponse{}, nil)
+			return
+		},
+	}
+
+	client, err := corerpv20250801preview.NewApplicationsClient(&azfake.TokenCredential{}, &armpolicy.ClientOptions{
+		ClientOptions: policy.ClientOptions{Transport: corerpfake.NewApplicationsServerTransport(&server)},
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func Test_NewGraphDriftDetector(t *testing.T) {
+	detector, err := NewGraphDriftDetector(hostoptions.GraphDriftOptions{}, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, DefaultGraphDriftInterval, detector.Interval)
+
+	detector, err = NewGraphDriftDetector(hostoptions.GraphDriftOptions{Interval: "30s"}, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, detector.Interval)
+
+	_, err = NewGraphDriftDetector(hostoptions.GraphDriftOptions{Interval: "soon"}, nil, nil)
+	require.ErrorContains(t, err, "invalid graphDrift.interval")
+
+	_, err = NewGraphDriftDetector(hostoptions.GraphDriftOptions{Applications: []hostoptions.GraphDriftApplication{{ID: "myapp", Archive: "file:///tmp"}}}, nil, nil)
+	require.ErrorContains(t, err, "invalid graphDrift.applications[0].id")
+
+	_, err = NewGraphDriftDetector(hostoptions.GraphDriftOptions{Applications: []hostoptions.GraphDriftApplication{{ID: graphDriftAppID}}}, nil, nil)
+	require.ErrorContains(t, err, "graphDrift.applications[0].archive is required")
+}
+
+func Test_GraphDriftDetector_Check(t *testing.T) {
+	modeled := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{graphDriftResource("frontend", "frontend:1")},
+	}
+
+	tests := []struct {
+		name     string
+		branch   string
+		deployed *corerpv20250801preview.ApplicationGraphResponse
+		status   corerpv20250801preview.ConditionStatus
+		reason   string
+	}{
+		{
+			name:     "in sync",
+			deployed: modeled,
+			status:   corerpv20250801preview.ConditionStatusFalse,
+			reason:   cligraph.DriftReasonInSync,
+		},
+		{
+			name: "drifted",
+			deployed: &corerpv20250801preview.ApplicationGraphResponse{
+				Resources: []*corerpv20250801preview.ApplicationGraphResource{graphDriftResource("frontend", "frontend:2")},
+			},
+			status: corerpv20250801preview.ConditionStatusTrue,
+			reason: cligraph.DriftReasonDrifted,
+		},
+		{
+			name:     "modeled graph not found",
+			branch:   "feature",
+			deployed: modeled,
+			status:   corerpv20250801preview.ConditionStatusUnknown,
+			reason:   cligraph.DriftReasonModeledGraphNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := graphdb.NewStore(nil)
+			require.NoError(t, store.Save(t.Context(), cligraph.ModeledGraphKey("main"), modeled, persistence.SaveOptions{}))
+
+			recorded := []*corerpv20250801preview.ApplicationCondition{}
+			detector := &GraphDriftDetector{
+				Applications: newGraphDriftApplicationsClient(t, tc.deployed, &recorded),
+				NewGraphStore: func(archive string) (persistence.Store, error) {
+					if archive != "file:///tmp/radius-graph" {
+						return nil, errors.New("unexpected archive " + archive)
+					}
+					return store, nil
+				},
+			}
+
+			err := detector.Check(t.Context(), hostoptions.GraphDriftApplication{ID: graphDriftAppID, Archive: "file:///tmp/radius-graph", Branch: tc.branch})
+			require.NoError(t, err)
+
+			require.Len(t, recorded, 1)
+			require.Equal(t, cligraph.DriftConditionType, *recorded[0].Type)
+			require.Equal(t, tc.status, *recorded[0].Status)
+			require.Equal(t, tc.reason, *recorded[0].Reason)
+		})
+	}
+
+	t.Run("archive unavailable", func(t *testing.T) {
+		recorded := []*corerpv20250801preview.ApplicationCondition{}
+		detector := &GraphDriftDetector{
+			Applications: newGraphDriftApplicationsClient(t, modeled, &recorded),
+			NewGraphStore: func(archive string) (persistence.Store, error) {
+				return nil, errors.New("unreachable")
+			},
+		}
+
+		err := detector.Check(t.Context(), hostoptions.GraphDriftApplication{ID: graphDriftAppID, Archive: "s3://bucket"})
+		require.NoError(t, err)
+		require.Len(t, recorded, 1)
+		require.Equal(t, corerpv20250801preview.ConditionStatusUnknown, *recorded[0].Status)
+		require.Equal(t, cligraph.DriftReasonCheckFailed, *recorded[0].Reason)
+		require.Contains(t, *recorded[0].Message, "unreachable")
+	})
+}
@@ -19,6 +19,7 @@ package reconciler
 import (
 	"context"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
 
@@ -26,10 +27,13 @@ import (
 	azcoreruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/google/uuid"
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/cli/clients"
 	"github.com/radius-project/radius/pkg/cli/clients_new/generated"
 	corerpv20231001preview "github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
 	sdkclients "github.com/radius-project/radius/pkg/sdk/clients"
 	ucpv20231001preview "github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/radius-project/radius/pkg/ucp/trackedresource"
 )
 
 // This file contains mocks for the RadiusClient interface.
@@ -42,6 +46,7 @@ func NewMockRadiusClient() *mockRadiusClient {
 		groups:       map[string]ucpv20231001preview.ResourceGroupResource{},
 		resources:    map[string]generated.GenericResource{},
 		operations:   map[string]*sdkclients.OperationState{},
+		watches:      []*mockWatch{},
 
 		lock: &sync.Mutex{},
 	}
@@ -56,10 +61,40 @@ type mockRadiusClient struct {
 	groups       map[string]ucpv20231001preview.ResourceGroupResource
 	resources    map[string]generated.GenericResource
 	operations   map[string]*sdkclients.OperationState
+	watches      []*mockWatch
 
 	lock *sync.Mutex
 }
 
+type mockWatch struct {
+	scope   string
+	pending chan clients.ResourceChange
+	changes chan clients.ResourceChange
+	done    chan struct{}
+	once    *sync.Once
+}
+
+func (w *mockWatch) run() {
+	defer close(w.changes)
+
+	for {
+		select {
+		case change := <-w.pending:
+			select {
+			case w.changes <- change:
+			case <-w.done:
+				return
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *mockWatch) stop() {
+	w.once.Do(func() { close(w.done) })
+}
+
 func (rc *mockRadiusClient) Update(exec func()) {
 	rc.lock.Lock()
 	defer rc.lock.Unlock()
@@ -94,6 +129,62 @@ func (rc *mockRadiusClient) Resources(scope string, resourceType string) Resourc
 	return &mockResourceClient{mock: rc, scope: scope, resourceType: resourceType}
 }
 
+func (rc *mockRadiusClient) Watch(ctx context.Context, scope string) (<-chan clients.ResourceChange, error) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	watch := &mockWatch{
+		scope:   scope,
+		pending: make(chan clients.ResourceChange, 100),
+		changes: make(chan clients.ResourceChange),
+		done:    make(chan struct{}),
+		once:    &sync.Once{},
+	}
+	rc.watches = append(rc.watches, watch)
+
+	go watch.run()
+	go func() {
+		select {
+		case <-ctx.Done():
+			watch.stop()
+		case <-watch.done:
+		}
+
+		rc.lock.Lock()
+		defer rc.lock.Unlock()
+
+		rc.watches = slices.DeleteFunc(rc.watches, func(w *mockWatch) bool { return w == watch })
+	}()
+
+	return watch.changes, nil
+}
+
+// Notify sends a change of the resource with the given ID to the watches of its resource group, the same way
+// UCP reports a change of the tracking entry of the resource.
+//
+// The caller must hold the lock.
+func (rc *mockRadiusClient) Notify(resourceID string, changeType string) {
+	id := resources.MustParse(resourceID)
+	change := clients.ResourceChange{Type: changeType, ID: trackedresource.IDFor(id).String()}
+	for _, watch := range rc.watches {
+		if strings.EqualFold(watch.scope, id.RootScope()) {
+			watch.pending <- change
+		}
+	}
+}
+
+// CloseWatches ends all watches as if the connection to the server was lost.
+func (rc *mockRadiusClient) CloseWatches() {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	for _, watch := range rc.watches {
+		watch.stop()
+	}
+
+	rc.watches = []*mockWatch{}
+}
+
 func (rc *mockRadiusClient) CompleteOperation(operationID string, update func(state *sdkclients.OperationState)) {
 	rc.lock.Lock()
 	defer rc.lock.Unlock()
@@ -115,6 +206,9 @@ func (rc *mockRadiusClient) CompleteOperation(operationID string, update func(st
 		delete(rc.containers, state.ResourceID)
 		delete(rc.groups, state.ResourceID)
 		delete(rc.resources, state.ResourceID)
+		rc.Notify(state.ResourceID, "Deleted")
+	} else {
+		rc.Notify(state.ResourceID, "Updated")
 	}
 }
 
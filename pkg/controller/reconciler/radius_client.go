@@ -20,6 +20,7 @@ import (
 	"context"
 
 	aztoken "github.com/radius-project/radius/pkg/azure/tokencredentials"
+	"github.com/radius-project/radius/pkg/cli/clients"
 	"github.com/radius-project/radius/pkg/cli/clients_new/generated"
 	corerpv20231001preview "github.com/radius-project/radius/pkg/corerp/api/v20231001preview"
 	"github.com/radius-project/radius/pkg/sdk"
@@ -34,6 +35,10 @@ type RadiusClient interface {
 	Environments(scope string) EnvironmentClient
 	Groups(scope string) ResourceGroupClient
 	Resources(scope string, resourceType string) ResourceClient
+
+	// Watch streams the changes to the resources in the resource group identified by scope. The channel is closed
+	// when ctx is done or when the connection to the server is lost.
+	Watch(ctx context.Context, scope string) (<-chan clients.ResourceChange, error)
 }
 
 type ApplicationClient interface {
@@ -123,6 +128,16 @@ func (c *RadiusClientImpl) Resources(scope string, resourceType string) Resource
 	return &ResourceClientImpl{inner: gc}
 }
 
+func (c *RadiusClientImpl) Watch(ctx context.Context, scope string) (<-chan clients.ResourceChange, error) {
+	parsed, err := resources.ParseScope(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	mc := &clients.UCPApplicationsManagementClient{RootScope: scope, ClientOptions: sdk.NewClientOptions(c.connection)}
+	return mc.WatchResourcesInResourceGroup(ctx, parsed.FindScope("radius"), parsed.FindScope("resourceGroups"))
+}
+
 var _ ApplicationClient = (*ApplicationClientImpl)(nil)
 
 type ApplicationClientImpl struct {
@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/radius-project/radius/pkg/cli/clients"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/radius-project/radius/pkg/ucp/trackedresource"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+// resourceWatcher watches the resource groups of Radius resources with an operation in progress, and triggers
+// a reconcile of the Deployment that started the operation whenever the resource changes.
+//
+// UCP tracks each resource in its resource group, and updates the tracking entry when an operation on the resource
+// completes. Watching the tracking entries lets the controller react to completion instead of polling for it.
+type resourceWatcher struct {
+	radius RadiusClient
+	events chan event.TypedGenericEvent[*appsv1.Deployment]
+
+	lock *sync.Mutex
+
+	// ctx is the lifetime of the watches. It is nil until the watcher is started by the manager.
+	ctx context.Context
+
+	// groups holds the resource groups that are currently watched.
+	groups map[string]bool
+
+	// pending maps the (lowercased) tracking entry ID of a resource to the Deployment waiting on it.
+	pending map[string]types.NamespacedName
+}
+
+func newResourceWatcher(radius RadiusClient) *resourceWatcher {
+	return &resourceWatcher{
+		radius:  radius,
+		events:  make(chan event.TypedGenericEvent[*appsv1.Deployment]),
+		lock:    &sync.Mutex{},
+		groups:  map[string]bool{},
+		pending: map[string]types.NamespacedName{},
+	}
+}
+
+// Source returns the source of the reconcile requests triggered by resource changes.
+func (w *resourceWatcher) Source() source.TypedSource[reconcile.Request] {
+	return source.Channel(w.events, &handler.TypedEnqueueRequestForObject[*appsv1.Deployment]{})
+}
+
+// Start implements manager.Runnable. Watches can only be started while the manager is running.
+func (w *resourceWatcher) Start(ctx context.Context) error {
+	w.lock.Lock()
+	w.ctx = ctx
+	w.lock.Unlock()
+
+	<-ctx.Done()
+
+	w.lock.Lock()
+	w.ctx = nil
+	w.lock.Unlock()
+	return nil
+}
+
+// Track registers the Deployment as waiting on an operation for the resource with the given ID, and starts
+// watching the resource group of the resource if needed. Track returns false if the resource can't be watched,
+// in which case the caller must poll for the status of the operation.
+func (w *resourceWatcher) Track(ctx context.Context, resourceID string, deployment types.NamespacedName) bool {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	id, err := resources.ParseResource(resourceID)
+	if err != nil {
+		return false
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.ctx == nil {
+		return false
+	}
+
+	group := strings.ToLower(id.RootScope())
+	if !w.groups[group] {
+		changes, err := w.radius.Watch(w.ctx, id.RootScope())
+		if err != nil {
+			logger.Error(err, "Unable to watch resource group, falling back to polling.", "resourceGroup", id.RootScope())
+			return false
+		}
+
+		w.groups[group] = true
+		go w.forward(w.ctx, group, changes)
+	}
+
+	w.pending[trackingKey(id)] = deployment
+	return true
+}
+
+// Untrack removes the registration made by Track.
+func (w *resourceWatcher) Untrack(resourceID string) {
+	id, err := resources.ParseResource(resourceID)
+	if err != nil {
+		return
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	delete(w.pending, trackingKey(id))
+}
+
+func (w *resourceWatcher) forward(ctx context.Context, group string, changes <-chan clients.ResourceChange) {
+	for change := range changes {
+		w.lock.Lock()
+		deployment, ok := w.pending[strings.ToLower(change.ID)]
+		w.lock.Unlock()
+
+		if ok {
+			w.enqueue(ctx, deployment)
+		}
+	}
+
+	// The watch has ended. Reconcile every Deployment that was waiting on this resource group so that the watch
+	// is started again, and no change that happened in the meantime is missed.
+	w.lock.Lock()
+	delete(w.groups, group)
+	waiting := []types.NamespacedName{}
+	for key, deployment := range w.pending {
+		if strings.HasPrefix(key, group+"/") {
+			waiting = append(waiting, deployment)
+		}
+	}
+	w.lock.Unlock()
+
+	for _, deployment := range waiting {
+		w.enqueue(ctx, deployment)
+	}
+}
+
+func (w *resourceWatcher) enqueue(ctx context.Context, deployment types.NamespacedName) {
+	e := event.TypedGenericEvent[*appsv1.Deployment]{
+		Object: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: deployment.Namespace}},
+	}
+
+	select {
+	case w.events <- e:
+	case <-ctx.Done():
+	}
+}
+
+// trackingKey returns the key of the tracking entry of the resource with the given ID.
+func trackingKey(id resources.ID) string {
+	return strings.ToLower(trackedresource.IDFor(id).String())
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	watcherTestScope       = "/planes/radius/local/resourceGroups/test-group"
+	watcherTestContainerID = watcherTestScope + "/providers/Applications.Core/containers/test-container"
+	watcherTestOtherID     = watcherTestScope + "/providers/Applications.Core/containers/other-container"
+)
+
+func startResourceWatcher(t *testing.T) (*mockRadiusClient, *resourceWatcher) {
+	radius := NewMockRadiusClient()
+	watcher := newResourceWatcher(radius)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	go func() { _ = watcher.Start(ctx) }()
+	require.Eventually(t, func() bool {
+		watcher.lock.Lock()
+		defer watcher.lock.Unlock()
+		return watcher.ctx != nil
+	}, time.Second*5, time.Millisecond*10)
+
+	return radius, watcher
+}
+
+func requireEnqueued(t *testing.T, watcher *resourceWatcher, expected types.NamespacedName) {
+	select {
+	case e := <-watcher.events:
+		require.Equal(t, expected.Name, e.Object.Name)
+		require.Equal(t, expected.Namespace, e.Object.Namespace)
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "timed out waiting for the deployment to be enqueued")
+	}
+}
+
+func requireNotEnqueued(t *testing.T, watcher *resourceWatcher) {
+	select {
+	case e := <-watcher.events:
+		require.Fail(t, "unexpected event", "deployment: %s/%s", e.Object.Namespace, e.Object.Name)
+	case <-time.After(time.Millisecond * 200):
+	}
+}
+
+func Test_ResourceWatcher_Track_NotStarted(t *testing.T) {
+	watcher := newResourceWatcher(NewMockRadiusClient())
+
+	tracked := watcher.Track(t.Context(), watcherTestContainerID, types.NamespacedName{Namespace: "default", Name: "test"})
+	require.False(t, tracked)
+}
+
+func Test_ResourceWatcher_Track_InvalidID(t *testing.T) {
+	_, watcher := startResourceWatcher(t)
+
+	tracked := watcher.Track(t.Context(), "not-a-resource-id", types.NamespacedName{Namespace: "default", Name: "test"})
+	require.False(t, tracked)
+}
+
+func Test_ResourceWatcher_EnqueuesOnChange(t *testing.T) {
+	radius, watcher := startResourceWatcher(t)
+	deployment := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	require.True(t, watcher.Track(t.Context(), watcherTestContainerID, deployment))
+
+	// Only one watch is needed per resource group.
+	require.True(t, watcher.Track(t.Context(), watcherTestOtherID, types.NamespacedName{Namespace: "default", Name: "other"}))
+	watcher.Untrack(watcherTestOtherID)
+	radius.Update(func() { require.Len(t, radius.watches, 1) })
+
+	radius.Update(func() { radius.Notify(watcherTestContainerID, "Updated") })
+	requireEnqueued(t, watcher, deployment)
+
+	// Changes to resources nobody is waiting on are ignored.
+	radius.Update(func() { radius.Notify(watcherTestOtherID, "Updated") })
+	requireNotEnqueued(t, watcher)
+
+	watcher.Untrack(watcherTestContainerID)
+	radius.Update(func() { radius.Notify(watcherTestContainerID, "Deleted") })
+	requireNotEnqueued(t, watcher)
+}
+
+func Test_ResourceWatcher_WatchClosed(t *testing.T) {
+	radius, watcher := startResourceWatcher(t)
+	deployment := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	require.True(t, watcher.Track(t.Context(), watcherTestContainerID, deployment))
+
+	// Losing the watch reconciles the waiting deployments, so they can't miss a change.
+	radius.CloseWatches()
+	requireEnqueued(t, watcher, deployment)
+
+	require.Eventually(t, func() bool {
+		watcher.lock.Lock()
+		defer watcher.lock.Unlock()
+		return len(watcher.groups) == 0
+	}, time.Second*5, time.Millisecond*10)
+
+	// Tracking again starts a new watch.
+	require.True(t, watcher.Track(t.Context(), watcherTestContainerID, deployment))
+	radius.Update(func() { require.Len(t, radius.watches, 1) })
+
+	radius.Update(func() { radius.Notify(watcherTestContainerID, "Updated") })
+	requireEnqueued(t, watcher, deployment)
+}
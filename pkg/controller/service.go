@@ -28,8 +28,12 @@ import (
 	"github.com/radius-project/radius/pkg/components/hosting"
 	radappiov1alpha3 "github.com/radius-project/radius/pkg/controller/api/radapp.io/v1alpha3"
 	"github.com/radius-project/radius/pkg/controller/reconciler"
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/graph/persistence"
+	gitstore "github.com/radius-project/radius/pkg/graph/persistence/git"
 	"github.com/radius-project/radius/pkg/sdk"
 	sdkclients "github.com/radius-project/radius/pkg/sdk/clients"
+	"github.com/radius-project/radius/pkg/statearchive/factory"
 	"github.com/radius-project/radius/pkg/ucp/ucplog"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -161,6 +165,22 @@ func (s *Service) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to setup %s controller: %w", "FluxController", err)
 	}
 
+	if s.Options.Config.GraphDrift.Enabled {
+		applicationsClient, err := corerpv20250801preview.NewApplicationsClient(&aztoken.AnonymousCredential{}, sdk.NewClientOptions(s.Options.UCPConnection))
+		if err != nil {
+			return fmt.Errorf("failed to create applications client: %w", err)
+		}
+		detector, err := reconciler.NewGraphDriftDetector(s.Options.Config.GraphDrift, applicationsClient, func(archive string) (persistence.Store, error) {
+			return gitstore.NewStore(gitstore.Options{Archive: factory.NewGraphArchive(archive)})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create graph drift detector: %w", err)
+		}
+		if err = detector.SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("failed to setup %s controller: %w", "GraphDrift", err)
+		}
+	}
+
 	if s.TLSCertDir == "" {
 		logger.Info("Webhooks will be skipped. TLS certificates not present.")
 	} else {
@@ -223,6 +223,10 @@ func toRecipeConfigDatamodel(config *RecipeConfigProperties) datamodel.RecipeCon
 		recipeConfig.Env = toRecipeConfigEnvDatamodel(config)
 		recipeConfig.EnvSecrets = toSecretReferenceDatamodel(config.EnvSecrets)
 
+		if config.Drift != nil && config.Drift.Policy != nil {
+			recipeConfig.Drift.Policy = string(*config.Drift.Policy)
+		}
+
 		return recipeConfig
 	}
 
@@ -267,6 +271,12 @@ func fromRecipeConfigDatamodel(config datamodel.RecipeConfigProperties) *RecipeC
 		recipeConfig.Env = fromRecipeConfigEnvDatamodel(config)
 		recipeConfig.EnvSecrets = fromSecretReferenceDatamodel(config.EnvSecrets)
 
+		if config.Drift.Policy != "" {
+			recipeConfig.Drift = &RecipeDriftConfig{
+				Policy: new(RecipeDriftPolicy(config.Drift.Policy)),
+			}
+		}
+
 		return recipeConfig
 	}
 
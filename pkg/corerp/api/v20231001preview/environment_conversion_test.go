@@ -176,6 +176,9 @@ func TestConvertVersionedToDataModel(t *testing.T) {
 								Key:    "envKey1",
 							},
 						},
+						Drift: datamodel.RecipeDriftConfig{
+							Policy: datamodel.RecipeDriftPolicyDetect,
+						},
 					},
 					Recipes: map[string]map[string]datamodel.EnvironmentRecipeProperties{
 						ds_ctrl.MongoDatabasesResourceType: {
@@ -492,6 +495,8 @@ func TestConvertDataModelToVersioned(t *testing.T) {
 					require.True(t, ok)
 					require.Equal(t, envSecretRef, new(SecretReference{Source: new(baseSecretStorePath + "envSecretStore1"), Key: new("envKey1")}))
 					require.Equal(t, 1, len(envSecretIDs))
+
+					require.Equal(t, RecipeDriftPolicyDetect, *versioned.Properties.RecipeConfig.Drift.Policy)
 				}
 
 				if tt.filename == "environmentresourcedatamodelemptyext.json" {
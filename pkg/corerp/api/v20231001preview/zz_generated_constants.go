@@ -300,6 +300,27 @@ func PossibleProvisioningStateValues() []ProvisioningState {
 	}
 }
 
+// RecipeDriftPolicy - The drift policy for the Recipes in the environment.
+type RecipeDriftPolicy string
+
+const (
+	// RecipeDriftPolicyDetect - Drift of Recipe resources is periodically checked and recorded in the Recipe status
+	RecipeDriftPolicyDetect RecipeDriftPolicy = "detect"
+	// RecipeDriftPolicyOff - Drift of Recipe resources is not checked
+	RecipeDriftPolicyOff RecipeDriftPolicy = "off"
+	// RecipeDriftPolicyRemediate - Drift of Recipe resources is periodically checked and Recipes that have drifted are re-applied
+	RecipeDriftPolicyRemediate RecipeDriftPolicy = "remediate"
+)
+
+// PossibleRecipeDriftPolicyValues returns the possible values for the RecipeDriftPolicy const type.
+func PossibleRecipeDriftPolicyValues() []RecipeDriftPolicy {
+	return []RecipeDriftPolicy{
+		RecipeDriftPolicyDetect,
+		RecipeDriftPolicyOff,
+		RecipeDriftPolicyRemediate,
+	}
+}
+
 // ResourceProvisioning - Specifies how the underlying service/resource is provisioned and managed. Available values are 'recipe',
 // where Radius manages the lifecycle of the resource through a Recipe, and 'manual', where a user manages the resource and
 // provides the values.
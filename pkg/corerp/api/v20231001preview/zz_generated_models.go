@@ -1099,6 +1099,9 @@ type RecipeConfigProperties struct {
 	// Configuration for Bicep Recipes. Controls how Bicep plans and applies templates as part of Recipe deployment.
 	Bicep *BicepConfigProperties
 
+	// Configuration for detecting drift of the resources deployed by Recipes in the environment.
+	Drift *RecipeDriftConfig
+
 	// Environment variables injected during recipe execution for the recipes in the environment, currently supported for Terraform
 	// recipes.
 	Env *EnvironmentVariables
@@ -1111,6 +1114,12 @@ type RecipeConfigProperties struct {
 	Terraform *TerraformConfigProperties
 }
 
+// RecipeDriftConfig - Configuration for detecting drift of the resources deployed by Recipes in the environment.
+type RecipeDriftConfig struct {
+	// The drift policy for the Recipes in the environment. Defaults to off.
+	Policy *RecipeDriftPolicy
+}
+
 // RecipeGetMetadata - Represents the request body of the getmetadata action.
 type RecipeGetMetadata struct {
 	// REQUIRED; The name of the recipe registered to the environment.
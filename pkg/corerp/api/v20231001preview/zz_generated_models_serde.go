@@ -2549,6 +2549,7 @@ func (r *Recipe) UnmarshalJSON(data []byte) error {
 func (r RecipeConfigProperties) MarshalJSON() ([]byte, error) {
 	objectMap := make(map[string]any)
 	populate(objectMap, "bicep", r.Bicep)
+	populate(objectMap, "drift", r.Drift)
 	populate(objectMap, "env", r.Env)
 	populate(objectMap, "envSecrets", r.EnvSecrets)
 	populate(objectMap, "terraform", r.Terraform)
@@ -2567,6 +2568,9 @@ func (r *RecipeConfigProperties) UnmarshalJSON(data []byte) error {
 		case "bicep":
 			err = unpopulate(val, "Bicep", &r.Bicep)
 			delete(rawMsg, key)
+		case "drift":
+			err = unpopulate(val, "Drift", &r.Drift)
+			delete(rawMsg, key)
 		case "env":
 			err = unpopulate(val, "Env", &r.Env)
 			delete(rawMsg, key)
@@ -2584,6 +2588,33 @@ func (r *RecipeConfigProperties) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON implements the json.Marshaller interface for type RecipeDriftConfig.
+func (r RecipeDriftConfig) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]any)
+	populate(objectMap, "policy", r.Policy)
+	return json.Marshal(objectMap)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface for type RecipeDriftConfig.
+func (r *RecipeDriftConfig) UnmarshalJSON(data []byte) error {
+	var rawMsg map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rawMsg); err != nil {
+		return fmt.Errorf("unmarshalling type %T: %s", r, err.Error())
+	}
+	for key, val := range rawMsg {
+		var err error
+		switch key {
+		case "policy":
+			err = unpopulate(val, "Policy", &r.Policy)
+			delete(rawMsg, key)
+		}
+		if err != nil {
+			return fmt.Errorf("unmarshalling type %T: %s", r, err.Error())
+		}
+	}
+	return nil
+}
+
 // MarshalJSON implements the json.Marshaller interface for type RecipeGetMetadata.
 func (r RecipeGetMetadata) MarshalJSON() ([]byte, error) {
 	objectMap := make(map[string]any)
@@ -66,7 +66,55 @@ func (dst *ApplicationResource) ConvertFrom(src v1.DataModelInterface) error {
 		ProvisioningState: fromProvisioningStateDataModel(app.InternalMetadata.AsyncProvisioningState),
 		Environment:       new(app.Properties.Environment),
 		Status:            &ResourceStatus{},
+		Conditions:        fromApplicationConditionsDataModel(app.Properties.Conditions),
 	}
 
 	return nil
 }
+
+// ConvertTo converts from the versioned updateConditions request to version-agnostic datamodel.
+func (src *UpdateConditionsRequest) ConvertTo() (v1.DataModelInterface, error) {
+	converted := &datamodel.ApplicationConditions{Conditions: []datamodel.ApplicationCondition{}}
+	for _, condition := range src.Conditions {
+		if condition == nil {
+			continue
+		}
+		c := datamodel.ApplicationCondition{
+			Type:    to.String(condition.Type),
+			Reason:  to.String(condition.Reason),
+			Message: to.String(condition.Message),
+		}
+		if condition.Status != nil {
+			c.Status = string(*condition.Status)
+		}
+		if condition.LastTransitionTime != nil {
+			c.LastTransitionTime = *condition.LastTransitionTime
+		}
+		converted.Conditions = append(converted.Conditions, c)
+	}
+	return converted, nil
+}
+
+func fromApplicationConditionsDataModel(conditions []datamodel.ApplicationCondition) []*ApplicationCondition {
+	if len(conditions) == 0 {
+		return nil
+	}
+	result := []*ApplicationCondition{}
+	for _, condition := range conditions {
+		converted := &ApplicationCondition{
+			Type:   to.Ptr(condition.Type),
+			Status: to.Ptr(ConditionStatus(condition.Status)),
+		}
+		if condition.Reason != "" {
+			converted.Reason = to.Ptr(condition.Reason)
+		}
+		if condition.Message != "" {
+			converted.Message = to.Ptr(condition.Message)
+		}
+		if !condition.LastTransitionTime.IsZero() {
+			converted.LastTransitionTime = to.Ptr(condition.LastTransitionTime)
+		}
+		result = append(result, converted)
+	}
+	return result
+}
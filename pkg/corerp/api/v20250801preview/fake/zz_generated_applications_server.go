@@ -43,6 +43,10 @@ type ApplicationsServer struct {
 	// Update is the fake for method ApplicationsClient.Update
 	// HTTP status codes to indicate success: http.StatusOK
 	Update func(ctx context.Context, rootScope string, applicationName string, properties v20250801preview.ApplicationResource, options *v20250801preview.ApplicationsClientUpdateOptions) (resp azfake.Responder[v20250801preview.ApplicationsClientUpdateResponse], errResp azfake.ErrorResponder)
+
+	// UpdateConditions is the fake for method ApplicationsClient.UpdateConditions
+	// HTTP status codes to indicate success: http.StatusOK
+	UpdateConditions func(ctx context.Context, rootScope string, applicationName string, body v20250801preview.UpdateConditionsRequest, options *v20250801preview.ApplicationsClientUpdateConditionsOptions) (resp azfake.Responder[v20250801preview.ApplicationsClientUpdateConditionsResponse], errResp azfake.ErrorResponder)
 }
 
 // NewApplicationsServerTransport creates a new instance of ApplicationsServerTransport with the provided implementation.
@@ -95,6 +99,8 @@ func (a *ApplicationsServerTransport) dispatchToMethodFake(req *http.Request, me
 				res.resp, res.err = a.dispatchNewListByScopePager(req)
 			case "ApplicationsClient.Update":
 				res.resp, res.err = a.dispatchUpdate(req)
+			case "ApplicationsClient.UpdateConditions":
+				res.resp, res.err = a.dispatchUpdateConditions(req)
 			default:
 				res.err = fmt.Errorf("unhandled API %s", method)
 			}
@@ -325,6 +331,43 @@ func (a *ApplicationsServerTransport) dispatchUpdate(req *http.Request) (*http.R
 	return resp, nil
 }
 
+func (a *ApplicationsServerTransport) dispatchUpdateConditions(req *http.Request) (*http.Response, error) {
+	if a.srv.UpdateConditions == nil {
+		return nil, &nonRetriableError{errors.New("fake for method UpdateConditions not implemented")}
+	}
+	const regexStr = `/(?P<rootScope>[!#&$-;=?-\[\]_a-zA-Z0-9~%@]+)/providers/Radius\.Core/applications/(?P<applicationName>[!#&$-;=?-\[\]_a-zA-Z0-9~%@]+)/updateConditions`
+	regex := regexp.MustCompile(regexStr)
+	matches := regex.FindStringSubmatch(req.URL.EscapedPath())
+	if len(matches) < 3 {
+		return nil, fmt.Errorf("failed to parse path %s", req.URL.Path)
+	}
+	body, err := server.UnmarshalRequestAsJSON[v20250801preview.UpdateConditionsRequest](req)
+	if err != nil {
+		return nil, err
+	}
+	rootScopeParam, err := url.PathUnescape(matches[regex.SubexpIndex("rootScope")])
+	if err != nil {
+		return nil, err
+	}
+	applicationNameParam, err := url.PathUnescape(matches[regex.SubexpIndex("applicationName")])
+	if err != nil {
+		return nil, err
+	}
+	respr, errRespr := a.srv.UpdateConditions(req.Context(), rootScopeParam, applicationNameParam, body, nil)
+	if respErr := server.GetError(errRespr, req); respErr != nil {
+		return nil, respErr
+	}
+	respContent := server.GetResponseContent(respr)
+	if !slices.Contains([]int{http.StatusOK}, respContent.HTTPStatus) {
+		return nil, &nonRetriableError{fmt.Errorf("unexpected status code %d. acceptable values are http.StatusOK", respContent.HTTPStatus)}
+	}
+	resp, err := server.MarshalResponseAsJSON(respContent, server.GetResponse(respr).ApplicationResource, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // set this to conditionally intercept incoming requests to ApplicationsServerTransport
 var applicationsServerTransportInterceptor interface {
 	// Do returns true if the server transport should use the returned response/error
@@ -50,6 +50,10 @@ func (src *TerraformSettingsResource) ConvertTo() (v1.DataModelInterface, error)
 		converted.Properties.Env = to.StringMap(src.Properties.Env)
 	}
 
+	if src.Properties.Backend != nil {
+		converted.Properties.Backend = toTerraformBackendDataModel(src.Properties.Backend)
+	}
+
 	if src.Properties.ReferencedBy != nil {
 		converted.Properties.ReferencedBy = to.StringArray(src.Properties.ReferencedBy)
 	}
@@ -80,6 +84,10 @@ func (dst *TerraformSettingsResource) ConvertFrom(src v1.DataModelInterface) err
 		dst.Properties.Env = *to.StringMapPtr(tc.Properties.Env)
 	}
 
+	if tc.Properties.Backend != nil {
+		dst.Properties.Backend = fromTerraformBackendDataModel(tc.Properties.Backend)
+	}
+
 	if len(tc.Properties.ReferencedBy) > 0 {
 		dst.Properties.ReferencedBy = to.ArrayofStringPtrs(tc.Properties.ReferencedBy)
 	}
@@ -153,3 +161,40 @@ func fromTerraformrcDataModel(src datamodel.TerraformrcConfig) *TerraformrcConfi
 
 	return result
 }
+
+func toTerraformBackendDataModel(src *TerraformBackendConfig) *datamodel.TerraformBackendConfig {
+	result := &datamodel.TerraformBackendConfig{}
+	if src.Kind != nil {
+		result.Kind = string(*src.Kind)
+	}
+
+	if src.S3 != nil {
+		result.S3 = &datamodel.TerraformS3BackendConfig{
+			Bucket:    to.String(src.S3.Bucket),
+			KeyPrefix: to.String(src.S3.KeyPrefix),
+			Region:    to.String(src.S3.Region),
+			Endpoint:  to.String(src.S3.Endpoint),
+			Secret:    to.String(src.S3.Secret),
+		}
+	}
+
+	return result
+}
+
+func fromTerraformBackendDataModel(src *datamodel.TerraformBackendConfig) *TerraformBackendConfig {
+	result := &TerraformBackendConfig{
+		Kind: to.Ptr(TerraformBackendKind(src.Kind)),
+	}
+
+	if src.S3 != nil {
+		result.S3 = &TerraformS3BackendConfig{
+			Bucket:    to.Ptr(src.S3.Bucket),
+			KeyPrefix: to.Ptr(src.S3.KeyPrefix),
+			Region:    to.Ptr(src.S3.Region),
+			Endpoint:  to.Ptr(src.S3.Endpoint),
+			Secret:    to.Ptr(src.S3.Secret),
+		}
+	}
+
+	return result
+}
@@ -208,6 +208,44 @@ func TestTerraformSettings_RoundTrip_Identity(t *testing.T) {
 	require.Equal(t, "/tmp/tf.log", *roundTripped.Properties.Env["TF_LOG_PATH"])
 }
 
+func TestTerraformSettings_RoundTrip_Backend(t *testing.T) {
+	original := newVersionedTerraformSettings(nil)
+	original.Properties.Backend = &TerraformBackendConfig{
+		Kind: to.Ptr(TerraformBackendKindS3),
+		S3: &TerraformS3BackendConfig{
+			Bucket:    to.Ptr("tfstate"),
+			KeyPrefix: to.Ptr("envs/dev/"),
+			Region:    to.Ptr("us-west-2"),
+			Endpoint:  to.Ptr("http://minio.minio-system:9000"),
+			Secret:    to.Ptr("/planes/radius/local/resourceGroups/rg/providers/Radius.Security/secrets/s3"),
+		},
+	}
+
+	dm, err := original.ConvertTo()
+	require.NoError(t, err)
+	tc := dm.(*datamodel.TerraformSettings)
+	require.Equal(t, &datamodel.TerraformBackendConfig{
+		Kind: datamodel.TerraformBackendS3,
+		S3: &datamodel.TerraformS3BackendConfig{
+			Bucket:    "tfstate",
+			KeyPrefix: "envs/dev/",
+			Region:    "us-west-2",
+			Endpoint:  "http://minio.minio-system:9000",
+			Secret:    "/planes/radius/local/resourceGroups/rg/providers/Radius.Security/secrets/s3",
+		},
+	}, tc.Properties.Backend)
+
+	roundTripped := &TerraformSettingsResource{}
+	require.NoError(t, roundTripped.ConvertFrom(dm))
+	require.Equal(t, original.Properties.Backend, roundTripped.Properties.Backend)
+}
+
+func TestTerraformSettings_ConvertTo_NoBackend(t *testing.T) {
+	dm, err := newVersionedTerraformSettings(nil).ConvertTo()
+	require.NoError(t, err)
+	require.Nil(t, dm.(*datamodel.TerraformSettings).Properties.Backend)
+}
+
 func TestTerraformSettings_CredentialsAreIndependentEntries(t *testing.T) {
 	// Guards against pointer-aliasing bugs in the credentials map: each host's
 	// Secret must point to its own value, not share storage across iterations.
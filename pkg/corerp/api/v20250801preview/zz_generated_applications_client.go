@@ -375,3 +375,65 @@ func (client *ApplicationsClient) updateHandleResponse(resp *http.Response) (App
 	}
 	return result, nil
 }
+
+// UpdateConditions - Sets conditions on the application.
+// If the operation fails it returns an *azcore.ResponseError type.
+//   - rootScope - The scope in which the resource is present. UCP Scope is /planes/{planeType}/{planeName}/resourceGroup/{resourcegroupID}
+//     and Azure resource scope is /subscriptions/{subscriptionID}/resourceGroup/{resourcegroupID}
+//   - applicationName - The application name
+//   - body - The content of the action request
+//   - options - ApplicationsClientUpdateConditionsOptions contains the optional parameters for the ApplicationsClient.UpdateConditions
+//     method.
+func (client *ApplicationsClient) UpdateConditions(ctx context.Context, rootScope string, applicationName string, body UpdateConditionsRequest, options *ApplicationsClientUpdateConditionsOptions) (ApplicationsClientUpdateConditionsResponse, error) {
+	var err error
+	ctx = context.WithValue(ctx, runtime.CtxAPINameKey{}, "ApplicationsClient.UpdateConditions")
+	req, err := client.updateConditionsCreateRequest(ctx, rootScope, applicationName, body, options)
+	if err != nil {
+		return ApplicationsClientUpdateConditionsResponse{}, err
+	}
+	httpResp, err := client.internal.Pipeline().Do(req)
+	if err != nil {
+		return ApplicationsClientUpdateConditionsResponse{}, err
+	}
+	if !runtime.HasStatusCode(httpResp, http.StatusOK) {
+		err = runtime.NewResponseError(httpResp)
+		return ApplicationsClientUpdateConditionsResponse{}, err
+	}
+	resp, err := client.updateConditionsHandleResponse(httpResp)
+	return resp, err
+}
+
+// updateConditionsCreateRequest creates the UpdateConditions request.
+func (client *ApplicationsClient) updateConditionsCreateRequest(ctx context.Context, rootScope string, applicationName string, body UpdateConditionsRequest, _ *ApplicationsClientUpdateConditionsOptions) (*policy.Request, error) {
+	urlPath := "/{rootScope}/providers/Radius.Core/applications/{applicationName}/updateConditions"
+	if rootScope == "" {
+		return nil, errors.New("parameter rootScope cannot be empty")
+	}
+	urlPath = strings.ReplaceAll(urlPath, "{rootScope}", rootScope)
+	if applicationName == "" {
+		return nil, errors.New("parameter applicationName cannot be empty")
+	}
+	urlPath = strings.ReplaceAll(urlPath, "{applicationName}", url.PathEscape(applicationName))
+	req, err := runtime.NewRequest(ctx, http.MethodPost, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", version20250801Preview)
+	req.Raw().URL.RawQuery = strings.ReplaceAll(reqQP.Encode(), "+", "%20")
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	req.Raw().Header["Content-Type"] = []string{"application/json"}
+	if err := runtime.MarshalAsJSON(req, body); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// updateConditionsHandleResponse handles the UpdateConditions response.
+func (client *ApplicationsClient) updateConditionsHandleResponse(resp *http.Response) (ApplicationsClientUpdateConditionsResponse, error) {
+	result := ApplicationsClientUpdateConditionsResponse{}
+	if err := runtime.UnmarshalAsJSON(resp, &result.ApplicationResource); err != nil {
+		return ApplicationsClientUpdateConditionsResponse{}, err
+	}
+	return result, nil
+}
@@ -43,6 +43,27 @@ func PossibleBicepAuthenticationMethodValues() []BicepAuthenticationMethod {
 	}
 }
 
+// ConditionStatus - The status of an application condition.
+type ConditionStatus string
+
+const (
+	// ConditionStatusFalse - The condition does not apply.
+	ConditionStatusFalse ConditionStatus = "False"
+	// ConditionStatusTrue - The condition applies.
+	ConditionStatusTrue ConditionStatus = "True"
+	// ConditionStatusUnknown - Whether the condition applies could not be determined.
+	ConditionStatusUnknown ConditionStatus = "Unknown"
+)
+
+// PossibleConditionStatusValues returns the possible values for the ConditionStatus const type.
+func PossibleConditionStatusValues() []ConditionStatus {
+	return []ConditionStatus{
+		ConditionStatusFalse,
+		ConditionStatusTrue,
+		ConditionStatusUnknown,
+	}
+}
+
 // ConnectionKind - The origin of a connection: 'Connection' for author-declared entries in properties.connections, 'Dependency'
 // for implicit entries derived from Bicep's dependsOn list.
 type ConnectionKind string
@@ -197,6 +218,10 @@ type RecipeKind string
 const (
 	// RecipeKindBicep - Bicep recipe
 	RecipeKindBicep RecipeKind = "bicep"
+	// RecipeKindHelm - Helm chart recipe
+	RecipeKindHelm RecipeKind = "helm"
+	// RecipeKindKubernetes - Kubernetes manifest recipe
+	RecipeKindKubernetes RecipeKind = "kubernetes"
 	// RecipeKindTerraform - Terraform recipe
 	RecipeKindTerraform RecipeKind = "terraform"
 )
@@ -205,6 +230,29 @@ const (
 func PossibleRecipeKindValues() []RecipeKind {
 	return []RecipeKind{
 		RecipeKindBicep,
+		RecipeKindHelm,
+		RecipeKindKubernetes,
 		RecipeKindTerraform,
 	}
 }
+
+// TerraformBackendKind - The kind of Terraform state backend.
+type TerraformBackendKind string
+
+const (
+	// TerraformBackendKindKubernetes - Kubernetes Secrets in the `radius-system` namespace
+	TerraformBackendKindKubernetes TerraformBackendKind = "kubernetes"
+	// TerraformBackendKindPostgresql - The PostgreSQL database of the Radius control plane
+	TerraformBackendKindPostgresql TerraformBackendKind = "postgresql"
+	// TerraformBackendKindS3 - An S3 or S3-compatible bucket
+	TerraformBackendKindS3 TerraformBackendKind = "s3"
+)
+
+// PossibleTerraformBackendKindValues returns the possible values for the TerraformBackendKind const type.
+func PossibleTerraformBackendKindValues() []TerraformBackendKind {
+	return []TerraformBackendKind{
+		TerraformBackendKindKubernetes,
+		TerraformBackendKindPostgresql,
+		TerraformBackendKindS3,
+	}
+}
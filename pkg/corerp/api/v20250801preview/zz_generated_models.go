@@ -5,6 +5,24 @@ package v20250801preview
 
 import "time"
 
+// ApplicationCondition - Describes an observation about an Application, keyed by type.
+type ApplicationCondition struct {
+	// REQUIRED; The status of the condition.
+	Status *ConditionStatus
+
+	// REQUIRED; The type of the condition, for example 'GraphDrift'. An Application has at most one condition of each type.
+	Type *string
+
+	// The last time the status of the condition changed.
+	LastTransitionTime *time.Time
+
+	// A human-readable message describing the condition.
+	Message *string
+
+	// A machine-readable, CamelCase reason for the last transition of the condition.
+	Reason *string
+}
+
 // ApplicationGraphConnection - Describes the connection between two resources.
 type ApplicationGraphConnection struct {
 	// REQUIRED; The direction of the connection. 'Outbound' indicates this connection specifies the ID of the destination and
@@ -90,6 +108,10 @@ type ApplicationProperties struct {
 	// REQUIRED; (Required) Fully qualified resource ID of the environment the application is deployed to
 	Environment *string
 
+	// READ-ONLY; (Read Only) Observations about the Application reported by Radius controllers, such as drift between the modeled
+	// and the deployed application graph. Conditions are set with the updateConditions action.
+	Conditions []*ApplicationCondition
+
 	// READ-ONLY; (Read Only) The status of the Application resource within the Radius control plane. Does not include the other
 	// resources that compose the Application.
 	ProvisioningState *ProvisioningState
@@ -646,7 +668,9 @@ type RecipeDefinition struct {
 	Kind *RecipeKind
 
 	// REQUIRED; (Required) Location of the Recipe. For Bicep Recipes this is an OCI registry reference. For Terraform Recipes
-	// this is the module source such as a Git URL or a Terraform registry module.
+	// this is the module source such as a Git URL or a Terraform registry module. For Helm Recipes this is an OCI or HTTP chart
+	// reference such as `oci://ghcr.io/org/charts/redis:1.2.3`. For Kubernetes Recipes this is an OCI artifact or a `git::`
+	// repository path holding YAML manifests or a kustomization.
 	Source *string
 
 	// (Optional) Maps the module outputs onto the resource type properties for recipes that point directly at a Bicep or Terraform
@@ -836,6 +860,15 @@ type SystemData struct {
 	LastModifiedByType *CreatedByType
 }
 
+// TerraformBackendConfig - Terraform state backend configuration.
+type TerraformBackendConfig struct {
+	// REQUIRED; (Required) The kind of backend that stores the Terraform state.
+	Kind *TerraformBackendKind
+
+	// (Optional) Settings for the `s3` backend. Required when `kind` is `s3`.
+	S3 *TerraformS3BackendConfig
+}
+
 // TerraformCredentialConfig - Credential configuration for a Terraform registry or module source host.
 type TerraformCredentialConfig struct {
 	// (Optional) The ID of a `Radius.Security/secrets` resource containing the authentication token. The secret must have a key
@@ -873,8 +906,31 @@ type TerraformProviderMirror struct {
 	URL *string
 }
 
+// TerraformS3BackendConfig - S3 state backend configuration.
+type TerraformS3BackendConfig struct {
+	// REQUIRED; (Required) The bucket that stores the Terraform state.
+	Bucket *string
+
+	// (Optional) The endpoint of an S3-compatible server such as `http://minio.minio-system:9000`. Path-style addressing is used
+	// when this is set.
+	Endpoint *string
+
+	// (Optional) The prefix of the keys of the state objects. Defaults to `radius/tfstate/`.
+	KeyPrefix *string
+
+	// (Optional) The region of the bucket. Defaults to `us-east-1`.
+	Region *string
+
+	// (Optional) The ID of a `Radius.Security/secrets` resource with the keys `accessKeyId` and `secretAccessKey`. Defaults to
+	// the AWS configuration of the Radius control plane, such as environment variables or workload identity.
+	Secret *string
+}
+
 // TerraformSettingsProperties - Terraform settings properties.
 type TerraformSettingsProperties struct {
+	// (Optional) Where Terraform stores the state of Recipes. Defaults to Kubernetes Secrets in the `radius-system` namespace.
+	Backend *TerraformBackendConfig
+
 	// (Optional) Environment variables injected into the Terraform process during Recipe execution.
 	Env map[string]*string
 
@@ -1024,3 +1080,10 @@ type TerraformrcConfig struct {
 	// (Optional) Controls where Terraform installs providers from, such as a network mirror instead of the public registry.
 	ProviderInstallation *TerraformProviderInstallation
 }
+
+// UpdateConditionsRequest - Request body for the updateConditions action.
+type UpdateConditionsRequest struct {
+	// REQUIRED; The conditions to set. A condition replaces the existing condition of the same type; conditions of other types
+	// are kept.
+	Conditions []*ApplicationCondition
+}
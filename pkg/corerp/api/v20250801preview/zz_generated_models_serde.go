@@ -12,6 +12,49 @@ import (
 	"time"
 )
 
+// MarshalJSON implements the json.Marshaller interface for type ApplicationCondition.
+func (a ApplicationCondition) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]any)
+	populateTime[datetime.RFC3339](objectMap, "lastTransitionTime", a.LastTransitionTime)
+	populate(objectMap, "message", a.Message)
+	populate(objectMap, "reason", a.Reason)
+	populate(objectMap, "status", a.Status)
+	populate(objectMap, "type", a.Type)
+	return json.Marshal(objectMap)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface for type ApplicationCondition.
+func (a *ApplicationCondition) UnmarshalJSON(data []byte) error {
+	var rawMsg map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rawMsg); err != nil {
+		return fmt.Errorf("unmarshalling type %T: %s", a, err.Error())
+	}
+	for key, val := range rawMsg {
+		var err error
+		switch key {
+		case "lastTransitionTime":
+			err = unpopulateTime[datetime.RFC3339](val, "LastTransitionTime", &a.LastTransitionTime)
+			delete(rawMsg, key)
+		case "message":
+			err = unpopulate(val, "Message", &a.Message)
+			delete(rawMsg, key)
+		case "reason":
+			err = unpopulate(val, "Reason", &a.Reason)
+			delete(rawMsg, key)
+		case "status":
+			err = unpopulate(val, "Status", &a.Status)
+			delete(rawMsg, key)
+		case "type":
+			err = unpopulate(val, "Type", &a.Type)
+			delete(rawMsg, key)
+		}
+		if err != nil {
+			return fmt.Errorf("unmarshalling type %T: %s", a, err.Error())
+		}
+	}
+	return nil
+}
+
 // MarshalJSON implements the json.Marshaller interface for type ApplicationGraphConnection.
 func (a ApplicationGraphConnection) MarshalJSON() ([]byte, error) {
 	objectMap := make(map[string]any)
@@ -179,6 +222,7 @@ func (a *ApplicationGraphResponse) UnmarshalJSON(data []byte) error {
 // MarshalJSON implements the json.Marshaller interface for type ApplicationProperties.
 func (a ApplicationProperties) MarshalJSON() ([]byte, error) {
 	objectMap := make(map[string]any)
+	populate(objectMap, "conditions", a.Conditions)
 	populate(objectMap, "environment", a.Environment)
 	populate(objectMap, "provisioningState", a.ProvisioningState)
 	populate(objectMap, "status", a.Status)
@@ -194,6 +238,9 @@ func (a *ApplicationProperties) UnmarshalJSON(data []byte) error {
 	for key, val := range rawMsg {
 		var err error
 		switch key {
+		case "conditions":
+			err = unpopulate(val, "Conditions", &a.Conditions)
+			delete(rawMsg, key)
 		case "environment":
 			err = unpopulate(val, "Environment", &a.Environment)
 			delete(rawMsg, key)
@@ -1360,6 +1407,37 @@ func (s *SystemData) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON implements the json.Marshaller interface for type TerraformBackendConfig.
+func (t TerraformBackendConfig) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]any)
+	populate(objectMap, "kind", t.Kind)
+	populate(objectMap, "s3", t.S3)
+	return json.Marshal(objectMap)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface for type TerraformBackendConfig.
+func (t *TerraformBackendConfig) UnmarshalJSON(data []byte) error {
+	var rawMsg map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rawMsg); err != nil {
+		return fmt.Errorf("unmarshalling type %T: %s", t, err.Error())
+	}
+	for key, val := range rawMsg {
+		var err error
+		switch key {
+		case "kind":
+			err = unpopulate(val, "Kind", &t.Kind)
+			delete(rawMsg, key)
+		case "s3":
+			err = unpopulate(val, "S3", &t.S3)
+			delete(rawMsg, key)
+		}
+		if err != nil {
+			return fmt.Errorf("unmarshalling type %T: %s", t, err.Error())
+		}
+	}
+	return nil
+}
+
 // MarshalJSON implements the json.Marshaller interface for type TerraformCredentialConfig.
 func (t TerraformCredentialConfig) MarshalJSON() ([]byte, error) {
 	objectMap := make(map[string]any)
@@ -1484,9 +1562,53 @@ func (t *TerraformProviderMirror) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON implements the json.Marshaller interface for type TerraformS3BackendConfig.
+func (t TerraformS3BackendConfig) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]any)
+	populate(objectMap, "bucket", t.Bucket)
+	populate(objectMap, "endpoint", t.Endpoint)
+	populate(objectMap, "keyPrefix", t.KeyPrefix)
+	populate(objectMap, "region", t.Region)
+	populate(objectMap, "secret", t.Secret)
+	return json.Marshal(objectMap)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface for type TerraformS3BackendConfig.
+func (t *TerraformS3BackendConfig) UnmarshalJSON(data []byte) error {
+	var rawMsg map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rawMsg); err != nil {
+		return fmt.Errorf("unmarshalling type %T: %s", t, err.Error())
+	}
+	for key, val := range rawMsg {
+		var err error
+		switch key {
+		case "bucket":
+			err = unpopulate(val, "Bucket", &t.Bucket)
+			delete(rawMsg, key)
+		case "endpoint":
+			err = unpopulate(val, "Endpoint", &t.Endpoint)
+			delete(rawMsg, key)
+		case "keyPrefix":
+			err = unpopulate(val, "KeyPrefix", &t.KeyPrefix)
+			delete(rawMsg, key)
+		case "region":
+			err = unpopulate(val, "Region", &t.Region)
+			delete(rawMsg, key)
+		case "secret":
+			err = unpopulate(val, "Secret", &t.Secret)
+			delete(rawMsg, key)
+		}
+		if err != nil {
+			return fmt.Errorf("unmarshalling type %T: %s", t, err.Error())
+		}
+	}
+	return nil
+}
+
 // MarshalJSON implements the json.Marshaller interface for type TerraformSettingsProperties.
 func (t TerraformSettingsProperties) MarshalJSON() ([]byte, error) {
 	objectMap := make(map[string]any)
+	populate(objectMap, "backend", t.Backend)
 	populate(objectMap, "env", t.Env)
 	populate(objectMap, "provisioningState", t.ProvisioningState)
 	populate(objectMap, "referencedBy", t.ReferencedBy)
@@ -1503,6 +1625,9 @@ func (t *TerraformSettingsProperties) UnmarshalJSON(data []byte) error {
 	for key, val := range rawMsg {
 		var err error
 		switch key {
+		case "backend":
+			err = unpopulate(val, "Backend", &t.Backend)
+			delete(rawMsg, key)
 		case "env":
 			err = unpopulate(val, "Env", &t.Env)
 			delete(rawMsg, key)
@@ -1636,6 +1761,33 @@ func (t *TerraformrcConfig) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON implements the json.Marshaller interface for type UpdateConditionsRequest.
+func (u UpdateConditionsRequest) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]any)
+	populate(objectMap, "conditions", u.Conditions)
+	return json.Marshal(objectMap)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface for type UpdateConditionsRequest.
+func (u *UpdateConditionsRequest) UnmarshalJSON(data []byte) error {
+	var rawMsg map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rawMsg); err != nil {
+		return fmt.Errorf("unmarshalling type %T: %s", u, err.Error())
+	}
+	for key, val := range rawMsg {
+		var err error
+		switch key {
+		case "conditions":
+			err = unpopulate(val, "Conditions", &u.Conditions)
+			delete(rawMsg, key)
+		}
+		if err != nil {
+			return fmt.Errorf("unmarshalling type %T: %s", u, err.Error())
+		}
+	}
+	return nil
+}
+
 func populate(m map[string]any, k string, v any) {
 	if v == nil {
 		return
@@ -28,6 +28,12 @@ type ApplicationsClientListByScopeOptions struct {
 	// placeholder for future optional parameters
 }
 
+// ApplicationsClientUpdateConditionsOptions contains the optional parameters for the ApplicationsClient.UpdateConditions
+// method.
+type ApplicationsClientUpdateConditionsOptions struct {
+	// placeholder for future optional parameters
+}
+
 // ApplicationsClientUpdateOptions contains the optional parameters for the ApplicationsClient.Update method.
 type ApplicationsClientUpdateOptions struct {
 	// placeholder for future optional parameters
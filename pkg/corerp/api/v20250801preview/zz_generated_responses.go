@@ -114,6 +114,53 @@ type ApplicationsClientListByScopeResponse struct {
 	ApplicationResourceListResult
 }
 
+// ApplicationsClientUpdateConditionsResponse contains the response from method ApplicationsClient.UpdateConditions.
+type ApplicationsClientUpdateConditionsResponse struct {
+	// The `Radius.Core/applications` Resource Type represents a Radius Application: a logical grouping of the resources that
+	// make up a single Application, such as containers, databases, and message queues, along with the connections between them.
+	// Radius uses the Application to build the application graph, apply shared configuration, and manage its resources together
+	// throughout their lifecycle.
+	// ## Defining an Application
+	// An Application is always deployed to a Radius Environment, which is supplied through the `environment` property. To define
+	// an Application, add a `Radius.Core/applications` resource to your application definition Bicep file.
+	// ```bicep
+	// extension radius
+	// @description('The Radius Environment ID. Injected automatically by the rad CLI.')
+	// param environment string
+	// resource myApp 'Radius.Core/applications@2025-08-01-preview' = {
+	// name: 'my-app'
+	// properties: {
+	// environment: environment
+	// }
+	// }
+	// ```
+	// ## Deploying an Application
+	// An Application is deployed with the `rad deploy` command, which deploys the Application together with the resources that
+	// belong to it:
+	// ```bash
+	// rad deploy ./app.bicep
+	// ```
+	// ## Adding resources to an Application
+	// Resources are composed into an Application by setting their `application` property to the Application's ID. For example,
+	// to add a Container to this Application, add the following to the application definition Bicep file and set `application:
+	// myApp.id`:
+	// ```bicep
+	// resource frontend 'Radius.Compute/containers@2025-08-01-preview' = {
+	// name: 'frontend'
+	// properties: {
+	// environment: environment
+	// application: myApp.id
+	// containers: {
+	// frontend: {
+	// image: 'ghcr.io/my-org/frontend:latest'
+	// }
+	// }
+	// }
+	// }
+	// ```
+	ApplicationResource
+}
+
 // ApplicationsClientUpdateResponse contains the response from method ApplicationsClient.Update.
 type ApplicationsClientUpdateResponse struct {
 	// The `Radius.Core/applications` Resource Type represents a Radius Application: a logical grouping of the resources that
@@ -17,6 +17,9 @@ limitations under the License.
 package datamodel
 
 import (
+	"strings"
+	"time"
+
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
 	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
 )
@@ -57,4 +60,62 @@ func (h *Application_v20250801preview) ResourceMetadata() rpv1.BasicResourceProp
 // ApplicationProperties_v20250801preview represents the properties of Application resource.
 type ApplicationProperties_v20250801preview struct {
 	rpv1.BasicResourceProperties
+
+	// Conditions are observations about the application reported by Radius controllers, at most
+	// one per type.
+	Conditions []ApplicationCondition `json:"conditions,omitempty"`
+}
+
+// ApplicationCondition is an observation about an application, such as drift between its
+// modeled and deployed graphs.
+type ApplicationCondition struct {
+	// Type identifies the condition, for example "GraphDrift".
+	Type string `json:"type"`
+
+	// Status is "True", "False" or "Unknown".
+	Status string `json:"status"`
+
+	// Reason is a CamelCase reason for the last transition.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable description of the condition.
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the last time Status changed.
+	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ApplicationConditions represents input properties for the Radius.Core/applications
+// updateConditions action.
+type ApplicationConditions struct {
+	// Conditions are the conditions to set on the application.
+	Conditions []ApplicationCondition `json:"conditions"`
+}
+
+// ResourceTypeName returns the resource type of the ApplicationConditions instance.
+func (c *ApplicationConditions) ResourceTypeName() string {
+	return ApplicationResourceType_v20250801preview
+}
+
+// SetCondition adds condition to the application, replacing the existing condition of the same
+// type. LastTransitionTime is kept from the existing condition when the status is unchanged, and
+// set to now when the status changes or condition does not carry one.
+func (p *ApplicationProperties_v20250801preview) SetCondition(condition ApplicationCondition, now time.Time) {
+	for i, existing := range p.Conditions {
+		if !strings.EqualFold(existing.Type, condition.Type) {
+			continue
+		}
+		if existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		} else if condition.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = now
+		}
+		p.Conditions[i] = condition
+		return
+	}
+
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = now
+	}
+	p.Conditions = append(p.Conditions, condition)
 }
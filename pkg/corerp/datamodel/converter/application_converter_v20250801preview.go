@@ -52,3 +52,22 @@ func Application20250801DataModelFromVersioned(content []byte, version string) (
 		return nil, v1.ErrUnsupportedAPIVersion
 	}
 }
+
+// ApplicationConditions20250801DataModelFromVersioned converts a versioned Radius.Core application updateConditions request to datamodel.
+func ApplicationConditions20250801DataModelFromVersioned(content []byte, version string) (*datamodel.ApplicationConditions, error) {
+	switch version {
+	case v20250801preview.Version:
+		am := &v20250801preview.UpdateConditionsRequest{}
+		if err := json.Unmarshal(content, am); err != nil {
+			return nil, err
+		}
+		dm, err := am.ConvertTo()
+		if err != nil {
+			return nil, err
+		}
+		return dm.(*datamodel.ApplicationConditions), nil
+
+	default:
+		return nil, v1.ErrUnsupportedAPIVersion
+	}
+}
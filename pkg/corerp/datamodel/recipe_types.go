@@ -30,6 +30,26 @@ type RecipeConfigProperties struct {
 	// EnvSecrets represents the environment secrets for the recipe.
 	// The keys of the map are the names of the secrets, and the values are the references to the secrets.
 	EnvSecrets map[string]SecretReference `json:"envSecrets,omitempty"`
+
+	// Drift controls how drift of the resources deployed by Recipes in the environment is handled.
+	Drift RecipeDriftConfig `json:"drift"`
+}
+
+const (
+	// RecipeDriftPolicyOff disables drift detection for Recipes. This is the default.
+	RecipeDriftPolicyOff = "off"
+
+	// RecipeDriftPolicyDetect periodically checks Recipes for drift and records it in the Recipe status.
+	RecipeDriftPolicyDetect = "detect"
+
+	// RecipeDriftPolicyRemediate periodically checks Recipes for drift and re-applies the Recipes that have drifted.
+	RecipeDriftPolicyRemediate = "remediate"
+)
+
+// RecipeDriftConfig - Configuration for detecting drift of the resources deployed by Recipes.
+type RecipeDriftConfig struct {
+	// Policy is the drift policy for the Recipes in the environment: off, detect or remediate. Empty means off.
+	Policy string `json:"policy,omitempty"`
 }
 
 // TerraformConfigProperties - Configuration for Terraform Recipes. Controls how Terraform plans and applies templates as
@@ -55,6 +75,10 @@ type TerraformConfigProperties struct {
 	//
 	// The element type is declared in terraformconfig.go.
 	Credentials map[string]TerraformCredentialConfig `json:"credentials,omitempty"`
+
+	// Backend specifies where Terraform stores the state of recipes. Nil means Kubernetes Secrets.
+	// Populated only by the Radius.Core path.
+	Backend *TerraformBackendConfig `json:"backend,omitempty"`
 }
 
 // BicepConfigProperties - Configuration for Bicep Recipes. Controls how Bicep plans and applies templates as part of Recipe
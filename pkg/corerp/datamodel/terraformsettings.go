@@ -43,6 +43,9 @@ type TerraformSettingsResourceProperties struct {
 	// Env specifies the environment variables to be set during Terraform recipe execution.
 	Env map[string]string `json:"env,omitempty"`
 
+	// Backend specifies where Terraform stores the state of recipes. Nil means Kubernetes Secrets.
+	Backend *TerraformBackendConfig `json:"backend,omitempty"`
+
 	// ReferencedBy is a list of environment IDs that reference this config.
 	ReferencedBy []string `json:"referencedBy,omitempty"`
 }
@@ -84,3 +87,41 @@ type TerraformCredentialConfig struct {
 	// Supported types: Radius.Security/secrets or Applications.Core/secretStores.
 	Secret string `json:"secret,omitempty"`
 }
+
+const (
+	// TerraformBackendKubernetes stores Terraform state in Kubernetes Secrets in the radius-system namespace.
+	TerraformBackendKubernetes = "kubernetes"
+
+	// TerraformBackendPostgreSQL stores Terraform state in the PostgreSQL database of the control plane.
+	TerraformBackendPostgreSQL = "postgresql"
+
+	// TerraformBackendS3 stores Terraform state in an S3 or S3-compatible bucket.
+	TerraformBackendS3 = "s3"
+)
+
+// TerraformBackendConfig configures where Terraform stores the state of recipes.
+type TerraformBackendConfig struct {
+	// Kind is the kind of backend. One of kubernetes, postgresql or s3.
+	Kind string `json:"kind"`
+
+	// S3 configures the s3 backend.
+	S3 *TerraformS3BackendConfig `json:"s3,omitempty"`
+}
+
+// TerraformS3BackendConfig configures the s3 Terraform state backend.
+type TerraformS3BackendConfig struct {
+	// Bucket is the bucket that stores the Terraform state.
+	Bucket string `json:"bucket"`
+
+	// KeyPrefix is the prefix of the keys of the state objects.
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+
+	// Region is the region of the bucket.
+	Region string `json:"region,omitempty"`
+
+	// Endpoint is the endpoint of an S3-compatible server. Path-style addressing is used when set.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Secret is the ID of a Radius.Security/secrets resource with the keys accessKeyId and secretAccessKey.
+	Secret string `json:"secret,omitempty"`
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v20250801preview
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	"github.com/radius-project/radius/pkg/armrpc/rest"
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/corerp/datamodel"
+	"github.com/radius-project/radius/pkg/corerp/datamodel/converter"
+)
+
+var _ ctrl.Controller = (*UpdateConditionsv20250801preview)(nil)
+
+// UpdateConditionsv20250801preview is the controller implementation of the updateConditions action
+// for Radius.Core/applications resources.
+type UpdateConditionsv20250801preview struct {
+	ctrl.Operation[*datamodel.Application_v20250801preview, datamodel.Application_v20250801preview]
+}
+
+// NewUpdateConditionsv20250801preview creates a new instance of the UpdateConditionsv20250801preview controller.
+func NewUpdateConditionsv20250801preview(opts ctrl.Options) (ctrl.Controller, error) {
+	return &UpdateConditionsv20250801preview{
+		ctrl.NewOperation(opts,
+			ctrl.ResourceOptions[datamodel.Application_v20250801preview]{
+				RequestConverter:  converter.Application20250801DataModelFromVersioned,
+				ResponseConverter: converter.Application20250801DataModelToVersioned,
+			},
+		),
+	}, nil
+}
+
+// Run handles the updateConditions custom action for Radius.Core/applications. Each condition in
+// the request replaces the application's condition of the same type; the other conditions are
+// kept. The updated application is returned.
+func (c *UpdateConditionsv20250801preview) Run(ctx context.Context, w http.ResponseWriter, req *http.Request) (rest.Response, error) {
+	sCtx := v1.ARMRequestContextFromContext(ctx)
+
+	// route id format: /planes/radius/local/resourcegroups/default/providers/Radius.Core/applications/<app>/updateConditions
+	applicationID := sCtx.ResourceID.Truncate()
+	application, etag, err := c.GetResource(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return rest.NewNotFoundResponse(sCtx.ResourceID), nil
+	}
+
+	content, err := ctrl.ReadJSONBody(req)
+	if err != nil {
+		return nil, err
+	}
+	request, err := converter.ApplicationConditions20250801DataModelFromVersioned(content, sCtx.APIVersion)
+	if err != nil {
+		return rest.NewBadRequestResponse(err.Error()), nil
+	}
+	if err := validateConditions(request.Conditions); err != nil {
+		return rest.NewBadRequestResponse(err.Error()), nil
+	}
+
+	now := time.Now().UTC()
+	for _, condition := range request.Conditions {
+		application.Properties.SetCondition(condition, now)
+	}
+
+	etag, err = c.SaveResource(ctx, applicationID.String(), application, etag)
+	if err != nil {
+		return nil, err
+	}
+	return c.ConstructSyncResponse(ctx, req.Method, etag, application)
+}
+
+// validateConditions checks that every condition has a type and a known status.
+func validateConditions(conditions []datamodel.ApplicationCondition) error {
+	for i, condition := range conditions {
+		if condition.Type == "" {
+			return fmt.Errorf("conditions[%d].type is required", i)
+		}
+		valid := false
+		for _, status := range corerpv20250801preview.PossibleConditionStatusValues() {
+			if condition.Status == string(status) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("conditions[%d].status %q is invalid: expected True, False or Unknown", i, condition.Status)
+		}
+	}
+	return nil
+}
+
+// PreserveConditions is an update filter that keeps the conditions of an existing application
+// when it is replaced or patched. Conditions are read-only and only set by updateConditions, so
+// a redeployment does not clear them.
+func PreserveConditions(ctx context.Context, newResource *datamodel.Application_v20250801preview, oldResource *datamodel.Application_v20250801preview, options *ctrl.Options) (rest.Response, error) {
+	if oldResource != nil {
+		newResource.Properties.Conditions = oldResource.Properties.Conditions
+	}
+	return nil, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v20250801preview
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	"github.com/radius-project/radius/pkg/armrpc/rest"
+	"github.com/radius-project/radius/pkg/armrpc/rpctest"
+	"github.com/radius-project/radius/pkg/components/database"
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/corerp/datamodel"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const updateConditionsAppID = "/planes/radius/local/resourcegroups/default/providers/Radius.Core/applications/myapp"
+
+func newUpdateConditionsRequest(t *testing.T, body string) (*http.Request, context.Context) {
+	req, err := rpctest.NewHTTPRequestWithContent(
+		t.Context(),
+		v1.OperationPost.HTTPMethod(),
+		"http://localhost:8080"+updateConditionsAppID+"/updateConditions?api-version=2025-08-01-preview", []byte(body))
+	require.NoError(t, err)
+	req.ContentLength = int64(len(body))
+	return req, rpctest.NewARMRequestContext(req)
+}
+
+func TestUpdateConditionsRun_20250801Preview(t *testing.T) {
+	transitioned := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("merges conditions by type", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		databaseClient := database.NewMockClient(mctrl)
+
+		existing := &datamodel.Application_v20250801preview{
+			BaseResource: v1.BaseResource{TrackedResource: v1.TrackedResource{ID: updateConditionsAppID, Name: "myapp", Type: datamodel.ApplicationResourceType_v20250801preview}},
+			Properties: datamodel.ApplicationProperties_v20250801preview{
+				BasicResourceProperties: rpv1.BasicResourceProperties{Environment: "/planes/radius/local/resourcegroups/default/providers/Radius.Core/environments/env"},
+				Conditions: []datamodel.ApplicationCondition{
+					{Type: "GraphDrift", Status: "True", Reason: "Drifted", LastTransitionTime: transitioned},
+					{Type: "Other", Status: "False"},
+				},
+			},
+		}
+		databaseClient.EXPECT().Get(gomock.Any(), updateConditionsAppID).Return(rpctest.FakeStoreObject(existing), nil)
+
+		var saved *datamodel.Application_v20250801preview
+		databaseClient.EXPECT().
+			Save(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, obj *database.Object, _ ...database.SaveOptions) error {
+				saved = obj.Data.(*datamodel.Application_v20250801preview)
+				obj.ETag = "new-etag"
+				return nil
+			})
+
+		req, ctx := newUpdateConditionsRequest(t, `{"conditions":[{"type":"GraphDrift","status":"True","reason":"ResourceModified","message":"1 resource changed"}]}`)
+		ctl, err := NewUpdateConditionsv20250801preview(ctrl.Options{DatabaseClient: databaseClient})
+		require.NoError(t, err)
+
+		resp, err := ctl.Run(ctx, httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		ok, isOK := resp.(*rest.OKResponse)
+		require.True(t, isOK, "expected an OK response, got %T", resp)
+		require.Equal(t, "new-etag", ok.Headers["ETag"])
+
+		require.Equal(t, []datamodel.ApplicationCondition{
+			// The status did not change, so the transition time is kept.
+			{Type: "GraphDrift", Status: "True", Reason: "ResourceModified", Message: "1 resource changed", LastTransitionTime: transitioned},
+			{Type: "Other", Status: "False"},
+		}, saved.Properties.Conditions)
+
+		versioned := ok.Body.(*corerpv20250801preview.ApplicationResource)
+		require.Len(t, versioned.Properties.Conditions, 2)
+		require.Equal(t, corerpv20250801preview.ConditionStatusTrue, *versioned.Properties.Conditions[0].Status)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		databaseClient := database.NewMockClient(mctrl)
+		databaseClient.EXPECT().Get(gomock.Any(), gomock.Any()).Return(rpctest.FakeStoreObject(&datamodel.Application_v20250801preview{}), nil)
+
+		req, ctx := newUpdateConditionsRequest(t, `{"conditions":[{"type":"GraphDrift","status":"Maybe"}]}`)
+		ctl, err := NewUpdateConditionsv20250801preview(ctrl.Options{DatabaseClient: databaseClient})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		resp, err := ctl.Run(ctx, w, req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Apply(ctx, w, req))
+		require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("application not found", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		databaseClient := database.NewMockClient(mctrl)
+		databaseClient.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, &database.ErrNotFound{})
+
+		req, ctx := newUpdateConditionsRequest(t, `{"conditions":[]}`)
+		ctl, err := NewUpdateConditionsv20250801preview(ctrl.Options{DatabaseClient: databaseClient})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		resp, err := ctl.Run(ctx, w, req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Apply(ctx, w, req))
+		require.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestPreserveConditions(t *testing.T) {
+	conditions := []datamodel.ApplicationCondition{{Type: "GraphDrift", Status: "False"}}
+	newResource := &datamodel.Application_v20250801preview{}
+
+	resp, err := PreserveConditions(t.Context(), newResource, nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	require.Empty(t, newResource.Properties.Conditions)
+
+	old := &datamodel.Application_v20250801preview{Properties: datamodel.ApplicationProperties_v20250801preview{Conditions: conditions}}
+	resp, err = PreserveConditions(t.Context(), newResource, old, nil)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	require.Equal(t, conditions, newResource.Properties.Conditions)
+}
@@ -38,6 +38,7 @@ import (
 	vol_ctrl "github.com/radius-project/radius/pkg/corerp/frontend/controller/volumes"
 	ext_processor "github.com/radius-project/radius/pkg/corerp/processors/extenders"
 	pr_ctrl "github.com/radius-project/radius/pkg/portableresources/backend/controller"
+	pr_frontend_ctrl "github.com/radius-project/radius/pkg/portableresources/frontend/controller"
 	"github.com/radius-project/radius/pkg/recipes/controllerconfig"
 	rp_frontend "github.com/radius-project/radius/pkg/rp/frontend"
 )
@@ -245,6 +246,17 @@ func SetupNamespace(recipeControllerConfig *controllerconfig.RecipeControllerCon
 				APIController: ext_ctrl.NewListSecretsExtender,
 			},
 		},
+		RecipePlan: builder.Operation[datamodel.Extender]{
+			APIController: func(opt apictrl.Options) (apictrl.Controller, error) {
+				return pr_frontend_ctrl.NewPlanRecipe[*datamodel.Extender](opt, apictrl.ResourceOptions[datamodel.Extender]{
+					AsyncOperationTimeout:    ext_ctrl.AsyncCreateOrUpdateExtenderTimeout,
+					AsyncOperationRetryAfter: AsyncOperationRetryAfter,
+				})
+			},
+			AsyncJobController: func(options asyncctrl.Options) (asyncctrl.Controller, error) {
+				return pr_ctrl.NewPlanRecipe[*datamodel.Extender, datamodel.Extender](options, recipeControllerConfig.Engine)
+			},
+		},
 	})
 
 	// Optional
@@ -288,11 +300,13 @@ func SetupRadiusCoreNamespace(recipeControllerConfig *controllerconfig.RecipeCon
 		Put: builder.Operation[datamodel.Application_v20250801preview]{
 			UpdateFilters: []apictrl.UpdateFilter[datamodel.Application_v20250801preview]{
 				rp_frontend.PrepareRadiusResource[*datamodel.Application_v20250801preview],
+				app_v20250801_ctrl.PreserveConditions,
 			},
 		},
 		Patch: builder.Operation[datamodel.Application_v20250801preview]{
 			UpdateFilters: []apictrl.UpdateFilter[datamodel.Application_v20250801preview]{
 				rp_frontend.PrepareRadiusResource[*datamodel.Application_v20250801preview],
+				app_v20250801_ctrl.PreserveConditions,
 			},
 		},
 		Custom: map[string]builder.Operation[datamodel.Application_v20250801preview]{
@@ -301,6 +315,9 @@ func SetupRadiusCoreNamespace(recipeControllerConfig *controllerconfig.RecipeCon
 					return app_v20250801_ctrl.NewGetGraphv20250801preview(opt, *recipeControllerConfig.UCPConnection)
 				},
 			},
+			"updateConditions": {
+				APIController: app_v20250801_ctrl.NewUpdateConditionsv20250801preview,
+			},
 		},
 	})
 
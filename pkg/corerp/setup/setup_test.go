@@ -239,6 +239,10 @@ var radiusCoreHandlerTests = []rpctest.HandlerTestSpec{
 		OperationType: v1.OperationType{Type: "Radius.Core/applications", Method: "ACTIONGETGRAPH"},
 		Path:          "/resourcegroups/testrg/providers/radius.core/applications/app0/getgraph",
 		Method:        http.MethodPost,
+	}, {
+		OperationType: v1.OperationType{Type: "Radius.Core/applications", Method: "ACTIONUPDATECONDITIONS"},
+		Path:          "/resourcegroups/testrg/providers/radius.core/applications/app0/updateconditions",
+		Method:        http.MethodPost,
 	}, {
 		OperationType: v1.OperationType{Type: "Radius.Core/terraformSettings", Method: v1.OperationPut},
 		Path:          "/resourcegroups/testrg/providers/radius.core/terraformsettings/tfconfig0",
@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryptionprovider
+
+// Options configures the encryption key provider.
+type Options struct {
+	// Provider configures the encryption key provider. Defaults to TypeKubernetes if not specified.
+	Provider KeyProviderType `yaml:"provider,omitempty"`
+
+	// Kubernetes configures the Kubernetes Secret holding the key store. It is used by the kubernetes provider and
+	// by the envelope provider when its key store is a Kubernetes Secret.
+	Kubernetes KubernetesOptions `yaml:"kubernetes,omitempty"`
+
+	// File configures the key store file. It is used by the file provider and by the envelope provider when its key
+	// store is a file.
+	File FileOptions `yaml:"file,omitempty"`
+
+	// Envelope configures the envelope provider.
+	Envelope EnvelopeOptions `yaml:"envelope,omitempty"`
+
+	// Rotation configures the key rotation controller. Keys can only be rotated when they are stored in a
+	// Kubernetes Secret.
+	Rotation RotationOptions `yaml:"rotation,omitempty"`
+}
+
+// RotationOptions configures the key rotation controller.
+type RotationOptions struct {
+	// Interval is the time between two checks for a requested rotation, as a Go duration string (e.g. "1m").
+	// Defaults to keyrotation.DefaultInterval if not specified.
+	Interval string `yaml:"interval,omitempty"`
+
+	// GracePeriod is the time to wait after the sensitive fields were re-encrypted before they are scanned again
+	// and the previous key versions are retired, as a Go duration string (e.g. "24h"). Defaults to
+	// keyrotation.DefaultGracePeriod if not specified.
+	GracePeriod string `yaml:"gracePeriod,omitempty"`
+}
+
+// KubernetesOptions configures the Kubernetes Secret holding the key store.
+type KubernetesOptions struct {
+	// SecretName is the name of the Secret. Defaults to encryption.DefaultEncryptionKeySecretName if not specified.
+	SecretName string `yaml:"secretName,omitempty"`
+
+	// SecretKey is the key of the key store within the Secret. Defaults to encryption.DefaultEncryptionKeySecretKey
+	// if not specified.
+	SecretKey string `yaml:"secretKey,omitempty"`
+
+	// Namespace is the namespace of the Secret. Defaults to encryption.RadiusNamespace if not specified.
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// FileOptions configures the key store file.
+type FileOptions struct {
+	// Path is the path of the key store file.
+	Path string `yaml:"path"`
+}
+
+// EnvelopeOptions configures the envelope provider.
+type EnvelopeOptions struct {
+	// KeyStore configures where the wrapped keys are stored, either TypeKubernetes or TypeFile.
+	// Defaults to TypeKubernetes if not specified.
+	KeyStore KeyProviderType `yaml:"keyStore,omitempty"`
+
+	// KMS configures the key management service that wraps the keys.
+	KMS KMSType `yaml:"kms"`
+
+	// VaultTransit configures the Vault Transit KMS.
+	VaultTransit VaultTransitOptions `yaml:"vaultTransit,omitempty"`
+
+	// File configures the file KMS.
+	File FileKMSOptions `yaml:"file,omitempty"`
+}
+
+// VaultTransitOptions configures the Vault Transit KMS.
+type VaultTransitOptions struct {
+	// Address is the address of the Vault server.
+	Address string `yaml:"address"`
+
+	// Namespace is the optional Vault Enterprise namespace.
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// MountPath is the mount path of the Transit secrets engine. Defaults to "transit" if not specified.
+	MountPath string `yaml:"mountPath,omitempty"`
+
+	// KeyName is the name of the Transit key.
+	KeyName string `yaml:"keyName"`
+
+	// TokenFile is the path of a file holding the Vault token. When it is not specified, the token is read
+	// from the VAULT_TOKEN environment variable.
+	TokenFile string `yaml:"tokenFile,omitempty"`
+}
+
+// FileKMSOptions configures the file KMS.
+type FileKMSOptions struct {
+	// KeyPath is the path of the file holding the base64-encoded key-encryption key.
+	KeyPath string `yaml:"keyPath"`
+}
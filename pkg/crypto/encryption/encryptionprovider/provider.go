@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encryptionprovider creates the encryption.KeyProvider selected by the configuration of a service.
+package encryptionprovider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/radius-project/radius/pkg/crypto/encryption"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// VaultTokenEnvVar is the environment variable holding the Vault token when no token file is configured.
+	VaultTokenEnvVar = "VAULT_TOKEN" //nolint:gosec // This is an environment variable name, not credentials
+)
+
+var (
+	// ErrUnsupportedKeyProvider is returned when the configured key provider is not supported.
+	ErrUnsupportedKeyProvider = errors.New("unsupported encryption key provider")
+
+	// ErrKeyRotationUnsupported is returned when the key store of the configured key provider cannot be rotated.
+	ErrKeyRotationUnsupported = errors.New("the encryption key store cannot be rotated")
+)
+
+// NewKeyProvider creates the encryption.KeyProvider selected by options. kubeClient is used when the key store
+// is a Kubernetes Secret and may be nil otherwise.
+func NewKeyProvider(options Options, kubeClient runtimeclient.Client) (encryption.KeyProvider, error) {
+	switch options.Provider {
+	case "", TypeKubernetes:
+		return newKubernetesKeyProvider(options, kubeClient)
+	case TypeFile:
+		return newFileKeyProvider(options)
+	case TypeEnvelope:
+		var source encryption.KeyStoreSource
+		var err error
+		switch options.Envelope.KeyStore {
+		case "", TypeKubernetes:
+			source, err = newKubernetesKeyProvider(options, kubeClient)
+		case TypeFile:
+			source, err = newFileKeyProvider(options)
+		default:
+			err = fmt.Errorf("%w: unsupported envelope key store %q", ErrUnsupportedKeyProvider, options.Envelope.KeyStore)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		wrapper, err := NewKeyWrapper(options.Envelope)
+		if err != nil {
+			return nil, err
+		}
+
+		return encryption.NewEnvelopeKeyProvider(source, wrapper), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedKeyProvider, options.Provider)
+	}
+}
+
+// NewKeyRotationStore returns the Kubernetes Secret holding the key store of the key provider selected by
+// options, and the encryption.KeyWrapper that wraps new keys, which is nil when keys are not wrapped. It returns
+// ErrKeyRotationUnsupported when the key store is a file, which is read-only.
+func NewKeyRotationStore(options Options, kubeClient runtimeclient.Client) (*encryption.KubernetesKeyProvider, encryption.KeyWrapper, error) {
+	switch options.Provider {
+	case "", TypeKubernetes:
+		store, err := newKubernetesKeyProvider(options, kubeClient)
+		return store, nil, err
+	case TypeEnvelope:
+		if options.Envelope.KeyStore != "" && options.Envelope.KeyStore != TypeKubernetes {
+			return nil, nil, fmt.Errorf("%w: the envelope key store is %q", ErrKeyRotationUnsupported, options.Envelope.KeyStore)
+		}
+
+		store, err := newKubernetesKeyProvider(options, kubeClient)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		wrapper, err := NewKeyWrapper(options.Envelope)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return store, wrapper, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: the key provider is %q", ErrKeyRotationUnsupported, options.Provider)
+	}
+}
+
+// NewKeyWrapper creates the encryption.KeyWrapper of the KMS selected by options.
+func NewKeyWrapper(options EnvelopeOptions) (encryption.KeyWrapper, error) {
+	switch options.KMS {
+	case KMSVaultTransit:
+		vault := options.VaultTransit
+		opts := encryption.VaultTransitOptions{
+			Address:   vault.Address,
+			Namespace: vault.Namespace,
+			MountPath: vault.MountPath,
+			KeyName:   vault.KeyName,
+			TokenFile: vault.TokenFile,
+		}
+		if opts.TokenFile == "" {
+			opts.Token = os.Getenv(VaultTokenEnvVar)
+		}
+		return encryption.NewVaultTransitKeyWrapper(opts)
+	case KMSFile:
+		if options.File.KeyPath == "" {
+			return nil, errors.New("the key-encryption key path of the file KMS is required")
+		}
+		return encryption.NewFileKeyWrapper(options.File.KeyPath), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported KMS %q", ErrUnsupportedKeyProvider, options.KMS)
+	}
+}
+
+func newKubernetesKeyProvider(options Options, kubeClient runtimeclient.Client) (*encryption.KubernetesKeyProvider, error) {
+	if kubeClient == nil {
+		return nil, errors.New("a Kubernetes client is required to load the encryption keys from a Secret")
+	}
+
+	return encryption.NewKubernetesKeyProvider(kubeClient, &encryption.KubernetesKeyProviderOptions{
+		SecretName: options.Kubernetes.SecretName,
+		SecretKey:  options.Kubernetes.SecretKey,
+		Namespace:  options.Kubernetes.Namespace,
+	}), nil
+}
+
+func newFileKeyProvider(options Options) (*encryption.FileKeyProvider, error) {
+	if options.File.Path == "" {
+		return nil, errors.New("the key store file path is required")
+	}
+
+	return encryption.NewFileKeyProvider(options.File.Path), nil
+}
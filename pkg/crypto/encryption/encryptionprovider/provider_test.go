@@ -0,0 +1,189 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryptionprovider
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/radius-project/radius/pkg/crypto/encryption"
+	"github.com/radius-project/radius/test/k8sutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+func TestNewKeyProvider(t *testing.T) {
+	kubeClient := k8sutil.NewFakeKubeClient(scheme.Scheme)
+
+	tests := []struct {
+		name     string
+		options  Options
+		expected any
+		err      string
+	}{
+		{
+			name:     "default",
+			options:  Options{},
+			expected: &encryption.KubernetesKeyProvider{},
+		},
+		{
+			name:     "file",
+			options:  Options{Provider: TypeFile, File: FileOptions{Path: "/keys.json"}},
+			expected: &encryption.FileKeyProvider{},
+		},
+		{
+			name:    "file without path",
+			options: Options{Provider: TypeFile},
+			err:     "the key store file path is required",
+		},
+		{
+			name: "envelope with vault transit",
+			options: Options{Provider: TypeEnvelope, Envelope: EnvelopeOptions{
+				KMS:          KMSVaultTransit,
+				VaultTransit: VaultTransitOptions{Address: "http://localhost:8200", KeyName: "radius"},
+			}},
+			expected: &encryption.EnvelopeKeyProvider{},
+		},
+		{
+			name: "envelope with file kms and key store",
+			options: Options{Provider: TypeEnvelope, File: FileOptions{Path: "/keys.json"}, Envelope: EnvelopeOptions{
+				KeyStore: TypeFile,
+				KMS:      KMSFile,
+				File:     FileKMSOptions{KeyPath: "/kek"},
+			}},
+			expected: &encryption.EnvelopeKeyProvider{},
+		},
+		{
+			name:    "envelope without kms",
+			options: Options{Provider: TypeEnvelope},
+			err:     "unsupported encryption key provider: unsupported KMS \"\"",
+		},
+		{
+			name:    "envelope with invalid key store",
+			options: Options{Provider: TypeEnvelope, Envelope: EnvelopeOptions{KeyStore: TypeEnvelope, KMS: KMSFile}},
+			err:     "unsupported encryption key provider: unsupported envelope key store \"envelope\"",
+		},
+		{
+			name:    "unsupported",
+			options: Options{Provider: "cloud"},
+			err:     "unsupported encryption key provider: \"cloud\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewKeyProvider(tt.options, kubeClient)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.IsType(t, tt.expected, provider)
+		})
+	}
+
+	t.Run("kubernetes without client", func(t *testing.T) {
+		_, err := NewKeyProvider(Options{}, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestNewKeyProvider_Envelope(t *testing.T) {
+	dir := t.TempDir()
+
+	kek, err := encryption.GenerateKey()
+	require.NoError(t, err)
+	kekPath := filepath.Join(dir, "kek")
+	require.NoError(t, os.WriteFile(kekPath, []byte(base64.StdEncoding.EncodeToString(kek)), 0600))
+
+	key, err := encryption.GenerateKey()
+	require.NoError(t, err)
+
+	options := Options{
+		Provider: TypeEnvelope,
+		File:     FileOptions{Path: filepath.Join(dir, "keys.json")},
+		Envelope: EnvelopeOptions{KeyStore: TypeFile, KMS: KMSFile, File: FileKMSOptions{KeyPath: kekPath}},
+	}
+
+	wrapper, err := NewKeyWrapper(options.Envelope)
+	require.NoError(t, err)
+	wrapped, err := wrapper.WrapKey(t.Context(), key)
+	require.NoError(t, err)
+	keyStore := `{"currentVersion":1,"keys":{"1":{"key":"` + wrapped + `","version":1}}}`
+	require.NoError(t, os.WriteFile(options.File.Path, []byte(keyStore), 0600))
+
+	provider, err := NewKeyProvider(options, nil)
+	require.NoError(t, err)
+
+	actual, version, err := provider.GetCurrentKey(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, key, actual)
+	require.Equal(t, 1, version)
+}
+
+func TestNewKeyRotationStore(t *testing.T) {
+	kubeClient := k8sutil.NewFakeKubeClient(scheme.Scheme)
+	vault := VaultTransitOptions{Address: "http://localhost:8200", KeyName: "radius"}
+
+	tests := []struct {
+		name    string
+		options Options
+		wrapped bool
+		err     string
+	}{
+		{
+			name:    "default",
+			options: Options{},
+		},
+		{
+			name:    "envelope",
+			options: Options{Provider: TypeEnvelope, Envelope: EnvelopeOptions{KMS: KMSVaultTransit, VaultTransit: vault}},
+			wrapped: true,
+		},
+		{
+			name:    "file",
+			options: Options{Provider: TypeFile, File: FileOptions{Path: "/keys.json"}},
+			err:     "the encryption key store cannot be rotated: the key provider is \"file\"",
+		},
+		{
+			name: "envelope with file key store",
+			options: Options{Provider: TypeEnvelope, Envelope: EnvelopeOptions{
+				KeyStore:     TypeFile,
+				KMS:          KMSVaultTransit,
+				VaultTransit: vault,
+			}},
+			err: "the encryption key store cannot be rotated: the envelope key store is \"file\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, wrapper, err := NewKeyRotationStore(tt.options, kubeClient)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, store)
+			require.Equal(t, tt.wrapped, wrapper != nil)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryptionprovider
+
+// KeyProviderType represents types of encryption key provider.
+type KeyProviderType string
+
+const (
+	// TypeKubernetes represents the provider that loads the key store from a Kubernetes Secret.
+	TypeKubernetes KeyProviderType = "kubernetes"
+
+	// TypeFile represents the provider that loads the key store from a local file.
+	TypeFile KeyProviderType = "file"
+
+	// TypeEnvelope represents the provider that loads a key store of keys wrapped by a key management service.
+	TypeEnvelope KeyProviderType = "envelope"
+)
+
+// KMSType represents types of key management service used by the envelope provider.
+type KMSType string
+
+const (
+	// KMSVaultTransit represents the HashiCorp Vault Transit secrets engine, or a server with the same API.
+	KMSVaultTransit KMSType = "vaultTransit"
+
+	// KMSFile represents a key-encryption key read from a local file.
+	KMSFile KMSType = "file"
+)
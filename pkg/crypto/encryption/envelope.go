@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// KeyWrapper wraps data-encryption keys with a key-encryption key held by a key management service (KMS),
+// so that the keys are never stored in plaintext.
+type KeyWrapper interface {
+	// WrapKey encrypts a data-encryption key and returns the wrapped key as text.
+	WrapKey(ctx context.Context, key []byte) (string, error)
+
+	// UnwrapKey decrypts a wrapped key returned by WrapKey.
+	UnwrapKey(ctx context.Context, wrapped string) ([]byte, error)
+}
+
+// KeyStoreSource loads a versioned key store. KubernetesKeyProvider and FileKeyProvider are key store sources.
+type KeyStoreSource interface {
+	// LoadKeyStore loads and parses the key store.
+	LoadKeyStore(ctx context.Context) (*KeyStore, error)
+}
+
+var _ KeyStoreSource = (*KubernetesKeyProvider)(nil)
+var _ KeyStoreSource = (*FileKeyProvider)(nil)
+var _ KeyProvider = (*EnvelopeKeyProvider)(nil)
+
+// EnvelopeKeyProvider implements KeyProvider with envelope encryption. The key store holds data-encryption keys
+// wrapped by a KeyWrapper instead of plaintext keys, and each key is unwrapped with the KMS when it is first used.
+type EnvelopeKeyProvider struct {
+	source  KeyStoreSource
+	wrapper KeyWrapper
+
+	// mutex protects unwrapped.
+	mutex sync.Mutex
+
+	// unwrapped caches unwrapped keys by their wrapped form, so the KMS is only called once per key.
+	unwrapped map[string][]byte
+}
+
+// NewEnvelopeKeyProvider creates a new EnvelopeKeyProvider that loads wrapped keys from source and unwraps them
+// with wrapper.
+func NewEnvelopeKeyProvider(source KeyStoreSource, wrapper KeyWrapper) *EnvelopeKeyProvider {
+	return &EnvelopeKeyProvider{
+		source:    source,
+		wrapper:   wrapper,
+		unwrapped: map[string][]byte{},
+	}
+}
+
+// GetCurrentKey loads the key store and unwraps the key of the current version.
+func (p *EnvelopeKeyProvider) GetCurrentKey(ctx context.Context) ([]byte, int, error) {
+	keyStore, err := p.source.LoadKeyStore(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	keyData, ok := keyStore.Keys[strconv.Itoa(keyStore.CurrentVersion)]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: current version %d not found in key store", ErrKeyVersionNotFound, keyStore.CurrentVersion)
+	}
+
+	key, err := p.unwrap(ctx, keyStore.CurrentVersion, keyData.Key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return key, keyStore.CurrentVersion, nil
+}
+
+// GetKeyByVersion loads the key store and unwraps the key of a specific version.
+func (p *EnvelopeKeyProvider) GetKeyByVersion(ctx context.Context, version int) ([]byte, error) {
+	keyStore, err := p.source.LoadKeyStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keyData, ok := keyStore.Keys[strconv.Itoa(version)]
+	if !ok {
+		return nil, fmt.Errorf("%w: version %d not found in key store", ErrKeyVersionNotFound, version)
+	}
+
+	return p.unwrap(ctx, version, keyData.Key)
+}
+
+// unwrap returns a copy of the unwrapped key for a wrapped key, using the cache when possible.
+func (p *EnvelopeKeyProvider) unwrap(ctx context.Context, version int, wrapped string) ([]byte, error) {
+	p.mutex.Lock()
+	key, ok := p.unwrapped[wrapped]
+	p.mutex.Unlock()
+	if ok {
+		return append([]byte(nil), key...), nil
+	}
+
+	key, err := p.wrapper.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unwrap key version %d: %v", ErrKeyLoadFailed, version, err)
+	}
+
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: key version %d has invalid size (expected %d bytes, got %d)", ErrKeyLoadFailed, version, KeySize, len(key))
+	}
+
+	p.mutex.Lock()
+	p.unwrapped[wrapped] = key
+	p.mutex.Unlock()
+
+	return append([]byte(nil), key...), nil
+}
+
+// WrapKeyStore returns a copy of a key store holding plaintext keys in which every key is wrapped by wrapper.
+// It is used to migrate a key store to an EnvelopeKeyProvider.
+func WrapKeyStore(ctx context.Context, keyStore *KeyStore, wrapper KeyWrapper) (*KeyStore, error) {
+	wrapped := &KeyStore{
+		CurrentVersion: keyStore.CurrentVersion,
+		Keys:           make(map[string]KeyData, len(keyStore.Keys)),
+	}
+
+	var errs error
+	for version, keyData := range keyStore.Keys {
+		key, err := base64.StdEncoding.DecodeString(keyData.Key)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%w: failed to decode key version %s: %v", ErrKeyLoadFailed, version, err))
+			continue
+		}
+
+		keyData.Key, err = wrapper.WrapKey(ctx, key)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to wrap key version %s: %w", version, err))
+			continue
+		}
+
+		wrapped.Keys[version] = keyData
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return wrapped, nil
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransitServer is a stand-in for the encrypt and decrypt endpoints of the Vault Transit secrets engine.
+type fakeTransitServer struct {
+	*httptest.Server
+	decrypts atomic.Int32
+}
+
+func newFakeTransitServer(t *testing.T, token string) *fakeTransitServer {
+	kek, err := GenerateKey()
+	require.NoError(t, err)
+	encryptor, err := NewEncryptor(kek)
+	require.NoError(t, err)
+
+	server := &fakeTransitServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get(vaultTokenHeader) != token {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		body := vaultTransitRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		response := vaultTransitResponse{}
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/radius":
+			plaintext, err := base64.StdEncoding.DecodeString(body.Plaintext)
+			require.NoError(t, err)
+			encrypted, err := encryptor.Encrypt(plaintext, nil)
+			require.NoError(t, err)
+			response.Data.Ciphertext = "vault:v1:" + base64.StdEncoding.EncodeToString(encrypted)
+		case "/v1/transit/decrypt/radius":
+			server.decrypts.Add(1)
+			encrypted, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(body.Ciphertext, "vault:v1:"))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":["invalid ciphertext"]}`))
+				return
+			}
+			plaintext, err := encryptor.Decrypt(encrypted, nil)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":["invalid ciphertext"]}`))
+				return
+			}
+			response.Data.Plaintext = base64.StdEncoding.EncodeToString(plaintext)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":["unsupported path"]}`))
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestVaultTransitKeyWrapper(t *testing.T) {
+	server := newFakeTransitServer(t, "test-token")
+	key, err := GenerateKey()
+	require.NoError(t, err)
+
+	t.Run("wrap and unwrap", func(t *testing.T) {
+		wrapper, err := NewVaultTransitKeyWrapper(VaultTransitOptions{Address: server.URL, Token: "test-token", KeyName: "radius"})
+		require.NoError(t, err)
+
+		wrapped, err := wrapper.WrapKey(t.Context(), key)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(wrapped, "vault:v1:"))
+
+		unwrapped, err := wrapper.UnwrapKey(t.Context(), wrapped)
+		require.NoError(t, err)
+		require.Equal(t, key, unwrapped)
+	})
+
+	t.Run("token file", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte("test-token\n"), 0600))
+
+		wrapper, err := NewVaultTransitKeyWrapper(VaultTransitOptions{Address: server.URL, Token: "ignored", TokenFile: tokenFile, KeyName: "radius"})
+		require.NoError(t, err)
+
+		_, err = wrapper.WrapKey(t.Context(), key)
+		require.NoError(t, err)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		wrapper, err := NewVaultTransitKeyWrapper(VaultTransitOptions{Address: server.URL, Token: "wrong-token", KeyName: "radius"})
+		require.NoError(t, err)
+
+		_, err = wrapper.WrapKey(t.Context(), key)
+		require.EqualError(t, err, "vault transit encrypt failed with status 403: permission denied")
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		wrapper, err := NewVaultTransitKeyWrapper(VaultTransitOptions{Address: server.URL, Token: "test-token", KeyName: "other"})
+		require.NoError(t, err)
+
+		_, err = wrapper.UnwrapKey(t.Context(), "vault:v1:abc")
+		require.EqualError(t, err, "vault transit decrypt failed with status 404: unsupported path")
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := NewVaultTransitKeyWrapper(VaultTransitOptions{KeyName: "radius"})
+		require.Error(t, err)
+
+		_, err = NewVaultTransitKeyWrapper(VaultTransitOptions{Address: server.URL})
+		require.Error(t, err)
+	})
+}
+
+func TestFileKeyWrapper(t *testing.T) {
+	writeKEK := func(t *testing.T) string {
+		kek, err := GenerateKey()
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "kek")
+		require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(kek)+"\n"), 0600))
+		return path
+	}
+
+	key, err := GenerateKey()
+	require.NoError(t, err)
+
+	wrapper := NewFileKeyWrapper(writeKEK(t))
+	wrapped, err := wrapper.WrapKey(t.Context(), key)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(wrapped, FileWrappedKeyPrefix))
+
+	unwrapped, err := wrapper.UnwrapKey(t.Context(), wrapped)
+	require.NoError(t, err)
+	require.Equal(t, key, unwrapped)
+
+	_, err = NewFileKeyWrapper(writeKEK(t)).UnwrapKey(t.Context(), wrapped)
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+
+	_, err = wrapper.UnwrapKey(t.Context(), base64.StdEncoding.EncodeToString(key))
+	require.ErrorIs(t, err, ErrInvalidEncryptedData)
+
+	_, err = NewFileKeyWrapper(filepath.Join(t.TempDir(), "missing")).WrapKey(t.Context(), key)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestEnvelopeKeyProvider(t *testing.T) {
+	server := newFakeTransitServer(t, "test-token")
+	wrapper, err := NewVaultTransitKeyWrapper(VaultTransitOptions{Address: server.URL, Token: "test-token", KeyName: "radius"})
+	require.NoError(t, err)
+
+	key1, err := GenerateKey()
+	require.NoError(t, err)
+	key2, err := GenerateKey()
+	require.NoError(t, err)
+
+	plaintext := KeyStore{}
+	require.NoError(t, json.Unmarshal(createTestKeyStore(t, map[int][]byte{1: key1, 2: key2}, 2), &plaintext))
+
+	wrapped, err := WrapKeyStore(t.Context(), &plaintext, wrapper)
+	require.NoError(t, err)
+	require.Equal(t, 2, wrapped.CurrentVersion)
+	require.NotContains(t, wrapped.Keys["1"].Key, base64.StdEncoding.EncodeToString(key1))
+
+	writeKeyStore := func(t *testing.T, keyStore *KeyStore) string {
+		data, err := json.Marshal(keyStore)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "keys.json")
+		require.NoError(t, os.WriteFile(path, data, 0600))
+		return path
+	}
+
+	t.Run("unwraps keys once", func(t *testing.T) {
+		provider := NewEnvelopeKeyProvider(NewFileKeyProvider(writeKeyStore(t, wrapped)), wrapper)
+		before := server.decrypts.Load()
+
+		key, version, err := provider.GetCurrentKey(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, key2, key)
+		require.Equal(t, 2, version)
+
+		key, err = provider.GetKeyByVersion(t.Context(), 1)
+		require.NoError(t, err)
+		require.Equal(t, key1, key)
+
+		key, _, err = provider.GetCurrentKey(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, key2, key)
+		require.Equal(t, int32(2), server.decrypts.Load()-before)
+	})
+
+	t.Run("version not found", func(t *testing.T) {
+		provider := NewEnvelopeKeyProvider(NewFileKeyProvider(writeKeyStore(t, wrapped)), wrapper)
+
+		_, err := provider.GetKeyByVersion(t.Context(), 3)
+		require.ErrorIs(t, err, ErrKeyVersionNotFound)
+	})
+
+	t.Run("plaintext keys are rejected", func(t *testing.T) {
+		provider := NewEnvelopeKeyProvider(NewFileKeyProvider(writeKeyStore(t, &plaintext)), wrapper)
+
+		_, _, err := provider.GetCurrentKey(t.Context())
+		require.ErrorIs(t, err, ErrKeyLoadFailed)
+	})
+
+	t.Run("key store not found", func(t *testing.T) {
+		provider := NewEnvelopeKeyProvider(NewFileKeyProvider(filepath.Join(t.TempDir(), "missing.json")), wrapper)
+
+		_, _, err := provider.GetCurrentKey(t.Context())
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// FileWrappedKeyPrefix is the prefix of the keys wrapped by a FileKeyWrapper.
+	FileWrappedKeyPrefix = "file:v1:"
+)
+
+// fileKeyWrapperAD is the associated data of the keys wrapped by a FileKeyWrapper. It prevents other data
+// encrypted with the key-encryption key from being used as a wrapped key.
+var fileKeyWrapperAD = []byte("radius-key-wrap")
+
+var _ KeyWrapper = (*FileKeyWrapper)(nil)
+
+// FileKeyWrapper implements KeyWrapper with a key-encryption key read from a local file, for example a file
+// mounted from a hardware security module or a secret manager. The file holds the base64-encoded 32 byte key.
+type FileKeyWrapper struct {
+	path string
+}
+
+// NewFileKeyWrapper creates a new FileKeyWrapper that reads the key-encryption key at path.
+// The file is read on every call so that a replaced key-encryption key is picked up without a restart.
+func NewFileKeyWrapper(path string) *FileKeyWrapper {
+	return &FileKeyWrapper{path: path}
+}
+
+// encryptor reads the key-encryption key and creates an encryptor for it.
+func (w *FileKeyWrapper) encryptor() (*Encryptor, error) {
+	data, err := os.ReadFile(w.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: key-encryption key file %q not found", ErrKeyNotFound, w.path)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyLoadFailed, err)
+	}
+
+	kek, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode key-encryption key: %v", ErrKeyLoadFailed, err)
+	}
+
+	return NewEncryptor(kek)
+}
+
+// WrapKey encrypts key with the key-encryption key.
+func (w *FileKeyWrapper) WrapKey(ctx context.Context, key []byte) (string, error) {
+	encryptor, err := w.encryptor()
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := encryptor.Encrypt(key, fileKeyWrapperAD)
+	if err != nil {
+		return "", err
+	}
+
+	return FileWrappedKeyPrefix + base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+// UnwrapKey decrypts a key wrapped by WrapKey.
+func (w *FileKeyWrapper) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(wrapped, FileWrappedKeyPrefix)
+	if !ok {
+		return nil, fmt.Errorf("%w: the key was not wrapped by a file key-encryption key", ErrInvalidEncryptedData)
+	}
+
+	encrypted, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncryptedData, err)
+	}
+
+	encryptor, err := w.encryptor()
+	if err != nil {
+		return nil, err
+	}
+
+	return encryptor.Decrypt(encrypted, fileKeyWrapperAD)
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
@@ -43,7 +44,7 @@ const (
 )
 
 // KeyStore represents a versioned key store containing multiple encryption keys.
-// This structure matches the format used by the key rotation CronJob.
+// This structure matches the format written by the key rotation controller of the dynamic-rp.
 type KeyStore struct {
 	// CurrentVersion is the version number of the key to use for encryption.
 	CurrentVersion int `json:"currentVersion"`
@@ -139,8 +140,8 @@ func NewKubernetesKeyProvider(client controller_runtime.Client, opts *Kubernetes
 	}
 }
 
-// loadKeyStore loads and parses the key store from the Kubernetes Secret.
-func (p *KubernetesKeyProvider) loadKeyStore(ctx context.Context) (*KeyStore, error) {
+// LoadKeyStore loads and parses the key store from the Kubernetes Secret.
+func (p *KubernetesKeyProvider) LoadKeyStore(ctx context.Context) (*KeyStore, error) {
 	secret := &corev1.Secret{}
 	objectKey := controller_runtime.ObjectKey{
 		Name:      p.secretName,
@@ -170,15 +171,76 @@ func (p *KubernetesKeyProvider) loadKeyStore(ctx context.Context) (*KeyStore, er
 // GetCurrentKey retrieves the current encryption key from the Kubernetes Secret.
 // Returns the key bytes, version number, and any error.
 func (p *KubernetesKeyProvider) GetCurrentKey(ctx context.Context) ([]byte, int, error) {
-	keyStore, err := p.loadKeyStore(ctx)
+	keyStore, err := p.LoadKeyStore(ctx)
 	if err != nil {
 		return nil, 0, err
 	}
+	return keyStore.currentKey()
+}
+
+// GetKeyByVersion retrieves a specific key version from the Kubernetes Secret.
+func (p *KubernetesKeyProvider) GetKeyByVersion(ctx context.Context, version int) ([]byte, error) {
+	keyStore, err := p.LoadKeyStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return keyStore.keyByVersion(version)
+}
+
+// FileKeyProvider implements KeyProvider by loading a versioned key store from a local file.
+// The file uses the same JSON format as the Kubernetes Secret, so a key store exported from
+// the cluster can be used by tools that run while the cluster is unavailable.
+type FileKeyProvider struct {
+	path string
+}
+
+// NewFileKeyProvider creates a new FileKeyProvider that reads the key store JSON at path.
+// The file is read on every call so that rotated keys are picked up without a restart.
+func NewFileKeyProvider(path string) *FileKeyProvider {
+	return &FileKeyProvider{path: path}
+}
+
+// LoadKeyStore loads and parses the key store from the file.
+func (p *FileKeyProvider) LoadKeyStore(ctx context.Context) (*KeyStore, error) {
+	keysJSON, err := os.ReadFile(p.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: key store file %q not found", ErrKeyNotFound, p.path)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyLoadFailed, err)
+	}
+
+	var keyStore KeyStore
+	if err := json.Unmarshal(keysJSON, &keyStore); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse key store JSON: %v", ErrKeyLoadFailed, err)
+	}
 
-	versionStr := strconv.Itoa(keyStore.CurrentVersion)
-	keyData, ok := keyStore.Keys[versionStr]
+	return &keyStore, nil
+}
+
+// GetCurrentKey retrieves the current encryption key from the key store file.
+func (p *FileKeyProvider) GetCurrentKey(ctx context.Context) ([]byte, int, error) {
+	keyStore, err := p.LoadKeyStore(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return keyStore.currentKey()
+}
+
+// GetKeyByVersion retrieves a specific key version from the key store file.
+func (p *FileKeyProvider) GetKeyByVersion(ctx context.Context, version int) ([]byte, error) {
+	keyStore, err := p.LoadKeyStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return keyStore.keyByVersion(version)
+}
+
+// currentKey decodes the key for the current version of the key store.
+func (s *KeyStore) currentKey() ([]byte, int, error) {
+	versionStr := strconv.Itoa(s.CurrentVersion)
+	keyData, ok := s.Keys[versionStr]
 	if !ok {
-		return nil, 0, fmt.Errorf("%w: current version %d not found in key store", ErrKeyVersionNotFound, keyStore.CurrentVersion)
+		return nil, 0, fmt.Errorf("%w: current version %d not found in key store", ErrKeyVersionNotFound, s.CurrentVersion)
 	}
 
 	key, err := base64.StdEncoding.DecodeString(keyData.Key)
@@ -187,21 +249,16 @@ func (p *KubernetesKeyProvider) GetCurrentKey(ctx context.Context) ([]byte, int,
 	}
 
 	if len(key) != KeySize {
-		return nil, 0, fmt.Errorf("%w: key version %d has invalid size (expected %d bytes, got %d)", ErrKeyLoadFailed, keyStore.CurrentVersion, KeySize, len(key))
+		return nil, 0, fmt.Errorf("%w: key version %d has invalid size (expected %d bytes, got %d)", ErrKeyLoadFailed, s.CurrentVersion, KeySize, len(key))
 	}
 
-	return key, keyStore.CurrentVersion, nil
+	return key, s.CurrentVersion, nil
 }
 
-// GetKeyByVersion retrieves a specific key version from the Kubernetes Secret.
-func (p *KubernetesKeyProvider) GetKeyByVersion(ctx context.Context, version int) ([]byte, error) {
-	keyStore, err := p.loadKeyStore(ctx)
-	if err != nil {
-		return nil, err
-	}
-
+// keyByVersion decodes the key for a specific version of the key store.
+func (s *KeyStore) keyByVersion(version int) ([]byte, error) {
 	versionStr := strconv.Itoa(version)
-	keyData, ok := keyStore.Keys[versionStr]
+	keyData, ok := s.Keys[versionStr]
 	if !ok {
 		return nil, fmt.Errorf("%w: version %d not found in key store", ErrKeyVersionNotFound, version)
 	}
@@ -19,6 +19,8 @@ package encryption
 import (
 	"encoding/base64"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -368,6 +370,44 @@ func TestNewKubernetesKeyProvider_DefaultOptions(t *testing.T) {
 	require.Equal(t, RadiusNamespace, provider.namespace)
 }
 
+func TestFileKeyProvider(t *testing.T) {
+	ctx := t.Context()
+	key1 := make([]byte, KeySize)
+	key2 := make([]byte, KeySize)
+	for i := range key1 {
+		key1[i] = byte(i)
+		key2[i] = byte(i + 100)
+	}
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, createTestKeyStore(t, map[int][]byte{1: key1, 2: key2}, 2), 0o600))
+	provider := NewFileKeyProvider(path)
+
+	key, version, err := provider.GetCurrentKey(ctx)
+	require.NoError(t, err)
+	require.Equal(t, key2, key)
+	require.Equal(t, 2, version)
+
+	key, err = provider.GetKeyByVersion(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, key1, key)
+
+	_, err = provider.GetKeyByVersion(ctx, 3)
+	require.ErrorIs(t, err, ErrKeyVersionNotFound)
+
+	t.Run("missing-file", func(t *testing.T) {
+		_, _, err := NewFileKeyProvider(filepath.Join(t.TempDir(), "missing.json")).GetCurrentKey(ctx)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("invalid-json", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "keys.json")
+		require.NoError(t, os.WriteFile(invalid, []byte("not json"), 0o600))
+		_, _, err := NewFileKeyProvider(invalid).GetCurrentKey(ctx)
+		require.ErrorIs(t, err, ErrKeyLoadFailed)
+	})
+}
+
 func TestInMemoryKeyProvider(t *testing.T) {
 	ctx := t.Context()
 	validKey := make([]byte, KeySize)
@@ -0,0 +1,283 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_error "k8s.io/apimachinery/pkg/api/errors"
+	controller_runtime "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// KeyRotationRequestedAnnotation is the annotation of the key store Secret that requests a key rotation.
+	// Its value is the time of the request (RFC3339 format).
+	KeyRotationRequestedAnnotation = "radius.dev/key-rotation-requested"
+
+	// KeyRotationStatusAnnotation is the annotation of the key store Secret that holds the KeyRotationStatus
+	// of the last key rotation as JSON.
+	KeyRotationStatusAnnotation = "radius.dev/key-rotation-status"
+
+	// LastRotationAnnotation is the annotation of the key store Secret that holds the time of the last key
+	// rotation (RFC3339 format).
+	LastRotationAnnotation = "radius.dev/last-rotation"
+
+	// DefaultKeyValidity is the time a new key version is used for encryption before it expires.
+	DefaultKeyValidity = 90 * 24 * time.Hour
+)
+
+var (
+	// ErrKeyRotationConflict is returned when the key store Secret was modified since it was loaded.
+	ErrKeyRotationConflict = errors.New("the key store was modified concurrently")
+)
+
+// KeyRotationState is the state of a key rotation.
+type KeyRotationState string
+
+const (
+	// KeyRotationStateReencrypting is the state of a key rotation while the sensitive fields are re-encrypted
+	// with the new key version.
+	KeyRotationStateReencrypting KeyRotationState = "Reencrypting"
+
+	// KeyRotationStateVerifying is the state of a key rotation after all sensitive fields were re-encrypted, until
+	// the previous key versions are retired. Replicas that loaded the previous key before the rotation can still
+	// write with it, so the sensitive fields are scanned again after a grace period, and the previous versions are
+	// retired once a scan finds nothing to re-encrypt.
+	KeyRotationStateVerifying KeyRotationState = "Verifying"
+
+	// KeyRotationStateCompleted is the state of a key rotation after all sensitive fields were re-encrypted and
+	// the previous key versions were retired.
+	KeyRotationStateCompleted KeyRotationState = "Completed"
+
+	// KeyRotationStateFailed is the state of a key rotation after some sensitive fields could not be
+	// re-encrypted. The previous key versions are kept because they are still referenced, and the
+	// resources are scanned again like in the Verifying state.
+	KeyRotationStateFailed KeyRotationState = "Failed"
+)
+
+// KeyRotationStatus is the progress of a key rotation. It records the position of the re-encryption, so that
+// it can be resumed after a restart.
+type KeyRotationStatus struct {
+	// State is the state of the rotation.
+	State KeyRotationState `json:"state"`
+	// Version is the key version the sensitive fields are re-encrypted with.
+	Version int `json:"version"`
+	// StartedAt is the time when the rotation started.
+	StartedAt time.Time `json:"startedAt"`
+	// CompletedAt is the time when the rotation completed or failed.
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// VerifyAfter is the time when the next verification scan starts, while the rotation is Verifying or Failed.
+	VerifyAfter *time.Time `json:"verifyAfter,omitempty"`
+
+	// ResourceType is the resource type being re-encrypted.
+	ResourceType string `json:"resourceType,omitempty"`
+	// PaginationToken is the token of the next page of resources of ResourceType.
+	PaginationToken string `json:"paginationToken,omitempty"`
+
+	// Scanned is the number of resources scanned by the current or last scan.
+	Scanned int `json:"scanned"`
+	// Reencrypted is the number of resources whose sensitive fields were re-encrypted by the current or last scan.
+	Reencrypted int `json:"reencrypted"`
+	// Failed is the number of resources whose sensitive fields could not be re-encrypted by the current or last scan.
+	Failed int `json:"failed"`
+	// Stragglers is the number of resources re-encrypted by the current verification scan.
+	Stragglers int `json:"stragglers,omitempty"`
+	// RetiredVersions are the key versions removed from the key store when the rotation completed.
+	RetiredVersions []int `json:"retiredVersions,omitempty"`
+	// Message describes the last failure of the current or last scan.
+	Message string `json:"message,omitempty"`
+}
+
+// KeyRotation is the key store of a Kubernetes Secret along with the state of its rotation.
+type KeyRotation struct {
+	// KeyStore is the key store.
+	KeyStore *KeyStore
+	// RequestedAt is the time a key rotation was requested, or empty if no rotation is requested.
+	RequestedAt string
+	// Status is the status of the last key rotation, or nil if the key store was never rotated.
+	Status *KeyRotationStatus
+
+	// secret is the Secret the key rotation was loaded from.
+	secret *corev1.Secret
+}
+
+// LoadKeyRotation loads the key store and the state of its rotation from the Kubernetes Secret.
+func (p *KubernetesKeyProvider) LoadKeyRotation(ctx context.Context) (*KeyRotation, error) {
+	secret := &corev1.Secret{}
+	objectKey := controller_runtime.ObjectKey{
+		Name:      p.secretName,
+		Namespace: p.namespace,
+	}
+
+	if err := p.client.Get(ctx, objectKey, secret); err != nil {
+		if k8s_error.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: secret %s/%s not found", ErrKeyNotFound, p.namespace, p.secretName)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrKeyLoadFailed, err)
+	}
+
+	keysJSON, ok := secret.Data[p.secretKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: key %q not found in secret %s/%s", ErrKeyNotFound, p.secretKey, p.namespace, p.secretName)
+	}
+
+	rotation := &KeyRotation{
+		KeyStore:    &KeyStore{},
+		RequestedAt: secret.Annotations[KeyRotationRequestedAnnotation],
+		secret:      secret,
+	}
+	if err := json.Unmarshal(keysJSON, rotation.KeyStore); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse key store JSON: %v", ErrKeyLoadFailed, err)
+	}
+
+	if status, ok := secret.Annotations[KeyRotationStatusAnnotation]; ok && status != "" {
+		rotation.Status = &KeyRotationStatus{}
+		if err := json.Unmarshal([]byte(status), rotation.Status); err != nil {
+			return nil, fmt.Errorf("%w: failed to parse key rotation status: %v", ErrKeyLoadFailed, err)
+		}
+	}
+
+	return rotation, nil
+}
+
+// SaveKeyRotation saves the key store and the state of its rotation to the Kubernetes Secret it was loaded
+// from. It returns ErrKeyRotationConflict if the Secret was modified since it was loaded.
+func (p *KubernetesKeyProvider) SaveKeyRotation(ctx context.Context, rotation *KeyRotation) error {
+	if rotation.secret == nil {
+		return errors.New("the key rotation was not loaded from a Secret")
+	}
+
+	keysJSON, err := json.Marshal(rotation.KeyStore)
+	if err != nil {
+		return err
+	}
+
+	secret := rotation.secret.DeepCopy()
+	secret.Data[p.secretKey] = keysJSON
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+
+	if rotation.RequestedAt == "" {
+		delete(secret.Annotations, KeyRotationRequestedAnnotation)
+	} else {
+		secret.Annotations[KeyRotationRequestedAnnotation] = rotation.RequestedAt
+	}
+
+	if rotation.Status == nil {
+		delete(secret.Annotations, KeyRotationStatusAnnotation)
+	} else {
+		status, err := json.Marshal(rotation.Status)
+		if err != nil {
+			return err
+		}
+		secret.Annotations[KeyRotationStatusAnnotation] = string(status)
+		secret.Annotations[LastRotationAnnotation] = rotation.Status.StartedAt.UTC().Format(time.RFC3339)
+	}
+
+	if err := p.client.Update(ctx, secret); err != nil {
+		if k8s_error.IsConflict(err) {
+			return fmt.Errorf("%w: secret %s/%s", ErrKeyRotationConflict, p.namespace, p.secretName)
+		}
+		return fmt.Errorf("failed to save the key store: %w", err)
+	}
+
+	rotation.secret = secret
+	return nil
+}
+
+// RequestKeyRotation requests a key rotation. The rotation is performed by the key rotation controller of the
+// Dynamic RP, which adds a new key version and re-encrypts the sensitive fields with it.
+func (p *KubernetesKeyProvider) RequestKeyRotation(ctx context.Context, now time.Time) (*KeyRotation, error) {
+	rotation, err := p.LoadKeyRotation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation.RequestedAt = now.UTC().Format(time.RFC3339)
+	if err := p.SaveKeyRotation(ctx, rotation); err != nil {
+		return nil, err
+	}
+
+	return rotation, nil
+}
+
+// AddVersion adds key as a new version of the key store and makes it the current version. key is the encoded
+// key, as returned by EncodeKey. It returns the new version.
+func (s *KeyStore) AddVersion(key string, createdAt time.Time, validity time.Duration) int {
+	version := s.CurrentVersion
+	for _, v := range s.Versions() {
+		version = max(version, v)
+	}
+	version++
+
+	if s.Keys == nil {
+		s.Keys = map[string]KeyData{}
+	}
+
+	s.Keys[strconv.Itoa(version)] = KeyData{
+		Key:       key,
+		Version:   version,
+		CreatedAt: createdAt.UTC().Format(time.RFC3339),
+		ExpiresAt: createdAt.Add(validity).UTC().Format(time.RFC3339),
+	}
+	s.CurrentVersion = version
+	return version
+}
+
+// RemoveVersion removes a key version from the key store. The current version cannot be removed.
+func (s *KeyStore) RemoveVersion(version int) error {
+	if version == s.CurrentVersion {
+		return fmt.Errorf("the current key version %d cannot be removed", version)
+	}
+
+	delete(s.Keys, strconv.Itoa(version))
+	return nil
+}
+
+// Versions returns the versions of the key store in ascending order.
+func (s *KeyStore) Versions() []int {
+	versions := []int{}
+	for name, keyData := range s.Keys {
+		version := keyData.Version
+		if v, err := strconv.Atoi(name); err == nil {
+			version = v
+		}
+		versions = append(versions, version)
+	}
+
+	slices.Sort(versions)
+	return versions
+}
+
+// EncodeKey encodes a new key to be stored in a key store. The key is wrapped with wrapper if it is not nil,
+// and base64-encoded otherwise.
+func EncodeKey(ctx context.Context, key []byte, wrapper KeyWrapper) (string, error) {
+	if wrapper == nil {
+		return base64.StdEncoding.EncodeToString(key), nil
+	}
+
+	return wrapper.WrapKey(ctx, key)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radius-project/radius/test/k8sutil"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+func TestKubernetesKeyProvider_KeyRotation(t *testing.T) {
+	ctx := t.Context()
+
+	key1, err := GenerateKey()
+	require.NoError(t, err)
+
+	k8sClient := k8sutil.NewFakeKubeClient(scheme.Scheme)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DefaultEncryptionKeySecretName,
+			Namespace: RadiusNamespace,
+		},
+		Data: map[string][]byte{
+			DefaultEncryptionKeySecretKey: createTestKeyStore(t, map[int][]byte{1: key1}, 1),
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, secret))
+
+	provider := NewKubernetesKeyProvider(k8sClient, nil)
+
+	rotation, err := provider.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Empty(t, rotation.RequestedAt)
+	require.Nil(t, rotation.Status)
+	require.Equal(t, 1, rotation.KeyStore.CurrentVersion)
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	_, err = provider.RequestKeyRotation(ctx, now)
+	require.NoError(t, err)
+
+	// The rotation loaded before the request is stale.
+	err = provider.SaveKeyRotation(ctx, rotation)
+	require.ErrorIs(t, err, ErrKeyRotationConflict)
+
+	rotation, err = provider.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "2026-01-02T03:04:05Z", rotation.RequestedAt)
+
+	key2, err := GenerateKey()
+	require.NoError(t, err)
+	encoded, err := EncodeKey(ctx, key2, nil)
+	require.NoError(t, err)
+
+	version := rotation.KeyStore.AddVersion(encoded, now, DefaultKeyValidity)
+	require.Equal(t, 2, version)
+	rotation.RequestedAt = ""
+	rotation.Status = &KeyRotationStatus{State: KeyRotationStateReencrypting, Version: version, StartedAt: now}
+	require.NoError(t, provider.SaveKeyRotation(ctx, rotation))
+
+	// Saving again with the same rotation succeeds because it tracks the saved Secret.
+	rotation.Status.Scanned = 10
+	require.NoError(t, provider.SaveKeyRotation(ctx, rotation))
+
+	actual, currentVersion, err := provider.GetCurrentKey(ctx)
+	require.NoError(t, err)
+	require.Equal(t, key2, actual)
+	require.Equal(t, 2, currentVersion)
+
+	rotation, err = provider.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Empty(t, rotation.RequestedAt)
+	require.Equal(t, &KeyRotationStatus{State: KeyRotationStateReencrypting, Version: 2, StartedAt: now, Scanned: 10}, rotation.Status)
+	require.Equal(t, "2026-04-02T03:04:05Z", rotation.KeyStore.Keys["2"].ExpiresAt)
+
+	// Retire the previous version.
+	require.Error(t, rotation.KeyStore.RemoveVersion(2))
+	require.NoError(t, rotation.KeyStore.RemoveVersion(1))
+	require.NoError(t, provider.SaveKeyRotation(ctx, rotation))
+
+	_, err = provider.GetKeyByVersion(ctx, 1)
+	require.ErrorIs(t, err, ErrKeyVersionNotFound)
+}
+
+func TestKeyStore_Versions(t *testing.T) {
+	keyStore := &KeyStore{}
+	require.Empty(t, keyStore.Versions())
+
+	now := time.Now()
+	require.Equal(t, 1, keyStore.AddVersion("a", now, time.Hour))
+	require.Equal(t, 2, keyStore.AddVersion("b", now, time.Hour))
+	require.Equal(t, 3, keyStore.AddVersion("c", now, time.Hour))
+	require.NoError(t, keyStore.RemoveVersion(2))
+
+	require.Equal(t, []int{1, 3}, keyStore.Versions())
+	require.Equal(t, 3, keyStore.CurrentVersion)
+
+	// Removed versions are not reused.
+	require.NoError(t, keyStore.RemoveVersion(1))
+	keyStore.Keys["3"] = KeyData{Key: "c", Version: 3}
+	require.Equal(t, 4, keyStore.AddVersion("d", now, time.Hour))
+}
+
+func TestEncodeKey(t *testing.T) {
+	ctx := t.Context()
+
+	key, err := GenerateKey()
+	require.NoError(t, err)
+
+	encoded, err := EncodeKey(ctx, key, nil)
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString(key), encoded)
+
+	kek, err := GenerateKey()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "kek")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(kek)), 0600))
+
+	wrapper := NewFileKeyWrapper(path)
+	encoded, err = EncodeKey(ctx, key, wrapper)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(encoded, FileWrappedKeyPrefix))
+
+	unwrapped, err := wrapper.UnwrapKey(ctx, encoded)
+	require.NoError(t, err)
+	require.Equal(t, key, unwrapped)
+}
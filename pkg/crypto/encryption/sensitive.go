@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/radius-project/radius/pkg/schema"
 )
@@ -38,11 +39,16 @@ var (
 
 	// ErrFieldDecryptionFailed is returned when decryption of a field fails.
 	ErrFieldDecryptionFailed = errors.New("field decryption failed")
+
+	// ErrFieldReencryptionFailed is returned when re-encryption of a field fails.
+	ErrFieldReencryptionFailed = errors.New("field re-encryption failed")
 )
 
 // SensitiveDataHandler provides methods for encrypting and decrypting sensitive fields
 // in data structures based on field paths marked with x-radius-sensitive annotation.
 type SensitiveDataHandler struct {
+	// mu guards encryptor, which is replaced by RefreshCurrentKey.
+	mu          sync.RWMutex
 	encryptor   *Encryptor
 	keyProvider KeyProvider
 }
@@ -84,6 +90,43 @@ func NewSensitiveDataHandlerFromProvider(ctx context.Context, provider KeyProvid
 	}, nil
 }
 
+// RefreshCurrentKey loads the current key from the key provider, so that fields are encrypted with the
+// current key version after a key rotation. It does nothing if the handler has no key provider.
+func (h *SensitiveDataHandler) RefreshCurrentKey(ctx context.Context) error {
+	if h.keyProvider == nil {
+		return nil
+	}
+
+	key, version, err := h.keyProvider.GetCurrentKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	h.mu.RLock()
+	current := h.encryptor.keyVersion
+	h.mu.RUnlock()
+	if current == version {
+		return nil
+	}
+
+	encryptor, err := NewEncryptorWithVersion(key, version)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	h.encryptor = encryptor
+	h.mu.Unlock()
+	return nil
+}
+
+// currentEncryptor returns the encryptor of the current key.
+func (h *SensitiveDataHandler) currentEncryptor() *Encryptor {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.encryptor
+}
+
 // EncryptSensitiveFields encrypts all sensitive fields in the data based on the provided field paths.
 // The data is modified in place. Field paths support dot notation and [*] for arrays/maps.
 // Examples: "credentials.password", "secrets[*].value", "config[*]"
@@ -161,13 +204,44 @@ func (h *SensitiveDataHandler) DecryptSensitiveFieldsWithSchema(ctx context.Cont
 	return nil
 }
 
+// ReencryptSensitiveFields re-encrypts the sensitive fields of data that are encrypted with a key version other
+// than the current version of the handler, and returns the number of re-encrypted values. The data is modified in
+// place. Values that are not encrypted are left unchanged, so the paths of every API version of a resource type
+// can be used.
+//
+// The resourceID must match what was provided during encryption. The values are re-encrypted with the same
+// associated data.
+func (h *SensitiveDataHandler) ReencryptSensitiveFields(ctx context.Context, data map[string]any, sensitiveFieldPaths []string, resourceID string) (int, error) {
+	encryptor := h.currentEncryptor()
+	count := 0
+	for _, path := range sensitiveFieldPaths {
+		ad := buildAssociatedData(resourceID, path)
+		processor := func(value any) (any, error) {
+			reencrypted, ok, err := h.reencryptValue(ctx, encryptor, value, ad)
+			if ok {
+				count++
+			}
+			return reencrypted, err
+		}
+
+		if err := h.processFieldAtPath(data, path, processor); err != nil {
+			if errors.Is(err, ErrFieldNotFound) {
+				continue
+			}
+			return count, fmt.Errorf("%w: path %q: %v", ErrFieldReencryptionFailed, path, err)
+		}
+	}
+
+	return count, nil
+}
+
 // getEncryptorForDecryption returns the appropriate encryptor for decrypting data.
 // If a keyProvider is available and the data contains a version, it fetches the versioned key.
 // Otherwise, it falls back to the default encryptor.
 func (h *SensitiveDataHandler) getEncryptorForDecryption(ctx context.Context, encryptedJSON []byte) (*Encryptor, error) {
 	// If no key provider, use the default encryptor
 	if h.keyProvider == nil {
-		return h.encryptor, nil
+		return h.currentEncryptor(), nil
 	}
 
 	// Extract the version from the encrypted data
@@ -178,7 +252,7 @@ func (h *SensitiveDataHandler) getEncryptorForDecryption(ctx context.Context, en
 
 	// If version is 0 (unversioned/legacy data), use the default encryptor
 	if version == 0 {
-		return h.encryptor, nil
+		return h.currentEncryptor(), nil
 	}
 
 	// Fetch the key for this specific version
@@ -374,7 +448,7 @@ func (h *SensitiveDataHandler) encryptValue(value any, associatedData []byte) (a
 		}
 	}
 
-	encrypted, err := h.encryptor.Encrypt(dataToEncrypt, associatedData)
+	encrypted, err := h.currentEncryptor().Encrypt(dataToEncrypt, associatedData)
 	if err != nil {
 		return nil, err
 	}
@@ -452,6 +526,56 @@ func (h *SensitiveDataHandler) decryptValue(ctx context.Context, value any, fiel
 	return result, nil
 }
 
+// reencryptValue decrypts an encrypted value and encrypts the plaintext with encryptor. It returns the value
+// unchanged and false if it is not encrypted or is already encrypted with the key version of encryptor.
+func (h *SensitiveDataHandler) reencryptValue(ctx context.Context, encryptor *Encryptor, value any, associatedData []byte) (any, bool, error) {
+	encMap, ok := value.(map[string]any)
+	if !ok {
+		return value, false, nil
+	}
+
+	_, hasEncrypted := encMap["encrypted"].(string)
+	_, hasNonce := encMap["nonce"].(string)
+	if !hasEncrypted || !hasNonce {
+		return value, false, nil
+	}
+
+	encryptedJSON, err := json.Marshal(encMap)
+	if err != nil {
+		return nil, false, err
+	}
+
+	version, err := GetEncryptedDataVersion(encryptedJSON)
+	if err != nil {
+		return nil, false, err
+	}
+	if version == encryptor.keyVersion {
+		return value, false, nil
+	}
+
+	decryptor, err := h.getEncryptorForDecryption(ctx, encryptedJSON)
+	if err != nil {
+		return nil, false, err
+	}
+
+	plaintext, err := decryptor.Decrypt(encryptedJSON, associatedData)
+	if err != nil {
+		return nil, false, err
+	}
+
+	encrypted, err := encryptor.Encrypt(plaintext, associatedData)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(encrypted, &result); err != nil {
+		return nil, false, err
+	}
+
+	return result, true, nil
+}
+
 // buildAssociatedData constructs the associated data for AEAD encryption from the resource ID and field path.
 // This binds the ciphertext to its context, preventing encrypted values from being moved between
 // different resources or fields.
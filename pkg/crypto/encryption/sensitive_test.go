@@ -1127,3 +1127,99 @@ func deepCopySlice(original []any) []any {
 	}
 	return result
 }
+
+func TestSensitiveDataHandler_RefreshCurrentKey(t *testing.T) {
+	ctx := t.Context()
+
+	key1, err := GenerateKey()
+	require.NoError(t, err)
+	key2, err := GenerateKey()
+	require.NoError(t, err)
+
+	provider, err := NewInMemoryKeyProviderWithVersions(map[int][]byte{1: key1, 2: key2}, 1)
+	require.NoError(t, err)
+
+	handler, err := NewSensitiveDataHandlerFromProvider(ctx, provider)
+	require.NoError(t, err)
+
+	// Rotate the key without creating a new handler.
+	require.NoError(t, provider.SetCurrentVersion(2))
+	require.NoError(t, handler.RefreshCurrentKey(ctx))
+
+	data := map[string]any{"password": "secret"}
+	require.NoError(t, handler.EncryptSensitiveFields(data, []string{"password"}, testResourceID))
+	require.Equal(t, float64(2), data["password"].(map[string]any)["version"])
+
+	t.Run("without provider", func(t *testing.T) {
+		handler, err := NewSensitiveDataHandlerFromKey(key1)
+		require.NoError(t, err)
+		require.NoError(t, handler.RefreshCurrentKey(ctx))
+	})
+}
+
+func TestSensitiveDataHandler_ReencryptSensitiveFields(t *testing.T) {
+	ctx := t.Context()
+
+	key1, err := GenerateKey()
+	require.NoError(t, err)
+	key2, err := GenerateKey()
+	require.NoError(t, err)
+
+	provider, err := NewInMemoryKeyProviderWithVersions(map[int][]byte{1: key1, 2: key2}, 1)
+	require.NoError(t, err)
+
+	handler1, err := NewSensitiveDataHandlerFromProvider(ctx, provider)
+	require.NoError(t, err)
+
+	paths := []string{"password", "credentials.token", "secrets[*].value", "missing"}
+	data := map[string]any{
+		"password":    "secret",
+		"credentials": map[string]any{"token": map[string]any{"id": "abc", "ttl": 60}},
+		"secrets": []any{
+			map[string]any{"value": "one"},
+			map[string]any{"value": "two"},
+		},
+	}
+	require.NoError(t, handler1.EncryptSensitiveFields(data, paths, testResourceID))
+
+	require.NoError(t, provider.SetCurrentVersion(2))
+	handler2, err := NewSensitiveDataHandlerFromProvider(ctx, provider)
+	require.NoError(t, err)
+
+	count, err := handler2.ReencryptSensitiveFields(ctx, data, paths, testResourceID)
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+	require.Equal(t, float64(2), data["password"].(map[string]any)["version"])
+	require.Equal(t, float64(2), data["credentials"].(map[string]any)["token"].(map[string]any)["version"])
+
+	// Values encrypted with the current version are not re-encrypted.
+	count, err = handler2.ReencryptSensitiveFields(ctx, data, paths, testResourceID)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
+	// The version 1 key is no longer needed to decrypt the data.
+	provider2, err := NewInMemoryKeyProviderWithVersions(map[int][]byte{2: key2}, 2)
+	require.NoError(t, err)
+	handler3, err := NewSensitiveDataHandlerFromProvider(ctx, provider2)
+	require.NoError(t, err)
+	require.NoError(t, handler3.DecryptSensitiveFields(ctx, data, paths, testResourceID))
+	require.Equal(t, "secret", data["password"])
+	require.Equal(t, map[string]any{"id": "abc", "ttl": float64(60)}, data["credentials"].(map[string]any)["token"])
+	require.Equal(t, "two", data["secrets"].([]any)[1].(map[string]any)["value"])
+
+	t.Run("plaintext values are not encrypted", func(t *testing.T) {
+		data := map[string]any{"password": "plain"}
+		count, err := handler2.ReencryptSensitiveFields(ctx, data, []string{"password"}, testResourceID)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+		require.Equal(t, "plain", data["password"])
+	})
+
+	t.Run("wrong resource", func(t *testing.T) {
+		data := map[string]any{"password": "secret"}
+		require.NoError(t, handler1.EncryptSensitiveFields(data, []string{"password"}, testResourceID))
+
+		_, err := handler2.ReencryptSensitiveFields(ctx, data, []string{"password"}, testResourceID+"-other")
+		require.ErrorIs(t, err, ErrFieldReencryptionFailed)
+	})
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	// DefaultVaultTransitMountPath is the default mount path of the Vault Transit secrets engine.
+	DefaultVaultTransitMountPath = "transit"
+
+	// vaultTokenHeader is the header holding the Vault token.
+	vaultTokenHeader = "X-Vault-Token" //nolint:gosec // This is a header name, not credentials
+
+	// vaultNamespaceHeader is the header holding the Vault Enterprise namespace.
+	vaultNamespaceHeader = "X-Vault-Namespace"
+
+	// maxVaultResponseSize limits the size of the responses read from Vault.
+	maxVaultResponseSize = 1 << 20
+)
+
+var _ KeyWrapper = (*VaultTransitKeyWrapper)(nil)
+
+// VaultTransitOptions contains options for creating a VaultTransitKeyWrapper.
+type VaultTransitOptions struct {
+	// Address is the address of the Vault server, for example "https://vault.vault-system:8200".
+	Address string
+
+	// Token is the Vault token used to authenticate requests.
+	Token string
+
+	// TokenFile is the path of a file holding the Vault token, for example a token written by the Vault agent.
+	// The file is read on every request so that renewed tokens are picked up. It takes precedence over Token.
+	TokenFile string
+
+	// Namespace is the optional Vault Enterprise namespace.
+	Namespace string
+
+	// MountPath is the mount path of the Transit secrets engine.
+	// Defaults to DefaultVaultTransitMountPath if not specified.
+	MountPath string
+
+	// KeyName is the name of the Transit key used to wrap keys.
+	KeyName string
+
+	// HTTPClient is the client used to call Vault. Defaults to http.DefaultClient if not specified.
+	HTTPClient *http.Client
+}
+
+// VaultTransitKeyWrapper implements KeyWrapper with the encrypt and decrypt endpoints of the HashiCorp Vault
+// Transit secrets engine. Any server implementing the same HTTP API can be used.
+type VaultTransitKeyWrapper struct {
+	options VaultTransitOptions
+}
+
+// NewVaultTransitKeyWrapper creates a new VaultTransitKeyWrapper with the given options.
+func NewVaultTransitKeyWrapper(opts VaultTransitOptions) (*VaultTransitKeyWrapper, error) {
+	if opts.Address == "" {
+		return nil, errors.New("the Vault address is required")
+	}
+	if opts.KeyName == "" {
+		return nil, errors.New("the Vault Transit key name is required")
+	}
+	if opts.MountPath == "" {
+		opts.MountPath = DefaultVaultTransitMountPath
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	return &VaultTransitKeyWrapper{options: opts}, nil
+}
+
+// vaultTransitRequest is the request body of the Transit encrypt and decrypt endpoints.
+type vaultTransitRequest struct {
+	Plaintext  string `json:"plaintext,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+}
+
+// vaultTransitResponse is the response body of the Transit encrypt and decrypt endpoints.
+type vaultTransitResponse struct {
+	Data   vaultTransitRequest `json:"data"`
+	Errors []string            `json:"errors"`
+}
+
+// WrapKey encrypts key with the Transit key and returns the Vault ciphertext (for example "vault:v1:...").
+func (w *VaultTransitKeyWrapper) WrapKey(ctx context.Context, key []byte) (string, error) {
+	response, err := w.call(ctx, "encrypt", vaultTransitRequest{Plaintext: base64.StdEncoding.EncodeToString(key)})
+	if err != nil {
+		return "", err
+	}
+
+	if response.Data.Ciphertext == "" {
+		return "", errors.New("vault transit encrypt returned no ciphertext")
+	}
+
+	return response.Data.Ciphertext, nil
+}
+
+// UnwrapKey decrypts a Vault ciphertext returned by WrapKey.
+func (w *VaultTransitKeyWrapper) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	response, err := w.call(ctx, "decrypt", vaultTransitRequest{Ciphertext: wrapped})
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(response.Data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("vault transit decrypt returned invalid plaintext: %w", err)
+	}
+
+	return key, nil
+}
+
+// token returns the Vault token from TokenFile or Token.
+func (w *VaultTransitKeyWrapper) token() (string, error) {
+	if w.options.TokenFile == "" {
+		return w.options.Token, nil
+	}
+
+	token, err := os.ReadFile(w.options.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the Vault token file: %w", err)
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
+
+// call sends a request to a Transit endpoint for the configured key.
+func (w *VaultTransitKeyWrapper) call(ctx context.Context, operation string, body vaultTransitRequest) (*vaultTransitResponse, error) {
+	endpoint, err := url.JoinPath(w.options.Address, "v1", w.options.MountPath, operation, w.options.KeyName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Vault address %q: %w", w.options.Address, err)
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	token, err := w.token()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(vaultTokenHeader, token)
+	if w.options.Namespace != "" {
+		req.Header.Set(vaultNamespaceHeader, w.options.Namespace)
+	}
+
+	resp, err := w.options.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault transit %s failed: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	response := &vaultTransitResponse{}
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxVaultResponseSize)).Decode(response)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// The errors reported by Vault never contain the plaintext.
+		return nil, fmt.Errorf("vault transit %s failed with status %d: %s", operation, resp.StatusCode, strings.Join(response.Errors, "; "))
+	} else if err != nil {
+		return nil, fmt.Errorf("vault transit %s returned an invalid response: %w", operation, err)
+	}
+
+	return response, nil
+}
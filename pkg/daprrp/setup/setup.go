@@ -32,6 +32,7 @@ import (
 	secretstore_proc "github.com/radius-project/radius/pkg/daprrp/processors/secretstores"
 	statestore_proc "github.com/radius-project/radius/pkg/daprrp/processors/statestores"
 	pr_ctrl "github.com/radius-project/radius/pkg/portableresources/backend/controller"
+	pr_frontend_ctrl "github.com/radius-project/radius/pkg/portableresources/frontend/controller"
 	rp_frontend "github.com/radius-project/radius/pkg/rp/frontend"
 )
 
@@ -75,6 +76,17 @@ func SetupNamespace(recipeControllerConfig *controllerconfig.RecipeControllerCon
 			AsyncOperationTimeout:    dapr_ctrl.AsyncDeleteDaprPubSubBrokerTimeout,
 			AsyncOperationRetryAfter: AsyncOperationRetryAfter,
 		},
+		RecipePlan: builder.Operation[datamodel.DaprPubSubBroker]{
+			APIController: func(opt apictrl.Options) (apictrl.Controller, error) {
+				return pr_frontend_ctrl.NewPlanRecipe[*datamodel.DaprPubSubBroker](opt, apictrl.ResourceOptions[datamodel.DaprPubSubBroker]{
+					AsyncOperationTimeout:    dapr_ctrl.AsyncCreateOrUpdateDaprPubSubBrokerTimeout,
+					AsyncOperationRetryAfter: AsyncOperationRetryAfter,
+				})
+			},
+			AsyncJobController: func(options asyncctrl.Options) (asyncctrl.Controller, error) {
+				return pr_ctrl.NewPlanRecipe[*datamodel.DaprPubSubBroker, datamodel.DaprPubSubBroker](options, recipeControllerConfig.Engine)
+			},
+		},
 	})
 
 	_ = ns.AddResource("stateStores", &builder.ResourceOption[*datamodel.DaprStateStore, datamodel.DaprStateStore]{
@@ -108,6 +120,17 @@ func SetupNamespace(recipeControllerConfig *controllerconfig.RecipeControllerCon
 			AsyncOperationTimeout:    dapr_ctrl.AsyncDeleteDaprStateStoreTimeout,
 			AsyncOperationRetryAfter: AsyncOperationRetryAfter,
 		},
+		RecipePlan: builder.Operation[datamodel.DaprStateStore]{
+			APIController: func(opt apictrl.Options) (apictrl.Controller, error) {
+				return pr_frontend_ctrl.NewPlanRecipe[*datamodel.DaprStateStore](opt, apictrl.ResourceOptions[datamodel.DaprStateStore]{
+					AsyncOperationTimeout:    dapr_ctrl.AsyncCreateOrUpdateDaprStateStoreTimeout,
+					AsyncOperationRetryAfter: AsyncOperationRetryAfter,
+				})
+			},
+			AsyncJobController: func(options asyncctrl.Options) (asyncctrl.Controller, error) {
+				return pr_ctrl.NewPlanRecipe[*datamodel.DaprStateStore, datamodel.DaprStateStore](options, recipeControllerConfig.Engine)
+			},
+		},
 	})
 
 	_ = ns.AddResource("secretStores", &builder.ResourceOption[*datamodel.DaprSecretStore, datamodel.DaprSecretStore]{
@@ -141,6 +164,17 @@ func SetupNamespace(recipeControllerConfig *controllerconfig.RecipeControllerCon
 			AsyncOperationTimeout:    dapr_ctrl.AsyncDeleteDaprSecretStoreTimeout,
 			AsyncOperationRetryAfter: AsyncOperationRetryAfter,
 		},
+		RecipePlan: builder.Operation[datamodel.DaprSecretStore]{
+			APIController: func(opt apictrl.Options) (apictrl.Controller, error) {
+				return pr_frontend_ctrl.NewPlanRecipe[*datamodel.DaprSecretStore](opt, apictrl.ResourceOptions[datamodel.DaprSecretStore]{
+					AsyncOperationTimeout:    dapr_ctrl.AsyncCreateOrUpdateDaprSecretStoreTimeout,
+					AsyncOperationRetryAfter: AsyncOperationRetryAfter,
+				})
+			},
+			AsyncJobController: func(options asyncctrl.Options) (asyncctrl.Controller, error) {
+				return pr_ctrl.NewPlanRecipe[*datamodel.DaprSecretStore, datamodel.DaprSecretStore](options, recipeControllerConfig.Engine)
+			},
+		},
 	})
 
 	_ = ns.AddResource("configurationStores", &builder.ResourceOption[*datamodel.DaprConfigurationStore, datamodel.DaprConfigurationStore]{
@@ -174,6 +208,17 @@ func SetupNamespace(recipeControllerConfig *controllerconfig.RecipeControllerCon
 			AsyncOperationTimeout:    dapr_ctrl.AsyncDeleteDaprConfigurationStoreTimeout,
 			AsyncOperationRetryAfter: AsyncOperationRetryAfter,
 		},
+		RecipePlan: builder.Operation[datamodel.DaprConfigurationStore]{
+			APIController: func(opt apictrl.Options) (apictrl.Controller, error) {
+				return pr_frontend_ctrl.NewPlanRecipe[*datamodel.DaprConfigurationStore](opt, apictrl.ResourceOptions[datamodel.DaprConfigurationStore]{
+					AsyncOperationTimeout:    dapr_ctrl.AsyncCreateOrUpdateDaprConfigurationStoreTimeout,
+					AsyncOperationRetryAfter: AsyncOperationRetryAfter,
+				})
+			},
+			AsyncJobController: func(options asyncctrl.Options) (asyncctrl.Controller, error) {
+				return pr_ctrl.NewPlanRecipe[*datamodel.DaprConfigurationStore, datamodel.DaprConfigurationStore](options, recipeControllerConfig.Engine)
+			},
+		},
 	})
 
 	// Optional
@@ -34,6 +34,7 @@ import (
 	rds_proc "github.com/radius-project/radius/pkg/datastoresrp/processors/rediscaches"
 	sql_proc "github.com/radius-project/radius/pkg/datastoresrp/processors/sqldatabases"
 	pr_ctrl "github.com/radius-project/radius/pkg/portableresources/backend/controller"
+	pr_frontend_ctrl "github.com/radius-project/radius/pkg/portableresources/frontend/controller"
 	rp_frontend "github.com/radius-project/radius/pkg/rp/frontend"
 )
 
@@ -82,6 +83,17 @@ func SetupNamespace(recipeControllerConfig *controllerconfig.RecipeControllerCon
 				APIController: rds_ctrl.NewListSecretsRedisCache,
 			},
 		},
+		RecipePlan: builder.Operation[datamodel.RedisCache]{
+			APIController: func(opt apictrl.Options) (apictrl.Controller, error) {
+				return pr_frontend_ctrl.NewPlanRecipe[*datamodel.RedisCache](opt, apictrl.ResourceOptions[datamodel.RedisCache]{
+					AsyncOperationTimeout:    ds_ctrl.AsyncCreateOrUpdateRedisCacheTimeout,
+					AsyncOperationRetryAfter: AsyncOperationRetryAfter,
+				})
+			},
+			AsyncJobController: func(options asyncctrl.Options) (asyncctrl.Controller, error) {
+				return pr_ctrl.NewPlanRecipe[*datamodel.RedisCache, datamodel.RedisCache](options, recipeControllerConfig.Engine)
+			},
+		},
 	})
 
 	_ = ns.AddResource("mongoDatabases", &builder.ResourceOption[*datamodel.MongoDatabase, datamodel.MongoDatabase]{
@@ -120,6 +132,17 @@ func SetupNamespace(recipeControllerConfig *controllerconfig.RecipeControllerCon
 				APIController: mongo_ctrl.NewListSecretsMongoDatabase,
 			},
 		},
+		RecipePlan: builder.Operation[datamodel.MongoDatabase]{
+			APIController: func(opt apictrl.Options) (apictrl.Controller, error) {
+				return pr_frontend_ctrl.NewPlanRecipe[*datamodel.MongoDatabase](opt, apictrl.ResourceOptions[datamodel.MongoDatabase]{
+					AsyncOperationTimeout:    ds_ctrl.AsyncCreateOrUpdateMongoDatabaseTimeout,
+					AsyncOperationRetryAfter: AsyncOperationRetryAfter,
+				})
+			},
+			AsyncJobController: func(options asyncctrl.Options) (asyncctrl.Controller, error) {
+				return pr_ctrl.NewPlanRecipe[*datamodel.MongoDatabase, datamodel.MongoDatabase](options, recipeControllerConfig.Engine)
+			},
+		},
 	})
 
 	_ = ns.AddResource("sqlDatabases", &builder.ResourceOption[*datamodel.SqlDatabase, datamodel.SqlDatabase]{
@@ -158,6 +181,17 @@ func SetupNamespace(recipeControllerConfig *controllerconfig.RecipeControllerCon
 				APIController: sql_ctrl.NewListSecretsSqlDatabase,
 			},
 		},
+		RecipePlan: builder.Operation[datamodel.SqlDatabase]{
+			APIController: func(opt apictrl.Options) (apictrl.Controller, error) {
+				return pr_frontend_ctrl.NewPlanRecipe[*datamodel.SqlDatabase](opt, apictrl.ResourceOptions[datamodel.SqlDatabase]{
+					AsyncOperationTimeout:    ds_ctrl.AsyncCreateOrUpdateSqlDatabaseTimeout,
+					AsyncOperationRetryAfter: AsyncOperationRetryAfter,
+				})
+			},
+			AsyncJobController: func(options asyncctrl.Options) (asyncctrl.Controller, error) {
+				return pr_ctrl.NewPlanRecipe[*datamodel.SqlDatabase, datamodel.SqlDatabase](options, recipeControllerConfig.Engine)
+			},
+		},
 	})
 
 	// Optional
@@ -60,6 +60,10 @@ var handlerTests = []rpctest.HandlerTestSpec{
 		OperationType: v1.OperationType{Type: ds_ctrl.MongoDatabasesResourceType, Method: v1.OperationDelete},
 		Path:          "/resourcegroups/testrg/providers/applications.datastores/mongodatabases/mongo",
 		Method:        http.MethodDelete,
+	}, {
+		OperationType: v1.OperationType{Type: ds_ctrl.MongoDatabasesResourceType, Method: v1.OperationRecipePlan},
+		Path:          "/resourcegroups/testrg/providers/applications.datastores/mongodatabases/mongo/recipes/plan",
+		Method:        http.MethodPost,
 	}, {
 		OperationType: v1.OperationType{Type: ds_ctrl.MongoDatabasesResourceType, Method: ds_ctrl.OperationListSecret},
 		Path:          "/resourcegroups/testrg/providers/applications.datastores/mongodatabases/mongo/listsecrets",
@@ -88,6 +92,10 @@ var handlerTests = []rpctest.HandlerTestSpec{
 		OperationType: v1.OperationType{Type: ds_ctrl.RedisCachesResourceType, Method: v1.OperationDelete},
 		Path:          "/resourcegroups/testrg/providers/applications.datastores/rediscaches/redis",
 		Method:        http.MethodDelete,
+	}, {
+		OperationType: v1.OperationType{Type: ds_ctrl.RedisCachesResourceType, Method: v1.OperationRecipePlan},
+		Path:          "/resourcegroups/testrg/providers/applications.datastores/rediscaches/redis/recipes/plan",
+		Method:        http.MethodPost,
 	}, {
 		OperationType: v1.OperationType{Type: ds_ctrl.RedisCachesResourceType, Method: ds_ctrl.OperationListSecret},
 		Path:          "/resourcegroups/testrg/providers/applications.datastores/rediscaches/redis/listsecrets",
@@ -116,6 +124,10 @@ var handlerTests = []rpctest.HandlerTestSpec{
 		OperationType: v1.OperationType{Type: ds_ctrl.SqlDatabasesResourceType, Method: v1.OperationDelete},
 		Path:          "/resourcegroups/testrg/providers/applications.datastores/sqldatabases/sql",
 		Method:        http.MethodDelete,
+	}, {
+		OperationType: v1.OperationType{Type: ds_ctrl.SqlDatabasesResourceType, Method: v1.OperationRecipePlan},
+		Path:          "/resourcegroups/testrg/providers/applications.datastores/sqldatabases/sql/recipes/plan",
+		Method:        http.MethodPost,
 	}, {
 		OperationType: v1.OperationType{Type: ds_ctrl.SqlDatabasesResourceType, Method: ds_ctrl.OperationListSecret},
 		Path:          "/resourcegroups/testrg/providers/applications.datastores/sqldatabases/sql/listsecrets",
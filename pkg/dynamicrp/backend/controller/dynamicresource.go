@@ -96,6 +96,7 @@ func (c *DynamicResourceController) selectController(ctx context.Context, reques
 		ResourceType:   *resourceTypeDetails.Name,
 		UcpClient:      c.ucp,
 		KubeClient:     c.KubeClient(),
+		KeyProvider:    c.KeyProvider(),
 	}
 
 	switch operationType.Method {
@@ -111,6 +112,9 @@ func (c *DynamicResourceController) selectController(ctx context.Context, reques
 		}
 		return NewRecipePutController(options, c.engine, c.configurationLoader, c.secretMaterializer)
 
+	case v1.OperationRecipePlan:
+		return NewRecipePlanController(options, c.engine)
+
 	default:
 		return nil, fmt.Errorf("unsupported operation type: %q", request.OperationType)
 	}
@@ -104,6 +104,19 @@ func Test_DynamicResourceController_selectController(t *testing.T) {
 		require.IsType(t, &RecipeDeleteController{}, selected)
 	})
 
+	t.Run("recipe plan", func(t *testing.T) {
+		controller := setup()
+		request := &ctrl.Request{
+			ResourceID:    "/planes/radius/local/resourceGroups/test-group/providers/" + recipeResourceType + "/test-resource",
+			OperationType: v1.OperationType{Type: recipeResourceType, Method: v1.OperationRecipePlan}.String(),
+		}
+
+		selected, err := controller.selectController(t.Context(), request)
+		require.NoError(t, err)
+
+		require.IsType(t, &RecipePlanController{}, selected)
+	})
+
 	t.Run("unknown operation", func(t *testing.T) {
 		controller := setup()
 		request := &ctrl.Request{
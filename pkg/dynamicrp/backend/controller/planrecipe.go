@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	ctrl "github.com/radius-project/radius/pkg/armrpc/asyncoperation/controller"
+	"github.com/radius-project/radius/pkg/dynamicrp/datamodel"
+	recipecontroller "github.com/radius-project/radius/pkg/portableresources/backend/controller"
+	"github.com/radius-project/radius/pkg/recipes/engine"
+)
+
+// RecipePlanController is the async operation controller to plan the recipe of dynamic resources deployed using recipes.
+type RecipePlanController struct {
+	ctrl.BaseController
+	opts   ctrl.Options
+	engine engine.Engine
+}
+
+// NewRecipePlanController creates a new RecipePlanController.
+func NewRecipePlanController(opts ctrl.Options, engine engine.Engine) (ctrl.Controller, error) {
+	return &RecipePlanController{
+		BaseController: ctrl.NewBaseAsyncController(opts),
+		opts:           opts,
+		engine:         engine,
+	}, nil
+}
+
+// Run plans the recipe of a dynamic resource.
+// It creates and delegates the request to PlanRecipe controller to handle the plan.
+func (c *RecipePlanController) Run(ctx context.Context, request *ctrl.Request) (ctrl.Result, error) {
+	planController, err := recipecontroller.NewPlanRecipe[*datamodel.DynamicResource](c.opts, c.engine)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return planController.Run(ctx, request)
+}
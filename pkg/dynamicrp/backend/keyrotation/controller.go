@@ -0,0 +1,480 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyrotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/crypto/encryption"
+	"github.com/radius-project/radius/pkg/crypto/encryption/encryptionprovider"
+	"github.com/radius-project/radius/pkg/dynamicrp/datamodel"
+	"github.com/radius-project/radius/pkg/schema"
+	"github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+const (
+	// DefaultInterval is the time between two checks for a requested rotation when none is configured.
+	DefaultInterval = time.Minute
+
+	// DefaultGracePeriod is the time between the re-encryption and the verification scan when none is configured.
+	DefaultGracePeriod = 24 * time.Hour
+
+	// queryPageSize is the number of resources re-encrypted between two saves of the progress.
+	queryPageSize = 100
+
+	// maxSaveAttempts is the number of times a resource is re-encrypted when it is modified concurrently.
+	maxSaveAttempts = 3
+)
+
+// ResourceType is a resource type with sensitive fields.
+type ResourceType struct {
+	// Plane is the name of the Radius plane of the resource type.
+	Plane string
+
+	// Type is the fully-qualified resource type, e.g. "Radius.Security/secrets".
+	Type string
+
+	// SensitiveFieldPaths are the paths of the sensitive fields relative to the properties of the resource,
+	// for every API version of the resource type.
+	SensitiveFieldPaths []string
+}
+
+// key returns the key used to order resource types and to record the progress of a rotation.
+func (t ResourceType) key() string {
+	return t.Plane + "|" + t.Type
+}
+
+// Controller rotates the key used to encrypt sensitive fields when a rotation is requested on the key store
+// Secret. It adds a new key version, re-encrypts the sensitive fields of every resource with it, and retires
+// the previous versions once no resource references them.
+//
+// Other replicas can write with a previous key version until they reload the key store, so the previous versions
+// are not retired right after the re-encryption. The sensitive fields are scanned again once GracePeriod has
+// elapsed, and the previous versions are retired when a scan finds nothing left to re-encrypt. A rotation that
+// failed to re-encrypt some resources is scanned again the same way, so that they are retried.
+//
+// The progress of the re-encryption is saved in the key store Secret after each page of resources, so that a
+// rotation is resumed after a restart. Concurrent controllers are serialized by the resource version of the
+// Secret.
+type Controller struct {
+	// Store is the key store that is rotated.
+	Store *encryption.KubernetesKeyProvider
+
+	// Wrapper wraps new keys before they are stored. It is nil when keys are stored unwrapped.
+	Wrapper encryption.KeyWrapper
+
+	// KeyProvider provides the keys of Store, unwrapped.
+	KeyProvider encryption.KeyProvider
+
+	// DatabaseClient is the database of the resources.
+	DatabaseClient database.Client
+
+	// ListResourceTypes returns the resource types with sensitive fields.
+	ListResourceTypes func(ctx context.Context) ([]ResourceType, error)
+
+	// Interval is the time between two checks.
+	Interval time.Duration
+
+	// GracePeriod is the time between the re-encryption and the verification scan that retires the previous
+	// key versions.
+	GracePeriod time.Duration
+
+	// now returns the current time. It can be replaced in tests.
+	now func() time.Time
+}
+
+// NewController creates a Controller from the encryption configuration. The resource types are listed
+// from UCP.
+func NewController(options encryptionprovider.RotationOptions, store *encryption.KubernetesKeyProvider, wrapper encryption.KeyWrapper, keyProvider encryption.KeyProvider, databaseClient database.Client, ucp *v20231001preview.ClientFactory) (*Controller, error) {
+	interval := DefaultInterval
+	if options.Interval != "" {
+		var err error
+		interval, err = time.ParseDuration(options.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryptionProvider.rotation.interval %q: %w", options.Interval, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("invalid encryptionProvider.rotation.interval %q: must be positive", options.Interval)
+		}
+	}
+
+	gracePeriod := DefaultGracePeriod
+	if options.GracePeriod != "" {
+		var err error
+		gracePeriod, err = time.ParseDuration(options.GracePeriod)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryptionProvider.rotation.gracePeriod %q: %w", options.GracePeriod, err)
+		}
+		if gracePeriod < 0 {
+			return nil, fmt.Errorf("invalid encryptionProvider.rotation.gracePeriod %q: must not be negative", options.GracePeriod)
+		}
+	}
+
+	return &Controller{
+		Store:          store,
+		Wrapper:        wrapper,
+		KeyProvider:    keyProvider,
+		DatabaseClient: databaseClient,
+		ListResourceTypes: func(ctx context.Context) ([]ResourceType, error) {
+			return listResourceTypes(ctx, ucp)
+		},
+		Interval:    interval,
+		GracePeriod: gracePeriod,
+		now:         time.Now,
+	}, nil
+}
+
+// Start checks for a rotation immediately and then once per Interval, until ctx is done.
+func (c *Controller) Start(ctx context.Context) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Reconcile(ctx); err != nil && ctx.Err() == nil {
+			logger.Error(err, "Failed to rotate the encryption key")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile performs a requested rotation and continues the re-encryption of a rotation in progress. A
+// re-encryption is also started when the current key version was changed without the controller, for example
+// by an older release.
+func (c *Controller) Reconcile(ctx context.Context) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	rotation, err := c.Store.LoadKeyRotation(ctx)
+	if err != nil {
+		return err
+	}
+
+	currentVersion := rotation.KeyStore.CurrentVersion
+	switch {
+	case rotation.RequestedAt != "":
+		key, err := encryption.GenerateKey()
+		if err != nil {
+			return err
+		}
+
+		encoded, err := encryption.EncodeKey(ctx, key, c.Wrapper)
+		if err != nil {
+			return fmt.Errorf("failed to wrap the new key: %w", err)
+		}
+
+		now := c.now().UTC()
+		version := rotation.KeyStore.AddVersion(encoded, now, encryption.DefaultKeyValidity)
+		rotation.RequestedAt = ""
+		rotation.Status = &encryption.KeyRotationStatus{
+			State:     encryption.KeyRotationStateReencrypting,
+			Version:   version,
+			StartedAt: now,
+		}
+		if err := c.Store.SaveKeyRotation(ctx, rotation); err != nil {
+			return err
+		}
+
+		logger.Info("Rotated the encryption key", "previousVersion", currentVersion, "version", version)
+
+	case rotation.Status == nil && len(rotation.KeyStore.Versions()) > 1,
+		rotation.Status != nil && rotation.Status.Version != currentVersion:
+		rotation.Status = &encryption.KeyRotationStatus{
+			State:     encryption.KeyRotationStateReencrypting,
+			Version:   currentVersion,
+			StartedAt: c.now().UTC(),
+		}
+		if err := c.Store.SaveKeyRotation(ctx, rotation); err != nil {
+			return err
+		}
+
+		logger.Info("Detected a new encryption key version", "version", currentVersion)
+	}
+
+	if rotation.Status == nil {
+		return nil
+	}
+
+	switch rotation.Status.State {
+	case encryption.KeyRotationStateReencrypting:
+		return c.reencrypt(ctx, rotation)
+	case encryption.KeyRotationStateVerifying, encryption.KeyRotationStateFailed:
+		if rotation.Status.VerifyAfter != nil && c.now().Before(*rotation.Status.VerifyAfter) {
+			return nil
+		}
+		return c.reencrypt(ctx, rotation)
+	}
+
+	return nil
+}
+
+// reencrypt re-encrypts the sensitive fields of every resource, starting from the position recorded in the
+// status of rotation. After the first scan the rotation is verified once the grace period has elapsed, and the
+// previous key versions are retired by the first verification scan that re-encrypts nothing and fails nothing.
+func (c *Controller) reencrypt(ctx context.Context, rotation *encryption.KeyRotation) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+	status := rotation.Status
+
+	if status.ResourceType == "" && status.PaginationToken == "" {
+		// A new scan starts: the counters and the failure describe the current scan only. The resources that
+		// failed are retried by a verification scan.
+		status.Scanned = 0
+		status.Reencrypted = 0
+		status.Failed = 0
+		status.Message = ""
+		if status.State == encryption.KeyRotationStateFailed {
+			status.State = encryption.KeyRotationStateVerifying
+			status.CompletedAt = nil
+		}
+	}
+
+	// The handler encrypts with the current key of the provider, which must be the version of the rotation.
+	// Otherwise the key store was rotated again and the next check restarts the re-encryption.
+	_, version, err := c.KeyProvider.GetCurrentKey(ctx)
+	if err != nil {
+		return err
+	}
+	if version != status.Version {
+		return fmt.Errorf("the current key version %d is not the version %d of the rotation", version, status.Version)
+	}
+
+	handler, err := encryption.NewSensitiveDataHandlerFromProvider(ctx, c.KeyProvider)
+	if err != nil {
+		return err
+	}
+
+	resourceTypes, err := c.ListResourceTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list resource types: %w", err)
+	}
+	slices.SortFunc(resourceTypes, func(a, b ResourceType) int {
+		return strings.Compare(a.key(), b.key())
+	})
+
+	for _, resourceType := range resourceTypes {
+		if resourceType.key() < status.ResourceType {
+			// Already re-encrypted.
+			continue
+		}
+		if resourceType.key() != status.ResourceType {
+			status.ResourceType = resourceType.key()
+			status.PaginationToken = ""
+		}
+
+		for {
+			options := []database.QueryOptions{database.WithMaxQueryItemCount(queryPageSize)}
+			if status.PaginationToken != "" {
+				options = append(options, database.WithPaginationToken(status.PaginationToken))
+			}
+
+			result, err := c.DatabaseClient.Query(ctx, database.Query{
+				RootScope:      "/planes/radius/" + resourceType.Plane,
+				ScopeRecursive: true,
+				ResourceType:   resourceType.Type,
+			}, options...)
+			if err != nil {
+				return err
+			}
+
+			for i := range result.Items {
+				status.Scanned++
+				reencrypted, err := c.reencryptResource(ctx, handler, &result.Items[i], resourceType.SensitiveFieldPaths)
+				if err != nil {
+					logger.Error(err, "Failed to re-encrypt sensitive fields", "resourceID", result.Items[i].ID)
+					status.Failed++
+					status.Message = fmt.Sprintf("failed to re-encrypt resource %s: %v", result.Items[i].ID, err)
+				} else if reencrypted {
+					status.Reencrypted++
+					if status.State == encryption.KeyRotationStateVerifying {
+						status.Stragglers++
+					}
+				}
+			}
+
+			status.PaginationToken = result.PaginationToken
+			if err := c.Store.SaveKeyRotation(ctx, rotation); err != nil {
+				return err
+			}
+
+			if status.PaginationToken == "" {
+				break
+			}
+		}
+	}
+
+	now := c.now().UTC()
+	status.ResourceType = ""
+	status.PaginationToken = ""
+	if status.Failed > 0 {
+		// The resources that failed still reference the previous versions, so they can't be retired. They are
+		// retried by a verification scan once the grace period has elapsed.
+		verifyAfter := now.Add(c.GracePeriod)
+		status.State = encryption.KeyRotationStateFailed
+		status.CompletedAt = &now
+		status.VerifyAfter = &verifyAfter
+		status.Stragglers = 0
+	} else if status.State == encryption.KeyRotationStateReencrypting || status.Stragglers > 0 {
+		// Resources written with a previous version since the scan are found by the next verification scan.
+		verifyAfter := now.Add(c.GracePeriod)
+		status.State = encryption.KeyRotationStateVerifying
+		status.VerifyAfter = &verifyAfter
+		status.Stragglers = 0
+	} else {
+		status.State = encryption.KeyRotationStateCompleted
+		status.CompletedAt = &now
+		status.VerifyAfter = nil
+		status.RetiredVersions = nil
+		for _, version := range rotation.KeyStore.Versions() {
+			if version == status.Version {
+				continue
+			}
+			if err := rotation.KeyStore.RemoveVersion(version); err != nil {
+				return err
+			}
+			status.RetiredVersions = append(status.RetiredVersions, version)
+		}
+	}
+
+	if err := c.Store.SaveKeyRotation(ctx, rotation); err != nil {
+		return err
+	}
+
+	logger.Info("Finished scanning sensitive fields", "state", status.State, "version", status.Version,
+		"scanned", status.Scanned, "reencrypted", status.Reencrypted, "failed", status.Failed, "retiredVersions", status.RetiredVersions)
+	return nil
+}
+
+// reencryptResource re-encrypts the sensitive fields of a resource and saves it. It returns false if no field
+// had to be re-encrypted.
+func (c *Controller) reencryptResource(ctx context.Context, handler *encryption.SensitiveDataHandler, obj *database.Object, sensitiveFieldPaths []string) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		resource := &datamodel.DynamicResource{}
+		if err := obj.As(resource); err != nil {
+			return false, err
+		}
+		if resource.Properties == nil {
+			return false, nil
+		}
+
+		count, err := handler.ReencryptSensitiveFields(ctx, resource.Properties, sensitiveFieldPaths, obj.ID)
+		if err != nil {
+			return false, err
+		}
+		if count == 0 {
+			return false, nil
+		}
+
+		obj.Data = resource
+		err = c.DatabaseClient.Save(ctx, obj, database.WithETag(obj.ETag))
+		if err == nil {
+			return true, nil
+		} else if !errors.Is(err, &database.ErrConcurrency{}) || attempt == maxSaveAttempts {
+			return false, err
+		}
+
+		// The resource was modified since it was read. Re-encrypt the latest version.
+		obj, err = c.DatabaseClient.Get(ctx, obj.ID)
+		if errors.Is(err, &database.ErrNotFound{}) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+	}
+}
+
+// listResourceTypes lists the resource types of every Radius plane that have sensitive fields in any of their
+// API versions.
+func listResourceTypes(ctx context.Context, ucp *v20231001preview.ClientFactory) ([]ResourceType, error) {
+	resourceTypes := []ResourceType{}
+
+	planes := ucp.NewRadiusPlanesClient().NewListPager(nil)
+	for planes.More() {
+		page, err := planes.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, plane := range page.Value {
+			if plane == nil || plane.Name == nil {
+				continue
+			}
+
+			summaries := ucp.NewResourceProvidersClient().NewListProviderSummariesPager(*plane.Name, nil)
+			for summaries.More() {
+				page, err := summaries.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, summary := range page.Value {
+					resourceTypes = append(resourceTypes, sensitiveResourceTypes(*plane.Name, summary)...)
+				}
+			}
+		}
+	}
+
+	return resourceTypes, nil
+}
+
+// sensitiveResourceTypes returns the resource types of a resource provider that have sensitive fields.
+func sensitiveResourceTypes(plane string, summary *v20231001preview.ResourceProviderSummary) []ResourceType {
+	if summary == nil || summary.Name == nil {
+		return nil
+	}
+
+	resourceTypes := []ResourceType{}
+	for typeName, resourceType := range summary.ResourceTypes {
+		if resourceType == nil {
+			continue
+		}
+
+		paths := []string{}
+		for _, apiVersion := range resourceType.APIVersions {
+			if apiVersion == nil || apiVersion.Schema == nil {
+				continue
+			}
+			for _, path := range schema.ExtractSensitiveFieldPaths(apiVersion.Schema, "") {
+				if !slices.Contains(paths, path) {
+					paths = append(paths, path)
+				}
+			}
+		}
+
+		if len(paths) > 0 {
+			slices.Sort(paths)
+			resourceTypes = append(resourceTypes, ResourceType{
+				Plane:               plane,
+				Type:                *summary.Name + "/" + typeName,
+				SensitiveFieldPaths: paths,
+			})
+		}
+	}
+
+	return resourceTypes
+}
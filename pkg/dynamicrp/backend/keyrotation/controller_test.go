@@ -0,0 +1,501 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyrotation
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
+	"github.com/radius-project/radius/pkg/crypto/encryption"
+	"github.com/radius-project/radius/pkg/crypto/encryption/encryptionprovider"
+	"github.com/radius-project/radius/pkg/dynamicrp/datamodel"
+	"github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
+	"github.com/radius-project/radius/test/k8sutil"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+const (
+	secretID = "/planes/radius/local/resourceGroups/test/providers/Radius.Security/secrets/s1"
+	otherID  = "/planes/radius/local/resourceGroups/test/providers/Applications.Test/credentials/c1"
+)
+
+var (
+	testResourceTypes = []ResourceType{
+		{Plane: "local", Type: "Radius.Security/secrets", SensitiveFieldPaths: []string{"data[*].value"}},
+		{Plane: "local", Type: "Applications.Test/credentials", SensitiveFieldPaths: []string{"password"}},
+	}
+
+	testNow = time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	testVerifyAt = testNow.Add(DefaultGracePeriod)
+)
+
+type testSetup struct {
+	controller *Controller
+	store      *encryption.KubernetesKeyProvider
+	database   database.Client
+	key1       []byte
+}
+
+func setup(t *testing.T) *testSetup {
+	key1, err := encryption.GenerateKey()
+	require.NoError(t, err)
+
+	keyStore := &encryption.KeyStore{}
+	keyStore.AddVersion(base64.StdEncoding.EncodeToString(key1), testNow.Add(-time.Hour), encryption.DefaultKeyValidity)
+	keysJSON, err := json.Marshal(keyStore)
+	require.NoError(t, err)
+
+	kubeClient := k8sutil.NewFakeKubeClient(scheme.Scheme)
+	require.NoError(t, kubeClient.Create(t.Context(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      encryption.DefaultEncryptionKeySecretName,
+			Namespace: encryption.RadiusNamespace,
+		},
+		Data: map[string][]byte{
+			encryption.DefaultEncryptionKeySecretKey: keysJSON,
+		},
+	}))
+
+	store := encryption.NewKubernetesKeyProvider(kubeClient, nil)
+	databaseClient := inmemory.NewClient()
+
+	controller, err := NewController(encryptionprovider.RotationOptions{}, store, nil, store, databaseClient, nil)
+	require.NoError(t, err)
+	controller.ListResourceTypes = func(ctx context.Context) ([]ResourceType, error) {
+		return testResourceTypes, nil
+	}
+	controller.now = func() time.Time { return testNow }
+
+	return &testSetup{controller: controller, store: store, database: databaseClient, key1: key1}
+}
+
+// verify advances the clock past the grace period of the rotation and reconciles.
+func (s *testSetup) verify(t *testing.T) {
+	s.controller.now = func() time.Time { return testVerifyAt }
+	require.NoError(t, s.controller.Reconcile(t.Context()))
+}
+
+// saveResource encrypts the sensitive fields of properties with the current key and saves the resource.
+func (s *testSetup) saveResource(t *testing.T, id string, properties map[string]any, paths []string) {
+	handler, err := encryption.NewSensitiveDataHandlerFromProvider(t.Context(), s.store)
+	require.NoError(t, err)
+	require.NoError(t, handler.EncryptSensitiveFields(properties, paths, id))
+
+	require.NoError(t, s.database.Save(t.Context(), &database.Object{
+		Metadata: database.Metadata{ID: id},
+		Data:     &datamodel.DynamicResource{Properties: properties},
+	}))
+}
+
+// properties returns the properties of a resource.
+func (s *testSetup) properties(t *testing.T, id string) map[string]any {
+	obj, err := s.database.Get(t.Context(), id)
+	require.NoError(t, err)
+
+	resource := &datamodel.DynamicResource{}
+	require.NoError(t, obj.As(resource))
+	return resource.Properties
+}
+
+func Test_NewController(t *testing.T) {
+	controller, err := NewController(encryptionprovider.RotationOptions{Interval: "5m"}, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Minute, controller.Interval)
+
+	_, err = NewController(encryptionprovider.RotationOptions{Interval: "soon"}, nil, nil, nil, nil, nil)
+	require.ErrorContains(t, err, "invalid encryptionProvider.rotation.interval")
+
+	_, err = NewController(encryptionprovider.RotationOptions{Interval: "-1m"}, nil, nil, nil, nil, nil)
+	require.ErrorContains(t, err, "must be positive")
+
+	require.Equal(t, DefaultGracePeriod, controller.GracePeriod)
+
+	controller, err = NewController(encryptionprovider.RotationOptions{GracePeriod: "1h"}, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, controller.GracePeriod)
+
+	_, err = NewController(encryptionprovider.RotationOptions{GracePeriod: "later"}, nil, nil, nil, nil, nil)
+	require.ErrorContains(t, err, "invalid encryptionProvider.rotation.gracePeriod")
+
+	_, err = NewController(encryptionprovider.RotationOptions{GracePeriod: "-1h"}, nil, nil, nil, nil, nil)
+	require.ErrorContains(t, err, "must not be negative")
+}
+
+func Test_Reconcile_NoRotation(t *testing.T) {
+	s := setup(t)
+
+	require.NoError(t, s.controller.Reconcile(t.Context()))
+
+	rotation, err := s.store.LoadKeyRotation(t.Context())
+	require.NoError(t, err)
+	require.Nil(t, rotation.Status)
+	require.Equal(t, []int{1}, rotation.KeyStore.Versions())
+}
+
+func Test_Reconcile_Rotation(t *testing.T) {
+	s := setup(t)
+	ctx := t.Context()
+
+	s.saveResource(t, secretID, map[string]any{
+		"data": map[string]any{
+			"username": map[string]any{"value": "admin"},
+			"password": map[string]any{"value": "hunter2"},
+		},
+	}, []string{"data[*].value"})
+	s.saveResource(t, otherID, map[string]any{"password": "secret", "user": "admin"}, []string{"password"})
+
+	_, err := s.store.RequestKeyRotation(ctx, testNow)
+	require.NoError(t, err)
+
+	require.NoError(t, s.controller.Reconcile(ctx))
+
+	// The previous version is kept until the verification scan.
+	rotation, err := s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Empty(t, rotation.RequestedAt)
+	require.Equal(t, 2, rotation.KeyStore.CurrentVersion)
+	require.Equal(t, []int{1, 2}, rotation.KeyStore.Versions())
+	require.Equal(t, &encryption.KeyRotationStatus{
+		State:       encryption.KeyRotationStateVerifying,
+		Version:     2,
+		StartedAt:   testNow,
+		VerifyAfter: &testVerifyAt,
+		Scanned:     2,
+		Reencrypted: 2,
+	}, rotation.Status)
+
+	// Nothing happens before the grace period has elapsed.
+	require.NoError(t, s.controller.Reconcile(ctx))
+	rotation, err = s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, encryption.KeyRotationStateVerifying, rotation.Status.State)
+	require.Equal(t, 2, rotation.Status.Scanned)
+
+	// The verification scan finds nothing to re-encrypt and retires the previous version.
+	s.verify(t)
+
+	rotation, err = s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int{2}, rotation.KeyStore.Versions())
+	require.Equal(t, &encryption.KeyRotationStatus{
+		State:           encryption.KeyRotationStateCompleted,
+		Version:         2,
+		StartedAt:       testNow,
+		CompletedAt:     &testVerifyAt,
+		Scanned:         2,
+		Reencrypted:     0,
+		RetiredVersions: []int{1},
+	}, rotation.Status)
+
+	// The resources can be decrypted with the new key alone.
+	handler, err := encryption.NewSensitiveDataHandlerFromProvider(ctx, s.store)
+	require.NoError(t, err)
+
+	properties := s.properties(t, secretID)
+	require.NoError(t, handler.DecryptSensitiveFields(ctx, properties, []string{"data[*].value"}, secretID))
+	require.Equal(t, "hunter2", properties["data"].(map[string]any)["password"].(map[string]any)["value"])
+
+	properties = s.properties(t, otherID)
+	require.NoError(t, handler.DecryptSensitiveFields(ctx, properties, []string{"password"}, otherID))
+	require.Equal(t, map[string]any{"password": "secret", "user": "admin"}, properties)
+
+	// Nothing changes until the next rotation is requested.
+	require.NoError(t, s.controller.Reconcile(ctx))
+	rotation, err = s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, encryption.KeyRotationStateCompleted, rotation.Status.State)
+	require.Equal(t, 2, rotation.Status.Scanned)
+}
+
+func Test_Reconcile_Verification_Stragglers(t *testing.T) {
+	s := setup(t)
+	ctx := t.Context()
+
+	s.saveResource(t, otherID, map[string]any{"password": "secret"}, []string{"password"})
+
+	_, err := s.store.RequestKeyRotation(ctx, testNow)
+	require.NoError(t, err)
+	require.NoError(t, s.controller.Reconcile(ctx))
+
+	// A replica that still has the previous key writes a resource after the re-encryption.
+	encryptor, err := encryption.NewEncryptorWithVersion(s.key1, 1)
+	require.NoError(t, err)
+	stale := encryption.NewSensitiveDataHandler(encryptor)
+	properties := map[string]any{"password": "late"}
+	require.NoError(t, stale.EncryptSensitiveFields(properties, []string{"password"}, secretID))
+	require.NoError(t, s.database.Save(ctx, &database.Object{
+		Metadata: database.Metadata{ID: secretID},
+		Data:     &datamodel.DynamicResource{Properties: properties},
+	}))
+	s.controller.ListResourceTypes = func(ctx context.Context) ([]ResourceType, error) {
+		return []ResourceType{
+			{Plane: "local", Type: "Radius.Security/secrets", SensitiveFieldPaths: []string{"password"}},
+			testResourceTypes[1],
+		}, nil
+	}
+
+	// The verification scan re-encrypts it, so the previous version is kept for another grace period.
+	s.verify(t)
+
+	rotation, err := s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, rotation.KeyStore.Versions())
+	require.Equal(t, encryption.KeyRotationStateVerifying, rotation.Status.State)
+	require.Equal(t, testVerifyAt.Add(DefaultGracePeriod), *rotation.Status.VerifyAfter)
+	require.Equal(t, 2, rotation.Status.Scanned)
+	require.Equal(t, 1, rotation.Status.Reencrypted)
+	require.Equal(t, 0, rotation.Status.Stragglers)
+	require.Equal(t, float64(2), s.properties(t, secretID)["password"].(map[string]any)["version"])
+
+	// The next scan is clean.
+	s.controller.now = func() time.Time { return testVerifyAt.Add(DefaultGracePeriod) }
+	require.NoError(t, s.controller.Reconcile(ctx))
+
+	rotation, err = s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, encryption.KeyRotationStateCompleted, rotation.Status.State)
+	require.Equal(t, []int{1}, rotation.Status.RetiredVersions)
+	require.Equal(t, []int{2}, rotation.KeyStore.Versions())
+}
+
+// pageRecorder records the page size of every query.
+type pageRecorder struct {
+	database.Client
+	pageSizes []int
+}
+
+func (r *pageRecorder) Query(ctx context.Context, query database.Query, options ...database.QueryOptions) (*database.ObjectQueryResult, error) {
+	r.pageSizes = append(r.pageSizes, database.NewQueryConfig(options...).MaxQueryItemCount)
+	return r.Client.Query(ctx, query, options...)
+}
+
+func Test_Reconcile_Paging(t *testing.T) {
+	s := setup(t)
+	ctx := t.Context()
+
+	recorder := &pageRecorder{Client: s.database}
+	s.controller.DatabaseClient = recorder
+
+	for i := range queryPageSize + 1 {
+		id := fmt.Sprintf("%s%d", otherID, i)
+		s.saveResource(t, id, map[string]any{"password": "secret"}, []string{"password"})
+	}
+
+	_, err := s.store.RequestKeyRotation(ctx, testNow)
+	require.NoError(t, err)
+	require.NoError(t, s.controller.Reconcile(ctx))
+
+	rotation, err := s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, queryPageSize+1, rotation.Status.Reencrypted)
+
+	// One page for the first resource type, and two for the second.
+	require.Equal(t, []int{queryPageSize, queryPageSize, queryPageSize}, recorder.pageSizes)
+}
+
+func Test_Reconcile_Resume(t *testing.T) {
+	s := setup(t)
+	ctx := t.Context()
+
+	s.saveResource(t, secretID, map[string]any{"data": map[string]any{"a": map[string]any{"value": "x"}}}, []string{"data[*].value"})
+	s.saveResource(t, otherID, map[string]any{"password": "secret"}, []string{"password"})
+
+	// Simulate a rotation that was interrupted while re-encrypting the second resource type.
+	rotation, err := s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	key2, err := encryption.GenerateKey()
+	require.NoError(t, err)
+	rotation.KeyStore.AddVersion(base64.StdEncoding.EncodeToString(key2), testNow, encryption.DefaultKeyValidity)
+	rotation.Status = &encryption.KeyRotationStatus{
+		State:        encryption.KeyRotationStateReencrypting,
+		Version:      2,
+		StartedAt:    testNow,
+		ResourceType: "local|Radius.Security/secrets",
+		Scanned:      1,
+		Reencrypted:  1,
+	}
+	require.NoError(t, s.store.SaveKeyRotation(ctx, rotation))
+
+	require.NoError(t, s.controller.Reconcile(ctx))
+
+	rotation, err = s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, encryption.KeyRotationStateVerifying, rotation.Status.State)
+	require.Equal(t, 2, rotation.Status.Scanned)
+	require.Equal(t, 2, rotation.Status.Reencrypted)
+
+	// Resource types are processed in order, so the first resource type is not re-encrypted again.
+	require.Equal(t, float64(1), s.properties(t, otherID)["password"].(map[string]any)["version"])
+	require.Equal(t, float64(2), s.properties(t, secretID)["data"].(map[string]any)["a"].(map[string]any)["value"].(map[string]any)["version"])
+}
+
+func Test_Reconcile_Failure(t *testing.T) {
+	s := setup(t)
+	ctx := t.Context()
+
+	s.saveResource(t, otherID, map[string]any{"password": "secret"}, []string{"password"})
+
+	// A value encrypted for another resource can't be decrypted with the associated data of this one.
+	properties := map[string]any{"password": "secret"}
+	handler, err := encryption.NewSensitiveDataHandlerFromProvider(ctx, s.store)
+	require.NoError(t, err)
+	require.NoError(t, handler.EncryptSensitiveFields(properties, []string{"password"}, otherID))
+	badID := otherID + "-bad"
+	require.NoError(t, s.database.Save(ctx, &database.Object{
+		Metadata: database.Metadata{ID: badID},
+		Data:     &datamodel.DynamicResource{Properties: properties},
+	}))
+
+	_, err = s.store.RequestKeyRotation(ctx, testNow)
+	require.NoError(t, err)
+
+	require.NoError(t, s.controller.Reconcile(ctx))
+
+	rotation, err := s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, encryption.KeyRotationStateFailed, rotation.Status.State)
+	require.Equal(t, 2, rotation.Status.Scanned)
+	require.Equal(t, 1, rotation.Status.Reencrypted)
+	require.Equal(t, 1, rotation.Status.Failed)
+	require.Contains(t, rotation.Status.Message, badID)
+
+	require.Equal(t, &testVerifyAt, rotation.Status.VerifyAfter)
+
+	// The previous version is kept because it is still referenced.
+	require.Equal(t, []int{1, 2}, rotation.KeyStore.Versions())
+
+	// Nothing happens before the grace period has elapsed.
+	require.NoError(t, s.controller.Reconcile(ctx))
+	rotation, err = s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, encryption.KeyRotationStateFailed, rotation.Status.State)
+
+	// The failed resources are scanned again after the grace period. The bad resource is replaced, so the scan
+	// succeeds and the previous version is retired.
+	s.saveResource(t, badID, map[string]any{"password": "secret"}, []string{"password"})
+	s.verify(t)
+
+	rotation, err = s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, encryption.KeyRotationStateCompleted, rotation.Status.State)
+	require.Equal(t, 2, rotation.Status.Scanned)
+	require.Equal(t, 0, rotation.Status.Reencrypted)
+	require.Equal(t, 0, rotation.Status.Failed)
+	require.Empty(t, rotation.Status.Message)
+	require.Equal(t, []int{1}, rotation.Status.RetiredVersions)
+	require.Equal(t, []int{2}, rotation.KeyStore.Versions())
+}
+
+func Test_Reconcile_Failure_Retried(t *testing.T) {
+	s := setup(t)
+	ctx := t.Context()
+
+	properties := map[string]any{"password": "secret"}
+	handler, err := encryption.NewSensitiveDataHandlerFromProvider(ctx, s.store)
+	require.NoError(t, err)
+	require.NoError(t, handler.EncryptSensitiveFields(properties, []string{"password"}, otherID))
+	badID := otherID + "-bad"
+	require.NoError(t, s.database.Save(ctx, &database.Object{
+		Metadata: database.Metadata{ID: badID},
+		Data:     &datamodel.DynamicResource{Properties: properties},
+	}))
+
+	_, err = s.store.RequestKeyRotation(ctx, testNow)
+	require.NoError(t, err)
+	require.NoError(t, s.controller.Reconcile(ctx))
+
+	// The resource still can't be decrypted: the rotation fails again with the counters of the new scan only.
+	s.verify(t)
+
+	rotation, err := s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, encryption.KeyRotationStateFailed, rotation.Status.State)
+	require.Equal(t, 1, rotation.Status.Scanned)
+	require.Equal(t, 0, rotation.Status.Reencrypted)
+	require.Equal(t, 1, rotation.Status.Failed)
+	require.Contains(t, rotation.Status.Message, badID)
+	require.Equal(t, []int{1, 2}, rotation.KeyStore.Versions())
+}
+
+func Test_Reconcile_ExternalRotation(t *testing.T) {
+	s := setup(t)
+	ctx := t.Context()
+
+	s.saveResource(t, otherID, map[string]any{"password": "secret"}, []string{"password"})
+
+	// A new version added without a rotation request, e.g. by an older release.
+	rotation, err := s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	key2, err := encryption.GenerateKey()
+	require.NoError(t, err)
+	rotation.KeyStore.AddVersion(base64.StdEncoding.EncodeToString(key2), testNow, encryption.DefaultKeyValidity)
+	require.NoError(t, s.store.SaveKeyRotation(ctx, rotation))
+
+	require.NoError(t, s.controller.Reconcile(ctx))
+	s.verify(t)
+
+	rotation, err = s.store.LoadKeyRotation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, encryption.KeyRotationStateCompleted, rotation.Status.State)
+	require.Equal(t, []int{1}, rotation.Status.RetiredVersions)
+	require.Equal(t, float64(2), s.properties(t, otherID)["password"].(map[string]any)["version"])
+}
+
+func Test_sensitiveResourceTypes(t *testing.T) {
+	name := "Radius.Security"
+	summary := &v20231001preview.ResourceProviderSummary{
+		Name: &name,
+		ResourceTypes: map[string]*v20231001preview.ResourceProviderSummaryResourceType{
+			"secrets": {
+				APIVersions: map[string]*v20231001preview.ResourceTypeSummaryResultAPIVersion{
+					"2025-01-01": {Schema: map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"token": map[string]any{"type": "string", "x-radius-sensitive": true},
+						},
+					}},
+					"2025-06-01": {Schema: map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"token":    map[string]any{"type": "string", "x-radius-sensitive": true},
+							"password": map[string]any{"type": "string", "x-radius-sensitive": true},
+						},
+					}},
+				},
+			},
+			"plain": {
+				APIVersions: map[string]*v20231001preview.ResourceTypeSummaryResultAPIVersion{
+					"2025-01-01": {Schema: map[string]any{"type": "object"}},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []ResourceType{
+		{Plane: "local", Type: "Radius.Security/secrets", SensitiveFieldPaths: []string{"password", "token"}},
+	}, sensitiveResourceTypes("local", summary))
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyrotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	aztoken "github.com/radius-project/radius/pkg/azure/tokencredentials"
+	"github.com/radius-project/radius/pkg/crypto/encryption/encryptionprovider"
+	"github.com/radius-project/radius/pkg/dynamicrp"
+	"github.com/radius-project/radius/pkg/sdk"
+	"github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+// Service runs the key rotation controller of the dynamic-rp.
+type Service struct {
+	options *dynamicrp.Options
+}
+
+// NewService creates a new service to run the key rotation controller.
+func NewService(options *dynamicrp.Options) *Service {
+	return &Service{options: options}
+}
+
+// Name returns the name of the service used for logging.
+func (s *Service) Name() string {
+	return "dynamic-rp key rotation"
+}
+
+// Run runs the service. It returns immediately when the configured key store can't be rotated.
+func (s *Service) Run(ctx context.Context) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	kubeClient, err := s.options.KubernetesProvider.RuntimeClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Kubernetes runtime client: %w", err)
+	}
+
+	store, wrapper, err := encryptionprovider.NewKeyRotationStore(s.options.Config.Encryption, kubeClient)
+	if errors.Is(err, encryptionprovider.ErrKeyRotationUnsupported) {
+		logger.Info("Encryption key rotation is disabled", "reason", err.Error())
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	keyProvider, err := encryptionprovider.NewKeyProvider(s.options.Config.Encryption, kubeClient)
+	if err != nil {
+		return fmt.Errorf("failed to create encryption key provider: %w", err)
+	}
+
+	databaseClient, err := s.options.DatabaseProvider.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	ucp, err := v20231001preview.NewClientFactory(&aztoken.AnonymousCredential{}, sdk.NewClientOptions(s.options.UCP))
+	if err != nil {
+		return err
+	}
+
+	controller, err := NewController(s.options.Config.Encryption.Rotation, store, wrapper, keyProvider, databaseClient, ucp)
+	if err != nil {
+		return err
+	}
+
+	return controller.Start(ctx)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recipedrift
+
+import (
+	"context"
+	"slices"
+
+	aztoken "github.com/radius-project/radius/pkg/azure/tokencredentials"
+	"github.com/radius-project/radius/pkg/dynamicrp"
+	"github.com/radius-project/radius/pkg/dynamicrp/datamodel"
+	"github.com/radius-project/radius/pkg/portableresources/backend/drift"
+	"github.com/radius-project/radius/pkg/schema"
+	"github.com/radius-project/radius/pkg/sdk"
+	"github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+// applicationsRPNamespaces are the resource provider namespaces of the resource types that are implemented
+// by the applications-rp, which checks their recipes for drift itself.
+var applicationsRPNamespaces = []string{
+	"Applications.Core",
+	"Applications.Dapr",
+	"Applications.Datastores",
+	"Applications.Messaging",
+	"Radius.Core",
+}
+
+// Service runs the recipe drift controller of the dynamic-rp.
+type Service struct {
+	options *dynamicrp.Options
+}
+
+// NewService creates a new service to run the recipe drift controller.
+func NewService(options *dynamicrp.Options) *Service {
+	return &Service{options: options}
+}
+
+// Name returns the name of the service used for logging.
+func (s *Service) Name() string {
+	return "dynamic-rp recipe drift"
+}
+
+// Run runs the service. It returns immediately when recipe drift detection is not enabled.
+func (s *Service) Run(ctx context.Context) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	if !s.options.Config.RecipeDrift.Enabled {
+		logger.Info("Recipe drift detection is disabled")
+		return nil
+	}
+
+	eng, err := s.options.RecipeEngine()
+	if err != nil {
+		return err
+	}
+
+	databaseClient, err := s.options.DatabaseProvider.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	ucp, err := v20231001preview.NewClientFactory(&aztoken.AnonymousCredential{}, sdk.NewClientOptions(s.options.UCP))
+	if err != nil {
+		return err
+	}
+
+	controller, err := drift.NewController(s.options.Config.RecipeDrift, databaseClient, s.options.StatusManager, eng, s.options.Recipes.ConfigurationLoader,
+		func(ctx context.Context) ([]drift.ResourceType, error) {
+			return listResourceTypes(ctx, ucp)
+		})
+	if err != nil {
+		return err
+	}
+
+	return controller.Start(ctx)
+}
+
+// listResourceTypes lists the resource types of every Radius plane that are implemented by the dynamic-rp.
+func listResourceTypes(ctx context.Context, ucp *v20231001preview.ClientFactory) ([]drift.ResourceType, error) {
+	resourceTypes := []drift.ResourceType{}
+
+	planes := ucp.NewRadiusPlanesClient().NewListPager(nil)
+	for planes.More() {
+		page, err := planes.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, plane := range page.Value {
+			if plane == nil || plane.Name == nil {
+				continue
+			}
+
+			summaries := ucp.NewResourceProvidersClient().NewListProviderSummariesPager(*plane.Name, nil)
+			for summaries.More() {
+				page, err := summaries.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, summary := range page.Value {
+					resourceTypes = append(resourceTypes, dynamicResourceTypes(*plane.Name, summary)...)
+				}
+			}
+		}
+	}
+
+	return resourceTypes, nil
+}
+
+// dynamicResourceTypes returns the resource types of a resource provider that are implemented by the dynamic-rp.
+func dynamicResourceTypes(plane string, summary *v20231001preview.ResourceProviderSummary) []drift.ResourceType {
+	if summary == nil || summary.Name == nil || slices.Contains(applicationsRPNamespaces, *summary.Name) {
+		return nil
+	}
+
+	resourceTypes := []drift.ResourceType{}
+	for typeName, resourceType := range summary.ResourceTypes {
+		if resourceType == nil {
+			continue
+		}
+
+		hasSensitiveFields := false
+		for _, apiVersion := range resourceType.APIVersions {
+			if apiVersion != nil && apiVersion.Schema != nil && len(schema.ExtractSensitiveFieldPaths(apiVersion.Schema, "")) > 0 {
+				hasSensitiveFields = true
+			}
+		}
+
+		resourceTypes = append(resourceTypes, drift.ResourceType{
+			Plane: plane,
+			Type:  *summary.Name + "/" + typeName,
+			New: func() drift.Resource {
+				return &datamodel.DynamicResource{}
+			},
+			HasSensitiveFields: hasSensitiveFields,
+		})
+	}
+
+	return resourceTypes
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recipedrift
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/radius-project/radius/pkg/dynamicrp/datamodel"
+	"github.com/radius-project/radius/pkg/portableresources/backend/drift"
+	"github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dynamicResourceTypes(t *testing.T) {
+	name := "Radius.Data"
+	summary := &v20231001preview.ResourceProviderSummary{
+		Name: &name,
+		ResourceTypes: map[string]*v20231001preview.ResourceProviderSummaryResourceType{
+			"postgreSqlDatabases": {
+				APIVersions: map[string]*v20231001preview.ResourceTypeSummaryResultAPIVersion{
+					"2025-08-01-preview": {Schema: map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"password": map[string]any{"type": "string", "x-radius-sensitive": true},
+						},
+					}},
+				},
+			},
+			"redisCaches": {
+				APIVersions: map[string]*v20231001preview.ResourceTypeSummaryResultAPIVersion{
+					"2025-08-01-preview": {Schema: map[string]any{"type": "object"}},
+				},
+			},
+		},
+	}
+
+	resourceTypes := dynamicResourceTypes("local", summary)
+	slices.SortFunc(resourceTypes, func(a, b drift.ResourceType) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+
+	require.Len(t, resourceTypes, 2)
+	require.Equal(t, "local", resourceTypes[0].Plane)
+	require.Equal(t, "Radius.Data/postgreSqlDatabases", resourceTypes[0].Type)
+	require.True(t, resourceTypes[0].HasSensitiveFields)
+	require.IsType(t, &datamodel.DynamicResource{}, resourceTypes[0].New())
+	require.Equal(t, "Radius.Data/redisCaches", resourceTypes[1].Type)
+	require.False(t, resourceTypes[1].HasSensitiveFields)
+}
+
+func Test_dynamicResourceTypes_ApplicationsRP(t *testing.T) {
+	name := "Applications.Datastores"
+	summary := &v20231001preview.ResourceProviderSummary{
+		Name: &name,
+		ResourceTypes: map[string]*v20231001preview.ResourceProviderSummaryResourceType{
+			"redisCaches": {},
+		},
+	}
+
+	require.Empty(t, dynamicResourceTypes("local", summary))
+}
@@ -23,6 +23,7 @@ import (
 	ctrl "github.com/radius-project/radius/pkg/armrpc/asyncoperation/controller"
 	"github.com/radius-project/radius/pkg/armrpc/asyncoperation/worker"
 	aztoken "github.com/radius-project/radius/pkg/azure/tokencredentials"
+	"github.com/radius-project/radius/pkg/crypto/encryption/encryptionprovider"
 	"github.com/radius-project/radius/pkg/dynamicrp"
 	"github.com/radius-project/radius/pkg/dynamicrp/backend/controller"
 	"github.com/radius-project/radius/pkg/dynamicrp/backend/secret"
@@ -98,9 +99,15 @@ func (w *Service) registerControllers() error {
 		return fmt.Errorf("failed to get Kubernetes runtime client: %w", err)
 	}
 
+	keyProvider, err := encryptionprovider.NewKeyProvider(w.options.Config.Encryption, kubeClient)
+	if err != nil {
+		return fmt.Errorf("failed to create encryption key provider: %w", err)
+	}
+
 	options := ctrl.Options{
 		DatabaseClient: w.Service.DatabaseClient,
 		KubeClient:     kubeClient,
+		KeyProvider:    keyProvider,
 	}
 
 	ucp, err := v20231001preview.NewClientFactory(&aztoken.AnonymousCredential{}, sdk.NewClientOptions(w.options.UCP))
@@ -27,6 +27,7 @@ import (
 	"github.com/radius-project/radius/pkg/components/queue/queueprovider"
 	"github.com/radius-project/radius/pkg/components/secret/secretprovider"
 	"github.com/radius-project/radius/pkg/components/trace/traceservice"
+	"github.com/radius-project/radius/pkg/crypto/encryption/encryptionprovider"
 	ucpconfig "github.com/radius-project/radius/pkg/ucp/config"
 	"github.com/radius-project/radius/pkg/ucp/ucplog"
 	"go.yaml.in/yaml/v3"
@@ -42,6 +43,9 @@ type Config struct {
 	// Database is the configuration for the database.
 	Database databaseprovider.Options `yaml:"databaseProvider"`
 
+	// Encryption is the configuration for the keys used to encrypt sensitive fields.
+	Encryption encryptionprovider.Options `yaml:"encryptionProvider"`
+
 	// Environment is the configuration for the hosting environment.
 	Environment hostoptions.EnvironmentOptions `yaml:"environment"`
 
@@ -60,6 +64,9 @@ type Config struct {
 	// Queue is the configuration for the message queue.
 	Queue queueprovider.QueueProviderOptions `yaml:"queueProvider"`
 
+	// RecipeDrift is the configuration for the periodic check of recipes for drift.
+	RecipeDrift hostoptions.RecipeDriftOptions `yaml:"recipeDrift"`
+
 	// Secrets is the configuration for the secret storage system.
 	Secrets secretprovider.SecretProviderOptions `yaml:"secretProvider"`
 
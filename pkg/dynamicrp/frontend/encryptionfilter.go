@@ -108,6 +108,19 @@ func encryptSensitiveFields(
 		return nil, nil
 	}
 
+	// Pick up a key rotation that happened since the handler was created, so that new values are
+	// encrypted with the current key version and don't have to be re-encrypted.
+	if err := handler.RefreshCurrentKey(ctx); err != nil {
+		logger.Error(err, "Failed to refresh the current encryption key",
+			"resourceType", resourceType, "resourceID", resourceID)
+		return rest.NewInternalServerErrorARMResponse(v1.ErrorResponse{
+			Error: &v1.ErrorDetails{
+				Code:    v1.CodeInternal,
+				Message: "Failed to load the encryption key",
+			},
+		}), nil
+	}
+
 	// Encrypt sensitive fields in the Properties map
 	// Field paths from schema are relative to "properties", so we operate on Properties directly
 	if err := handler.EncryptSensitiveFields(
@@ -18,6 +18,7 @@ package frontend
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
@@ -55,14 +56,22 @@ func (c *ListResourcesWithRedaction) Run(ctx context.Context, w http.ResponseWri
 	serviceCtx := v1.ARMRequestContextFromContext(ctx)
 	logger := ucplog.FromContextOrDiscard(ctx)
 
+	filters, options, err := ctrl.ListQueryOptions(serviceCtx)
+	if err != nil {
+		return rest.NewBadRequestResponse(err.Error()), nil
+	}
+
 	query := database.Query{
 		RootScope:      serviceCtx.ResourceID.RootScope(),
 		ResourceType:   serviceCtx.ResourceID.Type(),
 		ScopeRecursive: c.listRecursiveQuery,
+		Filters:        filters,
 	}
 
-	result, err := c.DatabaseClient().Query(ctx, query, database.WithPaginationToken(serviceCtx.SkipToken), database.WithMaxQueryItemCount(serviceCtx.Top))
-	if err != nil {
+	result, err := c.DatabaseClient().Query(ctx, query, options...)
+	if errors.Is(err, &database.ErrInvalid{}) {
+		return rest.NewBadRequestResponse(err.Error()), nil
+	} else if err != nil {
 		return nil, err
 	}
 
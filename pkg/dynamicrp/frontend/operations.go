@@ -23,6 +23,7 @@ import (
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
 	"github.com/radius-project/radius/pkg/armrpc/frontend/controller"
 	"github.com/radius-project/radius/pkg/armrpc/frontend/server"
+	"github.com/radius-project/radius/pkg/armrpc/history"
 	"github.com/radius-project/radius/pkg/armrpc/rest"
 	"github.com/radius-project/radius/pkg/ucp/resources"
 )
@@ -47,7 +48,7 @@ import (
 // This code ensures that the controller will be provided with the correct resource type.
 func dynamicOperationHandler(method v1.OperationMethod, baseOptions controller.Options, factory func(opts controller.Options) (controller.Controller, error)) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		id, err := resources.Parse(r.URL.Path)
+		id, err := resources.ParseByMethod(r.URL.Path, r.Method)
 		if err != nil {
 			result := rest.NewBadRequestResponse(err.Error())
 			err = result.Apply(r.Context(), w, r)
@@ -58,6 +59,11 @@ func dynamicOperationHandler(method v1.OperationMethod, baseOptions controller.O
 			return
 		}
 
+		// The history of a resource is handled as an operation on the resource itself.
+		if history.IsHistoryID(id) {
+			id = id.Truncate()
+		}
+
 		operationType := v1.OperationType{Type: strings.ToUpper(id.Type()), Method: method}
 
 		// Copy the options and initalize them dynamically for this type.
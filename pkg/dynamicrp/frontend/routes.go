@@ -27,6 +27,8 @@ import (
 	"github.com/radius-project/radius/pkg/crypto/encryption"
 	"github.com/radius-project/radius/pkg/dynamicrp/datamodel"
 	"github.com/radius-project/radius/pkg/dynamicrp/datamodel/converter"
+	pr_frontend_ctrl "github.com/radius-project/radius/pkg/portableresources/frontend/controller"
+	"github.com/radius-project/radius/pkg/recipes"
 	"github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
 	"github.com/radius-project/radius/pkg/validator"
 )
@@ -84,7 +86,9 @@ func (s *Service) registerRoutes(
 			// Async operation status/results
 			r.Route("/locations/{locationName}", func(r chi.Router) {
 				r.Get("/{or:operation[Rr]esults}/{operationID}", dynamicOperationHandler(v1.OperationGet, controllerOptions, makeGetOperationResultController))
+				r.Get("/{os:operation[Ss]tatuses}", dynamicOperationHandler(v1.OperationList, controllerOptions, makeListOperationStatusesController))
 				r.Get("/{os:operation[Ss]tatuses}/{operationID}", dynamicOperationHandler(v1.OperationGet, controllerOptions, makeGetOperationStatusController))
+				r.Post("/{os:operation[Ss]tatuses}/{operationID}/"+defaultoperation.CancelOperationAction, dynamicOperationHandler(v1.OperationCancel, controllerOptions, makeCancelOperationController))
 			})
 		})
 
@@ -106,6 +110,10 @@ func (s *Service) registerRoutes(
 				func(opts controller.Options) (controller.Controller, error) {
 					return defaultoperation.NewDefaultAsyncDelete(opts, resourceOptions)
 				}))
+			r.Post("/{resourceName}/"+recipes.PlanAction, dynamicOperationHandler(v1.OperationRecipePlan, controllerOptions,
+				func(opts controller.Options) (controller.Controller, error) {
+					return pr_frontend_ctrl.NewPlanRecipe[*datamodel.DynamicResource](opts, resourceOptions)
+				}))
 		})
 	})
 
@@ -116,6 +124,18 @@ func makeGetOperationResultController(opts controller.Options) (controller.Contr
 	return defaultoperation.NewGetOperationResult(opts)
 }
 
+func makeCancelOperationController(opts controller.Options) (controller.Controller, error) {
+	return defaultoperation.NewCancelOperation(opts)
+}
+
+func makeGetResourceHistoryController(opts controller.Options) (controller.Controller, error) {
+	return defaultoperation.NewGetResourceHistory(opts)
+}
+
+func makeListOperationStatusesController(opts controller.Options) (controller.Controller, error) {
+	return defaultoperation.NewListOperationStatuses(opts)
+}
+
 func makeGetOperationStatusController(opts controller.Options) (controller.Controller, error) {
 	return defaultoperation.NewGetOperationStatus(opts)
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/radius-project/radius/pkg/armrpc/servicecontext"
 	aztoken "github.com/radius-project/radius/pkg/azure/tokencredentials"
 	"github.com/radius-project/radius/pkg/crypto/encryption"
+	"github.com/radius-project/radius/pkg/crypto/encryption/encryptionprovider"
 	"github.com/radius-project/radius/pkg/dynamicrp"
 	"github.com/radius-project/radius/pkg/middleware"
 	"github.com/radius-project/radius/pkg/sdk"
@@ -152,7 +153,7 @@ func (s *Service) Run(ctx context.Context) error {
 }
 
 // createSensitiveDataHandler creates a SensitiveDataHandler for encrypting sensitive fields.
-// It loads the encryption key from a Kubernetes secret.
+// It loads the encryption key from the configured key provider, a Kubernetes secret by default.
 func (s *Service) createSensitiveDataHandler(ctx context.Context) (*encryption.SensitiveDataHandler, error) {
 	// Get Kubernetes runtime client from provider
 	kubeClient, err := s.options.KubernetesProvider.RuntimeClient()
@@ -160,8 +161,10 @@ func (s *Service) createSensitiveDataHandler(ctx context.Context) (*encryption.S
 		return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
 	}
 
-	// Create key provider that loads encryption keys from Kubernetes secret
-	keyProvider := encryption.NewKubernetesKeyProvider(kubeClient, nil)
+	keyProvider, err := encryptionprovider.NewKeyProvider(s.options.Config.Encryption, kubeClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encryption key provider: %w", err)
+	}
 
 	// Create handler with versioned key support
 	handler, err := encryption.NewSensitiveDataHandlerFromProvider(ctx, keyProvider)
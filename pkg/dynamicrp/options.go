@@ -34,6 +34,8 @@ import (
 	"github.com/radius-project/radius/pkg/recipes/configloader"
 	"github.com/radius-project/radius/pkg/recipes/driver"
 	"github.com/radius-project/radius/pkg/recipes/driver/bicep"
+	"github.com/radius-project/radius/pkg/recipes/driver/helm"
+	"github.com/radius-project/radius/pkg/recipes/driver/kubernetes"
 	"github.com/radius-project/radius/pkg/recipes/driver/terraform"
 	"github.com/radius-project/radius/pkg/recipes/engine"
 	"github.com/radius-project/radius/pkg/sdk"
@@ -77,8 +79,8 @@ type RecipeOptions struct {
 	// ConfigurationLoader is the loader for recipe configurations.
 	ConfigurationLoader configloader.ConfigurationLoader
 
-	// Drivers is a map of recipe driver names to driver constructors. If nil, the default drivers are used (Bicep, Terraform) will
-	// be used.
+	// Drivers is a map of recipe driver names to driver constructors. If nil, the default drivers (Bicep, Terraform, Helm and
+	// Kubernetes) are used.
 	Drivers map[string]func(options *Options) (driver.Driver, error)
 
 	// SecretsLoader provides access to secrets for recipes.
@@ -134,15 +136,19 @@ func (o *Options) RecipeEngine() (engine.Engine, error) {
 	var errs error
 	drivers := map[string]driver.Driver{}
 
-	// Use the default drivers if not otherwise specified.
-	if o.Recipes.Drivers == nil {
-		o.Recipes.Drivers = map[string]func(options *Options) (driver.Driver, error){
-			recipes.TemplateKindBicep:     bicepDriver,
-			recipes.TemplateKindTerraform: terraformDriver,
+	// Use the default drivers if not otherwise specified. The options are not updated, because several services
+	// create an engine.
+	constructors := o.Recipes.Drivers
+	if constructors == nil {
+		constructors = map[string]func(options *Options) (driver.Driver, error){
+			recipes.TemplateKindBicep:      bicepDriver,
+			recipes.TemplateKindTerraform:  terraformDriver,
+			recipes.TemplateKindHelm:       helmDriver,
+			recipes.TemplateKindKubernetes: kubernetesDriver,
 		}
 	}
 
-	for name, driverConstructor := range o.Recipes.Drivers {
+	for name, driverConstructor := range constructors {
 		driver, err := driverConstructor(o)
 		if err != nil {
 			errs = errors.Join(errs, err)
@@ -172,44 +178,104 @@ func bicepDriver(options *Options) (driver.Driver, error) {
 		return nil, err
 	}
 
-	provider, err := sdk_cred.NewAzureCredentialProvider(options.SecretProvider, options.UCP, &aztoken.AnonymousCredential{})
+	resourceClient, err := newResourceClient(options)
 	if err != nil {
 		return nil, err
 	}
 
-	armConfig, err := armauth.NewArmConfig(&armauth.Options{CredentialProvider: provider})
+	deleteRetryCount, deleteRetryDelaySeconds, err := deleteRetrySettings(options)
 	if err != nil {
 		return nil, err
 	}
 
-	resourceClient := processors.NewResourceClient(armConfig, options.UCP, options.KubernetesProvider)
+	return bicep.NewBicepDriver(
+		sdk.NewClientOptions(options.UCP),
+		deploymentEngineClient,
+		resourceClient,
+		bicep.BicepOptions{
+			DeleteRetryCount:        deleteRetryCount,
+			DeleteRetryDelaySeconds: deleteRetryDelaySeconds,
+		}), nil
+}
 
-	bicepDeleteRetryCount, err := strconv.Atoi(options.Config.Bicep.DeleteRetryCount)
+func helmDriver(options *Options) (driver.Driver, error) {
+	resourceClient, err := newResourceClient(options)
 	if err != nil {
 		return nil, err
 	}
 
-	bicepDeleteRetryDeleteSeconds, err := strconv.Atoi(options.Config.Bicep.DeleteRetryDelaySeconds)
+	deleteRetryCount, deleteRetryDelaySeconds, err := deleteRetrySettings(options)
 	if err != nil {
 		return nil, err
 	}
 
-	return bicep.NewBicepDriver(
-		sdk.NewClientOptions(options.UCP),
-		deploymentEngineClient,
-		resourceClient,
-		bicep.BicepOptions{
-			DeleteRetryCount:        bicepDeleteRetryCount,
-			DeleteRetryDelaySeconds: bicepDeleteRetryDeleteSeconds,
-		}), nil
+	return helm.NewHelmDriver(resourceClient, helm.HelmOptions{
+		DeleteRetryCount:        deleteRetryCount,
+		DeleteRetryDelaySeconds: deleteRetryDelaySeconds,
+	}), nil
+}
+
+func kubernetesDriver(options *Options) (driver.Driver, error) {
+	resourceClient, err := newResourceClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteRetryCount, deleteRetryDelaySeconds, err := deleteRetrySettings(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewKubernetesDriver(resourceClient, kubernetes.KubernetesOptions{
+		DeleteRetryCount:        deleteRetryCount,
+		DeleteRetryDelaySeconds: deleteRetryDelaySeconds,
+	}), nil
+}
+
+// deleteRetrySettings returns the number of retries and the delay in seconds between two attempts to delete an
+// output resource. They are configured in the Bicep settings, and are used by every driver that deletes output
+// resources with driver.DeleteOutputResources.
+func deleteRetrySettings(options *Options) (int, int, error) {
+	deleteRetryCount, err := strconv.Atoi(options.Config.Bicep.DeleteRetryCount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	deleteRetryDelaySeconds, err := strconv.Atoi(options.Config.Bicep.DeleteRetryDelaySeconds)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return deleteRetryCount, deleteRetryDelaySeconds, nil
+}
+
+// newResourceClient creates the client used by recipe drivers to delete output resources.
+func newResourceClient(options *Options) (processors.ResourceClient, error) {
+	provider, err := sdk_cred.NewAzureCredentialProvider(options.SecretProvider, options.UCP, &aztoken.AnonymousCredential{})
+	if err != nil {
+		return nil, err
+	}
+
+	armConfig, err := armauth.NewArmConfig(&armauth.Options{CredentialProvider: provider})
+	if err != nil {
+		return nil, err
+	}
+
+	return processors.NewResourceClient(armConfig, options.UCP, options.KubernetesProvider), nil
 }
 
 func terraformDriver(options *Options) (driver.Driver, error) {
+	postgreSQLURL, err := databaseprovider.PostgreSQLURL(options.Config.Database)
+	if err != nil {
+		return nil, err
+	}
+
 	return terraform.NewTerraformDriver(
 		options.UCP,
 		options.SecretProvider,
 		terraform.TerraformOptions{
-			Path:     options.Config.Terraform.Path,
-			LogLevel: options.Config.Terraform.LogLevel,
+			Path:          options.Config.Terraform.Path,
+			LogLevel:      options.Config.Terraform.LogLevel,
+			PostgreSQLURL: postgreSQLURL,
 		}, *options.KubernetesProvider), nil
 }
@@ -23,6 +23,8 @@ import (
 	"github.com/radius-project/radius/pkg/components/trace/traceservice"
 	"github.com/radius-project/radius/pkg/dynamicrp"
 	"github.com/radius-project/radius/pkg/dynamicrp/backend"
+	"github.com/radius-project/radius/pkg/dynamicrp/backend/keyrotation"
+	"github.com/radius-project/radius/pkg/dynamicrp/backend/recipedrift"
 	"github.com/radius-project/radius/pkg/dynamicrp/frontend"
 )
 
@@ -47,6 +49,8 @@ func NewServer(options *dynamicrp.Options) (*hosting.Host, error) {
 
 	services = append(services, frontend.NewService(options))
 	services = append(services, backend.NewService(options))
+	services = append(services, keyrotation.NewService(options))
+	services = append(services, recipedrift.NewService(options))
 
 	return &hosting.Host{
 		Services: services,
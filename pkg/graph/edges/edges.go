@@ -146,6 +146,73 @@ func MergeDependencyEdges(
 	}
 }
 
+// Edge is a directed relationship between two resources of an
+// application graph. Edges always point from the dependent resource
+// (Source) to the resource it depends on (Target), whichever side of
+// the relationship declared it.
+type Edge struct {
+	Source string                                `json:"source"`
+	Target string                                `json:"target"`
+	Kind   corerpv20250801preview.ConnectionKind `json:"kind"`
+}
+
+// ListEdges flattens the Connections of every resource in graph into
+// a list of Edges. An outbound entry on R to T yields R -> T and an
+// inbound entry on R from S yields S -> R, so the mirrored pair that
+// MergeDependencyEdges and the graph builders emit collapses into a
+// single Edge. Pairs are compared case-insensitively; the first casing
+// seen is kept. Entries without an ID, Direction or Kind are skipped.
+//
+// The result is sorted by (Source, Target, Kind) so two graphs can be
+// compared edge by edge. ListEdges returns an empty slice when graph
+// is nil.
+func ListEdges(graph *corerpv20250801preview.ApplicationGraphResponse) []Edge {
+	result := []Edge{}
+	if graph == nil {
+		return result
+	}
+
+	seen := map[string]struct{}{}
+	for _, r := range graph.Resources {
+		if r == nil || r.ID == nil {
+			continue
+		}
+		for _, c := range r.Connections {
+			if c == nil || c.ID == nil || c.Direction == nil || c.Kind == nil {
+				continue
+			}
+
+			edge := Edge{Source: *r.ID, Target: *c.ID, Kind: *c.Kind}
+			switch *c.Direction {
+			case corerpv20250801preview.DirectionOutbound:
+			case corerpv20250801preview.DirectionInbound:
+				edge.Source, edge.Target = *c.ID, *r.ID
+			default:
+				continue
+			}
+
+			key := strings.ToLower(edge.Source) + "|" + strings.ToLower(edge.Target) + "|" + string(edge.Kind)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			result = append(result, edge)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if as, bs := strings.ToLower(a.Source), strings.ToLower(b.Source); as != bs {
+			return as < bs
+		}
+		if at, bt := strings.ToLower(a.Target), strings.ToLower(b.Target); at != bt {
+			return at < bt
+		}
+		return a.Kind < b.Kind
+	})
+	return result
+}
+
 // hasOutbound reports whether conns contains any outbound edge whose
 // ID matches targetID case-insensitively.
 func hasOutbound(conns []*corerpv20250801preview.ApplicationGraphConnection, targetID string) bool {
@@ -17,6 +17,7 @@ limitations under the License.
 package edges
 
 import (
+	"strings"
 	"testing"
 
 	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
@@ -274,3 +275,31 @@ func TestMergeDependencyEdges_MalformedInputEntriesAreSkipped(t *testing.T) {
 	require.Len(t, consumer.Connections, 1, "only the valid entry must be emitted")
 	require.Equal(t, corerpv20250801preview.ConnectionKindDependency, *consumer.Connections[0].Kind)
 }
+
+func TestListEdges(t *testing.T) {
+	require.Empty(t, ListEdges(nil))
+
+	inbound := func(source string, kind corerpv20250801preview.ConnectionKind) *corerpv20250801preview.ApplicationGraphConnection {
+		return &corerpv20250801preview.ApplicationGraphConnection{
+			ID:        to.Ptr(source),
+			Direction: to.Ptr(corerpv20250801preview.DirectionInbound),
+			Kind:      to.Ptr(kind),
+		}
+	}
+
+	graph := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			resource(consumerID, containerType, connOut(rabbitmqID), dep(appsecretID), nil, &corerpv20250801preview.ApplicationGraphConnection{ID: to.Ptr(appsecretID)}),
+			// The mirrored inbound entry uses different casing and must collapse into the outbound one.
+			resource(rabbitmqID, queueType, inbound(strings.ToUpper(consumerID), corerpv20250801preview.ConnectionKindConnection)),
+			// An inbound-only declaration still yields a consumer -> secret edge.
+			resource(appsecretID, secretType, inbound(consumerID, corerpv20250801preview.ConnectionKindConnection)),
+		},
+	}
+
+	require.Equal(t, []Edge{
+		{Source: consumerID, Target: rabbitmqID, Kind: corerpv20250801preview.ConnectionKindConnection},
+		{Source: consumerID, Target: appsecretID, Kind: corerpv20250801preview.ConnectionKindConnection},
+		{Source: consumerID, Target: appsecretID, Kind: corerpv20250801preview.ConnectionKindDependency},
+	}, ListEdges(graph))
+}
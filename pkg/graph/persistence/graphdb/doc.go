@@ -14,10 +14,23 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package graphdb is a placeholder for a future persistence.Store
-// implementation backed by a graph database (e.g. Neo4j, JanusGraph,
-// Azure Cosmos DB Gremlin).
+// Package graphdb provides a persistence.Store that keeps application graphs
+// in a graph database instead of as JSON documents.
 //
-// Implementations in this package must satisfy persistence.Store so that
-// callers can switch backends without code changes.
+// Every resource of an ApplicationGraphResponse is stored as a vertex labeled
+// with its resource type, and every connection as an edge labeled with its
+// kind. Edges always point from the dependent resource to the resource it
+// depends on, whichever side declared the connection, and the side that
+// declared it is kept as an edge property so Load returns the graph exactly as
+// it was saved.
+//
+// Because edges are indexed by their target across every saved graph, Store
+// also implements persistence.DependencyQuerier: "every application that
+// depends on resource X" is answered by walking edges backwards from X rather
+// than by loading each graph.
+//
+// Storage is delegated to an Engine. MemoryEngine is an in-process engine for
+// tests and single-process tools; adapters for external graph databases (for
+// example Neo4j, JanusGraph or Azure Cosmos DB Gremlin) implement the same
+// interface so callers can switch backends without code changes.
 package graphdb
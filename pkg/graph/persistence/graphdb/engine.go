@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphdb
+
+import (
+	"context"
+
+	"github.com/radius-project/radius/pkg/graph/persistence"
+)
+
+// Vertex is a node of a stored graph. Every resource of an ApplicationGraphResponse is a vertex.
+type Vertex struct {
+	// ID is the resource ID of the vertex. It is unique within a graph, compared case-insensitively.
+	ID string
+
+	// Label is the resource type of the vertex.
+	Label string
+
+	// Properties are the attributes of the vertex. Values are JSON-compatible: strings, numbers,
+	// booleans, nil, []any and map[string]any.
+	Properties map[string]any
+}
+
+// Edge is a directed relationship between two vertices of a stored graph. Edges always point
+// from the dependent resource to the resource it depends on, whichever side declared it.
+type Edge struct {
+	// From is the resource ID of the dependent resource.
+	From string
+
+	// To is the resource ID of the resource that From depends on. It may name a resource that is
+	// not a vertex of the graph.
+	To string
+
+	// Label is the kind of the edge, for example "Connection" or "Dependency".
+	Label string
+
+	// Properties are the attributes of the edge. Values are JSON-compatible.
+	Properties map[string]any
+}
+
+// Graph is the content of a stored graph.
+type Graph struct {
+	// Properties are the attributes of the graph itself. Values are JSON-compatible.
+	Properties map[string]any
+
+	// Vertices are the vertices of the graph, in insertion order.
+	Vertices []Vertex
+
+	// Edges are the edges of the graph, in insertion order.
+	Edges []Edge
+}
+
+// KeyedEdge is an edge together with the key of the graph that holds it.
+type KeyedEdge struct {
+	// Key identifies the graph that holds the edge.
+	Key persistence.Key
+
+	// Edge is the edge.
+	Edge Edge
+}
+
+// Engine is the graph database used by Store. It holds any number of graphs, each identified by
+// a persistence.Key, and indexes edges by their target so dependency queries do not need to load
+// every graph.
+//
+// Implementations must be safe for concurrent use by multiple goroutines.
+type Engine interface {
+	// PutGraph atomically replaces the graph stored under key.
+	PutGraph(ctx context.Context, key persistence.Key, graph *Graph) error
+
+	// GetGraph returns the graph stored under key, or persistence.ErrNotFound.
+	GetGraph(ctx context.Context, key persistence.Key) (*Graph, error)
+
+	// ListGraphs returns the keys of the stored graphs whose Namespace matches namespace, sorted by
+	// namespace and name. An empty namespace lists every graph.
+	ListGraphs(ctx context.Context, namespace string) ([]persistence.Key, error)
+
+	// DeleteGraph removes the graph stored under key, or returns persistence.ErrNotFound.
+	DeleteGraph(ctx context.Context, key persistence.Key) error
+
+	// InEdges returns the edges, across every stored graph, whose To matches vertexID
+	// case-insensitively. The result is sorted by graph key and keeps the insertion order of the
+	// edges within a graph.
+	InEdges(ctx context.Context, vertexID string) ([]KeyedEdge, error)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphdb
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/radius-project/radius/pkg/graph/persistence"
+)
+
+// MemoryEngine is an in-process Engine. It keeps every graph in memory with an index of edges by
+// target vertex. It is intended for tests, single-process tools and as the reference behavior of
+// the Engine contract.
+type MemoryEngine struct {
+	mutex sync.RWMutex
+
+	// graphs holds a private copy of every stored graph.
+	graphs map[persistence.Key]*Graph
+
+	// inEdges maps a lowercased target vertex ID to the graphs holding edges to it.
+	inEdges map[string]map[persistence.Key][]Edge
+}
+
+// NewMemoryEngine creates an empty MemoryEngine.
+func NewMemoryEngine() *MemoryEngine {
+	return &MemoryEngine{
+		graphs:  map[persistence.Key]*Graph{},
+		inEdges: map[string]map[persistence.Key][]Edge{},
+	}
+}
+
+// PutGraph atomically replaces the graph stored under key.
+func (e *MemoryEngine) PutGraph(ctx context.Context, key persistence.Key, graph *Graph) error {
+	stored := copyGraph(graph)
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.removeLocked(key)
+	e.graphs[key] = stored
+	for _, edge := range stored.Edges {
+		target := strings.ToLower(edge.To)
+		if e.inEdges[target] == nil {
+			e.inEdges[target] = map[persistence.Key][]Edge{}
+		}
+		e.inEdges[target][key] = append(e.inEdges[target][key], edge)
+	}
+
+	return nil
+}
+
+// GetGraph returns the graph stored under key, or persistence.ErrNotFound.
+func (e *MemoryEngine) GetGraph(ctx context.Context, key persistence.Key) (*Graph, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	graph, ok := e.graphs[key]
+	if !ok {
+		return nil, persistence.ErrNotFound
+	}
+	return copyGraph(graph), nil
+}
+
+// ListGraphs returns the keys of the stored graphs in namespace, or of every graph when namespace is empty.
+func (e *MemoryEngine) ListGraphs(ctx context.Context, namespace string) ([]persistence.Key, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	keys := []persistence.Key{}
+	for key := range e.graphs {
+		if namespace == "" || key.Namespace == namespace {
+			keys = append(keys, key)
+		}
+	}
+	sortKeys(keys)
+	return keys, nil
+}
+
+// DeleteGraph removes the graph stored under key, or returns persistence.ErrNotFound.
+func (e *MemoryEngine) DeleteGraph(ctx context.Context, key persistence.Key) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if _, ok := e.graphs[key]; !ok {
+		return persistence.ErrNotFound
+	}
+	e.removeLocked(key)
+	return nil
+}
+
+// InEdges returns the edges across every stored graph that point to vertexID.
+func (e *MemoryEngine) InEdges(ctx context.Context, vertexID string) ([]KeyedEdge, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	byKey := e.inEdges[strings.ToLower(vertexID)]
+	keys := make([]persistence.Key, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sortKeys(keys)
+
+	result := []KeyedEdge{}
+	for _, key := range keys {
+		for _, edge := range byKey[key] {
+			result = append(result, KeyedEdge{Key: key, Edge: copyEdge(edge)})
+		}
+	}
+	return result, nil
+}
+
+// removeLocked removes the graph stored under key and its index entries. The caller must hold
+// the write lock.
+func (e *MemoryEngine) removeLocked(key persistence.Key) {
+	graph, ok := e.graphs[key]
+	if !ok {
+		return
+	}
+
+	for _, edge := range graph.Edges {
+		target := strings.ToLower(edge.To)
+		delete(e.inEdges[target], key)
+		if len(e.inEdges[target]) == 0 {
+			delete(e.inEdges, target)
+		}
+	}
+	delete(e.graphs, key)
+}
+
+func sortKeys(keys []persistence.Key) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Namespace != keys[j].Namespace {
+			return keys[i].Namespace < keys[j].Namespace
+		}
+		return keys[i].Name < keys[j].Name
+	})
+}
+
+func copyGraph(graph *Graph) *Graph {
+	result := &Graph{
+		Properties: copyProperties(graph.Properties),
+		Vertices:   make([]Vertex, 0, len(graph.Vertices)),
+		Edges:      make([]Edge, 0, len(graph.Edges)),
+	}
+	for _, vertex := range graph.Vertices {
+		result.Vertices = append(result.Vertices, Vertex{ID: vertex.ID, Label: vertex.Label, Properties: copyProperties(vertex.Properties)})
+	}
+	for _, edge := range graph.Edges {
+		result.Edges = append(result.Edges, copyEdge(edge))
+	}
+	return result
+}
+
+func copyEdge(edge Edge) Edge {
+	return Edge{From: edge.From, To: edge.To, Label: edge.Label, Properties: copyProperties(edge.Properties)}
+}
+
+func copyProperties(properties map[string]any) map[string]any {
+	if properties == nil {
+		return nil
+	}
+	return copyValue(properties).(map[string]any)
+}
+
+// copyValue deep-copies a JSON-compatible value.
+func copyValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = copyValue(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = copyValue(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// Compile-time check that *MemoryEngine satisfies Engine.
+var _ Engine = (*MemoryEngine)(nil)
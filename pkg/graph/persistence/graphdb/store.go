@@ -0,0 +1,391 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/graph/persistence"
+	"github.com/radius-project/radius/pkg/to"
+)
+
+const (
+	// Graph properties.
+	propertyIcons   = "icons"
+	propertyLabels  = "labels"
+	propertyMessage = "message"
+
+	// Vertex properties.
+	propertyName              = "name"
+	propertyProvisioningState = "provisioningState"
+	propertyDiffHash          = "diffHash"
+	propertyIconHash          = "iconHash"
+	propertyProperties        = "properties"
+	propertyOutputResources   = "outputResources"
+
+	// propertyDirection is the edge property recording which side declared the connection. An
+	// "Outbound" edge was declared by From, an "Inbound" edge by To.
+	propertyDirection = "direction"
+)
+
+// Store is a persistence.Store that keeps each ApplicationGraphResponse in a graph database:
+// resources become vertices and connections become edges. It also implements
+// persistence.DependencyQuerier.
+//
+// Concurrency: the persistence.Store contract requires implementations to be safe for concurrent
+// use. Every operation maps to a single Engine call, except Dependents, which traverses the
+// graphs one step at a time and may observe concurrent saves between steps.
+type Store struct {
+	engine Engine
+}
+
+// NewStore returns a Store backed by engine. If engine is nil, an in-process MemoryEngine is used.
+func NewStore(engine Engine) *Store {
+	if engine == nil {
+		engine = NewMemoryEngine()
+	}
+	return &Store{engine: engine}
+}
+
+// Save replaces the graph stored under key with graph.
+func (s *Store) Save(ctx context.Context, key persistence.Key, graph *corerpv20250801preview.ApplicationGraphResponse, opts persistence.SaveOptions) error {
+	if graph == nil {
+		return errors.New("graphdb: nil graph")
+	}
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	stored, err := toGraph(graph, opts)
+	if err != nil {
+		return err
+	}
+	return s.engine.PutGraph(ctx, key, stored)
+}
+
+// Load returns the graph previously stored under key, or persistence.ErrNotFound.
+func (s *Store) Load(ctx context.Context, key persistence.Key) (*corerpv20250801preview.ApplicationGraphResponse, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.engine.GetGraph(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return fromGraph(stored)
+}
+
+// List returns the keys stored under namespace. An empty namespace lists every key.
+func (s *Store) List(ctx context.Context, namespace string) ([]persistence.Key, error) {
+	return s.engine.ListGraphs(ctx, namespace)
+}
+
+// Delete removes the graph stored under key, or returns persistence.ErrNotFound.
+func (s *Store) Delete(ctx context.Context, key persistence.Key) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return s.engine.DeleteGraph(ctx, key)
+}
+
+// Dependents returns every stored graph holding a resource that depends on resourceID, directly
+// or transitively. It walks the edges backwards from resourceID without loading whole graphs.
+func (s *Store) Dependents(ctx context.Context, resourceID string) ([]persistence.Dependent, error) {
+	if resourceID == "" {
+		return nil, errors.New("graphdb: resource ID must not be empty")
+	}
+
+	type node struct {
+		key persistence.Key
+		id  string
+	}
+
+	found := map[persistence.Key]map[string]string{}
+	queue := []node{}
+	visit := func(key persistence.Key, id string) {
+		lower := strings.ToLower(id)
+		if lower == strings.ToLower(resourceID) {
+			return
+		}
+		if found[key] == nil {
+			found[key] = map[string]string{}
+		}
+		if _, ok := found[key][lower]; ok {
+			return
+		}
+		found[key][lower] = id
+		queue = append(queue, node{key: key, id: id})
+	}
+
+	edges, err := s.engine.InEdges(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, edge := range edges {
+		visit(edge.Key, edge.Edge.From)
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		edges, err := s.engine.InEdges(ctx, current.id)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range edges {
+			// Dependencies are only followed within the graph they were found in.
+			if edge.Key == current.key {
+				visit(edge.Key, edge.Edge.From)
+			}
+		}
+	}
+
+	result := []persistence.Dependent{}
+	for key, resources := range found {
+		dependent := persistence.Dependent{Key: key}
+		lowered := make([]string, 0, len(resources))
+		for lower := range resources {
+			lowered = append(lowered, lower)
+		}
+		sort.Strings(lowered)
+		for _, lower := range lowered {
+			dependent.Resources = append(dependent.Resources, resources[lower])
+		}
+		result = append(result, dependent)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Key.Namespace != result[j].Key.Namespace {
+			return result[i].Key.Namespace < result[j].Key.Namespace
+		}
+		return result[i].Key.Name < result[j].Key.Name
+	})
+	return result, nil
+}
+
+// validateKey rejects keys with an empty namespace or name.
+func validateKey(key persistence.Key) error {
+	if key.Namespace == "" {
+		return errors.New("graphdb: key namespace must not be empty")
+	}
+	if key.Name == "" {
+		return errors.New("graphdb: key name must not be empty")
+	}
+	return nil
+}
+
+// toGraph converts an ApplicationGraphResponse into vertices and edges. Each connection becomes
+// an edge from the dependent resource to its dependency, whichever resource declared it.
+func toGraph(response *corerpv20250801preview.ApplicationGraphResponse, opts persistence.SaveOptions) (*Graph, error) {
+	properties := map[string]any{}
+	if response.Icons != nil {
+		icons, err := toGeneric(response.Icons)
+		if err != nil {
+			return nil, err
+		}
+		properties[propertyIcons] = icons
+	}
+	if len(opts.Labels) > 0 {
+		labels := map[string]any{}
+		for k, v := range opts.Labels {
+			labels[k] = v
+		}
+		properties[propertyLabels] = labels
+	}
+	if opts.Message != "" {
+		properties[propertyMessage] = opts.Message
+	}
+
+	graph := &Graph{Properties: properties}
+	seen := map[string]bool{}
+	for _, resource := range response.Resources {
+		if resource == nil {
+			continue
+		}
+		id := to.String(resource.ID)
+		if id == "" {
+			return nil, errors.New("graphdb: graph resource has no ID")
+		}
+		if seen[strings.ToLower(id)] {
+			return nil, fmt.Errorf("graphdb: graph resource %q appears more than once", id)
+		}
+		seen[strings.ToLower(id)] = true
+
+		vertex := Vertex{
+			ID:    id,
+			Label: to.String(resource.Type),
+			Properties: map[string]any{
+				propertyName:              to.String(resource.Name),
+				propertyProvisioningState: to.String(resource.ProvisioningState),
+			},
+		}
+		if resource.DiffHash != nil {
+			vertex.Properties[propertyDiffHash] = *resource.DiffHash
+		}
+		if resource.IconHash != nil {
+			vertex.Properties[propertyIconHash] = *resource.IconHash
+		}
+		if resource.Properties != nil {
+			value, err := toGeneric(resource.Properties)
+			if err != nil {
+				return nil, err
+			}
+			vertex.Properties[propertyProperties] = value
+		}
+		if resource.OutputResources != nil {
+			value, err := toGeneric(resource.OutputResources)
+			if err != nil {
+				return nil, err
+			}
+			vertex.Properties[propertyOutputResources] = value
+		}
+		graph.Vertices = append(graph.Vertices, vertex)
+
+		for _, connection := range resource.Connections {
+			if connection == nil {
+				continue
+			}
+			var kind corerpv20250801preview.ConnectionKind
+			if connection.Kind != nil {
+				kind = *connection.Kind
+			}
+			var direction corerpv20250801preview.Direction
+			if connection.Direction != nil {
+				direction = *connection.Direction
+			}
+
+			edge := Edge{
+				Label:      string(kind),
+				Properties: map[string]any{propertyDirection: string(direction)},
+			}
+			switch direction {
+			case corerpv20250801preview.DirectionOutbound:
+				edge.From, edge.To = id, to.String(connection.ID)
+			case corerpv20250801preview.DirectionInbound:
+				edge.From, edge.To = to.String(connection.ID), id
+			default:
+				return nil, fmt.Errorf("graphdb: connection of %q to %q has unknown direction %q", id, to.String(connection.ID), direction)
+			}
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+
+	return graph, nil
+}
+
+// fromGraph converts stored vertices and edges back into an ApplicationGraphResponse. Each edge
+// is restored as a connection of the resource that declared it.
+func fromGraph(graph *Graph) (*corerpv20250801preview.ApplicationGraphResponse, error) {
+	response := &corerpv20250801preview.ApplicationGraphResponse{Resources: []*corerpv20250801preview.ApplicationGraphResource{}}
+	if icons, ok := graph.Properties[propertyIcons]; ok {
+		if err := fromGeneric(icons, &response.Icons); err != nil {
+			return nil, err
+		}
+	}
+
+	byID := map[string]*corerpv20250801preview.ApplicationGraphResource{}
+	for _, vertex := range graph.Vertices {
+		resource := &corerpv20250801preview.ApplicationGraphResource{
+			ID:                to.Ptr(vertex.ID),
+			Type:              to.Ptr(vertex.Label),
+			Name:              to.Ptr(stringProperty(vertex.Properties, propertyName)),
+			ProvisioningState: to.Ptr(stringProperty(vertex.Properties, propertyProvisioningState)),
+			Connections:       []*corerpv20250801preview.ApplicationGraphConnection{},
+		}
+		if value, ok := vertex.Properties[propertyDiffHash].(string); ok {
+			resource.DiffHash = to.Ptr(value)
+		}
+		if value, ok := vertex.Properties[propertyIconHash].(string); ok {
+			resource.IconHash = to.Ptr(value)
+		}
+		if value, ok := vertex.Properties[propertyProperties]; ok {
+			if err := fromGeneric(value, &resource.Properties); err != nil {
+				return nil, err
+			}
+		}
+		if value, ok := vertex.Properties[propertyOutputResources]; ok {
+			if err := fromGeneric(value, &resource.OutputResources); err != nil {
+				return nil, err
+			}
+		}
+		response.Resources = append(response.Resources, resource)
+		byID[strings.ToLower(vertex.ID)] = resource
+	}
+
+	for _, edge := range graph.Edges {
+		direction := corerpv20250801preview.Direction(stringProperty(edge.Properties, propertyDirection))
+		owner, other := edge.From, edge.To
+		if direction == corerpv20250801preview.DirectionInbound {
+			owner, other = edge.To, edge.From
+		}
+
+		resource, ok := byID[strings.ToLower(owner)]
+		if !ok {
+			return nil, fmt.Errorf("graphdb: edge declared by %q, which is not a vertex of the graph", owner)
+		}
+		resource.Connections = append(resource.Connections, &corerpv20250801preview.ApplicationGraphConnection{
+			ID:        to.Ptr(other),
+			Direction: to.Ptr(direction),
+			Kind:      to.Ptr(corerpv20250801preview.ConnectionKind(edge.Label)),
+		})
+	}
+
+	return response, nil
+}
+
+func stringProperty(properties map[string]any, name string) string {
+	value, _ := properties[name].(string)
+	return value
+}
+
+// toGeneric converts a value into its JSON-compatible form (map[string]any, []any and scalars).
+func toGeneric(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("graphdb: marshal graph: %w", err)
+	}
+	var result any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("graphdb: marshal graph: %w", err)
+	}
+	return result, nil
+}
+
+// fromGeneric converts a JSON-compatible value back into out.
+func fromGeneric(value any, out any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("graphdb: unmarshal graph: %w", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("graphdb: unmarshal graph: %w", err)
+	}
+	return nil
+}
+
+// Compile-time checks that *Store satisfies persistence.Store and persistence.DependencyQuerier.
+var (
+	_ persistence.Store             = (*Store)(nil)
+	_ persistence.DependencyQuerier = (*Store)(nil)
+)
@@ -0,0 +1,218 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphdb
+
+import (
+	"encoding/json"
+	"path"
+	"testing"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/graph/persistence"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	frontendID = "/planes/radius/local/resourceGroups/default/providers/Radius.Compute/containers/frontend"
+	backendID  = "/planes/radius/local/resourceGroups/default/providers/Radius.Compute/containers/backend"
+	databaseID = "/planes/radius/local/resourceGroups/default/providers/Radius.Data/postgreSqlDatabases/db"
+	cacheID    = "/planes/radius/local/resourceGroups/default/providers/Radius.Data/redisCaches/cache"
+)
+
+func connection(id string, direction corerpv20250801preview.Direction, kind corerpv20250801preview.ConnectionKind) *corerpv20250801preview.ApplicationGraphConnection {
+	return &corerpv20250801preview.ApplicationGraphConnection{ID: to.Ptr(id), Direction: to.Ptr(direction), Kind: to.Ptr(kind)}
+}
+
+func resource(id, resourceType string, connections ...*corerpv20250801preview.ApplicationGraphConnection) *corerpv20250801preview.ApplicationGraphResource {
+	if connections == nil {
+		connections = []*corerpv20250801preview.ApplicationGraphConnection{}
+	}
+	return &corerpv20250801preview.ApplicationGraphResource{
+		ID:                to.Ptr(id),
+		Name:              to.Ptr(path.Base(id)),
+		Type:              to.Ptr(resourceType),
+		ProvisioningState: to.Ptr("Succeeded"),
+		Connections:       connections,
+		OutputResources:   []*corerpv20250801preview.ApplicationGraphOutputResource{},
+	}
+}
+
+// sampleGraph returns frontend -> backend -> database, with the backend -> database edge
+// declared as an inbound connection on the database.
+func sampleGraph() *corerpv20250801preview.ApplicationGraphResponse {
+	frontend := resource(frontendID, "Radius.Compute/containers", connection(backendID, corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindConnection))
+	frontend.DiffHash = to.Ptr("sha256:abc")
+	frontend.IconHash = to.Ptr("icon")
+	frontend.Properties = map[string]any{"image": "frontend:latest", "ports": map[string]any{"web": map[string]any{"containerPort": float64(80)}}}
+	frontend.OutputResources = []*corerpv20250801preview.ApplicationGraphOutputResource{
+		{ID: to.Ptr("/planes/kubernetes/local/namespaces/default/providers/apps/Deployment/frontend"), Name: to.Ptr("frontend"), Type: to.Ptr("apps/Deployment")},
+	}
+
+	return &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			frontend,
+			resource(backendID, "Radius.Compute/containers",
+				connection(frontendID, corerpv20250801preview.DirectionInbound, corerpv20250801preview.ConnectionKindConnection)),
+			resource(databaseID, "Radius.Data/postgreSqlDatabases",
+				connection(backendID, corerpv20250801preview.DirectionInbound, corerpv20250801preview.ConnectionKindDependency)),
+		},
+		Icons: map[string]*string{"icon": to.Ptr("<svg/>")},
+	}
+}
+
+func requireSameGraph(t *testing.T, expected, actual *corerpv20250801preview.ApplicationGraphResponse) {
+	t.Helper()
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
+
+func TestStore_SaveLoadRoundTrip(t *testing.T) {
+	ctx := t.Context()
+	store := NewStore(nil)
+	key := persistence.Key{Namespace: "main", Name: "app"}
+
+	graph := sampleGraph()
+	require.NoError(t, store.Save(ctx, key, graph, persistence.SaveOptions{Message: "save", Labels: map[string]string{"branch": "main"}}))
+
+	loaded, err := store.Load(ctx, key)
+	require.NoError(t, err)
+	requireSameGraph(t, graph, loaded)
+
+	// Mutating the caller's graph must not change the stored one.
+	graph.Resources[0].Properties["image"] = "changed"
+	loaded, err = store.Load(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, "frontend:latest", loaded.Resources[0].Properties["image"])
+}
+
+func TestStore_LoadMissing(t *testing.T) {
+	_, err := NewStore(nil).Load(t.Context(), persistence.Key{Namespace: "main", Name: "missing"})
+	require.ErrorIs(t, err, persistence.ErrNotFound)
+}
+
+func TestStore_ListAndDelete(t *testing.T) {
+	ctx := t.Context()
+	store := NewStore(nil)
+	keys := []persistence.Key{
+		{Namespace: "pr-1", Name: "app"},
+		{Namespace: "main", Name: "web"},
+		{Namespace: "main", Name: "app"},
+	}
+	for _, key := range keys {
+		require.NoError(t, store.Save(ctx, key, sampleGraph(), persistence.SaveOptions{}))
+	}
+
+	all, err := store.List(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, []persistence.Key{{Namespace: "main", Name: "app"}, {Namespace: "main", Name: "web"}, {Namespace: "pr-1", Name: "app"}}, all)
+
+	main, err := store.List(ctx, "main")
+	require.NoError(t, err)
+	require.Equal(t, []persistence.Key{{Namespace: "main", Name: "app"}, {Namespace: "main", Name: "web"}}, main)
+
+	require.NoError(t, store.Delete(ctx, persistence.Key{Namespace: "main", Name: "app"}))
+	require.ErrorIs(t, store.Delete(ctx, persistence.Key{Namespace: "main", Name: "app"}), persistence.ErrNotFound)
+
+	main, err = store.List(ctx, "main")
+	require.NoError(t, err)
+	require.Equal(t, []persistence.Key{{Namespace: "main", Name: "web"}}, main)
+}
+
+func TestStore_InvalidInput(t *testing.T) {
+	ctx := t.Context()
+	store := NewStore(nil)
+
+	require.ErrorContains(t, store.Save(ctx, persistence.Key{Name: "app"}, sampleGraph(), persistence.SaveOptions{}), "namespace must not be empty")
+	require.ErrorContains(t, store.Save(ctx, persistence.Key{Namespace: "main"}, sampleGraph(), persistence.SaveOptions{}), "name must not be empty")
+	require.ErrorContains(t, store.Save(ctx, persistence.Key{Namespace: "main", Name: "app"}, nil, persistence.SaveOptions{}), "nil graph")
+
+	duplicate := sampleGraph()
+	duplicate.Resources = append(duplicate.Resources, resource(frontendID, "Radius.Compute/containers"))
+	require.ErrorContains(t, store.Save(ctx, persistence.Key{Namespace: "main", Name: "app"}, duplicate, persistence.SaveOptions{}), "appears more than once")
+
+	noDirection := sampleGraph()
+	noDirection.Resources[0].Connections[0].Direction = nil
+	require.ErrorContains(t, store.Save(ctx, persistence.Key{Namespace: "main", Name: "app"}, noDirection, persistence.SaveOptions{}), "unknown direction")
+}
+
+func TestStore_Dependents(t *testing.T) {
+	ctx := t.Context()
+	store := NewStore(nil)
+
+	require.NoError(t, store.Save(ctx, persistence.Key{Namespace: "main", Name: "shop"}, sampleGraph(), persistence.SaveOptions{}))
+
+	// A second application shares the database, and uses a different casing for its ID.
+	reports := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			resource(cacheID, "Radius.Data/redisCaches"),
+			resource(frontendID+"-reports", "Radius.Compute/containers",
+				connection(databaseID, corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindConnection)),
+		},
+	}
+	reports.Resources[1].Connections[0].ID = to.Ptr("/planes/radius/local/resourcegroups/default/providers/Radius.Data/postgreSqlDatabases/db")
+	require.NoError(t, store.Save(ctx, persistence.Key{Namespace: "main", Name: "reports"}, reports, persistence.SaveOptions{}))
+
+	dependents, err := store.Dependents(ctx, databaseID)
+	require.NoError(t, err)
+	require.Equal(t, []persistence.Dependent{
+		{Key: persistence.Key{Namespace: "main", Name: "reports"}, Resources: []string{frontendID + "-reports"}},
+		{Key: persistence.Key{Namespace: "main", Name: "shop"}, Resources: []string{backendID, frontendID}},
+	}, dependents, "dependents are transitive, across graphs, and matched case-insensitively")
+
+	dependents, err = store.Dependents(ctx, frontendID)
+	require.NoError(t, err)
+	require.Empty(t, dependents, "nothing depends on the frontend")
+
+	dependents, err = store.Dependents(ctx, cacheID)
+	require.NoError(t, err)
+	require.Empty(t, dependents)
+
+	// Replacing a graph replaces its edges.
+	require.NoError(t, store.Save(ctx, persistence.Key{Namespace: "main", Name: "reports"}, &corerpv20250801preview.ApplicationGraphResponse{}, persistence.SaveOptions{}))
+	dependents, err = store.Dependents(ctx, databaseID)
+	require.NoError(t, err)
+	require.Len(t, dependents, 1)
+
+	require.NoError(t, store.Delete(ctx, persistence.Key{Namespace: "main", Name: "shop"}))
+	dependents, err = store.Dependents(ctx, databaseID)
+	require.NoError(t, err)
+	require.Empty(t, dependents)
+}
+
+func TestStore_DependentsDoesNotCrossGraphs(t *testing.T) {
+	ctx := t.Context()
+	store := NewStore(nil)
+
+	// In "a" the backend depends on the database; in "b" the frontend depends on the backend.
+	// The frontend must not be reported as depending on the database through graph "b".
+	a := &corerpv20250801preview.ApplicationGraphResponse{Resources: []*corerpv20250801preview.ApplicationGraphResource{
+		resource(backendID, "Radius.Compute/containers", connection(databaseID, corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindConnection)),
+	}}
+	b := &corerpv20250801preview.ApplicationGraphResponse{Resources: []*corerpv20250801preview.ApplicationGraphResource{
+		resource(frontendID, "Radius.Compute/containers", connection(backendID, corerpv20250801preview.DirectionOutbound, corerpv20250801preview.ConnectionKindConnection)),
+	}}
+	require.NoError(t, store.Save(ctx, persistence.Key{Namespace: "main", Name: "a"}, a, persistence.SaveOptions{}))
+	require.NoError(t, store.Save(ctx, persistence.Key{Namespace: "main", Name: "b"}, b, persistence.SaveOptions{}))
+
+	dependents, err := store.Dependents(ctx, databaseID)
+	require.NoError(t, err)
+	require.Equal(t, []persistence.Dependent{{Key: persistence.Key{Namespace: "main", Name: "a"}, Resources: []string{backendID}}}, dependents)
+}
@@ -34,8 +34,8 @@ var ErrNotFound = errors.New("persistence: not found")
 // The meaning of the fields is backend-specific:
 //   - For the git backend, Namespace maps to a branch prefix and Name to the
 //     file path inside the branch.
-//   - For a future graph DB backend, these fields map to database/collection
-//     identifiers.
+//   - For the graphdb backend, these fields identify a graph within the
+//     graph database.
 type Key struct {
 	// Namespace groups related graphs (e.g. a branch prefix or DB collection).
 	Namespace string
@@ -75,3 +75,28 @@ type Store interface {
 	// must return ErrNotFound.
 	Delete(ctx context.Context, key Key) error
 }
+
+// Dependent describes a persisted graph in which resources depend on a
+// given resource. It is returned by DependencyQuerier.
+type Dependent struct {
+	// Key identifies the graph.
+	Key Key
+
+	// Resources are the IDs of the resources in the graph that depend on the
+	// queried resource, directly or transitively, sorted case-insensitively.
+	Resources []string
+}
+
+// DependencyQuerier is implemented by Stores that can answer dependency
+// queries across every persisted graph without loading each one.
+//
+// DependencyQuerier is optional. Use a type assertion on a Store to check for
+// support.
+type DependencyQuerier interface {
+	// Dependents returns every graph holding a resource that depends on
+	// resourceID, sorted by key. A resource depends on another when it has an
+	// outbound connection to it, or the other resource has an inbound
+	// connection from it, of any kind. Resource IDs are compared
+	// case-insensitively.
+	Dependents(ctx context.Context, resourceID string) ([]Dependent, error)
+}
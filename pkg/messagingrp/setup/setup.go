@@ -27,6 +27,7 @@ import (
 	rmq_ctrl "github.com/radius-project/radius/pkg/messagingrp/frontend/controller/rabbitmqqueues"
 	rmq_proc "github.com/radius-project/radius/pkg/messagingrp/processors/rabbitmqqueues"
 	pr_ctrl "github.com/radius-project/radius/pkg/portableresources/backend/controller"
+	pr_frontend_ctrl "github.com/radius-project/radius/pkg/portableresources/frontend/controller"
 	rp_frontend "github.com/radius-project/radius/pkg/rp/frontend"
 )
 
@@ -75,6 +76,17 @@ func SetupNamespace(recipeControllerConfig *controllerconfig.RecipeControllerCon
 				APIController: rmq_ctrl.NewListSecretsRabbitMQQueue,
 			},
 		},
+		RecipePlan: builder.Operation[datamodel.RabbitMQQueue]{
+			APIController: func(opt apictrl.Options) (apictrl.Controller, error) {
+				return pr_frontend_ctrl.NewPlanRecipe[*datamodel.RabbitMQQueue](opt, apictrl.ResourceOptions[datamodel.RabbitMQQueue]{
+					AsyncOperationTimeout:    msrp_ctrl.AsyncCreateOrUpdateRabbitMQTimeout,
+					AsyncOperationRetryAfter: AsyncOperationRetryAfter,
+				})
+			},
+			AsyncJobController: func(options asyncctrl.Options) (asyncctrl.Controller, error) {
+				return pr_ctrl.NewPlanRecipe[*datamodel.RabbitMQQueue, datamodel.RabbitMQQueue](options, recipeControllerConfig.Engine)
+			},
+		},
 	})
 
 	// Optional
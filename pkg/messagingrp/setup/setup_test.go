@@ -58,6 +58,10 @@ var handlerTests = []rpctest.HandlerTestSpec{
 		OperationType: v1.OperationType{Type: msg_ctrl.RabbitMQQueuesResourceType, Method: v1.OperationDelete},
 		Path:          "/resourcegroups/testrg/providers/applications.messaging/rabbitmqqueues/rabbitmq",
 		Method:        http.MethodDelete,
+	}, {
+		OperationType: v1.OperationType{Type: msg_ctrl.RabbitMQQueuesResourceType, Method: v1.OperationRecipePlan},
+		Path:          "/resourcegroups/testrg/providers/applications.messaging/rabbitmqqueues/rabbitmq/recipes/plan",
+		Method:        http.MethodPost,
 	}, {
 		OperationType: v1.OperationType{Type: msg_ctrl.RabbitMQQueuesResourceType, Method: msg_ctrl.OperationListSecret},
 		Path:          "/resourcegroups/testrg/providers/applications.messaging/rabbitmqqueues/rabbitmq/listsecrets",
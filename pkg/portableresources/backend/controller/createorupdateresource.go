@@ -27,6 +27,7 @@ import (
 	ctrl "github.com/radius-project/radius/pkg/armrpc/asyncoperation/controller"
 	"github.com/radius-project/radius/pkg/components/database"
 	"github.com/radius-project/radius/pkg/crypto/encryption"
+	"github.com/radius-project/radius/pkg/portableresources"
 	"github.com/radius-project/radius/pkg/portableresources/datamodel"
 	"github.com/radius-project/radius/pkg/portableresources/processors"
 	"github.com/radius-project/radius/pkg/recipes"
@@ -106,13 +107,17 @@ func (c *CreateOrUpdateResource[P, T]) Run(ctx context.Context, req *ctrl.Reques
 					return ctrl.Result{}, err
 				}
 
-				if c.KubeClient() == nil {
-					err = fmt.Errorf("kubernetes client not configured for sensitive data decryption")
-					logger.Error(err, "Failed to initialize encryption key provider", "resourceID", req.ResourceID)
-					return ctrl.NewFailedResult(v1.ErrorDetails{Message: err.Error()}), err
-				}
+				// Use the configured key provider, and fall back to the keys of the default Kubernetes Secret.
+				keyProvider := c.KeyProvider()
+				if keyProvider == nil {
+					if c.KubeClient() == nil {
+						err = fmt.Errorf("kubernetes client not configured for sensitive data decryption")
+						logger.Error(err, "Failed to initialize encryption key provider", "resourceID", req.ResourceID)
+						return ctrl.NewFailedResult(v1.ErrorDetails{Message: err.Error()}), err
+					}
 
-				keyProvider := encryption.NewKubernetesKeyProvider(c.KubeClient(), nil)
+					keyProvider = encryption.NewKubernetesKeyProvider(c.KubeClient(), nil)
+				}
 				handler, err := encryption.NewSensitiveDataHandlerFromProvider(ctx, keyProvider)
 				if err != nil {
 					logger.Error(err, "Failed to initialize sensitive data handler", "resourceID", req.ResourceID)
@@ -266,35 +271,50 @@ func (c *CreateOrUpdateResource[P, T]) copyOutputResources(resource P) []string
 
 func (c *CreateOrUpdateResource[P, T]) executeRecipeIfNeeded(ctx context.Context, resource P, recipeDataModel datamodel.RecipeDataModel, prevState []string, simulated bool, recipeProperties map[string]any) (*recipes.RecipeOutput, error) {
 	// Caller ensures recipeDataModel supports recipes and has a non-nil recipe
-	recipe := recipeDataModel.GetRecipe()
+	metadata, err := NewRecipeMetadata(ctx, c.DatabaseClient(), resource, recipeDataModel.GetRecipe(), recipeProperties)
+	if err != nil {
+		return nil, err
+	}
 
-	resourceProperties := recipeProperties
+	return c.engine.Execute(ctx, engine.ExecuteOptions{
+		BaseOptions: engine.BaseOptions{
+			Recipe: metadata,
+		},
+		PreviousState: prevState,
+		Simulated:     simulated,
+	})
+}
+
+// NewRecipeMetadata builds the metadata that the recipe engine needs to execute or plan the recipe of a resource,
+// including the properties of its connected resources. The resource properties are read from the resource unless
+// resourceProperties is set, which is used to pass properties with decrypted sensitive fields.
+func NewRecipeMetadata[P rpv1.RadiusResourceModel](ctx context.Context, databaseClient database.Client, resource P, recipe *portableresources.ResourceRecipe, resourceProperties map[string]any) (recipes.ResourceMetadata, error) {
 	if resourceProperties == nil {
 		var err error
 		resourceProperties, err = resourceutil.GetPropertiesFromResource(resource)
 		if err != nil {
-			return nil, err
+			return recipes.ResourceMetadata{}, err
 		}
 	}
 
 	connectionsAndSourceIDs, err := resourceutil.GetConnectionNameandSourceIDs(resource)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get connected resource IDs: %w", err)
+		return recipes.ResourceMetadata{}, fmt.Errorf("failed to get connected resource IDs: %w", err)
 	}
 	connectedResourcesMetadata := make(map[string]recipes.ConnectedResource)
 
 	// If there are connected resources, we need to fetch their properties and add them to the recipe context.
 	for connName, connectedResourceID := range connectionsAndSourceIDs {
-		connectedResource, err := c.DatabaseClient().Get(ctx, connectedResourceID)
+		connectedResource, err := databaseClient.Get(ctx, connectedResourceID)
 		if errors.Is(&database.ErrNotFound{ID: connectedResourceID}, err) {
-			return nil, fmt.Errorf("connected resource %s not found: %w", connectedResourceID, err)
+			return recipes.ResourceMetadata{}, fmt.Errorf("connected resource %s not found: %w", connectedResourceID, err)
 		} else if err != nil {
-			return nil, fmt.Errorf("failed to get connected resource %s: %w", connectedResourceID, err)
+			return recipes.ResourceMetadata{}, fmt.Errorf("failed to get connected resource %s: %w", connectedResourceID, err)
 		}
 
 		connectedResourceMetadata, err := resourceutil.GetAllPropertiesFromResource(connectedResource.Data)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get metadata from connected resource %s: %w", connectedResourceID, err)
+			return recipes.ResourceMetadata{}, fmt.Errorf("failed to get metadata from connected resource %s: %w", connectedResourceID, err)
 		}
 
 		connectedResourcesMetadata[connName] = recipes.ConnectedResource{
@@ -305,7 +325,7 @@ func (c *CreateOrUpdateResource[P, T]) executeRecipeIfNeeded(ctx context.Context
 		}
 	}
 
-	metadata := recipes.ResourceMetadata{
+	return recipes.ResourceMetadata{
 		Name:                         recipe.Name,
 		Parameters:                   recipe.Parameters,
 		EnvironmentID:                resource.ResourceMetadata().EnvironmentID(),
@@ -313,15 +333,7 @@ func (c *CreateOrUpdateResource[P, T]) executeRecipeIfNeeded(ctx context.Context
 		ResourceID:                   resource.GetBaseResource().ID,
 		Properties:                   resourceProperties,
 		ConnectedResourcesProperties: connectedResourcesMetadata,
-	}
-
-	return c.engine.Execute(ctx, engine.ExecuteOptions{
-		BaseOptions: engine.BaseOptions{
-			Recipe: metadata,
-		},
-		PreviousState: prevState,
-		Simulated:     simulated,
-	})
+	}, nil
 }
 
 func getResourceAPIVersion[P rpv1.RadiusResourceModel](resource P) string {
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/asyncoperation/controller"
+	"github.com/radius-project/radius/pkg/portableresources/datamodel"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/engine"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+)
+
+// PlanRecipe is the async operation controller to plan the recipe of a portable resource. The plan is the output
+// of the operation: the changes that deploying the recipe of the resource as it is stored would make, without
+// making them.
+//
+// Sensitive properties are not decrypted for the plan. Once a recipe is deployed they are removed from the stored
+// resource, so the recipe is planned with those properties unset.
+type PlanRecipe[P interface {
+	*T
+	rpv1.RadiusResourceModel
+}, T any] struct {
+	ctrl.BaseController
+	engine engine.Engine
+}
+
+// NewPlanRecipe creates a new PlanRecipe controller which is used to plan the recipe of a resource asynchronously.
+func NewPlanRecipe[P interface {
+	*T
+	rpv1.RadiusResourceModel
+}, T any](opts ctrl.Options, eng engine.Engine) (ctrl.Controller, error) {
+	return &PlanRecipe[P, T]{
+		ctrl.NewBaseAsyncController(opts),
+		eng,
+	}, nil
+}
+
+// Run plans the recipe of the resource, using the output resources of its last deployment as the previous state.
+// Recipe errors, such as a missing recipe or a failed plan, fail the operation with the recipe error details.
+func (c *PlanRecipe[P, T]) Run(ctx context.Context, request *ctrl.Request) (ctrl.Result, error) {
+	obj, err := c.DatabaseClient().Get(ctx, request.ResourceID)
+	if err != nil {
+		return ctrl.NewFailedResult(v1.ErrorDetails{Message: err.Error()}), err
+	}
+
+	data := P(new(T))
+	if err = obj.As(data); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	recipeDataModel, supportsRecipes := any(data).(datamodel.RecipeDataModel)
+	if !supportsRecipes || recipeDataModel.GetRecipe() == nil {
+		return ctrl.NewFailedResult(v1.ErrorDetails{
+			Code:    v1.CodeInvalid,
+			Message: "The resource is not provisioned by a recipe.",
+		}), nil
+	}
+
+	metadata, err := NewRecipeMetadata(ctx, c.DatabaseClient(), data, recipeDataModel.GetRecipe(), nil)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	prevState := []string{}
+	for _, outputResource := range data.OutputResources() {
+		prevState = append(prevState, outputResource.ID.String())
+	}
+
+	plan, err := c.engine.Plan(ctx, engine.PlanOptions{
+		BaseOptions:   engine.BaseOptions{Recipe: metadata},
+		PreviousState: prevState,
+	})
+	var recipeErr *recipes.RecipeError
+	if errors.As(err, &recipeErr) {
+		return ctrl.NewFailedResult(recipeErr.ErrorDetails), nil
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Output: plan}, nil
+}
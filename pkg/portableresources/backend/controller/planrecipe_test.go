@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/asyncoperation/controller"
+	"github.com/radius-project/radius/pkg/components/database"
+	ds_dm "github.com/radius-project/radius/pkg/datastoresrp/datamodel"
+	"github.com/radius-project/radius/pkg/dynamicrp/datamodel"
+	"github.com/radius-project/radius/pkg/portableresources"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/engine"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testPlanEnvironmentID  = "/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/environments/env"
+	testPlanResourceID     = "/planes/radius/local/resourceGroups/test-group/providers/Applications.Test/testResources/myResource"
+	testPlanOutputResource = "/planes/kubernetes/local/namespaces/default/providers/core/Service/redis"
+)
+
+func newTestPlanResource(t *testing.T) *datamodel.DynamicResource {
+	t.Helper()
+
+	resource := &datamodel.DynamicResource{
+		BaseResource: v1.BaseResource{
+			TrackedResource: v1.TrackedResource{
+				ID:   testPlanResourceID,
+				Name: "myResource",
+				Type: "Applications.Test/testResources",
+			},
+		},
+		Properties: map[string]any{
+			"environment": testPlanEnvironmentID,
+			"recipe":      map[string]any{"name": "default"},
+		},
+	}
+
+	err := resource.ApplyDeploymentOutput(rpv1.DeploymentOutput{
+		DeployedOutputResources: []rpv1.OutputResource{
+			{ID: resources.MustParse(testPlanOutputResource)},
+		},
+	})
+	require.NoError(t, err)
+
+	return resource
+}
+
+func TestPlanRecipeRun(t *testing.T) {
+	setupTest := func(t *testing.T, resource *datamodel.DynamicResource) (*engine.MockEngine, ctrl.Controller, *ctrl.Request) {
+		mctrl := gomock.NewController(t)
+		databaseClient := database.NewMockClient(mctrl)
+		eng := engine.NewMockEngine(mctrl)
+
+		databaseClient.EXPECT().
+			Get(gomock.Any(), testPlanResourceID).
+			Return(&database.Object{Metadata: database.Metadata{ID: testPlanResourceID}, Data: resource}, nil)
+
+		c, err := NewPlanRecipe[*datamodel.DynamicResource](ctrl.Options{DatabaseClient: databaseClient}, eng)
+		require.NoError(t, err)
+
+		req := &ctrl.Request{
+			OperationID:      uuid.New(),
+			OperationType:    "APPLICATIONS.TEST/TESTRESOURCES|RECIPEPLAN",
+			ResourceID:       testPlanResourceID,
+			CorrelationID:    uuid.NewString(),
+			OperationTimeout: &ctrl.DefaultAsyncOperationTimeout,
+		}
+
+		return eng, c, req
+	}
+
+	t.Run("success", func(t *testing.T) {
+		eng, c, req := setupTest(t, newTestPlanResource(t))
+
+		plan := &recipes.RecipePlan{
+			Changes: []recipes.ResourceChange{
+				{ID: testPlanOutputResource, Type: "core/Service", Action: recipes.ChangeActionDelete},
+			},
+		}
+		eng.EXPECT().
+			Plan(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, opts engine.PlanOptions) (*recipes.RecipePlan, error) {
+				require.Equal(t, "default", opts.Recipe.Name)
+				require.Equal(t, testPlanResourceID, opts.Recipe.ResourceID)
+				require.Equal(t, testPlanEnvironmentID, opts.Recipe.EnvironmentID)
+				require.Equal(t, []string{testPlanOutputResource}, opts.PreviousState)
+				return plan, nil
+			})
+
+		result, err := c.Run(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, ctrl.Result{Output: plan}, result)
+	})
+
+	t.Run("not provisioned by a recipe", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		databaseClient := database.NewMockClient(mctrl)
+
+		resource := &ds_dm.RedisCache{
+			Properties: ds_dm.RedisCacheProperties{
+				ResourceProvisioning: portableresources.ResourceProvisioningManual,
+			},
+		}
+		databaseClient.EXPECT().
+			Get(gomock.Any(), testPlanResourceID).
+			Return(&database.Object{Metadata: database.Metadata{ID: testPlanResourceID}, Data: resource}, nil)
+
+		c, err := NewPlanRecipe[*ds_dm.RedisCache](ctrl.Options{DatabaseClient: databaseClient}, engine.NewMockEngine(mctrl))
+		require.NoError(t, err)
+
+		result, err := c.Run(context.Background(), &ctrl.Request{ResourceID: testPlanResourceID})
+		require.NoError(t, err)
+		require.Equal(t, v1.ProvisioningStateFailed, result.ProvisioningState())
+		require.Equal(t, v1.CodeInvalid, result.Error.Code)
+		require.Equal(t, "The resource is not provisioned by a recipe.", result.Error.Message)
+	})
+
+	t.Run("recipe error", func(t *testing.T) {
+		eng, c, req := setupTest(t, newTestPlanResource(t))
+
+		eng.EXPECT().
+			Plan(gomock.Any(), gomock.Any()).
+			Return(nil, recipes.NewRecipeError(recipes.RecipePlanFailed, "terraform plan failure", "executionError", nil))
+
+		result, err := c.Run(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, v1.ProvisioningStateFailed, result.ProvisioningState())
+		require.Equal(t, recipes.RecipePlanFailed, result.Error.Code)
+		require.Equal(t, "terraform plan failure", result.Error.Message)
+	})
+
+	t.Run("engine error", func(t *testing.T) {
+		eng, c, req := setupTest(t, newTestPlanResource(t))
+
+		eng.EXPECT().
+			Plan(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("failed"))
+
+		_, err := c.Run(context.Background(), req)
+		require.EqualError(t, err, "failed")
+	})
+}
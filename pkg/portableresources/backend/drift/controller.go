@@ -0,0 +1,346 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/armrpc/asyncoperation/statusmanager"
+	"github.com/radius-project/radius/pkg/armrpc/hostoptions"
+	"github.com/radius-project/radius/pkg/components/database"
+	corerp_dm "github.com/radius-project/radius/pkg/corerp/datamodel"
+	backend_ctrl "github.com/radius-project/radius/pkg/portableresources/backend/controller"
+	"github.com/radius-project/radius/pkg/portableresources/datamodel"
+	"github.com/radius-project/radius/pkg/recipes/configloader"
+	"github.com/radius-project/radius/pkg/recipes/engine"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+const (
+	// DefaultInterval is the time between two checks for drift when none is configured.
+	DefaultInterval = 30 * time.Minute
+
+	// RemediationTimeout is the timeout of the operation that re-applies the recipe of a resource that has drifted.
+	RemediationTimeout = 60 * time.Minute
+)
+
+// Resource is a resource that can be deployed by a recipe.
+type Resource interface {
+	rpv1.RadiusResourceModel
+	datamodel.RecipeDataModel
+}
+
+// ResourceType is a resource type whose resources can be deployed by a recipe.
+type ResourceType struct {
+	// Plane is the name of the Radius plane of the resource type.
+	Plane string
+
+	// Type is the fully-qualified resource type, e.g. "Applications.Datastores/redisCaches".
+	Type string
+
+	// New returns an empty datamodel of the resource type.
+	New func() Resource
+
+	// HasSensitiveFields is true if the resource type has sensitive fields. They are removed from the resource
+	// once its recipe is deployed, so the recipe can't be re-applied to remediate drift.
+	HasSensitiveFields bool
+}
+
+// key returns the key used to order resource types.
+func (t ResourceType) key() string {
+	return t.Plane + "|" + t.Type
+}
+
+// Controller periodically checks the resources deployed by the recipes of resources for drift, that is changes
+// made to them outside of the recipe. The drift policy of the environment of a resource decides whether the
+// drift is only recorded in the recipe status of the resource, or whether the recipe is also re-applied.
+//
+// Terraform recipes are checked with a refresh-only plan. Bicep recipes are checked by reading their output
+// resources, which detects the output resources that were deleted.
+type Controller struct {
+	// DatabaseClient is the database of the resources.
+	DatabaseClient database.Client
+
+	// StatusManager queues the operations that re-apply recipes.
+	StatusManager statusmanager.StatusManager
+
+	// Engine plans the recipes.
+	Engine engine.Engine
+
+	// ConfigLoader loads the configuration of the environment of a resource, which includes its drift policy.
+	ConfigLoader configloader.ConfigurationLoader
+
+	// ListResourceTypes returns the resource types that are checked.
+	ListResourceTypes func(ctx context.Context) ([]ResourceType, error)
+
+	// Interval is the time between two checks.
+	Interval time.Duration
+
+	// now returns the current time. It can be replaced in tests.
+	now func() time.Time
+}
+
+// NewController creates a Controller from the recipe drift configuration.
+func NewController(options hostoptions.RecipeDriftOptions, databaseClient database.Client, statusManager statusmanager.StatusManager, eng engine.Engine, configLoader configloader.ConfigurationLoader, listResourceTypes func(ctx context.Context) ([]ResourceType, error)) (*Controller, error) {
+	interval := DefaultInterval
+	if options.Interval != "" {
+		var err error
+		interval, err = time.ParseDuration(options.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipeDrift.interval %q: %w", options.Interval, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("invalid recipeDrift.interval %q: must be positive", options.Interval)
+		}
+	}
+
+	return &Controller{
+		DatabaseClient:    databaseClient,
+		StatusManager:     statusManager,
+		Engine:            eng,
+		ConfigLoader:      configLoader,
+		ListResourceTypes: listResourceTypes,
+		Interval:          interval,
+		now:               time.Now,
+	}, nil
+}
+
+// Start checks for drift immediately and then once per Interval, until ctx is done.
+func (c *Controller) Start(ctx context.Context) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Reconcile(ctx); err != nil && ctx.Err() == nil {
+			logger.Error(err, "Failed to check recipes for drift")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile checks the recipe of every resource of the resource types for drift. A resource that can't be
+// checked is logged and skipped, so that it doesn't prevent the other resources from being checked.
+func (c *Controller) Reconcile(ctx context.Context) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	resourceTypes, err := c.ListResourceTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list resource types: %w", err)
+	}
+	slices.SortFunc(resourceTypes, func(a, b ResourceType) int {
+		return strings.Compare(a.key(), b.key())
+	})
+
+	checked, drifted := 0, 0
+	for _, resourceType := range resourceTypes {
+		paginationToken := ""
+		for {
+			options := []database.QueryOptions{}
+			if paginationToken != "" {
+				options = append(options, database.WithPaginationToken(paginationToken))
+			}
+
+			result, err := c.DatabaseClient.Query(ctx, database.Query{
+				RootScope:      "/planes/radius/" + resourceType.Plane,
+				ScopeRecursive: true,
+				ResourceType:   resourceType.Type,
+			}, options...)
+			if err != nil {
+				return err
+			}
+
+			for i := range result.Items {
+				status, err := c.checkResource(ctx, resourceType, &result.Items[i])
+				if err != nil {
+					logger.Error(err, "Failed to check the recipe of the resource for drift", "resourceID", result.Items[i].ID)
+					continue
+				}
+				if status != nil {
+					checked++
+					if status.Drifted {
+						drifted++
+					}
+				}
+			}
+
+			paginationToken = result.PaginationToken
+			if paginationToken == "" {
+				break
+			}
+		}
+	}
+
+	logger.Info("Finished checking recipes for drift", "checked", checked, "drifted", drifted)
+	return nil
+}
+
+// checkResource checks the recipe of a resource for drift and records the result in the recipe status of the
+// resource. It re-applies the recipe when it has drifted and the drift policy of the environment is remediate.
+// It returns nil if the resource was not checked.
+func (c *Controller) checkResource(ctx context.Context, resourceType ResourceType, obj *database.Object) (*rpv1.RecipeDriftStatus, error) {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	resource := resourceType.New()
+	if err := obj.As(resource); err != nil {
+		return nil, err
+	}
+
+	// Only the recipes that were deployed successfully are checked. A resource that is being deployed is
+	// checked once its deployment completes.
+	resourceStatus := resource.ResourceMetadata().GetResourceStatus()
+	if resource.GetRecipe() == nil || resourceStatus.Recipe == nil || resource.ProvisioningState() != v1.ProvisioningStateSucceeded {
+		return nil, nil
+	}
+
+	metadata, err := backend_ctrl.NewRecipeMetadata(ctx, c.DatabaseClient, resource, resource.GetRecipe(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration, err := c.ConfigLoader.LoadConfiguration(ctx, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the configuration of environment %s: %w", metadata.EnvironmentID, err)
+	}
+
+	policy := configuration.RecipeConfig.Drift.Policy
+	if policy == "" || policy == corerp_dm.RecipeDriftPolicyOff {
+		return nil, nil
+	}
+
+	prevState := []string{}
+	for _, outputResource := range resource.OutputResources() {
+		prevState = append(prevState, outputResource.ID.String())
+	}
+
+	status := &rpv1.RecipeDriftStatus{LastCheckedTime: c.now().UTC()}
+	plan, err := c.Engine.Plan(ctx, engine.PlanOptions{
+		BaseOptions:   engine.BaseOptions{Recipe: metadata},
+		PreviousState: prevState,
+		RefreshOnly:   true,
+	})
+	if err != nil {
+		// The failure is recorded, so that a recipe that can't be checked is visible in its status.
+		status.Message = fmt.Sprintf("failed to check the recipe for drift: %s", err.Error())
+	} else {
+		for _, change := range plan.Changes {
+			status.Resources = append(status.Resources, rpv1.RecipeDriftedResource{ID: change.ID, Action: change.Action})
+		}
+		status.Drifted = len(status.Resources) > 0
+	}
+
+	remediate := false
+	if status.Drifted && policy == corerp_dm.RecipeDriftPolicyRemediate {
+		if resourceType.HasSensitiveFields {
+			status.Message = "the recipe was not re-applied because the sensitive fields of the resource are not stored once it is deployed"
+		} else {
+			remediate = true
+			requested := status.LastCheckedTime
+			status.RemediationRequestedTime = &requested
+			resource.SetProvisioningState(v1.ProvisioningStateUpdating)
+		}
+	}
+
+	// Saving the resource changes its ETag and notifies its watchers, so it is only saved when the result of
+	// the check changed or the recipe is re-applied. An unchanged result keeps the time of the check that
+	// first found it.
+	if !remediate && !driftStatusChanged(resourceStatus.Recipe.Drift, status) {
+		logger.V(ucplog.LevelDebug).Info("The drift status of the resource is unchanged", "resourceID", obj.ID)
+		return status, nil
+	}
+
+	resourceStatus.Recipe.Drift = status
+	resource.ResourceMetadata().SetResourceStatus(resourceStatus)
+
+	obj.Data = resource
+	err = c.DatabaseClient.Save(ctx, obj, database.WithETag(obj.ETag))
+	if errors.Is(err, &database.ErrConcurrency{}) {
+		// The resource was modified since it was read. It is checked again on the next check.
+		logger.Info("Skipping the drift status of a resource that was modified", "resourceID", obj.ID)
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if remediate {
+		if err := c.queueRemediation(ctx, resource, obj); err != nil {
+			return nil, err
+		}
+		logger.Info("Requested the remediation of a recipe that has drifted", "resourceID", obj.ID)
+	}
+
+	return status, nil
+}
+
+// driftStatusChanged reports whether the result of a check differs from the result recorded by the previous check.
+func driftStatusChanged(previous *rpv1.RecipeDriftStatus, current *rpv1.RecipeDriftStatus) bool {
+	return previous == nil ||
+		previous.Drifted != current.Drifted ||
+		previous.Message != current.Message ||
+		!slices.Equal(previous.Resources, current.Resources)
+}
+
+// queueRemediation queues an operation that re-applies the recipe of a resource, as if the resource was updated.
+// The resource must have been saved in the Updating state. It is saved in the Succeeded state again when the
+// operation can't be queued.
+func (c *Controller) queueRemediation(ctx context.Context, resource Resource, obj *database.Object) error {
+	id, err := resources.ParseResource(obj.ID)
+	if err != nil {
+		return err
+	}
+
+	err = c.StatusManager.QueueAsyncOperation(ctx, &v1.ARMRequestContext{
+		ResourceID:    id,
+		OperationID:   uuid.New(),
+		OperationType: v1.OperationType{Type: id.Type(), Method: v1.OperationPut},
+		APIVersion:    resource.GetBaseResource().InternalMetadata.UpdatedAPIVersion,
+	}, statusmanager.QueueOperationOptions{
+		OperationTimeout: RemediationTimeout,
+		RetryAfter:       v1.DefaultRetryAfterDuration,
+	})
+	if err == nil {
+		return nil
+	}
+
+	resource.SetProvisioningState(v1.ProvisioningStateSucceeded)
+	resourceStatus := resource.ResourceMetadata().GetResourceStatus()
+	resourceStatus.Recipe.Drift.RemediationRequestedTime = nil
+	resourceStatus.Recipe.Drift.Message = fmt.Sprintf("failed to re-apply the recipe: %s", err.Error())
+	resource.ResourceMetadata().SetResourceStatus(resourceStatus)
+
+	obj.Data = resource
+	if rbErr := c.DatabaseClient.Save(ctx, obj, database.WithETag(obj.ETag)); rbErr != nil {
+		return rbErr
+	}
+	return err
+}
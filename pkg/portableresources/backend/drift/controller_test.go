@@ -0,0 +1,333 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/armrpc/asyncoperation/statusmanager"
+	"github.com/radius-project/radius/pkg/armrpc/hostoptions"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
+	corerp_dm "github.com/radius-project/radius/pkg/corerp/datamodel"
+	ds_dm "github.com/radius-project/radius/pkg/datastoresrp/datamodel"
+	ds_ctrl "github.com/radius-project/radius/pkg/datastoresrp/frontend/controller"
+	"github.com/radius-project/radius/pkg/portableresources"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/configloader"
+	"github.com/radius-project/radius/pkg/recipes/engine"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+const (
+	redisID       = "/planes/radius/local/resourceGroups/test/providers/Applications.Datastores/redisCaches/redis"
+	environmentID = "/planes/radius/local/resourceGroups/test/providers/Applications.Core/environments/env"
+	outputID      = "/planes/aws/aws/accounts/000000000000/regions/us-west-2/providers/AWS.ElastiCache/CacheCluster/redis"
+)
+
+var testNow = time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+type testSetup struct {
+	controller    *Controller
+	database      database.Client
+	engine        *engine.MockEngine
+	configLoader  *configloader.MockConfigurationLoader
+	statusManager *statusmanager.MockStatusManager
+}
+
+func setup(t *testing.T) *testSetup {
+	mctrl := gomock.NewController(t)
+	s := &testSetup{
+		database:      inmemory.NewClient(),
+		engine:        engine.NewMockEngine(mctrl),
+		configLoader:  configloader.NewMockConfigurationLoader(mctrl),
+		statusManager: statusmanager.NewMockStatusManager(mctrl),
+	}
+
+	controller, err := NewController(hostoptions.RecipeDriftOptions{}, s.database, s.statusManager, s.engine, s.configLoader,
+		func(ctx context.Context) ([]ResourceType, error) {
+			return []ResourceType{{
+				Plane: "local",
+				Type:  ds_ctrl.RedisCachesResourceType,
+				New:   func() Resource { return &ds_dm.RedisCache{} },
+			}}, nil
+		})
+	require.NoError(t, err)
+	controller.now = func() time.Time { return testNow }
+	s.controller = controller
+
+	return s
+}
+
+// saveRedis saves a Redis cache deployed by a recipe in the given provisioning state.
+func (s *testSetup) saveRedis(t *testing.T, state v1.ProvisioningState) {
+	outputResourceID, err := resources.ParseResource(outputID)
+	require.NoError(t, err)
+
+	require.NoError(t, s.database.Save(t.Context(), &database.Object{
+		Metadata: database.Metadata{ID: redisID},
+		Data: &ds_dm.RedisCache{
+			BaseResource: v1.BaseResource{
+				TrackedResource: v1.TrackedResource{ID: redisID, Name: "redis", Type: ds_ctrl.RedisCachesResourceType},
+				InternalMetadata: v1.InternalMetadata{
+					UpdatedAPIVersion:      "2023-10-01-preview",
+					AsyncProvisioningState: state,
+				},
+			},
+			Properties: ds_dm.RedisCacheProperties{
+				BasicResourceProperties: rpv1.BasicResourceProperties{
+					Environment: environmentID,
+					Status: rpv1.ResourceStatus{
+						OutputResources: []rpv1.OutputResource{{ID: outputResourceID}},
+						Recipe:          &rpv1.RecipeStatus{TemplateKind: recipes.TemplateKindTerraform, TemplatePath: "redis"},
+					},
+				},
+				Recipe: portableresources.ResourceRecipe{Name: "default"},
+			},
+		},
+	}))
+}
+
+// expectPolicy expects the configuration of the environment to be loaded, with the given drift policy.
+func (s *testSetup) expectPolicy(policy string) {
+	s.configLoader.EXPECT().
+		LoadConfiguration(gomock.Any(), gomock.Any()).
+		Return(&recipes.Configuration{RecipeConfig: corerp_dm.RecipeConfigProperties{
+			Drift: corerp_dm.RecipeDriftConfig{Policy: policy},
+		}}, nil)
+}
+
+// expectPlan expects a refresh-only plan of the recipe of the Redis cache.
+func (s *testSetup) expectPlan(plan *recipes.RecipePlan, err error) {
+	s.engine.EXPECT().
+		Plan(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, opts engine.PlanOptions) (*recipes.RecipePlan, error) {
+			if !opts.RefreshOnly || opts.Recipe.ResourceID != redisID || len(opts.PreviousState) != 1 || opts.PreviousState[0] != outputID {
+				return nil, errors.New("unexpected plan options")
+			}
+			return plan, err
+		})
+}
+
+// redis returns the stored Redis cache.
+func (s *testSetup) redis(t *testing.T) *ds_dm.RedisCache {
+	obj, err := s.database.Get(t.Context(), redisID)
+	require.NoError(t, err)
+
+	resource := &ds_dm.RedisCache{}
+	require.NoError(t, obj.As(resource))
+	return resource
+}
+
+var driftedPlan = &recipes.RecipePlan{
+	Changes: []recipes.ResourceChange{
+		{ID: "aws_elasticache_cluster.redis", Type: "aws_elasticache_cluster", Action: recipes.ChangeActionUpdate},
+	},
+}
+
+func Test_NewController(t *testing.T) {
+	controller, err := NewController(hostoptions.RecipeDriftOptions{}, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, DefaultInterval, controller.Interval)
+
+	controller, err = NewController(hostoptions.RecipeDriftOptions{Interval: "5m"}, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Minute, controller.Interval)
+
+	_, err = NewController(hostoptions.RecipeDriftOptions{Interval: "soon"}, nil, nil, nil, nil, nil)
+	require.ErrorContains(t, err, "invalid recipeDrift.interval")
+
+	_, err = NewController(hostoptions.RecipeDriftOptions{Interval: "0s"}, nil, nil, nil, nil, nil)
+	require.ErrorContains(t, err, "must be positive")
+}
+
+func Test_Reconcile_PolicyOff(t *testing.T) {
+	for _, policy := range []string{"", corerp_dm.RecipeDriftPolicyOff} {
+		s := setup(t)
+		s.saveRedis(t, v1.ProvisioningStateSucceeded)
+		s.expectPolicy(policy)
+
+		require.NoError(t, s.controller.Reconcile(t.Context()))
+		require.Nil(t, s.redis(t).Properties.Status.Recipe.Drift)
+	}
+}
+
+func Test_Reconcile_NotSucceeded(t *testing.T) {
+	s := setup(t)
+	s.saveRedis(t, v1.ProvisioningStateUpdating)
+
+	require.NoError(t, s.controller.Reconcile(t.Context()))
+	require.Nil(t, s.redis(t).Properties.Status.Recipe.Drift)
+}
+
+func Test_Reconcile_Detect(t *testing.T) {
+	t.Run("drifted", func(t *testing.T) {
+		s := setup(t)
+		s.saveRedis(t, v1.ProvisioningStateSucceeded)
+		s.expectPolicy(corerp_dm.RecipeDriftPolicyDetect)
+		s.expectPlan(driftedPlan, nil)
+
+		require.NoError(t, s.controller.Reconcile(t.Context()))
+
+		redis := s.redis(t)
+		require.Equal(t, v1.ProvisioningStateSucceeded, redis.ProvisioningState())
+		require.Equal(t, &rpv1.RecipeDriftStatus{
+			Drifted:         true,
+			Resources:       []rpv1.RecipeDriftedResource{{ID: "aws_elasticache_cluster.redis", Action: recipes.ChangeActionUpdate}},
+			LastCheckedTime: testNow,
+		}, redis.Properties.Status.Recipe.Drift)
+	})
+
+	t.Run("not drifted", func(t *testing.T) {
+		s := setup(t)
+		s.saveRedis(t, v1.ProvisioningStateSucceeded)
+		s.expectPolicy(corerp_dm.RecipeDriftPolicyDetect)
+		s.expectPlan(&recipes.RecipePlan{Changes: []recipes.ResourceChange{}}, nil)
+
+		require.NoError(t, s.controller.Reconcile(t.Context()))
+		require.Equal(t, &rpv1.RecipeDriftStatus{LastCheckedTime: testNow}, s.redis(t).Properties.Status.Recipe.Drift)
+	})
+
+	t.Run("plan failed", func(t *testing.T) {
+		s := setup(t)
+		s.saveRedis(t, v1.ProvisioningStateSucceeded)
+		s.expectPolicy(corerp_dm.RecipeDriftPolicyDetect)
+		s.expectPlan(nil, errors.New("refresh failed"))
+
+		require.NoError(t, s.controller.Reconcile(t.Context()))
+		require.Equal(t, &rpv1.RecipeDriftStatus{
+			LastCheckedTime: testNow,
+			Message:         "failed to check the recipe for drift: refresh failed",
+		}, s.redis(t).Properties.Status.Recipe.Drift)
+	})
+}
+
+func Test_Reconcile_Remediate(t *testing.T) {
+	t.Run("drifted", func(t *testing.T) {
+		s := setup(t)
+		s.saveRedis(t, v1.ProvisioningStateSucceeded)
+		s.expectPolicy(corerp_dm.RecipeDriftPolicyRemediate)
+		s.expectPlan(driftedPlan, nil)
+		s.statusManager.EXPECT().
+			QueueAsyncOperation(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, sCtx *v1.ARMRequestContext, options statusmanager.QueueOperationOptions) error {
+				require.Equal(t, redisID, sCtx.ResourceID.String())
+				require.Equal(t, "APPLICATIONS.DATASTORES/REDISCACHES|PUT", sCtx.OperationType.String())
+				require.Equal(t, "2023-10-01-preview", sCtx.APIVersion)
+				require.Equal(t, RemediationTimeout, options.OperationTimeout)
+				return nil
+			})
+
+		require.NoError(t, s.controller.Reconcile(t.Context()))
+
+		redis := s.redis(t)
+		require.Equal(t, v1.ProvisioningStateUpdating, redis.ProvisioningState())
+		require.True(t, redis.Properties.Status.Recipe.Drift.Drifted)
+		require.Equal(t, &testNow, redis.Properties.Status.Recipe.Drift.RemediationRequestedTime)
+	})
+
+	t.Run("not drifted", func(t *testing.T) {
+		s := setup(t)
+		s.saveRedis(t, v1.ProvisioningStateSucceeded)
+		s.expectPolicy(corerp_dm.RecipeDriftPolicyRemediate)
+		s.expectPlan(&recipes.RecipePlan{}, nil)
+
+		require.NoError(t, s.controller.Reconcile(t.Context()))
+		require.Equal(t, v1.ProvisioningStateSucceeded, s.redis(t).ProvisioningState())
+	})
+
+	t.Run("queue failed", func(t *testing.T) {
+		s := setup(t)
+		s.saveRedis(t, v1.ProvisioningStateSucceeded)
+		s.expectPolicy(corerp_dm.RecipeDriftPolicyRemediate)
+		s.expectPlan(driftedPlan, nil)
+		s.statusManager.EXPECT().
+			QueueAsyncOperation(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New("queue is full"))
+
+		require.NoError(t, s.controller.Reconcile(t.Context()))
+
+		redis := s.redis(t)
+		require.Equal(t, v1.ProvisioningStateSucceeded, redis.ProvisioningState())
+		require.True(t, redis.Properties.Status.Recipe.Drift.Drifted)
+		require.Nil(t, redis.Properties.Status.Recipe.Drift.RemediationRequestedTime)
+		require.Equal(t, "failed to re-apply the recipe: queue is full", redis.Properties.Status.Recipe.Drift.Message)
+	})
+
+	t.Run("sensitive fields", func(t *testing.T) {
+		s := setup(t)
+		s.controller.ListResourceTypes = func(ctx context.Context) ([]ResourceType, error) {
+			return []ResourceType{{
+				Plane:              "local",
+				Type:               ds_ctrl.RedisCachesResourceType,
+				New:                func() Resource { return &ds_dm.RedisCache{} },
+				HasSensitiveFields: true,
+			}}, nil
+		}
+		s.saveRedis(t, v1.ProvisioningStateSucceeded)
+		s.expectPolicy(corerp_dm.RecipeDriftPolicyRemediate)
+		s.expectPlan(driftedPlan, nil)
+
+		require.NoError(t, s.controller.Reconcile(t.Context()))
+
+		redis := s.redis(t)
+		require.Equal(t, v1.ProvisioningStateSucceeded, redis.ProvisioningState())
+		require.True(t, redis.Properties.Status.Recipe.Drift.Drifted)
+		require.Contains(t, redis.Properties.Status.Recipe.Drift.Message, "the recipe was not re-applied")
+	})
+}
+
+func Test_Reconcile_Unchanged(t *testing.T) {
+	s := setup(t)
+	s.saveRedis(t, v1.ProvisioningStateSucceeded)
+	s.expectPolicy(corerp_dm.RecipeDriftPolicyDetect)
+	s.expectPlan(driftedPlan, nil)
+	require.NoError(t, s.controller.Reconcile(t.Context()))
+
+	obj, err := s.database.Get(t.Context(), redisID)
+	require.NoError(t, err)
+	etag := obj.ETag
+
+	// A check that finds the same result doesn't save the resource.
+	s.controller.now = func() time.Time { return testNow.Add(time.Hour) }
+	s.expectPolicy(corerp_dm.RecipeDriftPolicyDetect)
+	s.expectPlan(driftedPlan, nil)
+	require.NoError(t, s.controller.Reconcile(t.Context()))
+
+	obj, err = s.database.Get(t.Context(), redisID)
+	require.NoError(t, err)
+	require.Equal(t, etag, obj.ETag)
+	require.Equal(t, testNow, s.redis(t).Properties.Status.Recipe.Drift.LastCheckedTime)
+
+	// A check that finds a different result saves it.
+	s.expectPolicy(corerp_dm.RecipeDriftPolicyDetect)
+	s.expectPlan(&recipes.RecipePlan{}, nil)
+	require.NoError(t, s.controller.Reconcile(t.Context()))
+
+	obj, err = s.database.Get(t.Context(), redisID)
+	require.NoError(t, err)
+	require.NotEqual(t, etag, obj.ETag)
+	require.Equal(t, &rpv1.RecipeDriftStatus{LastCheckedTime: testNow.Add(time.Hour)}, s.redis(t).Properties.Status.Recipe.Drift)
+}
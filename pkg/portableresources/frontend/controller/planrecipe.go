@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	sm "github.com/radius-project/radius/pkg/armrpc/asyncoperation/statusmanager"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	"github.com/radius-project/radius/pkg/armrpc/rest"
+	"github.com/radius-project/radius/pkg/portableresources/datamodel"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+)
+
+// PlanRecipe is the controller implementation to plan the recipe of a resource. The plan runs as an async operation,
+// whose result is the plan of the recipe once the operation succeeds.
+type PlanRecipe[P interface {
+	*T
+	rpv1.RadiusResourceModel
+}, T any] struct {
+	ctrl.Operation[P, T]
+}
+
+// NewPlanRecipe creates a new controller for planning the recipe of a resource.
+func NewPlanRecipe[P interface {
+	*T
+	rpv1.RadiusResourceModel
+}, T any](opts ctrl.Options, resourceOpts ctrl.ResourceOptions[T]) (ctrl.Controller, error) {
+	return &PlanRecipe[P, T]{ctrl.NewOperation[P](opts, resourceOpts)}, nil
+}
+
+// Run queues the async operation that plans the recipe of the resource and returns an Accepted response, whose
+// Location header points to the result of the operation. The resource itself is not changed, so a plan can run
+// alongside another operation on the resource.
+func (c *PlanRecipe[P, T]) Run(ctx context.Context, w http.ResponseWriter, req *http.Request) (rest.Response, error) {
+	serviceCtx := v1.ARMRequestContextFromContext(ctx)
+
+	resource, _, err := c.GetResource(ctx, serviceCtx.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return rest.NewNotFoundResponse(serviceCtx.ResourceID), nil
+	}
+
+	recipeDataModel, supportsRecipes := any(resource).(datamodel.RecipeDataModel)
+	if !supportsRecipes || recipeDataModel.GetRecipe() == nil {
+		return rest.NewBadRequestResponse("The resource is not provisioned by a recipe."), nil
+	}
+
+	err = c.StatusManager().QueueAsyncOperation(ctx, serviceCtx, sm.QueueOperationOptions{
+		OperationTimeout: c.AsyncOperationTimeout(),
+		RetryAfter:       c.AsyncOperationRetryAfter(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := rest.NewAsyncOperationResponse(map[string]any{}, serviceCtx.Location, http.StatusAccepted, serviceCtx.ResourceID, serviceCtx.OperationID, serviceCtx.APIVersion, "", "")
+	response.RetryAfter = c.AsyncOperationRetryAfter()
+	return response, nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	sm "github.com/radius-project/radius/pkg/armrpc/asyncoperation/statusmanager"
+	ctrl "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	"github.com/radius-project/radius/pkg/armrpc/rpctest"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/dynamicrp/datamodel"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testEnvironmentID = "/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/environments/env"
+	testResourceID    = "/planes/radius/local/resourceGroups/test-group/providers/Applications.Test/testResources/myResource"
+)
+
+func newTestPlanController(t *testing.T, databaseClient database.Client, statusManager sm.StatusManager) ctrl.Controller {
+	t.Helper()
+
+	c, err := NewPlanRecipe[*datamodel.DynamicResource](
+		ctrl.Options{DatabaseClient: databaseClient, StatusManager: statusManager},
+		ctrl.ResourceOptions[datamodel.DynamicResource]{
+			AsyncOperationTimeout:    time.Hour,
+			AsyncOperationRetryAfter: 5 * time.Second,
+		})
+	require.NoError(t, err)
+
+	return c
+}
+
+func newTestPlanRequest(t *testing.T) (context.Context, *http.Request) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, testResourceID+"/"+recipes.PlanAction+"?api-version=2023-10-01-preview", nil)
+	return rpctest.NewARMRequestContext(req), req
+}
+
+func newTestPlanResource() *datamodel.DynamicResource {
+	return &datamodel.DynamicResource{
+		BaseResource: v1.BaseResource{
+			TrackedResource: v1.TrackedResource{
+				ID:   testResourceID,
+				Name: "myResource",
+				Type: "Applications.Test/testResources",
+			},
+		},
+		Properties: map[string]any{
+			"environment": testEnvironmentID,
+			"recipe":      map[string]any{"name": "default"},
+		},
+	}
+}
+
+func expectGetResource(databaseClient *database.MockClient, resource *datamodel.DynamicResource) {
+	databaseClient.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, _ ...database.GetOptions) (*database.Object, error) {
+			if resource == nil {
+				return nil, &database.ErrNotFound{ID: id}
+			}
+			return &database.Object{Metadata: database.Metadata{ID: id}, Data: resource}, nil
+		})
+}
+
+func TestPlanRecipe_Run(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		databaseClient := database.NewMockClient(mctrl)
+		statusManager := sm.NewMockStatusManager(mctrl)
+		expectGetResource(databaseClient, newTestPlanResource())
+
+		ctx, req := newTestPlanRequest(t)
+		serviceCtx := v1.ARMRequestContextFromContext(ctx)
+
+		statusManager.EXPECT().
+			QueueAsyncOperation(gomock.Any(), serviceCtx, sm.QueueOperationOptions{OperationTimeout: time.Hour, RetryAfter: 5 * time.Second}).
+			Return(nil)
+
+		w := httptest.NewRecorder()
+		resp, err := newTestPlanController(t, databaseClient, statusManager).Run(ctx, w, req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Apply(ctx, w, req))
+		require.Equal(t, http.StatusAccepted, w.Result().StatusCode)
+
+		require.Contains(t, w.Header().Get("Location"), "/operationResults/"+serviceCtx.OperationID.String())
+		require.Contains(t, w.Header().Get("Azure-AsyncOperation"), "/operationStatuses/"+serviceCtx.OperationID.String())
+		require.Equal(t, "5", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("resource not found", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		databaseClient := database.NewMockClient(mctrl)
+		expectGetResource(databaseClient, nil)
+
+		ctx, req := newTestPlanRequest(t)
+		w := httptest.NewRecorder()
+		resp, err := newTestPlanController(t, databaseClient, sm.NewMockStatusManager(mctrl)).Run(ctx, w, req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Apply(ctx, w, req))
+		require.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("queue error", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		databaseClient := database.NewMockClient(mctrl)
+		statusManager := sm.NewMockStatusManager(mctrl)
+		expectGetResource(databaseClient, newTestPlanResource())
+
+		statusManager.EXPECT().
+			QueueAsyncOperation(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New("failed"))
+
+		ctx, req := newTestPlanRequest(t)
+		_, err := newTestPlanController(t, databaseClient, statusManager).Run(ctx, httptest.NewRecorder(), req)
+		require.EqualError(t, err, "failed")
+	})
+}
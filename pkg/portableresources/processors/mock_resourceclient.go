@@ -77,3 +77,42 @@ func (c *MockResourceClientDeleteCall) DoAndReturn(f func(context.Context, strin
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Exists mocks base method.
+func (m *MockResourceClient) Exists(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockResourceClientMockRecorder) Exists(ctx, id any) *MockResourceClientExistsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockResourceClient)(nil).Exists), ctx, id)
+	return &MockResourceClientExistsCall{Call: call}
+}
+
+// MockResourceClientExistsCall wrap *gomock.Call
+type MockResourceClientExistsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockResourceClientExistsCall) Return(arg0 bool, arg1 error) *MockResourceClientExistsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockResourceClientExistsCall) Do(f func(context.Context, string) (bool, error)) *MockResourceClientExistsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockResourceClientExistsCall) DoAndReturn(f func(context.Context, string) (bool, error)) *MockResourceClientExistsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -358,6 +358,155 @@ func Test_Delete_UCP(t *testing.T) {
 	})
 }
 
+func Test_Exists_ARM(t *testing.T) {
+	provider := handleJSONResponse(t, armresources.Provider{
+		Namespace: new("Microsoft.Compute"),
+		ResourceTypes: []*armresources.ProviderResourceType{
+			{
+				ResourceType:      new("virtualMachines"),
+				DefaultAPIVersion: new(ARMAPIVersion),
+			},
+		},
+	}, 200)
+
+	t.Run("success - resource exists", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(ARMResourceID, handleJSONResponse(t, armresources.GenericResource{ID: new(ARMResourceID)}, 200))
+		mux.HandleFunc(ARMProviderPath, provider)
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		c := NewResourceClient(newArmOptions(server.URL), nil, nil)
+		c.armClientOptions = newClientOptions(server.Client(), server.URL)
+
+		exists, err := c.Exists(t.Context(), AzureUCPResourceID)
+		require.NoError(t, err)
+		require.True(t, exists)
+	})
+
+	t.Run("success - resource not found", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(ARMResourceID, handleNotFound(t))
+		mux.HandleFunc(ARMProviderPath, provider)
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		c := NewResourceClient(newArmOptions(server.URL), nil, nil)
+		c.armClientOptions = newClientOptions(server.Client(), server.URL)
+
+		exists, err := c.Exists(t.Context(), ARMResourceID)
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+}
+
+func Test_Exists_Kubernetes(t *testing.T) {
+	dc := &k8sutil.DiscoveryClient{
+		Resources: []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{
+						Name:    "api1",
+						Version: "v1",
+						Kind:    "Secret",
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("success - resource exists", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-name",
+				Namespace: "test-namespace",
+			},
+		}).Build()
+
+		kcp := kubernetesclientprovider.FromConfig(nil)
+		kcp.SetRuntimeClient(client)
+		kcp.SetDiscoveryClient(dc)
+
+		c := NewResourceClient(nil, nil, kcp)
+
+		exists, err := c.Exists(t.Context(), KubernetesCoreGroupResourceID)
+		require.NoError(t, err)
+		require.True(t, exists)
+	})
+
+	t.Run("success - resource not found", func(t *testing.T) {
+		kcp := kubernetesclientprovider.FromConfig(nil)
+		kcp.SetRuntimeClient(fake.NewClientBuilder().Build())
+		kcp.SetDiscoveryClient(dc)
+
+		c := NewResourceClient(nil, nil, kcp)
+
+		exists, err := c.Exists(t.Context(), KubernetesCoreGroupResourceID)
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+}
+
+func Test_Exists_UCP(t *testing.T) {
+	t.Run("success - resource exists", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(AWSResourceID, handleJSONResponse(t, map[string]any{"id": AWSResourceID}, 200))
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		connection, err := sdk.NewDirectConnection(server.URL)
+		require.NoError(t, err)
+
+		c := NewResourceClient(nil, connection, nil)
+
+		exists, err := c.Exists(t.Context(), AWSResourceID)
+		require.NoError(t, err)
+		require.True(t, exists)
+	})
+
+	t.Run("success - resource not found", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(AWSResourceID, handleNotFound(t))
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		connection, err := sdk.NewDirectConnection(server.URL)
+		require.NoError(t, err)
+
+		c := NewResourceClient(nil, connection, nil)
+
+		exists, err := c.Exists(t.Context(), AWSResourceID)
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+
+	t.Run("failure - get fails", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(AWSResourceID, handleJSONResponse(t, v1.ErrorResponse{
+			Error: &v1.ErrorDetails{
+				Code: v1.CodeConflict,
+			},
+		}, 409))
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		connection, err := sdk.NewDirectConnection(server.URL)
+		require.NoError(t, err)
+
+		c := NewResourceClient(nil, connection, nil)
+
+		_, err = c.Exists(t.Context(), AWSResourceID)
+		require.Error(t, err)
+		require.IsType(t, &ResourceError{}, err)
+	})
+}
+
 func newArmOptions(url string) *armauth.ArmConfig {
 	return &armauth.ArmConfig{
 		ClientOptions: clientv2.Options{
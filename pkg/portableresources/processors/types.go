@@ -70,6 +70,11 @@ type ResourceClient interface {
 	//
 	// If the API version is omitted, then an attempt will be made to look up the API version.
 	Delete(ctx context.Context, id string) error
+
+	// Exists returns true if the resource with the given id exists.
+	//
+	// If the API version is omitted, then an attempt will be made to look up the API version.
+	Exists(ctx context.Context, id string) (bool, error)
 }
 
 // ResourceError represents an error that occurred while processing a resource.
@@ -228,6 +228,11 @@ func getConfigurationV20250801(ctx context.Context, environment *v20250801previe
 		if tfProps.Terraformrc.ProviderInstallation != nil {
 			config.RecipeConfig.Terraform.ProviderInstallation = tfProps.Terraformrc.ProviderInstallation
 		}
+
+		// Map the state backend through to the shared driver. Nil keeps the Kubernetes Secret backend.
+		if tfProps.Backend != nil {
+			config.RecipeConfig.Terraform.Backend = tfProps.Backend
+		}
 	}
 
 	// Resolve BicepSettings resource if referenced.
@@ -381,6 +386,8 @@ func getRecipeDefinitionFromEnvironmentV20250801(ctx context.Context, environmen
 		templateVersion := ""
 		if strings.EqualFold(recipeDefinition.Kind, recipes.TemplateKindTerraform) {
 			templatePath, templateVersion = parseTerraformModuleSource(recipeDefinition.Source)
+		} else if strings.EqualFold(recipeDefinition.Kind, recipes.TemplateKindHelm) {
+			templatePath, templateVersion = parseHelmChartSource(recipeDefinition.Source)
 		}
 
 		// TODO: For now, we can set "Name" to default as recipe packs don't have named recipes.
@@ -446,6 +453,37 @@ func parseTerraformModuleSource(location string) (source string, version string)
 	return candidateSource, candidateVersion
 }
 
+// parseHelmChartSource splits a Helm chart reference in a recipe pack into the chart location and the
+// chart version. Helm charts are versioned separately from their location, so recipe packs encode the
+// version as a ":<version>" suffix on the chart name, for example "oci://ghcr.io/org/charts/redis:1.2.3"
+// yields source "oci://ghcr.io/org/charts/redis" and version "1.2.3", and
+// "https://charts.example.com/stable/redis:18.0.0" yields source "https://charts.example.com/stable/redis"
+// and version "18.0.0".
+//
+// Only a ":" in the final path segment denotes a version, so a registry "host:port" is never mistaken for
+// one. References without a version are returned unchanged with an empty version, which installs the
+// latest chart version.
+func parseHelmChartSource(location string) (source string, version string) {
+	lastSlash := strings.LastIndex(location, "/")
+	if lastSlash < 0 || strings.HasSuffix(location[:lastSlash+1], "://") {
+		return location, ""
+	}
+
+	rel := strings.IndexByte(location[lastSlash+1:], ':')
+	if rel < 0 {
+		return location, ""
+	}
+	colon := lastSlash + 1 + rel
+
+	candidateSource := location[:colon]
+	candidateVersion := location[colon+1:]
+	if candidateVersion == "" {
+		return location, ""
+	}
+
+	return candidateSource, candidateVersion
+}
+
 // fetchRecipeDefinition fetches recipe pack resources from the given recipe pack IDs and returns
 // the recipe definition from the first recipe pack that has a recipe defined for the specified resource type.
 // There cannot be more than one recipe pack with a recipe definition for the same resource type as part of an environment.
@@ -804,3 +804,58 @@ func Test_parseTerraformModuleSource(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseHelmChartSource(t *testing.T) {
+	tests := []struct {
+		name            string
+		location        string
+		expectedSource  string
+		expectedVersion string
+	}{
+		{
+			name:            "oci chart with version",
+			location:        "oci://ghcr.io/org/charts/redis:1.2.3",
+			expectedSource:  "oci://ghcr.io/org/charts/redis",
+			expectedVersion: "1.2.3",
+		},
+		{
+			name:            "oci chart with registry port and version",
+			location:        "oci://localhost:5000/charts/redis:1.2.3",
+			expectedSource:  "oci://localhost:5000/charts/redis",
+			expectedVersion: "1.2.3",
+		},
+		{
+			name:            "oci chart with registry port and no version is unchanged",
+			location:        "oci://localhost:5000/charts/redis",
+			expectedSource:  "oci://localhost:5000/charts/redis",
+			expectedVersion: "",
+		},
+		{
+			name:            "http repository chart with version",
+			location:        "https://charts.example.com/stable/redis:18.0.0",
+			expectedSource:  "https://charts.example.com/stable/redis",
+			expectedVersion: "18.0.0",
+		},
+		{
+			name:            "scheme with host only is unchanged",
+			location:        "oci://localhost:5000",
+			expectedSource:  "oci://localhost:5000",
+			expectedVersion: "",
+		},
+		{
+			name:            "trailing colon with empty version is unchanged",
+			location:        "oci://ghcr.io/org/charts/redis:",
+			expectedSource:  "oci://ghcr.io/org/charts/redis:",
+			expectedVersion: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			source, version := parseHelmChartSource(tt.location)
+			require.Equal(t, tt.expectedSource, source)
+			require.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
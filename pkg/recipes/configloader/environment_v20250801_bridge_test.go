@@ -97,6 +97,9 @@ func TestGetConfigurationV20250801_TerraformCredentialsAndEnvAndProviderInstalla
 							"TF_LOG":      to.Ptr("DEBUG"),
 							"TF_LOG_PATH": to.Ptr("/tmp/tf.log"),
 						},
+						Backend: &v20250801.TerraformBackendConfig{
+							Kind: to.Ptr(v20250801.TerraformBackendKindPostgresql),
+						},
 					},
 				},
 			}, nil)
@@ -128,6 +131,10 @@ func TestGetConfigurationV20250801_TerraformCredentialsAndEnvAndProviderInstalla
 	require.NotNil(t, cfg.RecipeConfig.Terraform.ProviderInstallation.NetworkMirror)
 	require.Equal(t, "https://mirror.example.com/", cfg.RecipeConfig.Terraform.ProviderInstallation.NetworkMirror.URL)
 	require.Equal(t, []string{"hashicorp/aws"}, cfg.RecipeConfig.Terraform.ProviderInstallation.NetworkMirror.Include)
+
+	// Backend forwarded by reference.
+	require.NotNil(t, cfg.RecipeConfig.Terraform.Backend)
+	require.Equal(t, "postgresql", cfg.RecipeConfig.Terraform.Backend.Kind)
 }
 
 func TestGetConfigurationV20250801_BicepBasicAuthMapped(t *testing.T) {
@@ -22,6 +22,7 @@ import (
 	"github.com/radius-project/radius/pkg/armrpc/hostoptions"
 	"github.com/radius-project/radius/pkg/azure/armauth"
 	aztoken "github.com/radius-project/radius/pkg/azure/tokencredentials"
+	"github.com/radius-project/radius/pkg/components/database/databaseprovider"
 	"github.com/radius-project/radius/pkg/components/kubernetesclient/kubernetesclientprovider"
 	"github.com/radius-project/radius/pkg/components/secret/secretprovider"
 	"github.com/radius-project/radius/pkg/portableresources/processors"
@@ -29,6 +30,8 @@ import (
 	"github.com/radius-project/radius/pkg/recipes/configloader"
 	"github.com/radius-project/radius/pkg/recipes/driver"
 	"github.com/radius-project/radius/pkg/recipes/driver/bicep"
+	"github.com/radius-project/radius/pkg/recipes/driver/helm"
+	"github.com/radius-project/radius/pkg/recipes/driver/kubernetes"
 	"github.com/radius-project/radius/pkg/recipes/driver/terraform"
 	"github.com/radius-project/radius/pkg/recipes/engine"
 	"github.com/radius-project/radius/pkg/sdk"
@@ -86,6 +89,8 @@ func New(options hostoptions.HostOptions) (*RecipeControllerConfig, error) {
 		options.Config.Bicep.DeleteRetryDelaySeconds = "10"
 	}
 
+	// The Bicep delete retry settings are used by every driver that deletes output resources with
+	// driver.DeleteOutputResources.
 	bicepDeleteRetryCount, err := strconv.Atoi(options.Config.Bicep.DeleteRetryCount)
 	if err != nil {
 		return nil, err
@@ -96,6 +101,13 @@ func New(options hostoptions.HostOptions) (*RecipeControllerConfig, error) {
 		return nil, err
 	}
 
+	resourceClient := processors.NewResourceClient(options.Arm, options.UCPConnection, cfg.Kubernetes)
+
+	postgreSQLURL, err := databaseprovider.PostgreSQLURL(options.Config.DatabaseProvider)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg.ConfigLoader = configloader.NewEnvironmentLoader(clientOptions)
 	cfg.Engine = engine.NewEngine(engine.Options{
 		ConfigurationLoader: cfg.ConfigLoader,
@@ -104,7 +116,7 @@ func New(options hostoptions.HostOptions) (*RecipeControllerConfig, error) {
 			recipes.TemplateKindBicep: bicep.NewBicepDriver(
 				clientOptions,
 				cfg.DeploymentEngineClient,
-				processors.NewResourceClient(options.Arm, options.UCPConnection, cfg.Kubernetes),
+				resourceClient,
 				bicep.BicepOptions{
 					DeleteRetryCount:        bicepDeleteRetryCount,
 					DeleteRetryDelaySeconds: bicepDeleteRetryDeleteSeconds,
@@ -112,9 +124,18 @@ func New(options hostoptions.HostOptions) (*RecipeControllerConfig, error) {
 			),
 			recipes.TemplateKindTerraform: terraform.NewTerraformDriver(options.UCPConnection, secretprovider.NewSecretProvider(options.Config.SecretProvider),
 				terraform.TerraformOptions{
-					Path:     options.Config.Terraform.Path,
-					LogLevel: options.Config.Terraform.LogLevel,
+					Path:          options.Config.Terraform.Path,
+					LogLevel:      options.Config.Terraform.LogLevel,
+					PostgreSQLURL: postgreSQLURL,
 				}, *cfg.Kubernetes),
+			recipes.TemplateKindHelm: helm.NewHelmDriver(resourceClient, helm.HelmOptions{
+				DeleteRetryCount:        bicepDeleteRetryCount,
+				DeleteRetryDelaySeconds: bicepDeleteRetryDeleteSeconds,
+			}),
+			recipes.TemplateKindKubernetes: kubernetes.NewKubernetesDriver(resourceClient, kubernetes.KubernetesOptions{
+				DeleteRetryCount:        bicepDeleteRetryCount,
+				DeleteRetryDelaySeconds: bicepDeleteRetryDeleteSeconds,
+			}),
 		},
 	})
 
@@ -28,10 +28,11 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armdeployments"
 	"github.com/go-logr/logr"
-	"golang.org/x/sync/errgroup"
+	"go.opentelemetry.io/otel/attribute"
 	"oras.land/oras-go/v2/registry/remote"
 
 	"github.com/radius-project/radius/pkg/components/metrics"
+	"github.com/radius-project/radius/pkg/components/trace"
 	coredm "github.com/radius-project/radius/pkg/corerp/datamodel"
 	"github.com/radius-project/radius/pkg/portableresources/datamodel"
 	"github.com/radius-project/radius/pkg/portableresources/processors"
@@ -47,7 +48,6 @@ import (
 	"github.com/radius-project/radius/pkg/sdk/clients"
 	"github.com/radius-project/radius/pkg/ucp/resources"
 	resources_radius "github.com/radius-project/radius/pkg/ucp/resources/radius"
-	"github.com/radius-project/radius/pkg/ucp/ucplog"
 )
 
 const (
@@ -57,6 +57,7 @@ const (
 )
 
 var _ driver.Driver = (*bicepDriver)(nil)
+var _ driver.DriverWithPlan = (*bicepDriver)(nil)
 
 // NewBicepDriver creates a new bicep driver instance with the given ARM client options, deployment client, resource client, and options.
 func NewBicepDriver(armOptions *arm.ClientOptions, deploymentClient clients.ResourceDeploymentsClient, client processors.ResourceClient, options BicepOptions) driver.Driver {
@@ -88,62 +89,23 @@ type bicepDriver struct {
 // Execute fetches recipe contents from container registry, creates a deployment ID, a recipe context parameter, recipe parameters,
 // a provider config, and deploys a bicep template for the recipe using UCP deployment client, then polls until the deployment
 // is done and prepares the recipe response.
-func (d *bicepDriver) Execute(ctx context.Context, opts driver.ExecuteOptions) (*recipes.RecipeOutput, error) {
+func (d *bicepDriver) Execute(ctx context.Context, opts driver.ExecuteOptions) (_ *recipes.RecipeOutput, err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "bicepdriver.Execute", &opts.Recipe, &opts.Definition)
+	defer func() { trace.EndSpan(span, err) }()
+
 	logger := logr.FromContextOrDiscard(ctx)
 	logger.Info(fmt.Sprintf("Deploying recipe: %q, template: %q", opts.Definition.Name, opts.Definition.TemplatePath))
 
-	recipeData := make(map[string]any)
-	downloadStartTime := time.Now()
-	secrets, err := util.GetRegistrySecrets(opts.Configuration, opts.Definition.TemplatePath, opts.Secrets)
+	recipeData, err := d.downloadRecipe(ctx, opts.BaseOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	registryClient := d.RegistryClient
-	// Get ORAS authentication client if secrets are found for the registry.
-	if !reflect.DeepEqual(secrets, recipes.SecretData{}) {
-		authClient, err := getRegistryAuthClient(ctx, secrets, opts.Definition.TemplatePath)
-		if err != nil {
-			return nil, err
-		}
-
-		registryClient = authClient
-	}
-
-	err = util.ReadFromRegistry(ctx, opts.Definition, &recipeData, registryClient)
-	if err != nil {
-		metrics.DefaultRecipeEngineMetrics.RecordRecipeDownloadDuration(ctx, downloadStartTime,
-			metrics.NewRecipeAttributes(metrics.RecipeEngineOperationDownloadRecipe, opts.Recipe.Name, &opts.Definition, recipes.RecipeDownloadFailed))
-		return nil, recipes.NewRecipeError(recipes.RecipeDownloadFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
-	}
-	metrics.DefaultRecipeEngineMetrics.RecordRecipeDownloadDuration(ctx, downloadStartTime,
-		metrics.NewRecipeAttributes(metrics.RecipeEngineOperationDownloadRecipe, opts.Recipe.Name, &opts.Definition, metrics.SuccessfulOperationState))
-
-	// create the context object to be passed to the recipe deployment
-	recipeContext, err := recipecontext.New(&opts.Recipe, &opts.Configuration)
+	parameters, recipeContext, err := resolveParameters(ctx, opts.BaseOptions, recipeData)
 	if err != nil {
 		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
 	}
 
-	//update the recipe context with connected resources properties
-	recipeContext.Resource.Connections = opts.Recipe.ConnectedResourcesProperties
-
-	// get the parameters after resolving the conflict between developer and operator parameters
-	// if the recipe template also has the context parameter defined then add it to the parameter for deployment
-	isContextParameterDefined := hasContextParameter(recipeData)
-
-	var parameters map[string]any
-	if isContextParameterDefined {
-		// Wrapped recipe — use the existing context injection flow.
-		parameters = createRecipeParameters(opts.Recipe.Parameters, opts.Definition.Parameters, true, recipeContext)
-	} else {
-		// Direct module — resolve {{context.*}} expressions, merge parameters, and wrap as ARM parameters.
-		// Resource (developer) parameters take precedence over environment (operator) parameters.
-		mergedParams := recipes_util.ShallowMergeParameters(opts.Definition.Parameters, opts.Recipe.Parameters)
-		resolvedParams := paramresolver.ResolveParameterExpressions(mergedParams, recipeContext)
-		parameters = wrapARMParameters(resolvedParams)
-	}
-
 	deploymentName := deploymentPrefix + strconv.FormatInt(time.Now().UnixNano(), 10)
 	deploymentID, err := createDeploymentID(recipeContext.Resource.ID, deploymentName)
 	if err != nil {
@@ -161,8 +123,10 @@ func (d *bicepDriver) Execute(ctx context.Context, opts driver.ExecuteOptions) (
 		logger.Info("using Azure provider", "deploymentID", deploymentID, "scope", providerConfig.Az.Value.Scope)
 	}
 
+	deployCtx, deploySpan := trace.StartRecipeSpan(ctx, "bicepdriver.Deployment", &opts.Recipe, &opts.Definition)
+	deploySpan.SetAttributes(attribute.String("deployment.id", deploymentID.String()))
 	poller, err := d.DeploymentClient.CreateOrUpdate(
-		ctx,
+		deployCtx,
 		clients.Deployment{
 			Properties: &clients.DeploymentProperties{
 				Mode:           armdeployments.DeploymentModeIncremental,
@@ -176,10 +140,12 @@ func (d *bicepDriver) Execute(ctx context.Context, opts driver.ExecuteOptions) (
 	)
 
 	if err != nil {
+		trace.EndSpan(deploySpan, err)
 		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, fmt.Sprintf("failed to deploy recipe %s of type %s", opts.BaseOptions.Recipe.Name, opts.BaseOptions.Definition.ResourceType), recipes_util.ExecutionError, recipes.GetErrorDetails(err))
 	}
 
-	resp, err := poller.PollUntilDone(ctx, &clients.PollUntilDoneOptions{Frequency: pollFrequency})
+	resp, err := poller.PollUntilDone(deployCtx, &clients.PollUntilDoneOptions{Frequency: pollFrequency})
+	trace.EndSpan(deploySpan, err)
 	if err != nil {
 		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, fmt.Sprintf("failed to deploy recipe %s of type %s", opts.BaseOptions.Recipe.Name, opts.BaseOptions.Definition.ResourceType), recipes_util.ExecutionError, recipes.GetErrorDetails(err))
 	}
@@ -206,15 +172,17 @@ func (d *bicepDriver) Execute(ctx context.Context, opts driver.ExecuteOptions) (
 	// as bicep does not take care of automatically deleting the unused resources.
 	// Identify the output resources that are no longer relevant to the recipe.
 	garbageCollectionStartTime := time.Now()
-	diff, err := d.getGCOutputResources(recipeResponse.Resources, opts.PrevState)
+	diff, err := driver.GetGCOutputResources(recipeResponse.Resources, opts.PrevState)
 	if err != nil {
 		return nil, err
 	}
 
 	// Deleting obsolete output resources.
-	err = d.Delete(ctx, driver.DeleteOptions{
+	gcCtx, gcSpan := trace.StartRecipeSpan(ctx, "bicepdriver.GarbageCollection", &opts.Recipe, &opts.Definition)
+	err = d.Delete(gcCtx, driver.DeleteOptions{
 		OutputResources: diff,
 	})
+	trace.EndSpan(gcSpan, err)
 	if err != nil {
 		metrics.DefaultRecipeEngineMetrics.RecordRecipeGarbageCollectionDuration(ctx, garbageCollectionStartTime,
 			metrics.NewRecipeAttributes(metrics.RecipeEngineOperationGC, opts.Recipe.Name, &opts.Definition, metrics.FailedOperationState))
@@ -225,63 +193,185 @@ func (d *bicepDriver) Execute(ctx context.Context, opts driver.ExecuteOptions) (
 	return recipeResponse, nil
 }
 
-// Delete deletes all of the output resources that are marked as managed by Radius.
-// It will create a goroutine for each resource to be deleted and wait for them to finish,
-// retrying if necessary.
-// We don't have context on the dependency ordering here, so we need to try to delete them
-// all in parallel. Since some resources may depend on others, we may need to retry.
-func (d *bicepDriver) Delete(ctx context.Context, opts driver.DeleteOptions) error {
-	logger := ucplog.FromContextOrDiscard(ctx)
+// Plan fetches recipe contents from container registry, resolves the recipe parameters as Execute does, and runs
+// a what-if operation of the bicep template using UCP deployment client. It returns the changes that deploying the
+// recipe would make, with the values of secure parameters redacted.
+func (d *bicepDriver) Plan(ctx context.Context, opts driver.PlanOptions) (_ *recipes.RecipePlan, err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "bicepdriver.Plan", &opts.Recipe, &opts.Definition)
+	defer func() { trace.EndSpan(span, err) }()
 
-	// Create a waitgroup to track the deletion of each output resource
-	g, groupCtx := errgroup.WithContext(ctx)
+	logger := logr.FromContextOrDiscard(ctx)
+	logger.Info(fmt.Sprintf("Planning recipe: %q, template: %q", opts.Definition.Name, opts.Definition.TemplatePath))
 
-	for i := range opts.OutputResources {
-		outputResource := opts.OutputResources[i]
+	// The template is not needed to detect the changes made outside of the recipe. Bicep deployments keep no
+	// state, so the output resources are read again instead.
+	if opts.RefreshOnly {
+		return d.planRefreshOnly(ctx, opts)
+	}
 
-		// Create a goroutine that handles the deletion of one resource
-		g.Go(func() error {
-			id := outputResource.ID.String()
-			logger.V(ucplog.LevelInfo).Info(fmt.Sprintf("Deleting output resource: %v, LocalID: %s, resource type: %s\n", outputResource.ID, outputResource.LocalID, outputResource.GetResourceType()))
+	recipeData, err := d.downloadRecipe(ctx, opts.BaseOptions)
+	if err != nil {
+		return nil, err
+	}
 
-			// If the resource is not managed by Radius, skip the deletion
-			if outputResource.RadiusManaged == nil || !*outputResource.RadiusManaged {
-				logger.Info(fmt.Sprintf("Skipping deletion of output resource: %q, not managed by Radius", id))
-				return nil
-			}
+	parameters, recipeContext, err := resolveParameters(ctx, opts.BaseOptions, recipeData)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipePlanFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
 
-			var err error
-			for attempt := 0; attempt <= d.options.DeleteRetryCount; attempt++ {
-				logger.WithValues("attempt", attempt)
-				ctx := logr.NewContext(groupCtx, logger)
-				logger.V(ucplog.LevelDebug).Info("beginning attempt")
-
-				err = d.ResourceClient.Delete(ctx, id)
-				if err != nil {
-					if attempt <= d.options.DeleteRetryCount {
-						logger.V(ucplog.LevelInfo).Error(err, "attempt failed", "delay", d.options.DeleteRetryDelaySeconds)
-						time.Sleep(time.Duration(d.options.DeleteRetryDelaySeconds) * time.Second)
-						continue
-					}
+	deploymentName := deploymentPrefix + strconv.FormatInt(time.Now().UnixNano(), 10)
+	deploymentID, err := createDeploymentID(recipeContext.Resource.ID, deploymentName)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipePlanFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
 
-					return recipes.NewRecipeError(recipes.RecipeDeletionFailed, err.Error(), "", recipes.GetErrorDetails(err))
-				}
+	providerConfig := newProviderConfig(deploymentID.FindScope(resources_radius.ScopeResourceGroups), opts.Configuration.Providers)
 
-				// If the err is nil, then the resource is deleted successfully
-				logger.V(ucplog.LevelInfo).Info(fmt.Sprintf("Deleted output resource: %q", id))
-				return nil
-			}
+	whatIfCtx, whatIfSpan := trace.StartRecipeSpan(ctx, "bicepdriver.WhatIf", &opts.Recipe, &opts.Definition)
+	whatIfSpan.SetAttributes(attribute.String("deployment.id", deploymentID.String()))
+	poller, err := d.DeploymentClient.WhatIf(
+		whatIfCtx,
+		clients.Deployment{
+			Properties: &clients.DeploymentProperties{
+				Mode:           armdeployments.DeploymentModeIncremental,
+				ProviderConfig: &providerConfig,
+				Parameters:     parameters,
+				Template:       recipeData,
+			},
+		},
+		deploymentID.String(),
+		clients.DeploymentsClientAPIVersion,
+	)
+	if err != nil {
+		trace.EndSpan(whatIfSpan, err)
+		return nil, recipes.NewRecipeError(recipes.RecipePlanFailed, fmt.Sprintf("failed to plan recipe %s of type %s", opts.Recipe.Name, opts.Definition.ResourceType), recipes_util.ExecutionError, recipes.GetErrorDetails(err))
+	}
 
-			deletionErr := fmt.Errorf("failed to delete resource after %d attempt(s), last error: %s", d.options.DeleteRetryCount+1, err.Error())
-			return recipes.NewRecipeError(recipes.RecipeDeletionFailed, deletionErr.Error(), "", recipes.GetErrorDetails(deletionErr))
-		})
+	resp, err := poller.PollUntilDone(whatIfCtx, &clients.PollUntilDoneOptions{Frequency: pollFrequency})
+	trace.EndSpan(whatIfSpan, err)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipePlanFailed, fmt.Sprintf("failed to plan recipe %s of type %s", opts.Recipe.Name, opts.Definition.ResourceType), recipes_util.ExecutionError, recipes.GetErrorDetails(err))
+	}
+	if resp.Error != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipePlanFailed, fmt.Sprintf("failed to plan recipe %s of type %s", opts.Recipe.Name, opts.Definition.ResourceType), recipes_util.ExecutionError, toErrorDetails(resp.Error))
 	}
 
-	if err := g.Wait(); err != nil {
-		return err
+	return preparePlanResponse(opts.Definition, recipeData, parameters, resp.WhatIfOperationResult, opts.PrevState), nil
+}
+
+// planRefreshOnly plans the output resources of the previous deployment of a recipe that were deleted outside of
+// the recipe. The properties of the output resources are not compared, because the deployment does not record them.
+func (d *bicepDriver) planRefreshOnly(ctx context.Context, opts driver.PlanOptions) (*recipes.RecipePlan, error) {
+	plan := &recipes.RecipePlan{
+		Changes: []recipes.ResourceChange{},
+		Status: &rpv1.RecipeStatus{
+			TemplateKind:    recipes.TemplateKindBicep,
+			TemplatePath:    opts.Definition.TemplatePath,
+			TemplateVersion: opts.Definition.TemplateVersion,
+		},
 	}
 
-	return nil
+	for _, id := range opts.PrevState {
+		exists, err := d.ResourceClient.Exists(ctx, id)
+		if err != nil {
+			return nil, recipes.NewRecipeError(recipes.RecipePlanFailed, fmt.Sprintf("failed to read output resource %s: %s", id, err.Error()), recipes_util.ExecutionError, recipes.GetErrorDetails(err))
+		}
+		if !exists {
+			plan.Changes = append(plan.Changes, recipes.ResourceChange{
+				ID:     id,
+				Type:   resourceType(id),
+				Action: recipes.ChangeActionDelete,
+			})
+		}
+	}
+
+	slices.SortFunc(plan.Changes, func(a, b recipes.ResourceChange) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return plan, nil
+}
+
+// downloadRecipe fetches the recipe template from the container registry, authenticating with the registry
+// credentials from the recipe configuration when they are available.
+func (d *bicepDriver) downloadRecipe(ctx context.Context, opts driver.BaseOptions) (map[string]any, error) {
+	recipeData := make(map[string]any)
+	downloadStartTime := time.Now()
+	secrets, err := util.GetRegistrySecrets(opts.Configuration, opts.Definition.TemplatePath, opts.Secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	registryClient := d.RegistryClient
+	// Get ORAS authentication client if secrets are found for the registry.
+	if !reflect.DeepEqual(secrets, recipes.SecretData{}) {
+		authClient, err := getRegistryAuthClient(ctx, secrets, opts.Definition.TemplatePath)
+		if err != nil {
+			return nil, err
+		}
+
+		registryClient = authClient
+	}
+
+	downloadCtx, downloadSpan := trace.StartRecipeSpan(ctx, "bicepdriver.DownloadRecipe", &opts.Recipe, &opts.Definition)
+	err = util.ReadFromRegistry(downloadCtx, opts.Definition, &recipeData, registryClient)
+	trace.EndSpan(downloadSpan, err)
+	if err != nil {
+		metrics.DefaultRecipeEngineMetrics.RecordRecipeDownloadDuration(ctx, downloadStartTime,
+			metrics.NewRecipeAttributes(metrics.RecipeEngineOperationDownloadRecipe, opts.Recipe.Name, &opts.Definition, recipes.RecipeDownloadFailed))
+		return nil, recipes.NewRecipeError(recipes.RecipeDownloadFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+	metrics.DefaultRecipeEngineMetrics.RecordRecipeDownloadDuration(ctx, downloadStartTime,
+		metrics.NewRecipeAttributes(metrics.RecipeEngineOperationDownloadRecipe, opts.Recipe.Name, &opts.Definition, metrics.SuccessfulOperationState))
+
+	return recipeData, nil
+}
+
+// resolveParameters creates the recipe context and returns the deployment parameters for the recipe template,
+// after resolving the conflicts between developer and operator parameters.
+func resolveParameters(ctx context.Context, opts driver.BaseOptions, recipeData map[string]any) (map[string]any, *recipecontext.Context, error) {
+	// create the context object to be passed to the recipe deployment
+	_, resolveSpan := trace.StartRecipeSpan(ctx, "bicepdriver.ResolveParameters", &opts.Recipe, &opts.Definition)
+	recipeContext, err := recipecontext.New(&opts.Recipe, &opts.Configuration)
+	if err != nil {
+		trace.EndSpan(resolveSpan, err)
+		return nil, nil, err
+	}
+
+	//update the recipe context with connected resources properties
+	recipeContext.Resource.Connections = opts.Recipe.ConnectedResourcesProperties
+
+	// get the parameters after resolving the conflict between developer and operator parameters
+	// if the recipe template also has the context parameter defined then add it to the parameter for deployment
+	isContextParameterDefined := hasContextParameter(recipeData)
+
+	var parameters map[string]any
+	if isContextParameterDefined {
+		// Wrapped recipe — use the existing context injection flow.
+		parameters = createRecipeParameters(opts.Recipe.Parameters, opts.Definition.Parameters, true, recipeContext)
+	} else {
+		// Direct module — resolve {{context.*}} expressions, merge parameters, and wrap as ARM parameters.
+		// Resource (developer) parameters take precedence over environment (operator) parameters.
+		mergedParams := recipes_util.ShallowMergeParameters(opts.Definition.Parameters, opts.Recipe.Parameters)
+		resolvedParams := paramresolver.ResolveParameterExpressions(mergedParams, recipeContext)
+		parameters = wrapARMParameters(resolvedParams)
+	}
+	trace.EndSpan(resolveSpan, nil)
+
+	return parameters, recipeContext, nil
+}
+
+// Delete deletes all of the output resources that are marked as managed by Radius.
+// It will create a goroutine for each resource to be deleted and wait for them to finish,
+// retrying if necessary.
+// We don't have context on the dependency ordering here, so we need to try to delete them
+// all in parallel. Since some resources may depend on others, we may need to retry.
+func (d *bicepDriver) Delete(ctx context.Context, opts driver.DeleteOptions) (err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "bicepdriver.Delete", &opts.Recipe, &opts.Definition)
+	span.SetAttributes(attribute.Int("recipe.output_resources", len(opts.OutputResources)))
+	defer func() { trace.EndSpan(span, err) }()
+
+	return driver.DeleteOutputResources(ctx, d.ResourceClient, opts.OutputResources, d.options.DeleteRetryCount, time.Duration(d.options.DeleteRetryDelaySeconds)*time.Second)
 }
 
 // GetRecipeMetadata gets the Bicep recipe parameters information from the container registry
@@ -517,31 +607,6 @@ func wrapARMParameters(params map[string]any) map[string]any {
 	return wrapped
 }
 
-// getGCOutputResources [GC stands for Garbage Collection] compares two slices of resource ids and
-// returns a slice of OutputResources that contains the elements that are in the "previous" slice but not in the "current".
-func (d *bicepDriver) getGCOutputResources(current []string, previous []string) ([]rpv1.OutputResource, error) {
-	// We can easily determine which resources have changed via a brute-force search comparing IDs.
-	// The lists of resources we work with are small, so this is fine.
-	diff := []rpv1.OutputResource{}
-	for _, prevResourceId := range previous {
-		found := slices.Contains(current, prevResourceId)
-
-		if !found {
-			id, err := resources.Parse(prevResourceId)
-			if err != nil {
-				return nil, recipes.NewRecipeError(recipes.RecipeGarbageCollectionFailed, err.Error(), recipes_util.ExecutionError, nil)
-			}
-
-			diff = append(diff, rpv1.OutputResource{
-				ID:            id,
-				RadiusManaged: new(true),
-			})
-		}
-	}
-
-	return diff, nil
-}
-
 func (d *bicepDriver) FindSecretIDs(ctx context.Context, envConfig recipes.Configuration, definition recipes.EnvironmentDefinition) (secretStoreIDResourceKeys map[string][]string, err error) {
 	secretStoreIDResourceKeys = make(map[string][]string)
 	if envConfig.RecipeConfig.Bicep.Authentication != nil {
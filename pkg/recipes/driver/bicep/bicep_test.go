@@ -17,9 +17,12 @@ limitations under the License.
 package bicep
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armdeployments"
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
@@ -513,45 +516,65 @@ func Test_Bicep_GetRecipeMetadata_Error(t *testing.T) {
 	require.Equal(t, actualErr, &expErr)
 }
 
-func Test_GetGCOutputResources(t *testing.T) {
-	d := &bicepDriver{}
-	before := []string{
-		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource1",
-		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource2",
-	}
-	after := []string{
-		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource1",
-		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource3",
-	}
+func Test_Bicep_Delete_NoWaitAfterLastAttempt(t *testing.T) {
+	driverBicep, client := setupDeleteInputs(t)
+	driverBicep.options.DeleteRetryDelaySeconds = 60
 
-	expId := "/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource2"
-	id, err := resources.Parse(expId)
-	require.NoError(t, err)
-	exp := []rpv1.OutputResource{
+	outputResources := []rpv1.OutputResource{
 		{
-			ID:            id,
+			ID:            resources_kubernetes.IDFromParts(resources_kubernetes.PlaneNameTODO, "core", "Deployment", "recipe-app", "redis"),
 			RadiusManaged: new(true),
 		},
 	}
-	res, err := d.getGCOutputResources(after, before)
-	require.NoError(t, err)
-	require.Equal(t, exp, res)
+
+	client.EXPECT().
+		Delete(gomock.Any(), "/planes/kubernetes/local/namespaces/recipe-app/providers/core/Deployment/redis").
+		Return(errors.New("could not delete")).
+		Times(1)
+
+	// The only attempt fails, so the error is returned without waiting for the retry delay.
+	start := time.Now()
+	err := driverBicep.Delete(t.Context(), driver.DeleteOptions{OutputResources: outputResources})
+	require.Less(t, time.Since(start), 30*time.Second)
+
+	recipeError := &recipes.RecipeError{}
+	require.ErrorAs(t, err, &recipeError)
+	require.Equal(t, recipes.RecipeDeletionFailed, recipeError.ErrorDetails.Code)
+	require.Equal(t, "failed to delete resource after 1 attempt(s), last error: could not delete", recipeError.ErrorDetails.Message)
 }
 
-func Test_GetGCOutputResources_NoDiff(t *testing.T) {
-	d := &bicepDriver{}
-	before := []string{
-		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource1",
-		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource2",
-	}
-	after := []string{
-		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource1",
-		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource2",
+func Test_Bicep_Delete_CanceledDuringRetryDelay(t *testing.T) {
+	driverBicep, client := setupDeleteInputs(t)
+	driverBicep.options.DeleteRetryCount = 3
+	driverBicep.options.DeleteRetryDelaySeconds = 60
+
+	outputResources := []rpv1.OutputResource{
+		{
+			ID:            resources_kubernetes.IDFromParts(resources_kubernetes.PlaneNameTODO, "core", "Deployment", "recipe-app", "redis"),
+			RadiusManaged: new(true),
+		},
 	}
-	exp := []rpv1.OutputResource{}
-	res, err := d.getGCOutputResources(after, before)
-	require.NoError(t, err)
-	require.Equal(t, exp, res)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	client.EXPECT().
+		Delete(gomock.Any(), "/planes/kubernetes/local/namespaces/recipe-app/providers/core/Deployment/redis").
+		DoAndReturn(func(ctx context.Context, id string) error {
+			cancel()
+			return errors.New("could not delete")
+		}).
+		Times(1)
+
+	// The wait before the next attempt stops when the context is canceled.
+	start := time.Now()
+	err := driverBicep.Delete(ctx, driver.DeleteOptions{OutputResources: outputResources})
+	require.Less(t, time.Since(start), 30*time.Second)
+
+	recipeError := &recipes.RecipeError{}
+	require.ErrorAs(t, err, &recipeError)
+	require.Equal(t, recipes.RecipeDeletionFailed, recipeError.ErrorDetails.Code)
+	require.Equal(t, context.Canceled.Error(), recipeError.ErrorDetails.Message)
 }
 
 func Test_Bicep_Delete_Success_AfterRetry(t *testing.T) {
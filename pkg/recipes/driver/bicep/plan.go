@@ -0,0 +1,277 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bicep
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armdeployments"
+
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/recipes"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+// preparePlanResponse converts the result of the what-if operation of a recipe to a recipe plan. Resources that would
+// not change are omitted, and values that contain the value of a secure parameter of the template are redacted.
+// Resources of the previous deployment that are no longer part of the template are planned for deletion, since they
+// are garbage collected when the recipe is deployed.
+func preparePlanResponse(definition recipes.EnvironmentDefinition, template map[string]any, parameters map[string]any, result armdeployments.WhatIfOperationResult, prevState []string) *recipes.RecipePlan {
+	plan := &recipes.RecipePlan{
+		Changes: []recipes.ResourceChange{},
+		Status: &rpv1.RecipeStatus{
+			TemplateKind:    recipes.TemplateKindBicep,
+			TemplatePath:    definition.TemplatePath,
+			TemplateVersion: definition.TemplateVersion,
+		},
+	}
+
+	secrets := secureParameterValues(template, parameters)
+
+	planned := map[string]struct{}{}
+	if result.Properties != nil {
+		for _, wc := range result.Properties.Changes {
+			if wc == nil || wc.ResourceID == nil {
+				continue
+			}
+			planned[strings.ToLower(*wc.ResourceID)] = struct{}{}
+
+			action := whatIfChangeAction(wc.ChangeType)
+			if action == "" {
+				continue
+			}
+
+			change := recipes.ResourceChange{
+				ID:     *wc.ResourceID,
+				Type:   resourceType(*wc.ResourceID),
+				Action: action,
+			}
+
+			switch *wc.ChangeType {
+			case armdeployments.ChangeTypeCreate:
+				change.Attributes = createdAttributes(wc.After)
+			case armdeployments.ChangeTypeModify:
+				change.Attributes = flattenDelta("", wc.Delta)
+			}
+
+			for i := range change.Attributes {
+				redact(&change.Attributes[i], secrets)
+			}
+
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	for _, id := range prevState {
+		if _, ok := planned[strings.ToLower(id)]; ok {
+			continue
+		}
+
+		plan.Changes = append(plan.Changes, recipes.ResourceChange{
+			ID:     id,
+			Type:   resourceType(id),
+			Action: recipes.ChangeActionDelete,
+		})
+	}
+
+	sort.Slice(plan.Changes, func(i, j int) bool {
+		return plan.Changes[i].ID < plan.Changes[j].ID
+	})
+
+	return plan
+}
+
+// whatIfChangeAction returns the recipe plan action for the change type of a what-if change, or an empty string if
+// the resource would not change.
+func whatIfChangeAction(changeType *armdeployments.ChangeType) string {
+	if changeType == nil {
+		return ""
+	}
+
+	switch *changeType {
+	case armdeployments.ChangeTypeCreate:
+		return recipes.ChangeActionCreate
+	case armdeployments.ChangeTypeModify, armdeployments.ChangeTypeDeploy:
+		return recipes.ChangeActionUpdate
+	case armdeployments.ChangeTypeDelete:
+		return recipes.ChangeActionDelete
+	default:
+		// NoChange, Ignore and Unsupported.
+		return ""
+	}
+}
+
+// resourceType returns the type of the resource ID, or an empty string if the ID cannot be parsed.
+func resourceType(id string) string {
+	parsed, err := resources.ParseResource(id)
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Type()
+}
+
+// createdAttributes returns the top-level properties of a resource that would be created.
+func createdAttributes(after any) []recipes.AttributeChange {
+	properties, _ := after.(map[string]any)
+
+	changes := []recipes.AttributeChange{}
+	for path, value := range properties {
+		if value == nil {
+			continue
+		}
+		changes = append(changes, recipes.AttributeChange{Path: path, After: value})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+// flattenDelta returns the leaf property changes of a what-if delta, with the paths of nested properties joined by
+// dots. Changes that have no effect are omitted.
+func flattenDelta(prefix string, delta []*armdeployments.WhatIfPropertyChange) []recipes.AttributeChange {
+	changes := []recipes.AttributeChange{}
+	for _, pc := range delta {
+		if pc == nil || (pc.PropertyChangeType != nil && *pc.PropertyChangeType == armdeployments.PropertyChangeTypeNoEffect) {
+			continue
+		}
+
+		path := to.String(pc.Path)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		if len(pc.Children) > 0 {
+			changes = append(changes, flattenDelta(path, pc.Children)...)
+			continue
+		}
+
+		changes = append(changes, recipes.AttributeChange{Path: path, Before: pc.Before, After: pc.After})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+// secureParameterValues returns the values of the parameters that the template declares as secureString or
+// secureObject. The parameters are in the ARM format, where each value is wrapped in an object with a value property.
+func secureParameterValues(template map[string]any, parameters map[string]any) []any {
+	definitions, _ := template[recipeParameters].(map[string]any)
+
+	secrets := []any{}
+	for name, definition := range definitions {
+		d, _ := definition.(map[string]any)
+		kind, _ := d["type"].(string)
+		if !strings.EqualFold(kind, "securestring") && !strings.EqualFold(kind, "secureobject") {
+			continue
+		}
+
+		wrapped, _ := parameters[name].(map[string]any)
+		if value, ok := wrapped["value"]; ok && value != nil && value != "" {
+			secrets = append(secrets, value)
+			continue
+		}
+
+		// The template default value is used when the parameter is not set.
+		if value, ok := d["defaultValue"]; ok && value != nil && value != "" {
+			secrets = append(secrets, value)
+		}
+	}
+
+	return secrets
+}
+
+// redact replaces the values of an attribute change that contain a secret, in part or in whole.
+func redact(change *recipes.AttributeChange, secrets []any) {
+	if !containsSecret(change.Before, secrets) && !containsSecret(change.After, secrets) {
+		return
+	}
+
+	change.Sensitive = true
+	if change.Before != nil {
+		change.Before = recipes.SensitiveValue
+	}
+	if change.After != nil {
+		change.After = recipes.SensitiveValue
+	}
+}
+
+// containsSecret returns true if the value is one of the secrets, or contains one of them.
+func containsSecret(value any, secrets []any) bool {
+	if value == nil {
+		return false
+	}
+
+	for _, secret := range secrets {
+		if reflect.DeepEqual(value, secret) {
+			return true
+		}
+
+		if s, ok := secret.(string); ok {
+			if v, ok := value.(string); ok && strings.Contains(v, s) {
+				return true
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, item := range v {
+			if containsSecret(item, secrets) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if containsSecret(item, secrets) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// toErrorDetails converts the error of a what-if operation to error details.
+func toErrorDetails(e *armdeployments.ErrorResponse) *v1.ErrorDetails {
+	if e == nil {
+		return nil
+	}
+
+	details := &v1.ErrorDetails{
+		Code:    to.String(e.Code),
+		Message: to.String(e.Message),
+		Target:  to.String(e.Target),
+	}
+	for _, d := range e.Details {
+		if inner := toErrorDetails(d); inner != nil {
+			details.Details = append(details.Details, inner)
+		}
+	}
+
+	return details
+}
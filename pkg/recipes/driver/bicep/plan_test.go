@@ -0,0 +1,260 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bicep
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armdeployments"
+	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
+	"github.com/radius-project/radius/pkg/portableresources/processors"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/driver"
+	"github.com/radius-project/radius/pkg/rp/util/registrytest"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/sdk/clients"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	planStorageID = "/planes/aws/aws/accounts/000/regions/us-east-1/providers/AWS.S3/Bucket/bucket"
+	planQueueID   = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ServiceBus/namespaces/queue"
+	planRedisID   = "/planes/kubernetes/local/namespaces/default/providers/core/Service/redis"
+)
+
+func Test_PreparePlanResponse(t *testing.T) {
+	template := map[string]any{
+		"parameters": map[string]any{
+			"password": map[string]any{"type": "secureString"},
+			"size":     map[string]any{"type": "string"},
+		},
+	}
+	parameters := map[string]any{
+		"password": map[string]any{"value": "s3cr3t"},
+		"size":     map[string]any{"value": "large"},
+	}
+
+	result := armdeployments.WhatIfOperationResult{
+		Properties: &armdeployments.WhatIfOperationProperties{
+			Changes: []*armdeployments.WhatIfChange{
+				{
+					ResourceID: to.Ptr(planStorageID),
+					ChangeType: to.Ptr(armdeployments.ChangeTypeCreate),
+					After: map[string]any{
+						"name":       "bucket",
+						"properties": map[string]any{"connectionString": "user:s3cr3t@host"},
+					},
+				},
+				{
+					ResourceID: to.Ptr(planQueueID),
+					ChangeType: to.Ptr(armdeployments.ChangeTypeModify),
+					Delta: []*armdeployments.WhatIfPropertyChange{
+						{
+							Path:               to.Ptr("properties"),
+							PropertyChangeType: to.Ptr(armdeployments.PropertyChangeTypeModify),
+							Children: []*armdeployments.WhatIfPropertyChange{
+								{
+									Path:               to.Ptr("size"),
+									PropertyChangeType: to.Ptr(armdeployments.PropertyChangeTypeModify),
+									Before:             "small",
+									After:              "large",
+								},
+								{
+									Path:               to.Ptr("etag"),
+									PropertyChangeType: to.Ptr(armdeployments.PropertyChangeTypeNoEffect),
+									Before:             "a",
+									After:              "b",
+								},
+							},
+						},
+					},
+				},
+				{
+					ResourceID: to.Ptr("/planes/kubernetes/local/namespaces/default/providers/core/Secret/unchanged"),
+					ChangeType: to.Ptr(armdeployments.ChangeTypeNoChange),
+				},
+			},
+		},
+	}
+
+	prevState := []string{
+		"/planes/kubernetes/local/namespaces/default/providers/core/Secret/Unchanged",
+		planRedisID,
+	}
+
+	definition := recipes.EnvironmentDefinition{TemplatePath: "registry/recipe:1.0"}
+	plan := preparePlanResponse(definition, template, parameters, result, prevState)
+
+	expected := &recipes.RecipePlan{
+		Changes: []recipes.ResourceChange{
+			{
+				ID:     planStorageID,
+				Type:   "AWS.S3/Bucket",
+				Action: recipes.ChangeActionCreate,
+				Attributes: []recipes.AttributeChange{
+					{Path: "name", After: "bucket"},
+					{Path: "properties", After: recipes.SensitiveValue, Sensitive: true},
+				},
+			},
+			{
+				ID:     planRedisID,
+				Type:   "core/Service",
+				Action: recipes.ChangeActionDelete,
+			},
+			{
+				ID:     planQueueID,
+				Type:   "Microsoft.ServiceBus/namespaces",
+				Action: recipes.ChangeActionUpdate,
+				Attributes: []recipes.AttributeChange{
+					{Path: "properties.size", Before: "small", After: "large"},
+				},
+			},
+		},
+		Status: &rpv1.RecipeStatus{
+			TemplateKind: recipes.TemplateKindBicep,
+			TemplatePath: "registry/recipe:1.0",
+		},
+	}
+	require.Equal(t, expected, plan)
+}
+
+func Test_PreparePlanResponse_NoChanges(t *testing.T) {
+	plan := preparePlanResponse(recipes.EnvironmentDefinition{}, nil, nil, armdeployments.WhatIfOperationResult{}, nil)
+	require.False(t, plan.HasChanges())
+	require.Equal(t, recipes.TemplateKindBicep, plan.Status.TemplateKind)
+}
+
+func Test_Bicep_Plan(t *testing.T) {
+	ts := registrytest.NewFakeRegistryServer(t)
+	t.Cleanup(ts.CloseServer)
+
+	opts := driver.PlanOptions{
+		BaseOptions: driver.BaseOptions{
+			Recipe: recipes.ResourceMetadata{
+				Name:          "default",
+				EnvironmentID: "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Core/environments/env",
+				ResourceID:    "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Datastores/mongoDatabases/mongo",
+			},
+			Definition: recipes.EnvironmentDefinition{
+				Name:         "mongo-azure",
+				Driver:       recipes.TemplateKindBicep,
+				TemplatePath: ts.TestImageURL,
+				ResourceType: "Applications.Datastores/mongoDatabases",
+			},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		deploymentClient := clients.NewMockResourceDeploymentsClient()
+		deploymentClient.SetWhatIfResult(armdeployments.WhatIfOperationResult{
+			Properties: &armdeployments.WhatIfOperationProperties{
+				Changes: []*armdeployments.WhatIfChange{
+					{
+						ResourceID: to.Ptr(planQueueID),
+						ChangeType: to.Ptr(armdeployments.ChangeTypeDeploy),
+					},
+				},
+			},
+		})
+		d := &bicepDriver{RegistryClient: ts.TestServer.Client(), DeploymentClient: deploymentClient}
+
+		plan, err := d.Plan(t.Context(), opts)
+		require.NoError(t, err)
+		require.Equal(t, []recipes.ResourceChange{
+			{ID: planQueueID, Type: "Microsoft.ServiceBus/namespaces", Action: recipes.ChangeActionUpdate},
+		}, plan.Changes)
+	})
+
+	t.Run("what-if error", func(t *testing.T) {
+		deploymentClient := clients.NewMockResourceDeploymentsClient()
+		deploymentClient.SetWhatIfResult(armdeployments.WhatIfOperationResult{
+			Error: &armdeployments.ErrorResponse{
+				Code:    to.Ptr("InvalidTemplate"),
+				Message: to.Ptr("the template is invalid"),
+			},
+		})
+		d := &bicepDriver{RegistryClient: ts.TestServer.Client(), DeploymentClient: deploymentClient}
+
+		_, err := d.Plan(t.Context(), opts)
+		expected := &recipes.RecipeError{
+			ErrorDetails: v1.ErrorDetails{
+				Code:    recipes.RecipePlanFailed,
+				Message: "failed to plan recipe default of type Applications.Datastores/mongoDatabases",
+				Details: []*v1.ErrorDetails{
+					{Code: "InvalidTemplate", Message: "the template is invalid"},
+				},
+			},
+			DeploymentStatus: "executionError",
+		}
+		require.Equal(t, expected, err)
+	})
+}
+
+func Test_Bicep_Plan_RefreshOnly(t *testing.T) {
+	opts := driver.PlanOptions{
+		BaseOptions: driver.BaseOptions{
+			Recipe: recipes.ResourceMetadata{
+				Name:       "default",
+				ResourceID: "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Datastores/mongoDatabases/mongo",
+			},
+			Definition: recipes.EnvironmentDefinition{
+				Name:         "mongo-azure",
+				Driver:       recipes.TemplateKindBicep,
+				TemplatePath: "radiusdev.azurecr.io/recipes/mongo:1.0",
+				ResourceType: "Applications.Datastores/mongoDatabases",
+			},
+		},
+		PrevState:   []string{planRedisID, planQueueID, planStorageID},
+		RefreshOnly: true,
+	}
+
+	t.Run("deleted output resources", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		resourceClient := processors.NewMockResourceClient(ctrl)
+		resourceClient.EXPECT().Exists(gomock.Any(), planRedisID).Return(false, nil)
+		resourceClient.EXPECT().Exists(gomock.Any(), planQueueID).Return(true, nil)
+		resourceClient.EXPECT().Exists(gomock.Any(), planStorageID).Return(false, nil)
+
+		// The template is not downloaded, and no what-if operation is started.
+		d := &bicepDriver{ResourceClient: resourceClient}
+
+		plan, err := d.Plan(t.Context(), opts)
+		require.NoError(t, err)
+		require.Equal(t, []recipes.ResourceChange{
+			{ID: planStorageID, Type: "AWS.S3/Bucket", Action: recipes.ChangeActionDelete},
+			{ID: planRedisID, Type: "core/Service", Action: recipes.ChangeActionDelete},
+		}, plan.Changes)
+		require.Equal(t, "radiusdev.azurecr.io/recipes/mongo:1.0", plan.Status.TemplatePath)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		resourceClient := processors.NewMockResourceClient(ctrl)
+		resourceClient.EXPECT().Exists(gomock.Any(), planRedisID).Return(false, errors.New("the cluster is unreachable"))
+
+		d := &bicepDriver{ResourceClient: resourceClient}
+
+		_, err := d.Plan(t.Context(), opts)
+		recipeErr, ok := err.(*recipes.RecipeError)
+		require.True(t, ok)
+		require.Equal(t, recipes.RecipePlanFailed, recipeErr.ErrorDetails.Code)
+		require.Equal(t, "failed to read output resource "+planRedisID+": the cluster is unreachable", recipeErr.ErrorDetails.Message)
+	})
+}
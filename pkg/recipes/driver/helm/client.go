@@ -0,0 +1,304 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"helm.sh/helm/v4/pkg/action"
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	"helm.sh/helm/v4/pkg/chart/v2/loader"
+	"helm.sh/helm/v4/pkg/cli"
+	"helm.sh/helm/v4/pkg/kube"
+	"helm.sh/helm/v4/pkg/registry"
+	"helm.sh/helm/v4/pkg/release"
+	releasev1 "helm.sh/helm/v4/pkg/release/v1"
+	"helm.sh/helm/v4/pkg/storage/driver"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// helmStorageDriver makes Helm store release information in Kubernetes secrets.
+	helmStorageDriver = "secret"
+
+	// operationTimeout is the time Helm waits for the resources of a release to become ready or to be deleted.
+	operationTimeout = 10 * time.Minute
+)
+
+//go:generate go tool mockgen -typed -source=client.go -destination=./mock_client.go -package=helm -imports chart=helm.sh/helm/v4/pkg/chart/v2,releasev1=helm.sh/helm/v4/pkg/release/v1
+
+// ChartReference identifies a Helm chart in an OCI registry or an HTTP chart repository.
+type ChartReference struct {
+	// Source is the location of the chart, for example "oci://ghcr.io/org/charts/redis" or
+	// "https://charts.example.com/stable/redis". For HTTP repositories the last path segment is the chart name.
+	Source string
+
+	// Version is the chart version. An empty version selects the latest chart version.
+	Version string
+
+	// PlainHTTP connects to an OCI registry using HTTP instead of HTTPS.
+	PlainHTTP bool
+}
+
+// HelmClient is an interface for the Helm operations used by the Helm recipe driver.
+type HelmClient interface {
+	// PullChart downloads and loads the chart identified by ref.
+	PullChart(ctx context.Context, ref ChartReference) (*chart.Chart, error)
+
+	// InstallOrUpgrade installs the chart as a new release, or upgrades the release when it already exists.
+	// Changes are applied with server-side apply and the call waits for the release resources to become ready.
+	InstallOrUpgrade(ctx context.Context, restConfig *rest.Config, namespace string, releaseName string, helmChart *chart.Chart, vals map[string]any) (*releasev1.Release, error)
+
+	// Uninstall removes the release and its resources. It does not return an error if the release does not exist.
+	Uninstall(ctx context.Context, restConfig *rest.Config, namespace string, releaseName string) error
+}
+
+// helmClient is the HelmClient implementation backed by the Helm Go SDK.
+type helmClient struct{}
+
+var _ HelmClient = (*helmClient)(nil)
+
+// NewHelmClient creates a new HelmClient that uses the Helm Go SDK.
+func NewHelmClient() HelmClient {
+	return &helmClient{}
+}
+
+// PullChart downloads the chart into a temporary directory and loads it into memory.
+func (c *helmClient) PullChart(ctx context.Context, ref ChartReference) (*chart.Chart, error) {
+	dir, err := os.MkdirTemp("", "helm-recipe-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chart download directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Keep the repository and content caches inside the download directory so pulling a chart does not
+	// depend on a writable home directory.
+	settings := cli.New()
+	settings.RepositoryConfig = filepath.Join(dir, "repositories.yaml")
+	settings.RepositoryCache = filepath.Join(dir, "repository")
+	settings.ContentCache = filepath.Join(dir, "content")
+
+	registryOptions := []registry.ClientOption{}
+	if ref.PlainHTTP {
+		registryOptions = append(registryOptions, registry.ClientOptPlainHTTP())
+	}
+	registryClient, err := registry.NewClient(registryOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry client: %w", err)
+	}
+
+	pull := action.NewPull(
+		action.WithConfig(&action.Configuration{RegistryClient: registryClient}),
+		func(p *action.Pull) {
+			p.Settings = settings
+		},
+	)
+	pull.DestDir = dir
+	pull.Version = ref.Version
+	pull.PlainHTTP = ref.PlainHTTP
+
+	chartRef := ref.Source
+	if !registry.IsOCI(ref.Source) {
+		// HTTP repositories are addressed by repository URL and chart name, for example
+		// "https://charts.example.com/stable" and "redis".
+		repoURL, chartName, found := cutLast(ref.Source, "/")
+		if !found || chartName == "" {
+			return nil, fmt.Errorf("chart reference %q must be an OCI reference or an HTTP repository URL followed by the chart name", ref.Source)
+		}
+		pull.RepoURL = repoURL
+		chartRef = chartName
+	}
+
+	if _, err := pull.Run(chartRef); err != nil {
+		return nil, fmt.Errorf("failed to pull chart %q: %w", ref.Source, err)
+	}
+
+	chartPath, err := findChartArchive(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.Load(chartPath)
+}
+
+// InstallOrUpgrade installs the release if Helm has no history for it, and upgrades it otherwise.
+func (c *helmClient) InstallOrUpgrade(ctx context.Context, restConfig *rest.Config, namespace string, releaseName string, helmChart *chart.Chart, vals map[string]any) (*releasev1.Release, error) {
+	cfg, err := newActionConfig(restConfig, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	history := action.NewHistory(cfg)
+	history.Max = 1
+	_, err = history.Run(releaseName)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		install := action.NewInstall(cfg)
+		install.ReleaseName = releaseName
+		install.Namespace = namespace
+		install.CreateNamespace = true
+		install.Timeout = operationTimeout
+		install.WaitStrategy = kube.StatusWatcherStrategy
+		install.ServerSideApply = true
+
+		rel, err := install.RunWithContext(ctx, helmChart, vals)
+		if err != nil {
+			return nil, err
+		}
+
+		return asRelease(rel)
+	} else if err != nil {
+		return nil, err
+	}
+
+	upgrade := action.NewUpgrade(cfg)
+	upgrade.Namespace = namespace
+	upgrade.Timeout = operationTimeout
+	upgrade.WaitStrategy = kube.StatusWatcherStrategy
+	upgrade.ServerSideApply = "true"
+	// The recipe always supplies the complete set of values, so values stored on previous revisions are discarded.
+	upgrade.ResetValues = true
+
+	rel, err := upgrade.RunWithContext(ctx, releaseName, helmChart, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	return asRelease(rel)
+}
+
+// Uninstall deletes the release and waits for its resources to be removed.
+func (c *helmClient) Uninstall(ctx context.Context, restConfig *rest.Config, namespace string, releaseName string) error {
+	cfg, err := newActionConfig(restConfig, namespace)
+	if err != nil {
+		return err
+	}
+
+	uninstall := action.NewUninstall(cfg)
+	uninstall.IgnoreNotFound = true
+	uninstall.Timeout = operationTimeout
+	uninstall.WaitStrategy = kube.StatusWatcherStrategy
+	uninstall.DeletionPropagation = "background"
+
+	_, err = uninstall.Run(releaseName)
+	return err
+}
+
+// newActionConfig creates a Helm action configuration for the cluster described by restConfig. Release
+// information is stored in secrets in the release namespace.
+func newActionConfig(restConfig *rest.Config, namespace string) (*action.Configuration, error) {
+	cfg := action.NewConfiguration()
+	if err := cfg.Init(&restClientGetter{restConfig: restConfig, namespace: namespace}, namespace, helmStorageDriver); err != nil {
+		return nil, fmt.Errorf("failed to initialize helm configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// findChartArchive returns the path of the chart archive downloaded into dir.
+func findChartArchive(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read chart download directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".tgz") {
+			return filepath.Join(dir, entry.Name()), nil
+		}
+	}
+
+	return "", fmt.Errorf("no chart archive found in %q", dir)
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s string, sep string) (before string, after string, found bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+
+	return s[:i], s[i+len(sep):], true
+}
+
+// asRelease converts a release.Releaser returned by the Helm v4 action API into a *releasev1.Release.
+func asRelease(r release.Releaser) (*releasev1.Release, error) {
+	rel, ok := r.(*releasev1.Release)
+	if !ok {
+		return nil, fmt.Errorf("unexpected release type %T returned by helm", r)
+	}
+
+	return rel, nil
+}
+
+// restClientGetter adapts an in-memory *rest.Config to the RESTClientGetter interface used to
+// initialize a Helm action configuration.
+type restClientGetter struct {
+	restConfig *rest.Config
+	namespace  string
+}
+
+var _ genericclioptions.RESTClientGetter = (*restClientGetter)(nil)
+
+// ToRESTConfig returns a copy of the REST config.
+func (g *restClientGetter) ToRESTConfig() (*rest.Config, error) {
+	return rest.CopyConfig(g.restConfig), nil
+}
+
+// ToDiscoveryClient returns a memory-cached discovery client for the cluster.
+func (g *restClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	client, err := discovery.NewDiscoveryClientForConfig(rest.CopyConfig(g.restConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	return memory.NewMemCacheClient(client), nil
+}
+
+// ToRESTMapper returns a REST mapper backed by the discovery client.
+func (g *restClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	client, err := g.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(client)
+	return restmapper.NewShortcutExpander(mapper, client, nil), nil
+}
+
+// ToRawKubeConfigLoader returns a client config that only carries the release namespace.
+func (g *restClientGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	overrides := &clientcmd.ConfigOverrides{
+		Context: clientcmdapi.Context{
+			Namespace: g.namespace,
+		},
+	}
+
+	return clientcmd.NewDefaultClientConfig(*clientcmdapi.NewConfig(), overrides)
+}
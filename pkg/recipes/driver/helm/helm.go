@@ -0,0 +1,409 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	releasev1 "helm.sh/helm/v4/pkg/release/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/radius-project/radius/pkg/components/metrics"
+	"github.com/radius-project/radius/pkg/components/trace"
+	"github.com/radius-project/radius/pkg/portableresources/processors"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/driver"
+	"github.com/radius-project/radius/pkg/recipes/kubernetes/clusteraccess"
+	"github.com/radius-project/radius/pkg/recipes/paramresolver"
+	"github.com/radius-project/radius/pkg/recipes/recipecontext"
+	recipes_util "github.com/radius-project/radius/pkg/recipes/util"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+const (
+	// maxReleaseNameLength is the maximum length of a Helm release name.
+	maxReleaseNameLength = 53
+
+	// releaseNameHashLength is the number of hex characters of the resource ID hash appended to release names.
+	releaseNameHashLength = 8
+)
+
+// invalidReleaseNameChars matches the characters that are not allowed in a Helm release name.
+var invalidReleaseNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+var _ driver.Driver = (*helmDriver)(nil)
+
+// NewHelmDriver creates a new instance of driver to execute a Helm chart recipe.
+func NewHelmDriver(resourceClient processors.ResourceClient, options HelmOptions) driver.Driver {
+	return &helmDriver{
+		HelmClient:            NewHelmClient(),
+		ResourceClient:        resourceClient,
+		options:               options,
+		clusterAccessResolver: clusteraccess.NewResolver(),
+	}
+}
+
+// HelmOptions represents the options of the Helm driver.
+type HelmOptions struct {
+	// DeleteRetryCount is the number of times to retry the deletion of an output resource.
+	DeleteRetryCount int
+	// DeleteRetryDelaySeconds is the delay between the retries of the deletion of an output resource.
+	DeleteRetryDelaySeconds int
+}
+
+// helmDriver represents a driver to interact with Helm chart recipes - install or upgrade a release, uninstall it, etc.
+type helmDriver struct {
+	// HelmClient is used to pull charts and to manage releases.
+	HelmClient HelmClient
+
+	// ResourceClient is used to delete output resources that are no longer part of the release.
+	ResourceClient processors.ResourceClient
+
+	options HelmOptions
+
+	clusterAccessResolver clusteraccess.ClusterAccessResolver
+}
+
+// Execute pulls the chart, maps the recipe parameters to chart values and installs or upgrades the Helm
+// release for the resource. The output resources are read from the release manifest, and the output
+// resources of the previous execution that are no longer part of the release are deleted.
+func (d *helmDriver) Execute(ctx context.Context, opts driver.ExecuteOptions) (_ *recipes.RecipeOutput, err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "helmdriver.Execute", &opts.Recipe, &opts.Definition)
+	defer func() { trace.EndSpan(span, err) }()
+
+	logger := ucplog.FromContextOrDiscard(ctx)
+	logger.Info(fmt.Sprintf("Deploying recipe: %q, chart: %q", opts.Definition.Name, opts.Definition.TemplatePath))
+
+	namespace, releaseName, err := releaseIdentity(opts.BaseOptions)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+
+	downloadStartTime := time.Now()
+	downloadCtx, downloadSpan := trace.StartRecipeSpan(ctx, "helmdriver.DownloadRecipe", &opts.Recipe, &opts.Definition)
+	helmChart, err := d.HelmClient.PullChart(downloadCtx, chartReference(opts.Definition))
+	trace.EndSpan(downloadSpan, err)
+	if err != nil {
+		metrics.DefaultRecipeEngineMetrics.RecordRecipeDownloadDuration(ctx, downloadStartTime,
+			metrics.NewRecipeAttributes(metrics.RecipeEngineOperationDownloadRecipe, opts.Recipe.Name, &opts.Definition, recipes.RecipeDownloadFailed))
+		return nil, recipes.NewRecipeError(recipes.RecipeDownloadFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+	metrics.DefaultRecipeEngineMetrics.RecordRecipeDownloadDuration(ctx, downloadStartTime,
+		metrics.NewRecipeAttributes(metrics.RecipeEngineOperationDownloadRecipe, opts.Recipe.Name, &opts.Definition, metrics.SuccessfulOperationState))
+
+	_, resolveSpan := trace.StartRecipeSpan(ctx, "helmdriver.ResolveParameters", &opts.Recipe, &opts.Definition)
+	vals, err := createChartValues(helmChart, opts.BaseOptions)
+	trace.EndSpan(resolveSpan, err)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+
+	restConfig, err := d.clusterAccessResolver.Resolve(ctx, &opts.Configuration)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, fmt.Sprintf("failed to resolve the target cluster: %s", err.Error()), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+
+	logger.Info("installing helm chart for recipe", "release", releaseName, "namespace", namespace)
+	installCtx, installSpan := trace.StartRecipeSpan(ctx, "helmdriver.InstallOrUpgrade", &opts.Recipe, &opts.Definition)
+	installSpan.SetAttributes(attribute.String("helm.release", releaseName), attribute.String("helm.namespace", namespace))
+	rel, err := d.HelmClient.InstallOrUpgrade(installCtx, restConfig, namespace, releaseName, helmChart, vals)
+	trace.EndSpan(installSpan, err)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, fmt.Sprintf("failed to install helm chart for recipe %s of type %s: %s", opts.Recipe.Name, opts.Definition.ResourceType, err.Error()), recipes_util.ExecutionError, recipes.GetErrorDetails(err))
+	}
+
+	recipeResponse, err := prepareRecipeResponse(opts.Definition, rel)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.InvalidRecipeOutputs, fmt.Sprintf("failed to read the recipe outputs: %s", err.Error()), recipes_util.ExecutionError, recipes.GetErrorDetails(err))
+	}
+
+	// Helm removes the objects dropped from the chart between revisions of the same release, but output
+	// resources recorded by a previous execution can also come from a different chart or a release that no
+	// longer owns them. Delete the previous output resources that are not part of the current release.
+	garbageCollectionStartTime := time.Now()
+	diff, err := driver.GetGCOutputResources(recipeResponse.Resources, opts.PrevState)
+	if err != nil {
+		return nil, err
+	}
+
+	gcCtx, gcSpan := trace.StartRecipeSpan(ctx, "helmdriver.GarbageCollection", &opts.Recipe, &opts.Definition)
+	err = d.deleteOutputResources(gcCtx, diff)
+	trace.EndSpan(gcSpan, err)
+	if err != nil {
+		metrics.DefaultRecipeEngineMetrics.RecordRecipeGarbageCollectionDuration(ctx, garbageCollectionStartTime,
+			metrics.NewRecipeAttributes(metrics.RecipeEngineOperationGC, opts.Recipe.Name, &opts.Definition, metrics.FailedOperationState))
+		return nil, recipes.NewRecipeError(recipes.RecipeGarbageCollectionFailed, err.Error(), recipes_util.ExecutionError, nil)
+	}
+	metrics.DefaultRecipeEngineMetrics.RecordRecipeGarbageCollectionDuration(ctx, garbageCollectionStartTime,
+		metrics.NewRecipeAttributes(metrics.RecipeEngineOperationGC, opts.Recipe.Name, &opts.Definition, metrics.SuccessfulOperationState))
+
+	return recipeResponse, nil
+}
+
+// Delete uninstalls the Helm release for the resource and then deletes any remaining output resources
+// that are marked as managed by Radius.
+func (d *helmDriver) Delete(ctx context.Context, opts driver.DeleteOptions) (err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "helmdriver.Delete", &opts.Recipe, &opts.Definition)
+	span.SetAttributes(attribute.Int("recipe.output_resources", len(opts.OutputResources)))
+	defer func() { trace.EndSpan(span, err) }()
+
+	namespace, releaseName, err := releaseIdentity(opts.BaseOptions)
+	if err != nil {
+		return recipes.NewRecipeError(recipes.RecipeDeletionFailed, err.Error(), "", recipes.GetErrorDetails(err))
+	}
+
+	restConfig, err := d.clusterAccessResolver.Resolve(ctx, &opts.Configuration)
+	if err != nil {
+		return recipes.NewRecipeError(recipes.RecipeDeletionFailed, fmt.Sprintf("failed to resolve the target cluster: %s", err.Error()), "", recipes.GetErrorDetails(err))
+	}
+
+	err = d.HelmClient.Uninstall(ctx, restConfig, namespace, releaseName)
+	if err != nil {
+		return recipes.NewRecipeError(recipes.RecipeDeletionFailed, fmt.Sprintf("failed to uninstall helm release %q: %s", releaseName, err.Error()), "", recipes.GetErrorDetails(err))
+	}
+
+	return d.deleteOutputResources(ctx, opts.OutputResources)
+}
+
+// GetRecipeMetadata pulls the chart and returns its default values as the recipe parameters.
+func (d *helmDriver) GetRecipeMetadata(ctx context.Context, opts driver.BaseOptions) (map[string]any, error) {
+	helmChart, err := d.HelmClient.PullChart(ctx, chartReference(opts.Definition))
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeGetMetadataFailed, err.Error(), "", recipes.GetErrorDetails(err))
+	}
+
+	parameters := map[string]any{}
+	for name, value := range helmChart.Values {
+		parameters[name] = map[string]any{
+			"type":         valueType(value),
+			"defaultValue": value,
+		}
+	}
+
+	return map[string]any{
+		"parameters": parameters,
+	}, nil
+}
+
+// deleteOutputResources deletes the output resources that are marked as managed by Radius, retrying the
+// deletions that fail because of the resources that depend on them.
+func (d *helmDriver) deleteOutputResources(ctx context.Context, outputResources []rpv1.OutputResource) error {
+	return driver.DeleteOutputResources(ctx, d.ResourceClient, outputResources, d.options.DeleteRetryCount, time.Duration(d.options.DeleteRetryDelaySeconds)*time.Second)
+}
+
+// releaseIdentity returns the namespace and the name of the Helm release for the resource. The release is
+// installed in the Kubernetes namespace of the resource, and its name is derived from the resource name and a
+// hash of the resource ID so that it is stable across executions and unique within the namespace.
+func releaseIdentity(opts driver.BaseOptions) (namespace string, releaseName string, err error) {
+	if opts.Configuration.Runtime.Kubernetes == nil || opts.Configuration.Runtime.Kubernetes.Namespace == "" {
+		return "", "", errors.New("helm recipes require a Kubernetes namespace in the environment runtime configuration")
+	}
+
+	id, err := resources.ParseResource(opts.Recipe.ResourceID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse resource ID %q: %w", opts.Recipe.ResourceID, err)
+	}
+
+	return opts.Configuration.Runtime.Kubernetes.Namespace, newReleaseName(id), nil
+}
+
+// newReleaseName returns a valid Helm release name for the resource: the lowercased resource name, truncated
+// so that the result fits in 53 characters, followed by a short hash of the full resource ID.
+func newReleaseName(id resources.ID) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(id.String())))
+	suffix := hex.EncodeToString(hash[:])[:releaseNameHashLength]
+
+	name := invalidReleaseNameChars.ReplaceAllString(strings.ToLower(id.Name()), "-")
+	maxNameLength := maxReleaseNameLength - releaseNameHashLength - 1
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return suffix
+	}
+
+	return name + "-" + suffix
+}
+
+// chartReference returns the chart referenced by the recipe definition.
+func chartReference(definition recipes.EnvironmentDefinition) ChartReference {
+	return ChartReference{
+		Source:    definition.TemplatePath,
+		Version:   definition.TemplateVersion,
+		PlainHTTP: definition.PlainHTTP,
+	}
+}
+
+// createChartValues creates the values passed to the chart after resolving the conflict between developer and
+// operator parameters; in case of conflict the developer parameter takes precedence. If the chart declares a
+// top-level "context" value, the recipe context is passed as that value. Otherwise {{context.*}} expressions in
+// the parameters are resolved against the recipe context.
+func createChartValues(helmChart *chart.Chart, opts driver.BaseOptions) (map[string]any, error) {
+	recipeContext, err := recipecontext.New(&opts.Recipe, &opts.Configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	//update the recipe context with connected resources properties
+	recipeContext.Resource.Connections = opts.Recipe.ConnectedResourcesProperties
+
+	mergedParams := recipes_util.ShallowMergeParameters(opts.Definition.Parameters, opts.Recipe.Parameters)
+	if mergedParams == nil {
+		mergedParams = map[string]any{}
+	}
+
+	if _, ok := helmChart.Values[recipecontext.RecipeContextParamKey]; !ok {
+		return paramresolver.ResolveParameterExpressions(mergedParams, recipeContext), nil
+	}
+
+	// Chart templates read values as plain maps, so convert the context using its JSON representation.
+	b, err := json.Marshal(recipeContext)
+	if err != nil {
+		return nil, err
+	}
+	contextValues := map[string]any{}
+	if err := json.Unmarshal(b, &contextValues); err != nil {
+		return nil, err
+	}
+	mergedParams[recipecontext.RecipeContextParamKey] = contextValues
+
+	return mergedParams, nil
+}
+
+// prepareRecipeResponse builds the recipe output from the release. Output resources are the objects in the
+// release manifest. Values and secrets follow the same precedence as the other drivers:
+//   - With an outputs mapping, each mapped output is either a key of the chart notes or a reference to a field
+//     of a rendered object (see recipes_util.ResolveObjectReference); fields of Secrets are routed to secrets.
+//   - Without a mapping, a "result" key in the chart notes is read as a wrapped recipe response.
+//   - Otherwise all keys of the chart notes pass through as values.
+func prepareRecipeResponse(definition recipes.EnvironmentDefinition, rel *releasev1.Release) (*recipes.RecipeOutput, error) {
+	objects, err := parseManifest(rel.Manifest, rel.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes map[string]any
+	if rel.Info != nil {
+		notes = parseNotes(rel.Info.Notes)
+	}
+
+	recipeResponse := &recipes.RecipeOutput{}
+	hasOutputsMapping := len(definition.Outputs) > 0 || len(definition.SecretOutputs) > 0
+	result, hasResultOutput := notes[recipes.ResultPropertyName].(map[string]any)
+
+	switch {
+	case hasOutputsMapping:
+		values, secrets, err := collectOutputs(notes, objects, definition)
+		if err != nil {
+			return nil, err
+		}
+		recipeResponse.Values, recipeResponse.Secrets = recipes_util.ApplyOutputsMapping(values, secrets, definition.Outputs, definition.SecretOutputs)
+	case hasResultOutput:
+		if err := recipeResponse.PrepareRecipeResponse(result); err != nil {
+			return nil, err
+		}
+	default:
+		recipeResponse.Values = notes
+	}
+
+	if recipeResponse.Values == nil {
+		recipeResponse.Values = map[string]any{}
+	}
+	if recipeResponse.Secrets == nil {
+		recipeResponse.Secrets = map[string]any{}
+	}
+
+	for _, id := range outputResourceIDs(objects) {
+		if !slices.Contains(recipeResponse.Resources, id) {
+			recipeResponse.Resources = append(recipeResponse.Resources, id)
+		}
+	}
+
+	templateVersion := definition.TemplateVersion
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		templateVersion = rel.Chart.Metadata.Version
+	}
+	recipeResponse.Status = &rpv1.RecipeStatus{
+		TemplateKind:    recipes.TemplateKindHelm,
+		TemplatePath:    definition.TemplatePath,
+		TemplateVersion: templateVersion,
+	}
+
+	return recipeResponse, nil
+}
+
+// collectOutputs collects the chart outputs referenced by the outputs mapping. The chart notes provide
+// values, and object references are resolved against the rendered objects.
+func collectOutputs(notes map[string]any, objects []*unstructured.Unstructured, definition recipes.EnvironmentDefinition) (map[string]any, map[string]any, error) {
+	values := map[string]any{}
+	secrets := map[string]any{}
+	for name, value := range notes {
+		values[name] = value
+	}
+
+	for _, outputs := range []map[string]string{definition.Outputs, definition.SecretOutputs} {
+		for _, outputName := range outputs {
+			if !recipes_util.IsObjectReference(outputName) {
+				continue
+			}
+
+			value, isSecret, err := recipes_util.ResolveObjectReference(objects, outputName)
+			if err != nil {
+				return nil, nil, err
+			}
+			if isSecret {
+				secrets[outputName] = value
+			} else {
+				values[outputName] = value
+			}
+		}
+	}
+
+	return values, secrets, nil
+}
+
+// valueType returns the parameter type reported for a chart default value.
+func valueType(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case int, int64, float64:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return "any"
+	}
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	releasev1 "helm.sh/helm/v4/pkg/release/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/radius-project/radius/pkg/portableresources/processors"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/driver"
+	"github.com/radius-project/radius/pkg/recipes/kubernetes/clusteraccess"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	resources_kubernetes "github.com/radius-project/radius/pkg/ucp/resources/kubernetes"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testResourceID = "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Datastores/redisCaches/redis"
+	testNamespace  = "test-app"
+	testManifest   = `---
+# Source: redis/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: redis-auth
+data:
+  password: c2VjcmV0
+---
+# Source: redis/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: redis
+spec:
+  clusterIP: 10.0.0.10
+  ports:
+  - port: 6379
+---
+# Source: redis/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: redis
+`
+)
+
+// fakeResolver is a ClusterAccessResolver that always returns the same config.
+type fakeResolver struct {
+	config *rest.Config
+}
+
+func (r *fakeResolver) Resolve(ctx context.Context, envConfig *recipes.Configuration) (*rest.Config, error) {
+	return r.config, nil
+}
+
+func (r *fakeResolver) ResolveKubeconfigSource(ctx context.Context, envConfig *recipes.Configuration) (clusteraccess.KubeconfigSource, error) {
+	return clusteraccess.KubeconfigSource{}, nil
+}
+
+func setupDriver(t *testing.T) (*helmDriver, *MockHelmClient, *processors.MockResourceClient) {
+	ctrl := gomock.NewController(t)
+	helmClient := NewMockHelmClient(ctrl)
+	resourceClient := processors.NewMockResourceClient(ctrl)
+
+	d := &helmDriver{
+		HelmClient:            helmClient,
+		ResourceClient:        resourceClient,
+		clusterAccessResolver: &fakeResolver{config: &rest.Config{Host: "https://example.com"}},
+	}
+
+	return d, helmClient, resourceClient
+}
+
+func baseOptions() driver.BaseOptions {
+	return driver.BaseOptions{
+		Configuration: recipes.Configuration{
+			Runtime: recipes.RuntimeConfiguration{
+				Kubernetes: &recipes.KubernetesRuntime{
+					Namespace:            testNamespace,
+					EnvironmentNamespace: "test-env",
+				},
+			},
+		},
+		Recipe: recipes.ResourceMetadata{
+			Name:          "default",
+			EnvironmentID: "/planes/radius/local/resourceGroups/test-rg/providers/Radius.Core/environments/env",
+			ApplicationID: "/planes/radius/local/resourceGroups/test-rg/providers/Radius.Core/applications/app",
+			ResourceID:    testResourceID,
+			Parameters: map[string]any{
+				"replicas": 2,
+			},
+		},
+		Definition: recipes.EnvironmentDefinition{
+			Name:            "default",
+			Driver:          recipes.TemplateKindHelm,
+			ResourceType:    "Applications.Datastores/redisCaches",
+			TemplatePath:    "oci://ghcr.io/radius-project/charts/redis",
+			TemplateVersion: "1.0.0",
+			Parameters: map[string]any{
+				"replicas": 1,
+				"name":     "{{context.resource.name}}",
+			},
+		},
+	}
+}
+
+func testChart(values map[string]any) *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "redis", Version: "1.0.0"},
+		Values:   values,
+	}
+}
+
+func Test_Helm_Execute_Success(t *testing.T) {
+	d, helmClient, resourceClient := setupDriver(t)
+	opts := driver.ExecuteOptions{
+		BaseOptions: baseOptions(),
+		PrevState: []string{
+			"/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis",
+			"/planes/kubernetes/local/namespaces/test-app/providers/apps/StatefulSet/redis-old",
+		},
+	}
+
+	helmChart := testChart(map[string]any{"replicas": 1})
+	helmClient.EXPECT().
+		PullChart(gomock.Any(), ChartReference{Source: "oci://ghcr.io/radius-project/charts/redis", Version: "1.0.0"}).
+		Return(helmChart, nil)
+	helmClient.EXPECT().
+		InstallOrUpgrade(gomock.Any(), gomock.Any(), testNamespace, gomock.Any(), helmChart, map[string]any{"replicas": 2, "name": "redis"}).
+		Return(&releasev1.Release{
+			Namespace: testNamespace,
+			Manifest:  testManifest,
+			Chart:     helmChart,
+			Info:      &releasev1.Info{Notes: "host: redis.test-app.svc.cluster.local\nport: 6379\n"},
+		}, nil)
+	resourceClient.EXPECT().
+		Delete(gomock.Any(), "/planes/kubernetes/local/namespaces/test-app/providers/apps/StatefulSet/redis-old").
+		Return(nil)
+
+	result, err := d.Execute(t.Context(), opts)
+	require.NoError(t, err)
+	require.Equal(t, &recipes.RecipeOutput{
+		Values: map[string]any{
+			"host": "redis.test-app.svc.cluster.local",
+			"port": float64(6379),
+		},
+		Secrets: map[string]any{},
+		Resources: []string{
+			"/planes/kubernetes/local/namespaces/test-app/providers/core/Secret/redis-auth",
+			"/planes/kubernetes/local/namespaces/test-app/providers/core/Service/redis",
+			"/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis",
+		},
+		Status: &rpv1.RecipeStatus{
+			TemplateKind:    recipes.TemplateKindHelm,
+			TemplatePath:    "oci://ghcr.io/radius-project/charts/redis",
+			TemplateVersion: "1.0.0",
+		},
+	}, result)
+}
+
+func Test_Helm_Execute_ContextValue(t *testing.T) {
+	d, helmClient, _ := setupDriver(t)
+	opts := driver.ExecuteOptions{BaseOptions: baseOptions()}
+
+	helmChart := testChart(map[string]any{"context": map[string]any{}})
+	helmClient.EXPECT().PullChart(gomock.Any(), gomock.Any()).Return(helmChart, nil)
+	helmClient.EXPECT().
+		InstallOrUpgrade(gomock.Any(), gomock.Any(), testNamespace, gomock.Any(), helmChart, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, restConfig *rest.Config, namespace string, releaseName string, helmChart *chart.Chart, vals map[string]any) (*releasev1.Release, error) {
+			// Parameters are passed unresolved and the recipe context is passed as the "context" value.
+			require.Equal(t, "{{context.resource.name}}", vals["name"])
+			recipeContext, ok := vals["context"].(map[string]any)
+			require.True(t, ok)
+			resource, ok := recipeContext["resource"].(map[string]any)
+			require.True(t, ok)
+			require.Equal(t, "redis", resource["name"])
+			return &releasev1.Release{Namespace: namespace}, nil
+		})
+
+	_, err := d.Execute(t.Context(), opts)
+	require.NoError(t, err)
+}
+
+func Test_Helm_Execute_NoNamespace(t *testing.T) {
+	d, _, _ := setupDriver(t)
+	opts := driver.ExecuteOptions{BaseOptions: baseOptions()}
+	opts.Configuration.Runtime.Kubernetes = nil
+
+	_, err := d.Execute(t.Context(), opts)
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.RecipeDeploymentFailed, recipeError.ErrorDetails.Code)
+}
+
+func Test_Helm_Execute_PullError(t *testing.T) {
+	d, helmClient, _ := setupDriver(t)
+	opts := driver.ExecuteOptions{BaseOptions: baseOptions()}
+
+	helmClient.EXPECT().PullChart(gomock.Any(), gomock.Any()).Return(nil, errors.New("not found"))
+
+	_, err := d.Execute(t.Context(), opts)
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.RecipeDownloadFailed, recipeError.ErrorDetails.Code)
+}
+
+func Test_Helm_Execute_InstallError(t *testing.T) {
+	d, helmClient, _ := setupDriver(t)
+	opts := driver.ExecuteOptions{BaseOptions: baseOptions()}
+
+	helmClient.EXPECT().PullChart(gomock.Any(), gomock.Any()).Return(testChart(nil), nil)
+	helmClient.EXPECT().
+		InstallOrUpgrade(gomock.Any(), gomock.Any(), testNamespace, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("timed out waiting for the condition"))
+
+	_, err := d.Execute(t.Context(), opts)
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.RecipeDeploymentFailed, recipeError.ErrorDetails.Code)
+	require.Equal(t, "executionError", string(recipeError.DeploymentStatus))
+}
+
+func Test_Helm_Delete_Success(t *testing.T) {
+	d, helmClient, resourceClient := setupDriver(t)
+	opts := driver.DeleteOptions{
+		BaseOptions: baseOptions(),
+		OutputResources: []rpv1.OutputResource{
+			{
+				ID:            resources_kubernetes.IDFromParts(resources_kubernetes.PlaneNameTODO, "apps", "Deployment", testNamespace, "redis"),
+				RadiusManaged: new(true),
+			},
+			{
+				// We don't expect a call to delete to be made when RadiusManaged is false.
+				ID:            resources_kubernetes.IDFromParts(resources_kubernetes.PlaneNameTODO, "", "Service", testNamespace, "redis"),
+				RadiusManaged: new(false),
+			},
+		},
+	}
+
+	helmClient.EXPECT().Uninstall(gomock.Any(), gomock.Any(), testNamespace, newReleaseName(resources.MustParse(testResourceID))).Return(nil)
+	resourceClient.EXPECT().Delete(gomock.Any(), "/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis").Return(nil)
+
+	err := d.Delete(t.Context(), opts)
+	require.NoError(t, err)
+}
+
+func Test_Helm_Delete_Retry(t *testing.T) {
+	d, helmClient, resourceClient := setupDriver(t)
+	d.options = HelmOptions{DeleteRetryCount: 1}
+	opts := driver.DeleteOptions{
+		BaseOptions: baseOptions(),
+		OutputResources: []rpv1.OutputResource{
+			{
+				ID:            resources_kubernetes.IDFromParts(resources_kubernetes.PlaneNameTODO, "apps", "Deployment", testNamespace, "redis"),
+				RadiusManaged: new(true),
+			},
+		},
+	}
+
+	helmClient.EXPECT().Uninstall(gomock.Any(), gomock.Any(), testNamespace, gomock.Any()).Return(nil)
+	gomock.InOrder(
+		resourceClient.EXPECT().Delete(gomock.Any(), "/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis").Return(errors.New("conflict")),
+		resourceClient.EXPECT().Delete(gomock.Any(), "/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis").Return(nil),
+	)
+
+	err := d.Delete(t.Context(), opts)
+	require.NoError(t, err)
+}
+
+func Test_Helm_Delete_UninstallError(t *testing.T) {
+	d, helmClient, _ := setupDriver(t)
+	opts := driver.DeleteOptions{BaseOptions: baseOptions()}
+
+	helmClient.EXPECT().Uninstall(gomock.Any(), gomock.Any(), testNamespace, gomock.Any()).Return(errors.New("forbidden"))
+
+	err := d.Delete(t.Context(), opts)
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.RecipeDeletionFailed, recipeError.ErrorDetails.Code)
+}
+
+func Test_Helm_GetRecipeMetadata(t *testing.T) {
+	d, helmClient, _ := setupDriver(t)
+
+	helmClient.EXPECT().PullChart(gomock.Any(), gomock.Any()).Return(testChart(map[string]any{
+		"replicas": float64(1),
+		"image":    map[string]any{"tag": "7"},
+	}), nil)
+
+	metadata, err := d.GetRecipeMetadata(t.Context(), baseOptions())
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"parameters": map[string]any{
+			"replicas": map[string]any{"type": "number", "defaultValue": float64(1)},
+			"image":    map[string]any{"type": "object", "defaultValue": map[string]any{"tag": "7"}},
+		},
+	}, metadata)
+}
+
+func Test_PrepareRecipeResponse_OutputsMapping(t *testing.T) {
+	definition := baseOptions().Definition
+	definition.Outputs = map[string]string{
+		"host": "host",
+		"ip":   "Service/redis:spec.clusterIP",
+	}
+	definition.SecretOutputs = map[string]string{
+		"password": "Secret/redis-auth:data.password",
+	}
+
+	response, err := prepareRecipeResponse(definition, &releasev1.Release{
+		Namespace: testNamespace,
+		Manifest:  testManifest,
+		Info:      &releasev1.Info{Notes: "host: redis\nunmapped: value\n"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"host": "redis", "ip": "10.0.0.10"}, response.Values)
+	require.Equal(t, map[string]any{"password": "secret"}, response.Secrets)
+}
+
+func Test_PrepareRecipeResponse_SecretReferenceInOutputs(t *testing.T) {
+	definition := baseOptions().Definition
+	definition.Outputs = map[string]string{
+		"password": "Secret/redis-auth:data.password",
+	}
+
+	// Fields of Secrets are classified as secrets even when mapped through outputs.
+	response, err := prepareRecipeResponse(definition, &releasev1.Release{Namespace: testNamespace, Manifest: testManifest})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{}, response.Values)
+	require.Equal(t, map[string]any{"password": "secret"}, response.Secrets)
+}
+
+func Test_PrepareRecipeResponse_MissingObjectReference(t *testing.T) {
+	definition := baseOptions().Definition
+	definition.Outputs = map[string]string{
+		"ip": "Service/missing:spec.clusterIP",
+	}
+
+	_, err := prepareRecipeResponse(definition, &releasev1.Release{Namespace: testNamespace, Manifest: testManifest})
+	require.ErrorContains(t, err, `Service "missing" was not found`)
+}
+
+func Test_PrepareRecipeResponse_WrappedResult(t *testing.T) {
+	notes := `result:
+  values:
+    host: redis
+  secrets:
+    password: secret
+  resources:
+  - /planes/kubernetes/local/namespaces/test-app/providers/core/Service/redis
+`
+	response, err := prepareRecipeResponse(baseOptions().Definition, &releasev1.Release{
+		Namespace: testNamespace,
+		Manifest:  testManifest,
+		Info:      &releasev1.Info{Notes: notes},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"host": "redis"}, response.Values)
+	require.Equal(t, map[string]any{"password": "secret"}, response.Secrets)
+	require.Equal(t, []string{
+		"/planes/kubernetes/local/namespaces/test-app/providers/core/Service/redis",
+		"/planes/kubernetes/local/namespaces/test-app/providers/core/Secret/redis-auth",
+		"/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis",
+	}, response.Resources)
+}
+
+func Test_PrepareRecipeResponse_PlainTextNotes(t *testing.T) {
+	response, err := prepareRecipeResponse(baseOptions().Definition, &releasev1.Release{
+		Namespace: testNamespace,
+		Info:      &releasev1.Info{Notes: "Thank you for installing redis."},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{}, response.Values)
+	require.Equal(t, map[string]any{}, response.Secrets)
+	require.Empty(t, response.Resources)
+}
+
+func Test_NewReleaseName(t *testing.T) {
+	short := newReleaseName(resources.MustParse(testResourceID))
+	require.Regexp(t, `^redis-[0-9a-f]{8}$`, short)
+
+	// Release names are stable for the same resource.
+	require.Equal(t, short, newReleaseName(resources.MustParse(testResourceID)))
+
+	long := newReleaseName(resources.MustParse("/planes/radius/local/resourceGroups/test-rg/providers/Applications.Datastores/redisCaches/My_Very_Long_Resource_Name_That_Exceeds_The_Helm_Release_Name_Limit"))
+	require.LessOrEqual(t, len(long), maxReleaseNameLength)
+	require.Regexp(t, `^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`, long)
+}
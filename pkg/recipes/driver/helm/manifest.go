@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	releaseutil "helm.sh/helm/v4/pkg/release/v1/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	kubernetesresources "github.com/radius-project/radius/pkg/ucp/resources/kubernetes"
+)
+
+// clusterScopedKinds lists the built-in Kubernetes kinds that are not namespaced. Rendered objects of these
+// kinds are recorded without a namespace; every other object defaults to the release namespace.
+var clusterScopedKinds = map[string]bool{
+	"APIService":                     true,
+	"CertificateSigningRequest":      true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"CSIDriver":                      true,
+	"CSINode":                        true,
+	"CustomResourceDefinition":       true,
+	"FlowSchema":                     true,
+	"IngressClass":                   true,
+	"MutatingWebhookConfiguration":   true,
+	"Namespace":                      true,
+	"Node":                           true,
+	"PersistentVolume":               true,
+	"PriorityClass":                  true,
+	"PriorityLevelConfiguration":     true,
+	"RuntimeClass":                   true,
+	"StorageClass":                   true,
+	"ValidatingWebhookConfiguration": true,
+	"VolumeAttachment":               true,
+}
+
+// parseManifest splits a rendered release manifest into its Kubernetes objects, in manifest order.
+// Namespaced objects without an explicit namespace are assigned the release namespace.
+func parseManifest(manifest string, namespace string) ([]*unstructured.Unstructured, error) {
+	documents := releaseutil.SplitManifests(manifest)
+	keys := make([]string, 0, len(documents))
+	for key := range documents {
+		keys = append(keys, key)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	objects := []*unstructured.Unstructured{}
+	for _, key := range keys {
+		content := map[string]any{}
+		if err := yaml.Unmarshal([]byte(documents[key]), &content); err != nil {
+			return nil, fmt.Errorf("failed to parse release manifest: %w", err)
+		}
+		if len(content) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: content}
+		if obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("release manifest contains an object without a kind or name")
+		}
+		if obj.GetNamespace() == "" && !clusterScopedKinds[obj.GetKind()] {
+			obj.SetNamespace(namespace)
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// outputResourceIDs returns the UCP resource IDs of the given Kubernetes objects.
+func outputResourceIDs(objects []*unstructured.Unstructured) []string {
+	ids := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		namespace := obj.GetNamespace()
+		if clusterScopedKinds[gvk.Kind] {
+			namespace = ""
+		}
+		id := kubernetesresources.IDFromParts(kubernetesresources.PlaneNameTODO, gvk.Group, gvk.Kind, namespace, obj.GetName())
+		ids = append(ids, id.String())
+	}
+
+	return ids
+}
+
+// parseNotes returns the outputs published by a chart through its NOTES.txt. Charts publish outputs by
+// rendering a YAML mapping as their notes; notes that are not a YAML mapping, such as usage
+// instructions, carry no outputs.
+func parseNotes(notes string) map[string]any {
+	if strings.TrimSpace(notes) == "" {
+		return nil
+	}
+
+	outputs := map[string]any{}
+	if err := yaml.Unmarshal([]byte(notes), &outputs); err != nil {
+		return nil
+	}
+
+	return outputs
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseManifest(t *testing.T) {
+	manifest := `---
+# Source: chart/templates/namespace.yaml
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: extra
+---
+# Source: chart/templates/role.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+---
+# Source: chart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: other
+---
+# Source: chart/templates/empty.yaml
+---
+# Source: chart/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`
+
+	objects, err := parseManifest(manifest, "default-ns")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"/planes/kubernetes/local/providers/core/Namespace/extra",
+		"/planes/kubernetes/local/providers/rbac.authorization.k8s.io/ClusterRole/reader",
+		"/planes/kubernetes/local/namespaces/other/providers/core/ConfigMap/settings",
+		"/planes/kubernetes/local/namespaces/default-ns/providers/apps/Deployment/app",
+	}, outputResourceIDs(objects))
+}
+
+func Test_ParseManifest_Invalid(t *testing.T) {
+	_, err := parseManifest("apiVersion: v1\nkind: ConfigMap\n", "default-ns")
+	require.Error(t, err)
+}
+
+func Test_ParseNotes(t *testing.T) {
+	require.Nil(t, parseNotes(""))
+	require.Nil(t, parseNotes("Thank you for installing."))
+	require.Equal(t, map[string]any{"host": "redis", "port": float64(6379)}, parseNotes("host: redis\nport: 6379\n"))
+}
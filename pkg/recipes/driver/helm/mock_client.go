@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: client.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=client.go -destination=./mock_client.go -package=helm -imports chart=helm.sh/helm/v4/pkg/chart/v2,releasev1=helm.sh/helm/v4/pkg/release/v1
+//
+
+// Package helm is a generated GoMock package.
+package helm
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	releasev1 "helm.sh/helm/v4/pkg/release/v1"
+	rest "k8s.io/client-go/rest"
+)
+
+// MockHelmClient is a mock of HelmClient interface.
+type MockHelmClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockHelmClientMockRecorder
+	isgomock struct{}
+}
+
+// MockHelmClientMockRecorder is the mock recorder for MockHelmClient.
+type MockHelmClientMockRecorder struct {
+	mock *MockHelmClient
+}
+
+// NewMockHelmClient creates a new mock instance.
+func NewMockHelmClient(ctrl *gomock.Controller) *MockHelmClient {
+	mock := &MockHelmClient{ctrl: ctrl}
+	mock.recorder = &MockHelmClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHelmClient) EXPECT() *MockHelmClientMockRecorder {
+	return m.recorder
+}
+
+// InstallOrUpgrade mocks base method.
+func (m *MockHelmClient) InstallOrUpgrade(ctx context.Context, restConfig *rest.Config, namespace, releaseName string, helmChart *chart.Chart, vals map[string]any) (*releasev1.Release, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallOrUpgrade", ctx, restConfig, namespace, releaseName, helmChart, vals)
+	ret0, _ := ret[0].(*releasev1.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstallOrUpgrade indicates an expected call of InstallOrUpgrade.
+func (mr *MockHelmClientMockRecorder) InstallOrUpgrade(ctx, restConfig, namespace, releaseName, helmChart, vals any) *MockHelmClientInstallOrUpgradeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallOrUpgrade", reflect.TypeOf((*MockHelmClient)(nil).InstallOrUpgrade), ctx, restConfig, namespace, releaseName, helmChart, vals)
+	return &MockHelmClientInstallOrUpgradeCall{Call: call}
+}
+
+// MockHelmClientInstallOrUpgradeCall wrap *gomock.Call
+type MockHelmClientInstallOrUpgradeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHelmClientInstallOrUpgradeCall) Return(arg0 *releasev1.Release, arg1 error) *MockHelmClientInstallOrUpgradeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHelmClientInstallOrUpgradeCall) Do(f func(context.Context, *rest.Config, string, string, *chart.Chart, map[string]any) (*releasev1.Release, error)) *MockHelmClientInstallOrUpgradeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHelmClientInstallOrUpgradeCall) DoAndReturn(f func(context.Context, *rest.Config, string, string, *chart.Chart, map[string]any) (*releasev1.Release, error)) *MockHelmClientInstallOrUpgradeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PullChart mocks base method.
+func (m *MockHelmClient) PullChart(ctx context.Context, ref ChartReference) (*chart.Chart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullChart", ctx, ref)
+	ret0, _ := ret[0].(*chart.Chart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PullChart indicates an expected call of PullChart.
+func (mr *MockHelmClientMockRecorder) PullChart(ctx, ref any) *MockHelmClientPullChartCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullChart", reflect.TypeOf((*MockHelmClient)(nil).PullChart), ctx, ref)
+	return &MockHelmClientPullChartCall{Call: call}
+}
+
+// MockHelmClientPullChartCall wrap *gomock.Call
+type MockHelmClientPullChartCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHelmClientPullChartCall) Return(arg0 *chart.Chart, arg1 error) *MockHelmClientPullChartCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHelmClientPullChartCall) Do(f func(context.Context, ChartReference) (*chart.Chart, error)) *MockHelmClientPullChartCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHelmClientPullChartCall) DoAndReturn(f func(context.Context, ChartReference) (*chart.Chart, error)) *MockHelmClientPullChartCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Uninstall mocks base method.
+func (m *MockHelmClient) Uninstall(ctx context.Context, restConfig *rest.Config, namespace, releaseName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Uninstall", ctx, restConfig, namespace, releaseName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Uninstall indicates an expected call of Uninstall.
+func (mr *MockHelmClientMockRecorder) Uninstall(ctx, restConfig, namespace, releaseName any) *MockHelmClientUninstallCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Uninstall", reflect.TypeOf((*MockHelmClient)(nil).Uninstall), ctx, restConfig, namespace, releaseName)
+	return &MockHelmClientUninstallCall{Call: call}
+}
+
+// MockHelmClientUninstallCall wrap *gomock.Call
+type MockHelmClientUninstallCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHelmClientUninstallCall) Return(arg0 error) *MockHelmClientUninstallCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHelmClientUninstallCall) Do(f func(context.Context, *rest.Config, string, string) error) *MockHelmClientUninstallCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHelmClientUninstallCall) DoAndReturn(f func(context.Context, *rest.Config, string, string) error) *MockHelmClientUninstallCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
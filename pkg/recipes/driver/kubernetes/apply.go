@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// fieldManager is the field manager recorded for the fields applied by Kubernetes recipes.
+const fieldManager = "radius-recipe"
+
+// serverSideApplier is the default ObjectApplier. It applies objects one at a time using server-side apply.
+type serverSideApplier struct{}
+
+var _ ObjectApplier = (*serverSideApplier)(nil)
+
+// NewObjectApplier creates an ObjectApplier that uses server-side apply.
+func NewObjectApplier() ObjectApplier {
+	return &serverSideApplier{}
+}
+
+// Apply creates or updates the objects on the cluster described by restConfig using server-side apply.
+func (a *serverSideApplier) Apply(ctx context.Context, restConfig *rest.Config, namespace string, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+
+	return applyObjects(ctx, client, mapper, namespace, objects)
+}
+
+// resettableRESTMapper is a RESTMapper whose cached discovery information can be refreshed, such as
+// restmapper.DeferredDiscoveryRESTMapper.
+type resettableRESTMapper interface {
+	meta.RESTMapper
+	Reset()
+}
+
+// applyObjects applies the objects in order. The objects are applied with the recipe field manager and take
+// ownership of conflicting fields, because the recipe is the source of truth for the objects it declares.
+func applyObjects(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, namespace string, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	applied := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, obj := range objects {
+		mapping, err := restMapping(mapper, obj)
+		if err != nil {
+			return nil, err
+		}
+
+		var resourceClient dynamic.ResourceInterface
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			if obj.GetNamespace() == "" {
+				obj.SetNamespace(namespace)
+			}
+			resourceClient = client.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+		} else {
+			obj.SetNamespace("")
+			resourceClient = client.Resource(mapping.Resource)
+		}
+
+		result, err := resourceClient.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply %s %q: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		applied = append(applied, result)
+	}
+
+	return applied, nil
+}
+
+// restMapping returns the REST mapping of the object kind. Kinds defined by CRDs applied earlier in the same
+// recipe are unknown to the cached discovery information, so the cache is refreshed once if the kind is not found.
+func restMapping(mapper meta.RESTMapper, obj *unstructured.Unstructured) (*meta.RESTMapping, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		if resettable, ok := mapper.(resettableRESTMapper); ok {
+			resettable.Reset()
+			mapping, err = mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the API resource for %s %q: %w", gvk.String(), obj.GetName(), err)
+	}
+
+	return mapping, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func Test_ApplyObjects(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, meta.RESTScopeNamespace)
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	applied := []string{}
+	client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		require.Equal(t, types.ApplyPatchType, patch.GetPatchType())
+		applied = append(applied, patch.GetResource().Resource+"/"+patch.GetNamespace()+"/"+patch.GetName())
+
+		obj := &unstructured.Unstructured{}
+		require.NoError(t, obj.UnmarshalJSON(patch.GetPatch()))
+		return true, obj, nil
+	})
+
+	objects := []*unstructured.Unstructured{
+		newObject("v1", "Namespace", "", "redis"),
+		newObject("v1", "Service", "", "redis"),
+		newObject("v1", "Service", "other", "redis"),
+	}
+
+	result, err := applyObjects(t.Context(), client, mapper, "test-app", objects)
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	require.Equal(t, []string{"namespaces//redis", "services/test-app/redis", "services/other/redis"}, applied)
+	require.Equal(t, "test-app", result[1].GetNamespace())
+}
+
+func Test_ApplyObjects_UnknownKind(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	_, err := applyObjects(t.Context(), client, mapper, "test-app", []*unstructured.Unstructured{
+		newObject("example.com/v1", "Widget", "", "widget"),
+	})
+	require.ErrorContains(t, err, `failed to find the API resource for example.com/v1, Kind=Widget "widget"`)
+}
+
+func newObject(apiVersion string, kind string, namespace string, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
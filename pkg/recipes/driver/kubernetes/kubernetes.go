@@ -0,0 +1,294 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/radius-project/radius/pkg/components/metrics"
+	"github.com/radius-project/radius/pkg/components/trace"
+	"github.com/radius-project/radius/pkg/portableresources/processors"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/driver"
+	"github.com/radius-project/radius/pkg/recipes/kubernetes/clusteraccess"
+	"github.com/radius-project/radius/pkg/recipes/recipecontext"
+	recipes_util "github.com/radius-project/radius/pkg/recipes/util"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	kubernetesresources "github.com/radius-project/radius/pkg/ucp/resources/kubernetes"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+var _ driver.DriverWithSecrets = (*kubernetesDriver)(nil)
+
+// NewKubernetesDriver creates a new instance of driver to execute a Kubernetes manifest recipe.
+func NewKubernetesDriver(resourceClient processors.ResourceClient, options KubernetesOptions) driver.DriverWithSecrets {
+	return &kubernetesDriver{
+		SourceFetcher:         &sourceFetcher{},
+		Applier:               NewObjectApplier(),
+		ResourceClient:        resourceClient,
+		options:               options,
+		clusterAccessResolver: clusteraccess.NewResolver(),
+	}
+}
+
+// KubernetesOptions represents the options of the Kubernetes driver.
+type KubernetesOptions struct {
+	// DeleteRetryCount is the number of times to retry the deletion of an output resource.
+	DeleteRetryCount int
+	// DeleteRetryDelaySeconds is the delay between the retries of the deletion of an output resource.
+	DeleteRetryDelaySeconds int
+}
+
+// kubernetesDriver represents a driver to interact with Kubernetes manifest recipes. The recipe source is an
+// OCI artifact or a git path holding YAML manifests or a kustomization, and its objects are applied with
+// server-side apply.
+type kubernetesDriver struct {
+	// SourceFetcher is used to download the recipe source.
+	SourceFetcher SourceFetcher
+
+	// Applier is used to apply the rendered objects to the cluster.
+	Applier ObjectApplier
+
+	// ResourceClient is used to delete the objects that are no longer part of the recipe.
+	ResourceClient processors.ResourceClient
+
+	options KubernetesOptions
+
+	clusterAccessResolver clusteraccess.ClusterAccessResolver
+}
+
+// Execute downloads the recipe source, renders its objects with the recipe context and applies them to the
+// cluster. The applied objects are the output resources, and the output resources of the previous execution
+// that are no longer rendered by the recipe are deleted.
+func (d *kubernetesDriver) Execute(ctx context.Context, opts driver.ExecuteOptions) (_ *recipes.RecipeOutput, err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "kubernetesdriver.Execute", &opts.Recipe, &opts.Definition)
+	defer func() { trace.EndSpan(span, err) }()
+
+	logger := ucplog.FromContextOrDiscard(ctx)
+	logger.Info(fmt.Sprintf("Deploying recipe: %q, source: %q", opts.Definition.Name, opts.Definition.TemplatePath))
+
+	if opts.Configuration.Runtime.Kubernetes == nil || opts.Configuration.Runtime.Kubernetes.Namespace == "" {
+		err := errors.New("kubernetes recipes require a Kubernetes namespace in the environment runtime configuration")
+		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+	namespace := opts.Configuration.Runtime.Kubernetes.Namespace
+
+	recipeContext, err := recipecontext.New(&opts.Recipe, &opts.Configuration)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+
+	//update the recipe context with connected resources properties
+	recipeContext.Resource.Connections = opts.Recipe.ConnectedResourcesProperties
+
+	objects, err := d.renderRecipe(ctx, opts.BaseOptions, recipeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := d.clusterAccessResolver.Resolve(ctx, &opts.Configuration)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, fmt.Sprintf("failed to resolve the target cluster: %s", err.Error()), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+
+	logger.Info("applying kubernetes objects for recipe", "objects", len(objects), "namespace", namespace)
+	applyCtx, applySpan := trace.StartRecipeSpan(ctx, "kubernetesdriver.Apply", &opts.Recipe, &opts.Definition)
+	applySpan.SetAttributes(attribute.Int("kubernetes.objects", len(objects)), attribute.String("kubernetes.namespace", namespace))
+	applied, err := d.Applier.Apply(applyCtx, restConfig, namespace, objects)
+	trace.EndSpan(applySpan, err)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, fmt.Sprintf("failed to apply kubernetes objects for recipe %s of type %s: %s", opts.Recipe.Name, opts.Definition.ResourceType, err.Error()), recipes_util.ExecutionError, recipes.GetErrorDetails(err))
+	}
+
+	recipeResponse, err := prepareRecipeResponse(opts.Definition, applied)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.InvalidRecipeOutputs, fmt.Sprintf("failed to read the recipe outputs: %s", err.Error()), recipes_util.ExecutionError, recipes.GetErrorDetails(err))
+	}
+
+	// Prune the objects that the recipe no longer renders.
+	garbageCollectionStartTime := time.Now()
+	diff, err := driver.GetGCOutputResources(recipeResponse.Resources, opts.PrevState)
+	if err != nil {
+		return nil, err
+	}
+
+	gcCtx, gcSpan := trace.StartRecipeSpan(ctx, "kubernetesdriver.GarbageCollection", &opts.Recipe, &opts.Definition)
+	err = d.deleteOutputResources(gcCtx, diff)
+	trace.EndSpan(gcSpan, err)
+	if err != nil {
+		metrics.DefaultRecipeEngineMetrics.RecordRecipeGarbageCollectionDuration(ctx, garbageCollectionStartTime,
+			metrics.NewRecipeAttributes(metrics.RecipeEngineOperationGC, opts.Recipe.Name, &opts.Definition, metrics.FailedOperationState))
+		return nil, recipes.NewRecipeError(recipes.RecipeGarbageCollectionFailed, err.Error(), recipes_util.ExecutionError, nil)
+	}
+	metrics.DefaultRecipeEngineMetrics.RecordRecipeGarbageCollectionDuration(ctx, garbageCollectionStartTime,
+		metrics.NewRecipeAttributes(metrics.RecipeEngineOperationGC, opts.Recipe.Name, &opts.Definition, metrics.SuccessfulOperationState))
+
+	return recipeResponse, nil
+}
+
+// Delete deletes the output resources of the recipe that are marked as managed by Radius.
+func (d *kubernetesDriver) Delete(ctx context.Context, opts driver.DeleteOptions) (err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "kubernetesdriver.Delete", &opts.Recipe, &opts.Definition)
+	span.SetAttributes(attribute.Int("recipe.output_resources", len(opts.OutputResources)))
+	defer func() { trace.EndSpan(span, err) }()
+
+	return d.deleteOutputResources(ctx, opts.OutputResources)
+}
+
+// GetRecipeMetadata downloads and renders the recipe source to validate it. Kubernetes recipes are
+// parameterized through the recipe context only, so they declare no parameters.
+func (d *kubernetesDriver) GetRecipeMetadata(ctx context.Context, opts driver.BaseOptions) (map[string]any, error) {
+	if _, err := d.renderRecipe(ctx, opts, nil); err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeGetMetadataFailed, err.Error(), "", recipes.GetErrorDetails(err))
+	}
+
+	return map[string]any{
+		"parameters": map[string]any{},
+	}, nil
+}
+
+// FindSecretIDs returns the secret stores that hold the credentials used to download the recipe source.
+//
+// The environment has no settings for Kubernetes recipes, so the settings of the other drivers that download from
+// the same kind of source are reused: the registry credentials used to pull OCI sources are the registry
+// authentication of the Bicep settings, and the credentials used to clone git sources are the git authentication of
+// the Terraform settings.
+func (d *kubernetesDriver) FindSecretIDs(ctx context.Context, envConfig recipes.Configuration, definition recipes.EnvironmentDefinition) (secretStoreIDResourceKeys map[string][]string, err error) {
+	secretStoreIDResourceKeys = make(map[string][]string)
+	if !isGitSource(definition.TemplatePath) {
+		for _, v := range envConfig.RecipeConfig.Bicep.Authentication {
+			secretStoreIDResourceKeys[v.Secret] = []string{}
+		}
+
+		return secretStoreIDResourceKeys, nil
+	}
+
+	secretStoreID, err := gitSecretStoreID(envConfig, definition.TemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if secretStoreID != "" {
+		secretStoreIDResourceKeys[secretStoreID] = []string{gitSecretKeyPAT, gitSecretKeyUsername}
+	}
+
+	return secretStoreIDResourceKeys, nil
+}
+
+// renderRecipe downloads the recipe source into a temporary directory and renders its objects. If
+// recipeContext is nil, {{context.*}} expressions are left unresolved.
+func (d *kubernetesDriver) renderRecipe(ctx context.Context, opts driver.BaseOptions, recipeContext *recipecontext.Context) ([]*unstructured.Unstructured, error) {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	dir, err := os.MkdirTemp("", "kubernetes-recipe-")
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeDownloadFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logger.Error(err, "Failed to clean up the recipe source directory", "dir", dir)
+		}
+	}()
+
+	downloadStartTime := time.Now()
+	downloadCtx, downloadSpan := trace.StartRecipeSpan(ctx, "kubernetesdriver.DownloadRecipe", &opts.Recipe, &opts.Definition)
+	err = d.SourceFetcher.Fetch(downloadCtx, opts, dir)
+	trace.EndSpan(downloadSpan, err)
+	if err != nil {
+		metrics.DefaultRecipeEngineMetrics.RecordRecipeDownloadDuration(ctx, downloadStartTime,
+			metrics.NewRecipeAttributes(metrics.RecipeEngineOperationDownloadRecipe, opts.Recipe.Name, &opts.Definition, recipes.RecipeDownloadFailed))
+		return nil, recipes.NewRecipeError(recipes.RecipeDownloadFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+	metrics.DefaultRecipeEngineMetrics.RecordRecipeDownloadDuration(ctx, downloadStartTime,
+		metrics.NewRecipeAttributes(metrics.RecipeEngineOperationDownloadRecipe, opts.Recipe.Name, &opts.Definition, metrics.SuccessfulOperationState))
+
+	_, renderSpan := trace.StartRecipeSpan(ctx, "kubernetesdriver.Render", &opts.Recipe, &opts.Definition)
+	objects, err := renderSource(dir, recipeContext)
+	trace.EndSpan(renderSpan, err)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeDeploymentFailed, fmt.Sprintf("failed to render recipe source %q: %s", opts.Definition.TemplatePath, err.Error()), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+
+	return objects, nil
+}
+
+// renderSource renders the objects of the recipe source downloaded into dir.
+func renderSource(dir string, recipeContext *recipecontext.Context) ([]*unstructured.Unstructured, error) {
+	root, err := sourceRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderObjects(root, recipeContext)
+}
+
+// deleteOutputResources deletes the output resources that are marked as managed by Radius, retrying the
+// deletions that fail because of the resources that depend on them.
+func (d *kubernetesDriver) deleteOutputResources(ctx context.Context, outputResources []rpv1.OutputResource) error {
+	return driver.DeleteOutputResources(ctx, d.ResourceClient, outputResources, d.options.DeleteRetryCount, time.Duration(d.options.DeleteRetryDelaySeconds)*time.Second)
+}
+
+// prepareRecipeResponse builds the recipe output from the applied objects. The applied objects are the output
+// resources. Manifests have no outputs of their own, so every output in the outputs mapping must reference a
+// field of an applied object (see recipes_util.ResolveObjectReference); fields of Secrets are routed to secrets.
+func prepareRecipeResponse(definition recipes.EnvironmentDefinition, applied []*unstructured.Unstructured) (*recipes.RecipeOutput, error) {
+	values := map[string]any{}
+	secrets := map[string]any{}
+	for _, outputs := range []map[string]string{definition.Outputs, definition.SecretOutputs} {
+		for _, outputName := range outputs {
+			if !recipes_util.IsObjectReference(outputName) {
+				return nil, fmt.Errorf("output %q must reference a field of an applied object", outputName)
+			}
+
+			value, isSecret, err := recipes_util.ResolveObjectReference(applied, outputName)
+			if err != nil {
+				return nil, err
+			}
+			if isSecret {
+				secrets[outputName] = value
+			} else {
+				values[outputName] = value
+			}
+		}
+	}
+
+	recipeResponse := &recipes.RecipeOutput{}
+	recipeResponse.Values, recipeResponse.Secrets = recipes_util.ApplyOutputsMapping(values, secrets, definition.Outputs, definition.SecretOutputs)
+
+	for _, obj := range applied {
+		gvk := obj.GroupVersionKind()
+		id := kubernetesresources.IDFromParts(kubernetesresources.PlaneNameTODO, gvk.Group, gvk.Kind, obj.GetNamespace(), obj.GetName()).String()
+		if !slices.Contains(recipeResponse.Resources, id) {
+			recipeResponse.Resources = append(recipeResponse.Resources, id)
+		}
+	}
+
+	recipeResponse.Status = &rpv1.RecipeStatus{
+		TemplateKind: recipes.TemplateKindKubernetes,
+		TemplatePath: definition.TemplatePath,
+	}
+
+	return recipeResponse, nil
+}
@@ -0,0 +1,403 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+
+	"github.com/radius-project/radius/pkg/corerp/datamodel"
+	"github.com/radius-project/radius/pkg/portableresources/processors"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/driver"
+	"github.com/radius-project/radius/pkg/recipes/kubernetes/clusteraccess"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	resources_kubernetes "github.com/radius-project/radius/pkg/ucp/resources/kubernetes"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testResourceID = "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Datastores/redisCaches/redis"
+	testNamespace  = "test-app"
+	testManifest   = `apiVersion: v1
+kind: Secret
+metadata:
+  name: '{{context.resource.name}}-auth'
+stringData:
+  password: secret
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: '{{context.resource.name}}'
+spec:
+  ports:
+  - port: 6379
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: '{{context.resource.name}}'
+  namespace: '{{context.runtime.kubernetes.namespace}}'
+spec:
+  replicas: 1
+`
+)
+
+// fakeResolver is a ClusterAccessResolver that always returns the same config.
+type fakeResolver struct {
+	config *rest.Config
+}
+
+func (r *fakeResolver) Resolve(ctx context.Context, envConfig *recipes.Configuration) (*rest.Config, error) {
+	return r.config, nil
+}
+
+func (r *fakeResolver) ResolveKubeconfigSource(ctx context.Context, envConfig *recipes.Configuration) (clusteraccess.KubeconfigSource, error) {
+	return clusteraccess.KubeconfigSource{}, nil
+}
+
+func setupDriver(t *testing.T) (*kubernetesDriver, *MockSourceFetcher, *MockObjectApplier, *processors.MockResourceClient) {
+	ctrl := gomock.NewController(t)
+	sourceFetcher := NewMockSourceFetcher(ctrl)
+	applier := NewMockObjectApplier(ctrl)
+	resourceClient := processors.NewMockResourceClient(ctrl)
+
+	d := &kubernetesDriver{
+		SourceFetcher:         sourceFetcher,
+		Applier:               applier,
+		ResourceClient:        resourceClient,
+		clusterAccessResolver: &fakeResolver{config: &rest.Config{Host: "https://example.com"}},
+	}
+
+	return d, sourceFetcher, applier, resourceClient
+}
+
+func baseOptions() driver.BaseOptions {
+	return driver.BaseOptions{
+		Configuration: recipes.Configuration{
+			Runtime: recipes.RuntimeConfiguration{
+				Kubernetes: &recipes.KubernetesRuntime{
+					Namespace:            testNamespace,
+					EnvironmentNamespace: "test-env",
+				},
+			},
+		},
+		Recipe: recipes.ResourceMetadata{
+			Name:          "default",
+			EnvironmentID: "/planes/radius/local/resourceGroups/test-rg/providers/Radius.Core/environments/env",
+			ApplicationID: "/planes/radius/local/resourceGroups/test-rg/providers/Radius.Core/applications/app",
+			ResourceID:    testResourceID,
+		},
+		Definition: recipes.EnvironmentDefinition{
+			Name:         "default",
+			Driver:       recipes.TemplateKindKubernetes,
+			ResourceType: "Applications.Datastores/redisCaches",
+			TemplatePath: "oci://ghcr.io/radius-project/manifests/redis:1.0.0",
+		},
+	}
+}
+
+// fetchManifest returns a Fetch implementation that writes the manifest into the source directory.
+func fetchManifest(manifest string) func(ctx context.Context, opts driver.BaseOptions, dst string) error {
+	return func(ctx context.Context, opts driver.BaseOptions, dst string) error {
+		return os.WriteFile(filepath.Join(dst, "redis.yaml"), []byte(manifest), 0600)
+	}
+}
+
+// applyObjectsInNamespace is an Apply implementation that returns the objects as the API server would,
+// with the namespace of namespaced objects set.
+func applyObjectsInNamespace(ctx context.Context, restConfig *rest.Config, namespace string, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	for _, obj := range objects {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+	}
+	return objects, nil
+}
+
+func Test_Kubernetes_Execute_Success(t *testing.T) {
+	d, sourceFetcher, applier, resourceClient := setupDriver(t)
+	opts := driver.ExecuteOptions{
+		BaseOptions: baseOptions(),
+		PrevState: []string{
+			"/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis",
+			"/planes/kubernetes/local/namespaces/test-app/providers/apps/StatefulSet/redis-old",
+		},
+	}
+
+	sourceFetcher.EXPECT().Fetch(gomock.Any(), opts.BaseOptions, gomock.Any()).DoAndReturn(fetchManifest(testManifest))
+	applier.EXPECT().
+		Apply(gomock.Any(), gomock.Any(), testNamespace, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, restConfig *rest.Config, namespace string, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+			require.Len(t, objects, 3)
+			require.Equal(t, "redis-auth", objects[0].GetName())
+			require.Equal(t, testNamespace, objects[2].GetNamespace())
+			return applyObjectsInNamespace(ctx, restConfig, namespace, objects)
+		})
+	resourceClient.EXPECT().
+		Delete(gomock.Any(), "/planes/kubernetes/local/namespaces/test-app/providers/apps/StatefulSet/redis-old").
+		Return(nil)
+
+	result, err := d.Execute(t.Context(), opts)
+	require.NoError(t, err)
+	require.Equal(t, &recipes.RecipeOutput{
+		Values:  map[string]any{},
+		Secrets: map[string]any{},
+		Resources: []string{
+			"/planes/kubernetes/local/namespaces/test-app/providers/core/Secret/redis-auth",
+			"/planes/kubernetes/local/namespaces/test-app/providers/core/Service/redis",
+			"/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis",
+		},
+		Status: &rpv1.RecipeStatus{
+			TemplateKind: recipes.TemplateKindKubernetes,
+			TemplatePath: "oci://ghcr.io/radius-project/manifests/redis:1.0.0",
+		},
+	}, result)
+}
+
+func Test_Kubernetes_Execute_OutputsMapping(t *testing.T) {
+	d, sourceFetcher, applier, _ := setupDriver(t)
+	opts := driver.ExecuteOptions{BaseOptions: baseOptions()}
+	opts.Definition.Outputs = map[string]string{
+		"replicas": "Deployment/redis:spec.replicas",
+	}
+	opts.Definition.SecretOutputs = map[string]string{
+		"password": "Secret/redis-auth:data.password",
+	}
+
+	sourceFetcher.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(fetchManifest(testManifest))
+	applier.EXPECT().
+		Apply(gomock.Any(), gomock.Any(), testNamespace, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, restConfig *rest.Config, namespace string, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+			// The API server returns the data of Secrets encoded in base64.
+			require.NoError(t, unstructured.SetNestedField(objects[0].Object, map[string]any{"password": "c2VjcmV0"}, "data"))
+			unstructured.RemoveNestedField(objects[0].Object, "stringData")
+			return applyObjectsInNamespace(ctx, restConfig, namespace, objects)
+		})
+
+	result, err := d.Execute(t.Context(), opts)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"replicas": int64(1)}, result.Values)
+	require.Equal(t, map[string]any{"password": "secret"}, result.Secrets)
+}
+
+func Test_Kubernetes_Execute_InvalidOutputsMapping(t *testing.T) {
+	d, sourceFetcher, applier, _ := setupDriver(t)
+	opts := driver.ExecuteOptions{BaseOptions: baseOptions()}
+	opts.Definition.Outputs = map[string]string{
+		"host": "host",
+	}
+
+	sourceFetcher.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(fetchManifest(testManifest))
+	applier.EXPECT().Apply(gomock.Any(), gomock.Any(), testNamespace, gomock.Any()).DoAndReturn(applyObjectsInNamespace)
+
+	_, err := d.Execute(t.Context(), opts)
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.InvalidRecipeOutputs, recipeError.ErrorDetails.Code)
+}
+
+func Test_Kubernetes_Execute_NoNamespace(t *testing.T) {
+	d, _, _, _ := setupDriver(t)
+	opts := driver.ExecuteOptions{BaseOptions: baseOptions()}
+	opts.Configuration.Runtime.Kubernetes = nil
+
+	_, err := d.Execute(t.Context(), opts)
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.RecipeDeploymentFailed, recipeError.ErrorDetails.Code)
+}
+
+func Test_Kubernetes_Execute_FetchError(t *testing.T) {
+	d, sourceFetcher, _, _ := setupDriver(t)
+	opts := driver.ExecuteOptions{BaseOptions: baseOptions()}
+
+	sourceFetcher.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("not found"))
+
+	_, err := d.Execute(t.Context(), opts)
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.RecipeDownloadFailed, recipeError.ErrorDetails.Code)
+}
+
+func Test_Kubernetes_Execute_EmptySource(t *testing.T) {
+	d, sourceFetcher, _, _ := setupDriver(t)
+	opts := driver.ExecuteOptions{BaseOptions: baseOptions()}
+
+	sourceFetcher.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	_, err := d.Execute(t.Context(), opts)
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.RecipeDeploymentFailed, recipeError.ErrorDetails.Code)
+	require.Equal(t, "setupError", string(recipeError.DeploymentStatus))
+}
+
+func Test_Kubernetes_Execute_ApplyError(t *testing.T) {
+	d, sourceFetcher, applier, _ := setupDriver(t)
+	opts := driver.ExecuteOptions{BaseOptions: baseOptions()}
+
+	sourceFetcher.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(fetchManifest(testManifest))
+	applier.EXPECT().Apply(gomock.Any(), gomock.Any(), testNamespace, gomock.Any()).Return(nil, errors.New("forbidden"))
+
+	_, err := d.Execute(t.Context(), opts)
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.RecipeDeploymentFailed, recipeError.ErrorDetails.Code)
+	require.Equal(t, "executionError", string(recipeError.DeploymentStatus))
+}
+
+func Test_Kubernetes_Delete_Success(t *testing.T) {
+	d, _, _, resourceClient := setupDriver(t)
+	opts := driver.DeleteOptions{
+		BaseOptions: baseOptions(),
+		OutputResources: []rpv1.OutputResource{
+			{
+				ID:            resources_kubernetes.IDFromParts(resources_kubernetes.PlaneNameTODO, "apps", "Deployment", testNamespace, "redis"),
+				RadiusManaged: new(true),
+			},
+			{
+				// We don't expect a call to delete to be made when RadiusManaged is false.
+				ID:            resources_kubernetes.IDFromParts(resources_kubernetes.PlaneNameTODO, "", "Service", testNamespace, "redis"),
+				RadiusManaged: new(false),
+			},
+		},
+	}
+
+	resourceClient.EXPECT().Delete(gomock.Any(), "/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis").Return(nil)
+
+	err := d.Delete(t.Context(), opts)
+	require.NoError(t, err)
+}
+
+func Test_Kubernetes_Delete_Retry(t *testing.T) {
+	d, _, _, resourceClient := setupDriver(t)
+	d.options = KubernetesOptions{DeleteRetryCount: 1}
+	opts := driver.DeleteOptions{
+		BaseOptions: baseOptions(),
+		OutputResources: []rpv1.OutputResource{
+			{
+				ID:            resources_kubernetes.IDFromParts(resources_kubernetes.PlaneNameTODO, "apps", "Deployment", testNamespace, "redis"),
+				RadiusManaged: new(true),
+			},
+		},
+	}
+
+	gomock.InOrder(
+		resourceClient.EXPECT().Delete(gomock.Any(), "/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis").Return(errors.New("conflict")),
+		resourceClient.EXPECT().Delete(gomock.Any(), "/planes/kubernetes/local/namespaces/test-app/providers/apps/Deployment/redis").Return(nil),
+	)
+
+	err := d.Delete(t.Context(), opts)
+	require.NoError(t, err)
+}
+
+func Test_Kubernetes_Delete_Error(t *testing.T) {
+	d, _, _, resourceClient := setupDriver(t)
+	opts := driver.DeleteOptions{
+		BaseOptions: baseOptions(),
+		OutputResources: []rpv1.OutputResource{
+			{
+				ID:            resources_kubernetes.IDFromParts(resources_kubernetes.PlaneNameTODO, "apps", "Deployment", testNamespace, "redis"),
+				RadiusManaged: new(true),
+			},
+		},
+	}
+
+	resourceClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(errors.New("forbidden"))
+
+	err := d.Delete(t.Context(), opts)
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.RecipeDeletionFailed, recipeError.ErrorDetails.Code)
+}
+
+func Test_Kubernetes_GetRecipeMetadata(t *testing.T) {
+	d, sourceFetcher, _, _ := setupDriver(t)
+
+	sourceFetcher.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(fetchManifest(testManifest))
+
+	metadata, err := d.GetRecipeMetadata(t.Context(), baseOptions())
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"parameters": map[string]any{}}, metadata)
+}
+
+func Test_Kubernetes_GetRecipeMetadata_InvalidSource(t *testing.T) {
+	d, sourceFetcher, _, _ := setupDriver(t)
+
+	sourceFetcher.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(fetchManifest("kind: Service\n"))
+
+	_, err := d.GetRecipeMetadata(t.Context(), baseOptions())
+	require.Error(t, err)
+
+	var recipeError *recipes.RecipeError
+	require.True(t, errors.As(err, &recipeError))
+	require.Equal(t, recipes.RecipeGetMetadataFailed, recipeError.ErrorDetails.Code)
+}
+
+func Test_Kubernetes_FindSecretIDs(t *testing.T) {
+	d, _, _, _ := setupDriver(t)
+	envConfig := recipes.Configuration{
+		RecipeConfig: datamodel.RecipeConfigProperties{
+			Bicep: datamodel.BicepConfigProperties{
+				Authentication: map[string]datamodel.RegistrySecretConfig{
+					"ghcr.io": {Secret: "registry-secret"},
+				},
+			},
+			Terraform: datamodel.TerraformConfigProperties{
+				Authentication: datamodel.AuthConfig{
+					Git: datamodel.GitAuthConfig{
+						PAT: map[string]datamodel.SecretConfig{"github.com": {Secret: "git-secret"}},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("OCI source", func(t *testing.T) {
+		ids, err := d.FindSecretIDs(t.Context(), envConfig, recipes.EnvironmentDefinition{TemplatePath: "ghcr.io/org/manifests/redis:1.0.0"})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{"registry-secret": {}}, ids)
+	})
+
+	t.Run("git source", func(t *testing.T) {
+		ids, err := d.FindSecretIDs(t.Context(), envConfig, recipes.EnvironmentDefinition{TemplatePath: "git::https://github.com/org/repo.git//manifests/redis"})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{"git-secret": {gitSecretKeyPAT, gitSecretKeyUsername}}, ids)
+	})
+}
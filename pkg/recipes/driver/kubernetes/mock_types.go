@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: types.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=types.go -destination=./mock_types.go -package=kubernetes -self_package github.com/radius-project/radius/pkg/recipes/driver/kubernetes
+//
+
+// Package kubernetes is a generated GoMock package.
+package kubernetes
+
+import (
+	context "context"
+	reflect "reflect"
+
+	driver "github.com/radius-project/radius/pkg/recipes/driver"
+	gomock "go.uber.org/mock/gomock"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	rest "k8s.io/client-go/rest"
+)
+
+// MockSourceFetcher is a mock of SourceFetcher interface.
+type MockSourceFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockSourceFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockSourceFetcherMockRecorder is the mock recorder for MockSourceFetcher.
+type MockSourceFetcherMockRecorder struct {
+	mock *MockSourceFetcher
+}
+
+// NewMockSourceFetcher creates a new mock instance.
+func NewMockSourceFetcher(ctrl *gomock.Controller) *MockSourceFetcher {
+	mock := &MockSourceFetcher{ctrl: ctrl}
+	mock.recorder = &MockSourceFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSourceFetcher) EXPECT() *MockSourceFetcherMockRecorder {
+	return m.recorder
+}
+
+// Fetch mocks base method.
+func (m *MockSourceFetcher) Fetch(ctx context.Context, opts driver.BaseOptions, dst string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fetch", ctx, opts, dst)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockSourceFetcherMockRecorder) Fetch(ctx, opts, dst any) *MockSourceFetcherFetchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockSourceFetcher)(nil).Fetch), ctx, opts, dst)
+	return &MockSourceFetcherFetchCall{Call: call}
+}
+
+// MockSourceFetcherFetchCall wrap *gomock.Call
+type MockSourceFetcherFetchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSourceFetcherFetchCall) Return(arg0 error) *MockSourceFetcherFetchCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSourceFetcherFetchCall) Do(f func(context.Context, driver.BaseOptions, string) error) *MockSourceFetcherFetchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSourceFetcherFetchCall) DoAndReturn(f func(context.Context, driver.BaseOptions, string) error) *MockSourceFetcherFetchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockObjectApplier is a mock of ObjectApplier interface.
+type MockObjectApplier struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectApplierMockRecorder
+	isgomock struct{}
+}
+
+// MockObjectApplierMockRecorder is the mock recorder for MockObjectApplier.
+type MockObjectApplierMockRecorder struct {
+	mock *MockObjectApplier
+}
+
+// NewMockObjectApplier creates a new mock instance.
+func NewMockObjectApplier(ctrl *gomock.Controller) *MockObjectApplier {
+	mock := &MockObjectApplier{ctrl: ctrl}
+	mock.recorder = &MockObjectApplierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectApplier) EXPECT() *MockObjectApplierMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockObjectApplier) Apply(ctx context.Context, restConfig *rest.Config, namespace string, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, restConfig, namespace, objects)
+	ret0, _ := ret[0].([]*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockObjectApplierMockRecorder) Apply(ctx, restConfig, namespace, objects any) *MockObjectApplierApplyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockObjectApplier)(nil).Apply), ctx, restConfig, namespace, objects)
+	return &MockObjectApplierApplyCall{Call: call}
+}
+
+// MockObjectApplierApplyCall wrap *gomock.Call
+type MockObjectApplierApplyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockObjectApplierApplyCall) Return(arg0 []*unstructured.Unstructured, arg1 error) *MockObjectApplierApplyCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockObjectApplierApplyCall) Do(f func(context.Context, *rest.Config, string, []*unstructured.Unstructured) ([]*unstructured.Unstructured, error)) *MockObjectApplierApplyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockObjectApplierApplyCall) DoAndReturn(f func(context.Context, *rest.Config, string, []*unstructured.Unstructured) ([]*unstructured.Unstructured, error)) *MockObjectApplierApplyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
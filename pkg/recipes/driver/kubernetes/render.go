@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+
+	"github.com/radius-project/radius/pkg/recipes/paramresolver"
+	"github.com/radius-project/radius/pkg/recipes/recipecontext"
+)
+
+// applyOrder lists the kinds that are applied before all other objects because other objects depend on them.
+var applyOrder = []string{"Namespace", "CustomResourceDefinition"}
+
+// renderObjects reads the Kubernetes objects held in dir. If dir holds a kustomization it is built with
+// kustomize, otherwise every YAML and JSON file under dir is read in lexical order of the file paths.
+// {{context.*}} expressions in the objects are then resolved against the recipe context, unless recipeContext is nil.
+func renderObjects(dir string, recipeContext *recipecontext.Context) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	var err error
+	if hasKustomization(dir) {
+		objects, err = buildKustomization(dir)
+	} else {
+		objects, err = readManifests(dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(objects) == 0 {
+		return nil, errors.New("the recipe source does not contain any Kubernetes objects")
+	}
+
+	if recipeContext != nil {
+		for _, obj := range objects {
+			if err := resolveExpressions(obj, recipeContext); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Namespaces and CRDs must exist before the objects that use them, so they are applied first.
+	slices.SortStableFunc(objects, func(a, b *unstructured.Unstructured) int {
+		return applyRank(a) - applyRank(b)
+	})
+
+	return objects, nil
+}
+
+// hasKustomization reports whether dir holds a kustomization file.
+func hasKustomization(dir string) bool {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildKustomization builds the kustomization in dir and returns the resulting objects.
+func buildKustomization(dir string) ([]*unstructured.Unstructured, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := kustomizer.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization: %w", err)
+	}
+
+	content, err := resMap.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization: %w", err)
+	}
+
+	return decodeObjects(content)
+}
+
+// readManifests reads the objects in every YAML and JSON file under dir. Hidden files and directories,
+// such as the .git directory of a cloned repository, are skipped.
+func readManifests(dir string) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		fileObjects, err := decodeObjects(content)
+		if err != nil {
+			rel, _ := filepath.Rel(dir, path)
+			return fmt.Errorf("failed to read %q: %w", rel, err)
+		}
+		objects = append(objects, fileObjects...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// decodeObjects decodes the objects in a multi-document YAML or JSON stream. Empty documents are skipped
+// and List objects are expanded into their items.
+func decodeObjects(content []byte) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		jsonDocument, err := yaml.YAMLToJSON(document)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(jsonDocument)) == 0 || string(bytes.TrimSpace(jsonDocument)) == "null" {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(jsonDocument); err != nil {
+			return nil, err
+		}
+
+		if !obj.IsList() {
+			objects = append(objects, obj)
+			continue
+		}
+
+		err = obj.EachListItem(func(item runtime.Object) error {
+			objects = append(objects, item.(*unstructured.Unstructured))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, obj := range objects {
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, errors.New("every Kubernetes object must declare an apiVersion, a kind and a name")
+		}
+	}
+
+	return objects, nil
+}
+
+// resolveExpressions resolves {{context.*}} expressions in the string fields of obj. A field whose value is a
+// single expression keeps the type of the context value, so for example a port can be used as an integer.
+// Values that start with an expression must be quoted to be valid YAML.
+func resolveExpressions(obj *unstructured.Unstructured, recipeContext *recipecontext.Context) error {
+	resolved := paramresolver.ResolveParameterExpressions(obj.Object, recipeContext)
+
+	// Round-trip through JSON so that resolved values use the types expected by unstructured objects.
+	content, err := json.Marshal(resolved)
+	if err != nil {
+		return err
+	}
+
+	return obj.UnmarshalJSON(content)
+}
+
+// applyRank returns the position of the object kind in the apply order.
+func applyRank(obj *unstructured.Unstructured) int {
+	if i := slices.Index(applyOrder, obj.GetKind()); i >= 0 {
+		return i
+	}
+
+	return len(applyOrder)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/radius-project/radius/pkg/recipes/recipecontext"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	return dir
+}
+
+func Test_RenderObjects_Manifests(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"b/service.yaml": "apiVersion: v1\nkind: Service\nmetadata:\n  name: redis\n",
+		"a/list.json":    `{"apiVersion": "v1", "kind": "List", "items": [{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config"}}]}`,
+		"namespace.yml":  "---\n# comment only\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: redis\n",
+		".git/head.yaml": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: ignored\n",
+		"README.md":      "# Redis\n",
+	})
+
+	objects, err := renderObjects(dir, nil)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, obj := range objects {
+		names = append(names, obj.GetKind()+"/"+obj.GetName())
+	}
+	// Namespaces are applied first, and the other objects keep the lexical order of their files.
+	require.Equal(t, []string{"Namespace/redis", "ConfigMap/config", "Service/redis"}, names)
+}
+
+func Test_RenderObjects_Kustomization(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"kustomization.yaml": "resources:\n- service.yaml\nnamePrefix: prod-\ncommonLabels:\n  app: redis\n",
+		"service.yaml":       "apiVersion: v1\nkind: Service\nmetadata:\n  name: redis\n",
+		"unused.yaml":        "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: unused\n",
+	})
+
+	objects, err := renderObjects(dir, nil)
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	require.Equal(t, "prod-redis", objects[0].GetName())
+	require.Equal(t, map[string]string{"app": "redis"}, objects[0].GetLabels())
+}
+
+func Test_RenderObjects_ResolvesContext(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"service.yaml": `apiVersion: v1
+kind: Service
+metadata:
+  name: '{{context.resource.name}}'
+  labels:
+    app: '{{context.application.name}}-{{context.resource.name}}'
+spec:
+  ports:
+  - port: '{{context.resource.properties.port}}'
+`,
+	})
+
+	recipeContext := &recipecontext.Context{
+		Resource: recipecontext.Resource{
+			ResourceInfo: recipecontext.ResourceInfo{Name: "redis"},
+			Properties:   map[string]any{"port": 6379},
+		},
+		Application: recipecontext.ResourceInfo{Name: "app"},
+	}
+
+	objects, err := renderObjects(dir, recipeContext)
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	require.Equal(t, "redis", objects[0].GetName())
+	require.Equal(t, map[string]string{"app": "app-redis"}, objects[0].GetLabels())
+
+	ports, _, err := unstructured.NestedSlice(objects[0].Object, "spec", "ports")
+	require.NoError(t, err)
+	require.Equal(t, int64(6379), ports[0].(map[string]any)["port"])
+}
+
+func Test_RenderObjects_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{
+			name:  "no objects",
+			files: map[string]string{"README.md": "# Redis\n"},
+			err:   "the recipe source does not contain any Kubernetes objects",
+		},
+		{
+			name:  "missing name",
+			files: map[string]string{"service.yaml": "apiVersion: v1\nkind: Service\n"},
+			err:   "every Kubernetes object must declare an apiVersion, a kind and a name",
+		},
+		{
+			name:  "invalid yaml",
+			files: map[string]string{"service.yaml": "apiVersion: [v1\n"},
+			err:   `failed to read "service.yaml"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := renderObjects(writeFiles(t, tc.files), nil)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
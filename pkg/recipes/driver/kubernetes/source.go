@@ -0,0 +1,238 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	getter "github.com/hashicorp/go-getter/v2"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/radius-project/radius/pkg/recipes/driver"
+	"github.com/radius-project/radius/pkg/rp/util"
+	"github.com/radius-project/radius/pkg/rp/util/authclient"
+)
+
+const (
+	// gitSourcePrefix is the prefix of recipe sources that reference a path in a git repository, for example
+	// "git::https://github.com/org/repo.git//manifests/redis?ref=v1.0.0".
+	gitSourcePrefix = "git::"
+
+	// ociSourcePrefix is the optional prefix of recipe sources that reference an OCI artifact, for example
+	// "oci://ghcr.io/org/manifests/redis:1.0.0".
+	ociSourcePrefix = "oci://"
+
+	// gitSecretKeyPAT and gitSecretKeyUsername are the keys of the secret that holds the credentials of a git
+	// host. They are the keys the Terraform driver reads for private git module sources.
+	gitSecretKeyPAT      = "pat"
+	gitSecretKeyUsername = "username"
+)
+
+// sourceFetcher is the default SourceFetcher. It downloads OCI artifacts with ORAS and git paths with go-getter.
+type sourceFetcher struct {
+	// RegistryClient is the optional client used to interact with the container registry.
+	RegistryClient remote.Client
+}
+
+var _ SourceFetcher = (*sourceFetcher)(nil)
+
+// Fetch downloads the recipe source into dst. Sources prefixed with "git::" are cloned from git, and any
+// other source is pulled as an OCI artifact.
+func (f *sourceFetcher) Fetch(ctx context.Context, opts driver.BaseOptions, dst string) error {
+	if isGitSource(opts.Definition.TemplatePath) {
+		secretStoreID, err := gitSecretStoreID(opts.Configuration, opts.Definition.TemplatePath)
+		if err != nil {
+			return err
+		}
+
+		var credentials map[string]string
+		if secretStoreID != "" {
+			secret, ok := opts.Secrets[secretStoreID]
+			if !ok {
+				return fmt.Errorf("secrets not found for secret store ID %q", secretStoreID)
+			}
+			credentials = secret.Data
+		}
+
+		return fetchGitSource(ctx, opts.Definition.TemplatePath, credentials, dst)
+	}
+
+	return f.fetchOCISource(ctx, opts, dst)
+}
+
+// isGitSource reports whether the recipe source references a path in a git repository.
+func isGitSource(source string) bool {
+	return strings.HasPrefix(source, gitSourcePrefix)
+}
+
+// gitURL returns the URL of the repository referenced by a git source.
+// git::https://example.com/org/repo.git//manifests -> https://example.com/org/repo.git//manifests
+func gitURL(source string) (*url.URL, error) {
+	u := strings.TrimPrefix(source, gitSourcePrefix)
+	if !strings.Contains(u, "://") {
+		u = "https://" + u
+	}
+
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse git url %q: %w", u, err)
+	}
+
+	return parsed, nil
+}
+
+// gitSecretStoreID returns the ID of the secret store that holds the credentials of the git host of source, or
+// "" if there are none. Kubernetes recipes use the git authentication of the Terraform settings of the environment,
+// which is where the environment configures the credentials of its git hosts.
+func gitSecretStoreID(envConfig recipes.Configuration, source string) (string, error) {
+	if !isGitSource(source) {
+		return "", nil
+	}
+
+	u, err := gitURL(source)
+	if err != nil {
+		return "", err
+	}
+
+	hostname := strings.TrimPrefix(u.Hostname(), "www.")
+	if patConfig, ok := envConfig.RecipeConfig.Terraform.Authentication.Git.PAT[hostname]; ok {
+		return patConfig.Secret, nil
+	}
+
+	return "", nil
+}
+
+// fetchGitSource clones the repository referenced by source and copies the referenced path into dst. If credentials
+// has a personal access token, the repository is cloned with it, and with the username if there is one.
+func fetchGitSource(ctx context.Context, source string, credentials map[string]string, dst string) error {
+	client := &getter.Client{
+		Getters: []getter.Getter{new(getter.GitGetter)},
+	}
+
+	src := source
+	token := credentials[gitSecretKeyPAT]
+	if token != "" {
+		u, err := gitURL(source)
+		if err != nil {
+			return err
+		}
+
+		// Like the Terraform driver, the token is the user of the URL when there is no username.
+		u.User = url.User(token)
+		if username := credentials[gitSecretKeyUsername]; username != "" {
+			u.User = url.UserPassword(username, token)
+		}
+		src = gitSourcePrefix + u.String()
+	}
+
+	_, err := client.Get(ctx, &getter.Request{
+		Src:     src,
+		Dst:     dst,
+		GetMode: getter.ModeDir,
+	})
+	if err != nil {
+		// The output of git can include the URL that was cloned, so the token is removed from the error.
+		message := err.Error()
+		if token != "" {
+			message = strings.ReplaceAll(message, url.QueryEscape(token), "***")
+			message = strings.ReplaceAll(message, token, "***")
+		}
+		return fmt.Errorf("failed to download %q: %s", source, message)
+	}
+
+	return nil
+}
+
+// fetchOCISource pulls the OCI artifact referenced by the recipe source into dst, using the registry
+// credentials configured for the environment if there are any. Like Bicep recipes, Kubernetes recipes are pulled
+// from OCI registries, so they use the registry authentication of the Bicep settings of the environment.
+func (f *sourceFetcher) fetchOCISource(ctx context.Context, opts driver.BaseOptions, dst string) error {
+	path := strings.TrimPrefix(opts.Definition.TemplatePath, ociSourcePrefix)
+	repository, tag, err := util.ParseRegistryPath(path)
+	if err != nil {
+		return fmt.Errorf("invalid OCI reference %q: %w", opts.Definition.TemplatePath, err)
+	}
+
+	repo, err := remote.NewRepository(repository)
+	if err != nil {
+		return fmt.Errorf("failed to create client to registry: %w", err)
+	}
+	repo.PlainHTTP = opts.Definition.PlainHTTP
+
+	secrets, err := util.GetRegistrySecrets(opts.Configuration, path, opts.Secrets)
+	if err != nil {
+		return err
+	}
+
+	repo.Client = f.RegistryClient
+	// Get ORAS authentication client if secrets are found for the registry.
+	if !reflect.DeepEqual(secrets, recipes.SecretData{}) {
+		authClient, err := authclient.GetNewRegistryAuthClient(secrets)
+		if err != nil {
+			return err
+		}
+
+		repo.Client, err = authClient.GetAuthClient(ctx, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return pullArtifact(ctx, repo, tag, dst)
+}
+
+// pullArtifact copies the artifact tagged tag from src into dst. Layers are written using their file
+// names, and directories pushed with "oras push" are unpacked, so dst mirrors the pushed content.
+func pullArtifact(ctx context.Context, src oras.ReadOnlyTarget, tag string, dst string) error {
+	store, err := file.New(dst)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if _, err := oras.Copy(ctx, src, tag, store, tag, oras.DefaultCopyOptions); err != nil {
+		return fmt.Errorf("failed to pull %q: %w", tag, err)
+	}
+
+	return nil
+}
+
+// sourceRoot returns the directory that holds the recipe templates. Artifacts created by pushing a directory
+// unpack into a directory of the same name, so a single top-level directory is descended into.
+func sourceRoot(dir string) (string, error) {
+	for {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return "", err
+		}
+
+		if len(entries) != 1 || !entries[0].IsDir() {
+			return dir, nil
+		}
+
+		dir = filepath.Join(dir, entries[0].Name())
+	}
+}
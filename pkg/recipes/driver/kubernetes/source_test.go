@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/radius-project/radius/pkg/corerp/datamodel"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func Test_IsGitSource(t *testing.T) {
+	require.True(t, isGitSource("git::https://github.com/org/repo.git//manifests/redis?ref=v1.0.0"))
+	require.False(t, isGitSource("oci://ghcr.io/org/manifests/redis:1.0.0"))
+	require.False(t, isGitSource("ghcr.io/org/manifests/redis:1.0.0"))
+}
+
+func Test_GitSecretStoreID(t *testing.T) {
+	envConfig := recipes.Configuration{
+		RecipeConfig: datamodel.RecipeConfigProperties{
+			Terraform: datamodel.TerraformConfigProperties{
+				Authentication: datamodel.AuthConfig{
+					Git: datamodel.GitAuthConfig{
+						PAT: map[string]datamodel.SecretConfig{
+							"github.com": {Secret: "/planes/radius/local/resourceGroups/test-group/providers/Radius.Security/secrets/github"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"git::https://github.com/org/repo.git//manifests/redis?ref=v1.0.0", "/planes/radius/local/resourceGroups/test-group/providers/Radius.Security/secrets/github"},
+		{"git::www.github.com/org/repo.git//manifests/redis", "/planes/radius/local/resourceGroups/test-group/providers/Radius.Security/secrets/github"},
+		{"git::https://gitlab.com/org/repo.git//manifests/redis", ""},
+		{"oci://github.com/org/manifests/redis:1.0.0", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.source, func(t *testing.T) {
+			id, err := gitSecretStoreID(envConfig, tc.source)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func Test_FetchGitSource_RedactsToken(t *testing.T) {
+	// Nothing listens on port 1, so the clone fails and its output includes the URL that was cloned.
+	err := fetchGitSource(t.Context(), "git::http://127.0.0.1:1/org/repo.git//manifests", map[string]string{
+		gitSecretKeyUsername: "user",
+		gitSecretKeyPAT:      "secret-token",
+	}, filepath.Join(t.TempDir(), "dst"))
+	require.ErrorContains(t, err, `failed to download "git::http://127.0.0.1:1/org/repo.git//manifests"`)
+	require.NotContains(t, err.Error(), "secret-token")
+}
+
+func Test_PullArtifact(t *testing.T) {
+	// Push a directory to an in-memory registry the same way "oras push" does.
+	srcDir := writeFiles(t, map[string]string{
+		"redis/service.yaml": "apiVersion: v1\nkind: Service\nmetadata:\n  name: redis\n",
+	})
+	store, err := file.New(srcDir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	layer, err := store.Add(t.Context(), "redis", "", filepath.Join(srcDir, "redis"))
+	require.NoError(t, err)
+	manifest, err := oras.PackManifest(t.Context(), store, oras.PackManifestVersion1_1, "application/vnd.radius.kubernetes.recipe", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	require.NoError(t, err)
+
+	registry := memory.New()
+	require.NoError(t, store.Tag(t.Context(), manifest, "1.0.0"))
+	_, err = oras.Copy(t.Context(), store, "1.0.0", registry, "1.0.0", oras.DefaultCopyOptions)
+	require.NoError(t, err)
+
+	dst := t.TempDir()
+	require.NoError(t, pullArtifact(t.Context(), registry, "1.0.0", dst))
+
+	root, err := sourceRoot(dst)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dst, "redis"), root)
+
+	content, err := os.ReadFile(filepath.Join(root, "service.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(content), "kind: Service")
+}
+
+func Test_SourceRoot(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"redis/kustomization.yaml": "resources:\n- base\n",
+		"redis/base/service.yaml":  "apiVersion: v1\nkind: Service\nmetadata:\n  name: redis\n",
+	})
+
+	// The single top-level directory is descended into, and the directory holding several entries is the root.
+	root, err := sourceRoot(dir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "redis"), root)
+}
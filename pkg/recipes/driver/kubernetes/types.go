@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+
+	"github.com/radius-project/radius/pkg/recipes/driver"
+)
+
+//go:generate go tool mockgen -typed -source=types.go -destination=./mock_types.go -package=kubernetes -self_package github.com/radius-project/radius/pkg/recipes/driver/kubernetes
+
+// SourceFetcher downloads the contents of a Kubernetes recipe source into a local directory.
+type SourceFetcher interface {
+	// Fetch downloads the source referenced by the recipe definition into dst.
+	Fetch(ctx context.Context, opts driver.BaseOptions, dst string) error
+}
+
+// ObjectApplier applies Kubernetes objects to a cluster.
+type ObjectApplier interface {
+	// Apply creates or updates the objects on the cluster described by restConfig using server-side apply.
+	// Namespaced objects without a namespace are applied to the given namespace. It returns the objects
+	// as returned by the API server.
+	Apply(ctx context.Context, restConfig *rest.Config, namespace string, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error)
+}
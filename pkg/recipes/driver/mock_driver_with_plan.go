@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/radius-project/radius/pkg/recipes/driver (interfaces: DriverWithPlan)
+//
+// Generated by this command:
+//
+//	mockgen -typed -destination=./mock_driver_with_plan.go -package=driver -self_package github.com/radius-project/radius/pkg/recipes/driver github.com/radius-project/radius/pkg/recipes/driver DriverWithPlan
+//
+
+// Package driver is a generated GoMock package.
+package driver
+
+import (
+	context "context"
+	reflect "reflect"
+
+	recipes "github.com/radius-project/radius/pkg/recipes"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDriverWithPlan is a mock of DriverWithPlan interface.
+type MockDriverWithPlan struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriverWithPlanMockRecorder
+	isgomock struct{}
+}
+
+// MockDriverWithPlanMockRecorder is the mock recorder for MockDriverWithPlan.
+type MockDriverWithPlanMockRecorder struct {
+	mock *MockDriverWithPlan
+}
+
+// NewMockDriverWithPlan creates a new mock instance.
+func NewMockDriverWithPlan(ctrl *gomock.Controller) *MockDriverWithPlan {
+	mock := &MockDriverWithPlan{ctrl: ctrl}
+	mock.recorder = &MockDriverWithPlanMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriverWithPlan) EXPECT() *MockDriverWithPlanMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockDriverWithPlan) Delete(ctx context.Context, opts DeleteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDriverWithPlanMockRecorder) Delete(ctx, opts any) *MockDriverWithPlanDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDriverWithPlan)(nil).Delete), ctx, opts)
+	return &MockDriverWithPlanDeleteCall{Call: call}
+}
+
+// MockDriverWithPlanDeleteCall wrap *gomock.Call
+type MockDriverWithPlanDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDriverWithPlanDeleteCall) Return(arg0 error) *MockDriverWithPlanDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDriverWithPlanDeleteCall) Do(f func(context.Context, DeleteOptions) error) *MockDriverWithPlanDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDriverWithPlanDeleteCall) DoAndReturn(f func(context.Context, DeleteOptions) error) *MockDriverWithPlanDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Execute mocks base method.
+func (m *MockDriverWithPlan) Execute(ctx context.Context, opts ExecuteOptions) (*recipes.RecipeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, opts)
+	ret0, _ := ret[0].(*recipes.RecipeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockDriverWithPlanMockRecorder) Execute(ctx, opts any) *MockDriverWithPlanExecuteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockDriverWithPlan)(nil).Execute), ctx, opts)
+	return &MockDriverWithPlanExecuteCall{Call: call}
+}
+
+// MockDriverWithPlanExecuteCall wrap *gomock.Call
+type MockDriverWithPlanExecuteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDriverWithPlanExecuteCall) Return(arg0 *recipes.RecipeOutput, arg1 error) *MockDriverWithPlanExecuteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDriverWithPlanExecuteCall) Do(f func(context.Context, ExecuteOptions) (*recipes.RecipeOutput, error)) *MockDriverWithPlanExecuteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDriverWithPlanExecuteCall) DoAndReturn(f func(context.Context, ExecuteOptions) (*recipes.RecipeOutput, error)) *MockDriverWithPlanExecuteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRecipeMetadata mocks base method.
+func (m *MockDriverWithPlan) GetRecipeMetadata(ctx context.Context, opts BaseOptions) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeMetadata", ctx, opts)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeMetadata indicates an expected call of GetRecipeMetadata.
+func (mr *MockDriverWithPlanMockRecorder) GetRecipeMetadata(ctx, opts any) *MockDriverWithPlanGetRecipeMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeMetadata", reflect.TypeOf((*MockDriverWithPlan)(nil).GetRecipeMetadata), ctx, opts)
+	return &MockDriverWithPlanGetRecipeMetadataCall{Call: call}
+}
+
+// MockDriverWithPlanGetRecipeMetadataCall wrap *gomock.Call
+type MockDriverWithPlanGetRecipeMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDriverWithPlanGetRecipeMetadataCall) Return(arg0 map[string]any, arg1 error) *MockDriverWithPlanGetRecipeMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDriverWithPlanGetRecipeMetadataCall) Do(f func(context.Context, BaseOptions) (map[string]any, error)) *MockDriverWithPlanGetRecipeMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDriverWithPlanGetRecipeMetadataCall) DoAndReturn(f func(context.Context, BaseOptions) (map[string]any, error)) *MockDriverWithPlanGetRecipeMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Plan mocks base method.
+func (m *MockDriverWithPlan) Plan(ctx context.Context, opts PlanOptions) (*recipes.RecipePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", ctx, opts)
+	ret0, _ := ret[0].(*recipes.RecipePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockDriverWithPlanMockRecorder) Plan(ctx, opts any) *MockDriverWithPlanPlanCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockDriverWithPlan)(nil).Plan), ctx, opts)
+	return &MockDriverWithPlanPlanCall{Call: call}
+}
+
+// MockDriverWithPlanPlanCall wrap *gomock.Call
+type MockDriverWithPlanPlanCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDriverWithPlanPlanCall) Return(arg0 *recipes.RecipePlan, arg1 error) *MockDriverWithPlanPlanCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDriverWithPlanPlanCall) Do(f func(context.Context, PlanOptions) (*recipes.RecipePlan, error)) *MockDriverWithPlanPlanCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDriverWithPlanPlanCall) DoAndReturn(f func(context.Context, PlanOptions) (*recipes.RecipePlan, error)) *MockDriverWithPlanPlanCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
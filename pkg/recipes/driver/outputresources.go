@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/radius-project/radius/pkg/portableresources/processors"
+	"github.com/radius-project/radius/pkg/recipes"
+	recipes_util "github.com/radius-project/radius/pkg/recipes/util"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+// GetGCOutputResources [GC stands for Garbage Collection] compares two slices of resource ids and
+// returns a slice of OutputResources that contains the elements that are in the "previous" slice but not in the "current".
+func GetGCOutputResources(current []string, previous []string) ([]rpv1.OutputResource, error) {
+	// We can easily determine which resources have changed via a brute-force search comparing IDs.
+	// The lists of resources we work with are small, so this is fine.
+	diff := []rpv1.OutputResource{}
+	for _, prevResourceId := range previous {
+		if slices.Contains(current, prevResourceId) {
+			continue
+		}
+
+		id, err := resources.Parse(prevResourceId)
+		if err != nil {
+			return nil, recipes.NewRecipeError(recipes.RecipeGarbageCollectionFailed, err.Error(), recipes_util.ExecutionError, nil)
+		}
+
+		diff = append(diff, rpv1.OutputResource{
+			ID:            id,
+			RadiusManaged: new(true),
+		})
+	}
+
+	return diff, nil
+}
+
+// DeleteOutputResources deletes the output resources that are marked as managed by Radius in parallel. Each
+// deletion is retried up to retryCount times, waiting retryDelay between attempts, unless ctx is done. We don't
+// have context on the dependency ordering here, and since some resources may depend on others, a deletion may
+// only succeed once the resources that depend on it are gone.
+func DeleteOutputResources(ctx context.Context, client processors.ResourceClient, outputResources []rpv1.OutputResource, retryCount int, retryDelay time.Duration) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	g, groupCtx := errgroup.WithContext(ctx)
+	for i := range outputResources {
+		outputResource := outputResources[i]
+
+		// Create a goroutine that handles the deletion of one resource
+		g.Go(func() error {
+			id := outputResource.ID.String()
+
+			// If the resource is not managed by Radius, skip the deletion
+			if outputResource.RadiusManaged == nil || !*outputResource.RadiusManaged {
+				logger.Info(fmt.Sprintf("Skipping deletion of output resource: %q, not managed by Radius", id))
+				return nil
+			}
+
+			logger.V(ucplog.LevelInfo).Info(fmt.Sprintf("Deleting output resource: %q", id))
+
+			var err error
+			for attempt := 0; attempt <= retryCount; attempt++ {
+				if attempt > 0 {
+					logger.V(ucplog.LevelInfo).Error(err, "attempt failed", "attempt", attempt, "delay", retryDelay)
+					select {
+					case <-groupCtx.Done():
+						return recipes.NewRecipeError(recipes.RecipeDeletionFailed, groupCtx.Err().Error(), "", recipes.GetErrorDetails(err))
+					case <-time.After(retryDelay):
+					}
+				}
+
+				err = client.Delete(logr.NewContext(groupCtx, logger), id)
+				if err == nil {
+					logger.V(ucplog.LevelInfo).Info(fmt.Sprintf("Deleted output resource: %q", id))
+					return nil
+				}
+			}
+
+			deletionErr := fmt.Errorf("failed to delete resource after %d attempt(s), last error: %s", retryCount+1, err.Error())
+			return recipes.NewRecipeError(recipes.RecipeDeletionFailed, deletionErr.Error(), "", recipes.GetErrorDetails(deletionErr))
+		})
+	}
+
+	return g.Wait()
+}
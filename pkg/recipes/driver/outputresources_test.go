@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/radius-project/radius/pkg/portableresources/processors"
+	"github.com/radius-project/radius/pkg/recipes"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+	"github.com/radius-project/radius/pkg/ucp/resources"
+)
+
+const (
+	deploymentID = "/planes/kubernetes/local/namespaces/recipe-app/providers/apps/Deployment/redis"
+	serviceID    = "/planes/kubernetes/local/namespaces/recipe-app/providers/core/Service/redis"
+)
+
+func Test_GetGCOutputResources(t *testing.T) {
+	before := []string{
+		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource1",
+		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource2",
+	}
+	after := []string{
+		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource1",
+		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource3",
+	}
+
+	expId := "/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource2"
+	id, err := resources.Parse(expId)
+	require.NoError(t, err)
+	exp := []rpv1.OutputResource{
+		{
+			ID:            id,
+			RadiusManaged: new(true),
+		},
+	}
+	res, err := GetGCOutputResources(after, before)
+	require.NoError(t, err)
+	require.Equal(t, exp, res)
+}
+
+func Test_GetGCOutputResources_NoDiff(t *testing.T) {
+	before := []string{
+		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource1",
+		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource2",
+	}
+	after := []string{
+		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource1",
+		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/resource2",
+	}
+	exp := []rpv1.OutputResource{}
+	res, err := GetGCOutputResources(after, before)
+	require.NoError(t, err)
+	require.Equal(t, exp, res)
+}
+
+func Test_GetGCOutputResources_InvalidID(t *testing.T) {
+	_, err := GetGCOutputResources(nil, []string{"invalid"})
+
+	var recipeError *recipes.RecipeError
+	require.ErrorAs(t, err, &recipeError)
+	require.Equal(t, recipes.RecipeGarbageCollectionFailed, recipeError.ErrorDetails.Code)
+}
+
+func outputResource(t *testing.T, id string, radiusManaged *bool) rpv1.OutputResource {
+	parsed, err := resources.Parse(id)
+	require.NoError(t, err)
+	return rpv1.OutputResource{ID: parsed, RadiusManaged: radiusManaged}
+}
+
+func Test_DeleteOutputResources(t *testing.T) {
+	client := processors.NewMockResourceClient(gomock.NewController(t))
+	client.EXPECT().Delete(gomock.Any(), deploymentID).Return(nil)
+
+	err := DeleteOutputResources(t.Context(), client, []rpv1.OutputResource{
+		outputResource(t, deploymentID, new(true)),
+		outputResource(t, serviceID, new(false)),
+		outputResource(t, serviceID, nil),
+	}, 0, 0)
+	require.NoError(t, err)
+}
+
+func Test_DeleteOutputResources_Retry(t *testing.T) {
+	client := processors.NewMockResourceClient(gomock.NewController(t))
+	gomock.InOrder(
+		client.EXPECT().Delete(gomock.Any(), deploymentID).Return(errors.New("dependent resources exist")),
+		client.EXPECT().Delete(gomock.Any(), deploymentID).Return(nil),
+	)
+
+	err := DeleteOutputResources(t.Context(), client, []rpv1.OutputResource{outputResource(t, deploymentID, new(true))}, 1, time.Millisecond)
+	require.NoError(t, err)
+}
+
+func Test_DeleteOutputResources_Failure(t *testing.T) {
+	client := processors.NewMockResourceClient(gomock.NewController(t))
+	client.EXPECT().Delete(gomock.Any(), deploymentID).Return(errors.New("dependent resources exist")).Times(2)
+
+	err := DeleteOutputResources(t.Context(), client, []rpv1.OutputResource{outputResource(t, deploymentID, new(true))}, 1, time.Millisecond)
+
+	var recipeError *recipes.RecipeError
+	require.ErrorAs(t, err, &recipeError)
+	require.Equal(t, recipes.RecipeDeletionFailed, recipeError.ErrorDetails.Code)
+	require.Equal(t, "failed to delete resource after 2 attempt(s), last error: dependent resources exist", recipeError.ErrorDetails.Message)
+}
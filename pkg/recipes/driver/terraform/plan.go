@@ -0,0 +1,181 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"reflect"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/radius-project/radius/pkg/recipes"
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+)
+
+// preparePlanResponse converts the Terraform plan of a recipe to a recipe plan. Data sources and resources that would
+// not change are omitted, and the values of sensitive attributes are redacted. The changes of a refresh-only plan are
+// the changes made to the resources outside of Terraform, from the state to the actual resources.
+func preparePlanResponse(definition recipes.EnvironmentDefinition, plan *tfjson.Plan, refreshOnly bool) *recipes.RecipePlan {
+	result := &recipes.RecipePlan{
+		Changes: []recipes.ResourceChange{},
+		Status: &rpv1.RecipeStatus{
+			TemplateKind:    recipes.TemplateKindTerraform,
+			TemplatePath:    definition.TemplatePath,
+			TemplateVersion: definition.TemplateVersion,
+		},
+	}
+
+	if plan == nil {
+		return result
+	}
+
+	resourceChanges := plan.ResourceChanges
+	if refreshOnly {
+		resourceChanges = plan.ResourceDrift
+	}
+
+	for _, rc := range resourceChanges {
+		if rc == nil || rc.Change == nil || rc.Mode == tfjson.DataResourceMode {
+			continue
+		}
+
+		action := changeAction(rc.Change.Actions)
+		if action == "" {
+			continue
+		}
+
+		change := recipes.ResourceChange{
+			ID:     rc.Address,
+			Type:   rc.Type,
+			Action: action,
+		}
+
+		// The attributes of a deleted resource are all removed, which is implied by the action.
+		if action != recipes.ChangeActionDelete {
+			change.Attributes = diffAttributes(rc.Change)
+		}
+
+		result.Changes = append(result.Changes, change)
+	}
+
+	sort.Slice(result.Changes, func(i, j int) bool {
+		return result.Changes[i].ID < result.Changes[j].ID
+	})
+
+	return result
+}
+
+// changeAction returns the recipe plan action for the actions of a Terraform resource change, or an empty string if
+// the resource would not change.
+func changeAction(actions tfjson.Actions) string {
+	switch {
+	case actions.Replace():
+		return recipes.ChangeActionReplace
+	case actions.Create():
+		return recipes.ChangeActionCreate
+	case actions.Update():
+		return recipes.ChangeActionUpdate
+	case actions.Delete():
+		return recipes.ChangeActionDelete
+	default:
+		// No-op and read.
+		return ""
+	}
+}
+
+// diffAttributes returns the changes to the top-level attributes of a Terraform resource change. An attribute that is
+// sensitive in part is redacted as a whole.
+func diffAttributes(change *tfjson.Change) []recipes.AttributeChange {
+	before, _ := change.Before.(map[string]any)
+	after, _ := change.After.(map[string]any)
+
+	keys := map[string]struct{}{}
+	for key := range before {
+		keys[key] = struct{}{}
+	}
+	for key := range after {
+		keys[key] = struct{}{}
+	}
+
+	paths := make([]string, 0, len(keys))
+	for key := range keys {
+		paths = append(paths, key)
+	}
+	sort.Strings(paths)
+
+	changes := []recipes.AttributeChange{}
+	for _, path := range paths {
+		attribute := recipes.AttributeChange{Path: path, Before: before[path], After: after[path]}
+
+		unknown := masked(maskAt(change.AfterUnknown, path))
+		if !unknown && reflect.DeepEqual(attribute.Before, attribute.After) {
+			continue
+		}
+
+		if masked(maskAt(change.BeforeSensitive, path)) || masked(maskAt(change.AfterSensitive, path)) {
+			attribute.Sensitive = true
+			if attribute.Before != nil {
+				attribute.Before = recipes.SensitiveValue
+			}
+			if attribute.After != nil {
+				attribute.After = recipes.SensitiveValue
+			}
+		}
+
+		if unknown {
+			attribute.After = recipes.UnknownValue
+		}
+
+		changes = append(changes, attribute)
+	}
+
+	return changes
+}
+
+// maskAt returns the part of a Terraform value mask (such as after_sensitive) for the top-level attribute key. A mask is
+// either a boolean for the whole value, or an object or list of masks mirroring the value.
+func maskAt(mask any, key string) any {
+	switch m := mask.(type) {
+	case bool:
+		return m
+	case map[string]any:
+		return m[key]
+	default:
+		return nil
+	}
+}
+
+// masked returns true if any part of a value is set in the Terraform value mask.
+func masked(mask any) bool {
+	switch m := mask.(type) {
+	case bool:
+		return m
+	case map[string]any:
+		for _, v := range m {
+			if masked(v) {
+				return true
+			}
+		}
+	case []any:
+		for _, v := range m {
+			if masked(v) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
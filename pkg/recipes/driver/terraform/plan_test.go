@@ -0,0 +1,196 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PreparePlanResponse(t *testing.T) {
+	// The resource changes of `terraform show -json` for a plan that updates, replaces, deletes and reads resources.
+	planJSON := `{
+		"format_version": "1.2",
+		"resource_changes": [
+			{
+				"address": "module.db.random_password.admin",
+				"mode": "managed",
+				"type": "random_password",
+				"change": {
+					"actions": ["delete", "create"],
+					"before": {"length": 16, "result": "old-secret"},
+					"after": {"length": 24},
+					"after_unknown": {"result": true},
+					"before_sensitive": {"result": true},
+					"after_sensitive": {"result": true}
+				}
+			},
+			{
+				"address": "module.db.aws_db_instance.db",
+				"mode": "managed",
+				"type": "aws_db_instance",
+				"change": {
+					"actions": ["update"],
+					"before": {"instance_class": "db.t3.micro", "port": 5432, "tags": {"env": "dev"}, "password": "old"},
+					"after": {"instance_class": "db.t3.small", "port": 5432, "tags": {"env": "prod"}, "password": "new"},
+					"after_unknown": {},
+					"before_sensitive": {"password": true},
+					"after_sensitive": {"password": true, "tags": {}}
+				}
+			},
+			{
+				"address": "module.db.aws_s3_bucket.logs",
+				"mode": "managed",
+				"type": "aws_s3_bucket",
+				"change": {
+					"actions": ["delete"],
+					"before": {"bucket": "logs"},
+					"after": null
+				}
+			},
+			{
+				"address": "module.db.aws_security_group.db",
+				"mode": "managed",
+				"type": "aws_security_group",
+				"change": {
+					"actions": ["no-op"],
+					"before": {"name": "db"},
+					"after": {"name": "db"}
+				}
+			},
+			{
+				"address": "module.db.data.aws_vpc.default",
+				"mode": "data",
+				"type": "aws_vpc",
+				"change": {
+					"actions": ["read"],
+					"before": null,
+					"after": {"default": true}
+				}
+			}
+		]
+	}`
+
+	plan := &tfjson.Plan{}
+	require.NoError(t, json.Unmarshal([]byte(planJSON), plan))
+
+	result := preparePlanResponse(recipes.EnvironmentDefinition{TemplatePath: "db/aws"}, plan, false)
+	require.True(t, result.HasChanges())
+	require.Equal(t, "db/aws", result.Status.TemplatePath)
+	require.Equal(t, []recipes.ResourceChange{
+		{
+			ID:     "module.db.aws_db_instance.db",
+			Type:   "aws_db_instance",
+			Action: recipes.ChangeActionUpdate,
+			Attributes: []recipes.AttributeChange{
+				{Path: "instance_class", Before: "db.t3.micro", After: "db.t3.small"},
+				{Path: "password", Before: recipes.SensitiveValue, After: recipes.SensitiveValue, Sensitive: true},
+				{Path: "tags", Before: map[string]any{"env": "dev"}, After: map[string]any{"env": "prod"}},
+			},
+		},
+		{
+			ID:     "module.db.aws_s3_bucket.logs",
+			Type:   "aws_s3_bucket",
+			Action: recipes.ChangeActionDelete,
+		},
+		{
+			ID:     "module.db.random_password.admin",
+			Type:   "random_password",
+			Action: recipes.ChangeActionReplace,
+			Attributes: []recipes.AttributeChange{
+				{Path: "length", Before: float64(16), After: float64(24)},
+				{Path: "result", Before: recipes.SensitiveValue, After: recipes.UnknownValue, Sensitive: true},
+			},
+		},
+	}, result.Changes)
+}
+
+func Test_PreparePlanResponse_RefreshOnly(t *testing.T) {
+	// A refresh-only plan of a bucket whose tags were changed and a queue that was deleted outside of Terraform.
+	planJSON := `{
+		"format_version": "1.2",
+		"resource_drift": [
+			{
+				"address": "aws_sqs_queue.jobs",
+				"mode": "managed",
+				"type": "aws_sqs_queue",
+				"change": {
+					"actions": ["delete"],
+					"before": {"name": "jobs"},
+					"after": null
+				}
+			},
+			{
+				"address": "aws_s3_bucket.main",
+				"mode": "managed",
+				"type": "aws_s3_bucket",
+				"change": {
+					"actions": ["update"],
+					"before": {"bucket": "main", "tags": {"env": "prod"}},
+					"after": {"bucket": "main", "tags": {"env": "test"}},
+					"after_unknown": {}
+				}
+			}
+		],
+		"resource_changes": [
+			{
+				"address": "aws_s3_bucket.main",
+				"mode": "managed",
+				"type": "aws_s3_bucket",
+				"change": {
+					"actions": ["no-op"],
+					"before": {"bucket": "main", "tags": {"env": "test"}},
+					"after": {"bucket": "main", "tags": {"env": "test"}}
+				}
+			}
+		]
+	}`
+
+	plan := &tfjson.Plan{}
+	require.NoError(t, json.Unmarshal([]byte(planJSON), plan))
+
+	result := preparePlanResponse(recipes.EnvironmentDefinition{}, plan, true)
+	require.Equal(t, []recipes.ResourceChange{
+		{
+			ID:     "aws_s3_bucket.main",
+			Type:   "aws_s3_bucket",
+			Action: recipes.ChangeActionUpdate,
+			Attributes: []recipes.AttributeChange{
+				{Path: "tags", Before: map[string]any{"env": "prod"}, After: map[string]any{"env": "test"}},
+			},
+		},
+		{
+			ID:     "aws_sqs_queue.jobs",
+			Type:   "aws_sqs_queue",
+			Action: recipes.ChangeActionDelete,
+		},
+	}, result.Changes)
+
+	// The drift is ignored by a plan that is not refresh-only.
+	result = preparePlanResponse(recipes.EnvironmentDefinition{}, plan, false)
+	require.False(t, result.HasChanges())
+}
+
+func Test_PreparePlanResponse_NoChanges(t *testing.T) {
+	result := preparePlanResponse(recipes.EnvironmentDefinition{}, nil, false)
+	require.False(t, result.HasChanges())
+	require.Empty(t, result.Changes)
+}
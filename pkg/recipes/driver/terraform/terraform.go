@@ -30,12 +30,14 @@ import (
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
 	"github.com/radius-project/radius/pkg/components/kubernetesclient/kubernetesclientprovider"
 	"github.com/radius-project/radius/pkg/components/secret/secretprovider"
+	"github.com/radius-project/radius/pkg/components/trace"
 	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
 	"golang.org/x/exp/slices"
 
 	"github.com/radius-project/radius/pkg/recipes"
 	"github.com/radius-project/radius/pkg/recipes/driver"
 	"github.com/radius-project/radius/pkg/recipes/terraform"
+	"github.com/radius-project/radius/pkg/recipes/terraform/config/backends"
 	recipes_util "github.com/radius-project/radius/pkg/recipes/util"
 	"github.com/radius-project/radius/pkg/sdk"
 	resources "github.com/radius-project/radius/pkg/ucp/resources"
@@ -48,11 +50,12 @@ import (
 )
 
 var _ driver.Driver = (*terraformDriver)(nil)
+var _ driver.DriverWithPlan = (*terraformDriver)(nil)
 
 // NewTerraformDriver creates a new instance of driver to execute a Terraform recipe.
 func NewTerraformDriver(ucpConn sdk.Connection, secretProvider *secretprovider.SecretProvider, options TerraformOptions, kubernetesClients kubernetesclientprovider.KubernetesClientProvider) driver.Driver {
 	return &terraformDriver{
-		terraformExecutor: terraform.NewExecutor(ucpConn, secretProvider, kubernetesClients),
+		terraformExecutor: terraform.NewExecutor(ucpConn, secretProvider, kubernetesClients, options.PostgreSQLURL),
 		options:           options,
 	}
 }
@@ -64,6 +67,10 @@ type TerraformOptions struct {
 
 	// LogLevel is the log level for Terraform execution. Valid values: TRACE, DEBUG, INFO, WARN, ERROR, OFF. Default: ERROR.
 	LogLevel string
+
+	// PostgreSQLURL is the connection URL of the control plane PostgreSQL database, used by the postgresql Terraform
+	// state backend. Empty when the control plane does not use PostgreSQL.
+	PostgreSQLURL string
 }
 
 // terraformDriver represents a driver to interact with Terraform Recipe - deploy recipe, delete resources, etc.
@@ -77,7 +84,10 @@ type terraformDriver struct {
 
 // Execute creates a unique directory for each execution of terraform and deploys the recipe using the
 // the Terraform CLI through terraform-exec. It returns a RecipeOutput or an error if the deployment fails.
-func (d *terraformDriver) Execute(ctx context.Context, opts driver.ExecuteOptions) (*recipes.RecipeOutput, error) {
+func (d *terraformDriver) Execute(ctx context.Context, opts driver.ExecuteOptions) (_ *recipes.RecipeOutput, err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "terraformdriver.Execute", &opts.Recipe, &opts.Definition)
+	defer func() { trace.EndSpan(span, err) }()
+
 	logger := ucplog.FromContextOrDiscard(ctx)
 
 	requestDirPath, err := d.createExecutionDirectory(ctx, opts.Recipe, opts.Definition)
@@ -131,7 +141,10 @@ func (d *terraformDriver) Execute(ctx context.Context, opts driver.ExecuteOption
 
 // Delete creates a unique directory for each execution of terraform and deletes the resources deployed by the Terraform module
 // using the Terraform CLI through terraform-exec. It returns an error if the deletion fails.
-func (d *terraformDriver) Delete(ctx context.Context, opts driver.DeleteOptions) error {
+func (d *terraformDriver) Delete(ctx context.Context, opts driver.DeleteOptions) (err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "terraformdriver.Delete", &opts.Recipe, &opts.Definition)
+	defer func() { trace.EndSpan(span, err) }()
+
 	logger := ucplog.FromContextOrDiscard(ctx)
 
 	requestDirPath, err := d.createExecutionDirectory(ctx, opts.Recipe, opts.Definition)
@@ -178,6 +191,59 @@ func (d *terraformDriver) Delete(ctx context.Context, opts driver.DeleteOptions)
 	return nil
 }
 
+// Plan creates a unique directory for each execution of terraform and plans the recipe using the Terraform CLI through
+// terraform-exec. It returns the changes that applying the recipe would make, with sensitive values redacted.
+func (d *terraformDriver) Plan(ctx context.Context, opts driver.PlanOptions) (_ *recipes.RecipePlan, err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "terraformdriver.Plan", &opts.Recipe, &opts.Definition)
+	defer func() { trace.EndSpan(span, err) }()
+
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	requestDirPath, err := d.createExecutionDirectory(ctx, opts.Recipe, opts.Definition)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipePlanFailed, err.Error(), recipes_util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+	defer func() {
+		if err := os.RemoveAll(requestDirPath); err != nil {
+			logger.Info(fmt.Sprintf("Failed to cleanup Terraform execution directory %q. Err: %s", requestDirPath, err.Error()))
+		}
+	}()
+
+	// Get the secret store ID associated with the git private terraform repository source.
+	secretStoreID, err := GetPrivateGitRepoSecretStoreID(opts.Configuration, opts.Definition.TemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add credential information to .gitconfig for module source of type git if applicable.
+	err = addSecretsToGitConfigIfApplicable(secretStoreID, opts.Secrets, requestDirPath, opts.Definition.TemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tfPlan, err := d.terraformExecutor.Plan(ctx, terraform.Options{
+		RootDir:          requestDirPath,
+		EnvConfig:        &opts.Configuration,
+		ResourceRecipe:   &opts.Recipe,
+		EnvRecipe:        &opts.Definition,
+		Secrets:          opts.Secrets,
+		StateLockTimeout: terraform.DefaultStateLockTimeout,
+		LogLevel:         d.options.LogLevel,
+		RefreshOnly:      opts.RefreshOnly,
+	})
+
+	unsetError := unsetGitConfigForDirIfApplicable(secretStoreID, opts.Secrets, requestDirPath, opts.Definition.TemplatePath)
+	if unsetError != nil {
+		return nil, unsetError
+	}
+
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipePlanFailed, err.Error(), recipes_util.ExecutionError, recipes.GetErrorDetails(err))
+	}
+
+	return preparePlanResponse(opts.Definition, tfPlan, opts.RefreshOnly), nil
+}
+
 // prepareRecipeResponse populates the recipe response from the module output named "result" and the
 // resources deployed by the Terraform module. The outputs and resources are retrieved from the input Terraform JSON state.
 //
@@ -366,6 +432,12 @@ func (d *terraformDriver) FindSecretIDs(ctx context.Context, envConfig recipes.C
 		}
 	}
 
+	// Include the access keys of the s3 state backend (Radius.Core path).
+	if backend := envConfig.RecipeConfig.Terraform.Backend; backend != nil && backend.S3 != nil && backend.S3.Secret != "" {
+		secretStoreIDResourceKeys[backend.S3.Secret] = append(secretStoreIDResourceKeys[backend.S3.Secret],
+			backends.S3BackendAccessKeyIDKey, backends.S3BackendSecretAccessKeyKey)
+	}
+
 	return secretStoreIDResourceKeys, nil
 }
 
@@ -127,7 +127,7 @@ func Test_Terraform_Execute_Success(t *testing.T) {
 		},
 	}
 
-	tfExecutor.EXPECT().Deploy(ctx, gomock.Any()).Times(1).Return(expectedTFState, nil)
+	tfExecutor.EXPECT().Deploy(gomock.Any(), gomock.Any()).Times(1).Return(expectedTFState, nil)
 
 	recipeOutput, err := tfDriver.Execute(ctx, driver.ExecuteOptions{
 		BaseOptions: driver.BaseOptions{
@@ -157,7 +157,7 @@ func Test_Terraform_Execute_DeploymentFailure(t *testing.T) {
 		},
 		DeploymentStatus: "executionError",
 	}
-	tfExecutor.EXPECT().Deploy(ctx, gomock.Any()).Times(1).Return(nil, errors.New("Failed to deploy terraform module"))
+	tfExecutor.EXPECT().Deploy(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("Failed to deploy terraform module"))
 
 	_, err := tfDriver.Execute(ctx, driver.ExecuteOptions{
 		BaseOptions: driver.BaseOptions{
@@ -203,7 +203,7 @@ func Test_Terraform_Execute_OutputsFailure(t *testing.T) {
 		},
 		DeploymentStatus: "executionError",
 	}
-	tfExecutor.EXPECT().Deploy(ctx, gomock.Any()).Times(1).Return(expectedTFState, nil)
+	tfExecutor.EXPECT().Deploy(gomock.Any(), gomock.Any()).Times(1).Return(expectedTFState, nil)
 
 	_, err := tfDriver.Execute(ctx, driver.ExecuteOptions{
 		BaseOptions: driver.BaseOptions{
@@ -276,7 +276,7 @@ func Test_Terraform_Execute_EmptyOperationID_Success(t *testing.T) {
 	}
 
 	tfExecutor.EXPECT().
-		Deploy(ctx, gomock.Any()).
+		Deploy(gomock.Any(), gomock.Any()).
 		Times(1).
 		Return(expectedTFState, nil)
 
@@ -324,7 +324,7 @@ func TestTerraformDriver_GetRecipeMetadata_Success(t *testing.T) {
 			"redis_cache_name": "redis-test",
 		},
 	}
-	tfExecutor.EXPECT().GetRecipeMetadata(ctx, gomock.Any()).Times(1).Return(expectedOutput, nil)
+	tfExecutor.EXPECT().GetRecipeMetadata(gomock.Any(), gomock.Any()).Times(1).Return(expectedOutput, nil)
 
 	recipeData, err := tfDriver.GetRecipeMetadata(ctx, driver.BaseOptions{
 		Recipe:     recipes.ResourceMetadata{},
@@ -371,7 +371,7 @@ func TestTerraformDriver_GetRecipeMetadata_Failure(t *testing.T) {
 			Message: "Failed to download module",
 		},
 	}
-	tfExecutor.EXPECT().GetRecipeMetadata(ctx, gomock.Any()).Times(1).Return(nil, errors.New("Failed to download module"))
+	tfExecutor.EXPECT().GetRecipeMetadata(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("Failed to download module"))
 
 	_, err := tfDriver.GetRecipeMetadata(ctx, driver.BaseOptions{
 		Recipe:     recipes.ResourceMetadata{},
@@ -392,7 +392,7 @@ func Test_Terraform_Delete_Success(t *testing.T) {
 	tfExecutor, tfDriver := setup(t)
 	envConfig, recipeMetadata, envRecipe := buildTestInputs()
 
-	tfExecutor.EXPECT().Delete(ctx, gomock.Any()).Times(1).Return(nil)
+	tfExecutor.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 
 	err := tfDriver.Delete(ctx, driver.DeleteOptions{
 		BaseOptions: driver.BaseOptions{
@@ -440,7 +440,7 @@ func Test_Terraform_Delete_Failure(t *testing.T) {
 	tfExecutor, tfDriver := setup(t)
 	envConfig, recipeMetadata, envRecipe := buildTestInputs()
 
-	tfExecutor.EXPECT().Delete(ctx, gomock.Any()).Times(1).
+	tfExecutor.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).
 		Return(errors.New("Failed to delete terraform module"))
 
 	expErr := recipes.RecipeError{
@@ -463,6 +463,82 @@ func Test_Terraform_Delete_Failure(t *testing.T) {
 	verifyDirectoryCleanup(t, tfDriver.options.Path, armCtx.OperationID.String())
 }
 
+func Test_Terraform_Plan_Success(t *testing.T) {
+	ctx := t.Context()
+	armCtx := &v1.ARMRequestContext{
+		OperationID: uuid.New(),
+	}
+	ctx = v1.WithARMRequestContext(ctx, armCtx)
+
+	tfExecutor, tfDriver := setup(t)
+	envConfig, recipeMetadata, envRecipe := buildTestInputs()
+
+	tfExecutor.EXPECT().Plan(gomock.Any(), gomock.Any()).Times(1).Return(&tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "module.redis-azure.azurerm_redis_cache.redis",
+				Type:    "azurerm_redis_cache",
+				Mode:    tfjson.ManagedResourceMode,
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+					After:   map[string]any{"name": "redis-test"},
+				},
+			},
+		},
+	}, nil)
+
+	plan, err := tfDriver.Plan(ctx, driver.PlanOptions{
+		BaseOptions: driver.BaseOptions{
+			Configuration: envConfig,
+			Recipe:        recipeMetadata,
+			Definition:    envRecipe,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []recipes.ResourceChange{
+		{
+			ID:         "module.redis-azure.azurerm_redis_cache.redis",
+			Type:       "azurerm_redis_cache",
+			Action:     recipes.ChangeActionCreate,
+			Attributes: []recipes.AttributeChange{{Path: "name", After: "redis-test"}},
+		},
+	}, plan.Changes)
+	require.Equal(t, &rpv1.RecipeStatus{TemplateKind: recipes.TemplateKindTerraform, TemplatePath: envRecipe.TemplatePath, TemplateVersion: envRecipe.TemplateVersion}, plan.Status)
+	verifyDirectoryCleanup(t, tfDriver.options.Path, armCtx.OperationID.String())
+}
+
+func Test_Terraform_Plan_Failure(t *testing.T) {
+	ctx := t.Context()
+	armCtx := &v1.ARMRequestContext{
+		OperationID: uuid.New(),
+	}
+	ctx = v1.WithARMRequestContext(ctx, armCtx)
+
+	tfExecutor, tfDriver := setup(t)
+	envConfig, recipeMetadata, envRecipe := buildTestInputs()
+
+	tfExecutor.EXPECT().Plan(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("Failed to plan terraform module"))
+
+	expErr := recipes.RecipeError{
+		ErrorDetails: v1.ErrorDetails{
+			Code:    recipes.RecipePlanFailed,
+			Message: "Failed to plan terraform module",
+		},
+		DeploymentStatus: "executionError",
+	}
+
+	_, err := tfDriver.Plan(ctx, driver.PlanOptions{
+		BaseOptions: driver.BaseOptions{
+			Configuration: envConfig,
+			Recipe:        recipeMetadata,
+			Definition:    envRecipe,
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, &expErr, err)
+	verifyDirectoryCleanup(t, tfDriver.options.Path, armCtx.OperationID.String())
+}
+
 func Test_Terraform_PrepareRecipeResponse(t *testing.T) {
 	d := &terraformDriver{}
 	tests := []struct {
@@ -1129,6 +1205,24 @@ func Test_FindSecretIDs(t *testing.T) {
 				"secret-store-id-env": {"secret-key-env1"},
 			},
 		},
+		{
+			name: "Secret of the s3 state backend",
+			envConfig: recipes.Configuration{
+				RecipeConfig: datamodel.RecipeConfigProperties{
+					Terraform: datamodel.TerraformConfigProperties{
+						Backend: &datamodel.TerraformBackendConfig{
+							Kind: datamodel.TerraformBackendS3,
+							S3:   &datamodel.TerraformS3BackendConfig{Bucket: "tfstate", Secret: "secret-store-s3"},
+						},
+					},
+				},
+			},
+			definition:    recipes.EnvironmentDefinition{TemplatePath: "Azure/redis/azurerm"},
+			expectedError: false,
+			expectedSecretIDs: map[string][]string{
+				"secret-store-s3": {"accessKeyId", "secretAccessKey"},
+			},
+		},
 		{
 			name:          "GetPrivateGitRepoSecretStoreID returns error",
 			definition:    recipes.EnvironmentDefinition{TemplatePath: "git::https://dev.azu  re.com/project/module"},
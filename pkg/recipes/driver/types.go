@@ -48,6 +48,17 @@ type DriverWithSecrets interface {
 	FindSecretIDs(ctx context.Context, config recipes.Configuration, definition recipes.EnvironmentDefinition) (secretIDs map[string][]string, err error)
 }
 
+// DriverWithPlan is an optional interface and used when the driver can plan a recipe deployment without deploying it.
+//
+//go:generate go tool mockgen -typed -destination=./mock_driver_with_plan.go -package=driver -self_package github.com/radius-project/radius/pkg/recipes/driver github.com/radius-project/radius/pkg/recipes/driver DriverWithPlan
+type DriverWithPlan interface {
+	// Driver is an interface to implement recipe deployment and recipe resources deletion.
+	Driver
+
+	// Plan fetches the recipe contents and returns the changes that deploying the recipe would make, without making them.
+	Plan(ctx context.Context, opts PlanOptions) (*recipes.RecipePlan, error)
+}
+
 // BaseOptions is the base options for the driver operations.
 type BaseOptions struct {
 	// Configuration is the configuration for the recipe.
@@ -90,3 +101,15 @@ type DeleteOptions struct {
 	// OutputResources is the list of output resources for the recipe.
 	OutputResources []rpv1.OutputResource
 }
+
+// PlanOptions is the options for the Plan method.
+type PlanOptions struct {
+	BaseOptions
+
+	// PrevState represents the previously deployed state of output resource IDs.
+	PrevState []string
+
+	// RefreshOnly plans only the changes made to the resources of the recipe outside of the recipe, such as a
+	// resource that was modified or deleted directly in the cloud, instead of the changes the recipe would make.
+	RefreshOnly bool
+}
@@ -22,9 +22,12 @@ import (
 	"time"
 
 	"github.com/radius-project/radius/pkg/components/metrics"
+	"github.com/radius-project/radius/pkg/components/trace"
 	"github.com/radius-project/radius/pkg/recipes"
 	"github.com/radius-project/radius/pkg/recipes/configloader"
 	recipedriver "github.com/radius-project/radius/pkg/recipes/driver"
+	"github.com/radius-project/radius/pkg/recipes/paramresolver"
+	"github.com/radius-project/radius/pkg/recipes/recipecontext"
 	"github.com/radius-project/radius/pkg/recipes/util"
 	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
 	"github.com/radius-project/radius/pkg/ucp/ucplog"
@@ -55,7 +58,10 @@ func (e *engine) Execute(ctx context.Context, opts ExecuteOptions) (*recipes.Rec
 	executionStart := time.Now()
 	result := metrics.SuccessfulOperationState
 
-	recipeOutput, definition, err := e.executeCore(ctx, opts.Recipe, opts.PreviousState)
+	ctx, span := trace.StartRecipeSpan(ctx, "recipeengine.Execute", &opts.Recipe, nil)
+	recipeOutput, definition, err := e.executeCore(ctx, opts.Recipe, opts.PreviousState, opts.Simulated)
+	span.SetAttributes(trace.RecipeAttributes(nil, definition)...)
+	trace.EndSpan(span, err)
 	if err != nil {
 		result = metrics.FailedOperationState
 		if errorDetails := recipes.GetErrorDetails(err); errorDetails != nil {
@@ -66,13 +72,15 @@ func (e *engine) Execute(ctx context.Context, opts ExecuteOptions) (*recipes.Rec
 	metrics.DefaultRecipeEngineMetrics.RecordRecipeOperationDuration(ctx, executionStart,
 		metrics.NewRecipeAttributes(metrics.RecipeEngineOperationExecute, opts.Recipe.Name,
 			definition, result))
+	metrics.DefaultRecipeEngineMetrics.RecordRecipeDuration(ctx, executionStart,
+		metrics.NewRecipeDurationAttributes(metrics.RecipeEngineOperationExecute, definition, err != nil))
 
 	return recipeOutput, err
 }
 
 // executeCore function is the core logic of the Execute function.
 // Any changes to the core logic of the Execute function should be made here.
-func (e *engine) executeCore(ctx context.Context, recipe recipes.ResourceMetadata, prevState []string) (*recipes.RecipeOutput, *recipes.EnvironmentDefinition, error) {
+func (e *engine) executeCore(ctx context.Context, recipe recipes.ResourceMetadata, prevState []string, simulated bool) (*recipes.RecipeOutput, *recipes.EnvironmentDefinition, error) {
 	logger := ucplog.FromContextOrDiscard(ctx)
 
 	configuration, err := e.options.ConfigurationLoader.LoadConfiguration(ctx, recipe)
@@ -86,6 +94,17 @@ func (e *engine) executeCore(ctx context.Context, recipe recipes.ResourceMetadat
 		return nil, nil, nil
 	}
 
+	if simulated {
+		definition, err := e.options.ConfigurationLoader.LoadRecipe(ctx, &recipe)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		logger.Info("simulated execution requested, resolving recipe without deployment")
+		res, err := simulate(recipe, configuration, definition)
+		return res, definition, err
+	}
+
 	definition, driver, err := e.getDriver(ctx, recipe)
 	if err != nil {
 		return nil, nil, err
@@ -112,12 +131,38 @@ func (e *engine) executeCore(ctx context.Context, recipe recipes.ResourceMetadat
 	return res, definition, nil
 }
 
+// simulate returns the output of a simulated recipe execution: the template the recipe would
+// be deployed from and its parameters, merged from the environment and the resource and with
+// {{context.*}} expressions resolved. No driver is called and nothing is deployed.
+func simulate(recipe recipes.ResourceMetadata, configuration *recipes.Configuration, definition *recipes.EnvironmentDefinition) (*recipes.RecipeOutput, error) {
+	recipeContext, err := recipecontext.New(&recipe, configuration)
+	if err != nil {
+		return nil, recipes.NewRecipeError(recipes.RecipeValidationFailed, err.Error(), util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+	recipeContext.Resource.Connections = recipe.ConnectedResourcesProperties
+
+	// Resource (developer) parameters take precedence over environment (operator) parameters.
+	parameters := util.ShallowMergeParameters(definition.Parameters, recipe.Parameters)
+
+	return &recipes.RecipeOutput{
+		Parameters: paramresolver.ResolveParameterExpressions(parameters, recipeContext),
+		Status: &rpv1.RecipeStatus{
+			TemplateKind:    definition.Driver,
+			TemplatePath:    definition.TemplatePath,
+			TemplateVersion: definition.TemplateVersion,
+		},
+	}, nil
+}
+
 // Delete calls the Delete method of the driver specified in the recipe definition to delete the output resources.
 func (e *engine) Delete(ctx context.Context, opts DeleteOptions) error {
 	deletionStart := time.Now()
 	result := metrics.SuccessfulOperationState
 
+	ctx, span := trace.StartRecipeSpan(ctx, "recipeengine.Delete", &opts.Recipe, nil)
 	definition, err := e.deleteCore(ctx, opts.Recipe, opts.OutputResources)
+	span.SetAttributes(trace.RecipeAttributes(nil, definition)...)
+	trace.EndSpan(span, err)
 	if err != nil {
 		result = metrics.FailedOperationState
 		if errorDetails := recipes.GetErrorDetails(err); errorDetails != nil {
@@ -128,6 +173,8 @@ func (e *engine) Delete(ctx context.Context, opts DeleteOptions) error {
 	metrics.DefaultRecipeEngineMetrics.RecordRecipeOperationDuration(ctx, deletionStart,
 		metrics.NewRecipeAttributes(metrics.RecipeEngineOperationDelete, opts.Recipe.Name,
 			definition, result))
+	metrics.DefaultRecipeEngineMetrics.RecordRecipeDuration(ctx, deletionStart,
+		metrics.NewRecipeDurationAttributes(metrics.RecipeEngineOperationDelete, definition, err != nil))
 
 	return err
 }
@@ -171,6 +218,81 @@ func (e *engine) deleteCore(ctx context.Context, recipe recipes.ResourceMetadata
 	return definition, nil
 }
 
+// Plan loads the recipe definition from the environment, finds the driver associated with the recipe, loads the
+// configuration associated with the recipe, and then plans the recipe deployment using the driver. It returns an
+// empty plan for a simulated environment, and an error if the driver does not support planning.
+func (e *engine) Plan(ctx context.Context, opts PlanOptions) (*recipes.RecipePlan, error) {
+	planStart := time.Now()
+	result := metrics.SuccessfulOperationState
+
+	ctx, span := trace.StartRecipeSpan(ctx, "recipeengine.Plan", &opts.Recipe, nil)
+	plan, definition, err := e.planCore(ctx, opts)
+	span.SetAttributes(trace.RecipeAttributes(nil, definition)...)
+	trace.EndSpan(span, err)
+	if err != nil {
+		result = metrics.FailedOperationState
+		if errorDetails := recipes.GetErrorDetails(err); errorDetails != nil {
+			result = errorDetails.Code
+		}
+	}
+
+	metrics.DefaultRecipeEngineMetrics.RecordRecipeOperationDuration(ctx, planStart,
+		metrics.NewRecipeAttributes(metrics.RecipeEngineOperationPlan, opts.Recipe.Name,
+			definition, result))
+
+	return plan, err
+}
+
+// planCore function is the core logic of the Plan function.
+// Any changes to the core logic of the Plan function should be made here.
+func (e *engine) planCore(ctx context.Context, opts PlanOptions) (*recipes.RecipePlan, *recipes.EnvironmentDefinition, error) {
+	logger := ucplog.FromContextOrDiscard(ctx)
+	recipe := opts.Recipe
+
+	configuration, err := e.options.ConfigurationLoader.LoadConfiguration(ctx, recipe)
+	if err != nil {
+		return nil, nil, recipes.NewRecipeError(recipes.RecipeConfigurationFailure, err.Error(), util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+
+	// Recipes are never deployed in a simulated environment, so nothing would change.
+	if configuration.Simulated {
+		logger.Info("simulated environment enabled, skipping plan")
+		return &recipes.RecipePlan{Changes: []recipes.ResourceChange{}}, nil, nil
+	}
+
+	definition, driver, err := e.getDriver(ctx, recipe)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	driverWithPlan, ok := driver.(recipedriver.DriverWithPlan)
+	if !ok {
+		err := fmt.Errorf("driver `%s` does not support planning recipes", definition.Driver)
+		return nil, definition, recipes.NewRecipeError(recipes.RecipePlanFailed, err.Error(), util.RecipeSetupError, recipes.GetErrorDetails(err))
+	}
+
+	secrets, err := e.getRecipeConfigSecrets(ctx, driver, configuration, definition)
+	if err != nil {
+		return nil, definition, err
+	}
+
+	plan, err := driverWithPlan.Plan(ctx, recipedriver.PlanOptions{
+		BaseOptions: recipedriver.BaseOptions{
+			Configuration: *configuration,
+			Recipe:        recipe,
+			Definition:    *definition,
+			Secrets:       secrets,
+		},
+		PrevState:   opts.PreviousState,
+		RefreshOnly: opts.RefreshOnly,
+	})
+	if err != nil {
+		return nil, definition, err
+	}
+
+	return plan, definition, nil
+}
+
 // Gets the Recipe metadata and parameters from Recipe's template path.
 func (e *engine) GetRecipeMetadata(ctx context.Context, opts GetRecipeMetadataOptions) (map[string]any, error) {
 	recipeData, err := e.getRecipeMetadataCore(ctx, opts)
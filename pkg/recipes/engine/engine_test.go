@@ -105,15 +105,15 @@ func Test_Engine_Execute_Success(t *testing.T) {
 	engine, configLoader, driver, _, _ := setup(t)
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(recipeDefinition, nil)
 	driver.EXPECT().
-		Execute(ctx, recipedriver.ExecuteOptions{
+		Execute(gomock.Any(), recipedriver.ExecuteOptions{
 			BaseOptions: recipedriver.BaseOptions{
 				Configuration: *envConfig,
 				Recipe:        recipeMetadata,
@@ -167,7 +167,7 @@ func Test_Engine_Execute_SimulatedEnv_Success(t *testing.T) {
 	engine, configLoader, _, _, _ := setup(t)
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 
@@ -183,6 +183,95 @@ func Test_Engine_Execute_SimulatedEnv_Success(t *testing.T) {
 	require.Nil(t, result)
 }
 
+func Test_Engine_Execute_Simulated_Success(t *testing.T) {
+	recipeMetadata := recipes.ResourceMetadata{
+		Name:          "redis",
+		ApplicationID: "/planes/radius/local/resourcegroups/test-rg/providers/applications.core/applications/app1",
+		EnvironmentID: "/planes/radius/local/resourcegroups/test-rg/providers/applications.core/environments/env1",
+		ResourceID:    "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Datastores/redisCaches/cache",
+		Parameters: map[string]any{
+			"name": "{{context.resource.name}}-{{context.runtime.kubernetes.namespace}}",
+		},
+	}
+	envConfig := &recipes.Configuration{
+		Runtime: recipes.RuntimeConfiguration{
+			Kubernetes: &recipes.KubernetesRuntime{
+				Namespace: "default",
+			},
+		},
+	}
+	recipeDefinition := &recipes.EnvironmentDefinition{
+		Name:            "redis",
+		Driver:          recipes.TemplateKindTerraform,
+		TemplatePath:    "terraform-aws-modules/elasticache/aws",
+		TemplateVersion: "1.0.0",
+		ResourceType:    "Applications.Datastores/redisCaches",
+		Parameters: map[string]any{
+			"name": "operator",
+			"size": "small",
+		},
+	}
+	ctx := t.Context()
+	engine, configLoader, _, _, _ := setup(t)
+
+	configLoader.EXPECT().
+		LoadConfiguration(gomock.Any(), recipeMetadata).
+		Times(1).
+		Return(envConfig, nil)
+	configLoader.EXPECT().
+		LoadRecipe(gomock.Any(), &recipeMetadata).
+		Times(1).
+		Return(recipeDefinition, nil)
+
+	// Note: the driver is not called for a simulated execution.
+
+	result, err := engine.Execute(ctx, ExecuteOptions{
+		BaseOptions: BaseOptions{
+			Recipe: recipeMetadata,
+		},
+		Simulated: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &recipes.RecipeOutput{
+		Parameters: map[string]any{
+			"name": "cache-default",
+			"size": "small",
+		},
+		Status: &rpv1.RecipeStatus{
+			TemplateKind:    recipes.TemplateKindTerraform,
+			TemplatePath:    "terraform-aws-modules/elasticache/aws",
+			TemplateVersion: "1.0.0",
+		},
+	}, result)
+}
+
+func Test_Engine_Execute_Simulated_RecipeNotFound(t *testing.T) {
+	recipeMetadata := recipes.ResourceMetadata{
+		Name:          "missing",
+		EnvironmentID: "/planes/radius/local/resourcegroups/test-rg/providers/applications.core/environments/env1",
+		ResourceID:    "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Datastores/redisCaches/cache",
+	}
+	ctx := t.Context()
+	engine, configLoader, _, _, _ := setup(t)
+
+	configLoader.EXPECT().
+		LoadConfiguration(gomock.Any(), recipeMetadata).
+		Times(1).
+		Return(&recipes.Configuration{}, nil)
+	configLoader.EXPECT().
+		LoadRecipe(gomock.Any(), &recipeMetadata).
+		Times(1).
+		Return(nil, recipes.NewRecipeError(recipes.RecipeNotFoundFailure, "could not find recipe \"missing\"", "", nil))
+
+	_, err := engine.Execute(ctx, ExecuteOptions{
+		BaseOptions: BaseOptions{
+			Recipe: recipeMetadata,
+		},
+		Simulated: true,
+	})
+	require.ErrorContains(t, err, "could not find recipe")
+}
+
 func Test_Engine_Execute_Failure(t *testing.T) {
 	recipeMetadata := recipes.ResourceMetadata{
 		Name:          "mongo-azure",
@@ -217,15 +306,15 @@ func Test_Engine_Execute_Failure(t *testing.T) {
 	engine, configLoader, driver, _, _ := setup(t)
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(recipeDefinition, nil)
 	driver.EXPECT().
-		Execute(ctx, recipedriver.ExecuteOptions{
+		Execute(gomock.Any(), recipedriver.ExecuteOptions{
 			BaseOptions: recipedriver.BaseOptions{
 				Configuration: *envConfig,
 				Recipe:        recipeMetadata,
@@ -292,19 +381,19 @@ func Test_Engine_Terraform_Success(t *testing.T) {
 	engine, configLoader, _, driverWithSecrets, _ := setup(t)
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(recipeDefinition, nil)
 	driverWithSecrets.EXPECT().
-		FindSecretIDs(ctx, *envConfig, *recipeDefinition).
+		FindSecretIDs(gomock.Any(), *envConfig, *recipeDefinition).
 		Times(1).
 		Return(nil, nil)
 	driverWithSecrets.EXPECT().
-		Execute(ctx, recipedriver.ExecuteOptions{
+		Execute(gomock.Any(), recipedriver.ExecuteOptions{
 			BaseOptions: recipedriver.BaseOptions{
 				Configuration: *envConfig,
 				Recipe:        recipeMetadata,
@@ -411,43 +500,43 @@ func Test_Engine_Terraform_Failure(t *testing.T) {
 			ctx := t.Context()
 			engine, configLoader, _, driverWithSecrets, secretsLoader := setup(t)
 			configLoader.EXPECT().
-				LoadConfiguration(ctx, recipeMetadata).
+				LoadConfiguration(gomock.Any(), recipeMetadata).
 				Times(1).
 				Return(envConfig, nil)
 			configLoader.EXPECT().
-				LoadRecipe(ctx, &recipeMetadata).
+				LoadRecipe(gomock.Any(), &recipeMetadata).
 				Times(1).
 				Return(recipeDefinition, nil)
 
 			if tc.errFindSecretRefs != nil {
 				driverWithSecrets.EXPECT().
-					FindSecretIDs(ctx, *envConfig, *recipeDefinition).
+					FindSecretIDs(gomock.Any(), *envConfig, *recipeDefinition).
 					Times(1).
 					Return(nil, tc.errFindSecretRefs)
 			} else {
 				driverWithSecrets.EXPECT().
-					FindSecretIDs(ctx, *envConfig, *recipeDefinition).
+					FindSecretIDs(gomock.Any(), *envConfig, *recipeDefinition).
 					Times(1).
 					Return(map[string][]string{"/planes/radius/local/resourcegroups/default/providers/Applications.Core/secretStores/azdevopsgit": {"username", "pat"}}, nil)
 
 				if tc.errLoadSecrets != nil {
 					secretsLoader.EXPECT().
-						LoadSecrets(ctx, gomock.Any()).
+						LoadSecrets(gomock.Any(), gomock.Any()).
 						Times(1).
 						Return(nil, tc.errLoadSecrets)
 				} else if tc.errLoadSecretsNotFound != nil {
 					secretsLoader.EXPECT().
-						LoadSecrets(ctx, gomock.Any()).
+						LoadSecrets(gomock.Any(), gomock.Any()).
 						Times(1).
 						Return(nil, tc.errLoadSecretsNotFound)
 				} else {
 					secretsLoader.EXPECT().
-						LoadSecrets(ctx, gomock.Any()).
+						LoadSecrets(gomock.Any(), gomock.Any()).
 						Times(1).
 						Return(nil, nil)
 					if tc.errExecute != nil {
 						driverWithSecrets.EXPECT().
-							Execute(ctx, recipedriver.ExecuteOptions{
+							Execute(gomock.Any(), recipedriver.ExecuteOptions{
 								BaseOptions: recipedriver.BaseOptions{
 									Configuration: *envConfig,
 									Recipe:        recipeMetadata,
@@ -459,7 +548,7 @@ func Test_Engine_Terraform_Failure(t *testing.T) {
 							Return(nil, tc.errExecute)
 					} else {
 						driverWithSecrets.EXPECT().
-							Execute(ctx, recipedriver.ExecuteOptions{
+							Execute(gomock.Any(), recipedriver.ExecuteOptions{
 								BaseOptions: recipedriver.BaseOptions{
 									Configuration: *envConfig,
 									Recipe:        recipeMetadata,
@@ -526,12 +615,12 @@ func Test_Engine_InvalidDriver(t *testing.T) {
 	}
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(recipeDefinition, nil)
 	_, err := engine.Execute(ctx, ExecuteOptions{
@@ -575,12 +664,12 @@ func Test_Engine_Lookup_Error(t *testing.T) {
 	}
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(nil, errors.New("could not find recipe mongo-azure in environment env1"))
 
@@ -611,7 +700,7 @@ func Test_Engine_Load_Error(t *testing.T) {
 	}
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(nil, errors.New("unable to fetch namespace information"))
 
@@ -644,17 +733,17 @@ func Test_Engine_Delete_Success(t *testing.T) {
 	engine, configLoader, driver, _, _ := setup(t)
 
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(&recipeDefinition, nil)
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 
 	driver.EXPECT().
-		Delete(ctx, recipedriver.DeleteOptions{
+		Delete(gomock.Any(), recipedriver.DeleteOptions{
 			BaseOptions: recipedriver.BaseOptions{
 				Configuration: *envConfig,
 				Recipe:        recipeMetadata,
@@ -695,7 +784,7 @@ func Test_Engine_Delete_SimulatedEnv_Success(t *testing.T) {
 	engine, configLoader, _, _, _ := setup(t)
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 
@@ -728,17 +817,17 @@ func Test_Engine_Delete_Error(t *testing.T) {
 	engine, configLoader, driver, _, _ := setup(t)
 
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(&recipeDefinition, nil)
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 
 	driver.EXPECT().
-		Delete(ctx, recipedriver.DeleteOptions{
+		Delete(gomock.Any(), recipedriver.DeleteOptions{
 			BaseOptions: recipedriver.BaseOptions{
 				Configuration: *envConfig,
 				Recipe:        recipeMetadata,
@@ -780,12 +869,12 @@ func Test_Delete_InvalidDriver(t *testing.T) {
 	}
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(&recipeDefinition, nil)
 	err := engine.Delete(ctx, DeleteOptions{
@@ -817,12 +906,12 @@ func Test_Delete_Lookup_Error(t *testing.T) {
 	}
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(nil, errors.New("could not find recipe mongo-azure in environment env1"))
 	err := engine.Delete(ctx, DeleteOptions{
@@ -853,10 +942,10 @@ func Test_Engine_GetRecipeMetadata_Success(t *testing.T) {
 	outputParams := map[string]any{"parameters": recipeDefinition.Parameters}
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
-	driver.EXPECT().GetRecipeMetadata(ctx, recipedriver.BaseOptions{
+	driver.EXPECT().GetRecipeMetadata(gomock.Any(), recipedriver.BaseOptions{
 		Recipe:        recipes.ResourceMetadata{},
 		Definition:    recipeDefinition,
 		Configuration: *envConfig,
@@ -916,18 +1005,18 @@ func Test_Engine_GetRecipeMetadata_Private_Module_Success(t *testing.T) {
 	outputParams := map[string]any{"parameters": recipeDefinition.Parameters}
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 	driverWithSecrets.EXPECT().
-		FindSecretIDs(ctx, *envConfig, *recipeDefinition).
+		FindSecretIDs(gomock.Any(), *envConfig, *recipeDefinition).
 		Times(1).
 		Return(map[string][]string{"/planes/radius/local/resourcegroups/default/providers/Applications.Core/secretStores/azdevopsgit": {"username", "pat"}}, nil)
 	secretsLoader.EXPECT().
-		LoadSecrets(ctx, map[string][]string{"/planes/radius/local/resourcegroups/default/providers/Applications.Core/secretStores/azdevopsgit": {"username", "pat"}}).
+		LoadSecrets(gomock.Any(), map[string][]string{"/planes/radius/local/resourcegroups/default/providers/Applications.Core/secretStores/azdevopsgit": {"username", "pat"}}).
 		Times(1).
 		Return(nil, nil)
-	driverWithSecrets.EXPECT().GetRecipeMetadata(ctx, recipedriver.BaseOptions{
+	driverWithSecrets.EXPECT().GetRecipeMetadata(gomock.Any(), recipedriver.BaseOptions{
 		Recipe:        recipes.ResourceMetadata{},
 		Definition:    *recipeDefinition,
 		Configuration: *envConfig,
@@ -961,10 +1050,10 @@ func Test_GetRecipeMetadata_Driver_Error(t *testing.T) {
 	engine, configLoader, driver, _, _ := setup(t)
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
-	driver.EXPECT().GetRecipeMetadata(ctx, recipedriver.BaseOptions{
+	driver.EXPECT().GetRecipeMetadata(gomock.Any(), recipedriver.BaseOptions{
 		Recipe:        recipes.ResourceMetadata{},
 		Definition:    recipeDefinition,
 		Configuration: *envConfig,
@@ -1001,7 +1090,7 @@ func Test_GetRecipeMetadata_Driver_InvalidDriver(t *testing.T) {
 		},
 	}
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 	_, err := engine.GetRecipeMetadata(ctx, GetRecipeMetadataOptions{
@@ -1095,23 +1184,23 @@ func Test_Engine_Execute_With_Secrets_Success(t *testing.T) {
 	ctx := t.Context()
 	engine, configLoader, _, driverWithSecrets, secretsLoader := setup(t)
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(recipeDefinition, nil)
 	driverWithSecrets.EXPECT().
-		FindSecretIDs(ctx, *envConfig, *recipeDefinition).
+		FindSecretIDs(gomock.Any(), *envConfig, *recipeDefinition).
 		Times(1).
 		Return(map[string][]string{"/planes/radius/local/resourcegroups/default/providers/Applications.Core/secretStores/azdevopsgit": {"username", "pat"}}, nil)
 	secretsLoader.EXPECT().
-		LoadSecrets(ctx, gomock.Any()).
+		LoadSecrets(gomock.Any(), gomock.Any()).
 		Times(1).
 		Return(nil, nil)
 	driverWithSecrets.EXPECT().
-		Execute(ctx, recipedriver.ExecuteOptions{
+		Execute(gomock.Any(), recipedriver.ExecuteOptions{
 			BaseOptions: recipedriver.BaseOptions{
 				Configuration: *envConfig,
 				Recipe:        recipeMetadata,
@@ -1177,24 +1266,24 @@ func Test_Engine_Delete_With_Secrets_Success(t *testing.T) {
 	engine, configLoader, _, driverWithSecrets, secretsLoader := setup(t)
 
 	configLoader.EXPECT().
-		LoadRecipe(ctx, &recipeMetadata).
+		LoadRecipe(gomock.Any(), &recipeMetadata).
 		Times(1).
 		Return(recipeDefinition, nil)
 
 	configLoader.EXPECT().
-		LoadConfiguration(ctx, recipeMetadata).
+		LoadConfiguration(gomock.Any(), recipeMetadata).
 		Times(1).
 		Return(envConfig, nil)
 	driverWithSecrets.EXPECT().
-		FindSecretIDs(ctx, *envConfig, *recipeDefinition).
+		FindSecretIDs(gomock.Any(), *envConfig, *recipeDefinition).
 		Times(1).
 		Return(map[string][]string{"/planes/radius/local/resourcegroups/default/providers/Applications.Core/secretStores/azdevopsgit": {"username", "pat"}}, nil)
 	secretsLoader.EXPECT().
-		LoadSecrets(ctx, map[string][]string{"/planes/radius/local/resourcegroups/default/providers/Applications.Core/secretStores/azdevopsgit": {"username", "pat"}}).
+		LoadSecrets(gomock.Any(), map[string][]string{"/planes/radius/local/resourcegroups/default/providers/Applications.Core/secretStores/azdevopsgit": {"username", "pat"}}).
 		Times(1).
 		Return(nil, nil)
 	driverWithSecrets.EXPECT().
-		Delete(ctx, recipedriver.DeleteOptions{
+		Delete(gomock.Any(), recipedriver.DeleteOptions{
 			BaseOptions: recipedriver.BaseOptions{
 				Configuration: *envConfig,
 				Recipe:        recipeMetadata,
@@ -1213,3 +1302,109 @@ func Test_Engine_Delete_With_Secrets_Success(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func Test_Engine_Plan(t *testing.T) {
+	recipeMetadata := recipes.ResourceMetadata{
+		Name:          "mongo-azure",
+		EnvironmentID: "/planes/radius/local/resourcegroups/test-rg/providers/applications.core/environments/env1",
+		ResourceID:    "/planes/radius/local/resourceGroups/test-rg/providers/Applications.Datastores/mongoDatabases/mongo",
+	}
+	prevState := []string{
+		"/subscriptions/test-sub/resourceGroups/test-rg/providers/System.Test/testResources/test1",
+	}
+	envConfig := &recipes.Configuration{
+		Runtime: recipes.RuntimeConfiguration{
+			Kubernetes: &recipes.KubernetesRuntime{
+				Namespace: "default",
+			},
+		},
+	}
+	recipeDefinition := &recipes.EnvironmentDefinition{
+		Driver:       recipes.TemplateKindBicep,
+		TemplatePath: "ghcr.io/radius-project/dev/recipes/functionaltest/basic/mongodatabases/azure:1.0",
+		ResourceType: "Applications.Datastores/mongoDatabases",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		configLoader := configloader.NewMockConfigurationLoader(ctrl)
+		driver := recipedriver.NewMockDriverWithPlan(ctrl)
+		engine := engine{
+			options: Options{
+				ConfigurationLoader: configLoader,
+				Drivers: map[string]recipedriver.Driver{
+					recipes.TemplateKindBicep: driver,
+				},
+			},
+		}
+
+		plan := &recipes.RecipePlan{
+			Changes: []recipes.ResourceChange{
+				{ID: prevState[0], Type: "System.Test/testResources", Action: recipes.ChangeActionDelete},
+			},
+		}
+
+		configLoader.EXPECT().
+			LoadConfiguration(gomock.Any(), recipeMetadata).
+			Times(1).
+			Return(envConfig, nil)
+		configLoader.EXPECT().
+			LoadRecipe(gomock.Any(), &recipeMetadata).
+			Times(1).
+			Return(recipeDefinition, nil)
+		driver.EXPECT().
+			Plan(gomock.Any(), recipedriver.PlanOptions{
+				BaseOptions: recipedriver.BaseOptions{
+					Configuration: *envConfig,
+					Recipe:        recipeMetadata,
+					Definition:    *recipeDefinition,
+				},
+				PrevState:   prevState,
+				RefreshOnly: true,
+			}).
+			Times(1).
+			Return(plan, nil)
+
+		result, err := engine.Plan(t.Context(), PlanOptions{
+			BaseOptions:   BaseOptions{Recipe: recipeMetadata},
+			PreviousState: prevState,
+			RefreshOnly:   true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, plan, result)
+	})
+
+	t.Run("simulated environment", func(t *testing.T) {
+		engine, configLoader, _, _, _ := setup(t)
+		configLoader.EXPECT().
+			LoadConfiguration(gomock.Any(), recipeMetadata).
+			Times(1).
+			Return(&recipes.Configuration{Simulated: true}, nil)
+
+		result, err := engine.Plan(t.Context(), PlanOptions{
+			BaseOptions: BaseOptions{Recipe: recipeMetadata},
+		})
+		require.NoError(t, err)
+		require.False(t, result.HasChanges())
+	})
+
+	t.Run("driver does not support plan", func(t *testing.T) {
+		engine, configLoader, _, _, _ := setup(t)
+		configLoader.EXPECT().
+			LoadConfiguration(gomock.Any(), recipeMetadata).
+			Times(1).
+			Return(envConfig, nil)
+		configLoader.EXPECT().
+			LoadRecipe(gomock.Any(), &recipeMetadata).
+			Times(1).
+			Return(recipeDefinition, nil)
+
+		_, err := engine.Plan(t.Context(), PlanOptions{
+			BaseOptions: BaseOptions{Recipe: recipeMetadata},
+		})
+		recipeError := &recipes.RecipeError{}
+		require.ErrorAs(t, err, &recipeError)
+		require.Equal(t, recipes.RecipePlanFailed, recipeError.ErrorDetails.Code)
+		require.Equal(t, "driver `bicep` does not support planning recipes", recipeError.ErrorDetails.Message)
+	})
+}
@@ -156,3 +156,42 @@ func (c *MockEngineGetRecipeMetadataCall) DoAndReturn(f func(context.Context, Ge
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Plan mocks base method.
+func (m *MockEngine) Plan(ctx context.Context, opts PlanOptions) (*recipes.RecipePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", ctx, opts)
+	ret0, _ := ret[0].(*recipes.RecipePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockEngineMockRecorder) Plan(ctx, opts any) *MockEnginePlanCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockEngine)(nil).Plan), ctx, opts)
+	return &MockEnginePlanCall{Call: call}
+}
+
+// MockEnginePlanCall wrap *gomock.Call
+type MockEnginePlanCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEnginePlanCall) Return(arg0 *recipes.RecipePlan, arg1 error) *MockEnginePlanCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEnginePlanCall) Do(f func(context.Context, PlanOptions) (*recipes.RecipePlan, error)) *MockEnginePlanCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEnginePlanCall) DoAndReturn(f func(context.Context, PlanOptions) (*recipes.RecipePlan, error)) *MockEnginePlanCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
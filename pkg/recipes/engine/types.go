@@ -33,6 +33,10 @@ type Engine interface {
 	// Delete handles deletion of output resources for the recipe deployment.
 	Delete(ctx context.Context, opts DeleteOptions) error
 
+	// Plan gathers environment configuration, recipe definition and calls the driver to plan the recipe deployment.
+	// It returns the changes that executing the recipe would make, without making them.
+	Plan(ctx context.Context, opts PlanOptions) (*recipes.RecipePlan, error)
+
 	// Gets the Recipe metadata and parameters from Recipe's template path
 	GetRecipeMetadata(ctx context.Context, opts GetRecipeMetadataOptions) (map[string]any, error)
 }
@@ -48,10 +52,21 @@ type ExecuteOptions struct {
 	BaseOptions
 	// PreviousState represents previously deployed state of output resource IDs.
 	PreviousState []string
-	// Simulated is the flag to indicate if the execution is a simulation.
+	// Simulated is the flag to indicate if the execution is a simulation. A simulated execution
+	// loads the recipe and resolves its parameters, but does not call the driver. The returned
+	// output carries the recipe status and the resolved parameters, and no resources.
 	Simulated bool
 }
 
+// PlanOptions is the options for the Plan method.
+type PlanOptions struct {
+	BaseOptions
+	// PreviousState represents previously deployed state of output resource IDs.
+	PreviousState []string
+	// RefreshOnly plans only the changes made to the resources of the recipe outside of the recipe.
+	RefreshOnly bool
+}
+
 // DeleteOptions is the options for the Delete method.
 type DeleteOptions struct {
 	BaseOptions
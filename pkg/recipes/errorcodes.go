@@ -49,4 +49,7 @@ const (
 
 	// Used for errors encountered while loading recipe secrets.
 	LoadSecretsFailed = "LoadSecretsFailed"
+
+	// Used for errors encountered while planning a recipe deployment.
+	RecipePlanFailed = "RecipePlanFailed"
 )
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recipes
+
+import (
+	rpv1 "github.com/radius-project/radius/pkg/rp/v1"
+)
+
+const (
+	// PlanAction is the path of the action that plans the recipe of a resource, relative to the resource.
+	PlanAction = "recipes/plan"
+
+	// ChangeActionCreate is the action of a resource that the recipe would create.
+	ChangeActionCreate = "create"
+	// ChangeActionUpdate is the action of a resource that the recipe would update in place.
+	ChangeActionUpdate = "update"
+	// ChangeActionReplace is the action of a resource that the recipe would delete and create again.
+	ChangeActionReplace = "replace"
+	// ChangeActionDelete is the action of a resource that the recipe would delete.
+	ChangeActionDelete = "delete"
+
+	// SensitiveValue replaces the sensitive values of a plan.
+	SensitiveValue = "(sensitive value)"
+	// UnknownValue replaces the values of a plan that are only known once the recipe is deployed.
+	UnknownValue = "(known after apply)"
+)
+
+// RecipePlan represents the changes that deploying a recipe would make, without making them.
+type RecipePlan struct {
+	// Changes represents the changes to the resources of the recipe, ordered by resource. Resources that
+	// would not change are omitted.
+	Changes []ResourceChange `json:"changes"`
+
+	// Status represents the recipe the plan was made for.
+	Status *rpv1.RecipeStatus `json:"recipe,omitempty"`
+}
+
+// ResourceChange represents the change to one resource of a recipe.
+type ResourceChange struct {
+	// ID represents the resource ID of the resource. For a Terraform recipe it is the address of the resource in
+	// the Terraform configuration, because resources that do not exist yet have no ID.
+	ID string `json:"id"`
+
+	// Type represents the type of the resource.
+	Type string `json:"type,omitempty"`
+
+	// Action represents the change to the resource, one of the ChangeAction constants.
+	Action string `json:"action"`
+
+	// Attributes represents the changes to the attributes of the resource, ordered by path.
+	Attributes []AttributeChange `json:"attributes,omitempty"`
+}
+
+// AttributeChange represents the change to one attribute of a resource.
+type AttributeChange struct {
+	// Path represents the path of the attribute in the resource, with segments separated by dots.
+	Path string `json:"path"`
+
+	// Before represents the value of the attribute before the change. It is nil for an attribute that is added.
+	Before any `json:"before,omitempty"`
+
+	// After represents the value of the attribute after the change. It is nil for an attribute that is removed.
+	After any `json:"after,omitempty"`
+
+	// Sensitive is true if the values of the attribute are sensitive, in which case they are replaced by SensitiveValue.
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// HasChanges returns true if deploying the recipe would change any resource.
+func (p *RecipePlan) HasChanges() bool {
+	return p != nil && len(p.Changes) > 0
+}
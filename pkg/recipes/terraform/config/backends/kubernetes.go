@@ -136,6 +136,16 @@ func (p *kubernetesBackend) ValidateBackendExists(ctx context.Context, name stri
 	return true, nil
 }
 
+// DeleteBackend deletes the Kubernetes secret for Terraform state file.
+func (p *kubernetesBackend) DeleteBackend(ctx context.Context, name string) error {
+	err := p.k8sClientSet.CoreV1().Secrets(RadiusNamespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
 // secretSuffixLength is the number of hexadecimal characters of the hash used for the Terraform
 // state secret suffix. The Terraform Kubernetes backend stores secret_suffix as a Kubernetes label
 // value (limited to 63 characters), so the SHA-256 hash (64 hex characters) is truncated. 40
@@ -197,7 +207,7 @@ func secretSuffixInput(resourceRecipe *recipes.ResourceMetadata) (string, error)
 }
 
 // generateKubernetesBackendConfig returns Terraform backend configuration to store Terraform state file for the deployment.
-// Currently, the supported backend for Terraform Recipes is Kubernetes secret. https://developer.hashicorp.com/terraform/language/settings/backends/kubernetes
+// https://developer.hashicorp.com/terraform/language/settings/backends/kubernetes
 func generateKubernetesBackendConfig(secretSuffix string) (map[string]any, error) {
 	backend := map[string]any{
 		BackendKubernetes: map[string]any{
@@ -225,3 +225,24 @@ func Test_ValidateBackendExists(t *testing.T) {
 	require.True(t, k8s_errors.IsServerTimeout(err))
 	require.False(t, exists)
 }
+
+func Test_DeleteBackend(t *testing.T) {
+	clientset := fake.NewClientset()
+	_, err := clientset.CoreV1().Secrets(RadiusNamespace).Create(t.Context(), &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-secret",
+			Namespace: RadiusNamespace,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	b := NewKubernetesBackend(clientset)
+	require.NoError(t, b.DeleteBackend(t.Context(), "test-secret"))
+
+	exists, err := b.ValidateBackendExists(t.Context(), "test-secret")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Deleting a non-existent secret is not an error.
+	require.NoError(t, b.DeleteBackend(t.Context(), "test-secret"))
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"context"
+
+	"github.com/radius-project/radius/pkg/recipes"
+)
+
+const (
+	BackendLocal = "local"
+)
+
+var _ Backend = (*localBackend)(nil)
+
+type localBackend struct{}
+
+// NewLocalBackend creates a Backend that keeps the Terraform state in the working directory, which is removed
+// with the working directory. It is used to plan a recipe that has no state yet, so that planning it does not
+// create a state in the backend of the environment.
+func NewLocalBackend() Backend {
+	return &localBackend{}
+}
+
+// BuildBackend generates the Terraform backend configuration for the local backend, which uses the default
+// state file of the working directory.
+// https://developer.hashicorp.com/terraform/language/settings/backends/local
+func (p *localBackend) BuildBackend(resourceRecipe *recipes.ResourceMetadata) (map[string]any, error) {
+	return map[string]any{BackendLocal: map[string]any{}}, nil
+}
+
+// ValidateBackendExists returns false, the state of the local backend never outlives the working directory.
+func (p *localBackend) ValidateBackendExists(ctx context.Context, name string) (bool, error) {
+	return false, nil
+}
+
+// DeleteBackend does nothing, the state of the local backend is removed with the working directory.
+func (p *localBackend) DeleteBackend(ctx context.Context, name string) error {
+	return nil
+}
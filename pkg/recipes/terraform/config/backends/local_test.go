@@ -0,0 +1,38 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Local_Backend(t *testing.T) {
+	_, resourceRecipe := getTestInputs()
+	b := NewLocalBackend()
+
+	config, err := b.BuildBackend(&resourceRecipe)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{BackendLocal: map[string]any{}}, config)
+	require.Empty(t, StateName(config))
+
+	exists, err := b.ValidateBackendExists(t.Context(), "")
+	require.NoError(t, err)
+	require.False(t, exists)
+	require.NoError(t, b.DeleteBackend(t.Context(), ""))
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/radius-project/radius/pkg/recipes"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// kubernetesStateKey is the key of the Kubernetes secret data that holds the gzip compressed Terraform state.
+	kubernetesStateKey = "tfstate"
+
+	// migratedStateFileName is the name of the file the Terraform state is exported to for the migration.
+	migratedStateFileName = "migrated.tfstate"
+)
+
+// KubernetesState is the Terraform state of a recipe exported from the Kubernetes secret backend.
+type KubernetesState struct {
+	// Path is the path of the exported Terraform state file.
+	Path string
+
+	// SecretName is the name of the Kubernetes secret that holds the state.
+	SecretName string
+}
+
+// ExportKubernetesState writes the Terraform state stored in the Kubernetes secret of the recipe to a file in
+// dir, so that it can be pushed to another backend with `terraform state push`. It returns nil if the recipe
+// has no Terraform state in a Kubernetes secret.
+func ExportKubernetesState(ctx context.Context, k8sClientSet kubernetes.Interface, resourceRecipe *recipes.ResourceMetadata, dir string) (*KubernetesState, error) {
+	suffix, err := (&kubernetesBackend{k8sClientSet: k8sClientSet}).resolveSecretSuffix(resourceRecipe)
+	if err != nil {
+		return nil, err
+	}
+
+	name := KubernetesBackendNamePrefix + suffix
+	secret, err := k8sClientSet.CoreV1().Secrets(RadiusNamespace).Get(ctx, name, metav1.GetOptions{})
+	if k8s_errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error retrieving kubernetes secret for terraform state: %w", err)
+	}
+
+	compressed, ok := secret.Data[kubernetesStateKey]
+	if !ok {
+		return nil, fmt.Errorf("kubernetes secret %q does not contain terraform state", name)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress terraform state of kubernetes secret %q: %w", name, err)
+	}
+	defer reader.Close()
+
+	state, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress terraform state of kubernetes secret %q: %w", name, err)
+	}
+
+	path := filepath.Join(dir, migratedStateFileName)
+	if err := os.WriteFile(path, state, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write terraform state of kubernetes secret %q: %w", name, err)
+	}
+
+	return &KubernetesState{Path: path, SecretName: name}, nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_ExportKubernetesState(t *testing.T) {
+	_, resourceRecipe := getTestInputs()
+	suffix, err := generateSecretSuffix(&resourceRecipe)
+	require.NoError(t, err)
+
+	state := []byte(`{"version":4,"serial":3,"lineage":"8a2f"}`)
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err = writer.Write(state)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	clientset := fake.NewClientset()
+	_, err = clientset.CoreV1().Secrets(RadiusNamespace).Create(t.Context(), &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      KubernetesBackendNamePrefix + suffix,
+			Namespace: RadiusNamespace,
+		},
+		Data: map[string][]byte{kubernetesStateKey: compressed.Bytes()},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	exported, err := ExportKubernetesState(t.Context(), clientset, &resourceRecipe, t.TempDir())
+	require.NoError(t, err)
+	require.NotNil(t, exported)
+	require.Equal(t, KubernetesBackendNamePrefix+suffix, exported.SecretName)
+
+	content, err := os.ReadFile(exported.Path)
+	require.NoError(t, err)
+	require.Equal(t, state, content)
+}
+
+func Test_ExportKubernetesState_NoSecret(t *testing.T) {
+	_, resourceRecipe := getTestInputs()
+
+	exported, err := ExportKubernetesState(t.Context(), fake.NewClientset(), &resourceRecipe, t.TempDir())
+	require.NoError(t, err)
+	require.Nil(t, exported)
+}
+
+func Test_ExportKubernetesState_InvalidState(t *testing.T) {
+	_, resourceRecipe := getTestInputs()
+	suffix, err := generateSecretSuffix(&resourceRecipe)
+	require.NoError(t, err)
+
+	clientset := fake.NewClientset()
+	_, err = clientset.CoreV1().Secrets(RadiusNamespace).Create(t.Context(), &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      KubernetesBackendNamePrefix + suffix,
+			Namespace: RadiusNamespace,
+		},
+		Data: map[string][]byte{kubernetesStateKey: []byte("not gzip")},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = ExportKubernetesState(t.Context(), clientset, &resourceRecipe, t.TempDir())
+	require.ErrorContains(t, err, "failed to decompress terraform state")
+}
@@ -80,6 +80,44 @@ func (c *MockBackendBuildBackendCall) DoAndReturn(f func(*recipes.ResourceMetada
 	return c
 }
 
+// DeleteBackend mocks base method.
+func (m *MockBackend) DeleteBackend(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBackend", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBackend indicates an expected call of DeleteBackend.
+func (mr *MockBackendMockRecorder) DeleteBackend(ctx, name any) *MockBackendDeleteBackendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBackend", reflect.TypeOf((*MockBackend)(nil).DeleteBackend), ctx, name)
+	return &MockBackendDeleteBackendCall{Call: call}
+}
+
+// MockBackendDeleteBackendCall wrap *gomock.Call
+type MockBackendDeleteBackendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBackendDeleteBackendCall) Return(arg0 error) *MockBackendDeleteBackendCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBackendDeleteBackendCall) Do(f func(context.Context, string) error) *MockBackendDeleteBackendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBackendDeleteBackendCall) DoAndReturn(f func(context.Context, string) error) *MockBackendDeleteBackendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ValidateBackendExists mocks base method.
 func (m *MockBackend) ValidateBackendExists(ctx context.Context, name string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/radius-project/radius/pkg/recipes"
+)
+
+const (
+	BackendPostgreSQL = "pg"
+
+	// PostgreSQLConnStrEnv is the environment variable the Terraform pg backend reads the connection string from.
+	PostgreSQLConnStrEnv = "PG_CONN_STR"
+
+	// PostgreSQLBackendSchemaPrefix is the prefix of the schema that holds the Terraform state of a recipe.
+	// The Terraform pg backend keeps the state of each workspace as a row of the "states" table in the schema.
+	// https://developer.hashicorp.com/terraform/language/settings/backends/pg
+	PostgreSQLBackendSchemaPrefix = "tfstate_"
+
+	// defaultWorkspace is the Terraform workspace used for recipes deployment.
+	defaultWorkspace = "default"
+)
+
+var _ Backend = (*postgreSQLBackend)(nil)
+var _ EnvBackend = (*postgreSQLBackend)(nil)
+
+// PostgresAPI defines the API surface from pgx that the PostgreSQL backend uses. This is used to allow for easier testing.
+type PostgresAPI interface {
+	// Exec executes a query without returning any rows.
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	// QueryRow executes a query that is expected to return at most one row.
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type postgreSQLBackend struct {
+	url string
+
+	// connect opens a connection to the database. The returned function closes the connection.
+	connect func(ctx context.Context, url string) (PostgresAPI, func(), error)
+}
+
+// NewPostgreSQLBackend creates a Backend that stores Terraform state in the PostgreSQL database at url.
+func NewPostgreSQLBackend(url string) Backend {
+	return &postgreSQLBackend{url: url, connect: connectPostgreSQL}
+}
+
+func connectPostgreSQL(ctx context.Context, url string) (PostgresAPI, func(), error) {
+	conn, err := pgx.Connect(ctx, url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to the Terraform state database: %w", err)
+	}
+
+	return conn, func() { _ = conn.Close(context.Background()) }, nil
+}
+
+// BuildBackend generates the Terraform backend configuration for the PostgreSQL backend. Each recipe
+// deployment gets a schema of its own, so that its state can be removed with the schema. The connection
+// string holds the database password, so it is passed with Env instead.
+// https://developer.hashicorp.com/terraform/language/settings/backends/pg
+func (p *postgreSQLBackend) BuildBackend(resourceRecipe *recipes.ResourceMetadata) (map[string]any, error) {
+	suffix, err := generateSecretSuffix(resourceRecipe)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		BackendPostgreSQL: map[string]any{
+			"schema_name": PostgreSQLBackendSchemaPrefix + suffix,
+		},
+	}, nil
+}
+
+// Env returns the connection string of the database for the Terraform pg backend.
+func (p *postgreSQLBackend) Env() map[string]string {
+	return map[string]string{PostgreSQLConnStrEnv: p.url}
+}
+
+// ValidateBackendExists checks if the schema with the given name holds the Terraform state of the default workspace.
+func (p *postgreSQLBackend) ValidateBackendExists(ctx context.Context, name string) (bool, error) {
+	api, closeConn, err := p.connect(ctx, p.url)
+	if err != nil {
+		return false, err
+	}
+	defer closeConn()
+
+	var tableExists bool
+	err = api.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = $1 AND table_name = 'states')",
+		name).Scan(&tableExists)
+	if err != nil {
+		return false, fmt.Errorf("failed to query Terraform state schema %q: %w", name, err)
+	}
+	if !tableExists {
+		return false, nil
+	}
+
+	var stateExists bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE name = $1)", pgx.Identifier{name, "states"}.Sanitize())
+	err = api.QueryRow(ctx, query, defaultWorkspace).Scan(&stateExists)
+	if err != nil {
+		return false, fmt.Errorf("failed to query Terraform state in schema %q: %w", name, err)
+	}
+
+	return stateExists, nil
+}
+
+// DeleteBackend drops the schema that holds the Terraform state.
+func (p *postgreSQLBackend) DeleteBackend(ctx context.Context, name string) error {
+	api, closeConn, err := p.connect(ctx, p.url)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	_, err = api.Exec(ctx, fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", pgx.Identifier{name}.Sanitize()))
+	if err != nil {
+		return fmt.Errorf("failed to drop Terraform state schema %q: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePostgres records the statements it receives and answers EXISTS queries from results in order.
+type fakePostgres struct {
+	statements []string
+	results    []bool
+	err        error
+}
+
+func (f *fakePostgres) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.statements = append(f.statements, sql)
+	return pgconn.CommandTag{}, f.err
+}
+
+func (f *fakePostgres) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	f.statements = append(f.statements, sql)
+	var result bool
+	if len(f.results) > 0 {
+		result, f.results = f.results[0], f.results[1:]
+	}
+	return &fakeRow{result: result, err: f.err}
+}
+
+type fakeRow struct {
+	result bool
+	err    error
+}
+
+func (r *fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*(dest[0].(*bool)) = r.result
+	return nil
+}
+
+func newTestPostgreSQLBackend(api PostgresAPI) *postgreSQLBackend {
+	return &postgreSQLBackend{
+		url: "postgresql://radius@database:5432/radius",
+		connect: func(ctx context.Context, url string) (PostgresAPI, func(), error) {
+			return api, func() {}, nil
+		},
+	}
+}
+
+func Test_PostgreSQL_BuildBackend(t *testing.T) {
+	_, resourceRecipe := getTestInputs()
+	suffix, err := generateSecretSuffix(&resourceRecipe)
+	require.NoError(t, err)
+
+	config, err := newTestPostgreSQLBackend(&fakePostgres{}).BuildBackend(&resourceRecipe)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		BackendPostgreSQL: map[string]any{
+			"schema_name": PostgreSQLBackendSchemaPrefix + suffix,
+		},
+	}, config)
+	require.Equal(t, PostgreSQLBackendSchemaPrefix+suffix, StateName(config))
+	require.Equal(t, map[string]string{PostgreSQLConnStrEnv: "postgresql://radius@database:5432/radius"}, newTestPostgreSQLBackend(&fakePostgres{}).Env())
+}
+
+func Test_PostgreSQL_ValidateBackendExists(t *testing.T) {
+	tests := []struct {
+		name       string
+		results    []bool
+		exists     bool
+		statements int
+	}{
+		{name: "no schema", results: []bool{false}, exists: false, statements: 1},
+		{name: "no state", results: []bool{true, false}, exists: false, statements: 2},
+		{name: "state", results: []bool{true, true}, exists: true, statements: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &fakePostgres{results: tc.results}
+			exists, err := newTestPostgreSQLBackend(api).ValidateBackendExists(t.Context(), "tfstate_abc")
+			require.NoError(t, err)
+			require.Equal(t, tc.exists, exists)
+			require.Len(t, api.statements, tc.statements)
+		})
+	}
+
+	t.Run("state query uses the schema", func(t *testing.T) {
+		api := &fakePostgres{results: []bool{true, true}}
+		_, err := newTestPostgreSQLBackend(api).ValidateBackendExists(t.Context(), "tfstate_abc")
+		require.NoError(t, err)
+		require.Equal(t, `SELECT EXISTS (SELECT 1 FROM "tfstate_abc"."states" WHERE name = $1)`, api.statements[1])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		api := &fakePostgres{err: errors.New("connection reset")}
+		_, err := newTestPostgreSQLBackend(api).ValidateBackendExists(t.Context(), "tfstate_abc")
+		require.ErrorContains(t, err, "connection reset")
+	})
+}
+
+func Test_PostgreSQL_DeleteBackend(t *testing.T) {
+	api := &fakePostgres{}
+	err := newTestPostgreSQLBackend(api).DeleteBackend(t.Context(), `tfstate_"abc`)
+	require.NoError(t, err)
+	require.Equal(t, []string{`DROP SCHEMA IF EXISTS "tfstate_""abc" CASCADE`}, api.statements)
+
+	api = &fakePostgres{err: errors.New("permission denied")}
+	err = newTestPostgreSQLBackend(api).DeleteBackend(t.Context(), "tfstate_abc")
+	require.ErrorContains(t, err, "permission denied")
+}
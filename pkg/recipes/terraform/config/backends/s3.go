@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/radius-project/radius/pkg/corerp/datamodel"
+	"github.com/radius-project/radius/pkg/recipes"
+)
+
+const (
+	BackendS3 = "s3"
+
+	// S3BackendAccessKeyIDKey and S3BackendSecretAccessKeyKey are the keys of the secret referenced by the s3 backend.
+	S3BackendAccessKeyIDKey     = "accessKeyId"
+	S3BackendSecretAccessKeyKey = "secretAccessKey"
+
+	// S3AccessKeyIDEnv and S3SecretAccessKeyEnv are the environment variables the Terraform s3 backend reads the
+	// access keys from.
+	S3AccessKeyIDEnv     = "AWS_ACCESS_KEY_ID"
+	S3SecretAccessKeyEnv = "AWS_SECRET_ACCESS_KEY"
+
+	defaultS3KeyPrefix = "radius/tfstate/"
+	defaultS3Region    = "us-east-1"
+	s3StateFileSuffix  = ".tfstate"
+)
+
+var _ Backend = (*s3Backend)(nil)
+var _ EnvBackend = (*s3Backend)(nil)
+
+// S3Client is the subset of the AWS S3 client used by the s3 backend.
+type S3Client interface {
+	HeadObject(ctx context.Context, params *awss3.HeadObjectInput, optFns ...func(*awss3.Options)) (*awss3.HeadObjectOutput, error)
+	DeleteObject(ctx context.Context, params *awss3.DeleteObjectInput, optFns ...func(*awss3.Options)) (*awss3.DeleteObjectOutput, error)
+}
+
+var _ S3Client = (*awss3.Client)(nil)
+
+type s3Backend struct {
+	config          datamodel.TerraformS3BackendConfig
+	accessKeyID     string
+	secretAccessKey string
+
+	// newClient creates the client used to inspect and delete state objects.
+	newClient func(ctx context.Context) (S3Client, error)
+}
+
+// NewS3Backend creates a Backend that stores Terraform state in an S3 or S3-compatible bucket. When the
+// configuration references a secret, its access keys are read from secrets.
+func NewS3Backend(config datamodel.TerraformS3BackendConfig, secrets map[string]recipes.SecretData) (Backend, error) {
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = defaultS3KeyPrefix
+	}
+	if config.Region == "" {
+		config.Region = defaultS3Region
+	}
+
+	backend := &s3Backend{config: config}
+	backend.newClient = backend.openClient
+
+	if config.Secret != "" {
+		secret, ok := secrets[config.Secret]
+		if !ok {
+			return nil, fmt.Errorf("missing secret %q of the s3 Terraform backend", config.Secret)
+		}
+		backend.accessKeyID = secret.Data[S3BackendAccessKeyIDKey]
+		backend.secretAccessKey = secret.Data[S3BackendSecretAccessKeyKey]
+		if backend.accessKeyID == "" || backend.secretAccessKey == "" {
+			return nil, fmt.Errorf("secret %q of the s3 Terraform backend must have the keys %q and %q", config.Secret, S3BackendAccessKeyIDKey, S3BackendSecretAccessKeyKey)
+		}
+	}
+
+	return backend, nil
+}
+
+// BuildBackend generates the Terraform backend configuration for the s3 backend. The state of each
+// recipe deployment is stored as an object of its own, and locked with an S3 lock file. The access keys
+// are passed with Env.
+// https://developer.hashicorp.com/terraform/language/settings/backends/s3
+func (p *s3Backend) BuildBackend(resourceRecipe *recipes.ResourceMetadata) (map[string]any, error) {
+	suffix, err := generateSecretSuffix(resourceRecipe)
+	if err != nil {
+		return nil, err
+	}
+
+	backend := map[string]any{
+		"bucket":       p.config.Bucket,
+		"key":          p.config.KeyPrefix + suffix + s3StateFileSuffix,
+		"region":       p.config.Region,
+		"use_lockfile": true,
+	}
+
+	if p.config.Endpoint != "" {
+		backend["endpoints"] = map[string]any{"s3": p.config.Endpoint}
+		backend["use_path_style"] = true
+		// S3-compatible servers do not implement the AWS account and region APIs.
+		backend["skip_credentials_validation"] = true
+		backend["skip_requesting_account_id"] = true
+		backend["skip_region_validation"] = true
+	}
+
+	return map[string]any{BackendS3: backend}, nil
+}
+
+// Env returns the access keys of the secret of the backend for the Terraform s3 backend. It returns nil when
+// the backend has no secret, so that the credentials of the Terraform process are used.
+func (p *s3Backend) Env() map[string]string {
+	if p.accessKeyID == "" {
+		return nil
+	}
+
+	return map[string]string{
+		S3AccessKeyIDEnv:     p.accessKeyID,
+		S3SecretAccessKeyEnv: p.secretAccessKey,
+	}
+}
+
+// ValidateBackendExists checks if the object with the given key exists in the bucket.
+func (p *s3Backend) ValidateBackendExists(ctx context.Context, name string) (bool, error) {
+	client, err := p.newClient(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = client.HeadObject(ctx, &awss3.HeadObjectInput{
+		Bucket: aws.String(p.config.Bucket),
+		Key:    aws.String(name),
+	})
+	var notFound *types.NotFound
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get Terraform state %q from bucket %q: %w", name, p.config.Bucket, err)
+	}
+
+	return true, nil
+}
+
+// DeleteBackend deletes the object with the given key from the bucket.
+func (p *s3Backend) DeleteBackend(ctx context.Context, name string) error {
+	client, err := p.newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteObject(ctx, &awss3.DeleteObjectInput{
+		Bucket: aws.String(p.config.Bucket),
+		Key:    aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete Terraform state %q from bucket %q: %w", name, p.config.Bucket, err)
+	}
+
+	return nil
+}
+
+func (p *s3Backend) openClient(ctx context.Context) (S3Client, error) {
+	optFns := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(p.config.Region)}
+	if p.accessKeyID != "" {
+		optFns = append(optFns, awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(p.accessKeyID, p.secretAccessKey, "")))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	return awss3.NewFromConfig(cfg, func(o *awss3.Options) {
+		if p.config.Endpoint != "" {
+			o.BaseEndpoint = aws.String(p.config.Endpoint)
+			o.UsePathStyle = true
+		}
+		// Many S3-compatible servers reject the SDK's default trailing checksums.
+		o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+		o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+	}), nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/radius-project/radius/pkg/corerp/datamodel"
+	"github.com/radius-project/radius/pkg/recipes"
+	"github.com/stretchr/testify/require"
+)
+
+const testS3SecretID = "/planes/radius/local/resourceGroups/test-group/providers/Radius.Security/secrets/s3"
+
+// fakeS3Server is a minimal MinIO-style stand-in that supports path-style HeadObject and DeleteObject.
+type fakeS3Server struct {
+	*httptest.Server
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3Server(t *testing.T) *fakeS3Server {
+	t.Helper()
+	setTestCredentials(t)
+
+	server := &fakeS3Server{objects: map[string][]byte{}}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *fakeS3Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodHead:
+		if _, ok := s.objects[key]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// setTestCredentials isolates the AWS configuration chain from the developer's environment.
+func setTestCredentials(t *testing.T) {
+	t.Helper()
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_CONFIG_FILE", os.DevNull)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", os.DevNull)
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+}
+
+func testS3Secrets() map[string]recipes.SecretData {
+	return map[string]recipes.SecretData{
+		testS3SecretID: {
+			Data: map[string]string{
+				S3BackendAccessKeyIDKey:     "test",
+				S3BackendSecretAccessKeyKey: "test-secret",
+			},
+		},
+	}
+}
+
+func Test_S3_BuildBackend(t *testing.T) {
+	_, resourceRecipe := getTestInputs()
+	suffix, err := generateSecretSuffix(&resourceRecipe)
+	require.NoError(t, err)
+
+	t.Run("defaults", func(t *testing.T) {
+		b, err := NewS3Backend(datamodel.TerraformS3BackendConfig{Bucket: "tfstate"}, nil)
+		require.NoError(t, err)
+
+		config, err := b.BuildBackend(&resourceRecipe)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{
+			BackendS3: map[string]any{
+				"bucket":       "tfstate",
+				"key":          "radius/tfstate/" + suffix + ".tfstate",
+				"region":       "us-east-1",
+				"use_lockfile": true,
+			},
+		}, config)
+		require.Equal(t, "radius/tfstate/"+suffix+".tfstate", StateName(config))
+	})
+
+	t.Run("S3-compatible server with secret", func(t *testing.T) {
+		b, err := NewS3Backend(datamodel.TerraformS3BackendConfig{
+			Bucket:    "tfstate",
+			KeyPrefix: "dev/",
+			Region:    "eu-west-1",
+			Endpoint:  "http://minio.minio-system:9000",
+			Secret:    testS3SecretID,
+		}, testS3Secrets())
+		require.NoError(t, err)
+
+		config, err := b.BuildBackend(&resourceRecipe)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{
+			BackendS3: map[string]any{
+				"bucket":                      "tfstate",
+				"key":                         "dev/" + suffix + ".tfstate",
+				"region":                      "eu-west-1",
+				"use_lockfile":                true,
+				"endpoints":                   map[string]any{"s3": "http://minio.minio-system:9000"},
+				"use_path_style":              true,
+				"skip_credentials_validation": true,
+				"skip_requesting_account_id":  true,
+				"skip_region_validation":      true,
+			},
+		}, config)
+		require.Equal(t, map[string]string{S3AccessKeyIDEnv: "test", S3SecretAccessKeyEnv: "test-secret"}, b.(EnvBackend).Env())
+	})
+}
+
+func Test_NewS3Backend_InvalidSecret(t *testing.T) {
+	_, err := NewS3Backend(datamodel.TerraformS3BackendConfig{Bucket: "tfstate", Secret: testS3SecretID}, nil)
+	require.ErrorContains(t, err, "missing secret")
+
+	secrets := map[string]recipes.SecretData{
+		testS3SecretID: {Data: map[string]string{S3BackendAccessKeyIDKey: "test"}},
+	}
+	_, err = NewS3Backend(datamodel.TerraformS3BackendConfig{Bucket: "tfstate", Secret: testS3SecretID}, secrets)
+	require.ErrorContains(t, err, "must have the keys")
+}
+
+func Test_S3_ValidateAndDeleteBackend(t *testing.T) {
+	server := newFakeS3Server(t)
+	server.objects["tfstate/radius/tfstate/abc.tfstate"] = []byte("{}")
+
+	b, err := NewS3Backend(datamodel.TerraformS3BackendConfig{
+		Bucket:   "tfstate",
+		Endpoint: server.URL,
+		Secret:   testS3SecretID,
+	}, testS3Secrets())
+	require.NoError(t, err)
+
+	exists, err := b.ValidateBackendExists(t.Context(), "radius/tfstate/abc.tfstate")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	require.NoError(t, b.DeleteBackend(t.Context(), "radius/tfstate/abc.tfstate"))
+	require.Empty(t, server.objects)
+
+	exists, err = b.ValidateBackendExists(t.Context(), "radius/tfstate/abc.tfstate")
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func Test_S3_ValidateBackendExists_Error(t *testing.T) {
+	// Requests without the credentials of the secret are rejected.
+	server := newFakeS3Server(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "other")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "other")
+
+	b, err := NewS3Backend(datamodel.TerraformS3BackendConfig{Bucket: "tfstate", Endpoint: server.URL}, nil)
+	require.NoError(t, err)
+
+	_, err = b.ValidateBackendExists(t.Context(), "radius/tfstate/abc.tfstate")
+	require.ErrorContains(t, err, "failed to get Terraform state")
+}
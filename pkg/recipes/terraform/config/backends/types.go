@@ -18,8 +18,12 @@ package backends
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/radius-project/radius/pkg/corerp/datamodel"
 	"github.com/radius-project/radius/pkg/recipes"
+	"k8s.io/client-go/kubernetes"
 )
 
 //go:generate go tool mockgen -typed -destination=./mock_backend.go -package=backends -self_package github.com/radius-project/radius/pkg/recipes/terraform/config/backends github.com/radius-project/radius/pkg/recipes/terraform/config/backends Backend
@@ -35,4 +39,80 @@ type Backend interface {
 	// For example, for Kubernetes backend, it checks if the Kubernetes secret for Terraform state file exists.
 	// returns true if backend is found, false otherwise.
 	ValidateBackendExists(ctx context.Context, name string) (bool, error)
+
+	// DeleteBackend deletes the Terraform state file backend source with the given name.
+	// It does not return an error if the backend source does not exist.
+	DeleteBackend(ctx context.Context, name string) error
+}
+
+// EnvBackend is implemented by the backends whose credentials are passed to Terraform through environment
+// variables. Credentials set in the backend configuration are written to the generated Terraform configuration
+// and saved by terraform init in the working directory, while environment variables are not.
+type EnvBackend interface {
+	// Env returns the environment variables to set for the Terraform process.
+	Env() map[string]string
+}
+
+// Options represents the inputs required to create a Backend with NewBackend.
+type Options struct {
+	// KubernetesClient is the Kubernetes client used by the Kubernetes backend.
+	KubernetesClient kubernetes.Interface
+
+	// PostgreSQLURL is the connection URL of the PostgreSQL database of the control plane.
+	// It is empty when the control plane does not use PostgreSQL.
+	PostgreSQLURL string
+
+	// Secrets is the secret data resolved for the recipe, keyed by secret resource ID.
+	Secrets map[string]recipes.SecretData
+}
+
+// NewBackend creates the Backend selected by the Terraform backend configuration of the environment.
+// A nil configuration selects the Kubernetes backend.
+func NewBackend(config *datamodel.TerraformBackendConfig, options Options) (Backend, error) {
+	if config == nil {
+		return NewKubernetesBackend(options.KubernetesClient), nil
+	}
+
+	switch config.Kind {
+	case "", datamodel.TerraformBackendKubernetes:
+		return NewKubernetesBackend(options.KubernetesClient), nil
+	case datamodel.TerraformBackendPostgreSQL:
+		if options.PostgreSQLURL == "" {
+			return nil, errors.New("the postgresql Terraform backend requires the control plane to use a PostgreSQL database")
+		}
+		return NewPostgreSQLBackend(options.PostgreSQLURL), nil
+	case datamodel.TerraformBackendS3:
+		if config.S3 == nil || config.S3.Bucket == "" {
+			return nil, errors.New("the s3 Terraform backend requires a bucket")
+		}
+		return NewS3Backend(*config.S3, options.Secrets)
+	default:
+		return nil, fmt.Errorf("unsupported Terraform backend kind %q", config.Kind)
+	}
+}
+
+// StateName returns the name of the backend source that stores the Terraform state for the given
+// backend configuration, as generated by Backend.BuildBackend. The name is the input of
+// Backend.ValidateBackendExists and Backend.DeleteBackend. It returns an empty string for an
+// unknown backend.
+func StateName(backendConfig map[string]any) string {
+	if details, ok := backendConfig[BackendKubernetes].(map[string]any); ok {
+		if suffix, ok := details["secret_suffix"].(string); ok {
+			return KubernetesBackendNamePrefix + suffix
+		}
+	}
+
+	if details, ok := backendConfig[BackendPostgreSQL].(map[string]any); ok {
+		if schema, ok := details["schema_name"].(string); ok {
+			return schema
+		}
+	}
+
+	if details, ok := backendConfig[BackendS3].(map[string]any); ok {
+		if key, ok := details["key"].(string); ok {
+			return key
+		}
+	}
+
+	return ""
 }
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	"testing"
+
+	"github.com/radius-project/radius/pkg/corerp/datamodel"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_NewBackend(t *testing.T) {
+	options := Options{
+		KubernetesClient: fake.NewClientset(),
+		PostgreSQLURL:    "postgresql://radius@database:5432/radius",
+	}
+
+	tests := []struct {
+		name     string
+		config   *datamodel.TerraformBackendConfig
+		options  Options
+		expected Backend
+		err      string
+	}{
+		{
+			name:     "default",
+			options:  options,
+			expected: &kubernetesBackend{},
+		},
+		{
+			name:     "kubernetes",
+			config:   &datamodel.TerraformBackendConfig{Kind: datamodel.TerraformBackendKubernetes},
+			options:  options,
+			expected: &kubernetesBackend{},
+		},
+		{
+			name:     "postgresql",
+			config:   &datamodel.TerraformBackendConfig{Kind: datamodel.TerraformBackendPostgreSQL},
+			options:  options,
+			expected: &postgreSQLBackend{},
+		},
+		{
+			name:    "postgresql without control plane database",
+			config:  &datamodel.TerraformBackendConfig{Kind: datamodel.TerraformBackendPostgreSQL},
+			options: Options{KubernetesClient: fake.NewClientset()},
+			err:     "requires the control plane to use a PostgreSQL database",
+		},
+		{
+			name: "s3",
+			config: &datamodel.TerraformBackendConfig{
+				Kind: datamodel.TerraformBackendS3,
+				S3:   &datamodel.TerraformS3BackendConfig{Bucket: "tfstate"},
+			},
+			options:  options,
+			expected: &s3Backend{},
+		},
+		{
+			name:    "s3 without bucket",
+			config:  &datamodel.TerraformBackendConfig{Kind: datamodel.TerraformBackendS3},
+			options: options,
+			err:     "requires a bucket",
+		},
+		{
+			name:    "unsupported",
+			config:  &datamodel.TerraformBackendConfig{Kind: "consul"},
+			options: options,
+			err:     `unsupported Terraform backend kind "consul"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			backend, err := NewBackend(tc.config, tc.options)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.IsType(t, tc.expected, backend)
+		})
+	}
+}
+
+func Test_StateName(t *testing.T) {
+	require.Equal(t, KubernetesBackendNamePrefix+"abc", StateName(map[string]any{
+		BackendKubernetes: map[string]any{"secret_suffix": "abc", "namespace": RadiusNamespace},
+	}))
+	require.Equal(t, "tfstate_abc", StateName(map[string]any{
+		BackendPostgreSQL: map[string]any{"schema_name": "tfstate_abc"},
+	}))
+	require.Equal(t, "radius/tfstate/abc.tfstate", StateName(map[string]any{
+		BackendS3: map[string]any{"bucket": "tfstate", "key": "radius/tfstate/abc.tfstate"},
+	}))
+	require.Empty(t, StateName(map[string]any{"local": map[string]any{}}))
+}
@@ -23,6 +23,7 @@ import (
 	"io"
 	"maps"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -32,6 +33,9 @@ import (
 	"github.com/radius-project/radius/pkg/components/kubernetesclient/kubernetesclientprovider"
 	"github.com/radius-project/radius/pkg/components/metrics"
 	"github.com/radius-project/radius/pkg/components/secret/secretprovider"
+	"github.com/radius-project/radius/pkg/components/trace"
+	dm "github.com/radius-project/radius/pkg/corerp/datamodel"
+	"github.com/radius-project/radius/pkg/recipes"
 	"github.com/radius-project/radius/pkg/recipes/paramresolver"
 	"github.com/radius-project/radius/pkg/recipes/recipecontext"
 	"github.com/radius-project/radius/pkg/recipes/terraform/config"
@@ -41,7 +45,11 @@ import (
 	"github.com/radius-project/radius/pkg/sdk"
 	"github.com/radius-project/radius/pkg/ucp/ucplog"
 	"go.opentelemetry.io/otel/attribute"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// planFileName is the name of the file in the working directory that Terraform writes the plan to.
+	planFileName = "radius.tfplan"
 )
 
 var (
@@ -52,8 +60,10 @@ var (
 var _ TerraformExecutor = (*executor)(nil)
 
 // NewExecutor creates a new Executor with the given UCP connection and secret provider, to execute a Terraform recipe.
-func NewExecutor(ucpConn sdk.Connection, secretProvider *secretprovider.SecretProvider, kubernetesClients kubernetesclientprovider.KubernetesClientProvider) *executor {
-	return &executor{ucpConn: ucpConn, secretProvider: secretProvider, kubernetesClients: kubernetesClients}
+// postgreSQLURL is the connection URL of the control plane database used by the postgresql state backend, and is
+// empty when the control plane does not use PostgreSQL.
+func NewExecutor(ucpConn sdk.Connection, secretProvider *secretprovider.SecretProvider, kubernetesClients kubernetesclientprovider.KubernetesClientProvider, postgreSQLURL string) *executor {
+	return &executor{ucpConn: ucpConn, secretProvider: secretProvider, kubernetesClients: kubernetesClients, postgreSQLURL: postgreSQLURL}
 }
 
 type executor struct {
@@ -65,14 +75,24 @@ type executor struct {
 
 	// kubernetesClients provides access to the Kubernetes clients.
 	kubernetesClients kubernetesclientprovider.KubernetesClientProvider
+
+	// postgreSQLURL is the connection URL of the control plane database, used by the postgresql state backend.
+	postgreSQLURL string
 }
 
 // Deploy ensures Terraform is available, creates a working directory, generates a config, and runs Terraform init and
 // apply in the working directory, returning an error if any of these steps fail.
-func (e *executor) Deploy(ctx context.Context, options Options) (*tfjson.State, error) {
+func (e *executor) Deploy(ctx context.Context, options Options) (_ *tfjson.State, err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "terraform.Deploy", options.ResourceRecipe, options.EnvRecipe)
+	defer func() { trace.EndSpan(span, err) }()
+
 	// Install Terraform
-	i := install.NewInstaller()
-	tf, err := Install(ctx, i, InstallOptions{RootDir: options.RootDir, LogLevel: options.LogLevel})
+	tf, err := installTerraform(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := e.newBackend(options)
 	if err != nil {
 		return nil, err
 	}
@@ -83,36 +103,36 @@ func (e *executor) Deploy(ctx context.Context, options Options) (*tfjson.State,
 	// authenticated registries need credentials and provider_installation
 	// rules in effect at fetch time, not just at apply time.
 	if options.EnvConfig != nil {
-		if err = e.setEnvironmentVariables(tf, options); err != nil {
+		if err = e.setEnvironmentVariables(tf, options, backend); err != nil {
 			return nil, err
 		}
 	}
 
 	// Create Terraform config in the working directory
-	kubernetesBackendSuffix, err := e.generateConfig(ctx, tf, options)
+	stateName, err := e.generateConfig(ctx, tf, options, backend)
 	if err != nil {
 		return nil, err
 	}
 
-	// Run TF Init and Apply in the working directory
-	stateLockTimeout := getStateLockTimeout(options.StateLockTimeout)
-	state, err := initAndApply(ctx, tf, stateLockTimeout)
+	// Find the state of a recipe deployed before the environment switched away from the Kubernetes secret backend.
+	migration, err := e.prepareStateMigration(ctx, tf.WorkingDir(), options, backend, stateName)
 	if err != nil {
 		return nil, err
 	}
 
-	// Validate that the terraform state file backend source exists.
-	// Currently only Kubernetes secret backend is supported, which is created by Terraform as a part of Terraform apply.
-	kubernetesClient, err := e.kubernetesClients.ClientGoClient()
+	// Run TF Init and Apply in the working directory
+	stateLockTimeout := getStateLockTimeout(options.StateLockTimeout)
+	state, err := initAndApply(ctx, tf, stateLockTimeout, migration)
 	if err != nil {
-		return nil, fmt.Errorf("error getting kubernetes client: %w", err)
+		return nil, err
 	}
 
-	backendExists, err := backends.NewKubernetesBackend(kubernetesClient).ValidateBackendExists(ctx, backends.KubernetesBackendNamePrefix+kubernetesBackendSuffix)
+	// Validate that the terraform state file backend source exists, which is created by Terraform as a part of Terraform apply.
+	backendExists, err := backend.ValidateBackendExists(ctx, stateName)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving kubernetes secret for terraform state: %w", err)
+		return nil, fmt.Errorf("error retrieving terraform state: %w", err)
 	} else if !backendExists {
-		return nil, errors.New("expected kubernetes secret for terraform state is not found")
+		return nil, fmt.Errorf("expected terraform state %q is not found", stateName)
 	}
 
 	return state, nil
@@ -120,12 +140,14 @@ func (e *executor) Deploy(ctx context.Context, options Options) (*tfjson.State,
 
 // Delete ensures Terraform is available, creates a working directory, generates a config, and runs Terraform destroy
 // in the working directory, returning an error if any of these steps fail.
-func (e *executor) Delete(ctx context.Context, options Options) error {
+func (e *executor) Delete(ctx context.Context, options Options) (err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "terraform.Delete", options.ResourceRecipe, options.EnvRecipe)
+	defer func() { trace.EndSpan(span, err) }()
+
 	logger := ucplog.FromContextOrDiscard(ctx)
 
 	// Install Terraform
-	i := install.NewInstaller()
-	tf, err := Install(ctx, i, InstallOptions{RootDir: options.RootDir, LogLevel: options.LogLevel})
+	tf, err := installTerraform(ctx, options)
 	// Note: We use a global shared binary approach, so we should NOT call i.Remove()
 	// as it would remove the shared global binary that other operations might be using.
 	// The global binary will persist across operations to eliminate race conditions.
@@ -133,49 +155,238 @@ func (e *executor) Delete(ctx context.Context, options Options) error {
 		return err
 	}
 
+	backend, err := e.newBackend(options)
+	if err != nil {
+		return err
+	}
+
 	// Create Terraform config in the working directory
-	kubernetesBackendSuffix, err := e.generateConfig(ctx, tf, options)
+	stateName, err := e.generateConfig(ctx, tf, options, backend)
 	if err != nil {
 		return err
 	}
 
-	// Apply provider_installation rules from the Radius.Core terraformConfig (if any).
-	// Applications.Core leaves this nil, so this is a no-op for the legacy path.
-	if err = e.applyTerraformCLIConfig(tf, options); err != nil {
+	// Apply provider_installation rules from the Radius.Core terraformConfig (if any), and the
+	// credentials of the state backend. Applications.Core leaves the rules nil.
+	if err = e.applyTerraformCLIConfig(tf, options, backend); err != nil {
 		return err
 	}
 
 	// Before running terraform init and destroy, ensure that the Terraform state file storage source exists.
 	// If the state file source has been deleted or wasn't created due to a failure during apply then
 	// terraform initialization will fail due to missing backend source.
-	kubernetesClient, err := e.kubernetesClients.ClientGoClient()
-	if err != nil {
-		return fmt.Errorf("error getting kubernetes client: %w", err)
-	}
-
-	backendExists, err := backends.NewKubernetesBackend(kubernetesClient).ValidateBackendExists(ctx, backends.KubernetesBackendNamePrefix+kubernetesBackendSuffix)
+	var migration *stateMigration
+	backendExists, err := backend.ValidateBackendExists(ctx, stateName)
 	if err != nil {
 		// Continue with the delete flow for all errors other than backend not found.
 		// If it is an intermittent error then the delete flow will fail and should be retried from the client.
 		logger.Info(fmt.Sprintf("Error retrieving Terraform state file backend: %s", err.Error()))
 	} else if !backendExists {
-		// Skip deletion if the backend does not exist. Delete can't be performed without Terraform state file.
-		logger.Info("Skipping deletion of recipe resources: Terraform state file backend does not exist.")
-		return nil
+		// The state may still be in the Kubernetes secret backend the environment used before.
+		migration, err = e.exportKubernetesState(ctx, tf.WorkingDir(), options)
+		if err != nil {
+			return err
+		}
+
+		if migration == nil {
+			// Skip deletion if the backend does not exist. Delete can't be performed without Terraform state file.
+			logger.Info("Skipping deletion of recipe resources: Terraform state file backend does not exist.")
+			return nil
+		}
 	}
 
 	// Run TF Destroy in the working directory to delete the resources deployed by the recipe
 	stateLockTimeout := getStateLockTimeout(options.StateLockTimeout)
-	err = initAndDestroy(ctx, tf, stateLockTimeout)
+	err = initAndDestroy(ctx, tf, stateLockTimeout, migration)
 	if err != nil {
 		return err
 	}
 
-	// Delete the kubernetes secret created for terraform state file.
-	err = kubernetesClient.CoreV1().
-		Secrets(backends.RadiusNamespace).
-		Delete(ctx, backends.KubernetesBackendNamePrefix+kubernetesBackendSuffix, metav1.DeleteOptions{})
+	// Delete the backend source created for terraform state file.
+	err = backend.DeleteBackend(ctx, stateName)
+	if err != nil {
+		return fmt.Errorf("error deleting terraform state: %w", err)
+	}
+
+	return nil
+}
+
+// Plan ensures Terraform is available, creates a working directory, generates a config, and runs Terraform init and
+// plan in the working directory, returning the plan. Neither the resources of the recipe nor its state are changed, and
+// a recipe that has no state yet is planned against an empty state in the working directory, so that no state is
+// created in the backend of the environment.
+func (e *executor) Plan(ctx context.Context, options Options) (_ *tfjson.Plan, err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "terraform.Plan", options.ResourceRecipe, options.EnvRecipe)
+	defer func() { trace.EndSpan(span, err) }()
+
+	// Install Terraform
+	tf, err := installTerraform(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := e.newBackend(options)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err = e.planBackend(ctx, options, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set environment variables before generateConfig, which downloads the module. See Deploy.
+	if options.EnvConfig != nil {
+		if err = e.setEnvironmentVariables(tf, options, backend); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create Terraform config in the working directory
+	_, err = e.generateConfig(ctx, tf, options, backend)
 	if err != nil {
+		return nil, err
+	}
+
+	// Run TF Init and Plan in the working directory
+	stateLockTimeout := getStateLockTimeout(options.StateLockTimeout)
+	return initAndPlan(ctx, tf, stateLockTimeout, options.RefreshOnly)
+}
+
+// newBackend creates the Terraform state backend configured for the environment of the recipe.
+func (e *executor) newBackend(options Options) (backends.Backend, error) {
+	kubernetesClient, err := e.kubernetesClients.ClientGoClient()
+	if err != nil {
+		return nil, fmt.Errorf("error getting kubernetes client: %w", err)
+	}
+
+	return backends.NewBackend(backendConfig(options), backends.Options{
+		KubernetesClient: kubernetesClient,
+		PostgreSQLURL:    e.postgreSQLURL,
+		Secrets:          options.Secrets,
+	})
+}
+
+// backendConfig returns the Terraform state backend configuration of the environment, or nil for the
+// default Kubernetes secret backend.
+func backendConfig(options Options) *dm.TerraformBackendConfig {
+	if options.EnvConfig == nil {
+		return nil
+	}
+
+	config := options.EnvConfig.RecipeConfig.Terraform.Backend
+	if config == nil || config.Kind == "" || config.Kind == dm.TerraformBackendKubernetes {
+		return nil
+	}
+
+	return config
+}
+
+// stateMigration is the Terraform state of a recipe to move from a Kubernetes secret to the configured backend.
+type stateMigration struct {
+	state *backends.KubernetesState
+
+	// source is the Kubernetes secret backend the state is moved from.
+	source backends.Backend
+}
+
+// prepareStateMigration returns the state to migrate when the configured backend is not the Kubernetes secret
+// backend and does not hold the state of the recipe yet. It returns nil if there is nothing to migrate.
+func (e *executor) prepareStateMigration(ctx context.Context, workingDir string, options Options, backend backends.Backend, stateName string) (*stateMigration, error) {
+	if backendConfig(options) == nil {
+		return nil, nil
+	}
+
+	exists, err := backend.ValidateBackendExists(ctx, stateName)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving terraform state: %w", err)
+	} else if exists {
+		return nil, nil
+	}
+
+	return e.exportKubernetesState(ctx, workingDir, options)
+}
+
+// exportKubernetesState exports the state of the recipe from the Kubernetes secret backend to workingDir when
+// the configured backend is another backend. It returns nil if there is nothing to migrate.
+func (e *executor) exportKubernetesState(ctx context.Context, workingDir string, options Options) (*stateMigration, error) {
+	if backendConfig(options) == nil {
+		return nil, nil
+	}
+
+	kubernetesClient, err := e.kubernetesClients.ClientGoClient()
+	if err != nil {
+		return nil, fmt.Errorf("error getting kubernetes client: %w", err)
+	}
+
+	state, err := backends.ExportKubernetesState(ctx, kubernetesClient, options.ResourceRecipe, workingDir)
+	if err != nil || state == nil {
+		return nil, err
+	}
+
+	return &stateMigration{state: state, source: backends.NewKubernetesBackend(kubernetesClient)}, nil
+}
+
+// planBackend returns the backend that holds the state of the recipe for a plan. A plan must not move or create the
+// state, so the state of a recipe that has not been migrated from the Kubernetes secret backend yet is planned where it
+// is, and a recipe that has no state in any backend is planned with the local backend of the working directory.
+func (e *executor) planBackend(ctx context.Context, options Options, backend backends.Backend) (backends.Backend, error) {
+	exists, err := stateExists(ctx, backend, options.ResourceRecipe)
+	if err != nil || exists {
+		return backend, err
+	}
+
+	if backendConfig(options) != nil {
+		kubernetesClient, err := e.kubernetesClients.ClientGoClient()
+		if err != nil {
+			return nil, fmt.Errorf("error getting kubernetes client: %w", err)
+		}
+
+		source := backends.NewKubernetesBackend(kubernetesClient)
+		exists, err = stateExists(ctx, source, options.ResourceRecipe)
+		if err != nil {
+			return nil, err
+		} else if exists {
+			return source, nil
+		}
+	}
+
+	return backends.NewLocalBackend(), nil
+}
+
+// stateExists returns true if backend holds the Terraform state of the recipe.
+func stateExists(ctx context.Context, backend backends.Backend, resourceRecipe *recipes.ResourceMetadata) (bool, error) {
+	config, err := backend.BuildBackend(resourceRecipe)
+	if err != nil {
+		return false, err
+	}
+
+	exists, err := backend.ValidateBackendExists(ctx, backends.StateName(config))
+	if err != nil {
+		return false, fmt.Errorf("error retrieving terraform state: %w", err)
+	}
+
+	return exists, nil
+}
+
+// push pushes the exported state to the configured backend, which must be initialized by terraform init, and
+// deletes the Kubernetes secret that held the state.
+func (m *stateMigration) push(ctx context.Context, tf *tfexec.Terraform) error {
+	if m == nil {
+		return nil
+	}
+
+	logger := ucplog.FromContextOrDiscard(ctx)
+	logger.Info(fmt.Sprintf("Migrating Terraform state from Kubernetes secret %q", m.state.SecretName))
+
+	pushCtx, span := trace.StartCustomSpan(ctx, "terraform.StatePush", trace.BackendTracerName, nil)
+	err := tf.StatePush(pushCtx, m.state.Path)
+	trace.EndSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("terraform state push failure: %w", err)
+	}
+
+	if err := m.source.DeleteBackend(ctx, m.state.SecretName); err != nil {
 		return fmt.Errorf("error deleting kubernetes secret for terraform state: %w", err)
 	}
 
@@ -184,8 +395,7 @@ func (e *executor) Delete(ctx context.Context, options Options) error {
 
 func (e *executor) GetRecipeMetadata(ctx context.Context, options Options) (map[string]any, error) {
 	// Install Terraform
-	i := install.NewInstaller()
-	tf, err := Install(ctx, i, InstallOptions{RootDir: options.RootDir, LogLevel: options.LogLevel})
+	tf, err := installTerraform(ctx, options)
 	if err != nil {
 		return nil, err
 	}
@@ -205,9 +415,19 @@ func (e *executor) GetRecipeMetadata(ctx context.Context, options Options) (map[
 	}, nil
 }
 
+// installTerraform ensures Terraform is available in a span of its own, so that time spent downloading the binary is
+// visible in the trace of the recipe.
+func installTerraform(ctx context.Context, options Options) (*tfexec.Terraform, error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "terraform.Install", options.ResourceRecipe, options.EnvRecipe)
+	tf, err := Install(ctx, install.NewInstaller(), InstallOptions{RootDir: options.RootDir, LogLevel: options.LogLevel})
+	trace.EndSpan(span, err)
+	return tf, err
+}
+
 // setEnvironmentVariables sets environment variables for the Terraform process by reading values from the recipe configuration.
-// Terraform process will use environment variables as input for the recipe deployment.
-func (e executor) setEnvironmentVariables(tf *tfexec.Terraform, options Options) error {
+// Terraform process will use environment variables as input for the recipe deployment. The credentials of the state backend
+// are set last, so that the state is always read from and written to the configured backend.
+func (e executor) setEnvironmentVariables(tf *tfexec.Terraform, options Options, backend backends.Backend) error {
 	if options.EnvConfig == nil {
 		return nil
 	}
@@ -257,6 +477,11 @@ func (e executor) setEnvironmentVariables(tf *tfexec.Terraform, options Options)
 		}
 	}
 
+	if env := backendEnv(backend); len(env) > 0 {
+		envVarUpdate = true
+		maps.Copy(envVars, env)
+	}
+
 	// Set the environment variables for the Terraform process
 	if envVarUpdate {
 		if err := tf.SetEnv(envVars); err != nil {
@@ -269,39 +494,59 @@ func (e executor) setEnvironmentVariables(tf *tfexec.Terraform, options Options)
 
 // applyTerraformCLIConfig writes a .terraformrc file derived from the
 // provider_installation rules and credentials in options.EnvConfig (if any) and
-// configures the Terraform CLI to use it via TF_CLI_CONFIG_FILE.
+// configures the Terraform CLI to use it via TF_CLI_CONFIG_FILE. It also sets the
+// credentials of the state backend.
 //
 // This path is used by Delete (and indirectly by Deploy via setEnvironmentVariables)
-// to ensure terraform init can resolve providers from a network mirror and
-// authenticate to private registries. When neither input is populated, this is
-// a no-op.
-func (e executor) applyTerraformCLIConfig(tf *tfexec.Terraform, options Options) error {
+// to ensure terraform init can resolve providers from a network mirror,
+// authenticate to private registries and reach the state backend. When none of
+// the inputs is populated, this is a no-op.
+func (e executor) applyTerraformCLIConfig(tf *tfexec.Terraform, options Options, backend backends.Backend) error {
 	if options.EnvConfig == nil {
 		return nil
 	}
+
+	// tf.SetEnv replaces the entire env, so seed from the current process env first.
+	envVars := splitEnvVar(os.Environ())
+	var envVarUpdate bool
+
 	pi := options.EnvConfig.RecipeConfig.Terraform.ProviderInstallation
 	creds := options.EnvConfig.RecipeConfig.Terraform.Credentials
-	if pi == nil && len(creds) == 0 {
-		return nil
+	if pi != nil || len(creds) > 0 {
+		rcPath, err := writeTerraformCLIConfig(tf.WorkingDir(), pi, creds, options.Secrets)
+		if err != nil {
+			return err
+		}
+		if rcPath != "" {
+			envVarUpdate = true
+			envVars[envTFCLIConfigFile] = rcPath
+		}
 	}
 
-	rcPath, err := writeTerraformCLIConfig(tf.WorkingDir(), pi, creds, options.Secrets)
-	if err != nil {
-		return err
+	if env := backendEnv(backend); len(env) > 0 {
+		envVarUpdate = true
+		maps.Copy(envVars, env)
 	}
-	if rcPath == "" {
+
+	if !envVarUpdate {
 		return nil
 	}
 
-	// tf.SetEnv replaces the entire env, so seed from the current process env first.
-	envVars := splitEnvVar(os.Environ())
-	envVars[envTFCLIConfigFile] = rcPath
 	if err := tf.SetEnv(envVars); err != nil {
 		return fmt.Errorf("failed to set environment variables: %w", err)
 	}
 	return nil
 }
 
+// backendEnv returns the environment variables that pass the credentials of backend to Terraform.
+func backendEnv(backend backends.Backend) map[string]string {
+	if envBackend, ok := backend.(backends.EnvBackend); ok {
+		return envBackend.Env()
+	}
+
+	return nil
+}
+
 // splitEnvVar splits a slice of environment variables into a map of keys and values.
 func splitEnvVar(envVars []string) map[string]string {
 	parsedEnvVars := make(map[string]string)
@@ -316,7 +561,11 @@ func splitEnvVar(envVars []string) map[string]string {
 }
 
 // generateConfig generates Terraform configuration with required inputs for the module, providers and backend to be initialized and applied.
-func (e *executor) generateConfig(ctx context.Context, tf *tfexec.Terraform, options Options) (string, error) {
+// It returns the name of the backend source that stores the Terraform state.
+func (e *executor) generateConfig(ctx context.Context, tf *tfexec.Terraform, options Options, backend backends.Backend) (_ string, err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "terraform.GenerateConfig", options.ResourceRecipe, options.EnvRecipe)
+	defer func() { trace.EndSpan(span, err) }()
+
 	logger := ucplog.FromContextOrDiscard(ctx)
 	workingDir := tf.WorkingDir()
 
@@ -337,35 +586,67 @@ func (e *executor) generateConfig(ctx context.Context, tf *tfexec.Terraform, opt
 		return "", err
 	}
 
-	kubernetesClient, err := e.kubernetesClients.ClientGoClient()
+	tfBackendConfig, err := tfConfig.AddTerraformBackend(options.ResourceRecipe, backend)
 	if err != nil {
-		return "", fmt.Errorf("error getting kubernetes client: %w", err)
+		return "", err
 	}
 
-	backendConfig, err := tfConfig.AddTerraformBackend(options.ResourceRecipe, backends.NewKubernetesBackend(kubernetesClient))
-	if err != nil {
+	// Add the recipe context or the resolved recipe parameters to the generated Terraform config's module parameters.
+	if err := e.resolveParameters(ctx, tfConfig, loadedModule, options); err != nil {
 		return "", err
 	}
 
-	// Retrieving the secret_suffix property from backend config to use it to verify secret creation during terraform init.
-	// This is only used for the backend of type kubernetes and should be moved inside an if block when we add more backends.
-	var secretSuffix string
-	if backendDetails, ok := backendConfig[backends.BackendKubernetes]; ok {
-		backendMap := backendDetails.(map[string]any)
-		if secret, ok := backendMap["secret_suffix"]; ok {
-			secretSuffix = secret.(string)
+	if loadedModule.ResultOutputExists {
+		if err = tfConfig.AddOutputs(options.EnvRecipe.Name); err != nil {
+			return "", err
+		}
+	} else if len(options.EnvRecipe.Outputs) > 0 || len(options.EnvRecipe.SecretOutputs) > 0 {
+		// Direct module with an outputs and/or secretOutputs mapping: generate an output block for each
+		// referenced module output so the values are available in the Terraform state for output mapping.
+		if err = tfConfig.AddMappedOutputs(options.EnvRecipe.Name, options.EnvRecipe.Outputs, loadedModule.OutputSensitivity, false); err != nil {
+			return "", err
+		}
+		// SecretOutputs are always treated as secrets — force their generated output blocks sensitive so
+		// a module output the module did not itself mark sensitive (e.g. AVM primaryConnectionString) is
+		// still redacted in Terraform's stdout/stderr, which Radius streams into logs.
+		if err = tfConfig.AddMappedOutputs(options.EnvRecipe.Name, options.EnvRecipe.SecretOutputs, loadedModule.OutputSensitivity, true); err != nil {
+			return "", err
+		}
+	} else {
+		// Direct module without a mapping: re-export every module output so they are present in
+		// the Terraform state and pass through unchanged (mirrors prepareRecipeResponse). Terraform
+		// does not expose child module outputs as root outputs unless they are re-declared here.
+		if err = tfConfig.AddAllOutputs(options.EnvRecipe.Name, loadedModule.OutputSensitivity); err != nil {
+			return "", err
 		}
 	}
 
-	// Add recipe context parameter to the generated Terraform config's module parameters.
-	// This should only be added if the recipe context variable is declared in the downloaded module.
+	// Add more configurations here.
+
+	// Ensure that we need to save the configuration after adding providers and recipecontext.
+	if err := tfConfig.Save(ctx, workingDir); err != nil {
+		return "", err
+	}
+
+	return backends.StateName(tfBackendConfig), nil
+}
+
+// resolveParameters sets the parameters of the recipe module in the Terraform config. A module that declares the recipe
+// context variable gets the recipe context; for a direct module the {{context.*}} expressions in the merged environment
+// and resource parameters are resolved instead.
+func (e *executor) resolveParameters(ctx context.Context, tfConfig *config.TerraformConfig, loadedModule *moduleInspectResult, options Options) (err error) {
+	ctx, span := trace.StartRecipeSpan(ctx, "terraform.ResolveParameters", options.ResourceRecipe, options.EnvRecipe)
+	defer func() { trace.EndSpan(span, err) }()
+
+	logger := ucplog.FromContextOrDiscard(ctx)
+
 	if loadedModule.ContextVarExists {
 		logger.Info("Adding recipe context module result")
 
 		// Create the recipe context object to be passed to the recipe deployment
 		recipectx, err := recipecontext.New(options.ResourceRecipe, options.EnvConfig)
 		if err != nil {
-			return "", err
+			return err
 		}
 
 		//update the recipe context with connected resources properties
@@ -374,7 +655,7 @@ func (e *executor) generateConfig(ctx context.Context, tf *tfexec.Terraform, opt
 		}
 
 		if err = tfConfig.AddRecipeContext(ctx, options.EnvRecipe.Name, recipectx); err != nil {
-			return "", err
+			return err
 		}
 	} else {
 		// Direct module path: the module does not declare a recipe context variable, so instead of
@@ -383,7 +664,7 @@ func (e *executor) generateConfig(ctx context.Context, tf *tfexec.Terraform, opt
 
 		recipectx, err := recipecontext.New(options.ResourceRecipe, options.EnvConfig)
 		if err != nil {
-			return "", err
+			return err
 		}
 
 		if options.ResourceRecipe != nil {
@@ -402,39 +683,8 @@ func (e *executor) generateConfig(ctx context.Context, tf *tfexec.Terraform, opt
 			tfConfig.Module[options.EnvRecipe.Name].SetParams(config.RecipeParams(resolvedParams))
 		}
 	}
-	if loadedModule.ResultOutputExists {
-		if err = tfConfig.AddOutputs(options.EnvRecipe.Name); err != nil {
-			return "", err
-		}
-	} else if len(options.EnvRecipe.Outputs) > 0 || len(options.EnvRecipe.SecretOutputs) > 0 {
-		// Direct module with an outputs and/or secretOutputs mapping: generate an output block for each
-		// referenced module output so the values are available in the Terraform state for output mapping.
-		if err = tfConfig.AddMappedOutputs(options.EnvRecipe.Name, options.EnvRecipe.Outputs, loadedModule.OutputSensitivity, false); err != nil {
-			return "", err
-		}
-		// SecretOutputs are always treated as secrets — force their generated output blocks sensitive so
-		// a module output the module did not itself mark sensitive (e.g. AVM primaryConnectionString) is
-		// still redacted in Terraform's stdout/stderr, which Radius streams into logs.
-		if err = tfConfig.AddMappedOutputs(options.EnvRecipe.Name, options.EnvRecipe.SecretOutputs, loadedModule.OutputSensitivity, true); err != nil {
-			return "", err
-		}
-	} else {
-		// Direct module without a mapping: re-export every module output so they are present in
-		// the Terraform state and pass through unchanged (mirrors prepareRecipeResponse). Terraform
-		// does not expose child module outputs as root outputs unless they are re-declared here.
-		if err = tfConfig.AddAllOutputs(options.EnvRecipe.Name, loadedModule.OutputSensitivity); err != nil {
-			return "", err
-		}
-	}
-
-	// Add more configurations here.
 
-	// Ensure that we need to save the configuration after adding providers and recipecontext.
-	if err := tfConfig.Save(ctx, workingDir); err != nil {
-		return "", err
-	}
-
-	return secretSuffix, nil
+	return nil
 }
 
 // getTerraformConfig initializes the Terraform json config with provided module source and saves it
@@ -472,14 +722,15 @@ func getStateLockTimeout(timeout string) string {
 	return timeout
 }
 
-// initAndApply runs Terraform init and apply in the provided working directory.
-func initAndApply(ctx context.Context, tf *tfexec.Terraform, stateLockTimeout string) (*tfjson.State, error) {
+// initAndApply runs Terraform init and apply in the provided working directory. A non-nil migration is pushed
+// to the backend after init.
+func initAndApply(ctx context.Context, tf *tfexec.Terraform, stateLockTimeout string, migration *stateMigration) (*tfjson.State, error) {
 	logger := ucplog.FromContextOrDiscard(ctx)
 
 	// Initialize Terraform
 	logger.Info("Initializing Terraform")
 	terraformInitStartTime := time.Now()
-	if err := tf.Init(ctx); err != nil {
+	if err := runInit(ctx, tf); err != nil {
 		metrics.DefaultRecipeEngineMetrics.RecordTerraformInitializationDuration(ctx, terraformInitStartTime,
 			[]attribute.KeyValue{metrics.OperationStateAttrKey.String(metrics.FailedOperationState)})
 
@@ -488,9 +739,16 @@ func initAndApply(ctx context.Context, tf *tfexec.Terraform, stateLockTimeout st
 	metrics.DefaultRecipeEngineMetrics.RecordTerraformInitializationDuration(ctx, terraformInitStartTime,
 		[]attribute.KeyValue{metrics.OperationStateAttrKey.String(metrics.SuccessfulOperationState)})
 
+	if err := migration.push(ctx, tf); err != nil {
+		return nil, err
+	}
+
 	// Apply Terraform configuration with state lock timeout
 	logger.Info("Running Terraform apply with state lock timeout: " + stateLockTimeout)
-	if err := tf.Apply(ctx, tfexec.Lock(true), tfexec.LockTimeout(stateLockTimeout)); err != nil {
+	applyCtx, applySpan := trace.StartCustomSpan(ctx, "terraform.Apply", trace.BackendTracerName, nil)
+	err := tf.Apply(applyCtx, tfexec.Lock(true), tfexec.LockTimeout(stateLockTimeout))
+	trace.EndSpan(applySpan, err)
+	if err != nil {
 		return nil, fmt.Errorf("terraform apply failure: %w", err)
 	}
 
@@ -510,17 +768,22 @@ func initAndApply(ctx context.Context, tf *tfexec.Terraform, stateLockTimeout st
 	tf.SetStdout(io.Discard)
 	defer tf.SetStdout(&tfLogWrapper{logger: logger})
 
-	return tf.Show(ctx)
+	showCtx, showSpan := trace.StartCustomSpan(ctx, "terraform.Show", trace.BackendTracerName, nil)
+	state, err := tf.Show(showCtx)
+	trace.EndSpan(showSpan, err)
+
+	return state, err
 }
 
-// initAndDestroy runs Terraform init and destroy in the provided working directory.
-func initAndDestroy(ctx context.Context, tf *tfexec.Terraform, stateLockTimeout string) error {
+// initAndDestroy runs Terraform init and destroy in the provided working directory. A non-nil migration is pushed
+// to the backend after init.
+func initAndDestroy(ctx context.Context, tf *tfexec.Terraform, stateLockTimeout string, migration *stateMigration) error {
 	logger := ucplog.FromContextOrDiscard(ctx)
 
 	// Initialize Terraform
 	logger.Info("Initializing Terraform")
 	terraformInitStartTime := time.Now()
-	if err := tf.Init(ctx); err != nil {
+	if err := runInit(ctx, tf); err != nil {
 		metrics.DefaultRecipeEngineMetrics.RecordTerraformInitializationDuration(ctx, terraformInitStartTime,
 			[]attribute.KeyValue{metrics.OperationStateAttrKey.String(metrics.FailedOperationState)})
 
@@ -528,11 +791,73 @@ func initAndDestroy(ctx context.Context, tf *tfexec.Terraform, stateLockTimeout
 	}
 	metrics.DefaultRecipeEngineMetrics.RecordTerraformInitializationDuration(ctx, terraformInitStartTime, nil)
 
+	if err := migration.push(ctx, tf); err != nil {
+		return err
+	}
+
 	// Destroy Terraform configuration with state lock timeout
 	logger.Info("Running Terraform destroy with state lock timeout: " + stateLockTimeout)
-	if err := tf.Destroy(ctx, tfexec.Lock(true), tfexec.LockTimeout(stateLockTimeout)); err != nil {
+	destroyCtx, destroySpan := trace.StartCustomSpan(ctx, "terraform.Destroy", trace.BackendTracerName, nil)
+	err := tf.Destroy(destroyCtx, tfexec.Lock(true), tfexec.LockTimeout(stateLockTimeout))
+	trace.EndSpan(destroySpan, err)
+	if err != nil {
 		return fmt.Errorf("terraform destroy failure: %w", err)
 	}
 
 	return nil
 }
+
+// initAndPlan runs Terraform init and plan in the provided working directory, and returns the plan. A refresh-only
+// plan only detects the changes made to the resources outside of Terraform.
+func initAndPlan(ctx context.Context, tf *tfexec.Terraform, stateLockTimeout string, refreshOnly bool) (*tfjson.Plan, error) {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	// Initialize Terraform
+	logger.Info("Initializing Terraform")
+	terraformInitStartTime := time.Now()
+	if err := runInit(ctx, tf); err != nil {
+		metrics.DefaultRecipeEngineMetrics.RecordTerraformInitializationDuration(ctx, terraformInitStartTime,
+			[]attribute.KeyValue{metrics.OperationStateAttrKey.String(metrics.FailedOperationState)})
+
+		return nil, fmt.Errorf("terraform init failure: %w", err)
+	}
+	metrics.DefaultRecipeEngineMetrics.RecordTerraformInitializationDuration(ctx, terraformInitStartTime,
+		[]attribute.KeyValue{metrics.OperationStateAttrKey.String(metrics.SuccessfulOperationState)})
+
+	// The machine readable output of plan does not contain attribute values, unlike the human readable output,
+	// so it can be written to the Radius logs.
+	planFile := filepath.Join(tf.WorkingDir(), planFileName)
+	logger.Info("Running Terraform plan with state lock timeout: " + stateLockTimeout)
+	planCtx, planSpan := trace.StartCustomSpan(ctx, "terraform.PlanJSON", trace.BackendTracerName, nil)
+	planOptions := []tfexec.PlanOption{tfexec.Out(planFile), tfexec.Lock(true), tfexec.LockTimeout(stateLockTimeout)}
+	if refreshOnly {
+		planOptions = append(planOptions, tfexec.RefreshOnly(true))
+	}
+	_, err := tf.PlanJSON(planCtx, &tfLogWrapper{logger: logger}, planOptions...)
+	trace.EndSpan(planSpan, err)
+	if err != nil {
+		return nil, fmt.Errorf("terraform plan failure: %w", err)
+	}
+
+	// Suppress stdout during tf.ShowPlanFile to prevent the plan (which may
+	// contain sensitive values) from being written to the Radius logs.
+	tf.SetStdout(io.Discard)
+	defer tf.SetStdout(&tfLogWrapper{logger: logger})
+
+	showCtx, showSpan := trace.StartCustomSpan(ctx, "terraform.ShowPlanFile", trace.BackendTracerName, nil)
+	plan, err := tf.ShowPlanFile(showCtx, planFile)
+	trace.EndSpan(showSpan, err)
+	if err != nil {
+		return nil, fmt.Errorf("terraform show failure: %w", err)
+	}
+
+	return plan, nil
+}
+
+// runInit runs Terraform init in a span of its own.
+func runInit(ctx context.Context, tf *tfexec.Terraform) error {
+	ctx, span := trace.StartCustomSpan(ctx, "terraform.Init", trace.BackendTracerName, nil)
+	err := tf.Init(ctx)
+	trace.EndSpan(span, err)
+	return err
+}
@@ -23,10 +23,16 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/radius-project/radius/pkg/components/kubernetesclient/kubernetesclientprovider"
 	dm "github.com/radius-project/radius/pkg/corerp/datamodel"
 	"github.com/radius-project/radius/pkg/recipes"
 	"github.com/radius-project/radius/pkg/recipes/terraform/config"
+	"github.com/radius-project/radius/pkg/recipes/terraform/config/backends"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestGenerateConfig(t *testing.T) {
@@ -69,13 +75,134 @@ func TestGenerateConfig(t *testing.T) {
 			require.NoError(t, err)
 
 			e := executor{}
-			_, err = e.generateConfig(ctx, tf, tc.opts)
+			_, err = e.generateConfig(ctx, tf, tc.opts, backends.NewKubernetesBackend(nil))
 			require.Error(t, err)
 			require.ErrorContains(t, err, tc.err)
 		})
 	}
 }
 
+func Test_BackendConfig(t *testing.T) {
+	s3 := &dm.TerraformBackendConfig{
+		Kind: dm.TerraformBackendS3,
+		S3:   &dm.TerraformS3BackendConfig{Bucket: "tfstate"},
+	}
+
+	tests := []struct {
+		name     string
+		backend  *dm.TerraformBackendConfig
+		envNil   bool
+		expected *dm.TerraformBackendConfig
+	}{
+		{name: "no environment configuration", envNil: true},
+		{name: "no backend"},
+		{name: "kubernetes backend", backend: &dm.TerraformBackendConfig{Kind: dm.TerraformBackendKubernetes}},
+		{name: "s3 backend", backend: s3, expected: s3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			options := Options{}
+			if !tc.envNil {
+				options.EnvConfig = &recipes.Configuration{
+					RecipeConfig: dm.RecipeConfigProperties{
+						Terraform: dm.TerraformConfigProperties{Backend: tc.backend},
+					},
+				}
+			}
+
+			require.Equal(t, tc.expected, backendConfig(options))
+		})
+	}
+}
+
+func Test_PrepareStateMigration_KubernetesBackend(t *testing.T) {
+	// The Kubernetes secret backend never migrates, so no backend or client is consulted.
+	e := executor{}
+	migration, err := e.prepareStateMigration(t.Context(), t.TempDir(), Options{}, nil, "tfstate-default-abc")
+	require.NoError(t, err)
+	require.Nil(t, migration)
+}
+
+func Test_PlanBackend(t *testing.T) {
+	resourceRecipe := &recipes.ResourceMetadata{
+		Name:          "redis",
+		EnvironmentID: "/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/environments/env",
+		ApplicationID: "/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/applications/app",
+		ResourceID:    "/planes/radius/local/resourceGroups/test-group/providers/Applications.Datastores/redisCaches/redis",
+	}
+	options := Options{
+		ResourceRecipe: resourceRecipe,
+		EnvConfig: &recipes.Configuration{
+			RecipeConfig: dm.RecipeConfigProperties{
+				Terraform: dm.TerraformConfigProperties{
+					Backend: &dm.TerraformBackendConfig{Kind: dm.TerraformBackendS3, S3: &dm.TerraformS3BackendConfig{Bucket: "tfstate"}},
+				},
+			},
+		},
+	}
+
+	newExecutor := func(t *testing.T, secretExists bool) (*executor, backends.Backend) {
+		clientset := fake.NewClientset()
+		source := backends.NewKubernetesBackend(clientset)
+		if secretExists {
+			config, err := source.BuildBackend(resourceRecipe)
+			require.NoError(t, err)
+			_, err = clientset.CoreV1().Secrets(backends.RadiusNamespace).Create(t.Context(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: backends.StateName(config), Namespace: backends.RadiusNamespace},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+
+		clients := kubernetesclientprovider.FromConfig(nil)
+		clients.SetClientGoClient(clientset)
+		return &executor{kubernetesClients: *clients}, source
+	}
+
+	newBackend := func(t *testing.T, exists bool) backends.Backend {
+		backend := backends.NewMockBackend(gomock.NewController(t))
+		backend.EXPECT().BuildBackend(resourceRecipe).Return(map[string]any{backends.BackendS3: map[string]any{"key": "state.tfstate"}}, nil)
+		backend.EXPECT().ValidateBackendExists(gomock.Any(), "state.tfstate").Return(exists, nil)
+		return backend
+	}
+
+	t.Run("kubernetes backend", func(t *testing.T) {
+		e, backend := newExecutor(t, true)
+		result, err := e.planBackend(t.Context(), Options{ResourceRecipe: resourceRecipe}, backend)
+		require.NoError(t, err)
+		require.Equal(t, backend, result)
+	})
+
+	t.Run("no state in kubernetes backend", func(t *testing.T) {
+		e, backend := newExecutor(t, false)
+		result, err := e.planBackend(t.Context(), Options{ResourceRecipe: resourceRecipe}, backend)
+		require.NoError(t, err)
+		require.Equal(t, backends.NewLocalBackend(), result)
+	})
+
+	t.Run("state in configured backend", func(t *testing.T) {
+		e, _ := newExecutor(t, true)
+		backend := newBackend(t, true)
+		result, err := e.planBackend(t.Context(), options, backend)
+		require.NoError(t, err)
+		require.Equal(t, backend, result)
+	})
+
+	t.Run("state not migrated yet", func(t *testing.T) {
+		e, source := newExecutor(t, true)
+		result, err := e.planBackend(t.Context(), options, newBackend(t, false))
+		require.NoError(t, err)
+		require.Equal(t, source, result)
+	})
+
+	t.Run("no state", func(t *testing.T) {
+		e, _ := newExecutor(t, false)
+		result, err := e.planBackend(t.Context(), options, newBackend(t, false))
+		require.NoError(t, err)
+		require.Equal(t, backends.NewLocalBackend(), result)
+	})
+}
+
 func Test_GetTerraformConfig(t *testing.T) {
 	// Create a temporary directory for testing.
 	testDir := t.TempDir()
@@ -273,7 +400,7 @@ func TestSetEnvironmentVariables(t *testing.T) {
 			require.NoError(t, err)
 
 			e := executor{}
-			err = e.setEnvironmentVariables(tf, tc.opts)
+			err = e.setEnvironmentVariables(tf, tc.opts, nil)
 
 			if tc.wantErr {
 				require.Error(t, err)
@@ -336,7 +463,7 @@ func TestApplyTerraformCLIConfig(t *testing.T) {
 			require.NoError(t, err)
 
 			e := executor{}
-			err = e.applyTerraformCLIConfig(tf, tc.opts)
+			err = e.applyTerraformCLIConfig(tf, tc.opts, nil)
 			if tc.wantError {
 				require.Error(t, err)
 				return
@@ -354,6 +481,12 @@ func TestApplyTerraformCLIConfig(t *testing.T) {
 	}
 }
 
+func Test_BackendEnv(t *testing.T) {
+	require.Nil(t, backendEnv(backends.NewKubernetesBackend(nil)))
+	require.Equal(t, map[string]string{backends.PostgreSQLConnStrEnv: "postgresql://radius@database/radius"},
+		backendEnv(backends.NewPostgreSQLBackend("postgresql://radius@database/radius")))
+}
+
 func TestSplitEnvVar(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -48,6 +48,10 @@ type TerraformExecutor interface {
 	// and deletes the Kubernetes secret created for terraform state store.
 	Delete(ctx context.Context, options Options) error
 
+	// Plan installs terraform and runs terraform init and plan on the terraform module referenced by the recipe using terraform-exec,
+	// and returns the plan without applying it.
+	Plan(ctx context.Context, options Options) (*tfjson.Plan, error)
+
 	// GetRecipeMetadata installs terraform and runs terraform get to retrieve information on the terraform module
 	GetRecipeMetadata(ctx context.Context, options Options) (map[string]any, error)
 }
@@ -76,6 +80,10 @@ type Options struct {
 
 	// LogLevel is the log level for Terraform execution (e.g., TRACE, DEBUG, INFO, WARN, ERROR).
 	LogLevel string
+
+	// RefreshOnly makes Plan compare the state with the actual resources, instead of the configuration with the
+	// state. The changes made to the resources outside of Terraform are returned as the resource drift of the plan.
+	RefreshOnly bool
 }
 
 // NewTerraform creates a working directory for Terraform execution and new Terraform executor with Terraform logs enabled.
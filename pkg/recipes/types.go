@@ -116,11 +116,20 @@ const (
 	TemplateKindBicep     = "bicep"
 	TemplateKindTerraform = "terraform"
 
+	// TemplateKindHelm is the kind for recipes that install a Helm chart. It is only available to
+	// Radius.Core recipe packs, so it is not part of SupportedTemplateKind.
+	TemplateKindHelm = "helm"
+
+	// TemplateKindKubernetes is the kind for recipes that apply Kubernetes manifests or a kustomization.
+	// Like TemplateKindHelm it is only available to Radius.Core recipe packs.
+	TemplateKindKubernetes = "kubernetes"
+
 	// Recipe outputs are expected to be wrapped under an object named "result"
 	ResultPropertyName = "result"
 )
 
 var (
+	// SupportedTemplateKind lists the template kinds accepted by Applications.Core environment recipes.
 	SupportedTemplateKind = []string{TemplateKindBicep, TemplateKindTerraform}
 )
 
@@ -137,6 +146,10 @@ type RecipeOutput struct {
 
 	// Status represents the recipe status at deployment time of resource.
 	Status *rpv1.RecipeStatus
+
+	// Parameters represents the resolved parameters the recipe is executed with. It is only set by
+	// simulated executions, and is never read from the recipe's result.
+	Parameters map[string]any `json:"-"`
 }
 
 // SecretData represents secrets data and includes secret type and a map of secret keys to their values.
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// IsObjectReference reports whether an output name refers to a field of a Kubernetes object deployed by
+// the recipe rather than to a named module output. Object references have the form "<Kind>/<name>:<path>".
+func IsObjectReference(outputName string) bool {
+	kindAndName, path, found := strings.Cut(outputName, ":")
+	return found && path != "" && strings.Contains(kindAndName, "/")
+}
+
+// ResolveObjectReference returns the value of the field referenced by an output name of the form
+// "<Kind>/<name>:<path>", for example "Service/redis:spec.clusterIP", from the given Kubernetes objects.
+// The path is a dot separated list of field names. Values under the data field of a Secret are base64
+// decoded, and isSecret reports whether the referenced object is a Secret so that its values can be
+// treated as secret outputs.
+func ResolveObjectReference(objects []*unstructured.Unstructured, outputName string) (value any, isSecret bool, err error) {
+	kindAndName, path, _ := strings.Cut(outputName, ":")
+	kind, name, _ := strings.Cut(kindAndName, "/")
+	fields := strings.Split(path, ".")
+
+	for _, obj := range objects {
+		if !strings.EqualFold(obj.GetKind(), kind) || obj.GetName() != name {
+			continue
+		}
+
+		value, found, err := unstructured.NestedFieldCopy(obj.Object, fields...)
+		if err != nil {
+			return nil, false, fmt.Errorf("output %q: %w", outputName, err)
+		}
+		if !found {
+			return nil, false, fmt.Errorf("output %q: field %q was not found on %s %q", outputName, path, obj.GetKind(), name)
+		}
+
+		isSecret = obj.GetKind() == "Secret" && obj.GroupVersionKind().Group == ""
+		if isSecret && fields[0] == "data" && len(fields) == 2 {
+			encoded, ok := value.(string)
+			if !ok {
+				return nil, false, fmt.Errorf("output %q: secret data must be a string", outputName)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, false, fmt.Errorf("output %q: failed to decode secret data: %w", outputName, err)
+			}
+			value = string(decoded)
+		}
+
+		return value, isSecret, nil
+	}
+
+	return nil, false, fmt.Errorf("output %q: %s %q was not found", outputName, kind, name)
+}
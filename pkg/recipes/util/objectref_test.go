@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_IsObjectReference(t *testing.T) {
+	require.True(t, IsObjectReference("Service/redis:spec.clusterIP"))
+	require.False(t, IsObjectReference("host"))
+	require.False(t, IsObjectReference("Service/redis"))
+	require.False(t, IsObjectReference("Service/redis:"))
+}
+
+func Test_ResolveObjectReference(t *testing.T) {
+	objects := []*unstructured.Unstructured{
+		{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   map[string]any{"name": "redis"},
+			"spec":       map[string]any{"clusterIP": "10.0.0.10"},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]any{"name": "redis"},
+			"data":       map[string]any{"password": "c2VjcmV0", "invalid": "!"},
+		}},
+	}
+
+	tests := []struct {
+		name             string
+		outputName       string
+		expectedValue    any
+		expectedIsSecret bool
+		expectedErr      string
+	}{
+		{
+			name:          "service field",
+			outputName:    "Service/redis:spec.clusterIP",
+			expectedValue: "10.0.0.10",
+		},
+		{
+			name:             "secret data is decoded",
+			outputName:       "secret/redis:data.password",
+			expectedValue:    "secret",
+			expectedIsSecret: true,
+		},
+		{
+			name:        "invalid secret data",
+			outputName:  "Secret/redis:data.invalid",
+			expectedErr: "failed to decode secret data",
+		},
+		{
+			name:        "missing field",
+			outputName:  "Service/redis:spec.ports",
+			expectedErr: `field "spec.ports" was not found on Service "redis"`,
+		},
+		{
+			name:        "missing object",
+			outputName:  "Service/missing:spec.clusterIP",
+			expectedErr: `Service "missing" was not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, isSecret, err := ResolveObjectReference(objects, tt.outputName)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedValue, value)
+			require.Equal(t, tt.expectedIsSecret, isSecret)
+		})
+	}
+}
@@ -35,7 +35,7 @@ import (
 // if the client to the registry fails to be created, if the manifest fails to be fetched, if the bytes fail to be fetched, or if
 // the data fails to be unmarshalled.
 func ReadFromRegistry(ctx context.Context, definition recipes.EnvironmentDefinition, data *map[string]any, client remote.Client) error {
-	registryRepo, tag, err := parsePath(definition.TemplatePath)
+	registryRepo, tag, err := ParseRegistryPath(definition.TemplatePath)
 	if err != nil {
 		return v1.NewClientErrInvalidRequest(fmt.Sprintf("invalid path %s", err.Error()))
 	}
@@ -125,14 +125,14 @@ func getBytes(ctx context.Context, repo *remote.Repository, layerDigest string)
 	return pulledBlob, nil
 }
 
-// parsePath parses a path in the form of registry/repository:tag. Recipe template
+// ParseRegistryPath parses a path in the form of registry/repository:tag. Recipe template
 // paths may include an http(s):// scheme even though OCI references do not, so a
 // leading scheme is stripped before normalizing (matching the previous parser).
 // A tag is required: TagNameOnly defaults a name-only reference to ":latest", but
 // a digest reference such as repo@sha256:... carries no tag, which the registry is
 // resolved by, so it is rejected here with a descriptive error rather than failing
 // later at resolution time.
-func parsePath(path string) (repository string, tag string, err error) {
+func ParseRegistryPath(path string) (repository string, tag string, err error) {
 	path = strings.TrimPrefix(path, "https://")
 	path = strings.TrimPrefix(path, "http://")
 
@@ -16,6 +16,8 @@ limitations under the License.
 
 package v1
 
+import "time"
+
 // RecipeStatus defines the status of the recipe
 type RecipeStatus struct {
 	// TemplateKind specifies the kind of template used for the recipe.
@@ -26,4 +28,36 @@ type RecipeStatus struct {
 
 	// TemplateVersion specifies the version of the template used for the recipe.
 	TemplateVersion string `json:"templateVersion,omitempty"`
+
+	// Drift specifies the result of the last check for changes made to the resources of the recipe outside of
+	// the recipe. It is nil if drift detection is off for the environment, and reset when the recipe is deployed.
+	Drift *RecipeDriftStatus `json:"drift,omitempty"`
+}
+
+// RecipeDriftStatus defines the result of a check for changes made to the resources of a recipe outside of the recipe.
+type RecipeDriftStatus struct {
+	// Drifted is true if resources of the recipe were changed outside of the recipe.
+	Drifted bool `json:"drifted"`
+
+	// Resources specifies the resources of the recipe that were changed outside of the recipe.
+	Resources []RecipeDriftedResource `json:"resources,omitempty"`
+
+	// LastCheckedTime specifies when the check that found the current result ran. Later checks that find the
+	// same result don't update the resource, so it is not updated on every check.
+	LastCheckedTime time.Time `json:"lastCheckedTime"`
+
+	// RemediationRequestedTime specifies when a deployment of the recipe was requested to revert the changes.
+	RemediationRequestedTime *time.Time `json:"remediationRequestedTime,omitempty"`
+
+	// Message specifies why the last check failed. The other fields are kept from the last successful check.
+	Message string `json:"message,omitempty"`
+}
+
+// RecipeDriftedResource defines a resource of a recipe that was changed outside of the recipe.
+type RecipeDriftedResource struct {
+	// ID specifies the resource ID of the resource, or its address for a Terraform recipe.
+	ID string `json:"id"`
+
+	// Action specifies how the resource was changed: update for a modified resource and delete for a deleted one.
+	Action string `json:"action"`
 }
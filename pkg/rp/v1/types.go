@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	"slices"
 	"strings"
 
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
@@ -118,6 +119,16 @@ func (original ResourceStatus) DeepCopyRecipeStatus() ResourceStatus {
 			TemplatePath:    original.Recipe.TemplatePath,
 			TemplateVersion: original.Recipe.TemplateVersion,
 		}
+
+		if original.Recipe.Drift != nil {
+			drift := *original.Recipe.Drift
+			drift.Resources = slices.Clone(drift.Resources)
+			if drift.RemediationRequestedTime != nil {
+				requested := *drift.RemediationRequestedTime
+				drift.RemediationRequestedTime = &requested
+			}
+			copy.Recipe.Drift = &drift
+		}
 	}
 
 	return copy
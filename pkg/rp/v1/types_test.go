@@ -18,6 +18,7 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -130,3 +131,27 @@ func Test_IsGlobalScopedResource(t *testing.T) {
 	}
 
 }
+
+func Test_DeepCopyRecipeStatus(t *testing.T) {
+	requested := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	original := ResourceStatus{
+		Recipe: &RecipeStatus{
+			TemplateKind: "terraform",
+			TemplatePath: "git::https://example.com/redis.git",
+			Drift: &RecipeDriftStatus{
+				Drifted:                  true,
+				Resources:                []RecipeDriftedResource{{ID: "aws_elasticache_cluster.redis", Action: "update"}},
+				LastCheckedTime:          requested,
+				RemediationRequestedTime: &requested,
+			},
+		},
+	}
+
+	copy := original.DeepCopyRecipeStatus()
+	require.Equal(t, original, copy)
+
+	copy.Recipe.Drift.Resources[0].Action = "delete"
+	*copy.Recipe.Drift.RemediationRequestedTime = requested.Add(time.Hour)
+	require.Equal(t, "update", original.Recipe.Drift.Resources[0].Action)
+	require.Equal(t, requested, *original.Recipe.Drift.RemediationRequestedTime)
+}
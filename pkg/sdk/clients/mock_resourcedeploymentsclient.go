@@ -42,6 +42,7 @@ var _ ResourceDeploymentsClient = (*MockResourceDeploymentsClient)(nil)
 type MockResourceDeploymentsClient struct {
 	resourceDeployments map[string]*ClientCreateOrUpdateResponse
 	operations          map[string]*OperationState
+	whatIfResult        armdeployments.WhatIfOperationResult
 
 	lock *sync.Mutex
 }
@@ -146,6 +147,36 @@ func (rdc *MockResourceDeploymentsClient) ContinueDeleteOperation(ctx context.Co
 	}, nil
 }
 
+// SetWhatIfResult sets the result returned by WhatIf operations. WhatIf operations complete immediately, with an
+// empty result unless one is set.
+func (rdc *MockResourceDeploymentsClient) SetWhatIfResult(result armdeployments.WhatIfOperationResult) {
+	rdc.lock.Lock()
+	defer rdc.lock.Unlock()
+
+	rdc.whatIfResult = result
+}
+
+func (rdc *MockResourceDeploymentsClient) WhatIf(ctx context.Context, parameters Deployment, resourceID, apiVersion string) (Poller[ClientWhatIfResponse], error) {
+	rdc.lock.Lock()
+	defer rdc.lock.Unlock()
+
+	state := &OperationState{
+		Kind:       http.MethodPost,
+		ResourceID: resourceID,
+		Value:      ClientWhatIfResponse{WhatIfOperationResult: rdc.whatIfResult},
+		Complete:   true,
+	}
+
+	operationID := uuid.New().String()
+	rdc.operations[operationID] = state
+
+	return &MockResourceDeploymentsClientPoller[ClientWhatIfResponse]{
+		mock:        rdc,
+		operationID: operationID,
+		state:       state,
+	}, nil
+}
+
 func (rdc *MockResourceDeploymentsClient) GetResource(resourceID string) (*ClientCreateOrUpdateResponse, bool) {
 	resource, ok := rdc.resourceDeployments[resourceID]
 
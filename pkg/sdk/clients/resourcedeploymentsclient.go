@@ -100,6 +100,7 @@ type ResourceDeploymentsClient interface {
 	ContinueCreateOperation(ctx context.Context, resumeToken string) (Poller[ClientCreateOrUpdateResponse], error)
 	Delete(ctx context.Context, resourceID, apiVersion string) (Poller[ClientDeleteResponse], error)
 	ContinueDeleteOperation(ctx context.Context, resumeToken string) (Poller[ClientDeleteResponse], error)
+	WhatIf(ctx context.Context, parameters Deployment, resourceID, apiVersion string) (Poller[ClientWhatIfResponse], error)
 }
 
 type ResourceDeploymentsClientImpl struct {
@@ -145,6 +146,11 @@ type ClientDeleteResponse struct {
 	armdeployments.DeploymentExtended
 }
 
+// ClientWhatIfResponse contains the response from method Client.WhatIf.
+type ClientWhatIfResponse struct {
+	armdeployments.WhatIfOperationResult
+}
+
 // CreateOrUpdate creates a request to create or update a deployment and returns a poller to
 // track the progress of the operation.
 func (client *ResourceDeploymentsClientImpl) CreateOrUpdate(ctx context.Context, parameters Deployment, resourceID, apiVersion string) (Poller[ClientCreateOrUpdateResponse], error) {
@@ -246,3 +252,49 @@ func (client *ResourceDeploymentsClientImpl) deleteCreateRequest(ctx context.Con
 func (client *ResourceDeploymentsClientImpl) ContinueDeleteOperation(ctx context.Context, resumeToken string) (Poller[ClientDeleteResponse], error) {
 	return runtime.NewPollerFromResumeToken[ClientDeleteResponse](resumeToken, *client.pipeline, nil)
 }
+
+// WhatIf creates a request to predict the changes that a deployment would make without deploying it, and returns a
+// poller to track the progress of the operation.
+func (client *ResourceDeploymentsClientImpl) WhatIf(ctx context.Context, parameters Deployment, resourceID, apiVersion string) (Poller[ClientWhatIfResponse], error) {
+	if !strings.HasPrefix(resourceID, "/") {
+		return nil, fmt.Errorf("error predicting the changes of a deployment: resourceID must start with a slash")
+	}
+
+	_, err := resources.ParseResource(resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resourceID: %v", resourceID)
+	}
+
+	req, err := client.whatIfCreateRequest(ctx, resourceID, apiVersion, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.pipeline.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusAccepted) {
+		return nil, runtime.NewResponseError(resp)
+	}
+
+	return runtime.NewPoller[ClientWhatIfResponse](resp, *client.pipeline, nil)
+}
+
+// whatIfCreateRequest creates the WhatIf request.
+func (client *ResourceDeploymentsClientImpl) whatIfCreateRequest(ctx context.Context, resourceID, apiVersion string, parameters Deployment) (*policy.Request, error) {
+	if resourceID == "" {
+		return nil, errors.New("resourceID cannot be empty")
+	}
+
+	urlPath := DeploymentEngineURL(client.baseURI, resourceID) + "/whatIf"
+	req, err := runtime.NewRequest(ctx, http.MethodPost, urlPath)
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", apiVersion)
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, runtime.MarshalAsJSON(req, parameters)
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // NewClientOptions creates a new ARM client options object with the given connection's endpoint, audience, transport and
@@ -37,6 +39,11 @@ func NewClientOptions(connection Connection) *arm.ClientOptions {
 					},
 				},
 			},
+			PerCallPolicies: []policy.Policy{
+				// Propagate the trace context of the caller so that the requests to Radius are part of the same
+				// trace as the operation that made them.
+				&traceparentPolicy{},
+			},
 			PerRetryPolicies: []policy.Policy{
 				// Autorest will inject an empty bearer token, which conflicts with bearer auth
 				// when its used by Kubernetes. We don't *ever* need Autorest to handle auth for us
@@ -68,3 +75,15 @@ func (p *removeAuthorizationHeaderPolicy) Do(req *policy.Request) (*http.Respons
 	delete(req.Raw().Header, "Authorization")
 	return req.Next()
 }
+
+var _ policy.Policy = (*traceparentPolicy)(nil)
+
+type traceparentPolicy struct {
+}
+
+// Do injects the trace context of the request context into the request headers using the global propagator, then
+// sends the request to the next policy.
+func (p *traceparentPolicy) Do(req *policy.Request) (*http.Response, error) {
+	otel.GetTextMapPropagator().Inject(req.Raw().Context(), propagation.HeaderCarrier(req.Raw().Header))
+	return req.Next()
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type recordingTransport struct {
+	request *http.Request
+}
+
+func (t *recordingTransport) Do(req *http.Request) (*http.Response, error) {
+	t.request = req
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: req, Body: http.NoBody}, nil
+}
+
+func Test_traceparentPolicy(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	transport := &recordingTransport{}
+	pipeline := runtime.NewPipeline("test", "v1", runtime.PipelineOptions{}, &policy.ClientOptions{
+		PerCallPolicies: []policy.Policy{&traceparentPolicy{}},
+		Transport:       transport,
+	})
+
+	t.Run("with trace context", func(t *testing.T) {
+		traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		ctx := otel.GetTextMapPropagator().Extract(t.Context(), propagation.MapCarrier{"traceparent": traceparent})
+
+		req, err := runtime.NewRequest(ctx, http.MethodGet, "http://localhost:9443/planes/radius/local")
+		require.NoError(t, err)
+
+		_, err = pipeline.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, traceparent, transport.request.Header.Get("traceparent"))
+	})
+
+	t.Run("without trace context", func(t *testing.T) {
+		req, err := runtime.NewRequest(t.Context(), http.MethodGet, "http://localhost:9443/planes/radius/local")
+		require.NoError(t, err)
+
+		_, err = pipeline.Do(req)
+		require.NoError(t, err)
+		require.Empty(t, transport.request.Header.Get("traceparent"))
+	})
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+
+	"github.com/radius-project/radius/pkg/armrpc/asyncoperation/statusmanager"
+	"github.com/radius-project/radius/pkg/armrpc/hostoptions"
+	aztoken "github.com/radius-project/radius/pkg/azure/tokencredentials"
+	"github.com/radius-project/radius/pkg/components/database/databaseprovider"
+	"github.com/radius-project/radius/pkg/components/queue/queueprovider"
+	corerp_dm "github.com/radius-project/radius/pkg/corerp/datamodel"
+	dapr_dm "github.com/radius-project/radius/pkg/daprrp/datamodel"
+	dapr_ctrl "github.com/radius-project/radius/pkg/daprrp/frontend/controller"
+	ds_dm "github.com/radius-project/radius/pkg/datastoresrp/datamodel"
+	ds_ctrl "github.com/radius-project/radius/pkg/datastoresrp/frontend/controller"
+	msg_dm "github.com/radius-project/radius/pkg/messagingrp/datamodel"
+	msg_ctrl "github.com/radius-project/radius/pkg/messagingrp/frontend/controller"
+	"github.com/radius-project/radius/pkg/portableresources/backend/drift"
+	"github.com/radius-project/radius/pkg/recipes/controllerconfig"
+	"github.com/radius-project/radius/pkg/sdk"
+	"github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+// recipeResourceTypes maps the resource types of the applications-rp that can be deployed by a recipe to
+// their datamodel.
+var recipeResourceTypes = map[string]func() drift.Resource{
+	corerp_dm.ExtenderResourceType:                func() drift.Resource { return &corerp_dm.Extender{} },
+	dapr_ctrl.DaprConfigurationStoresResourceType: func() drift.Resource { return &dapr_dm.DaprConfigurationStore{} },
+	dapr_ctrl.DaprPubSubBrokersResourceType:       func() drift.Resource { return &dapr_dm.DaprPubSubBroker{} },
+	dapr_ctrl.DaprSecretStoresResourceType:        func() drift.Resource { return &dapr_dm.DaprSecretStore{} },
+	dapr_ctrl.DaprStateStoresResourceType:         func() drift.Resource { return &dapr_dm.DaprStateStore{} },
+	ds_ctrl.MongoDatabasesResourceType:            func() drift.Resource { return &ds_dm.MongoDatabase{} },
+	ds_ctrl.RedisCachesResourceType:               func() drift.Resource { return &ds_dm.RedisCache{} },
+	ds_ctrl.SqlDatabasesResourceType:              func() drift.Resource { return &ds_dm.SqlDatabase{} },
+	msg_ctrl.RabbitMQQueuesResourceType:           func() drift.Resource { return &msg_dm.RabbitMQQueue{} },
+}
+
+// RecipeDriftService is a service to periodically check the recipes of the applications-rp resources for drift.
+type RecipeDriftService struct {
+	options hostoptions.HostOptions
+	config  *controllerconfig.RecipeControllerConfig
+}
+
+// NewRecipeDriftService creates a new service to check the recipes of the applications-rp resources for drift.
+func NewRecipeDriftService(options hostoptions.HostOptions, config *controllerconfig.RecipeControllerConfig) *RecipeDriftService {
+	return &RecipeDriftService{
+		options: options,
+		config:  config,
+	}
+}
+
+// Name represents the service name.
+func (s *RecipeDriftService) Name() string {
+	return "radiusrecipedrift"
+}
+
+// Run runs the service. It returns immediately when recipe drift detection is not enabled.
+func (s *RecipeDriftService) Run(ctx context.Context) error {
+	logger := ucplog.FromContextOrDiscard(ctx)
+
+	if !s.options.Config.RecipeDrift.Enabled {
+		logger.Info("Recipe drift detection is disabled")
+		return nil
+	}
+
+	databaseClient, err := databaseprovider.FromOptions(s.options.Config.DatabaseProvider).GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	queueClient, err := queueprovider.New(s.options.Config.QueueProvider).GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	statusManager := statusmanager.New(databaseClient, queueClient, s.options.Config.Env.RoleLocation)
+
+	ucp, err := v20231001preview.NewClientFactory(&aztoken.AnonymousCredential{}, sdk.NewClientOptions(*s.config.UCPConnection))
+	if err != nil {
+		return err
+	}
+
+	controller, err := drift.NewController(s.options.Config.RecipeDrift, databaseClient, statusManager, s.config.Engine, s.config.ConfigLoader,
+		func(ctx context.Context) ([]drift.ResourceType, error) {
+			return listRecipeResourceTypes(ctx, ucp)
+		})
+	if err != nil {
+		return err
+	}
+
+	return controller.Start(ctx)
+}
+
+// listRecipeResourceTypes lists the resource types of the applications-rp that can be deployed by a recipe, for
+// every Radius plane.
+func listRecipeResourceTypes(ctx context.Context, ucp *v20231001preview.ClientFactory) ([]drift.ResourceType, error) {
+	resourceTypes := []drift.ResourceType{}
+
+	planes := ucp.NewRadiusPlanesClient().NewListPager(nil)
+	for planes.More() {
+		page, err := planes.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, plane := range page.Value {
+			if plane == nil || plane.Name == nil {
+				continue
+			}
+
+			for resourceType, newResource := range recipeResourceTypes {
+				resourceTypes = append(resourceTypes, drift.ResourceType{
+					Plane: *plane.Name,
+					Type:  resourceType,
+					New:   newResource,
+				})
+			}
+		}
+	}
+
+	return resourceTypes, nil
+}
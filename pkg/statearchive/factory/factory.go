@@ -23,9 +23,13 @@ import (
 	"os"
 	"strings"
 
+	"github.com/radius-project/radius/pkg/crypto/encryption"
 	"github.com/radius-project/radius/pkg/statearchive"
+	archivefile "github.com/radius-project/radius/pkg/statearchive/file"
 	archivegit "github.com/radius-project/radius/pkg/statearchive/git"
 	archiveoci "github.com/radius-project/radius/pkg/statearchive/oci"
+	archives3 "github.com/radius-project/radius/pkg/statearchive/s3"
+	"github.com/radius-project/radius/pkg/statearchive/sealed"
 )
 
 const (
@@ -35,20 +39,34 @@ const (
 	// ArchivePlainHTTPEnvVar enables HTTP for a local OCI registry.
 	ArchivePlainHTTPEnvVar = "RADIUS_ARCHIVE_PLAIN_HTTP"
 
+	// ArchiveS3EndpointEnvVar sets the endpoint of an S3-compatible server, for
+	// example a local MinIO instance. It is unset for AWS S3.
+	ArchiveS3EndpointEnvVar = "RADIUS_ARCHIVE_S3_ENDPOINT"
+
 	// StateRegistryEnvVar configures the OCI repository used by rad startup and shutdown.
 	StateRegistryEnvVar = "RADIUS_STATE_REGISTRY"
 
 	// GraphRegistryEnvVar configures the OCI repository used by modeled graph output.
 	GraphRegistryEnvVar = "RADIUS_GRAPH_REGISTRY"
+
+	// StateEncryptionKeysEnvVar is the path of a versioned key store file (the keys.json
+	// format of the radius-encryption-key Secret). When it is set, rad startup and shutdown
+	// encrypt the state archive with those keys and reject snapshots that fail verification.
+	StateEncryptionKeysEnvVar = "RADIUS_STATE_ENCRYPTION_KEYS"
 )
 
 // NewStateArchive returns the archive for rad startup and rad shutdown. OCI is
 // the default: when BackendEnvVar is unset, OCI is selected even without a
 // registry, so a missing RADIUS_STATE_REGISTRY surfaces as a configuration
 // error from Archive.Open rather than silently falling back to git. Set
-// BackendEnvVar to "git" to opt into the git backend.
+// BackendEnvVar to "git" to opt into the git backend. When StateEncryptionKeysEnvVar
+// is set, the archive is sealed with the keys from that file.
 func NewStateArchive(registry string) statearchive.Archive {
-	return newFromEnvironment(registry, true)
+	archive := newFromEnvironment(registry, true)
+	if keys := os.Getenv(StateEncryptionKeysEnvVar); keys != "" {
+		return sealed.NewSealedArchive(archive, encryption.NewFileKeyProvider(keys))
+	}
+	return archive
 }
 
 // NewGraphArchive returns the archive for modeled graph output. OCI is selected
@@ -60,16 +78,23 @@ func NewGraphArchive(registry string) statearchive.Archive {
 }
 
 // newFromEnvironment selects the archive implementation. BackendEnvVar overrides
-// the default: "git" always selects git and "oci" always selects OCI. When it is
-// unset, OCI is selected if a registry is configured; when no registry is set,
-// ociDefaultWhenUnset decides between OCI (state commands, so the missing
-// registry is reported) and git (graph output, which keeps a zero-config
-// fallback).
+// the default: "git", "oci", "file" and "s3" always select that backend, and the
+// registry value is the location for the file ("file:///path" or a plain path)
+// and S3 ("s3://bucket/prefix") backends. When it is unset, a "file://" or
+// "s3://" registry selects the matching backend and any other registry selects
+// OCI; when no registry is set, ociDefaultWhenUnset decides between OCI (state
+// commands, so the missing registry is reported) and git (graph output, which
+// keeps a zero-config fallback).
 func newFromEnvironment(registry string, ociDefaultWhenUnset bool) statearchive.Archive {
 	backend := strings.ToLower(os.Getenv(BackendEnvVar))
 	switch backend {
 	case "":
-		if registry != "" || ociDefaultWhenUnset {
+		switch {
+		case strings.HasPrefix(registry, archivefile.URLScheme):
+			return newFileArchive(registry)
+		case strings.HasPrefix(registry, archives3.URLScheme):
+			return newS3Archive(registry)
+		case registry != "" || ociDefaultWhenUnset:
 			return newOCIArchive(registry)
 		}
 		return archivegit.NewGitArchive()
@@ -77,11 +102,32 @@ func newFromEnvironment(registry string, ociDefaultWhenUnset bool) statearchive.
 		return archivegit.NewGitArchive()
 	case "oci":
 		return newOCIArchive(registry)
+	case "file":
+		return newFileArchive(registry)
+	case "s3":
+		return newS3Archive(registry)
 	default:
-		return errorArchive{err: fmt.Errorf("invalid %s value %q: expected git or oci", BackendEnvVar, backend)}
+		return errorArchive{err: fmt.Errorf("invalid %s value %q: expected git, oci, file or s3", BackendEnvVar, backend)}
 	}
 }
 
+func newFileArchive(location string) statearchive.Archive {
+	return archivefile.NewFileArchive(archivefile.Options{Root: location})
+}
+
+func newS3Archive(location string) statearchive.Archive {
+	options := archives3.Options{}
+	if location != "" {
+		var err error
+		options, err = archives3.ParseLocation(location)
+		if err != nil {
+			return errorArchive{err: err}
+		}
+	}
+	options.Endpoint = os.Getenv(ArchiveS3EndpointEnvVar)
+	return archives3.NewS3Archive(options)
+}
+
 func newOCIArchive(registry string) statearchive.Archive {
 	return archiveoci.NewOCIArchive(archiveoci.Options{
 		Repository: registry,
@@ -97,4 +143,12 @@ func (a errorArchive) Open(context.Context, string) (statearchive.Session, error
 	return nil, a.err
 }
 
+func (a errorArchive) History(context.Context, string) ([]statearchive.Snapshot, error) {
+	return nil, a.err
+}
+
+func (a errorArchive) OpenRevision(context.Context, string, string) (statearchive.Session, error) {
+	return nil, a.err
+}
+
 var _ statearchive.Archive = errorArchive{}
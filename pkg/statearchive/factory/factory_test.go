@@ -19,7 +19,10 @@ package factory
 import (
 	"testing"
 
+	"github.com/radius-project/radius/pkg/statearchive/file"
 	"github.com/radius-project/radius/pkg/statearchive/oci"
+	archives3 "github.com/radius-project/radius/pkg/statearchive/s3"
+	"github.com/radius-project/radius/pkg/statearchive/sealed"
 	"github.com/stretchr/testify/require"
 )
 
@@ -108,3 +111,69 @@ func TestNewStateArchive_InvalidBackendFailsOnOpen(t *testing.T) {
 	_, err := archive.Open(t.Context(), "radius-state")
 	require.ErrorContains(t, err, "invalid "+BackendEnvVar)
 }
+
+func TestNewStateArchive_FileLocationSelectsFile(t *testing.T) {
+	t.Setenv(BackendEnvVar, "")
+
+	archive := NewStateArchive("file://" + t.TempDir())
+	require.IsType(t, &file.FileArchive{}, archive)
+}
+
+func TestNewStateArchive_ExplicitFileUsesPlainPath(t *testing.T) {
+	t.Setenv(BackendEnvVar, "file")
+	root := t.TempDir()
+
+	archive := NewStateArchive(root)
+	require.IsType(t, &file.FileArchive{}, archive)
+
+	session, err := archive.Open(t.Context(), "radius-state")
+	require.NoError(t, err)
+	session.Close(t.Context())
+}
+
+func TestNewStateArchive_ExplicitFileWithoutLocationFailsOnOpen(t *testing.T) {
+	t.Setenv(BackendEnvVar, "file")
+
+	archive := NewStateArchive("")
+	_, err := archive.Open(t.Context(), "radius-state")
+	require.ErrorContains(t, err, "root directory is not configured")
+}
+
+func TestNewGraphArchive_S3LocationSelectsS3(t *testing.T) {
+	t.Setenv(BackendEnvVar, "")
+
+	archive := NewGraphArchive("s3://radius-ci/graphs")
+	require.IsType(t, &archives3.S3Archive{}, archive)
+}
+
+func TestNewStateArchive_ExplicitS3WithoutBucketFailsOnOpen(t *testing.T) {
+	t.Setenv(BackendEnvVar, "s3")
+
+	archive := NewStateArchive("")
+	_, err := archive.Open(t.Context(), "radius-state")
+	require.ErrorContains(t, err, "bucket is not configured")
+}
+
+func TestNewStateArchive_ExplicitS3WithInvalidLocationFailsOnOpen(t *testing.T) {
+	t.Setenv(BackendEnvVar, "s3")
+
+	archive := NewStateArchive("localhost:5000/radius-state")
+	_, err := archive.Open(t.Context(), "radius-state")
+	require.ErrorContains(t, err, "invalid S3 archive location")
+}
+
+func TestNewStateArchive_EncryptionKeysSealArchive(t *testing.T) {
+	t.Setenv(BackendEnvVar, "")
+	t.Setenv(StateEncryptionKeysEnvVar, "/etc/radius/keys.json")
+
+	archive := NewStateArchive("file://" + t.TempDir())
+	require.IsType(t, &sealed.SealedArchive{}, archive)
+}
+
+func TestNewGraphArchive_IgnoresEncryptionKeys(t *testing.T) {
+	t.Setenv(BackendEnvVar, "")
+	t.Setenv(StateEncryptionKeysEnvVar, "/etc/radius/keys.json")
+
+	archive := NewGraphArchive("file://" + t.TempDir())
+	require.IsType(t, &file.FileArchive{}, archive)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package file implements the statearchive.Archive interface on a plain local
+// directory. Every Commit is kept as a versioned snapshot under the root
+// directory, so the backend works in air-gapped environments that have neither
+// an OCI registry nor a writable git remote. The directory may be a mounted
+// network or CI cache volume.
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/radius-project/radius/pkg/statearchive"
+	"github.com/radius-project/radius/pkg/statearchive/snapshot"
+)
+
+// URLScheme is the prefix that selects the file backend in an archive location,
+// for example "file:///var/lib/radius-state".
+const URLScheme = "file://"
+
+// Options configures a FileArchive.
+type Options struct {
+	// Root is the directory that holds the archive snapshots. A "file://" prefix
+	// is accepted and stripped.
+	Root string
+}
+
+// FileArchive is a statearchive.Archive backed by a local directory.
+type FileArchive struct {
+	*snapshot.Archive
+	root string
+}
+
+// NewFileArchive returns a filesystem-backed state archive.
+func NewFileArchive(options Options) *FileArchive {
+	root := strings.TrimPrefix(options.Root, URLScheme)
+	if root != "" {
+		if abs, err := filepath.Abs(root); err == nil {
+			root = abs
+		}
+	}
+	return &FileArchive{
+		Archive: snapshot.NewArchive(URLScheme+root, &store{root: root}),
+		root:    root,
+	}
+}
+
+// Open materializes the latest snapshot of the archive name.
+func (a *FileArchive) Open(ctx context.Context, name string) (statearchive.Session, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	return a.Archive.Open(ctx, name)
+}
+
+// History lists the snapshots of the archive name, newest first.
+func (a *FileArchive) History(ctx context.Context, name string) ([]statearchive.Snapshot, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	return a.Archive.History(ctx, name)
+}
+
+// OpenRevision materializes the snapshot id of the archive name.
+func (a *FileArchive) OpenRevision(ctx context.Context, name string, id string) (statearchive.Session, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	return a.Archive.OpenRevision(ctx, name, id)
+}
+
+func (a *FileArchive) validate() error {
+	if a.root == "" {
+		return errors.New("file archive root directory is not configured; set RADIUS_STATE_REGISTRY or RADIUS_GRAPH_REGISTRY to a file:// path")
+	}
+	return nil
+}
+
+// store implements snapshot.Store with one file per key under root.
+type store struct {
+	root string
+}
+
+// Get opens the file stored at key.
+func (s *store) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := snapshot.SafePath(s.root, key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, snapshot.ErrNotFound
+	}
+	return file, err
+}
+
+// Put writes data to a temporary file next to key and renames it into place, so
+// readers never observe a partially written object.
+func (s *store) Put(_ context.Context, key string, data io.Reader) error {
+	path, err := snapshot.SafePath(s.root, key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %q: %w", key, err)
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), ".radius-tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %q: %w", key, err)
+	}
+	_, copyErr := io.Copy(temp, data)
+	syncErr := temp.Sync()
+	closeErr := temp.Close()
+	if err := errors.Join(copyErr, syncErr, closeErr); err != nil {
+		_ = os.Remove(temp.Name())
+		return fmt.Errorf("failed to write %q: %w", key, err)
+	}
+	if err := os.Rename(temp.Name(), path); err != nil {
+		_ = os.Remove(temp.Name())
+		return fmt.Errorf("failed to write %q: %w", key, err)
+	}
+	return nil
+}
+
+var (
+	_ statearchive.Archive = (*FileArchive)(nil)
+	_ snapshot.Store       = (*store)(nil)
+)
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corerpv20250801preview "github.com/radius-project/radius/pkg/corerp/api/v20250801preview"
+	"github.com/radius-project/radius/pkg/graph/persistence"
+	graphstore "github.com/radius-project/radius/pkg/graph/persistence/git"
+	"github.com/radius-project/radius/pkg/to"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileArchive_CommitRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	archive := NewFileArchive(Options{Root: URLScheme + root})
+	ctx := t.Context()
+
+	session, err := archive.Open(ctx, "radius-state")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(session.Path(), "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(session.Path(), "nested", "state.txt"), []byte("saved state"), 0o644))
+	require.NoError(t, session.Commit(ctx, "radius: backup"))
+	session.Close(ctx)
+
+	latest, err := os.ReadFile(filepath.Join(root, "radius-state", "latest"))
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(root, "radius-state", "snapshots", string(latest), "files", "nested", "state.txt"))
+
+	session, err = archive.Open(ctx, "radius-state")
+	require.NoError(t, err)
+	t.Cleanup(func() { session.Close(context.Background()) }) //nolint:usetesting
+
+	data, err := os.ReadFile(filepath.Join(session.Path(), "nested", "state.txt"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("saved state"), data)
+}
+
+func TestFileArchive_OpenWithoutRootFails(t *testing.T) {
+	archive := NewFileArchive(Options{})
+
+	_, err := archive.Open(t.Context(), "radius-state")
+	require.ErrorContains(t, err, "root directory is not configured")
+}
+
+func TestFileArchive_UsesFileStorageForGraphs(t *testing.T) {
+	archive := NewFileArchive(Options{Root: t.TempDir()})
+	store, err := graphstore.NewStore(graphstore.Options{Archive: archive})
+	require.NoError(t, err)
+
+	key := persistence.Key{Namespace: "main", Name: "app"}
+	graph := &corerpv20250801preview.ApplicationGraphResponse{
+		Resources: []*corerpv20250801preview.ApplicationGraphResource{
+			{
+				Name: to.Ptr("frontend"),
+				Type: to.Ptr("Applications.Core/containers"),
+			},
+		},
+	}
+	require.NoError(t, store.Save(t.Context(), key, graph, persistence.SaveOptions{}))
+
+	got, err := store.Load(t.Context(), key)
+	require.NoError(t, err)
+	require.Len(t, got.Resources, 1)
+	require.Equal(t, "frontend", *got.Resources[0].Name)
+}
+
+func TestStore_PutReplacesAtomically(t *testing.T) {
+	root := t.TempDir()
+	store := &store{root: root}
+
+	require.NoError(t, store.Put(t.Context(), "a/b", strings.NewReader("one")))
+	require.NoError(t, store.Put(t.Context(), "a/b", strings.NewReader("two")))
+
+	data, err := os.ReadFile(filepath.Join(root, "a", "b"))
+	require.NoError(t, err)
+	require.Equal(t, "two", string(data))
+
+	entries, err := os.ReadDir(filepath.Join(root, "a"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files must not be left behind")
+}
+
+func TestStore_RejectsUnsafeKeys(t *testing.T) {
+	store := &store{root: t.TempDir()}
+
+	err := store.Put(t.Context(), "../escape", strings.NewReader("state"))
+	require.ErrorContains(t, err, "invalid archive path")
+}
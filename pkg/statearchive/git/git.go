@@ -37,6 +37,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -102,18 +103,8 @@ func (b *GitArchive) Open(ctx context.Context, branch string) (statearchive.Sess
 		return nil, fmt.Errorf("failed to determine git repo root: %w", err)
 	}
 
-	// If the branch exists on the remote, fetching it must succeed. Silently falling back to an
-	// empty or stale local branch would make a later restore use the wrong state, so a fetch
-	// failure (network, credentials) is fatal when the remote is known to hold the branch.
-	if hasRemote(ctx, root) && remoteHasBranch(ctx, root, branch) {
-		logger.Info("Fetching remote state branch", "branch", branch)
-		if err := gitExecIn(ctx, root, "fetch", remoteName, branch); err != nil {
-			return nil, fmt.Errorf("failed to fetch state branch %q from %q: %w", branch, remoteName, err)
-		}
-		// Force the local branch to match the remote so a stale local branch cannot shadow it.
-		if err := gitExecIn(ctx, root, "branch", "--force", branch, remoteName+"/"+branch); err != nil {
-			return nil, fmt.Errorf("failed to sync local branch %q to remote: %w", branch, err)
-		}
+	if err := syncBranch(ctx, root, branch); err != nil {
+		return nil, err
 	}
 
 	if !branchExists(ctx, root, branch) {
@@ -139,12 +130,119 @@ func (b *GitArchive) Open(ctx context.Context, branch string) (statearchive.Sess
 	}, nil
 }
 
+// History lists the commits of the orphan branch named branch, newest first. The empty commit
+// that initializes the branch is not a snapshot and is omitted.
+func (b *GitArchive) History(ctx context.Context, branch string) ([]statearchive.Snapshot, error) {
+	lock := lockForBranch(branch)
+	lock.Lock()
+	defer lock.Unlock()
+
+	root, err := repoRoot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine git repo root: %w", err)
+	}
+	if err := syncBranch(ctx, root, branch); err != nil {
+		return nil, err
+	}
+	if !branchExists(ctx, root, branch) {
+		return []statearchive.Snapshot{}, nil
+	}
+
+	out, err := gitOutputIn(ctx, root, "log", "--format=%H%x1f%T%x1f%cI%x1f%s", "refs/heads/"+branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of state branch %q: %w", branch, err)
+	}
+
+	snapshots := []statearchive.Snapshot{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 4 || fields[1] == emptyTreeSHA {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid commit time %q for %s: %w", fields[2], fields[0], err)
+		}
+		size, err := treeSize(ctx, root, fields[0])
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, statearchive.Snapshot{
+			ID:      fields[0],
+			Created: created,
+			Message: fields[3],
+			Size:    size,
+		})
+	}
+	return snapshots, nil
+}
+
+// OpenRevision checks the commit id of the orphan branch named branch out into a temporary,
+// detached worktree. The commit must be part of the branch history, so a revision from an
+// unrelated branch cannot be restored by mistake. The returned Session is read-only.
+func (b *GitArchive) OpenRevision(ctx context.Context, branch string, id string) (statearchive.Session, error) {
+	if id == "" {
+		return nil, errors.New("snapshot ID must not be empty")
+	}
+	// Reject anything git could parse as an option rather than a revision.
+	if strings.HasPrefix(id, "-") {
+		return nil, fmt.Errorf("invalid snapshot ID %q: %w", id, statearchive.ErrSnapshotNotFound)
+	}
+
+	lock := lockForBranch(branch)
+	lock.Lock()
+	unlockOnErr := true
+	defer func() {
+		if unlockOnErr {
+			lock.Unlock()
+		}
+	}()
+
+	root, err := repoRoot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine git repo root: %w", err)
+	}
+	if err := syncBranch(ctx, root, branch); err != nil {
+		return nil, err
+	}
+	if !branchExists(ctx, root, branch) {
+		return nil, fmt.Errorf("snapshot %q of state branch %q: %w", id, branch, statearchive.ErrSnapshotNotFound)
+	}
+
+	commit, err := gitOutputIn(ctx, root, "rev-parse", "--verify", "--quiet", id+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %q of state branch %q: %w", id, branch, statearchive.ErrSnapshotNotFound)
+	}
+	commit = strings.TrimSpace(commit)
+	if err := gitExecIn(ctx, root, "merge-base", "--is-ancestor", commit, "refs/heads/"+branch); err != nil {
+		return nil, fmt.Errorf("snapshot %q of state branch %q: %w", id, branch, statearchive.ErrSnapshotNotFound)
+	}
+
+	wtPath := filepath.Join(os.TempDir(), fmt.Sprintf("radius-state-%d", time.Now().UnixNano()))
+	ucplog.FromContextOrDiscard(ctx).Info("Adding detached git worktree", "path", wtPath, "branch", branch, "commit", commit)
+	if err := gitExecIn(ctx, root, "worktree", "add", "--detach", wtPath, commit); err != nil {
+		return nil, fmt.Errorf("failed to add worktree: %w", err)
+	}
+
+	unlockOnErr = false
+	return &session{
+		path:     wtPath,
+		branch:   branch,
+		repoRoot: root,
+		readOnly: true,
+		unlock:   lock.Unlock,
+	}, nil
+}
+
 // session is a storage.Session backed by a git worktree checked out to an orphan branch.
 type session struct {
 	path     string
 	branch   string
 	repoRoot string
 
+	// readOnly is set for sessions checked out at a past revision.
+	readOnly bool
+
 	unlock   func()
 	unlocked bool
 }
@@ -159,6 +257,10 @@ func (s *session) Path() string { return s.path }
 // remote is configured (local development, tests), the commit alone is sufficient and the missing
 // remote is not an error. Committing with no staged changes is a no-op.
 func (s *session) Commit(ctx context.Context, message string) error {
+	if s.readOnly {
+		return statearchive.ErrReadOnlySession
+	}
+
 	logger := ucplog.FromContextOrDiscard(ctx)
 
 	if err := gitExecIn(ctx, s.path, "add", "-A"); err != nil {
@@ -238,6 +340,53 @@ func gitConfigValue(ctx context.Context, dir, key string) string {
 	return strings.TrimSpace(string(out))
 }
 
+// syncBranch fetches branch from the remote when the remote holds it. If the branch exists on the
+// remote, fetching it must succeed. Silently falling back to an empty or stale local branch would
+// make a later restore use the wrong state, so a fetch failure (network, credentials) is fatal
+// when the remote is known to hold the branch.
+func syncBranch(ctx context.Context, root, branch string) error {
+	if !hasRemote(ctx, root) || !remoteHasBranch(ctx, root, branch) {
+		return nil
+	}
+
+	ucplog.FromContextOrDiscard(ctx).Info("Fetching remote state branch", "branch", branch)
+	if err := gitExecIn(ctx, root, "fetch", remoteName, branch); err != nil {
+		return fmt.Errorf("failed to fetch state branch %q from %q: %w", branch, remoteName, err)
+	}
+	// Force the local branch to match the remote so a stale local branch cannot shadow it.
+	if err := gitExecIn(ctx, root, "branch", "--force", branch, remoteName+"/"+branch); err != nil {
+		return fmt.Errorf("failed to sync local branch %q to remote: %w", branch, err)
+	}
+	return nil
+}
+
+// treeSize returns the total size in bytes of the blobs in the tree of commit.
+func treeSize(ctx context.Context, root, commit string) (int64, error) {
+	out, err := gitOutputIn(ctx, root, "ls-tree", "-r", "-l", commit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read tree of %s: %w", commit, err)
+	}
+
+	var size int64
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		// Each line is "<mode> <type> <object> <size>\t<path>".
+		meta, _, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 4 || fields[1] != "blob" {
+			continue
+		}
+		blobSize, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid blob size %q in tree of %s: %w", fields[3], commit, err)
+		}
+		size += blobSize
+	}
+	return size, nil
+}
+
 // hasRemote reports whether a remote named origin is configured.
 func hasRemote(ctx context.Context, root string) bool {
 	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", remoteName)
@@ -301,6 +450,19 @@ func repoRoot(ctx context.Context) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// gitOutputIn runs a git command with its working directory set to dir and returns its stdout.
+func gitOutputIn(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
 // gitExecIn runs a git command with its working directory set to dir.
 func gitExecIn(ctx context.Context, dir string, args ...string) error {
 	cmd := exec.CommandContext(ctx, "git", args...)
@@ -20,6 +20,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -396,3 +397,62 @@ func TestOpen_SerializesSessionsPerBranch(t *testing.T) {
 		t.Fatal("second Open on the same branch did not proceed after the first session was closed")
 	}
 }
+
+func TestHistory_ListsCommitsAndOpenRevisionRestoresThem(t *testing.T) {
+	root := initTestRepo(t)
+	chdir(t, root)
+
+	ctx := t.Context()
+	b := NewGitArchive()
+	branch := "radius-state-test"
+
+	history, err := b.History(ctx, branch)
+	require.NoError(t, err)
+	require.Empty(t, history, "a missing branch has no snapshots")
+
+	for _, content := range []string{"first-dump", "second-dump!"} {
+		s, err := b.Open(ctx, branch)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(s.Path(), "ucp.sql"), []byte(content), 0o644))
+		require.NoError(t, s.Commit(ctx, "radius: backup "+content))
+		s.Close(ctx)
+	}
+
+	history, err = b.History(ctx, branch)
+	require.NoError(t, err)
+	require.Len(t, history, 2, "the empty init commit is not a snapshot")
+	require.Equal(t, "radius: backup second-dump!", history[0].Message)
+	require.Equal(t, int64(len("second-dump!")), history[0].Size)
+	require.Equal(t, "radius: backup first-dump", history[1].Message)
+	require.False(t, history[1].Created.IsZero())
+
+	s, err := b.OpenRevision(ctx, branch, history[1].ID)
+	require.NoError(t, err)
+	defer s.Close(ctx)
+
+	data, err := os.ReadFile(filepath.Join(s.Path(), "ucp.sql"))
+	require.NoError(t, err)
+	require.Equal(t, "first-dump", string(data))
+	require.ErrorIs(t, s.Commit(ctx, "radius: backup"), statearchive.ErrReadOnlySession)
+}
+
+func TestOpenRevision_RejectsUnknownAndForeignCommits(t *testing.T) {
+	root := initTestRepo(t)
+	chdir(t, root)
+
+	ctx := t.Context()
+	b := NewGitArchive()
+	branch := "radius-state-test"
+
+	s, err := b.Open(ctx, branch)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(s.Path(), "ucp.sql"), []byte("dump"), 0o644))
+	require.NoError(t, s.Commit(ctx, "radius: backup"))
+	s.Close(ctx)
+
+	mainCommit := strings.TrimSpace(runGit(t, root, "git", "rev-parse", "main"))
+	for _, id := range []string{"0000000000000000000000000000000000000000", mainCommit, "--help"} {
+		_, err := b.OpenRevision(ctx, branch, id)
+		require.ErrorIs(t, err, statearchive.ErrSnapshotNotFound, id)
+	}
+}
@@ -40,6 +40,45 @@ func (m *MockArchive) EXPECT() *MockArchiveMockRecorder {
 	return m.recorder
 }
 
+// History mocks base method.
+func (m *MockArchive) History(ctx context.Context, name string) ([]Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, name)
+	ret0, _ := ret[0].([]Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockArchiveMockRecorder) History(ctx, name any) *MockArchiveHistoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockArchive)(nil).History), ctx, name)
+	return &MockArchiveHistoryCall{Call: call}
+}
+
+// MockArchiveHistoryCall wrap *gomock.Call
+type MockArchiveHistoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockArchiveHistoryCall) Return(arg0 []Snapshot, arg1 error) *MockArchiveHistoryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockArchiveHistoryCall) Do(f func(context.Context, string) ([]Snapshot, error)) *MockArchiveHistoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockArchiveHistoryCall) DoAndReturn(f func(context.Context, string) ([]Snapshot, error)) *MockArchiveHistoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Open mocks base method.
 func (m *MockArchive) Open(ctx context.Context, name string) (Session, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +118,45 @@ func (c *MockArchiveOpenCall) DoAndReturn(f func(context.Context, string) (Sessi
 	return c
 }
 
+// OpenRevision mocks base method.
+func (m *MockArchive) OpenRevision(ctx context.Context, name, id string) (Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenRevision", ctx, name, id)
+	ret0, _ := ret[0].(Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenRevision indicates an expected call of OpenRevision.
+func (mr *MockArchiveMockRecorder) OpenRevision(ctx, name, id any) *MockArchiveOpenRevisionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenRevision", reflect.TypeOf((*MockArchive)(nil).OpenRevision), ctx, name, id)
+	return &MockArchiveOpenRevisionCall{Call: call}
+}
+
+// MockArchiveOpenRevisionCall wrap *gomock.Call
+type MockArchiveOpenRevisionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockArchiveOpenRevisionCall) Return(arg0 Session, arg1 error) *MockArchiveOpenRevisionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockArchiveOpenRevisionCall) Do(f func(context.Context, string, string) (Session, error)) *MockArchiveOpenRevisionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockArchiveOpenRevisionCall) DoAndReturn(f func(context.Context, string, string) (Session, error)) *MockArchiveOpenRevisionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // MockSession is a mock of Session interface.
 type MockSession struct {
 	ctrl     *gomock.Controller
@@ -29,7 +29,9 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -41,6 +43,7 @@ import (
 	"oras.land/oras-go/v2/content"
 	filecontent "oras.land/oras-go/v2/content/file"
 	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
 	credentials "oras.land/oras-go/v2/registry/remote/credentials"
@@ -54,8 +57,22 @@ const (
 	layerMediaType         = "application/vnd.radius.statearchive.layer.v1.tar+gzip"
 	configMediaType        = "application/vnd.radius.statearchive.config.v1+json"
 	visibilityBootstrapTag = "radius-visibility-bootstrap"
+
+	// snapshotTagInfix separates the archive name from the snapshot ID in the
+	// immutable per-commit tags, for example
+	// "radius-state-snapshot-20260101T000000.000000000Z".
+	snapshotTagInfix = "-snapshot-"
+
+	// snapshotIDFormat formats snapshot IDs so that they sort in creation order.
+	snapshotIDFormat = "20060102T150405.000000000Z"
+
+	annotationMessage = "io.radapp.statearchive.message"
+	annotationSize    = "io.radapp.statearchive.size"
 )
 
+// snapshotIDPattern matches the characters allowed in an OCI tag suffix.
+var snapshotIDPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+
 var archiveLocks sync.Map // repository and archive name -> *sync.Mutex
 
 // Options configures an OCIArchive.
@@ -89,6 +106,72 @@ func NewOCIArchive(options Options) *OCIArchive {
 
 // Open materializes the archive tagged name in a temporary directory.
 func (a *OCIArchive) Open(ctx context.Context, name string) (statearchive.Session, error) {
+	return a.open(ctx, name, "")
+}
+
+// OpenRevision materializes a past snapshot of the archive name in a temporary,
+// read-only directory. id is either a snapshot ID returned by History or a
+// manifest digest such as "sha256:...".
+func (a *OCIArchive) OpenRevision(ctx context.Context, name string, id string) (statearchive.Session, error) {
+	if id == "" {
+		return nil, errors.New("snapshot ID must not be empty")
+	}
+	reference := id
+	if _, err := digest.Parse(id); err != nil {
+		if !snapshotIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("invalid snapshot ID %q: %w", id, statearchive.ErrSnapshotNotFound)
+		}
+		reference = snapshotTag(name, id)
+	}
+	return a.open(ctx, name, reference)
+}
+
+// History lists the snapshot tags of the archive name, newest first. Every
+// Commit that uploads new state also pushes an immutable snapshot tag whose
+// manifest carries the commit time, message and size as annotations.
+func (a *OCIArchive) History(ctx context.Context, name string) ([]statearchive.Snapshot, error) {
+	if name == "" {
+		return nil, errors.New("OCI archive name must not be empty")
+	}
+
+	target, err := a.newTarget(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := target.(registry.TagLister)
+	if !ok {
+		return nil, fmt.Errorf("OCI archive repository %q does not support listing tags", a.options.Repository)
+	}
+
+	prefix := snapshotTag(name, "")
+	var tags []string
+	err = lister.Tags(ctx, "", func(page []string) error {
+		for _, tag := range page {
+			if strings.HasPrefix(tag, prefix) {
+				tags = append(tags, tag)
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errdef.ErrNotFound) {
+		return nil, fmt.Errorf("failed to list snapshots of OCI archive %q: %w", name, err)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(tags)))
+
+	snapshots := make([]statearchive.Snapshot, 0, len(tags))
+	for _, tag := range tags {
+		snapshot, err := readSnapshot(ctx, target, tag, strings.TrimPrefix(tag, prefix))
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// open materializes the manifest referenced by revision, or the tag name when
+// revision is empty.
+func (a *OCIArchive) open(ctx context.Context, name string, revision string) (statearchive.Session, error) {
 	if name == "" {
 		return nil, errors.New("OCI archive name must not be empty")
 	}
@@ -126,17 +209,26 @@ func (a *OCIArchive) Open(ctx context.Context, name string) (statearchive.Sessio
 		repository:             a.options.Repository,
 		target:                 target,
 		checkPackageVisibility: a.checkPackageVisibility,
+		readOnly:               revision != "",
+		now:                    time.Now,
 		unlock:                 lock.Unlock,
 	}
 
-	desc, err := target.Resolve(ctx, name)
+	reference := name
+	if revision != "" {
+		reference = revision
+	}
+	desc, err := target.Resolve(ctx, reference)
 	if err != nil {
 		if errors.Is(err, errdef.ErrNotFound) {
+			if revision != "" {
+				return nil, fmt.Errorf("snapshot %q of OCI archive %q: %w", revision, name, statearchive.ErrSnapshotNotFound)
+			}
 			unlockOnError = false
 			removePathOnError = false
 			return session, nil
 		}
-		return nil, fmt.Errorf("failed to resolve OCI archive %q: %w", name, err)
+		return nil, fmt.Errorf("failed to resolve OCI archive %q: %w", reference, err)
 	}
 	session.manifestDigest = desc.Digest
 
@@ -188,6 +280,10 @@ type session struct {
 	manifestDigest         digest.Digest
 	checkPackageVisibility packageVisibilityChecker
 
+	// readOnly is set for sessions opened at a past snapshot.
+	readOnly bool
+	now      func() time.Time
+
 	unlock   func()
 	unlocked bool
 }
@@ -197,8 +293,13 @@ func (s *session) Path() string {
 	return s.path
 }
 
-// Commit writes the session directory to the configured OCI repository.
-func (s *session) Commit(ctx context.Context, _ string) error {
+// Commit writes the session directory to the configured OCI repository and
+// records it as a new snapshot.
+func (s *session) Commit(ctx context.Context, message string) error {
+	if s.readOnly {
+		return statearchive.ErrReadOnlySession
+	}
+
 	artifact, err := createArtifact(ctx, s.name, s.path)
 	if err != nil {
 		return err
@@ -231,6 +332,47 @@ func (s *session) Commit(ctx context.Context, _ string) error {
 		return fmt.Errorf("failed to push OCI archive %q: %w", s.name, err)
 	}
 	s.manifestDigest = artifact.manifestDesc.Digest
+
+	// The state is durable at this point, so a failure to record the snapshot
+	// only loses history and must not fail the Commit.
+	if err := s.pushSnapshot(ctx, artifact, message); err != nil {
+		ucplog.FromContextOrDiscard(ctx).Error(err, "Failed to record OCI archive snapshot", "archive", s.name)
+	}
+	return nil
+}
+
+// pushSnapshot tags an annotated copy of the artifact manifest with an
+// immutable snapshot tag. The archive tag itself keeps the annotation-free
+// manifest so unchanged state still produces the same digest.
+func (s *session) pushSnapshot(ctx context.Context, artifact *artifact, message string) error {
+	size, err := directorySize(s.path)
+	if err != nil {
+		return err
+	}
+
+	created := s.now().UTC()
+	manifest := artifact.manifest
+	manifest.Annotations = map[string]string{
+		ocispec.AnnotationCreated: created.Format(time.RFC3339Nano),
+		annotationMessage:         message,
+		annotationSize:            strconv.FormatInt(size, 10),
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot manifest: %w", err)
+	}
+	manifestDesc, err := pushBlob(ctx, artifact.source, ocispec.MediaTypeImageManifest, manifestBytes)
+	if err != nil {
+		return fmt.Errorf("failed to add snapshot manifest: %w", err)
+	}
+
+	tag := snapshotTag(s.name, created.Format(snapshotIDFormat))
+	if err := artifact.source.Tag(ctx, manifestDesc, tag); err != nil {
+		return fmt.Errorf("failed to tag snapshot manifest: %w", err)
+	}
+	if _, err := oras.Copy(ctx, artifact.source, tag, s.target, tag, oras.DefaultCopyOptions); err != nil {
+		return fmt.Errorf("failed to push snapshot %q: %w", tag, err)
+	}
 	return nil
 }
 
@@ -315,6 +457,7 @@ func (s *session) Close(ctx context.Context) {
 
 type artifact struct {
 	source       *filecontent.Store
+	manifest     ocispec.Manifest
 	manifestDesc ocispec.Descriptor
 	hasFiles     bool
 	tempDir      string
@@ -407,6 +550,7 @@ func createArtifact(ctx context.Context, name, root string) (*artifact, error) {
 	removeTempDir = false
 	return &artifact{
 		source:       source,
+		manifest:     manifest,
 		manifestDesc: manifestDesc,
 		hasFiles:     hasFiles,
 		tempDir:      tempDir,
@@ -505,26 +649,83 @@ func archiveFiles(root string) ([]string, error) {
 	return paths, nil
 }
 
-func unpackArchive(ctx context.Context, target oras.Target, manifestDesc ocispec.Descriptor, root string) error {
+func fetchManifest(ctx context.Context, target oras.Target, manifestDesc ocispec.Descriptor) (*ocispec.Manifest, error) {
 	manifestReader, err := target.Fetch(ctx, manifestDesc)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	manifestBytes, readErr := io.ReadAll(manifestReader)
 	closeErr := manifestReader.Close()
 	if readErr != nil {
-		return readErr
+		return nil, readErr
 	}
 	if closeErr != nil {
-		return closeErr
+		return nil, closeErr
 	}
 
 	var manifest ocispec.Manifest
 	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
-		return fmt.Errorf("invalid OCI manifest: %w", err)
+		return nil, fmt.Errorf("invalid OCI manifest: %w", err)
 	}
 	if len(manifest.Layers) != 1 || manifest.Layers[0].MediaType != layerMediaType {
-		return errors.New("OCI archive manifest must contain exactly one state archive layer")
+		return nil, errors.New("OCI archive manifest must contain exactly one state archive layer")
+	}
+	return &manifest, nil
+}
+
+// readSnapshot describes the snapshot manifest tagged tag. Snapshots without a
+// size annotation report the compressed layer size.
+func readSnapshot(ctx context.Context, target oras.Target, tag, id string) (statearchive.Snapshot, error) {
+	desc, err := target.Resolve(ctx, tag)
+	if err != nil {
+		return statearchive.Snapshot{}, fmt.Errorf("failed to resolve snapshot %q: %w", tag, err)
+	}
+	manifest, err := fetchManifest(ctx, target, desc)
+	if err != nil {
+		return statearchive.Snapshot{}, fmt.Errorf("failed to read snapshot %q: %w", tag, err)
+	}
+
+	snapshot := statearchive.Snapshot{
+		ID:      id,
+		Message: manifest.Annotations[annotationMessage],
+		Size:    manifest.Layers[0].Size,
+	}
+	if created, err := time.Parse(time.RFC3339Nano, manifest.Annotations[ocispec.AnnotationCreated]); err == nil {
+		snapshot.Created = created
+	} else if created, err := time.Parse(snapshotIDFormat, id); err == nil {
+		snapshot.Created = created
+	}
+	if size, err := strconv.ParseInt(manifest.Annotations[annotationSize], 10, 64); err == nil {
+		snapshot.Size = size
+	}
+	return snapshot, nil
+}
+
+func snapshotTag(name, id string) string {
+	return name + snapshotTagInfix + id
+}
+
+// directorySize returns the total size in bytes of the archived files under root.
+func directorySize(root string) (int64, error) {
+	paths, err := archiveFiles(root)
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return 0, fmt.Errorf("failed to stat archive file %q: %w", path, err)
+		}
+		size += info.Size()
+	}
+	return size, nil
+}
+
+func unpackArchive(ctx context.Context, target oras.Target, manifestDesc ocispec.Descriptor, root string) error {
+	manifest, err := fetchManifest(ctx, target, manifestDesc)
+	if err != nil {
+		return err
 	}
 
 	layerReader, err := target.Fetch(ctx, manifest.Layers[0])
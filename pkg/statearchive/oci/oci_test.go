@@ -25,6 +25,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 
@@ -40,6 +41,7 @@ import (
 	"oras.land/oras-go/v2/content/memory"
 	ocistore "oras.land/oras-go/v2/content/oci"
 	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
 )
 
@@ -93,6 +95,49 @@ func TestOCIArchive_UsesOCIStorageForGraphs(t *testing.T) {
 	require.ErrorIs(t, err, persistence.ErrNotFound)
 }
 
+func TestOCIArchive_HistoryAndOpenRevision(t *testing.T) {
+	archive, _ := newTestArchive(t)
+	ctx := t.Context()
+
+	history, err := archive.History(ctx, "radius-state")
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	for _, content := range []string{"one", "three"} {
+		session, err := archive.Open(ctx, "radius-state")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(session.Path(), "state.txt"), []byte(content), 0o644))
+		require.NoError(t, session.Commit(ctx, "radius: backup "+content))
+		session.Close(ctx)
+	}
+
+	history, err = archive.History(ctx, "radius-state")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "radius: backup three", history[0].Message)
+	require.Equal(t, int64(5), history[0].Size)
+	require.Equal(t, "radius: backup one", history[1].Message)
+	require.False(t, history[1].Created.IsZero())
+
+	session, err := archive.OpenRevision(ctx, "radius-state", history[1].ID)
+	require.NoError(t, err)
+	closeOnCleanup(t, session)
+
+	data, err := os.ReadFile(filepath.Join(session.Path(), "state.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "one", string(data))
+	require.ErrorIs(t, session.Commit(ctx, "radius: backup"), statearchive.ErrReadOnlySession)
+}
+
+func TestOCIArchive_OpenRevisionReportsMissingSnapshot(t *testing.T) {
+	archive, _ := newTestArchive(t)
+
+	for _, id := range []string{"20260101T000000.000000000Z", "../radius-state", "sha256:" + strings.Repeat("0", 64)} {
+		_, err := archive.OpenRevision(t.Context(), "radius-state", id)
+		require.ErrorIs(t, err, statearchive.ErrSnapshotNotFound, id)
+	}
+}
+
 func TestOCIArchive_OpenRejectsEmptyName(t *testing.T) {
 	archive, _ := newTestArchive(t)
 
@@ -618,6 +663,10 @@ func (t *countingTarget) Push(ctx context.Context, desc ocispec.Descriptor, cont
 	return t.Target.Push(ctx, desc, content)
 }
 
+func (t *countingTarget) Tags(ctx context.Context, last string, fn func(tags []string) error) error {
+	return t.Target.(registry.TagLister).Tags(ctx, last, fn)
+}
+
 func (t *countingTarget) PushCount() int {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -0,0 +1,245 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s3 implements the statearchive.Archive interface on an S3-compatible
+// object store (AWS S3, MinIO, Ceph RGW, ...). Every Commit is kept as a
+// versioned snapshot under the configured bucket and prefix.
+//
+// Credentials and region are resolved with the standard AWS SDK configuration
+// chain (AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_REGION, shared config,
+// ...). Setting Options.Endpoint targets an S3-compatible server and switches to
+// path-style addressing, which those servers commonly require.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/radius-project/radius/pkg/statearchive"
+	"github.com/radius-project/radius/pkg/statearchive/snapshot"
+)
+
+const (
+	// URLScheme is the prefix that selects the S3 backend in an archive location,
+	// for example "s3://radius-ci/state".
+	URLScheme = "s3://"
+
+	// defaultRegion is used when neither Options.Region nor the AWS configuration
+	// chain provides a region. S3-compatible servers generally ignore it.
+	defaultRegion = "us-east-1"
+)
+
+// Options configures an S3Archive.
+type Options struct {
+	// Bucket is the bucket that holds the archive snapshots.
+	Bucket string
+
+	// Prefix is an optional key prefix inside Bucket.
+	Prefix string
+
+	// Endpoint is the base URL of an S3-compatible server, for example
+	// "http://localhost:9000". Leave empty for AWS S3.
+	Endpoint string
+
+	// Region overrides the region from the AWS configuration chain.
+	Region string
+}
+
+// ParseLocation parses an "s3://bucket/prefix" location into Options.
+func ParseLocation(location string) (Options, error) {
+	if !strings.HasPrefix(location, URLScheme) {
+		return Options{}, fmt.Errorf("invalid S3 archive location %q: expected %sbucket[/prefix]", location, URLScheme)
+	}
+	u, err := url.Parse(location)
+	if err != nil || u.Host == "" {
+		return Options{}, fmt.Errorf("invalid S3 archive location %q: expected %sbucket[/prefix]", location, URLScheme)
+	}
+	return Options{
+		Bucket: u.Host,
+		Prefix: strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+// Client is the subset of the AWS S3 client used by the archive.
+type Client interface {
+	GetObject(ctx context.Context, params *awss3.GetObjectInput, optFns ...func(*awss3.Options)) (*awss3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *awss3.PutObjectInput, optFns ...func(*awss3.Options)) (*awss3.PutObjectOutput, error)
+}
+
+type clientFactory func(context.Context) (Client, error)
+
+// S3Archive is a statearchive.Archive backed by an S3-compatible object store.
+type S3Archive struct {
+	*snapshot.Archive
+	options   Options
+	newClient clientFactory
+}
+
+// NewS3Archive returns an S3-backed state archive. The client is created lazily
+// on Open so that configuration errors surface from Archive.Open.
+func NewS3Archive(options Options) *S3Archive {
+	archive := &S3Archive{options: options}
+	archive.newClient = archive.openClient
+	archive.Archive = snapshot.NewArchive(URLScheme+path.Join(options.Bucket, options.Prefix), &store{archive: archive})
+	return archive
+}
+
+// Open materializes the latest snapshot of the archive name.
+func (a *S3Archive) Open(ctx context.Context, name string) (statearchive.Session, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	return a.Archive.Open(ctx, name)
+}
+
+// History lists the snapshots of the archive name, newest first.
+func (a *S3Archive) History(ctx context.Context, name string) ([]statearchive.Snapshot, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	return a.Archive.History(ctx, name)
+}
+
+// OpenRevision materializes the snapshot id of the archive name.
+func (a *S3Archive) OpenRevision(ctx context.Context, name string, id string) (statearchive.Session, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	return a.Archive.OpenRevision(ctx, name, id)
+}
+
+func (a *S3Archive) validate() error {
+	if a.options.Bucket == "" {
+		return errors.New("S3 archive bucket is not configured; set RADIUS_STATE_REGISTRY or RADIUS_GRAPH_REGISTRY to an s3:// location")
+	}
+	return nil
+}
+
+func (a *S3Archive) openClient(ctx context.Context) (Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if a.options.Region != "" {
+		cfg.Region = a.options.Region
+	}
+	if cfg.Region == "" {
+		cfg.Region = defaultRegion
+	}
+
+	return awss3.NewFromConfig(cfg, func(o *awss3.Options) {
+		if a.options.Endpoint != "" {
+			o.BaseEndpoint = aws.String(a.options.Endpoint)
+			o.UsePathStyle = true
+		}
+		// Many S3-compatible servers reject the SDK's default trailing checksums.
+		o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+		o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+	}), nil
+}
+
+// store implements snapshot.Store with one object per key.
+type store struct {
+	archive *S3Archive
+
+	mu     sync.Mutex
+	client Client
+}
+
+func (s *store) getClient(ctx context.Context) (Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client != nil {
+		return s.client, nil
+	}
+	client, err := s.archive.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.client = client
+	return client, nil
+}
+
+func (s *store) objectKey(key string) string {
+	return path.Join(s.archive.options.Prefix, key)
+}
+
+// Get opens the object stored at key.
+func (s *store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.GetObject(ctx, &awss3.GetObjectInput{
+		Bucket: aws.String(s.archive.options.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, snapshot.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %q from bucket %q: %w", s.objectKey(key), s.archive.options.Bucket, err)
+	}
+	return output.Body, nil
+}
+
+// Put uploads data to key. S3 object writes are atomic, so readers observe
+// either the previous or the new object.
+func (s *store) Put(ctx context.Context, key string, data io.Reader) error {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The SDK needs a seekable body to sign the payload over plain HTTP.
+	body, ok := data.(io.ReadSeeker)
+	if !ok {
+		buffer, err := io.ReadAll(data)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(buffer)
+	}
+
+	_, err = client.PutObject(ctx, &awss3.PutObjectInput{
+		Bucket: aws.String(s.archive.options.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+		Body:   body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object %q to bucket %q: %w", s.objectKey(key), s.archive.options.Bucket, err)
+	}
+	return nil
+}
+
+var (
+	_ statearchive.Archive = (*S3Archive)(nil)
+	_ snapshot.Store       = (*store)(nil)
+	_ Client               = (*awss3.Client)(nil)
+)
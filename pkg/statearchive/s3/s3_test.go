@@ -0,0 +1,172 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3Archive_CommitRoundTrip(t *testing.T) {
+	server := newFakeS3Server(t)
+	archive := NewS3Archive(Options{Bucket: "radius", Prefix: "ci/state", Endpoint: server.URL})
+	ctx := t.Context()
+
+	session, err := archive.Open(ctx, "radius-state")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(session.Path(), "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(session.Path(), "nested", "state.txt"), []byte("saved state"), 0o644))
+	require.NoError(t, session.Commit(ctx, "radius: backup"))
+	session.Close(ctx)
+
+	require.Contains(t, server.Keys(), "radius/ci/state/radius-state/latest")
+
+	session, err = archive.Open(ctx, "radius-state")
+	require.NoError(t, err)
+	t.Cleanup(func() { session.Close(context.Background()) }) //nolint:usetesting
+
+	data, err := os.ReadFile(filepath.Join(session.Path(), "nested", "state.txt"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("saved state"), data)
+}
+
+func TestS3Archive_OpenWithoutBucketFails(t *testing.T) {
+	archive := NewS3Archive(Options{})
+
+	_, err := archive.Open(t.Context(), "radius-state")
+	require.ErrorContains(t, err, "bucket is not configured")
+}
+
+func TestS3Archive_OpenReturnsClientError(t *testing.T) {
+	archive := NewS3Archive(Options{Bucket: "radius"})
+	archive.newClient = func(context.Context) (Client, error) {
+		return nil, errors.New("credentials unavailable")
+	}
+
+	_, err := archive.Open(t.Context(), "radius-state")
+	require.ErrorContains(t, err, "credentials unavailable")
+}
+
+func TestS3Archive_OpenReturnsServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+	setTestCredentials(t)
+
+	archive := NewS3Archive(Options{Bucket: "radius", Endpoint: server.URL})
+	_, err := archive.Open(t.Context(), "radius-state")
+	require.ErrorContains(t, err, `failed to get object "radius-state/latest"`)
+}
+
+func TestParseLocation(t *testing.T) {
+	options, err := ParseLocation("s3://radius-ci/state/main/")
+	require.NoError(t, err)
+	require.Equal(t, Options{Bucket: "radius-ci", Prefix: "state/main"}, options)
+
+	options, err = ParseLocation("s3://radius-ci")
+	require.NoError(t, err)
+	require.Equal(t, Options{Bucket: "radius-ci"}, options)
+
+	for _, location := range []string{"radius-ci", "s3://", "file:///tmp"} {
+		_, err := ParseLocation(location)
+		require.ErrorContains(t, err, "invalid S3 archive location", location)
+	}
+}
+
+// fakeS3Server is a minimal MinIO-style stand-in that supports path-style
+// GetObject and PutObject.
+type fakeS3Server struct {
+	*httptest.Server
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3Server(t *testing.T) *fakeS3Server {
+	t.Helper()
+	setTestCredentials(t)
+
+	server := &fakeS3Server{objects: map[string][]byte{}}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *fakeS3Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.objects[key] = data
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *fakeS3Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// setTestCredentials isolates the AWS configuration chain from the developer's
+// environment and supplies static credentials for the stand-in server.
+func setTestCredentials(t *testing.T) {
+	t.Helper()
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_CONFIG_FILE", os.DevNull)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", os.DevNull)
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+}
@@ -0,0 +1,508 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sealed wraps a statearchive.Archive with client-side envelope
+// encryption. Callers read and write plaintext files in a private temporary
+// directory; the underlying archive only ever sees ciphertext produced with the
+// versioned keys of pkg/crypto/encryption.
+//
+// Every file is encrypted with the archive name and its path as associated
+// data, so a ciphertext cannot be moved to another path or archive. The list of
+// files and their digests is kept in an encrypted manifest (SealFile) at the
+// root of the underlying session. Opening an archive authenticates the manifest
+// and checks every file against it before any plaintext is returned, so a
+// snapshot that was modified, truncated, or sealed with a foreign key is
+// rejected with statearchive.ErrVerificationFailed before it can be restored.
+package sealed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/radius-project/radius/pkg/crypto/encryption"
+	"github.com/radius-project/radius/pkg/statearchive"
+	"github.com/radius-project/radius/pkg/statearchive/snapshot"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+// SealFile is the name of the encrypted manifest stored at the root of the
+// underlying session. It is reserved and cannot be written by callers.
+const SealFile = ".radius-seal"
+
+// gitDir is the worktree marker created by the git backend. It is not part of
+// the archive contents.
+const gitDir = ".git"
+
+// SealedArchive is a statearchive.Archive that encrypts the contents of
+// another archive.
+type SealedArchive struct {
+	inner statearchive.Archive
+	keys  encryption.KeyProvider
+}
+
+// NewSealedArchive returns an archive that stores the contents of inner
+// encrypted with the keys from keys. New data is always sealed with the
+// current key; data sealed with an older version is decrypted with that
+// version for as long as the key provider still holds it.
+func NewSealedArchive(inner statearchive.Archive, keys encryption.KeyProvider) *SealedArchive {
+	return &SealedArchive{inner: inner, keys: keys}
+}
+
+// Open verifies and decrypts the latest snapshot of the archive name.
+func (a *SealedArchive) Open(ctx context.Context, name string) (statearchive.Session, error) {
+	inner, err := a.inner.Open(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return a.open(ctx, name, inner, false)
+}
+
+// History lists the snapshots of the archive name, newest first. Sizes are
+// those of the encrypted contents.
+func (a *SealedArchive) History(ctx context.Context, name string) ([]statearchive.Snapshot, error) {
+	return a.inner.History(ctx, name)
+}
+
+// OpenRevision verifies and decrypts the snapshot id of the archive name into
+// a read-only session.
+func (a *SealedArchive) OpenRevision(ctx context.Context, name string, id string) (statearchive.Session, error) {
+	inner, err := a.inner.OpenRevision(ctx, name, id)
+	if err != nil {
+		return nil, err
+	}
+	return a.open(ctx, name, inner, true)
+}
+
+// manifest is the plaintext form of SealFile.
+type manifest struct {
+	// Name is the archive name the manifest was sealed for.
+	Name string `json:"name"`
+
+	// Files lists every sealed file, sorted by path.
+	Files []fileEntry `json:"files"`
+}
+
+// fileEntry describes one sealed file.
+type fileEntry struct {
+	// Path is the slash-separated path relative to the archive root.
+	Path string `json:"path"`
+
+	// Size is the size of the plaintext.
+	Size int64 `json:"size"`
+
+	// SHA256 is the hex-encoded digest of the plaintext.
+	SHA256 string `json:"sha256"`
+
+	// SealedSHA256 is the hex-encoded digest of the ciphertext stored in the
+	// underlying archive.
+	SealedSHA256 string `json:"sealedSha256"`
+}
+
+func (a *SealedArchive) open(ctx context.Context, name string, inner statearchive.Session, readOnly bool) (statearchive.Session, error) {
+	closeOnError := true
+	defer func() {
+		if closeOnError {
+			inner.Close(ctx)
+		}
+	}()
+
+	current, err := a.readManifest(ctx, name, inner.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "radius-sealed-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	removeDirOnError := true
+	defer func() {
+		if removeDirOnError {
+			if removeErr := os.RemoveAll(dir); removeErr != nil {
+				ucplog.FromContextOrDiscard(ctx).Info("Failed to remove archive directory", "path", dir, "error", removeErr)
+			}
+		}
+	}()
+
+	if err := a.unseal(ctx, name, current, inner.Path(), dir); err != nil {
+		return nil, err
+	}
+
+	closeOnError = false
+	removeDirOnError = false
+	return &session{
+		archive:  a,
+		name:     name,
+		inner:    inner,
+		path:     dir,
+		manifest: current,
+		readOnly: readOnly,
+	}, nil
+}
+
+// readManifest authenticates and decrypts SealFile. An archive without a
+// manifest is accepted only when it holds no files, which is the case before
+// the first Commit.
+func (a *SealedArchive) readManifest(ctx context.Context, name, root string) (*manifest, error) {
+	data, err := os.ReadFile(filepath.Join(root, SealFile))
+	if errors.Is(err, os.ErrNotExist) {
+		files, err := sealedFiles(root)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) > 0 {
+			return nil, fmt.Errorf("%w: archive %q is not encrypted; it was saved without an encryption key", statearchive.ErrVerificationFailed, name)
+		}
+		return &manifest{Name: name, Files: []fileEntry{}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read archive manifest: %w", err)
+	}
+
+	plaintext, err := a.decrypt(ctx, data, associatedData(name, SealFile))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decrypt the manifest of archive %q: %v", statearchive.ErrVerificationFailed, name, err)
+	}
+
+	var current manifest
+	if err := json.Unmarshal(plaintext, &current); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse the manifest of archive %q: %v", statearchive.ErrVerificationFailed, name, err)
+	}
+	if current.Name != name {
+		return nil, fmt.Errorf("%w: manifest belongs to archive %q, not %q", statearchive.ErrVerificationFailed, current.Name, name)
+	}
+	return &current, nil
+}
+
+// unseal checks that the files under root match the manifest exactly and
+// decrypts each of them into dir.
+func (a *SealedArchive) unseal(ctx context.Context, name string, current *manifest, root, dir string) error {
+	files, err := sealedFiles(root)
+	if err != nil {
+		return err
+	}
+	expected := make(map[string]bool, len(current.Files))
+	for _, entry := range current.Files {
+		expected[entry.Path] = true
+	}
+	for _, file := range files {
+		if !expected[file] {
+			return fmt.Errorf("%w: archive %q contains unexpected file %q", statearchive.ErrVerificationFailed, name, file)
+		}
+	}
+
+	for _, entry := range current.Files {
+		sealedPath, err := snapshot.SafePath(root, entry.Path)
+		if err != nil {
+			return fmt.Errorf("%w: %v", statearchive.ErrVerificationFailed, err)
+		}
+		ciphertext, err := os.ReadFile(sealedPath)
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: archive %q is missing file %q", statearchive.ErrVerificationFailed, name, entry.Path)
+		} else if err != nil {
+			return fmt.Errorf("failed to read archive file %q: %w", entry.Path, err)
+		}
+		if digest(ciphertext) != entry.SealedSHA256 {
+			return fmt.Errorf("%w: archive file %q was modified", statearchive.ErrVerificationFailed, entry.Path)
+		}
+
+		plaintext := []byte{}
+		if entry.Size > 0 {
+			plaintext, err = a.decrypt(ctx, ciphertext, associatedData(name, entry.Path))
+			if err != nil {
+				return fmt.Errorf("%w: failed to decrypt archive file %q: %v", statearchive.ErrVerificationFailed, entry.Path, err)
+			}
+		}
+		if int64(len(plaintext)) != entry.Size || digest(plaintext) != entry.SHA256 {
+			return fmt.Errorf("%w: archive file %q does not match its manifest", statearchive.ErrVerificationFailed, entry.Path)
+		}
+
+		target, err := snapshot.SafePath(dir, entry.Path)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+			return fmt.Errorf("failed to create directory for %q: %w", entry.Path, err)
+		}
+		if err := os.WriteFile(target, plaintext, 0o600); err != nil {
+			return fmt.Errorf("failed to write archive file %q: %w", entry.Path, err)
+		}
+	}
+	return nil
+}
+
+func (a *SealedArchive) decrypt(ctx context.Context, data, associatedData []byte) ([]byte, error) {
+	version, err := encryption.GetEncryptedDataVersion(data)
+	if err != nil {
+		return nil, err
+	}
+	key, err := a.keys.GetKeyByVersion(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	encryptor, err := encryption.NewEncryptorWithVersion(key, version)
+	if err != nil {
+		return nil, err
+	}
+	return encryptor.Decrypt(data, associatedData)
+}
+
+// session is a plaintext working directory on top of an underlying session
+// that holds the sealed files.
+type session struct {
+	archive  *SealedArchive
+	name     string
+	inner    statearchive.Session
+	path     string
+	manifest *manifest
+	readOnly bool
+}
+
+// Path returns the plaintext working directory.
+func (s *session) Path() string {
+	return s.path
+}
+
+// Commit seals every changed file into the underlying session, rewrites the
+// manifest when anything changed, and commits the underlying session. Files
+// whose plaintext is unchanged keep their existing ciphertext, so committing an
+// unchanged directory remains a no-op for the underlying archive.
+func (s *session) Commit(ctx context.Context, message string) error {
+	if s.readOnly {
+		return statearchive.ErrReadOnlySession
+	}
+
+	next, err := s.seal(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.inner.Commit(ctx, message); err != nil {
+		return err
+	}
+	s.manifest = next
+	return nil
+}
+
+func (s *session) seal(ctx context.Context) (*manifest, error) {
+	previous := make(map[string]fileEntry, len(s.manifest.Files))
+	for _, entry := range s.manifest.Files {
+		previous[entry.Path] = entry
+	}
+
+	files, err := plaintextFiles(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var encryptor *encryption.Encryptor
+	next := &manifest{Name: s.name, Files: make([]fileEntry, 0, len(files))}
+	changed := len(files) != len(previous)
+	for _, file := range files {
+		source, err := snapshot.SafePath(s.path, file)
+		if err != nil {
+			return nil, err
+		}
+		plaintext, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive file %q: %w", file, err)
+		}
+		entry := fileEntry{Path: file, Size: int64(len(plaintext)), SHA256: digest(plaintext)}
+		if old, ok := previous[file]; ok && old.Size == entry.Size && old.SHA256 == entry.SHA256 {
+			next.Files = append(next.Files, old)
+			continue
+		}
+
+		changed = true
+		if encryptor == nil {
+			if encryptor, err = s.archive.currentEncryptor(ctx); err != nil {
+				return nil, err
+			}
+		}
+		ciphertext := []byte{}
+		if len(plaintext) > 0 {
+			ciphertext, err = encryptor.Encrypt(plaintext, associatedData(s.name, file))
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt archive file %q: %w", file, err)
+			}
+		}
+		if err := writeSealed(s.inner.Path(), file, ciphertext); err != nil {
+			return nil, err
+		}
+		entry.SealedSHA256 = digest(ciphertext)
+		next.Files = append(next.Files, entry)
+	}
+
+	if !changed {
+		return next, nil
+	}
+
+	current := make(map[string]bool, len(files))
+	for _, file := range files {
+		current[file] = true
+	}
+	for file := range previous {
+		if current[file] {
+			continue
+		}
+		sealedPath, err := snapshot.SafePath(s.inner.Path(), file)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Remove(sealedPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove archive file %q: %w", file, err)
+		}
+	}
+
+	if encryptor == nil {
+		if encryptor, err = s.archive.currentEncryptor(ctx); err != nil {
+			return nil, err
+		}
+	}
+	data, err := json.Marshal(next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal archive manifest: %w", err)
+	}
+	sealedManifest, err := encryptor.Encrypt(data, associatedData(s.name, SealFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt archive manifest: %w", err)
+	}
+	if err := writeSealed(s.inner.Path(), SealFile, sealedManifest); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// Close removes the plaintext directory and closes the underlying session.
+func (s *session) Close(ctx context.Context) {
+	if err := os.RemoveAll(s.path); err != nil {
+		ucplog.FromContextOrDiscard(ctx).Info("Failed to remove archive directory", "path", s.path, "error", err)
+	}
+	s.inner.Close(ctx)
+}
+
+func (a *SealedArchive) currentEncryptor(ctx context.Context) (*encryption.Encryptor, error) {
+	key, version, err := a.keys.GetCurrentKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archive encryption key: %w", err)
+	}
+	return encryption.NewEncryptorWithVersion(key, version)
+}
+
+func writeSealed(root, rel string, data []byte) error {
+	target, err := snapshot.SafePath(root, rel)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %q: %w", rel, err)
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write archive file %q: %w", rel, err)
+	}
+	return nil
+}
+
+// sealedFiles lists the sealed files in an underlying session, excluding the
+// manifest and the git worktree marker.
+func sealedFiles(root string) ([]string, error) {
+	files, err := listFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	result := files[:0]
+	for _, file := range files {
+		if file == SealFile || file == gitDir {
+			continue
+		}
+		result = append(result, file)
+	}
+	return result, nil
+}
+
+// plaintextFiles lists the files in the plaintext directory, rejecting the
+// reserved names that would collide with the underlying session.
+func plaintextFiles(root string) ([]string, error) {
+	files, err := listFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if file == SealFile || file == gitDir {
+			return nil, fmt.Errorf("archive file name %q is reserved", file)
+		}
+	}
+	return files, nil
+}
+
+// listFiles returns the sorted slash-separated paths of the regular files under
+// root. The git worktree marker directory is skipped.
+func listFiles(root string) ([]string, error) {
+	files := []string{}
+	err := filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if entry.IsDir() {
+			if rel == gitDir {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.Type()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("archive contains unsupported symbolic link %q", p)
+		}
+		if !entry.Type().IsRegular() {
+			return fmt.Errorf("archive contains unsupported file type %q", p)
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive directory: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// associatedData binds a ciphertext to its archive and path.
+func associatedData(name, rel string) []byte {
+	return []byte("statearchive/" + name + "/" + rel)
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+var (
+	_ statearchive.Archive = (*SealedArchive)(nil)
+	_ statearchive.Session = (*session)(nil)
+)
@@ -0,0 +1,252 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sealed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/radius-project/radius/pkg/crypto/encryption"
+	"github.com/radius-project/radius/pkg/statearchive"
+	archivefile "github.com/radius-project/radius/pkg/statearchive/file"
+	"github.com/stretchr/testify/require"
+)
+
+const archiveName = "radius-state"
+
+func TestSealedArchive_CommitRoundTrip(t *testing.T) {
+	inner := archivefile.NewFileArchive(archivefile.Options{Root: t.TempDir()})
+	archive := NewSealedArchive(inner, newKeyProvider(t))
+	ctx := t.Context()
+
+	commitFiles(t, archive, map[string]string{
+		"backup/ucp.sql":  "secret database dump",
+		"terraform/empty": "",
+	})
+
+	session, err := archive.Open(ctx, archiveName)
+	require.NoError(t, err)
+	requireFile(t, session.Path(), "backup/ucp.sql", "secret database dump")
+	requireFile(t, session.Path(), "terraform/empty", "")
+	session.Close(ctx)
+
+	raw, err := inner.Open(ctx, archiveName)
+	require.NoError(t, err)
+	defer raw.Close(ctx)
+	sealed, err := os.ReadFile(filepath.Join(raw.Path(), "backup", "ucp.sql"))
+	require.NoError(t, err)
+	require.True(t, encryption.IsEncryptedData(sealed))
+	require.NotContains(t, string(sealed), "secret database dump")
+	require.FileExists(t, filepath.Join(raw.Path(), SealFile))
+}
+
+func TestSealedArchive_UnchangedCommitIsNoOp(t *testing.T) {
+	inner := archivefile.NewFileArchive(archivefile.Options{Root: t.TempDir()})
+	archive := NewSealedArchive(inner, newKeyProvider(t))
+	ctx := t.Context()
+
+	commitFiles(t, archive, map[string]string{"state.txt": "saved"})
+
+	session := openSession(t, archive)
+	require.NoError(t, session.Commit(ctx, "radius: unchanged"))
+
+	history, err := archive.History(ctx, archiveName)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+}
+
+func TestSealedArchive_RemovesDeletedFiles(t *testing.T) {
+	archive := NewSealedArchive(archivefile.NewFileArchive(archivefile.Options{Root: t.TempDir()}), newKeyProvider(t))
+	ctx := t.Context()
+
+	commitFiles(t, archive, map[string]string{"keep.txt": "keep", "drop.txt": "drop"})
+
+	session, err := archive.Open(ctx, archiveName)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(session.Path(), "drop.txt")))
+	require.NoError(t, session.Commit(ctx, "radius: remove"))
+	session.Close(ctx)
+
+	session = openSession(t, archive)
+	requireFile(t, session.Path(), "keep.txt", "keep")
+	require.NoFileExists(t, filepath.Join(session.Path(), "drop.txt"))
+}
+
+func TestSealedArchive_DecryptsWithRotatedKeys(t *testing.T) {
+	inner := archivefile.NewFileArchive(archivefile.Options{Root: t.TempDir()})
+	keys := newKeyProvider(t)
+	commitFiles(t, NewSealedArchive(inner, keys), map[string]string{"state.txt": "saved"})
+
+	key2, err := encryption.GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, keys.AddKey(2, key2))
+	require.NoError(t, keys.SetCurrentVersion(2))
+
+	session := openSession(t, NewSealedArchive(inner, keys))
+	requireFile(t, session.Path(), "state.txt", "saved")
+}
+
+func TestSealedArchive_RejectsForeignKey(t *testing.T) {
+	inner := archivefile.NewFileArchive(archivefile.Options{Root: t.TempDir()})
+	commitFiles(t, NewSealedArchive(inner, newKeyProvider(t)), map[string]string{"state.txt": "saved"})
+
+	_, err := NewSealedArchive(inner, newKeyProvider(t)).Open(t.Context(), archiveName)
+	require.ErrorIs(t, err, statearchive.ErrVerificationFailed)
+}
+
+func TestSealedArchive_RejectsTamperedContents(t *testing.T) {
+	tests := []struct {
+		name    string
+		tamper  func(t *testing.T, root string)
+		wantErr string
+	}{
+		{
+			name: "modified file",
+			tamper: func(t *testing.T, root string) {
+				other := filepath.Join(root, "other.txt")
+				data, err := os.ReadFile(other)
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(filepath.Join(root, "state.txt"), data, 0o644))
+			},
+			wantErr: `archive file "state.txt" was modified`,
+		},
+		{
+			name: "added file",
+			tamper: func(t *testing.T, root string) {
+				require.NoError(t, os.WriteFile(filepath.Join(root, "injected.sql"), []byte("DROP TABLE"), 0o644))
+			},
+			wantErr: `contains unexpected file "injected.sql"`,
+		},
+		{
+			name: "removed file",
+			tamper: func(t *testing.T, root string) {
+				require.NoError(t, os.Remove(filepath.Join(root, "other.txt")))
+			},
+			wantErr: `is missing file "other.txt"`,
+		},
+		{
+			name: "modified manifest",
+			tamper: func(t *testing.T, root string) {
+				require.NoError(t, os.WriteFile(filepath.Join(root, SealFile), []byte(`{"version":1,"encrypted":"AAAA","nonce":"AAAAAAAAAAAAAAAA"}`), 0o644))
+			},
+			wantErr: "failed to decrypt the manifest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := archivefile.NewFileArchive(archivefile.Options{Root: t.TempDir()})
+			archive := NewSealedArchive(inner, newKeyProvider(t))
+			ctx := t.Context()
+			commitFiles(t, archive, map[string]string{"state.txt": "saved", "other.txt": "other"})
+
+			raw, err := inner.Open(ctx, archiveName)
+			require.NoError(t, err)
+			tt.tamper(t, raw.Path())
+			require.NoError(t, raw.Commit(ctx, "tampered"))
+			raw.Close(ctx)
+
+			_, err = archive.Open(ctx, archiveName)
+			require.ErrorIs(t, err, statearchive.ErrVerificationFailed)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSealedArchive_RejectsUnencryptedArchive(t *testing.T) {
+	inner := archivefile.NewFileArchive(archivefile.Options{Root: t.TempDir()})
+	ctx := t.Context()
+
+	raw, err := inner.Open(ctx, archiveName)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(raw.Path(), "state.txt"), []byte("plain"), 0o644))
+	require.NoError(t, raw.Commit(ctx, "plain"))
+	raw.Close(ctx)
+
+	_, err = NewSealedArchive(inner, newKeyProvider(t)).Open(ctx, archiveName)
+	require.ErrorIs(t, err, statearchive.ErrVerificationFailed)
+	require.ErrorContains(t, err, "is not encrypted")
+}
+
+func TestSealedArchive_OpenRevisionIsReadOnly(t *testing.T) {
+	archive := NewSealedArchive(archivefile.NewFileArchive(archivefile.Options{Root: t.TempDir()}), newKeyProvider(t))
+	ctx := t.Context()
+
+	commitFiles(t, archive, map[string]string{"state.txt": "first"})
+	commitFiles(t, archive, map[string]string{"state.txt": "second"})
+
+	history, err := archive.History(ctx, archiveName)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	session, err := archive.OpenRevision(ctx, archiveName, history[1].ID)
+	require.NoError(t, err)
+	t.Cleanup(func() { session.Close(context.Background()) }) //nolint:usetesting
+
+	requireFile(t, session.Path(), "state.txt", "first")
+	require.ErrorIs(t, session.Commit(ctx, "radius: rewrite"), statearchive.ErrReadOnlySession)
+}
+
+func TestSealedArchive_RejectsReservedNames(t *testing.T) {
+	archive := NewSealedArchive(archivefile.NewFileArchive(archivefile.Options{Root: t.TempDir()}), newKeyProvider(t))
+	ctx := t.Context()
+
+	session := openSession(t, archive)
+	require.NoError(t, os.WriteFile(filepath.Join(session.Path(), SealFile), []byte("x"), 0o644))
+	require.ErrorContains(t, session.Commit(ctx, "radius: backup"), "is reserved")
+}
+
+func newKeyProvider(t *testing.T) *encryption.InMemoryKeyProvider {
+	t.Helper()
+	key, err := encryption.GenerateKey()
+	require.NoError(t, err)
+	provider, err := encryption.NewInMemoryKeyProvider(key)
+	require.NoError(t, err)
+	return provider
+}
+
+func commitFiles(t *testing.T, archive statearchive.Archive, files map[string]string) {
+	t.Helper()
+	ctx := t.Context()
+	session, err := archive.Open(ctx, archiveName)
+	require.NoError(t, err)
+	defer session.Close(ctx)
+
+	for name, content := range files {
+		path := filepath.Join(session.Path(), filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	require.NoError(t, session.Commit(ctx, "radius: backup"))
+}
+
+func openSession(t *testing.T, archive statearchive.Archive) statearchive.Session {
+	t.Helper()
+	session, err := archive.Open(t.Context(), archiveName)
+	require.NoError(t, err)
+	t.Cleanup(func() { session.Close(context.Background()) }) //nolint:usetesting
+	return session
+}
+
+func requireFile(t *testing.T, root, name, want string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+	require.NoError(t, err)
+	require.Equal(t, want, string(data))
+}
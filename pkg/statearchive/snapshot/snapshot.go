@@ -0,0 +1,556 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot implements the statearchive.Archive interface on top of a
+// flat key/value object store, keeping every Commit as an immutable, versioned
+// snapshot.
+//
+// The layout under a store is:
+//
+//	<name>/latest                           ID of the most recent snapshot
+//	<name>/snapshots/<id>/manifest.json     snapshot metadata and file index
+//	<name>/snapshots/<id>/files/<path>      one object per archived file
+//
+// Snapshot IDs sort lexically in creation order. The latest pointer is written
+// last, so a crash part-way through a Commit leaves the previous snapshot as the
+// current one. Backends only need to provide the Store interface; the plain
+// filesystem (pkg/statearchive/file) and S3-compatible (pkg/statearchive/s3)
+// archives both build on this package.
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/radius-project/radius/pkg/statearchive"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
+)
+
+const (
+	latestKey      = "latest"
+	snapshotsDir   = "snapshots"
+	manifestKey    = "manifest.json"
+	filesDir       = "files"
+	snapshotFormat = "20060102T150405.000000000Z"
+)
+
+// ErrNotFound is returned by Store.Get when the key does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Store is the minimal object store a snapshot archive needs. Keys are
+// slash-separated relative paths. Implementations must make Put atomic with
+// respect to Get: a reader observes either the previous or the new object,
+// never a partial one.
+type Store interface {
+	// Get opens the object stored at key. It returns ErrNotFound when the key
+	// does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Put stores data at key, replacing any existing object.
+	Put(ctx context.Context, key string, data io.Reader) error
+}
+
+// FileEntry describes one file in a snapshot.
+type FileEntry struct {
+	// Path is the slash-separated path of the file relative to the session root.
+	Path string `json:"path"`
+
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// SHA256 is the hex-encoded SHA-256 digest of the file contents.
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest is the metadata stored alongside each snapshot.
+type Manifest struct {
+	// ID is the snapshot ID.
+	ID string `json:"id"`
+
+	// Parent is the ID of the snapshot this one was created from, if any.
+	Parent string `json:"parent,omitempty"`
+
+	// Message is the Commit message.
+	Message string `json:"message,omitempty"`
+
+	// Created is the time the snapshot was committed.
+	Created time.Time `json:"created"`
+
+	// Files lists every file in the snapshot, sorted by path.
+	Files []FileEntry `json:"files"`
+}
+
+var archiveLocks sync.Map // store identity and archive name -> *sync.Mutex
+
+// Archive is a statearchive.Archive that stores versioned snapshots in a Store.
+type Archive struct {
+	// id identifies the underlying store for per-archive locking, for example
+	// the root directory or bucket URL.
+	id    string
+	store Store
+	now   func() time.Time
+}
+
+// NewArchive returns a snapshot archive backed by store. id identifies the store
+// so that sessions for the same archive name in the same store are serialized.
+func NewArchive(id string, store Store) *Archive {
+	return &Archive{
+		id:    id,
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Open materializes the latest snapshot of the archive name into a temporary
+// directory. A name without any snapshot yields an empty session.
+func (a *Archive) Open(ctx context.Context, name string) (statearchive.Session, error) {
+	return a.open(ctx, name, "")
+}
+
+// OpenRevision materializes the snapshot id of the archive name into a
+// temporary, read-only directory.
+func (a *Archive) OpenRevision(ctx context.Context, name string, id string) (statearchive.Session, error) {
+	if id == "" {
+		return nil, errors.New("snapshot ID must not be empty")
+	}
+	return a.open(ctx, name, id)
+}
+
+// History follows the parent chain from the latest snapshot of the archive
+// name and returns every snapshot, newest first.
+func (a *Archive) History(ctx context.Context, name string) ([]statearchive.Snapshot, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	id, err := readLatest(ctx, a.store, name)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []statearchive.Snapshot{}
+	seen := map[string]bool{}
+	for id != "" {
+		if seen[id] {
+			return nil, fmt.Errorf("snapshot history of archive %q contains a cycle at %q", name, id)
+		}
+		seen[id] = true
+
+		manifest, err := readManifest(ctx, a.store, name, id)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, manifest.Snapshot())
+		id = manifest.Parent
+	}
+	return snapshots, nil
+}
+
+// Snapshot returns the statearchive.Snapshot that describes the manifest.
+func (m *Manifest) Snapshot() statearchive.Snapshot {
+	var size int64
+	for _, file := range m.Files {
+		size += file.Size
+	}
+	return statearchive.Snapshot{
+		ID:      m.ID,
+		Created: m.Created,
+		Message: m.Message,
+		Size:    size,
+	}
+}
+
+// open materializes the snapshot revision, or the latest snapshot when revision
+// is empty.
+func (a *Archive) open(ctx context.Context, name string, revision string) (statearchive.Session, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	lock := lockForArchive(a.id, name)
+	lock.Lock()
+	unlockOnError := true
+	defer func() {
+		if unlockOnError {
+			lock.Unlock()
+		}
+	}()
+
+	id := revision
+	if id == "" {
+		latest, err := readLatest(ctx, a.store, name)
+		if err != nil {
+			return nil, err
+		}
+		id = latest
+	} else if err := validateName(id); err != nil {
+		return nil, fmt.Errorf("invalid snapshot ID %q: %w", id, statearchive.ErrSnapshotNotFound)
+	}
+
+	var manifest *Manifest
+	if id != "" {
+		var err error
+		manifest, err = readManifest(ctx, a.store, name, id)
+		if revision != "" && errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("snapshot %q of archive %q: %w", revision, name, statearchive.ErrSnapshotNotFound)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "radius-snapshot-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	removeDirOnError := true
+	defer func() {
+		if removeDirOnError {
+			if removeErr := os.RemoveAll(dir); removeErr != nil {
+				ucplog.FromContextOrDiscard(ctx).Info("Failed to remove archive directory", "path", dir, "error", removeErr)
+			}
+		}
+	}()
+
+	if manifest != nil {
+		if err := restoreFiles(ctx, a.store, name, manifest, dir); err != nil {
+			return nil, err
+		}
+	}
+
+	unlockOnError = false
+	removeDirOnError = false
+	return &session{
+		archive:  a,
+		path:     dir,
+		name:     name,
+		manifest: manifest,
+		readOnly: revision != "",
+		unlock:   lock.Unlock,
+	}, nil
+}
+
+func lockForArchive(id, name string) *sync.Mutex {
+	lock, _ := archiveLocks.LoadOrStore(id+":"+name, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+type session struct {
+	archive  *Archive
+	path     string
+	name     string
+	manifest *Manifest
+	readOnly bool
+
+	unlock   func()
+	unlocked bool
+}
+
+// Path returns the session's temporary working directory.
+func (s *session) Path() string {
+	return s.path
+}
+
+// Commit stores the working directory as a new snapshot and makes it the
+// latest one. Committing unchanged contents is a no-op.
+func (s *session) Commit(ctx context.Context, message string) error {
+	if s.readOnly {
+		return statearchive.ErrReadOnlySession
+	}
+
+	files, err := indexFiles(s.path)
+	if err != nil {
+		return err
+	}
+
+	// Like the git and OCI backends, a brand-new archive with nothing to store
+	// is a no-op, but an empty directory for an existing archive is persisted so
+	// deletions are not dropped.
+	if s.manifest == nil && len(files) == 0 {
+		return nil
+	}
+	if s.manifest != nil && sameFiles(s.manifest.Files, files) {
+		return nil
+	}
+
+	latest, err := readLatest(ctx, s.archive.store, s.name)
+	if err != nil {
+		return err
+	}
+	if latest != s.currentID() {
+		return fmt.Errorf("archive %q changed while this session was open", s.name)
+	}
+
+	manifest := &Manifest{
+		ID:      s.archive.newID(latest),
+		Parent:  latest,
+		Message: message,
+		Created: s.archive.now().UTC(),
+		Files:   files,
+	}
+
+	for _, file := range files {
+		if err := putFile(ctx, s.archive.store, s.path, fileKey(s.name, manifest.ID, file.Path), file.Path); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot manifest: %w", err)
+	}
+	if err := s.archive.store.Put(ctx, manifestPath(s.name, manifest.ID), strings.NewReader(string(data))); err != nil {
+		return fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+	if err := s.archive.store.Put(ctx, path.Join(s.name, latestKey), strings.NewReader(manifest.ID)); err != nil {
+		return fmt.Errorf("failed to update latest snapshot of archive %q: %w", s.name, err)
+	}
+
+	ucplog.FromContextOrDiscard(ctx).Info("Snapshot committed", "archive", s.name, "snapshot", manifest.ID)
+	s.manifest = manifest
+	return nil
+}
+
+// Close removes the temporary directory and releases the session lock.
+func (s *session) Close(ctx context.Context) {
+	if err := os.RemoveAll(s.path); err != nil {
+		ucplog.FromContextOrDiscard(ctx).Info("Failed to remove archive directory", "path", s.path, "error", err)
+	}
+	if !s.unlocked {
+		s.unlocked = true
+		s.unlock()
+	}
+}
+
+func (s *session) currentID() string {
+	if s.manifest == nil {
+		return ""
+	}
+	return s.manifest.ID
+}
+
+// newID returns a snapshot ID derived from the current time. IDs must sort after
+// the parent, so a clock that has not advanced (or moved backwards) is nudged
+// forward past the parent ID.
+func (a *Archive) newID(parent string) string {
+	now := a.now().UTC()
+	id := now.Format(snapshotFormat)
+	if parent == "" || id > parent {
+		return id
+	}
+	parentTime, err := time.Parse(snapshotFormat, parent)
+	if err != nil {
+		return id
+	}
+	return parentTime.Add(time.Nanosecond).Format(snapshotFormat)
+}
+
+func readLatest(ctx context.Context, store Store, name string) (string, error) {
+	data, err := readObject(ctx, store, path.Join(name, latestKey))
+	if errors.Is(err, ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read latest snapshot of archive %q: %w", name, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func readManifest(ctx context.Context, store Store, name, id string) (*Manifest, error) {
+	data, err := readObject(ctx, store, manifestPath(name, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest of snapshot %q in archive %q: %w", id, name, err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest for snapshot %q in archive %q: %w", id, name, err)
+	}
+	return &manifest, nil
+}
+
+func readObject(ctx context.Context, store Store, key string) ([]byte, error) {
+	reader, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	data, readErr := io.ReadAll(reader)
+	closeErr := reader.Close()
+	if readErr != nil {
+		return nil, readErr
+	}
+	return data, closeErr
+}
+
+func restoreFiles(ctx context.Context, store Store, name string, manifest *Manifest, root string) error {
+	for _, file := range manifest.Files {
+		target, err := SafePath(root, file.Path)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("failed to create archive directory: %w", err)
+		}
+
+		reader, err := store.Get(ctx, fileKey(name, manifest.ID, file.Path))
+		if err != nil {
+			return fmt.Errorf("failed to read archive file %q: %w", file.Path, err)
+		}
+		out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			_ = reader.Close()
+			return fmt.Errorf("failed to create archive file %q: %w", file.Path, err)
+		}
+		hash := sha256.New()
+		_, copyErr := io.Copy(io.MultiWriter(out, hash), reader)
+		closeOutErr := out.Close()
+		closeReaderErr := reader.Close()
+		if copyErr != nil {
+			return fmt.Errorf("failed to restore archive file %q: %w", file.Path, copyErr)
+		}
+		if closeOutErr != nil {
+			return fmt.Errorf("failed to close archive file %q: %w", file.Path, closeOutErr)
+		}
+		if closeReaderErr != nil {
+			return fmt.Errorf("failed to close archive object %q: %w", file.Path, closeReaderErr)
+		}
+		if hex.EncodeToString(hash.Sum(nil)) != file.SHA256 {
+			return fmt.Errorf("archive file %q does not match its snapshot digest", file.Path)
+		}
+	}
+	return nil
+}
+
+func putFile(ctx context.Context, store Store, root, key, rel string) error {
+	file, err := os.Open(filepath.Join(root, filepath.FromSlash(rel)))
+	if err != nil {
+		return fmt.Errorf("failed to open archive file %q: %w", rel, err)
+	}
+	putErr := store.Put(ctx, key, file)
+	closeErr := file.Close()
+	if putErr != nil {
+		return fmt.Errorf("failed to write archive file %q: %w", rel, putErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to close archive file %q: %w", rel, closeErr)
+	}
+	return nil
+}
+
+// indexFiles returns a sorted FileEntry for every regular file under root.
+func indexFiles(root string) ([]FileEntry, error) {
+	files := []FileEntry{}
+	err := filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root || entry.IsDir() {
+			return nil
+		}
+		if entry.Type()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("archive contains unsupported symbolic link %q", p)
+		}
+		if !entry.Type().IsRegular() {
+			return fmt.Errorf("archive contains unsupported file type %q", p)
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		hash := sha256.New()
+		size, copyErr := io.Copy(hash, file)
+		closeErr := file.Close()
+		if copyErr != nil {
+			return copyErr
+		}
+		if closeErr != nil {
+			return closeErr
+		}
+		files = append(files, FileEntry{
+			Path:   filepath.ToSlash(rel),
+			Size:   size,
+			SHA256: hex.EncodeToString(hash.Sum(nil)),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive directory: %w", err)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+func sameFiles(a, b []FileEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func manifestPath(name, id string) string {
+	return path.Join(name, snapshotsDir, id, manifestKey)
+}
+
+func fileKey(name, id, rel string) string {
+	return path.Join(name, snapshotsDir, id, filesDir, rel)
+}
+
+func validateName(name string) error {
+	if name == "" {
+		return errors.New("archive name must not be empty")
+	}
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("invalid archive name %q", name)
+	}
+	return nil
+}
+
+// SafePath joins a slash-separated relative name onto root, rejecting names that
+// are empty, absolute or escape root.
+func SafePath(root, name string) (string, error) {
+	cleanName := filepath.Clean(filepath.FromSlash(name))
+	if cleanName == "." || filepath.IsAbs(cleanName) || cleanName == ".." || strings.HasPrefix(cleanName, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid archive path %q", name)
+	}
+	return filepath.Join(root, cleanName), nil
+}
+
+var (
+	_ statearchive.Archive = (*Archive)(nil)
+	_ statearchive.Session = (*session)(nil)
+)
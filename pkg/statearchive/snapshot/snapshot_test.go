@@ -0,0 +1,251 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/radius-project/radius/pkg/statearchive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive_CommitRoundTrip(t *testing.T) {
+	archive, store := newTestArchive(t)
+	ctx := t.Context()
+
+	session, err := archive.Open(ctx, "radius-state")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(session.Path(), "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(session.Path(), "nested", "state.txt"), []byte("saved state"), 0o644))
+	require.NoError(t, session.Commit(ctx, "radius: backup"))
+	session.Close(ctx)
+
+	session, err = archive.Open(ctx, "radius-state")
+	require.NoError(t, err)
+	closeOnCleanup(t, session)
+
+	data, err := os.ReadFile(filepath.Join(session.Path(), "nested", "state.txt"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("saved state"), data)
+
+	puts := store.PutCount()
+	require.NoError(t, session.Commit(ctx, "radius: backup"))
+	require.Equal(t, puts, store.PutCount(), "unchanged state must not write again")
+}
+
+func TestArchive_CommitKeepsPreviousSnapshots(t *testing.T) {
+	archive, store := newTestArchive(t)
+	ctx := t.Context()
+
+	first := commitFile(t, archive, "state.txt", "one")
+	second := commitFile(t, archive, "state.txt", "two")
+	require.Less(t, first, second)
+
+	manifest, err := readManifest(ctx, store, "radius-state", first)
+	require.NoError(t, err)
+	require.Equal(t, "radius: backup", manifest.Message)
+	require.Len(t, manifest.Files, 1)
+	require.Equal(t, int64(3), manifest.Files[0].Size)
+
+	manifest, err = readManifest(ctx, store, "radius-state", second)
+	require.NoError(t, err)
+	require.Equal(t, first, manifest.Parent)
+}
+
+func TestArchive_EmptyNewArchiveIsNoOp(t *testing.T) {
+	archive, store := newTestArchive(t)
+
+	session, err := archive.Open(t.Context(), "radius-state")
+	require.NoError(t, err)
+	closeOnCleanup(t, session)
+
+	require.NoError(t, session.Commit(t.Context(), "radius: backup"))
+	require.Zero(t, store.PutCount())
+}
+
+func TestArchive_CommitPersistsDeletion(t *testing.T) {
+	archive, _ := newTestArchive(t)
+	ctx := t.Context()
+	commitFile(t, archive, "state.txt", "state")
+
+	session, err := archive.Open(ctx, "radius-state")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(session.Path(), "state.txt")))
+	require.NoError(t, session.Commit(ctx, "radius: delete"))
+	session.Close(ctx)
+
+	session, err = archive.Open(ctx, "radius-state")
+	require.NoError(t, err)
+	closeOnCleanup(t, session)
+	require.NoFileExists(t, filepath.Join(session.Path(), "state.txt"))
+}
+
+func TestArchive_CommitRejectsConcurrentUpdate(t *testing.T) {
+	archive, store := newTestArchive(t)
+	ctx := t.Context()
+
+	session, err := archive.Open(ctx, "radius-state")
+	require.NoError(t, err)
+	closeOnCleanup(t, session)
+
+	require.NoError(t, store.Put(ctx, "radius-state/latest", bytes.NewReader([]byte("20260101T000000.000000000Z"))))
+	require.NoError(t, os.WriteFile(filepath.Join(session.Path(), "state.txt"), []byte("state"), 0o644))
+
+	err = session.Commit(ctx, "radius: backup")
+	require.ErrorContains(t, err, "changed while this session was open")
+}
+
+func TestArchive_OpenRejectsTamperedFiles(t *testing.T) {
+	archive, store := newTestArchive(t)
+	ctx := t.Context()
+	id := commitFile(t, archive, "state.txt", "state")
+
+	require.NoError(t, store.Put(ctx, fileKey("radius-state", id, "state.txt"), bytes.NewReader([]byte("other"))))
+
+	_, err := archive.Open(ctx, "radius-state")
+	require.ErrorContains(t, err, "does not match its snapshot digest")
+}
+
+func TestArchive_OpenRejectsInvalidNames(t *testing.T) {
+	archive, _ := newTestArchive(t)
+
+	for _, name := range []string{"", ".", "..", "a/b", `a\b`} {
+		_, err := archive.Open(t.Context(), name)
+		require.Error(t, err, name)
+	}
+}
+
+func TestArchive_NewIDSortsAfterParent(t *testing.T) {
+	archive, _ := newTestArchive(t)
+	fixed := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	archive.now = func() time.Time { return fixed }
+
+	parent := fixed.Format(snapshotFormat)
+	require.Greater(t, archive.newID(parent), parent)
+}
+
+func TestSafePathRejectsUnsafeNames(t *testing.T) {
+	for _, name := range []string{"", ".", "..", "../state.txt", filepath.Join(t.TempDir(), "state.txt")} {
+		_, err := SafePath(t.TempDir(), name)
+		require.ErrorContains(t, err, "invalid archive path", name)
+	}
+}
+
+func commitFile(t *testing.T, archive *Archive, name, content string) string {
+	t.Helper()
+	ctx := t.Context()
+
+	opened, err := archive.Open(ctx, "radius-state")
+	require.NoError(t, err)
+	defer opened.Close(ctx)
+
+	require.NoError(t, os.WriteFile(filepath.Join(opened.Path(), name), []byte(content), 0o644))
+	require.NoError(t, opened.Commit(ctx, "radius: backup"))
+	return opened.(*session).manifest.ID
+}
+
+func newTestArchive(t *testing.T) (*Archive, *memoryStore) {
+	t.Helper()
+	store := &memoryStore{objects: map[string][]byte{}}
+	return NewArchive(t.Name(), store), store
+}
+
+// closeOnCleanup closes the session once the test ends. It uses a fresh context because
+// t.Context() is cancelled before cleanup runs.
+func closeOnCleanup(t *testing.T, session statearchive.Session) {
+	t.Helper()
+	t.Cleanup(func() { session.Close(context.Background()) }) //nolint:usetesting
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	puts    int
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStore) Put(_ context.Context, key string, data io.Reader) error {
+	buffer, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = buffer
+	s.puts++
+	return nil
+}
+
+func (s *memoryStore) PutCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.puts
+}
+
+func TestArchive_HistoryAndOpenRevision(t *testing.T) {
+	archive, _ := newTestArchive(t)
+	ctx := t.Context()
+
+	history, err := archive.History(ctx, "radius-state")
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	first := commitFile(t, archive, "state.txt", "one")
+	second := commitFile(t, archive, "state.txt", "three")
+
+	history, err = archive.History(ctx, "radius-state")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, second, history[0].ID)
+	require.Equal(t, int64(5), history[0].Size)
+	require.Equal(t, first, history[1].ID)
+	require.Equal(t, "radius: backup", history[1].Message)
+
+	session, err := archive.OpenRevision(ctx, "radius-state", first)
+	require.NoError(t, err)
+	closeOnCleanup(t, session)
+
+	data, err := os.ReadFile(filepath.Join(session.Path(), "state.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "one", string(data))
+	require.ErrorIs(t, session.Commit(ctx, "radius: backup"), statearchive.ErrReadOnlySession)
+}
+
+func TestArchive_OpenRevisionReportsMissingSnapshot(t *testing.T) {
+	archive, _ := newTestArchive(t)
+
+	for _, id := range []string{"20260101T000000.000000000Z", "../latest"} {
+		_, err := archive.OpenRevision(t.Context(), "radius-state", id)
+		require.ErrorIs(t, err, statearchive.ErrSnapshotNotFound, id)
+	}
+}
@@ -21,14 +21,13 @@ limitations under the License.
 // It is intentionally distinct from the live, record-oriented persistence
 // subsystems in pkg/components (database.Client, secret.Client, queue.Client):
 // those serve the running control plane, whereas an Archive captures a whole
-// directory of state as a durable snapshot. Today the only implementation is a
-// git orphan branch (pkg/statearchive/git), but the interface deliberately
-// hides that: a Session is just a local working directory whose contents
-// survive across Open calls once Commit succeeds. Callers write files into
-// Session.Path() with any tool (pg_dump, kubectl, os.WriteFile, ...), then
-// Commit to persist them. Alternative implementations (for example OCI/GHCR or
-// a plain filesystem) implement the same two interfaces without changing any
-// caller.
+// directory of state as a durable snapshot. Implementations include a git
+// orphan branch (pkg/statearchive/git), OCI artifacts (pkg/statearchive/oci), a
+// plain directory (pkg/statearchive/file) and an S3-compatible bucket
+// (pkg/statearchive/s3), but the interface deliberately hides that: a Session
+// is just a local working directory whose contents survive across Open calls
+// once Commit succeeds. Callers write files into Session.Path() with any tool
+// (pg_dump, kubectl, os.WriteFile, ...), then Commit to persist them.
 //
 // Typical use:
 //
@@ -43,7 +42,24 @@ limitations under the License.
 //	}
 package statearchive
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrReadOnlySession is returned by Session.Commit for sessions opened with
+// Archive.OpenRevision. A past snapshot cannot be modified in place.
+var ErrReadOnlySession = errors.New("state archive session is read-only")
+
+// ErrSnapshotNotFound is returned by Archive.OpenRevision when the requested
+// snapshot does not exist in the archive.
+var ErrSnapshotNotFound = errors.New("state archive snapshot not found")
+
+// ErrVerificationFailed is returned by Archive.Open and Archive.OpenRevision when
+// the archive contents cannot be authenticated, for example because they were
+// modified outside of Radius or sealed with a different key.
+var ErrVerificationFailed = errors.New("state archive verification failed")
 
 // Archive is a pluggable durable state archive. Each named archive is
 // materialized into a local working directory (a Session) that callers mutate
@@ -61,6 +77,32 @@ type Archive interface {
 	// Commit are present under Session.Path() when Open returns. The caller
 	// must always defer Session.Close.
 	Open(ctx context.Context, name string) (Session, error)
+
+	// History lists the snapshots of the archive identified by name, newest
+	// first. An archive that has never been committed has no snapshots.
+	History(ctx context.Context, name string) ([]Snapshot, error)
+
+	// OpenRevision materializes the snapshot with the given ID (as returned by
+	// History) into a read-only Session. It returns an error wrapping
+	// ErrSnapshotNotFound when the snapshot does not exist.
+	OpenRevision(ctx context.Context, name string, id string) (Session, error)
+}
+
+// Snapshot describes one committed version of an archive.
+type Snapshot struct {
+	// ID identifies the snapshot within the archive: a commit SHA for git, a
+	// snapshot tag for OCI and a timestamp-based ID for the file and S3 backends.
+	ID string `json:"id"`
+
+	// Created is the time the snapshot was committed.
+	Created time.Time `json:"created"`
+
+	// Message is the message passed to Session.Commit. Backends that cannot
+	// record a message leave it empty.
+	Message string `json:"message,omitempty"`
+
+	// Size is the total size in bytes of the files in the snapshot.
+	Size int64 `json:"size"`
 }
 
 // Session is a durable working directory. Callers read and write files under
@@ -26,7 +26,9 @@ import (
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
 	"github.com/radius-project/radius/pkg/armrpc/frontend/controller"
 	"github.com/radius-project/radius/pkg/armrpc/frontend/server"
+	"github.com/radius-project/radius/pkg/components/queue"
 	"github.com/radius-project/radius/pkg/ucp"
+	deadletters_ctrl "github.com/radius-project/radius/pkg/ucp/frontend/controller/deadletters"
 	kubernetes_ctrl "github.com/radius-project/radius/pkg/ucp/frontend/controller/kubernetes"
 	planes_ctrl "github.com/radius-project/radius/pkg/ucp/frontend/controller/planes"
 	"github.com/radius-project/radius/pkg/ucp/frontend/modules"
@@ -37,6 +39,7 @@ import (
 const (
 	planeCollectionPath     = "/planes"
 	planeTypeCollectionPath = "/planes/{planeType}"
+	deadLetterPath          = "/admin/queues/{queueName}/deadletters"
 
 	// OperationTypeKubernetesOpenAPIV2Doc is the operation type for the required OpenAPI v2 discovery document.
 	//
@@ -53,6 +56,12 @@ const (
 
 	// OperationTypePlanes is the operation type for the planes (all types) collection.
 	OperationTypePlanes = "PLANES"
+
+	// OperationTypeDeadLetters is the operation type for the dead-letter queues of async operations.
+	OperationTypeDeadLetters = "DEADLETTERS"
+
+	// OperationReplay is the operation method to replay a message of a dead-letter queue.
+	OperationReplay v1.OperationMethod = "REPLAY"
 )
 
 func initModules(ctx context.Context, mods []modules.Initializer) (map[string]http.Handler, []string, error) {
@@ -143,6 +152,54 @@ func Register(ctx context.Context, router chi.Router, planeModules []modules.Ini
 		},
 	}...)
 
+	// Configures the admin routes to manage the dead-letter queues. The queues are not resources, so
+	// these routes are not validated against the API specs.
+	deadLetterRouter := server.NewSubrouter(router, options.Config.Server.PathBase+deadLetterPath)
+	queues := func(ctx context.Context, name string) (queue.Client, error) {
+		return options.QueueProvider.GetNamedClient(ctx, name)
+	}
+	handlerOptions = append(handlerOptions, []server.HandlerOptions{
+		{
+			ParentRouter:  deadLetterRouter,
+			Method:        v1.OperationList,
+			OperationType: &v1.OperationType{Type: OperationTypeDeadLetters, Method: v1.OperationList},
+			ResourceType:  OperationTypeDeadLetters,
+			ControllerFactory: func(opts controller.Options) (controller.Controller, error) {
+				return deadletters_ctrl.NewListDeadLetters(opts, queues)
+			},
+		},
+		{
+			ParentRouter:  deadLetterRouter,
+			Path:          "/{" + deadletters_ctrl.MessageIDParam + "}",
+			Method:        v1.OperationGet,
+			OperationType: &v1.OperationType{Type: OperationTypeDeadLetters, Method: v1.OperationGet},
+			ResourceType:  OperationTypeDeadLetters,
+			ControllerFactory: func(opts controller.Options) (controller.Controller, error) {
+				return deadletters_ctrl.NewGetDeadLetter(opts, queues)
+			},
+		},
+		{
+			ParentRouter:  deadLetterRouter,
+			Path:          "/{" + deadletters_ctrl.MessageIDParam + "}",
+			Method:        v1.OperationDelete,
+			OperationType: &v1.OperationType{Type: OperationTypeDeadLetters, Method: v1.OperationDelete},
+			ResourceType:  OperationTypeDeadLetters,
+			ControllerFactory: func(opts controller.Options) (controller.Controller, error) {
+				return deadletters_ctrl.NewPurgeDeadLetter(opts, queues)
+			},
+		},
+		{
+			ParentRouter:  deadLetterRouter,
+			Path:          "/{" + deadletters_ctrl.MessageIDParam + "}/replay",
+			Method:        OperationReplay,
+			OperationType: &v1.OperationType{Type: OperationTypeDeadLetters, Method: OperationReplay},
+			ResourceType:  OperationTypeDeadLetters,
+			ControllerFactory: func(opts controller.Options) (controller.Controller, error) {
+				return deadletters_ctrl.NewReplayDeadLetter(opts, queues)
+			},
+		},
+	}...)
+
 	databaseClient, err := options.DatabaseProvider.GetClient(ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deadletters implements the UCP admin API to manage the dead-letter queues of async operations.
+// The messages whose processing failed more than the maximum retry count are moved to the dead-letter
+// queue of the queue they were enqueued to, where they can be inspected, replayed or purged.
+package deadletters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	ctrl "github.com/radius-project/radius/pkg/armrpc/asyncoperation/controller"
+	armrpc_rest "github.com/radius-project/radius/pkg/armrpc/rest"
+	"github.com/radius-project/radius/pkg/components/queue"
+)
+
+const (
+	// QueueNameParam is the URL parameter of the queue name.
+	QueueNameParam = "queueName"
+	// MessageIDParam is the URL parameter of the message id.
+	MessageIDParam = "messageId"
+)
+
+// QueueGetter gets the client of the queue name.
+type QueueGetter func(ctx context.Context, name string) (queue.Client, error)
+
+// DeadLetterMessage is the representation of a message in the dead-letter queue.
+type DeadLetterMessage struct {
+	// ID is the id of the message.
+	ID string `json:"id"`
+	// Queue is the name of the queue.
+	Queue string `json:"queue"`
+	// DequeueCount is the number of attempts to process the message.
+	DequeueCount int `json:"dequeueCount"`
+	// EnqueueAt is the time when the message was enqueued.
+	EnqueueAt time.Time `json:"enqueueAt"`
+	// DeadLetterAt is the time when the message was moved to the dead-letter queue.
+	DeadLetterAt time.Time `json:"deadLetterAt"`
+	// LastError is the error of the last attempt to process the message.
+	LastError string `json:"lastError,omitempty"`
+
+	// OperationID is the id of the async operation of the message.
+	OperationID string `json:"operationId,omitempty"`
+	// OperationType is the type of the async operation of the message.
+	OperationType string `json:"operationType,omitempty"`
+	// ResourceID is the id of the resource of the async operation.
+	ResourceID string `json:"resourceId,omitempty"`
+
+	// Data is the payload of the message. It is only returned when a single message is requested.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// DeadLetterMessageList is the list of the messages in a dead-letter queue.
+type DeadLetterMessageList struct {
+	Value []*DeadLetterMessage `json:"value"`
+}
+
+// newDeadLetterMessage converts msg of the queue queueName. The async operation is decoded from the
+// payload when it is a request message.
+func newDeadLetterMessage(queueName string, msg *queue.DeadLetterMessage, includeData bool) *DeadLetterMessage {
+	result := &DeadLetterMessage{
+		ID:           msg.ID,
+		Queue:        queueName,
+		DequeueCount: msg.DequeueCount,
+		EnqueueAt:    msg.EnqueueAt,
+		DeadLetterAt: msg.DeadLetterAt,
+		LastError:    msg.LastError,
+	}
+
+	op := &ctrl.Request{}
+	if err := json.Unmarshal(msg.Data, op); err == nil {
+		result.OperationID = op.OperationID.String()
+		result.OperationType = op.OperationType
+		result.ResourceID = op.ResourceID
+	}
+
+	if includeData && json.Valid(msg.Data) {
+		result.Data = json.RawMessage(msg.Data)
+	}
+
+	return result
+}
+
+// getDeadLetterClient gets the client of the queue in the request URL. It returns a response when the
+// queue is not configured or does not support dead-lettering.
+func getDeadLetterClient(ctx context.Context, req *http.Request, queues QueueGetter) (string, queue.DeadLetterClient, armrpc_rest.Response, error) {
+	name := chi.URLParam(req, QueueNameParam)
+	client, err := queues(ctx, name)
+	if errors.Is(err, queue.ErrQueueNotFound) {
+		return "", nil, armrpc_rest.NewNotFoundMessageResponse(fmt.Sprintf("The queue %q is not found.", name)), nil
+	} else if err != nil {
+		return "", nil, nil, err
+	}
+
+	dlq, ok := client.(queue.DeadLetterClient)
+	if !ok {
+		return "", nil, armrpc_rest.NewBadRequestResponse(fmt.Sprintf("The queue %q does not have a dead-letter queue.", name)), nil
+	}
+
+	return name, dlq, nil, nil
+}
+
+// notFoundResponse returns the response for err if the message is not in the dead-letter queue.
+func notFoundResponse(err error, queueName string, id string) armrpc_rest.Response {
+	if !errors.Is(err, queue.ErrDeadLetterNotFound) {
+		return nil
+	}
+
+	return armrpc_rest.NewNotFoundMessageResponse(fmt.Sprintf("The message %q is not in the dead-letter queue of %q.", id, queueName))
+}
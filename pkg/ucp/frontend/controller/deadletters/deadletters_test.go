@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deadletters
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	ctrl "github.com/radius-project/radius/pkg/armrpc/asyncoperation/controller"
+	armrpc_controller "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	armrpc_rest "github.com/radius-project/radius/pkg/armrpc/rest"
+	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/queue"
+	"github.com/radius-project/radius/pkg/components/queue/inmemory"
+)
+
+const testResourceID = "/planes/radius/local/resourceGroups/test-group/providers/Applications.Core/containers/web"
+
+// setup returns a queue with one dead-letter message and the getter of the queue named "radius".
+func setup(t *testing.T) (*inmemory.Client, *queue.DeadLetterMessage, QueueGetter) {
+	cli := inmemory.New(inmemory.NewInMemQueue(time.Minute))
+
+	operationID := uuid.New()
+	err := cli.Enqueue(t.Context(), queue.NewMessage(&ctrl.Request{
+		OperationID:   operationID,
+		OperationType: "APPLICATIONS.CORE/CONTAINERS|PUT",
+		ResourceID:    testResourceID,
+	}))
+	require.NoError(t, err)
+
+	msg, err := cli.Dequeue(t.Context(), queue.QueueClientConfig{})
+	require.NoError(t, err)
+	require.NoError(t, cli.DeadLetter(t.Context(), msg, "exceeded max retry count"))
+
+	dead, err := cli.GetDeadLetter(t.Context(), msg.ID)
+	require.NoError(t, err)
+
+	queues := func(ctx context.Context, name string) (queue.Client, error) {
+		if name != "radius" {
+			return nil, queue.ErrQueueNotFound
+		}
+		return cli, nil
+	}
+	return cli, dead, queues
+}
+
+func newRequest(t *testing.T, method string, messageID string) *http.Request {
+	return newQueueRequest(t, method, "radius", messageID)
+}
+
+func newQueueRequest(t *testing.T, method string, queueName string, messageID string) *http.Request {
+	req := httptest.NewRequestWithContext(t.Context(), method, "/admin/queues/"+queueName+"/deadletters", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(QueueNameParam, queueName)
+	if messageID != "" {
+		rctx.URLParams.Add(MessageIDParam, messageID)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func run(t *testing.T, controller armrpc_controller.Controller, req *http.Request) *httptest.ResponseRecorder {
+	resp, err := controller.Run(req.Context(), nil, req)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	require.NoError(t, resp.Apply(req.Context(), w, req))
+	return w
+}
+
+func options(t *testing.T) armrpc_controller.Options {
+	return armrpc_controller.Options{DatabaseClient: database.NewMockClient(gomock.NewController(t))}
+}
+
+func TestListDeadLetters(t *testing.T) {
+	_, dead, queues := setup(t)
+	controller, err := NewListDeadLetters(options(t), queues)
+	require.NoError(t, err)
+
+	w := run(t, controller, newRequest(t, http.MethodGet, ""))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	list := &DeadLetterMessageList{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), list))
+	require.Len(t, list.Value, 1)
+	require.Equal(t, dead.ID, list.Value[0].ID)
+	require.Equal(t, "radius", list.Value[0].Queue)
+	require.Equal(t, 1, list.Value[0].DequeueCount)
+	require.Equal(t, "exceeded max retry count", list.Value[0].LastError)
+	require.Equal(t, "APPLICATIONS.CORE/CONTAINERS|PUT", list.Value[0].OperationType)
+	require.Equal(t, testResourceID, list.Value[0].ResourceID)
+	require.Empty(t, list.Value[0].Data, "the payload is only returned for a single message")
+}
+
+func TestListDeadLetters_UnknownQueue(t *testing.T) {
+	_, _, queues := setup(t)
+	controller, err := NewListDeadLetters(options(t), queues)
+	require.NoError(t, err)
+
+	w := run(t, controller, newQueueRequest(t, http.MethodGet, "unknown", ""))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetDeadLetter(t *testing.T) {
+	_, dead, queues := setup(t)
+	controller, err := NewGetDeadLetter(options(t), queues)
+	require.NoError(t, err)
+
+	w := run(t, controller, newRequest(t, http.MethodGet, dead.ID))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	msg := &DeadLetterMessage{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), msg))
+	require.Equal(t, dead.ID, msg.ID)
+	require.JSONEq(t, string(dead.Data), string(msg.Data))
+
+	w = run(t, controller, newRequest(t, http.MethodGet, "unknown"))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	cli, dead, queues := setup(t)
+	controller, err := NewReplayDeadLetter(options(t), queues)
+	require.NoError(t, err)
+
+	w := run(t, controller, newRequest(t, http.MethodPost, dead.ID))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	list, err := cli.ListDeadLetters(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	msg, err := cli.Dequeue(t.Context(), queue.QueueClientConfig{})
+	require.NoError(t, err)
+	require.Equal(t, dead.ID, msg.ID)
+	require.Equal(t, 1, msg.DequeueCount)
+
+	w = run(t, controller, newRequest(t, http.MethodPost, dead.ID))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPurgeDeadLetter(t *testing.T) {
+	cli, dead, queues := setup(t)
+	controller, err := NewPurgeDeadLetter(options(t), queues)
+	require.NoError(t, err)
+
+	w := run(t, controller, newRequest(t, http.MethodDelete, dead.ID))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	list, err := cli.ListDeadLetters(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	w = run(t, controller, newRequest(t, http.MethodDelete, dead.ID))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// noDeadLetterClient is a queue client without a dead-letter queue.
+type noDeadLetterClient struct {
+	queue.Client
+}
+
+func TestGetDeadLetterClient_Unsupported(t *testing.T) {
+	queues := func(ctx context.Context, name string) (queue.Client, error) {
+		return &noDeadLetterClient{}, nil
+	}
+
+	_, _, resp, err := getDeadLetterClient(t.Context(), newRequest(t, http.MethodGet, ""), queues)
+	require.NoError(t, err)
+	require.IsType(t, &armrpc_rest.BadRequestResponse{}, resp)
+}
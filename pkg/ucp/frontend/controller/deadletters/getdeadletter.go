@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deadletters
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	armrpc_controller "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	armrpc_rest "github.com/radius-project/radius/pkg/armrpc/rest"
+)
+
+var _ armrpc_controller.Controller = (*GetDeadLetter)(nil)
+
+// GetDeadLetter is the controller implementation to get a message in a dead-letter queue.
+type GetDeadLetter struct {
+	armrpc_controller.BaseController
+	queues QueueGetter
+}
+
+// NewGetDeadLetter creates a new GetDeadLetter controller.
+func NewGetDeadLetter(opts armrpc_controller.Options, queues QueueGetter) (armrpc_controller.Controller, error) {
+	return &GetDeadLetter{BaseController: armrpc_controller.NewBaseController(opts), queues: queues}, nil
+}
+
+// Run gets the message in the dead-letter queue with its payload.
+func (e *GetDeadLetter) Run(ctx context.Context, w http.ResponseWriter, req *http.Request) (armrpc_rest.Response, error) {
+	name, dlq, resp, err := getDeadLetterClient(ctx, req, e.queues)
+	if resp != nil || err != nil {
+		return resp, err
+	}
+
+	id := chi.URLParam(req, MessageIDParam)
+	msg, err := dlq.GetDeadLetter(ctx, id)
+	if resp := notFoundResponse(err, name, id); resp != nil {
+		return resp, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return armrpc_rest.NewOKResponse(newDeadLetterMessage(name, msg, true)), nil
+}
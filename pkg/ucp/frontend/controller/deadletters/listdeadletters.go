@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deadletters
+
+import (
+	"context"
+	"net/http"
+
+	armrpc_controller "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
+	armrpc_rest "github.com/radius-project/radius/pkg/armrpc/rest"
+)
+
+var _ armrpc_controller.Controller = (*ListDeadLetters)(nil)
+
+// ListDeadLetters is the controller implementation to list the messages in a dead-letter queue.
+type ListDeadLetters struct {
+	armrpc_controller.BaseController
+	queues QueueGetter
+}
+
+// NewListDeadLetters creates a new ListDeadLetters controller.
+func NewListDeadLetters(opts armrpc_controller.Options, queues QueueGetter) (armrpc_controller.Controller, error) {
+	return &ListDeadLetters{BaseController: armrpc_controller.NewBaseController(opts), queues: queues}, nil
+}
+
+// Run lists the messages in the dead-letter queue without their payload.
+func (e *ListDeadLetters) Run(ctx context.Context, w http.ResponseWriter, req *http.Request) (armrpc_rest.Response, error) {
+	name, dlq, resp, err := getDeadLetterClient(ctx, req, e.queues)
+	if resp != nil || err != nil {
+		return resp, err
+	}
+
+	messages, err := dlq.ListDeadLetters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &DeadLetterMessageList{Value: []*DeadLetterMessage{}}
+	for _, msg := range messages {
+		list.Value = append(list.Value, newDeadLetterMessage(name, msg, false))
+	}
+
+	return armrpc_rest.NewOKResponse(list), nil
+}
@@ -38,6 +38,8 @@ import (
 	"github.com/radius-project/radius/pkg/ucp/resources"
 	"github.com/radius-project/radius/pkg/ucp/trackedresource"
 	"github.com/radius-project/radius/pkg/ucp/ucplog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 const (
@@ -219,6 +221,10 @@ func (p *ProxyController) PrepareProxyRequest(ctx context.Context, originalReq *
 	proxyReq.Header.Set("X-Forwarded-Proto", refererURL.Scheme)
 	proxyReq.Header.Set(v1.RefererHeader, refererURL.String())
 
+	// Replace the traceparent of the incoming request with the span of this request, so that the downstream
+	// resource provider (and the async operation it queues) is traced as a child of UCP.
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(proxyReq.Header))
+
 	// Clear route context, we don't want to inherit any state from Chi.
 	proxyReq = proxyReq.WithContext(context.WithValue(ctx, chi.RouteCtxKey, nil))
 
@@ -29,10 +29,13 @@ import (
 	"github.com/radius-project/radius/pkg/armrpc/frontend/controller"
 	"github.com/radius-project/radius/pkg/armrpc/rest"
 	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/trace"
 	"github.com/radius-project/radius/pkg/ucp/datamodel"
 	"github.com/radius-project/radius/pkg/ucp/resources"
 	"github.com/radius-project/radius/pkg/ucp/trackedresource"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/mock/gomock"
 )
 
@@ -410,6 +413,25 @@ func Test_ProxyController_PrepareProxyRequest(t *testing.T) {
 		require.Equal(t, "yes", proxyReq.Header.Get("Copied"))
 	})
 
+	t.Run("propagates trace context", func(t *testing.T) {
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		originalURL, err := url.Parse("http://localhost:9443/path/base/planes/radius/local/resourceGroups/test-group/providers/System.TestRP?test=yes")
+		require.NoError(t, err)
+		originalReq := &http.Request{
+			Host:   originalURL.Host,
+			Header: http.Header{"Traceparent": []string{"00-10000000000000000000000000000000-00f067aa0ba902b7-01"}},
+			URL:    originalURL}
+
+		traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		ctx := trace.WithTraceparent(t.Context(), traceparent)
+
+		p, _, _, _, _ := createController(t)
+		proxyReq, err := p.PrepareProxyRequest(ctx, originalReq, downstream, relativePath)
+		require.NoError(t, err)
+		require.Equal(t, traceparent, proxyReq.Header.Get("Traceparent"))
+	})
+
 	t.Run("invalid downstream URL", func(t *testing.T) {
 		originalReq := &http.Request{Header: http.Header{}, URL: &url.URL{}}
 
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	http "net/http"
+	"strconv"
 
 	v1 "github.com/radius-project/radius/pkg/armrpc/api/v1"
 	armrpc_controller "github.com/radius-project/radius/pkg/armrpc/frontend/controller"
@@ -29,6 +30,7 @@ import (
 	"github.com/radius-project/radius/pkg/ucp/datamodel"
 	"github.com/radius-project/radius/pkg/ucp/datamodel/converter"
 	"github.com/radius-project/radius/pkg/ucp/resources"
+	"github.com/radius-project/radius/pkg/ucp/ucplog"
 )
 
 var _ armrpc_controller.Controller = (*ListResources)(nil)
@@ -74,6 +76,16 @@ func (r *ListResources) Run(ctx context.Context, w http.ResponseWriter, req *htt
 		ResourceType: v20231001preview.ResourceType,
 	}
 
+	// The client can ask to be notified of the changes to the resources instead of polling.
+	if watch, _ := strconv.ParseBool(req.URL.Query().Get(v1.WatchParameterName)); watch {
+		events, err := database.Watch(ctx, r.DatabaseClient(), query)
+		if err != nil {
+			return nil, err
+		}
+
+		return armrpc_rest.NewWatchResponse(r.convertEvents(ctx, events)), nil
+	}
+
 	result, err := r.DatabaseClient().Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -87,6 +99,49 @@ func (r *ListResources) Run(ctx context.Context, w http.ResponseWriter, req *htt
 	return armrpc_rest.NewOKResponse(response), nil
 }
 
+// convertEvents converts the changes to the stored resources to the API version of the request. The returned
+// channel is closed when events is closed.
+//
+// The ID of each event is the ID of the entry that tracks the resource. The resource itself, including its ID,
+// is the Object of created and updated events.
+func (r *ListResources) convertEvents(ctx context.Context, events <-chan database.WatchEvent) <-chan armrpc_rest.WatchEvent {
+	logger := ucplog.FromContextOrDiscard(ctx)
+	serviceCtx := v1.ARMRequestContextFromContext(ctx)
+
+	converted := make(chan armrpc_rest.WatchEvent)
+	go func() {
+		defer close(converted)
+
+		for event := range events {
+			result := armrpc_rest.WatchEvent{Type: string(event.Type), ID: event.Object.ID, ETag: event.Object.ETag}
+			if event.Type != database.EventTypeDeleted {
+				data := datamodel.GenericResource{}
+				err := event.Object.As(&data)
+				if err != nil {
+					logger.Error(err, "failed to read the changed resource", "id", event.Object.ID)
+					continue
+				}
+
+				versioned, err := converter.GenericResourceDataModelToVersioned(&data, serviceCtx.APIVersion)
+				if err != nil {
+					logger.Error(err, "failed to convert the changed resource", "id", event.Object.ID)
+					continue
+				}
+
+				result.Object = versioned
+			}
+
+			select {
+			case converted <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return converted
+}
+
 func (r *ListResources) createResponse(ctx context.Context, result *database.ObjectQueryResult) (*v1.PaginatedList, error) {
 	items := v1.PaginatedList{}
 	serviceCtx := v1.ARMRequestContextFromContext(ctx)
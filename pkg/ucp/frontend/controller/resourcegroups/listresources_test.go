@@ -28,6 +28,7 @@ import (
 	armrpc_rest "github.com/radius-project/radius/pkg/armrpc/rest"
 	"github.com/radius-project/radius/pkg/armrpc/rpctest"
 	"github.com/radius-project/radius/pkg/components/database"
+	"github.com/radius-project/radius/pkg/components/database/inmemory"
 	"github.com/radius-project/radius/pkg/ucp/api/v20231001preview"
 	"github.com/radius-project/radius/pkg/ucp/datamodel"
 	"github.com/radius-project/radius/pkg/ucp/resources"
@@ -110,6 +111,39 @@ func Test_ListResources(t *testing.T) {
 		require.Equal(t, expected, response)
 	})
 
+	t.Run("success - watch", func(t *testing.T) {
+		databaseClient := inmemory.NewClient()
+		c, err := NewListResources(armrpc_controller.Options{DatabaseClient: databaseClient, PathBase: "/" + uuid.New().String()})
+		require.NoError(t, err)
+		ctrl := c.(*ListResources)
+
+		err = databaseClient.Save(t.Context(), &database.Object{Metadata: database.Metadata{ID: resourceGroupID}, Data: resourceGroupDatamodel})
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodGet, ctrl.Options().PathBase+id+"?api-version="+v20231001preview.Version+"&watch=true", nil)
+		require.NoError(t, err)
+		ctx := rpctest.NewARMRequestContext(request)
+		response, err := ctrl.Run(ctx, nil, request)
+		require.NoError(t, err)
+
+		watch, ok := response.(*armrpc_rest.WatchResponse)
+		require.True(t, ok)
+
+		entryID := resourceGroupID + "/providers/" + v20231001preview.ResourceType + "/test-app"
+		obj := database.Object{Metadata: database.Metadata{ID: entryID}, Data: entryDatamodel}
+		err = databaseClient.Save(t.Context(), &obj)
+		require.NoError(t, err)
+
+		event := <-watch.Events
+		require.Equal(t, armrpc_rest.WatchEvent{Type: "Created", ID: entryID, ETag: obj.ETag, Object: &entryResource}, event)
+
+		err = databaseClient.Delete(t.Context(), entryID)
+		require.NoError(t, err)
+
+		event = <-watch.Events
+		require.Equal(t, armrpc_rest.WatchEvent{Type: "Deleted", ID: entryID, ETag: obj.ETag}, event)
+	})
+
 	t.Run("resource group not found", func(t *testing.T) {
 		databaseClient, ctrl := setupListResources(t)
 
@@ -23,6 +23,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/radius-project/radius/pkg/armrpc/history"
 	"github.com/radius-project/radius/pkg/components/database"
 	"github.com/radius-project/radius/pkg/ucp/datamodel"
 	"github.com/radius-project/radius/pkg/ucp/resources"
@@ -114,6 +115,11 @@ func ValidateResourceType(ctx context.Context, client database.Client, id resour
 
 	// We need to do both because they may not be in sync. This can be be the case if a resource type is being added or deleted.
 
+	// The history of a resource is served by the resource provider of the resource, so validate the resource itself.
+	if history.IsHistoryID(id) {
+		id = id.Truncate()
+	}
+
 	if !isOperationResourceType(id) {
 		resourceTypeID, err := datamodel.ResourceTypeIDFromResourceID(id)
 		if err != nil {
@@ -95,7 +95,9 @@ func (m *Module) Initialize(ctx context.Context) (http.Handler, error) {
 
 					// Routes for async support: operationResults + operationStatuses
 					r.Route("/locations/{location}", func(r chi.Router) {
+						r.Get("/operationStatuses", capture(operationStatusListHandler(ctx, ctrlOptions)))
 						r.Get("/operationStatuses/{operationId}", capture(operationStatusGetHandler(ctx, ctrlOptions)))
+						r.Post("/operationStatuses/{operationId}/"+defaultoperation.CancelOperationAction, capture(operationStatusCancelHandler(ctx, ctrlOptions)))
 						r.Get("/operationResults/{operationId}", capture(operationResultGetHandler(ctx, ctrlOptions)))
 					})
 
@@ -392,6 +394,14 @@ func operationStatusGetHandler(ctx context.Context, ctrlOptions controller.Optio
 	return server.CreateHandler(ctx, "System.Resources/operationstatuses", v1.OperationGet, ctrlOptions, defaultoperation.NewGetOperationStatus)
 }
 
+func operationStatusListHandler(ctx context.Context, ctrlOptions controller.Options) (http.HandlerFunc, error) {
+	return server.CreateHandler(ctx, "System.Resources/operationstatuses", v1.OperationList, ctrlOptions, defaultoperation.NewListOperationStatuses)
+}
+
+func operationStatusCancelHandler(ctx context.Context, ctrlOptions controller.Options) (http.HandlerFunc, error) {
+	return server.CreateHandler(ctx, "System.Resources/operationstatuses", v1.OperationCancel, ctrlOptions, defaultoperation.NewCancelOperation)
+}
+
 func operationResultGetHandler(ctx context.Context, ctrlOptions controller.Options) (http.HandlerFunc, error) {
 	// NOTE: The resource type below is CORRECT. operation status and operation result use the same resource type in the database.
 	return server.CreateHandler(ctx, "System.Resources/operationstatuses", v1.OperationGet, ctrlOptions, defaultoperation.NewGetOperationResult)
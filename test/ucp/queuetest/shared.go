@@ -239,3 +239,83 @@ func RunTest(t *testing.T, cli queue.Client, clear func(t *testing.T)) {
 		require.Equal(t, msgCount, recvCnt)
 	})
 }
+
+// DeadLetterQueueClient is a queue client with a dead-letter queue.
+type DeadLetterQueueClient interface {
+	queue.Client
+	queue.DeadLetterClient
+}
+
+// RunDeadLetterTest tests the client's DeadLetter, ListDeadLetters, GetDeadLetter, ReplayDeadLetter and
+// PurgeDeadLetter methods by dead-lettering dequeued messages and moving them back to the queue.
+func RunDeadLetterTest(t *testing.T, cli DeadLetterQueueClient, clear func(t *testing.T)) {
+	ctx := t.Context()
+
+	t.Run("dead-letter and replay message", func(t *testing.T) {
+		clear(t)
+
+		err := queueTestMessage(cli, 1)
+		require.NoError(t, err)
+
+		msg, err := cli.Dequeue(ctx, queue.QueueClientConfig{})
+		require.NoError(t, err)
+
+		err = cli.DeadLetter(ctx, msg, "failed")
+		require.NoError(t, err)
+
+		// Dead-letter messages are never dequeued, even after the message lock expires.
+		time.Sleep(TestMessageLockTime + pollingInterval)
+		_, err = cli.Dequeue(ctx, queue.QueueClientConfig{})
+		require.ErrorIs(t, err, queue.ErrMessageNotFound)
+
+		list, err := cli.ListDeadLetters(ctx)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, msg.ID, list[0].ID)
+
+		dead, err := cli.GetDeadLetter(ctx, msg.ID)
+		require.NoError(t, err)
+		require.Equal(t, "failed", dead.LastError)
+		require.Equal(t, 1, dead.DequeueCount)
+		require.Equal(t, msg.Data, dead.Data)
+		require.False(t, dead.DeadLetterAt.IsZero())
+
+		err = cli.ReplayDeadLetter(ctx, msg.ID)
+		require.NoError(t, err)
+
+		_, err = cli.GetDeadLetter(ctx, msg.ID)
+		require.ErrorIs(t, err, queue.ErrDeadLetterNotFound)
+
+		replayed, err := cli.Dequeue(ctx, queue.QueueClientConfig{})
+		require.NoError(t, err)
+		require.Equal(t, msg.Data, replayed.Data)
+		require.Equal(t, 1, replayed.DequeueCount, "DequeueCount must be reset by replay")
+
+		err = cli.FinishMessage(ctx, replayed)
+		require.NoError(t, err)
+	})
+
+	t.Run("purge message", func(t *testing.T) {
+		clear(t)
+
+		err := queueTestMessage(cli, 1)
+		require.NoError(t, err)
+
+		msg, err := cli.Dequeue(ctx, queue.QueueClientConfig{})
+		require.NoError(t, err)
+		err = cli.DeadLetter(ctx, msg, "failed")
+		require.NoError(t, err)
+
+		err = cli.PurgeDeadLetter(ctx, msg.ID)
+		require.NoError(t, err)
+
+		list, err := cli.ListDeadLetters(ctx)
+		require.NoError(t, err)
+		require.Empty(t, list)
+
+		err = cli.PurgeDeadLetter(ctx, msg.ID)
+		require.ErrorIs(t, err, queue.ErrDeadLetterNotFound)
+		err = cli.ReplayDeadLetter(ctx, msg.ID)
+		require.ErrorIs(t, err, queue.ErrDeadLetterNotFound)
+	})
+}
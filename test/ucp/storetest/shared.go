@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/radius-project/radius/pkg/components/database"
 	"github.com/radius-project/radius/pkg/ucp/resources"
@@ -580,3 +581,452 @@ func RunTest(t *testing.T, client database.Client, clear func(t *testing.T)) {
 		})
 	})
 }
+
+// RunQueryTest tests the filter operators, ordering and pagination of the Query method of a client.
+func RunQueryTest(t *testing.T, client database.Client, clear func(t *testing.T)) {
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	id := func(name string) string {
+		return parseOrPanic(ResourceGroup1Scope + "/providers/" + ResourceType1 + "/" + name).String()
+	}
+	objects := map[string]any{
+		"q1": map[string]any{"name": "web-1", "properties": map[string]any{"tier": "frontend", "size": 3, "owner": "a"}},
+		"q2": map[string]any{"name": "web-2", "properties": map[string]any{"tier": "frontend", "size": 1}},
+		"q3": map[string]any{"name": "api", "properties": map[string]any{"tier": "backend", "size": 2, "owner": "b"}},
+		"q4": map[string]any{"name": "db", "properties": map[string]any{"tier": "data", "owner": nil}},
+		"q5": map[string]any{"name": "cache", "properties": map[string]any{"tier": "data", "size": 10}},
+	}
+
+	clear(t)
+	for name, data := range objects {
+		obj := createObject(parseOrPanic(id(name)), data)
+		require.NoError(t, client.Save(ctx, &obj))
+	}
+
+	query := func(t *testing.T, filters []database.QueryFilter, options ...database.QueryOptions) ([]string, string) {
+		t.Helper()
+
+		result, err := client.Query(ctx, database.Query{RootScope: ResourceGroup1Scope, ResourceType: ResourceType1, Filters: filters}, options...)
+		require.NoError(t, err)
+
+		ids := []string{}
+		for _, item := range result.Items {
+			ids = append(ids, item.ID)
+		}
+		return ids, result.PaginationToken
+	}
+
+	queryAll := func(t *testing.T, filters []database.QueryFilter, options ...database.QueryOptions) []string {
+		t.Helper()
+
+		all := []string{}
+		token := ""
+		for range len(objects) + 1 {
+			ids, next := query(t, filters, append(options, database.WithMaxQueryItemCount(2), database.WithPaginationToken(token))...)
+			require.LessOrEqual(t, len(ids), 2)
+			all = append(all, ids...)
+
+			token = next
+			if token == "" {
+				break
+			}
+		}
+		require.Empty(t, token)
+		return all
+	}
+
+	filterTests := []struct {
+		name     string
+		filters  []database.QueryFilter
+		expected []string
+	}{
+		{
+			name:     "not_equal",
+			filters:  []database.QueryFilter{{Field: "properties.tier", Operator: database.FilterOperatorNotEqual, Value: "frontend"}},
+			expected: []string{id("q3"), id("q4"), id("q5")},
+		},
+		{
+			name:     "not_equal_matches_missing",
+			filters:  []database.QueryFilter{{Field: "properties.owner", Operator: database.FilterOperatorNotEqual, Value: "a"}},
+			expected: []string{id("q2"), id("q3"), id("q4"), id("q5")},
+		},
+		{
+			name:     "prefix",
+			filters:  []database.QueryFilter{{Field: "name", Operator: database.FilterOperatorPrefix, Value: "web-"}},
+			expected: []string{id("q1"), id("q2")},
+		},
+		{
+			name:     "in",
+			filters:  []database.QueryFilter{{Field: "properties.tier", Operator: database.FilterOperatorIn, Values: []string{"backend", "data"}}},
+			expected: []string{id("q3"), id("q4"), id("q5")},
+		},
+		{
+			name:     "exists",
+			filters:  []database.QueryFilter{{Field: "properties.owner", Operator: database.FilterOperatorExists}},
+			expected: []string{id("q1"), id("q3")},
+		},
+		{
+			name: "combined",
+			filters: []database.QueryFilter{
+				{Field: "properties.tier", Operator: database.FilterOperatorIn, Values: []string{"frontend", "data"}},
+				{Field: "properties.size", Operator: database.FilterOperatorExists},
+			},
+			expected: []string{id("q1"), id("q2"), id("q5")},
+		},
+	}
+	for _, tt := range filterTests {
+		t.Run("query_filter_"+tt.name, func(t *testing.T) {
+			ids, token := query(t, tt.filters)
+			require.ElementsMatch(t, tt.expected, ids)
+			require.Empty(t, token)
+		})
+	}
+
+	t.Run("query_filter_invalid", func(t *testing.T) {
+		for _, filter := range []database.QueryFilter{
+			{Field: "name", Operator: "matches", Value: "web"},
+			{Field: "name", Operator: database.FilterOperatorIn},
+		} {
+			_, err := client.Query(ctx, database.Query{RootScope: ResourceGroup1Scope, ResourceType: ResourceType1, Filters: []database.QueryFilter{filter}})
+			require.ErrorIs(t, err, &database.ErrInvalid{})
+		}
+	})
+
+	orderTests := []struct {
+		name     string
+		order    database.QueryOrder
+		expected []string
+	}{
+		{
+			name:     "ascending",
+			order:    database.QueryOrder{Field: "name"},
+			expected: []string{id("q3"), id("q5"), id("q4"), id("q1"), id("q2")},
+		},
+		{
+			name:     "descending",
+			order:    database.QueryOrder{Field: "name", Descending: true},
+			expected: []string{id("q2"), id("q1"), id("q4"), id("q5"), id("q3")},
+		},
+		{
+			name:     "ties_ordered_by_id",
+			order:    database.QueryOrder{Field: "properties.tier"},
+			expected: []string{id("q3"), id("q4"), id("q5"), id("q1"), id("q2")},
+		},
+		{
+			name:     "numbers_and_missing_values",
+			order:    database.QueryOrder{Field: "properties.size"},
+			expected: []string{id("q4"), id("q2"), id("q5"), id("q3"), id("q1")},
+		},
+	}
+	for _, tt := range orderTests {
+		t.Run("query_order_"+tt.name, func(t *testing.T) {
+			ids, token := query(t, nil, database.WithOrderBy(tt.order))
+			require.Equal(t, tt.expected, ids)
+			require.Empty(t, token)
+
+			require.Equal(t, tt.expected, queryAll(t, nil, database.WithOrderBy(tt.order)))
+		})
+	}
+
+	t.Run("query_pages", func(t *testing.T) {
+		all := queryAll(t, nil)
+		require.ElementsMatch(t, []string{id("q1"), id("q2"), id("q3"), id("q4"), id("q5")}, all)
+	})
+
+	t.Run("query_pages_with_filter", func(t *testing.T) {
+		filters := []database.QueryFilter{{Field: "properties.tier", Operator: database.FilterOperatorNotEqual, Value: "frontend"}}
+		require.Equal(t, []string{id("q3"), id("q5"), id("q4")}, queryAll(t, filters, database.WithOrderBy(database.QueryOrder{Field: "name"})))
+	})
+
+	t.Run("query_last_page_has_no_token", func(t *testing.T) {
+		ids, token := query(t, nil, database.WithMaxQueryItemCount(5))
+		require.Len(t, ids, 5)
+		require.Empty(t, token)
+	})
+
+	t.Run("query_invalid_token", func(t *testing.T) {
+		for _, options := range [][]database.QueryOptions{
+			{database.WithPaginationToken("not-a-token")},
+			{database.WithPaginationToken("not-a-token"), database.WithOrderBy(database.QueryOrder{Field: "name"})},
+		} {
+			_, err := client.Query(ctx, database.Query{RootScope: ResourceGroup1Scope, ResourceType: ResourceType1}, options...)
+			require.ErrorIs(t, err, &database.ErrInvalid{})
+		}
+	})
+
+	t.Run("query_invalid_order", func(t *testing.T) {
+		_, err := client.Query(ctx, database.Query{RootScope: ResourceGroup1Scope, ResourceType: ResourceType1}, database.WithOrderBy(database.QueryOrder{Field: "not a field"}))
+		require.ErrorIs(t, err, &database.ErrInvalid{})
+	})
+}
+
+// RunExportTest tests the database.Exporter implementation of a client by exporting objects of different
+// scopes and types page by page, and importing them into the same store with their ETags preserved.
+func RunExportTest(t *testing.T, client database.Client, clear func(t *testing.T)) {
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	exporter, ok := client.(database.Exporter)
+	require.True(t, ok, "client must implement database.Exporter")
+
+	saveAll := func(t *testing.T) []database.Object {
+		objects := []database.Object{
+			createObject(RadiusPlaneID, RadiusPlaneData),
+			createObject(ResourceGroup1ID, ResourceGroup1Data),
+			createObject(Resource1ID, Data1),
+			createObject(Resource2ID, Data2),
+			createObject(NestedResource1ID, NestedData1),
+			createObject(ARMResourceID, Data3),
+		}
+		for i := range objects {
+			require.NoError(t, client.Save(ctx, &objects[i]))
+		}
+		return objects
+	}
+
+	t.Run("export_can_be_empty", func(t *testing.T) {
+		clear(t)
+
+		result, err := exporter.Export(ctx)
+		require.NoError(t, err)
+		require.Empty(t, result.Items)
+		require.Empty(t, result.PaginationToken)
+	})
+
+	t.Run("export_returns_every_object_with_etag", func(t *testing.T) {
+		clear(t)
+		expected := saveAll(t)
+
+		result, err := exporter.Export(ctx)
+		require.NoError(t, err)
+		require.Empty(t, result.PaginationToken)
+		require.ElementsMatch(t, expected, result.Items)
+	})
+
+	t.Run("export_pages", func(t *testing.T) {
+		clear(t)
+		expected := saveAll(t)
+
+		actual := []database.Object{}
+		token := ""
+		for range len(expected) + 1 {
+			options := []database.QueryOptions{database.WithMaxQueryItemCount(4)}
+			if token != "" {
+				options = append(options, database.WithPaginationToken(token))
+			}
+			result, err := exporter.Export(ctx, options...)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(result.Items), 4)
+			actual = append(actual, result.Items...)
+
+			token = result.PaginationToken
+			if token == "" {
+				break
+			}
+		}
+		require.Empty(t, token)
+		require.ElementsMatch(t, expected, actual)
+	})
+
+	t.Run("export_all", func(t *testing.T) {
+		clear(t)
+		expected := saveAll(t)
+
+		actual := []database.Object{}
+		err := database.ExportAll(ctx, client, func(obj *database.Object) error {
+			actual = append(actual, *obj)
+			return nil
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, expected, actual)
+	})
+
+	t.Run("import_preserves_etag", func(t *testing.T) {
+		clear(t)
+
+		obj := createObject(Resource1ID, Data1)
+		obj.ETag = "imported-etag"
+		require.NoError(t, exporter.Import(ctx, &obj))
+
+		actual, err := client.Get(ctx, Resource1ID.String())
+		require.NoError(t, err)
+		require.Equal(t, obj, *actual)
+
+		// Imported objects take part in optimistic concurrency like any other object.
+		update := createObject(Resource1ID, Data2)
+		require.NoError(t, client.Save(ctx, &update, database.WithETag("imported-etag")))
+	})
+
+	t.Run("import_replaces_existing", func(t *testing.T) {
+		clear(t)
+
+		obj := createObject(ResourceGroup1ID, ResourceGroup1Data)
+		require.NoError(t, client.Save(ctx, &obj))
+
+		replacement := createObject(ResourceGroup1ID, ResourceGroup2Data)
+		replacement.ETag = "replacement-etag"
+		require.NoError(t, exporter.Import(ctx, &replacement))
+
+		actual, err := client.Get(ctx, ResourceGroup1ID.String())
+		require.NoError(t, err)
+		require.Equal(t, replacement, *actual)
+	})
+
+	t.Run("import_computes_missing_etag", func(t *testing.T) {
+		clear(t)
+
+		obj := createObject(Resource2ID, Data2)
+		require.NoError(t, exporter.Import(ctx, &obj))
+
+		actual, err := client.Get(ctx, Resource2ID.String())
+		require.NoError(t, err)
+		require.Equal(t, etag.New(MarshalOrPanic(Data2)), actual.ETag)
+	})
+}
+
+// RunWatchTest runs the shared tests for clients that implement database.Watcher.
+func RunWatchTest(t *testing.T, client database.Client, clear func(t *testing.T)) {
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	watcher, ok := client.(database.Watcher)
+	require.True(t, ok, "client must implement database.Watcher")
+
+	receive := func(t *testing.T, events <-chan database.WatchEvent) database.WatchEvent {
+		t.Helper()
+
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "watch was closed")
+			return event
+		case <-time.After(30 * time.Second):
+			require.Fail(t, "timed out waiting for a watch event")
+			return database.WatchEvent{}
+		}
+	}
+
+	t.Run("watch_invalid_query", func(t *testing.T) {
+		_, err := watcher.Watch(ctx, database.Query{})
+		require.ErrorIs(t, err, &database.ErrInvalid{})
+	})
+
+	t.Run("watch_reports_changes", func(t *testing.T) {
+		clear(t)
+
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		defer watchCancel()
+
+		events, err := watcher.Watch(watchCtx, database.Query{RootScope: ResourceGroup1Scope, ResourceType: ResourceType1})
+		require.NoError(t, err)
+
+		obj := createObject(Resource1ID, Data1)
+		require.NoError(t, client.Save(ctx, &obj))
+
+		event := receive(t, events)
+		require.Equal(t, database.EventTypeCreated, event.Type)
+		require.Equal(t, obj, event.Object)
+
+		updated := createObject(Resource1ID, Data2)
+		require.NoError(t, client.Save(ctx, &updated, database.WithETag(obj.ETag)))
+
+		event = receive(t, events)
+		require.Equal(t, database.EventTypeUpdated, event.Type)
+		require.Equal(t, updated, event.Object)
+
+		require.NoError(t, client.Delete(ctx, Resource1ID.String()))
+
+		event = receive(t, events)
+		require.Equal(t, database.EventTypeDeleted, event.Type)
+		require.Equal(t, database.Object{Metadata: updated.Metadata}, event.Object)
+	})
+
+	t.Run("watch_matches_query", func(t *testing.T) {
+		clear(t)
+
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		defer watchCancel()
+
+		filters := []database.QueryFilter{{Field: "value", Value: "n2"}}
+		events, err := watcher.Watch(watchCtx, database.Query{RootScope: ResourceGroup1Scope, ResourceType: NestedResourceType1, Filters: filters})
+		require.NoError(t, err)
+
+		// Changes in other scopes, of other types, or that don't match the filters are not reported.
+		for _, obj := range []database.Object{
+			createObject(Resource1ID, Data1),
+			createObject(Resource2ID, Data2),
+			createObject(NestedResource1ID, NestedData1),
+			createObject(NestedResource2ID, NestedData2),
+		} {
+			require.NoError(t, client.Save(ctx, &obj))
+		}
+
+		event := receive(t, events)
+		require.Equal(t, database.EventTypeCreated, event.Type)
+		require.Equal(t, NestedResource2ID.String(), event.Object.ID)
+	})
+
+	t.Run("watch_filters_deleted", func(t *testing.T) {
+		clear(t)
+
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		defer watchCancel()
+
+		filters := []database.QueryFilter{{Field: "value", Value: "n2"}}
+		events, err := watcher.Watch(watchCtx, database.Query{RootScope: ResourceGroup1Scope, ResourceType: NestedResourceType1, Filters: filters})
+		require.NoError(t, err)
+
+		for _, obj := range []database.Object{
+			createObject(NestedResource1ID, NestedData1),
+			createObject(NestedResource2ID, NestedData2),
+		} {
+			require.NoError(t, client.Save(ctx, &obj))
+		}
+
+		event := receive(t, events)
+		require.Equal(t, database.EventTypeCreated, event.Type)
+		require.Equal(t, NestedResource2ID.String(), event.Object.ID)
+
+		// The deletion of an object that doesn't match the filters is not reported.
+		require.NoError(t, client.Delete(ctx, NestedResource1ID.String()))
+		require.NoError(t, client.Delete(ctx, NestedResource2ID.String()))
+
+		event = receive(t, events)
+		require.Equal(t, database.EventTypeDeleted, event.Type)
+		require.Equal(t, NestedResource2ID.String(), event.Object.ID)
+	})
+
+	t.Run("watch_closes_when_done", func(t *testing.T) {
+		clear(t)
+
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		events, err := watcher.Watch(watchCtx, database.Query{RootScope: ResourceGroup1Scope, ResourceType: ResourceType1})
+		require.NoError(t, err)
+
+		watchCancel()
+
+		select {
+		case _, ok := <-events:
+			require.False(t, ok, "watch should not report changes after it is done")
+		case <-time.After(30 * time.Second):
+			require.Fail(t, "timed out waiting for the watch to close")
+		}
+	})
+
+	t.Run("watch_helper", func(t *testing.T) {
+		clear(t)
+
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		defer watchCancel()
+
+		events, err := database.Watch(watchCtx, client, database.Query{RootScope: RadiusScope, IsScopeQuery: true, ResourceType: "resourcegroups"})
+		require.NoError(t, err)
+
+		obj := createObject(ResourceGroup1ID, ResourceGroup1Data)
+		require.NoError(t, client.Save(ctx, &obj))
+
+		event := receive(t, events)
+		require.Equal(t, database.EventTypeCreated, event.Type)
+		require.Equal(t, obj, event.Object)
+	})
+}